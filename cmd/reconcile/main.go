@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"lucassantoss1701/bank/configs"
+	"lucassantoss1701/bank/internal/infra/database"
+	"lucassantoss1701/bank/internal/infra/database/connection"
+	"lucassantoss1701/bank/internal/usecase"
+	"os"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func init() {
+	time.Local = time.UTC
+	if err := configs.Load(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// reconcile compares the cached balance of every account with its ledger postings,
+// prints the report as JSON and exits with status 1 when an inconsistency is found.
+func main() {
+	db := connection.Connect(configs.Get().Database.Type, configs.Get().Database.User, configs.Get().Database.Pass, configs.Get().Database.Host, configs.Get().Database.Port, configs.Get().Database.Name)
+	defer db.Close()
+
+	reconcileLedgerUseCase := usecase.NewReconcileLedgerUseCase(database.NewLedgerRepository(db))
+
+	output, err := reconcileLedgerUseCase.Execute(context.Background(), usecase.NewReconcileLedgerUseCaseInput())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		log.Fatal(err)
+	}
+
+	if !output.IsConsistent() {
+		db.Close()
+		os.Exit(1)
+	}
+}
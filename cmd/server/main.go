@@ -1,21 +1,35 @@
 package main
 
 import (
+	"context"
+	"log"
 	"lucassantoss1701/bank/configs"
+	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/database"
 	"lucassantoss1701/bank/internal/infra/database/connection"
+	"lucassantoss1701/bank/internal/infra/exchange"
+	"lucassantoss1701/bank/internal/infra/notification"
+	"lucassantoss1701/bank/internal/infra/pricing"
+	"lucassantoss1701/bank/internal/infra/scheduler"
+	"lucassantoss1701/bank/internal/infra/security"
 	"lucassantoss1701/bank/internal/infra/web"
 	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
 	"lucassantoss1701/bank/internal/infra/web/webserver/routes"
 	"lucassantoss1701/bank/internal/usecase"
+	"os"
 	"time"
+	_ "time/tzdata"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
 )
 
 func init() {
 	time.Local = time.UTC
-	configs.Load()
+	if err := configs.Load(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 // @title Bank API
@@ -32,24 +46,230 @@ func main() {
 	connection.Migrate(db)
 
 	accountRepository := database.NewAccountRepository(db)
+	ledgerRepository := database.NewLedgerRepository(db)
+	movementRepository := database.NewMovementRepository(db)
 	transferRepository := database.NewTransferRepository(db)
+	transferLimitRepository := database.NewTransferLimitRepository(db)
 	baseRepostiory := database.NewRepository(db)
+	idempotencyKeyRepository := database.NewIdempotencyKeyRepository(db)
+	refreshTokenRepository := database.NewRefreshTokenRepository(db)
+	revokedTokenRepository := database.NewRevokedTokenRepository(db)
+	loginAttemptRepository := database.NewLoginAttemptRepository(db)
+	loginThrottleRepository := database.NewLoginThrottleRepository(db)
+	recoveryCodeRepository := database.NewRecoveryCodeRepository(db)
+	secretResetTokenRepository := database.NewSecretResetTokenRepository(db)
+	scheduledTransferRepository := database.NewScheduledTransferRepository(db)
+	overdraftInterestRepository := database.NewOverdraftInterestRepository(db)
+	holdRepository := database.NewHoldRepository(db)
+	transferBatchRepository := database.NewTransferBatchRepository(db)
+	transferKeyRepository := database.NewTransferKeyRepository(db)
+	beneficiaryRepository := database.NewBeneficiaryRepository(db)
 
-	webserver := webserver.NewWebServer(configs.Get().Server.Host)
+	keySet, err := security.LoadKeySet(configs.Get().Security.SigningKeyID, configs.Get().Security.SigningKeyFile, configs.Get().Security.VerificationKeys)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	findAccountUseCase := usecase.NewFindAccountUseCase(accountRepository)
-	createAccountUseCase := usecase.NewCreateAccountUseCase(accountRepository)
+	secretCipher, err := security.NewAESCipher(configs.Get().MFA.EncryptionKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	totpRepository := database.NewTOTPRepository(db, secretCipher)
+
+	var notifier entity.Notifier = notification.NewLogNotifier(os.Stdout)
+	if configs.Get().Notification.File != "" {
+		notifier, err = notification.NewFileNotifier(configs.Get().Notification.File)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	auth := middleware.NewAuth(keySet, revokedTokenRepository)
+	go auth.PurgeExpired(context.Background(), configs.Get().Security.AccessTokenTTL)
+
+	webserver := webserver.NewWebServer(configs.Get().Server.Host, auth.Handle)
+
+	paginator, err := entity.NewPaginator(configs.Get().Pagination.CursorSecret, configs.Get().Pagination.DefaultLimit, configs.Get().Pagination.MaxLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findAccountUseCase := usecase.NewFindAccountUseCase(accountRepository, paginator)
+	createAccountUseCase := usecase.NewCreateAccountUseCase(accountRepository, ledgerRepository, baseRepostiory)
 	findBalanceByAccountUseCase := usecase.NewFindBalanceByAccountUseCase(accountRepository)
-	loginUseCase := usecase.NewLoginUseCase(accountRepository)
+	tokenIssuer := usecase.NewTokenIssuer(refreshTokenRepository, keySet, configs.Get().Security.AccessTokenTTL, configs.Get().Security.RefreshTokenTTL)
+	login := configs.Get().Login
+	cpfLoginThrottlePolicy := entity.NewLoginThrottlePolicy(login.MaxAttempts, login.BaseDelay, login.MaxDelay, login.Lockout)
+	ipLoginThrottlePolicy := entity.NewLoginThrottlePolicy(login.IPMaxAttempts, login.BaseDelay, login.MaxDelay, login.Lockout)
+	keyLookupThrottlePolicy := entity.NewLoginThrottlePolicy(login.KeyLookupMaxAttempts, login.BaseDelay, login.MaxDelay, login.Lockout)
+	loginGuard := usecase.NewLoginGuard(loginAttemptRepository, loginThrottleRepository, cpfLoginThrottlePolicy, ipLoginThrottlePolicy, baseRepostiory)
+	twoFactor := usecase.NewTwoFactor(totpRepository, recoveryCodeRepository, loginThrottleRepository, cpfLoginThrottlePolicy, baseRepostiory)
+	loginUseCase := usecase.NewLoginUseCase(accountRepository, tokenIssuer, loginGuard, twoFactor)
 
 	webAccountHandler := web.NewWebAccountHandler(createAccountUseCase, findAccountUseCase, findBalanceByAccountUseCase, loginUseCase)
 
-	makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, baseRepostiory)
-	findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(transferRepository)
-	webTransferHandler := web.NewWebTransferHandler(makeTransferUseCase, findTransfersByAccountUseCase)
+	refreshTokenUseCase := usecase.NewRefreshTokenUseCase(accountRepository, refreshTokenRepository, tokenIssuer, baseRepostiory)
+	logoutUseCase := usecase.NewLogoutUseCase(refreshTokenRepository, revokedTokenRepository)
+	webSessionHandler := web.NewWebSessionHandler(refreshTokenUseCase, logoutUseCase)
+	webJWKSHandler := web.NewWebJWKSHandler(keySet)
+
+	changeSecretUseCase := usecase.NewChangeSecretUseCase(accountRepository, refreshTokenRepository, revokedTokenRepository, loginGuard, baseRepostiory)
+	requestSecretResetUseCase := usecase.NewRequestSecretResetUseCase(accountRepository, secretResetTokenRepository, loginThrottleRepository, notifier, cpfLoginThrottlePolicy, ipLoginThrottlePolicy, configs.Get().Security.SecretResetTTL, baseRepostiory)
+	resetSecretUseCase := usecase.NewResetSecretUseCase(accountRepository, secretResetTokenRepository, refreshTokenRepository, baseRepostiory)
+	webSecretHandler := web.NewWebSecretHandler(changeSecretUseCase, requestSecretResetUseCase, resetSecretUseCase)
+
+	enrollTOTPUseCase := usecase.NewEnrollTOTPUseCase(totpRepository, configs.Get().AppName)
+	confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(totpRepository, recoveryCodeRepository, twoFactor, baseRepostiory)
+	loginMFAUseCase := usecase.NewLoginMFAUseCase(accountRepository, keySet, tokenIssuer, loginGuard, twoFactor)
+	webTwoFactorHandler := web.NewWebTwoFactorHandler(enrollTOTPUseCase, confirmTOTPUseCase, loginMFAUseCase)
+
+	transferThreshold, err := entity.ParseMoney(configs.Get().MFA.TransferThreshold, string(entity.DEFAULT_CURRENCY))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// without a rates file there is no rate available, so only transfers in the same currency go through
+	var exchangeRateProvider entity.ExchangeRateProvider = exchange.NewStaticExchangeRateProvider()
+	if configs.Get().FX.RatesFile != "" {
+		exchangeRateProvider = exchange.NewFileExchangeRateProvider(configs.Get().FX.RatesFile)
+	}
+
+	transferLimitLocation, err := time.LoadLocation(configs.Get().Transfer.TimeZone)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	transferLimitCalendar, err := entity.NewTransferLimitCalendar(configs.Get().Transfer.NightStart, configs.Get().Transfer.NightEnd, transferLimitLocation)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	transferLimitDefaults := entity.TransferLimitDefaults{
+		PerTransaction: configs.Get().Transfer.LimitPerTransaction,
+		Daily:          configs.Get().Transfer.LimitDaily,
+		Monthly:        configs.Get().Transfer.LimitMonthly,
+		Nighttime:      configs.Get().Transfer.LimitNighttime,
+	}
+
+	// the defaults are read in the currency of each account, a typo must stop the server instead of every transfer
+	_, err = transferLimitDefaults.For("default", entity.DEFAULT_CURRENCY)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// without a fee file no rule applies, so every transfer is free
+	feeSchedule := &entity.FeeSchedule{}
+	if configs.Get().Transfer.FeeRulesFile != "" {
+		feeSchedule, err = pricing.ReadFeeScheduleFile(configs.Get().Transfer.FeeRulesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	transferTwoFactorRule := usecase.NewTransferTwoFactorRule(twoFactor, transferThreshold)
+	transferExchangeRateRule := usecase.NewTransferExchangeRateRule(exchangeRateProvider, configs.Get().FX.MaxRateAge)
+	transferLimitRule := usecase.NewTransferLimitRule(transferLimitRepository, transferRepository, transferLimitDefaults, transferLimitCalendar)
+	transferFeeRule := usecase.NewTransferFeeRule(transferRepository, feeSchedule, transferLimitCalendar)
+	transferKeyLookupRule := usecase.NewTransferKeyLookupRule(transferKeyRepository, loginThrottleRepository, keyLookupThrottlePolicy, baseRepostiory)
+	transferDestinationRule := usecase.NewTransferDestinationRule(transferKeyLookupRule, beneficiaryRepository)
+	makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, transferTwoFactorRule, transferExchangeRateRule, transferLimitRule, transferFeeRule, transferDestinationRule, baseRepostiory)
+	findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(transferRepository, paginator)
+	reverseTransferUseCase := usecase.NewReverseTransferUseCase(accountRepository, transferRepository, ledgerRepository, configs.Get().Transfer.ReversalWindow, baseRepostiory)
+	findTransferUseCase := usecase.NewFindTransferUseCase(transferRepository)
+	findTransferLimitUseCase := usecase.NewFindTransferLimitUseCase(accountRepository, transferLimitRule)
+	changeTransferLimitUseCase := usecase.NewChangeTransferLimitUseCase(accountRepository, transferLimitRepository, transferLimitRule)
+	webTransferLimitHandler := web.NewWebTransferLimitHandler(findTransferLimitUseCase, changeTransferLimitUseCase)
+
+	webTransferHandler := web.NewWebTransferHandler(makeTransferUseCase, findTransfersByAccountUseCase, reverseTransferUseCase, findTransferUseCase)
+
+	createTransferKeyUseCase := usecase.NewCreateTransferKeyUseCase(accountRepository, transferKeyRepository, baseRepostiory)
+	findTransferKeysByAccountUseCase := usecase.NewFindTransferKeysByAccountUseCase(transferKeyRepository)
+	findTransferKeyUseCase := usecase.NewFindTransferKeyUseCase(accountRepository, transferKeyLookupRule)
+	deleteTransferKeyUseCase := usecase.NewDeleteTransferKeyUseCase(transferKeyRepository)
+	webTransferKeyHandler := web.NewWebTransferKeyHandler(createTransferKeyUseCase, findTransferKeysByAccountUseCase, findTransferKeyUseCase, deleteTransferKeyUseCase)
+
+	createBeneficiaryUseCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, transferDestinationRule)
+	findBeneficiariesByAccountUseCase := usecase.NewFindBeneficiariesByAccountUseCase(beneficiaryRepository)
+	updateBeneficiaryUseCase := usecase.NewUpdateBeneficiaryUseCase(beneficiaryRepository)
+	deleteBeneficiaryUseCase := usecase.NewDeleteBeneficiaryUseCase(beneficiaryRepository)
+	findBeneficiarySuggestionsUseCase := usecase.NewFindBeneficiarySuggestionsUseCase(transferRepository, beneficiaryRepository, configs.Get().Beneficiary.SuggestionWindow, configs.Get().Beneficiary.SuggestionLimit)
+	webBeneficiaryHandler := web.NewWebBeneficiaryHandler(createBeneficiaryUseCase, findBeneficiariesByAccountUseCase, updateBeneficiaryUseCase, deleteBeneficiaryUseCase, findBeneficiarySuggestionsUseCase)
+
+	createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(accountRepository, scheduledTransferRepository, transferTwoFactorRule)
+	findScheduledTransfersByAccountUseCase := usecase.NewFindScheduledTransfersByAccountUseCase(scheduledTransferRepository, paginator)
+	changeScheduledTransferStatusUseCase := usecase.NewChangeScheduledTransferStatusUseCase(scheduledTransferRepository)
+	findScheduledTransferExecutionsUseCase := usecase.NewFindScheduledTransferExecutionsUseCase(scheduledTransferRepository)
+	webScheduledTransferHandler := web.NewWebScheduledTransferHandler(createScheduledTransferUseCase, findScheduledTransfersByAccountUseCase, changeScheduledTransferStatusUseCase, findScheduledTransferExecutionsUseCase)
+
+	// transfers run in the background have no TOTP code, so the two-factor threshold is turned off
+	// on purpose: scheduled transfers and batches check the code when they are created
+	backgroundMakeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(0, entity.DEFAULT_CURRENCY)), transferExchangeRateRule, transferLimitRule, transferFeeRule, transferDestinationRule, baseRepostiory)
+	runScheduledTransfersUseCase := usecase.NewRunScheduledTransfersUseCase(scheduledTransferRepository, backgroundMakeTransferUseCase, configs.Get().Scheduler.BatchSize)
+	scheduledTransferWorker := scheduler.NewScheduledTransferWorker(runScheduledTransfersUseCase, configs.Get().Scheduler.Interval, logrus.StandardLogger())
+	go scheduledTransferWorker.Run(context.Background())
+
+	createTransferBatchUseCase := usecase.NewCreateTransferBatchUseCase(accountRepository, transferBatchRepository, transferTwoFactorRule, transferLimitRule, configs.Get().Batch.MaxItems, baseRepostiory)
+	findTransferBatchUseCase := usecase.NewFindTransferBatchUseCase(transferBatchRepository)
+	webTransferBatchHandler := web.NewWebTransferBatchHandler(createTransferBatchUseCase, findTransferBatchUseCase)
+
+	// the TOTP code of a batch is checked against its total when it is created, its items run
+	// without one through the background transfers
+	processTransferBatchesUseCase := usecase.NewProcessTransferBatchesUseCase(transferBatchRepository, backgroundMakeTransferUseCase, configs.Get().Batch.PollSize, configs.Get().Batch.ProcessingTimeout)
+	transferBatchWorker := scheduler.NewTransferBatchWorker(processTransferBatchesUseCase, configs.Get().Batch.PollInterval, logrus.StandardLogger())
+	go transferBatchWorker.Run(context.Background())
+
+	createHoldUseCase := usecase.NewCreateHoldUseCase(accountRepository, holdRepository, transferTwoFactorRule, configs.Get().Hold.DefaultTTL, configs.Get().Hold.MaxTTL, baseRepostiory)
+	captureHoldUseCase := usecase.NewCaptureHoldUseCase(accountRepository, holdRepository, transferRepository, ledgerRepository, transferExchangeRateRule, transferLimitRule, transferFeeRule, baseRepostiory)
+	releaseHoldUseCase := usecase.NewReleaseHoldUseCase(accountRepository, holdRepository, baseRepostiory)
+	findHoldUseCase := usecase.NewFindHoldUseCase(holdRepository)
+	webHoldHandler := web.NewWebHoldHandler(createHoldUseCase, captureHoldUseCase, releaseHoldUseCase, findHoldUseCase)
+
+	expireHoldsUseCase := usecase.NewExpireHoldsUseCase(accountRepository, holdRepository, configs.Get().Hold.SweepBatchSize, baseRepostiory)
+	holdExpiryWorker := scheduler.NewHoldExpiryWorker(expireHoldsUseCase, configs.Get().Hold.SweepInterval, logrus.StandardLogger())
+	go holdExpiryWorker.Run(context.Background())
+
+	depositUseCase := usecase.NewDepositUseCase(accountRepository, movementRepository, ledgerRepository, baseRepostiory)
+	withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository, movementRepository, ledgerRepository, baseRepostiory)
+	webMovementHandler := web.NewWebMovementHandler(depositUseCase, withdrawUseCase)
+
+	overdraftInterestRate, err := entity.NewOverdraftInterestRate(configs.Get().Overdraft.InterestRate)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	overdraftInterestLocation, err := time.LoadLocation(configs.Get().Overdraft.TimeZone)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	accrueOverdraftInterestUseCase := usecase.NewAccrueOverdraftInterestUseCase(accountRepository, overdraftInterestRepository, ledgerRepository, overdraftInterestRate, overdraftInterestLocation, configs.Get().Overdraft.InterestBatchSize, baseRepostiory)
+	overdraftInterestWorker := scheduler.NewOverdraftInterestWorker(accrueOverdraftInterestUseCase, configs.Get().Overdraft.InterestInterval, logrus.StandardLogger())
+	go overdraftInterestWorker.Run(context.Background())
+
+	changeAccountRoleUseCase := usecase.NewChangeAccountRoleUseCase(accountRepository)
+	reconcileLedgerUseCase := usecase.NewReconcileLedgerUseCase(ledgerRepository)
+	changeCreditLimitUseCase := usecase.NewChangeCreditLimitUseCase(accountRepository, baseRepostiory)
+	webBackOfficeHandler := web.NewWebBackOfficeHandler(changeAccountRoleUseCase, reconcileLedgerUseCase, changeCreditLimitUseCase)
+
+	idempotency := middleware.NewIdempotency(idempotencyKeyRepository, configs.Get().Idempotency.Retention)
+	go idempotency.PurgeExpired(context.Background())
+
+	authorization := middleware.NewAuthorization(entity.NewOwnershipPolicy())
 
-	routes.HandleAccountRoutes(webserver, webAccountHandler)
-	routes.HandleTransferRoutes(webserver, webTransferHandler)
+	routes.HandleAccountRoutes(webserver, webAccountHandler, authorization)
+	routes.HandleSessionRoutes(webserver, webSessionHandler)
+	routes.HandleSecretRoutes(webserver, webSecretHandler)
+	routes.HandleJWKSRoutes(webserver, webJWKSHandler)
+	routes.HandleTwoFactorRoutes(webserver, webTwoFactorHandler)
+	routes.HandleTransferRoutes(webserver, webTransferHandler, idempotency, authorization)
+	routes.HandleTransferLimitRoutes(webserver, webTransferLimitHandler, authorization)
+	routes.HandleScheduledTransferRoutes(webserver, webScheduledTransferHandler, idempotency)
+	routes.HandleTransferBatchRoutes(webserver, webTransferBatchHandler, idempotency)
+	routes.HandleTransferKeyRoutes(webserver, webTransferKeyHandler)
+	routes.HandleBeneficiaryRoutes(webserver, webBeneficiaryHandler)
+	routes.HandleHoldRoutes(webserver, webHoldHandler, idempotency)
+	routes.HandleMovementRoutes(webserver, webMovementHandler, idempotency, authorization)
+	routes.HandleBackOfficeRoutes(webserver, webBackOfficeHandler)
 
 	webserver.Start()
 }
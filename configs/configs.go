@@ -3,6 +3,7 @@ package configs
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -10,10 +11,22 @@ import (
 var configuration *Config
 
 type Config struct {
-	AppName  string `mapstructure:"APP_NAME" default:"bank-api"`
-	Server   server
-	Database database
-	Security security
+	AppName      string `mapstructure:"APP_NAME" default:"bank-api"`
+	Server       server
+	Database     database
+	Security     security
+	Idempotency  idempotency
+	Pagination   pagination
+	Login        login
+	MFA          mfa
+	Notification notification
+	FX           fx
+	Scheduler    scheduler
+	Transfer     transfer
+	Overdraft    overdraft
+	Hold         hold
+	Batch        batch
+	Beneficiary  beneficiary
 }
 
 type database struct {
@@ -30,7 +43,88 @@ type server struct {
 }
 
 type security struct {
-	Secret string `mapstructure:"SECRET" default:"teste"`
+	SigningKeyID     string        `mapstructure:"JWT_SIGNING_KEY_ID" default:"bank-1"`
+	SigningKeyFile   string        `mapstructure:"JWT_SIGNING_KEY_FILE"`
+	VerificationKeys string        `mapstructure:"JWT_VERIFICATION_KEYS"`
+	AccessTokenTTL   time.Duration `mapstructure:"ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL  time.Duration `mapstructure:"REFRESH_TOKEN_TTL" default:"720h"`
+	SecretResetTTL   time.Duration `mapstructure:"SECRET_RESET_TOKEN_TTL" default:"15m"`
+}
+
+type idempotency struct {
+	Retention time.Duration `mapstructure:"IDEMPOTENCY_KEY_RETENTION" default:"24h"`
+}
+
+type pagination struct {
+	CursorSecret string `mapstructure:"PAGINATION_CURSOR_SECRET"`
+	DefaultLimit int    `mapstructure:"PAGINATION_DEFAULT_LIMIT" default:"20"`
+	MaxLimit     int    `mapstructure:"PAGINATION_MAX_LIMIT" default:"100"`
+}
+
+type login struct {
+	MaxAttempts          int           `mapstructure:"LOGIN_MAX_ATTEMPTS" default:"5"`
+	IPMaxAttempts        int           `mapstructure:"LOGIN_IP_MAX_ATTEMPTS" default:"20"`
+	KeyLookupMaxAttempts int           `mapstructure:"TRANSFER_KEY_LOOKUP_MAX_ATTEMPTS" default:"10"`
+	BaseDelay            time.Duration `mapstructure:"LOGIN_BACKOFF_BASE_DELAY" default:"1s"`
+	MaxDelay             time.Duration `mapstructure:"LOGIN_BACKOFF_MAX_DELAY" default:"1m"`
+	Lockout              time.Duration `mapstructure:"LOGIN_LOCKOUT" default:"15m"`
+}
+
+type mfa struct {
+	EncryptionKey     string `mapstructure:"MFA_ENCRYPTION_KEY"`
+	TransferThreshold string `mapstructure:"MFA_TRANSFER_THRESHOLD" default:"1000.00"`
+}
+
+type notification struct {
+	File string `mapstructure:"NOTIFICATION_FILE"`
+}
+
+type fx struct {
+	RatesFile  string        `mapstructure:"FX_RATES_FILE"`
+	MaxRateAge time.Duration `mapstructure:"FX_MAX_RATE_AGE" default:"1h"`
+}
+
+type scheduler struct {
+	Interval  time.Duration `mapstructure:"SCHEDULER_INTERVAL" default:"1m"`
+	BatchSize int           `mapstructure:"SCHEDULER_BATCH_SIZE" default:"100"`
+}
+
+type transfer struct {
+	ReversalWindow      time.Duration `mapstructure:"TRANSFER_REVERSAL_WINDOW" default:"168h"`
+	LimitPerTransaction string        `mapstructure:"TRANSFER_LIMIT_PER_TRANSACTION" default:"5000"`
+	LimitDaily          string        `mapstructure:"TRANSFER_LIMIT_DAILY" default:"10000"`
+	LimitMonthly        string        `mapstructure:"TRANSFER_LIMIT_MONTHLY" default:"50000"`
+	LimitNighttime      string        `mapstructure:"TRANSFER_LIMIT_NIGHTTIME" default:"1000"`
+	NightStart          time.Duration `mapstructure:"TRANSFER_LIMIT_NIGHT_START" default:"20h"`
+	NightEnd            time.Duration `mapstructure:"TRANSFER_LIMIT_NIGHT_END" default:"6h"`
+	TimeZone            string        `mapstructure:"TRANSFER_LIMIT_TIMEZONE" default:"America/Sao_Paulo"`
+	FeeRulesFile        string        `mapstructure:"TRANSFER_FEE_RULES_FILE"`
+}
+
+type overdraft struct {
+	InterestRate      string        `mapstructure:"OVERDRAFT_INTEREST_DAILY_RATE" default:"0.0026"`
+	InterestInterval  time.Duration `mapstructure:"OVERDRAFT_INTEREST_INTERVAL" default:"1h"`
+	InterestBatchSize int           `mapstructure:"OVERDRAFT_INTEREST_BATCH_SIZE" default:"100"`
+	TimeZone          string        `mapstructure:"OVERDRAFT_INTEREST_TIMEZONE" default:"America/Sao_Paulo"`
+}
+
+type hold struct {
+	DefaultTTL     time.Duration `mapstructure:"HOLD_DEFAULT_TTL" default:"168h"`
+	MaxTTL         time.Duration `mapstructure:"HOLD_MAX_TTL" default:"720h"`
+	SweepInterval  time.Duration `mapstructure:"HOLD_SWEEP_INTERVAL" default:"1m"`
+	SweepBatchSize int           `mapstructure:"HOLD_SWEEP_BATCH_SIZE" default:"100"`
+}
+
+type batch struct {
+	MaxItems          int           `mapstructure:"TRANSFER_BATCH_MAX_ITEMS" default:"1000"`
+	PollInterval      time.Duration `mapstructure:"TRANSFER_BATCH_POLL_INTERVAL" default:"10s"`
+	PollSize          int           `mapstructure:"TRANSFER_BATCH_POLL_SIZE" default:"10"`
+	ProcessingTimeout time.Duration `mapstructure:"TRANSFER_BATCH_PROCESSING_TIMEOUT" default:"15m"`
+}
+
+type beneficiary struct {
+	SuggestionWindow time.Duration `mapstructure:"BENEFICIARY_SUGGESTION_WINDOW" default:"2160h"`
+	SuggestionLimit  int           `mapstructure:"BENEFICIARY_SUGGESTION_LIMIT" default:"5"`
 }
 
 func getMappedEnvs(configStruct reflect.Type) []string {
@@ -109,6 +203,70 @@ func Load() error {
 		return err
 	}
 
+	if err := viper.Unmarshal(&configuration.Idempotency); err != nil {
+		return err
+	}
+
+	if configuration.Idempotency.Retention <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_KEY_RETENTION must be greater than zero")
+	}
+
+	if err := viper.Unmarshal(&configuration.Pagination); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.Login); err != nil {
+		return err
+	}
+
+	if configuration.Login.KeyLookupMaxAttempts <= 0 {
+		return fmt.Errorf("TRANSFER_KEY_LOOKUP_MAX_ATTEMPTS must be greater than zero")
+	}
+
+	if err := viper.Unmarshal(&configuration.MFA); err != nil {
+		return err
+	}
+
+	if configuration.MFA.EncryptionKey == "" {
+		return fmt.Errorf("MFA_ENCRYPTION_KEY is required")
+	}
+
+	if err := viper.Unmarshal(&configuration.Notification); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.FX); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.Scheduler); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.Transfer); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.Overdraft); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.Hold); err != nil {
+		return err
+	}
+
+	if err := viper.Unmarshal(&configuration.Batch); err != nil {
+		return err
+	}
+
+	if configuration.Batch.ProcessingTimeout <= 0 {
+		return fmt.Errorf("TRANSFER_BATCH_PROCESSING_TIMEOUT must be greater than zero")
+	}
+
+	if err := viper.Unmarshal(&configuration.Beneficiary); err != nil {
+		return err
+	}
+
 	return nil
 
 }
@@ -16,6 +16,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Public keys accepted to verify the access tokens, identified by the kid header of the token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/security.JWKS"
+                        }
+                    }
+                }
+            }
+        },
         "/accounts": {
             "get": {
                 "security": [
@@ -30,7 +50,7 @@ const docTemplate = `{
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Find",
+                "summary": "Find accounts",
                 "parameters": [
                     {
                         "type": "integer",
@@ -43,16 +63,19 @@ const docTemplate = `{
                         "description": "page offset",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/usecase.FindAccountUseCaseOutput"
-                            }
+                            "$ref": "#/definitions/usecase.FindAccountUseCasePageOutput"
                         }
                     },
                     "400": {
@@ -61,6 +84,9 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -77,7 +103,7 @@ const docTemplate = `{
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Create",
+                "summary": "Create account",
                 "parameters": [
                     {
                         "description": "create account request vody",
@@ -114,6 +140,57 @@ const docTemplate = `{
                 }
             }
         },
+        "/accounts/me/secret": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the secret of the logged account with the current secret, every session of the account is revoked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Change secret",
+                "parameters": [
+                    {
+                        "description": "change secret request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ChangeSecretUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/accounts/{account_id}/balance": {
             "get": {
                 "security": [
@@ -128,7 +205,7 @@ const docTemplate = `{
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Find",
+                "summary": "Find balance",
                 "parameters": [
                     {
                         "type": "string",
@@ -154,6 +231,9 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -163,24 +243,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Login checks that the user can use the API and returns a token",
+        "/accounts/{account_id}/credit-limit": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the overdraft approved for an account, only admins can do it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "accounts"
+                    "back-office"
                 ],
-                "summary": "Login",
+                "summary": "Change credit limit",
                 "parameters": [
                     {
-                        "description": "login request body",
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "change credit limit request body",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecase.LoginUseCaseInput"
+                            "$ref": "#/definitions/usecase.ChangeCreditLimitUseCaseInput"
                         }
                     }
                 ],
@@ -188,7 +280,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecase.LoginUseCaseOutput"
+                            "$ref": "#/definitions/usecase.ChangeCreditLimitUseCaseOutput"
                         }
                     },
                     "400": {
@@ -197,52 +289,65 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "Not Found"
                     },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/transfers": {
-            "get": {
+        "/accounts/{account_id}/deposits": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Find transfers from an account(user needs to be authenticated)",
+                "description": "Deposit money into an account, only administrators can deposit",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transfers"
+                    "accounts"
                 ],
-                "summary": "Find transfers by account",
+                "summary": "Deposit",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "number of items to be returned per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "page offset",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "deposit request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.MovementUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same deposit safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/usecase.MakeTransferUseCaseOutput"
-                            }
+                            "$ref": "#/definitions/usecase.MovementUseCaseOutput"
                         }
                     },
                     "400": {
@@ -251,6 +356,61 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/limits": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the per-transaction, daily, monthly and nighttime transfer limits of an account, customers can only read their own account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "limits"
+                ],
+                "summary": "Find transfer limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FindTransferLimitUseCaseOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -259,36 +419,43 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create transfer between two accounts",
+                "description": "Change the transfer limits of an account, customers can only lower the limits of their own account and admins can raise the limits of any account",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transfers"
+                    "limits"
                 ],
-                "summary": "Create transfer",
+                "summary": "Change transfer limits",
                 "parameters": [
                     {
-                        "description": "make transfer request body",
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "change limits request body, limits left empty are kept",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecase.MakeTransferUseCaseInput"
+                            "$ref": "#/definitions/usecase.ChangeTransferLimitUseCaseInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecase.MakeTransferUseCaseOutput"
+                            "$ref": "#/definitions/usecase.FindTransferLimitUseCaseOutput"
                         }
                     },
                     "400": {
@@ -297,6 +464,9 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -308,35 +478,2608 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "usecase.CreateAccountUseCaseInput": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "integer"
-                },
-                "cpf": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "secret": {
-                    "type": "string"
+        },
+        "/accounts/{account_id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the role of an account, only admins can do it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "back-office"
+                ],
+                "summary": "Change account role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "change role request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ChangeAccountRoleUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ChangeAccountRoleUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
                 }
             }
         },
-        "usecase.CreateAccountUseCaseOutput": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
+        "/accounts/{account_id}/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the transfers sent and received by a specific account, customers can only read their own account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Find transfers of an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items to be returned per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "incoming or outgoing, both when empty",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "transfers created at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "transfers created at or before (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "minimum amount, as a decimal such as 10.50",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "maximum amount, as a decimal such as 10.50",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency of min_amount and max_amount, BRL when empty",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the other account of the transfer",
+                        "name": "counterparty_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FindTransfersByAccountUseCasePageOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/accounts/{account_id}/withdrawals": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Withdraw money from the logged account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Withdraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "account_id",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "withdrawal request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.MovementUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same withdrawal safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.MovementUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/beneficiaries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the beneficiaries of the authenticated account, favorites first and then by nickname",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beneficiaries"
+                ],
+                "summary": "Find beneficiaries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecase.BeneficiaryUseCaseOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Save a recipient of the authenticated account, informed by its account id or by one of its keys, so transfers can be sent to the beneficiary id. Keys that do not exist are counted as the lookups of transfer keys, and after too many of them no beneficiary is saved by key until the account waits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beneficiaries"
+                ],
+                "summary": "Create beneficiary",
+                "parameters": [
+                    {
+                        "description": "create beneficiary request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateBeneficiaryUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BeneficiaryUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/beneficiaries/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Suggest as beneficiaries the accounts that most received transfers from the authenticated account recently and are not saved yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beneficiaries"
+                ],
+                "summary": "Find beneficiary suggestions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecase.BeneficiarySuggestionUseCaseOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/beneficiaries/{beneficiary_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rename a beneficiary of the authenticated account or mark it as favorite, the fields not informed are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beneficiaries"
+                ],
+                "summary": "Update beneficiary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "beneficiary id",
+                        "name": "beneficiary_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update beneficiary request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.UpdateBeneficiaryUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BeneficiaryUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a beneficiary of the authenticated account, the transfers already sent to it are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beneficiaries"
+                ],
+                "summary": "Delete beneficiary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "beneficiary id",
+                        "name": "beneficiary_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/holds": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reserve funds of the authenticated account for a later capture by the destination account, the funds stay in the balance but are not available until the hold is captured, released or expires",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Create hold",
+                "parameters": [
+                    {
+                        "description": "create hold request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateHoldUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same hold safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.HoldUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find a hold, customers can only read the holds of their own account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Find hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hold_id",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.HoldUseCaseOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}/capture": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move all or part of a hold to the destination account with a transfer, the rest goes back to the origin account. The transfer pays the fees of the origin account as any other transfer. Only the recipient can capture the hold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Capture hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hold_id",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "capture hold request body, the whole hold when empty",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CaptureHoldUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same capture safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CaptureHoldUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give back the whole hold to the origin account. Only the recipient can release the hold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Release hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hold_id",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.HoldUseCaseOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/ledger/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compare the cached balance of every account with the ledger, only admins can do it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "back-office"
+                ],
+                "summary": "Reconcile ledger",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ReconcileLedgerUseCaseOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login checks that the user can use the API and returns an access token and a refresh token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "login request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.LoginUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.TokenUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/login/mfa": {
+            "post": {
+                "description": "Trade the mfa_token returned by the login and a TOTP or recovery code for the access token and the refresh token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "two-factor"
+                ],
+                "summary": "Login second step",
+                "parameters": [
+                    {
+                        "description": "login mfa request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.LoginMFAUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.TokenUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke the access token used in the request and the refresh tokens of its session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/mfa/totp": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate the TOTP secret of the logged account, it has to be confirmed with a code before being required",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "two-factor"
+                ],
+                "summary": "Enroll TOTP",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.EnrollTOTPUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/mfa/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enable two-factor authentication with a code of the enrolled authenticator, returns the recovery codes only once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "two-factor"
+                ],
+                "summary": "Confirm TOTP",
+                "parameters": [
+                    {
+                        "description": "confirm totp request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ConfirmTOTPUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ConfirmTOTPUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the transfers scheduled by the authenticated account, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled transfers"
+                ],
+                "summary": "Find scheduled transfers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items to be returned per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FindScheduledTransfersByAccountUseCasePageOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Schedule a transfer for a future date, once or recurring daily, weekly or monthly until end_at or max_executions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled transfers"
+                ],
+                "summary": "Schedule transfer",
+                "parameters": [
+                    {
+                        "description": "schedule transfer request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateScheduledTransferUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same schedule safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ScheduledTransferUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers/{scheduled_transfer_id}/executions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the executions of a transfer scheduled by the authenticated account, newest first, with the transfer made or the reason it failed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled transfers"
+                ],
+                "summary": "Find scheduled transfer executions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "scheduled_transfer_id",
+                        "name": "scheduled_transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecase.FindScheduledTransferExecutionsUseCaseOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/scheduled-transfers/{scheduled_transfer_id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pause, resume or cancel a transfer scheduled by the authenticated account, status is paused, active or canceled",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled transfers"
+                ],
+                "summary": "Change scheduled transfer status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "scheduled_transfer_id",
+                        "name": "scheduled_transfer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "change status request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ChangeScheduledTransferStatusUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ScheduledTransferUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/secret/reset": {
+            "post": {
+                "description": "Send a secret reset token to the owner of the account, the answer is the same whether the CPF has an account or not. Requests are limited per CPF and per IP with the wait and the lockout of the login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Request secret reset",
+                "parameters": [
+                    {
+                        "description": "request secret reset request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.RequestSecretResetUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/secret/reset/confirm": {
+            "post": {
+                "description": "Choose a new secret with a secret reset token, every session of the account is revoked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Reset secret",
+                "parameters": [
+                    {
+                        "description": "reset secret request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ResetSecretUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Trade a refresh token for a new access token and a new refresh token, each refresh token can be used only once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Refresh token",
+                "parameters": [
+                    {
+                        "description": "refresh token request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.RefreshTokenUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.TokenUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfer-keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the keys of the authenticated account, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer keys"
+                ],
+                "summary": "Find transfer keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecase.TransferKeyUseCaseOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a key of the authenticated account, a CPF, an email, a phone or a random key (evp) generated by the bank, that can be used instead of the account id to receive transfers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer keys"
+                ],
+                "summary": "Create transfer key",
+                "parameters": [
+                    {
+                        "description": "create transfer key request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateTransferKeyUseCaseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.TransferKeyUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfer-keys/{key}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Look up a key before sending a transfer to it, the owner name is masked and the account id is not shown. Lookups of keys that do not exist are limited per account, after too many of them the account waits as after failed logins",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer keys"
+                ],
+                "summary": "Find transfer key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "key, a CPF with or without punctuation, an email, a phone as +5511987654321 or a random key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FindTransferKeyUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a key of the authenticated account, it can then be registered again by any account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer keys"
+                ],
+                "summary": "Delete transfer key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find transfers sent and received by an account, newest first(user needs to be authenticated)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Find transfers by account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items to be returned per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "incoming or outgoing, both when empty",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "transfers created at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "transfers created at or before (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "minimum amount, as a decimal such as 10.50",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "maximum amount, as a decimal such as 10.50",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency of min_amount and max_amount, BRL when empty",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the other account of the transfer",
+                        "name": "counterparty_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FindTransfersByAccountUseCasePageOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create transfer between two accounts, the destination account is informed by its id, by one of its keys or by a saved beneficiary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Create transfer",
+                "parameters": [
+                    {
+                        "description": "make transfer request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.MakeTransferUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same transfer safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.MakeTransferUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfers/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send many transfers from the authenticated account, the batch is checked up front and processed in the background. An atomic batch makes every transfer or none, a best_effort batch makes each transfer on its own. Poll the batch for the outcome of each item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Create transfer batch",
+                "parameters": [
+                    {
+                        "description": "create transfer batch request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateTransferBatchUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same batch safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.TransferBatchUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfers/batch/{batch_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find a transfer batch with the outcome of each item, customers can only read the batches of their own account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Find transfer batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "batch_id",
+                        "name": "batch_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.TransferBatchUseCaseOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfers/{transfer_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find a transfer with its status history, customers can only read the transfers of their own account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Find transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transfer_id",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FindTransferUseCaseOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/transfers/{transfer_id}/reversals": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give back all or part of a transfer with a transfer linked to it, the recipient can refund the transfers it received and admins can reverse any transfer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Reverse transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transfer_id",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reverse transfer request body, the whole reversible amount when empty",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ReverseTransferUseCaseInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key that makes retries of the same reversal safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ReverseTransferUseCaseOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "10.50"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                }
+            }
+        },
+        "entity.MovementChannel": {
+            "type": "string",
+            "enum": [
+                "BRANCH",
+                "ATM",
+                "ONLINE"
+            ],
+            "x-enum-varnames": [
+                "BRANCH_CHANNEL",
+                "ATM_CHANNEL",
+                "ONLINE_CHANNEL"
+            ]
+        },
+        "security.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "security.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/security.JWK"
+                    }
+                }
+            }
+        },
+        "usecase.BeneficiarySuggestionUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "destination_account_id": {
+                    "type": "string"
+                },
+                "last_transfer_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "transfers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.BeneficiaryUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "favorite": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CaptureHoldUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.CaptureHoldUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "captured_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination_account_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "origin_account_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transfer": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseOutput"
+                },
+                "transfer_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ChangeAccountRoleUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ChangeAccountRoleUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ChangeCreditLimitUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "credit_limit": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.ChangeCreditLimitUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "available_balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "credit_limit": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "held": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.ChangeScheduledTransferStatusUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "paused"
+                }
+            }
+        },
+        "usecase.ChangeSecretUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "current_secret": {
+                    "type": "string"
+                },
+                "new_secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ChangeTransferLimitUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "monthly": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "nighttime": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "per_transaction": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.ConfirmTOTPUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ConfirmTOTPUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.CreateAccountUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "cpf": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateAccountUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateBeneficiaryUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "destination_account_id": {
+                    "type": "string",
+                    "example": "d18551d3-cf13-49ec-b1dc-741a1f8715f6"
+                },
+                "favorite": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "joao@example.com"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Joao"
+                }
+            }
+        },
+        "usecase.CreateHoldUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccountInput"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2023-08-21T10:00:00Z"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateScheduledTransferUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccountInput"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "max_executions": {
+                    "type": "integer"
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2023-09-05T09:00:00Z"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateTransferBatchUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.CreateTransferBatchUseCaseItemInput"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "atomic"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateTransferBatchUseCaseItemInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccountInput"
+                }
+            }
+        },
+        "usecase.CreateTransferKeyUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "lucas@example.com"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "email"
+                }
+            }
+        },
+        "usecase.EnrollTOTPUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindAccountUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindAccountUseCasePageOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.FindAccountUseCaseOutput"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindBalanceByAccountUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "available_balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "credit_limit": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "held": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.FindScheduledTransferExecutionsUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "executed_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindScheduledTransfersByAccountUseCasePageOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.ScheduledTransferUseCaseOutput"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindTransferKeyUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "owner_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindTransferLimitUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "daily": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "monthly": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "night_end": {
+                    "type": "string"
+                },
+                "night_start": {
+                    "type": "string"
+                },
+                "nighttime": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "per_transaction": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindTransferUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                },
+                "destination_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "fee": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.MakeTransferUseCaseFee"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "origin_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                },
+                "reversal_of": {
+                    "type": "string"
+                },
+                "reversed_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.FindTransferUseCaseStatusChange"
+                    }
+                }
+            }
+        },
+        "usecase.FindTransferUseCaseStatusChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindTransfersByAccountUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "counterparty": {
+                    "$ref": "#/definitions/usecase.account"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.account"
+                },
+                "destination_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "fee": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.MakeTransferUseCaseFee"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "origin_account": {
+                    "$ref": "#/definitions/usecase.account"
+                },
+                "reversal_of": {
+                    "type": "string"
+                },
+                "reversed_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.FindTransfersByAccountUseCasePageOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.FindTransfersByAccountUseCaseOutput"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.HoldUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "captured_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination_account_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "origin_account_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.LoginMFAUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.LoginUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "cpf": {
+                    "type": "string"
                 },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.MakeTransferUseCaseAccount": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string"
                 },
@@ -345,97 +3088,431 @@ const docTemplate = `{
                 }
             }
         },
-        "usecase.FindAccountUseCaseOutput": {
+        "usecase.MakeTransferUseCaseAccountInput": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.MakeTransferUseCaseDestinationInput": {
+            "type": "object",
+            "properties": {
+                "beneficiary_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "lucas@example.com"
+                }
+            }
+        },
+        "usecase.MakeTransferUseCaseFee": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "waived": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "usecase.MakeTransferUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseDestinationInput"
+                },
+                "totp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.MakeTransferUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                },
+                "destination_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "fee": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.MakeTransferUseCaseFee"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "origin_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                },
+                "reversal_of": {
+                    "type": "string"
+                },
+                "reversed_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "usecase.FindBalanceByAccountUseCaseOutput": {
+        "usecase.MovementUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "channel": {
+                    "$ref": "#/definitions/entity.MovementChannel"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.MovementUseCaseOutput": {
             "type": "object",
             "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
                 "balance": {
-                    "type": "integer"
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "usecase.LoginUseCaseInput": {
+        "usecase.ReconcileLedgerUseCaseDriftOutput": {
             "type": "object",
             "properties": {
-                "cpf": {
+                "account_id": {
                     "type": "string"
                 },
-                "secret": {
+                "cached_balance": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "drift": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "ledger_balance": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.ReconcileLedgerUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "accounts_checked": {
+                    "type": "integer"
+                },
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.ReconcileLedgerUseCaseDriftOutput"
+                    }
+                },
+                "unbalanced_entries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.RefreshTokenUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.RequestSecretResetUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "cpf": {
                     "type": "string"
                 }
             }
         },
-        "usecase.LoginUseCaseOutput": {
+        "usecase.ResetSecretUseCaseInput": {
             "type": "object",
             "properties": {
+                "new_secret": {
+                    "type": "string"
+                },
                 "token": {
                     "type": "string"
                 }
             }
         },
-        "usecase.MakeTransferUseCaseAccount": {
+        "usecase.ReverseTransferUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                }
+            }
+        },
+        "usecase.ReverseTransferUseCaseOutput": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                },
+                "destination_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "fee": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.MakeTransferUseCaseFee"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "origin_account": {
+                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                },
+                "reversal_of": {
+                    "type": "string"
+                },
+                "reversed_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "reversible_amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "usecase.MakeTransferUseCaseAccountInput": {
+        "usecase.ScheduledTransferUseCaseOutput": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "destination_account_id": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "executions": {
+                    "type": "integer"
+                },
+                "frequency": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
+                },
+                "max_executions": {
+                    "type": "integer"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "origin_account_id": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "usecase.MakeTransferUseCaseInput": {
+        "usecase.TokenUseCaseOutput": {
             "type": "object",
             "properties": {
-                "amount": {
+                "expires_in": {
                     "type": "integer"
                 },
-                "destination_account": {
-                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccountInput"
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
                 }
             }
         },
-        "usecase.MakeTransferUseCaseOutput": {
+        "usecase.TransferBatchItemUseCaseOutput": {
             "type": "object",
             "properties": {
                 "amount": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "destination_account_id": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "position": {
                     "type": "integer"
                 },
+                "status": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.TransferBatchUseCaseOutput": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "destination_account": {
-                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                "failed": {
+                    "type": "integer"
+                },
+                "failure_reason": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "origin_account": {
-                    "$ref": "#/definitions/usecase.MakeTransferUseCaseAccount"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.TransferBatchItemUseCaseOutput"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "origin_account_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "$ref": "#/definitions/entity.Money"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.TransferKeyUseCaseOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.UpdateBeneficiaryUseCaseInput": {
+            "type": "object",
+            "properties": {
+                "favorite": {
+                    "type": "boolean"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Mom"
+                }
+            }
+        },
+        "usecase.account": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         }
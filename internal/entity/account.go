@@ -2,31 +2,50 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode"
 )
 
+const (
+	SECRET_MIN_LENGTH = 8
+
+	// SECRET_MAX_LENGTH is the limit of bcrypt, the bytes after it would be ignored.
+	SECRET_MAX_LENGTH = 72
+)
+
+// Account holds the ledger balance of a customer. The balance may go negative down to the
+// CreditLimit, the overdraft approved by the bank, which starts at zero. Held is the sum of
+// the active holds, money still in the balance that the account cannot spend.
 type Account struct {
-	ID        string
-	Name      string
-	CPF       string
-	Secret    string
-	Balance   int
-	CreatedAt *time.Time
+	ID          string
+	Name        string
+	CPF         string
+	Secret      string
+	Balance     Money
+	CreditLimit Money
+	Held        Money
+	Role        Role
+	CreatedAt   *time.Time
 }
 
-func NewAccount(ID string, name string, CPF string, secret string, balance int, createdAt *time.Time) (*Account, error) {
+func NewAccount(ID string, name string, CPF string, secret string, balance Money, createdAt *time.Time) (*Account, error) {
 
 	if ID == "" {
 		ID = NewUUID()
 	}
 
 	account := &Account{
-		ID:        ID,
-		Name:      name,
-		CPF:       CPF,
-		Secret:    secret,
-		Balance:   balance,
-		CreatedAt: createdAt,
+		ID:          ID,
+		Name:        name,
+		CPF:         CPF,
+		Secret:      secret,
+		Balance:     balance,
+		CreditLimit: NewMoney(0, balance.Currency),
+		Held:        NewMoney(0, balance.Currency),
+		Role:        CUSTOMER_ROLE,
+		CreatedAt:   createdAt,
 	}
 
 	err := account.isValid()
@@ -60,9 +79,17 @@ func (a *Account) isValid() error {
 
 	if a.Secret == "" {
 		validationError.Add("secret cannot be empty")
+	} else {
+		for _, message := range a.secretStrengthErrors(a.Secret) {
+			validationError.Add(message)
+		}
+	}
+
+	if !a.Balance.Currency.IsValid() {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", a.Balance.Currency))
 	}
 
-	if a.Balance < 0 {
+	if a.Balance.IsNegative() {
 		validationError.Add("balance cannot be minor than 0")
 	}
 
@@ -77,23 +104,178 @@ func (a *Account) isValid() error {
 	return nil
 }
 
-func (a *Account) addFromBalance(value int) {
-	a.Balance += value
+// secretStrengthErrors lists the rules the secret breaks, it cannot be short, be made only of
+// letters or only of digits, or contain the CPF of the account.
+func (a *Account) secretStrengthErrors(secret string) []string {
+	var messages []string
+
+	if len(secret) < SECRET_MIN_LENGTH {
+		messages = append(messages, fmt.Sprintf("secret must have at least %d characters", SECRET_MIN_LENGTH))
+	}
+
+	if len(secret) > SECRET_MAX_LENGTH {
+		messages = append(messages, fmt.Sprintf("secret must have at most %d characters", SECRET_MAX_LENGTH))
+	}
+
+	hasLetter := strings.IndexFunc(secret, unicode.IsLetter) >= 0
+	hasDigit := strings.IndexFunc(secret, unicode.IsDigit) >= 0
+	if !hasLetter || !hasDigit {
+		messages = append(messages, "secret must have letters and digits")
+	}
+
+	CPF := a.CPF
+	cleanNonDigits(&CPF)
+	if CPF != "" && strings.Contains(secret, CPF) {
+		messages = append(messages, "secret cannot contain the CPF")
+	}
+
+	return messages
+}
+
+// Currency is the currency of the balance, every amount moved by the account must be in it.
+func (a *Account) Currency() Currency {
+	return a.Balance.Currency
+}
+
+// AvailableBalance is what the account can still spend: the balance plus the credit limit,
+// less the funds on hold.
+func (a *Account) AvailableBalance() Money {
+	return NewMoney(a.Balance.Amount+a.CreditLimit.Amount-a.Held.Amount, a.Currency())
+}
+
+// ChangeCreditLimit sets the overdraft of the account, it cannot be lower than what the
+// account already owes.
+func (a *Account) ChangeCreditLimit(creditLimit Money) error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if creditLimit.Currency != a.Currency() {
+		return validationError.Add(fmt.Sprintf("credit limit must be in %s", a.Currency()))
+	}
+
+	if creditLimit.IsNegative() {
+		return validationError.Add("credit limit cannot be minor than 0")
+	}
+
+	if a.Balance.Amount+creditLimit.Amount < 0 {
+		owed := NewMoney(-a.Balance.Amount, a.Currency())
+		return validationError.Add(fmt.Sprintf("credit limit cannot be lower than the overdrawn amount of %s %s", owed, owed.Currency))
+	}
+
+	a.CreditLimit = creditLimit
+	return nil
+}
+
+// placeHold reserves part of the available balance, the balance itself does not change.
+func (a *Account) placeHold(amount Money) error {
+	comparison, err := a.AvailableBalance().Compare(amount)
+	if err != nil {
+		return err
+	}
+
+	if comparison < 0 {
+		return NewErrorHandler(ENTITY_ERROR).Add("insufficient available balance for hold")
+	}
+
+	a.Held = NewMoney(a.Held.Amount+amount.Amount, a.Currency())
+	return nil
+}
+
+// releaseHold gives back to the available balance what a hold reserved.
+func (a *Account) releaseHold(amount Money) {
+	a.Held = NewMoney(a.Held.Amount-amount.Amount, a.Currency())
 }
 
-func (a *Account) removeFromBalance(value int) error {
+func (a *Account) addFromBalance(value Money) error {
+	balance, err := a.Balance.Add(value)
+	if err != nil {
+		return err
+	}
+
+	a.Balance = balance
+	return nil
+}
+
+func (a *Account) removeFromBalance(value Money) error {
 	validationError := NewErrorHandler(BAD_REQUEST)
 
-	a.Balance -= value
+	balance, err := a.Balance.Sub(value)
+	if err != nil {
+		return err
+	}
 
-	if a.Balance < 0 {
-		validationError.Add("new balance cannot be minor than 0(insufficient balance)")
+	if balance.Amount+a.CreditLimit.Amount-a.Held.Amount < 0 {
+		if !a.Held.IsZero() {
+			validationError.Add("new balance cannot cover the funds on hold(insufficient available balance)")
+		} else if a.CreditLimit.IsZero() {
+			validationError.Add("new balance cannot be minor than 0(insufficient balance)")
+		} else {
+			validationError.Add(fmt.Sprintf("new balance cannot be minor than -%s(overdraft limit exceeded)", a.CreditLimit))
+		}
 		return validationError
 	}
 
+	a.Balance = balance
 	return nil
 }
 
+// Deposit credits a positive amount to the account.
+func (a *Account) Deposit(amount Money) error {
+	if !amount.IsPositive() {
+		return NewErrorHandler(ENTITY_ERROR).Add("deposit amount must be greater than zero")
+	}
+
+	return a.addFromBalance(amount)
+}
+
+// Withdraw debits a positive amount from the account, it cannot take more than the available
+// balance.
+func (a *Account) Withdraw(amount Money) error {
+	if !amount.IsPositive() {
+		return NewErrorHandler(ENTITY_ERROR).Add("withdrawal amount must be greater than zero")
+	}
+
+	comparison, err := a.AvailableBalance().Compare(amount)
+	if err != nil {
+		return err
+	}
+
+	if comparison < 0 {
+		return NewErrorHandler(ENTITY_ERROR).Add("insufficient balance for withdrawal")
+	}
+
+	return a.removeFromBalance(amount)
+}
+
 func (a *Account) SecretIsCorrect(secret string) bool {
 	return hashIsValid(a.Secret, secret)
 }
+
+// ChangeSecret replaces the hashed secret after checking the same rules of a new account, the
+// account needs its CPF and current secret loaded.
+func (a *Account) ChangeSecret(secret string) error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if secret == "" {
+		validationError.Add("secret cannot be empty")
+	} else {
+		for _, message := range a.secretStrengthErrors(secret) {
+			validationError.Add(message)
+		}
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	if a.SecretIsCorrect(secret) {
+		return validationError.Add("new secret must be different from the current secret")
+	}
+
+	secretHashed, err := hash(secret)
+	if err != nil {
+		return NewErrorHandler(INTERNAL_ERROR).Add("error on hashing password")
+	}
+
+	a.Secret = string(secretHashed)
+	return nil
+}
@@ -14,8 +14,8 @@ func TestAccount_NewAccount(t *testing.T) {
 		ID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 		name := "lucas"
 		CPF := "35768297090"
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -37,8 +37,8 @@ func TestAccount_NewAccount(t *testing.T) {
 		ID := ""
 		name := "lucas"
 		CPF := "35768297090"
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -60,23 +60,23 @@ func TestAccount_NewAccount(t *testing.T) {
 		ID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 		name := "lucas"
 		CPF := "35768297090"
-		secret := "45784054578405457840545784054578405454578405457840545784054578405457840578405457840545784054578405457840545784054578405"
-		balance := 100
+		secret := "lucas4578405457840545784054578405454578405457840545784054578405457840578405457840545784054578405457840545784054578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
 		assert.Nil(t, account)
 		assert.NotNil(t, err)
 
-		assert.Equal(t, "error on hashing password", err.Error())
+		assert.Equal(t, "secret must have at most 72 characters", err.Error())
 	})
 
 	t.Run("Testing NewAccount when returning an invalid account (Name is invalid)", func(t *testing.T) {
 		ID := "123"
 		name := ""
 		CPF := "35768297090"
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -90,8 +90,8 @@ func TestAccount_NewAccount(t *testing.T) {
 		ID := "123"
 		name := "Lucas"
 		CPF := ""
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -105,8 +105,8 @@ func TestAccount_NewAccount(t *testing.T) {
 		ID := "123"
 		name := "Lucas"
 		CPF := "4324234"
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -121,7 +121,7 @@ func TestAccount_NewAccount(t *testing.T) {
 		name := "Lucas"
 		CPF := "35768297090"
 		secret := ""
-		balance := 100
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -135,8 +135,8 @@ func TestAccount_NewAccount(t *testing.T) {
 		ID := "123"
 		name := "Lucas"
 		CPF := "35768297090"
-		secret := "4578405"
-		balance := -100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(-100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -146,12 +146,27 @@ func TestAccount_NewAccount(t *testing.T) {
 		assert.Equal(t, "balance cannot be minor than 0", err.Error())
 	})
 
+	t.Run("Testing NewAccount when returning an invalid account (Currency is invalid)", func(t *testing.T) {
+		ID := "123"
+		name := "Lucas"
+		CPF := "35768297090"
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.Currency("XYZ"))
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
+
+		assert.Nil(t, account)
+		assert.NotNil(t, err)
+
+		assert.Equal(t, "currency is invalid: XYZ", err.Error())
+	})
+
 	t.Run("Testing NewAccount when returning an invalid account (CreatedAt is invalid)", func(t *testing.T) {
 		ID := "123"
 		name := "Lucas"
 		CPF := "35768297090"
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		var createdAt *time.Time
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, createdAt)
 
@@ -160,6 +175,19 @@ func TestAccount_NewAccount(t *testing.T) {
 
 		assert.Equal(t, "created at cannot be nil", err.Error())
 	})
+	t.Run("Testing NewAccount when returning an invalid account (Secret is weak)", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		account, err := entity.NewAccount("123", "Lucas", "35768297090", "4578405", entity.NewMoney(100, entity.BRL), &createdAt)
+
+		assert.Nil(t, account)
+		assert.Equal(t, []string{"secret must have at least 8 characters", "secret must have letters and digits"}, err.(*entity.ErrorHandler).Messages)
+
+		account, err = entity.NewAccount("123", "Lucas", "357.682.970-90", "lucas35768297090", entity.NewMoney(100, entity.BRL), &createdAt)
+
+		assert.Nil(t, account)
+		assert.Equal(t, "secret cannot contain the CPF", err.Error())
+	})
 }
 
 func TestAccount_SecretIsCorrect(t *testing.T) {
@@ -167,8 +195,8 @@ func TestAccount_SecretIsCorrect(t *testing.T) {
 		ID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 		name := "lucas"
 		CPF := "35768297090"
-		secret := "4578405"
-		balance := 100
+		secret := "lucas4578405"
+		balance := entity.NewMoney(100, entity.BRL)
 		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 		account, err := entity.NewAccount(ID, name, CPF, secret, balance, &createdAt)
 
@@ -186,3 +214,178 @@ func TestAccount_SecretIsCorrect(t *testing.T) {
 		assert.True(t, result)
 	})
 }
+
+func TestAccount_Deposit(t *testing.T) {
+	t.Run("Testing Deposit adds the amount to the balance", func(t *testing.T) {
+		account := GetBaseOriginAccount(t) // Balance = 100
+
+		err := account.Deposit(entity.NewMoney(50, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(150, entity.BRL), account.Balance)
+	})
+
+	t.Run("Testing Deposit when amount is not positive", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.Deposit(entity.NewMoney(0, entity.BRL))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "deposit amount must be greater than zero", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+	})
+}
+
+func TestAccount_Withdraw(t *testing.T) {
+	t.Run("Testing Withdraw removes the amount from the balance", func(t *testing.T) {
+		account := GetBaseOriginAccount(t) // Balance = 100
+
+		err := account.Withdraw(entity.NewMoney(100, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), account.Balance)
+	})
+
+	t.Run("Testing Withdraw when balance is insufficient", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.Withdraw(entity.NewMoney(101, entity.BRL))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "insufficient balance for withdrawal", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+	})
+
+	t.Run("Testing Withdraw when amount is in another currency", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.Withdraw(entity.NewMoney(50, entity.USD))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "currency mismatch: BRL and USD", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+	})
+
+	t.Run("Testing Withdraw when amount is not positive", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.Withdraw(entity.NewMoney(-10, entity.BRL))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "withdrawal amount must be greater than zero", err.Error())
+	})
+}
+
+func TestAccount_CreditLimit(t *testing.T) {
+	t.Run("Testing NewAccount starts without credit limit", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), account.CreditLimit)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.AvailableBalance())
+	})
+
+	t.Run("Testing Withdraw uses the credit limit", func(t *testing.T) {
+		account := GetBaseOriginAccount(t) // Balance = 100
+		assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(500, entity.BRL)))
+
+		err := account.Withdraw(entity.NewMoney(350, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-250, entity.BRL), account.Balance)
+		assert.Equal(t, entity.NewMoney(250, entity.BRL), account.AvailableBalance())
+	})
+
+	t.Run("Testing Withdraw when amount is greater than the available balance", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(500, entity.BRL)))
+
+		err := account.Withdraw(entity.NewMoney(601, entity.BRL))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "insufficient balance for withdrawal", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+	})
+
+	t.Run("Testing ChangeCreditLimit when the limit is below the overdrawn amount", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(500, entity.BRL)))
+		assert.Nil(t, account.Withdraw(entity.NewMoney(400, entity.BRL)))
+
+		err := account.ChangeCreditLimit(entity.NewMoney(299, entity.BRL))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "credit limit cannot be lower than the overdrawn amount of 3.00 BRL", err.Error())
+		assert.Equal(t, entity.NewMoney(500, entity.BRL), account.CreditLimit)
+
+		assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(300, entity.BRL)))
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), account.AvailableBalance())
+	})
+
+	t.Run("Testing ChangeCreditLimit when the limit is invalid", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.ChangeCreditLimit(entity.NewMoney(-1, entity.BRL))
+		assert.NotNil(t, err)
+		assert.Equal(t, "credit limit cannot be minor than 0", err.Error())
+
+		err = account.ChangeCreditLimit(entity.NewMoney(500, entity.USD))
+		assert.NotNil(t, err)
+		assert.Equal(t, "credit limit must be in BRL", err.Error())
+	})
+}
+
+func TestAccount_Held(t *testing.T) {
+	t.Run("Testing Withdraw cannot take the funds on hold", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+		expiresAt := createdAt.Add(time.Hour)
+
+		_, err := entity.NewHold("", account, GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = account.Withdraw(entity.NewMoney(41, entity.BRL))
+
+		assert.Equal(t, "insufficient balance for withdrawal", err.Error())
+		assert.Nil(t, account.Withdraw(entity.NewMoney(40, entity.BRL)))
+		assert.Equal(t, entity.NewMoney(60, entity.BRL), account.Balance)
+	})
+}
+
+func TestAccount_ChangeSecret(t *testing.T) {
+	t.Run("Testing ChangeSecret hashes the new secret", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.ChangeSecret("n3wSecretValue")
+
+		assert.Nil(t, err)
+		assert.True(t, account.SecretIsCorrect("n3wSecretValue"))
+		assert.False(t, account.SecretIsCorrect("lucas4578405"))
+	})
+
+	t.Run("Testing ChangeSecret when secret is weak", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		current := account.Secret
+
+		err := account.ChangeSecret("onlyletters")
+
+		assert.Equal(t, "secret must have letters and digits", err.Error())
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, current, account.Secret)
+	})
+
+	t.Run("Testing ChangeSecret when secret is the current one", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.ChangeSecret("lucas4578405")
+
+		assert.Equal(t, "new secret must be different from the current secret", err.Error())
+	})
+
+	t.Run("Testing ChangeSecret when secret is empty", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		err := account.ChangeSecret("")
+
+		assert.Equal(t, "secret cannot be empty", err.Error())
+	})
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Principal is the caller identified by the access token. TokenID and SessionID are the
+// "jti" and "sid" claims, used to revoke the token and its session.
+type Principal struct {
+	AccountID      string
+	Role           Role
+	TokenID        string
+	SessionID      string
+	TokenExpiresAt *time.Time
+}
+
+func NewPrincipal(accountID string, role Role) *Principal {
+	return &Principal{
+		AccountID: accountID,
+		Role:      role,
+	}
+}
+
+func (p *Principal) Can(permission Permission) bool {
+	return p.Role.Can(permission)
+}
+
+// AuthorizationPolicy decides whether a principal may act on a resource. Implementations
+// return a FORBIDDEN_ERROR when access is denied.
+type AuthorizationPolicy interface {
+	CanAccessAccount(principal *Principal, accountID string) error
+}
+
+// OwnershipPolicy lets customers reach only their own account, principals allowed to
+// access any account bypass the check.
+type OwnershipPolicy struct{}
+
+func NewOwnershipPolicy() *OwnershipPolicy {
+	return &OwnershipPolicy{}
+}
+
+func (o *OwnershipPolicy) CanAccessAccount(principal *Principal, accountID string) error {
+	if principal == nil {
+		return NewErrorHandler(UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	if principal.Can(ACCESS_ANY_ACCOUNT_PERMISSION) || principal.AccountID == accountID {
+		return nil
+	}
+
+	return NewErrorHandler(FORBIDDEN_ERROR).Add("account does not belong to the logged account")
+}
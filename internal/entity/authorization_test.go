@@ -0,0 +1,39 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipPolicy_CanAccessAccount(t *testing.T) {
+	policy := entity.NewOwnershipPolicy()
+
+	t.Run("Testing CanAccessAccount when account belongs to the principal", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+
+		assert.Nil(t, policy.CanAccessAccount(principal, "2bd765a6-47bd-4731-9eb2-1e65542f4477"))
+	})
+
+	t.Run("Testing CanAccessAccount when account belongs to someone else", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+
+		err := policy.CanAccessAccount(principal, "d18551d3-cf13-49ec-b1dc-741a1f8715f6")
+
+		assert.Equal(t, "account does not belong to the logged account", err.Error())
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing CanAccessAccount when principal is an admin", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.ADMIN_ROLE)
+
+		assert.Nil(t, policy.CanAccessAccount(principal, "d18551d3-cf13-49ec-b1dc-741a1f8715f6"))
+	})
+
+	t.Run("Testing CanAccessAccount without principal", func(t *testing.T) {
+		err := policy.CanAccessAccount(nil, "d18551d3-cf13-49ec-b1dc-741a1f8715f6")
+
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
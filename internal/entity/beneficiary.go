@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	BENEFICIARY_NICKNAME_MAX_LENGTH = 50
+
+	// FREQUENT_DESTINATION_MIN_TRANSFERS is how many transfers an account must have sent to a
+	// destination before it is suggested as a beneficiary.
+	FREQUENT_DESTINATION_MIN_TRANSFERS = 2
+)
+
+// Beneficiary is a recipient saved by an account, so transfers can be sent to it without
+// typing the destination account again. An account saves each destination at most once.
+type Beneficiary struct {
+	ID                   string
+	AccountID            string
+	DestinationAccountID string
+	DestinationName      string
+	Nickname             string
+	Favorite             bool
+	CreatedAt            *time.Time
+	UpdatedAt            *time.Time
+}
+
+func NewBeneficiary(ID string, accountID string, destinationAccountID string, nickname string, favorite bool, createdAt *time.Time) (*Beneficiary, error) {
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	beneficiary := &Beneficiary{
+		ID:                   ID,
+		AccountID:            accountID,
+		DestinationAccountID: destinationAccountID,
+		Nickname:             strings.TrimSpace(nickname),
+		Favorite:             favorite,
+		CreatedAt:            createdAt,
+		UpdatedAt:            createdAt,
+	}
+
+	err := beneficiary.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return beneficiary, nil
+}
+
+func (b *Beneficiary) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if b.AccountID == "" {
+		validationError.Add("account id cannot be empty")
+	}
+
+	if b.DestinationAccountID == "" {
+		validationError.Add("destination account id cannot be empty")
+	} else if b.DestinationAccountID == b.AccountID {
+		validationError.Add("account cannot save itself as a beneficiary")
+	}
+
+	if utf8.RuneCountInString(b.Nickname) > BENEFICIARY_NICKNAME_MAX_LENGTH {
+		validationError.Add(fmt.Sprintf("nickname must have at most %d characters", BENEFICIARY_NICKNAME_MAX_LENGTH))
+	}
+
+	if b.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// Change renames the beneficiary and marks or unmarks it as favorite, a nil field is kept.
+func (b *Beneficiary) Change(nickname *string, favorite *bool, updatedAt time.Time) error {
+	if nickname != nil {
+		b.Nickname = strings.TrimSpace(*nickname)
+	}
+
+	if favorite != nil {
+		b.Favorite = *favorite
+	}
+
+	err := b.isValid()
+	if err != nil {
+		return err
+	}
+
+	b.UpdatedAt = &updatedAt
+
+	return nil
+}
+
+// FrequentDestination is an account that received transfers from another account, with how
+// many transfers it received and when the last one was made.
+type FrequentDestination struct {
+	AccountID      string
+	Name           string
+	Transfers      int
+	LastTransferAt *time.Time
+}
@@ -0,0 +1,64 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeneficiary_NewBeneficiary(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing NewBeneficiary when successful", func(t *testing.T) {
+		beneficiary, err := entity.NewBeneficiary("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", " Mom ", true, &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, beneficiary.ID)
+		assert.Equal(t, "Mom", beneficiary.Nickname)
+		assert.True(t, beneficiary.Favorite)
+		assert.Equal(t, &createdAt, beneficiary.UpdatedAt)
+	})
+
+	t.Run("Testing NewBeneficiary when returning invalid fields", func(t *testing.T) {
+		beneficiary, err := entity.NewBeneficiary("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "2bd765a6-47bd-4731-9eb2-1e65542f4477", strings.Repeat("a", 51), false, nil)
+
+		assert.Nil(t, beneficiary)
+		assert.Equal(t, []string{
+			"account cannot save itself as a beneficiary",
+			"nickname must have at most 50 characters",
+			"created at cannot be nil",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestBeneficiary_Change(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	updatedAt := time.Date(2023, 8, 15, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing Change keeps the fields not informed", func(t *testing.T) {
+		beneficiary, err := entity.NewBeneficiary("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Mom", false, &createdAt)
+		assert.Nil(t, err)
+
+		favorite := true
+		err = beneficiary.Change(nil, &favorite, updatedAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Mom", beneficiary.Nickname)
+		assert.True(t, beneficiary.Favorite)
+		assert.Equal(t, &updatedAt, beneficiary.UpdatedAt)
+	})
+
+	t.Run("Testing Change when the nickname is too long", func(t *testing.T) {
+		beneficiary, err := entity.NewBeneficiary("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Mom", false, &createdAt)
+		assert.Nil(t, err)
+
+		nickname := strings.Repeat("a", 51)
+		err = beneficiary.Change(&nickname, nil, updatedAt)
+
+		assert.Equal(t, "nickname must have at most 50 characters", err.Error())
+		assert.Equal(t, &createdAt, beneficiary.UpdatedAt)
+	})
+}
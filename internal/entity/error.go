@@ -1,22 +1,28 @@
 package entity
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 type TypeError string
 
 const (
-	ENTITY_ERROR       TypeError = "error on validate entity"
-	NOT_FOUND_ERROR    TypeError = "not found"
-	INTERNAL_ERROR     TypeError = "internal error"
-	CONFLICT_ERROR     TypeError = "conflict error"
-	NOT_ALLOWED_ERROR  TypeError = "not allowed"
-	UNAUTHORIZED_ERROR TypeError = "unauthorized"
-	BAD_REQUEST        TypeError = "bad request"
+	ENTITY_ERROR            TypeError = "error on validate entity"
+	NOT_FOUND_ERROR         TypeError = "not found"
+	INTERNAL_ERROR          TypeError = "internal error"
+	CONFLICT_ERROR          TypeError = "conflict error"
+	NOT_ALLOWED_ERROR       TypeError = "not allowed"
+	UNAUTHORIZED_ERROR      TypeError = "unauthorized"
+	FORBIDDEN_ERROR         TypeError = "forbidden"
+	BAD_REQUEST             TypeError = "bad request"
+	TOO_MANY_REQUESTS_ERROR TypeError = "too many requests"
 )
 
 type ErrorHandler struct {
-	Messages  []string
-	TypeError TypeError
+	Messages   []string
+	TypeError  TypeError
+	RetryAfter time.Duration
 }
 
 func NewErrorHandler(typeError TypeError) *ErrorHandler {
@@ -30,6 +36,12 @@ func (e *ErrorHandler) Add(message string) *ErrorHandler {
 	return e
 }
 
+// WithRetryAfter tells the client how long to wait before trying again.
+func (e *ErrorHandler) WithRetryAfter(retryAfter time.Duration) *ErrorHandler {
+	e.RetryAfter = retryAfter
+	return e
+}
+
 func (e *ErrorHandler) Error() string {
 	return strings.Join(e.Messages, ", ")
 }
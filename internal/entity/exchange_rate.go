@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ExchangeRate is the amount of To received for one unit of From, as a decimal string so the
+// rate persisted with a transfer is exactly the rate used to convert it.
+type ExchangeRate struct {
+	From      Currency
+	To        Currency
+	Rate      string
+	UpdatedAt *time.Time
+}
+
+func NewExchangeRate(from Currency, to Currency, rate string, updatedAt *time.Time) (*ExchangeRate, error) {
+	exchangeRate := &ExchangeRate{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		UpdatedAt: updatedAt,
+	}
+
+	err := exchangeRate.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return exchangeRate, nil
+}
+
+// NewIdentityExchangeRate is the rate of a transfer between accounts of the same currency.
+func NewIdentityExchangeRate(currency Currency, updatedAt *time.Time) ExchangeRate {
+	return ExchangeRate{
+		From:      currency,
+		To:        currency,
+		Rate:      "1",
+		UpdatedAt: updatedAt,
+	}
+}
+
+func (e *ExchangeRate) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if !e.From.IsValid() {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", e.From))
+	}
+
+	if !e.To.IsValid() {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", e.To))
+	}
+
+	rate, ok := parseRate(e.Rate)
+	if !ok || rate.Sign() <= 0 {
+		validationError.Add(fmt.Sprintf("exchange rate is invalid: %s", e.Rate))
+	}
+
+	if e.UpdatedAt == nil {
+		validationError.Add("updated at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// parseRate only accepts plain decimals like "5.4321", big.Rat would also take fractions and exponents.
+func parseRate(rate string) (*big.Rat, bool) {
+	integerPart, fractionPart, hasFraction := strings.Cut(rate, ".")
+	if integerPart == "" || (hasFraction && fractionPart == "") || !isDigits(integerPart) || !isDigits(fractionPart) {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(rate)
+}
+
+func (e ExchangeRate) IsStale(now time.Time, maxAge time.Duration) bool {
+	return now.Sub(*e.UpdatedAt) > maxAge
+}
+
+// Convert applies the rate to an amount in the From currency. The result is rounded half away
+// from zero to the minor unit of the To currency.
+func (e ExchangeRate) Convert(amount Money) (Money, error) {
+	if amount.Currency != e.From {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("currency mismatch: %s and %s", amount.Currency, e.From))
+	}
+
+	rate, ok := parseRate(e.Rate)
+	if !ok {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("exchange rate is invalid: %s", e.Rate))
+	}
+
+	// the minor units of both currencies differ when their exponents differ, like BRL and JPY
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt64(amount.Amount), rate)
+	exponentDifference := e.To.Exponent() - e.From.Exponent()
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exponentDifference))), nil))
+	if exponentDifference >= 0 {
+		converted.Mul(converted, scale)
+	} else {
+		converted.Quo(converted, scale)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(converted.Num(), converted.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(converted.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(converted.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add("amount overflow")
+	}
+
+	return NewMoney(quotient.Int64(), e.To), nil
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,92 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRate_NewExchangeRate(t *testing.T) {
+	t.Run("Testing NewExchangeRate when returning a valid exchange rate", func(t *testing.T) {
+		updatedAt := time.Date(2023, 8, 5, 8, 00, 00, 00, time.UTC)
+		exchangeRate, err := entity.NewExchangeRate(entity.USD, entity.BRL, "4.9512", &updatedAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.USD, exchangeRate.From)
+		assert.Equal(t, entity.BRL, exchangeRate.To)
+		assert.Equal(t, "4.9512", exchangeRate.Rate)
+	})
+
+	t.Run("Testing NewExchangeRate when fields are invalid", func(t *testing.T) {
+		exchangeRate, err := entity.NewExchangeRate("XYZ", entity.BRL, "0", nil)
+
+		assert.Nil(t, exchangeRate)
+		assert.Equal(t, []string{"currency is invalid: XYZ", "exchange rate is invalid: 0", "updated at cannot be nil"}, err.(*entity.ErrorHandler).Messages)
+	})
+
+	for _, rate := range []string{"", "-1", "1/3", "1e3", "4,95", ".5"} {
+		t.Run("Testing NewExchangeRate with invalid rate "+rate, func(t *testing.T) {
+			updatedAt := time.Date(2023, 8, 5, 8, 00, 00, 00, time.UTC)
+			_, err := entity.NewExchangeRate(entity.USD, entity.BRL, rate, &updatedAt)
+
+			assert.Equal(t, "exchange rate is invalid: "+rate, err.Error())
+		})
+	}
+}
+
+func TestExchangeRate_IsStale(t *testing.T) {
+	t.Run("Testing IsStale", func(t *testing.T) {
+		updatedAt := time.Date(2023, 8, 5, 8, 00, 00, 00, time.UTC)
+		exchangeRate, err := entity.NewExchangeRate(entity.USD, entity.BRL, "4.95", &updatedAt)
+		assert.Nil(t, err)
+
+		assert.False(t, exchangeRate.IsStale(updatedAt.Add(time.Hour), time.Hour))
+		assert.True(t, exchangeRate.IsStale(updatedAt.Add(time.Hour+time.Second), time.Hour))
+	})
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	updatedAt := time.Date(2023, 8, 5, 8, 00, 00, 00, time.UTC)
+
+	t.Run("Testing Convert rounds half away from zero", func(t *testing.T) {
+		exchangeRate, _ := entity.NewExchangeRate(entity.USD, entity.BRL, "4.955", &updatedAt)
+
+		converted, err := exchangeRate.Convert(entity.NewMoney(100, entity.USD))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(496, entity.BRL), converted)
+
+		converted, err = exchangeRate.Convert(entity.NewMoney(-100, entity.USD))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-496, entity.BRL), converted)
+	})
+
+	t.Run("Testing Convert between currencies with different minor units", func(t *testing.T) {
+		exchangeRate, _ := entity.NewExchangeRate(entity.USD, entity.JPY, "149.5", &updatedAt)
+
+		converted, err := exchangeRate.Convert(entity.NewMoney(1050, entity.USD))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1570, entity.JPY), converted)
+
+		exchangeRate, _ = entity.NewExchangeRate(entity.JPY, entity.BRL, "0.0331", &updatedAt)
+
+		converted, err = exchangeRate.Convert(entity.NewMoney(1000, entity.JPY))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(3310, entity.BRL), converted)
+	})
+
+	t.Run("Testing Convert when the amount is in another currency", func(t *testing.T) {
+		exchangeRate, _ := entity.NewExchangeRate(entity.USD, entity.BRL, "4.95", &updatedAt)
+
+		_, err := exchangeRate.Convert(entity.NewMoney(100, entity.EUR))
+		assert.Equal(t, "currency mismatch: EUR and USD", err.Error())
+	})
+
+	t.Run("Testing Convert when the result overflows", func(t *testing.T) {
+		exchangeRate, _ := entity.NewExchangeRate(entity.JPY, entity.BRL, "2", &updatedAt)
+
+		_, err := exchangeRate.Convert(entity.NewMoney(1<<62, entity.JPY))
+		assert.Equal(t, "amount overflow", err.Error())
+	})
+}
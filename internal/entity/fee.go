@@ -0,0 +1,263 @@
+package entity
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type FeeType string
+
+const (
+	FLAT_FEE       FeeType = "FLAT"
+	PERCENTAGE_FEE FeeType = "PERCENTAGE"
+	TIERED_FEE     FeeType = "TIERED"
+)
+
+// FeeRule prices the transfers in Currency sent by the accounts of AccountRole, or by any
+// account when it is empty. A FLAT rule charges Amount, a PERCENTAGE rule charges Rate of the
+// transfer amount kept between Minimum and Maximum when they are not zero, and a TIERED rule
+// charges the first tier the transfer amount fits in. The first FreePerMonth transfers an
+// account sends in a calendar month are not charged by the rule.
+type FeeRule struct {
+	Name         string
+	Type         FeeType
+	Currency     Currency
+	AccountRole  Role
+	Amount       Money
+	Rate         string
+	Minimum      Money
+	Maximum      Money
+	Tiers        []FeeTier
+	FreePerMonth int
+}
+
+// FeeTier charges Amount plus Rate, when set, of the transfer amount to the transfers up to
+// UpTo. The last tier has no UpTo and prices every amount above the other tiers.
+type FeeTier struct {
+	UpTo   *Money
+	Amount Money
+	Rate   string
+}
+
+// TransferFee is the part of the fee of a transfer charged by one rule. A Waived fee is within
+// the free quota of the rule and charges nothing.
+type TransferFee struct {
+	Rule   string
+	Type   FeeType
+	Amount Money
+	Waived bool
+}
+
+// FeeSchedule is every fee rule of the bank, a transfer no rule applies to is free.
+type FeeSchedule struct {
+	Rules []FeeRule
+}
+
+func NewFeeSchedule(rules ...FeeRule) (*FeeSchedule, error) {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if names[rule.Name] {
+			validationError.Add(fmt.Sprintf("fee rule %s is duplicated", rule.Name))
+		}
+		names[rule.Name] = true
+
+		if err := rule.isValid(); err != nil {
+			validationError.Messages = append(validationError.Messages, err.(*ErrorHandler).Messages...)
+		}
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	return &FeeSchedule{Rules: rules}, nil
+}
+
+func (r FeeRule) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if r.Name == "" {
+		return validationError.Add("fee rule name cannot be empty")
+	}
+
+	invalid := func(message string) {
+		validationError.Add(fmt.Sprintf("fee rule %s: %s", r.Name, message))
+	}
+
+	if !r.Currency.IsValid() {
+		invalid(fmt.Sprintf("currency is invalid: %s", r.Currency))
+	}
+
+	if r.AccountRole != "" {
+		if _, err := NewRole(string(r.AccountRole)); err != nil {
+			invalid(err.Error())
+		}
+	}
+
+	if r.FreePerMonth < 0 {
+		invalid("free transfers per month cannot be negative")
+	}
+
+	switch r.Type {
+	case FLAT_FEE:
+		if !r.Amount.IsPositive() || r.Amount.Currency != r.Currency {
+			invalid(fmt.Sprintf("amount must be greater than zero in %s", r.Currency))
+		}
+	case PERCENTAGE_FEE:
+		if rate, ok := parseRate(r.Rate); !ok || rate.Sign() <= 0 {
+			invalid(fmt.Sprintf("rate is invalid: %s", r.Rate))
+		}
+
+		for _, bound := range []Money{r.Minimum, r.Maximum} {
+			if bound.IsNegative() || (!bound.IsZero() && bound.Currency != r.Currency) {
+				invalid(fmt.Sprintf("minimum and maximum cannot be negative and must be in %s", r.Currency))
+				break
+			}
+		}
+
+		if r.Minimum.IsPositive() && r.Maximum.IsPositive() && r.Minimum.Amount > r.Maximum.Amount {
+			invalid("minimum cannot be greater than the maximum")
+		}
+	case TIERED_FEE:
+		if len(r.Tiers) == 0 {
+			invalid("tiers cannot be empty")
+		}
+
+		for i, tier := range r.Tiers {
+			last := i == len(r.Tiers)-1
+
+			if tier.Amount.IsNegative() || (!tier.Amount.IsZero() && tier.Amount.Currency != r.Currency) {
+				invalid(fmt.Sprintf("tier %d: amount cannot be negative and must be in %s", i, r.Currency))
+			}
+
+			if rate, ok := parseRate(tier.Rate); tier.Rate != "" && (!ok || rate.Sign() < 0) {
+				invalid(fmt.Sprintf("tier %d: rate is invalid: %s", i, tier.Rate))
+			}
+
+			if last && tier.UpTo != nil {
+				invalid("the last tier cannot have an upper bound")
+			}
+
+			if !last && (tier.UpTo == nil || !tier.UpTo.IsPositive() || tier.UpTo.Currency != r.Currency) {
+				invalid(fmt.Sprintf("tier %d: upper bound must be greater than zero in %s", i, r.Currency))
+			} else if !last && i > 0 && r.Tiers[i-1].UpTo != nil && tier.UpTo.Amount <= r.Tiers[i-1].UpTo.Amount {
+				invalid(fmt.Sprintf("tier %d: upper bound must be greater than the bound of the previous tier", i))
+			}
+		}
+	default:
+		invalid(fmt.Sprintf("type is invalid: %s", r.Type))
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// RulesFor returns the rules that price the transfer, in the order of the schedule.
+func (s *FeeSchedule) RulesFor(transfer *Transfer) []FeeRule {
+	rules := []FeeRule{}
+
+	for _, rule := range s.Rules {
+		if rule.Currency != transfer.Amount.Currency {
+			continue
+		}
+
+		if rule.AccountRole != "" && rule.AccountRole != transfer.OriginAccount.Role {
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// Price returns the fee the rule charges to a transfer of the amount, sent is how many
+// transfers the account already sent in the month.
+func (r FeeRule) Price(amount Money, sent int) (TransferFee, error) {
+	if amount.Currency != r.Currency {
+		return TransferFee{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("fee rule %s prices transfers in %s, the amount is in %s", r.Name, r.Currency, amount.Currency))
+	}
+
+	if sent < r.FreePerMonth {
+		return TransferFee{Rule: r.Name, Type: r.Type, Amount: NewMoney(0, r.Currency), Waived: true}, nil
+	}
+
+	var fee Money
+	var err error
+
+	switch r.Type {
+	case FLAT_FEE:
+		fee = r.Amount
+	case PERCENTAGE_FEE:
+		fee, err = charge(amount, NewMoney(0, r.Currency), r.Rate)
+		if err != nil {
+			return TransferFee{}, err
+		}
+
+		if r.Minimum.IsPositive() && fee.Amount < r.Minimum.Amount {
+			fee = r.Minimum
+		}
+
+		if r.Maximum.IsPositive() && fee.Amount > r.Maximum.Amount {
+			fee = r.Maximum
+		}
+	case TIERED_FEE:
+		for _, tier := range r.Tiers {
+			if tier.UpTo != nil && amount.Amount > tier.UpTo.Amount {
+				continue
+			}
+
+			fee, err = charge(amount, NewMoney(tier.Amount.Amount, r.Currency), tier.Rate)
+			if err != nil {
+				return TransferFee{}, err
+			}
+			break
+		}
+	default:
+		return TransferFee{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("fee rule %s: type is invalid: %s", r.Name, r.Type))
+	}
+
+	return TransferFee{Rule: r.Name, Type: r.Type, Amount: fee}, nil
+}
+
+// charge adds the rate of the amount, rounded half away from zero to the minor unit of its
+// currency, to the flat amount. An empty rate charges only the flat amount.
+func charge(amount Money, flat Money, rate string) (Money, error) {
+	if rate == "" {
+		return flat, nil
+	}
+
+	parsedRate, ok := parseRate(rate)
+	if !ok {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("fee rate is invalid: %s", rate))
+	}
+
+	percentage := new(big.Rat).Mul(new(big.Rat).SetInt64(amount.Amount), parsedRate)
+
+	quotient, remainder := new(big.Int).QuoRem(percentage.Num(), percentage.Denom(), new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(percentage.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add("amount overflow")
+	}
+
+	return flat.Add(NewMoney(quotient.Int64(), amount.Currency))
+}
+
+// HasFreeQuota tells whether any of the rules waives the first transfers of the month.
+func HasFreeQuota(rules []FeeRule) bool {
+	for _, rule := range rules {
+		if rule.FreePerMonth > 0 {
+			return true
+		}
+	}
+
+	return false
+}
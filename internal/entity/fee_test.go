@@ -0,0 +1,150 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetTieredFeeRule() entity.FeeRule {
+	upTo := entity.NewMoney(10000, entity.BRL)
+
+	return entity.FeeRule{
+		Name:     "tiered",
+		Type:     entity.TIERED_FEE,
+		Currency: entity.BRL,
+		Tiers: []entity.FeeTier{
+			{UpTo: &upTo, Amount: entity.NewMoney(50, entity.BRL)},
+			{Amount: entity.NewMoney(100, entity.BRL), Rate: "0.001"},
+		},
+	}
+}
+
+func TestFee_NewFeeSchedule(t *testing.T) {
+	t.Run("Testing NewFeeSchedule with every type of rule", func(t *testing.T) {
+		schedule, err := entity.NewFeeSchedule(
+			entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL), FreePerMonth: 5},
+			entity.FeeRule{Name: "percentage", Type: entity.PERCENTAGE_FEE, Currency: entity.BRL, AccountRole: entity.CUSTOMER_ROLE, Rate: "0.005", Maximum: entity.NewMoney(1000, entity.BRL)},
+			GetTieredFeeRule(),
+		)
+
+		assert.Nil(t, err)
+		assert.Len(t, schedule.Rules, 3)
+	})
+
+	t.Run("Testing NewFeeSchedule when returning invalid rules", func(t *testing.T) {
+		upTo := entity.NewMoney(100, entity.BRL)
+
+		_, err := entity.NewFeeSchedule(
+			entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.USD), FreePerMonth: -1},
+			entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL)},
+			entity.FeeRule{Name: "percentage", Type: entity.PERCENTAGE_FEE, Currency: entity.BRL, AccountRole: "MANAGER", Rate: "5%", Minimum: entity.NewMoney(200, entity.BRL), Maximum: entity.NewMoney(100, entity.BRL)},
+			entity.FeeRule{Name: "tiered", Type: entity.TIERED_FEE, Currency: entity.BRL, Tiers: []entity.FeeTier{{Amount: entity.NewMoney(50, entity.BRL)}, {UpTo: &upTo}}},
+			entity.FeeRule{Name: "unknown", Type: "MONTHLY", Currency: "ABC"},
+			entity.FeeRule{},
+		)
+
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"fee rule flat: free transfers per month cannot be negative",
+			"fee rule flat: amount must be greater than zero in BRL",
+			"fee rule flat is duplicated",
+			"fee rule percentage: role is invalid: MANAGER",
+			"fee rule percentage: rate is invalid: 5%",
+			"fee rule percentage: minimum cannot be greater than the maximum",
+			"fee rule tiered: tier 0: upper bound must be greater than zero in BRL",
+			"fee rule tiered: the last tier cannot have an upper bound",
+			"fee rule unknown: currency is invalid: ABC",
+			"fee rule unknown: type is invalid: MONTHLY",
+			"fee rule name cannot be empty",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestFee_Price(t *testing.T) {
+	t.Run("Testing Price of a flat rule", func(t *testing.T) {
+		rule := entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL)}
+
+		fee, err := rule.Price(entity.NewMoney(5000, entity.BRL), 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TransferFee{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(100, entity.BRL)}, fee)
+	})
+
+	t.Run("Testing Price of a percentage rule rounds half away from zero within the bounds", func(t *testing.T) {
+		rule := entity.FeeRule{Name: "percentage", Type: entity.PERCENTAGE_FEE, Currency: entity.BRL, Rate: "0.005", Minimum: entity.NewMoney(50, entity.BRL), Maximum: entity.NewMoney(1000, entity.BRL)}
+
+		for amount, expected := range map[int64]int64{
+			30000:   150,
+			30100:   151,
+			100:     50,
+			1000000: 1000,
+		} {
+			fee, err := rule.Price(entity.NewMoney(amount, entity.BRL), 0)
+
+			assert.Nil(t, err)
+			assert.Equal(t, entity.NewMoney(expected, entity.BRL), fee.Amount)
+		}
+	})
+
+	t.Run("Testing Price of a tiered rule charges the tier of the amount", func(t *testing.T) {
+		rule := GetTieredFeeRule()
+
+		for amount, expected := range map[int64]int64{
+			5000:   50,
+			10000:  50,
+			10001:  110,
+			500000: 600,
+		} {
+			fee, err := rule.Price(entity.NewMoney(amount, entity.BRL), 0)
+
+			assert.Nil(t, err)
+			assert.Equal(t, entity.NewMoney(expected, entity.BRL), fee.Amount)
+		}
+	})
+
+	t.Run("Testing Price waives the transfers within the free quota", func(t *testing.T) {
+		rule := entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL), FreePerMonth: 3}
+
+		fee, err := rule.Price(entity.NewMoney(5000, entity.BRL), 2)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TransferFee{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(0, entity.BRL), Waived: true}, fee)
+
+		fee, err = rule.Price(entity.NewMoney(5000, entity.BRL), 3)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), fee.Amount)
+		assert.False(t, fee.Waived)
+	})
+
+	t.Run("Testing Price when the amount is in another currency", func(t *testing.T) {
+		rule := entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL)}
+
+		_, err := rule.Price(entity.NewMoney(5000, entity.USD), 0)
+
+		assert.Equal(t, "fee rule flat prices transfers in BRL, the amount is in USD", err.Error())
+	})
+}
+
+func TestFee_RulesFor(t *testing.T) {
+	t.Run("Testing RulesFor returns the rules of the currency and the role of the origin account", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		schedule, err := entity.NewFeeSchedule(
+			entity.FeeRule{Name: "brl", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL)},
+			entity.FeeRule{Name: "usd", Type: entity.FLAT_FEE, Currency: entity.USD, Amount: entity.NewMoney(100, entity.USD)},
+			entity.FeeRule{Name: "admin", Type: entity.FLAT_FEE, Currency: entity.BRL, AccountRole: entity.ADMIN_ROLE, Amount: entity.NewMoney(100, entity.BRL)},
+		)
+		assert.Nil(t, err)
+
+		transfer, err := entity.NewTransfer("", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		rules := schedule.RulesFor(transfer)
+
+		assert.Len(t, rules, 1)
+		assert.Equal(t, "brl", rules[0].Name)
+		assert.False(t, entity.HasFreeQuota(rules))
+	})
+}
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type HoldStatus string
+
+const (
+	ACTIVE_HOLD   HoldStatus = "ACTIVE"
+	CAPTURED_HOLD HoldStatus = "CAPTURED"
+	RELEASED_HOLD HoldStatus = "RELEASED"
+	EXPIRED_HOLD  HoldStatus = "EXPIRED"
+)
+
+// Hold reserves Amount of the available balance of the origin account for a later transfer
+// to the destination account. While ACTIVE the money stays in the balance but cannot be
+// spent, capturing moves all or part of it with a transfer and gives back the rest, releasing
+// or expiring gives it all back. CAPTURED, RELEASED and EXPIRED are final.
+type Hold struct {
+	ID                 string
+	OriginAccount      *Account
+	DestinationAccount *Account
+	Amount             Money
+	CapturedAmount     Money
+	TransferID         string
+	Status             HoldStatus
+	ExpiresAt          *time.Time
+	CreatedAt          *time.Time
+	UpdatedAt          *time.Time
+}
+
+func NewHold(ID string, originAccount *Account, destinationAccount *Account, amount Money, expiresAt *time.Time, createdAt *time.Time) (*Hold, error) {
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	hold := &Hold{
+		ID:                 ID,
+		OriginAccount:      originAccount,
+		DestinationAccount: destinationAccount,
+		Amount:             amount,
+		CapturedAmount:     NewMoney(0, amount.Currency),
+		Status:             ACTIVE_HOLD,
+		ExpiresAt:          expiresAt,
+		CreatedAt:          createdAt,
+		UpdatedAt:          createdAt,
+	}
+
+	err := hold.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	err = hold.OriginAccount.placeHold(hold.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (h *Hold) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if h.OriginAccount == nil {
+		validationError.Add("originAccount cannot be nil")
+	}
+
+	if h.DestinationAccount == nil {
+		validationError.Add("destinationAccount cannot be nil")
+	}
+
+	if h.OriginAccount != nil && h.DestinationAccount != nil && h.OriginAccount.ID == h.DestinationAccount.ID {
+		validationError.Add("origin account id must be different to destination account id")
+	}
+
+	if !h.Amount.IsPositive() {
+		validationError.Add("amount must be greater than zero")
+	}
+
+	if h.OriginAccount != nil && h.Amount.Currency != h.OriginAccount.Currency() {
+		validationError.Add(fmt.Sprintf("hold currency %s must be the currency of the origin account", h.Amount.Currency))
+	}
+
+	if h.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if h.ExpiresAt == nil {
+		validationError.Add("expires at cannot be nil")
+	} else if h.CreatedAt != nil && !h.ExpiresAt.After(*h.CreatedAt) {
+		validationError.Add("expires at must be in the future")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// IsExpired tells whether an active hold passed its expiry at now.
+func (h *Hold) IsExpired(now time.Time) bool {
+	return h.Status == ACTIVE_HOLD && !h.ExpiresAt.After(now)
+}
+
+// Capture takes amount of the hold, up to all of it, and gives back the rest to the origin
+// account. The caller moves the captured amount with a transfer and links it with
+// LinkTransfer.
+func (h *Hold) Capture(amount Money, now time.Time) error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	err := h.checkActive("captured", now)
+	if err != nil {
+		return err
+	}
+
+	if !amount.IsPositive() {
+		return validationError.Add("capture amount must be greater than zero")
+	}
+
+	comparison, err := h.Amount.Compare(amount)
+	if err != nil {
+		return validationError.Add(fmt.Sprintf("capture amount must be in %s", h.Amount.Currency))
+	}
+
+	if comparison < 0 {
+		return validationError.Add(fmt.Sprintf("capture amount cannot be greater than the held amount of %s %s", h.Amount, h.Amount.Currency))
+	}
+
+	h.finish(CAPTURED_HOLD, now)
+	h.CapturedAmount = amount
+
+	return nil
+}
+
+// LinkTransfer records the transfer that moved the captured amount.
+func (h *Hold) LinkTransfer(transfer *Transfer) {
+	h.TransferID = transfer.ID
+}
+
+// Release gives back the whole hold to the origin account.
+func (h *Hold) Release(now time.Time) error {
+	err := h.checkActive("released", now)
+	if err != nil {
+		return err
+	}
+
+	h.finish(RELEASED_HOLD, now)
+	return nil
+}
+
+// Expire gives back a hold that passed its expiry without being captured or released.
+func (h *Hold) Expire(now time.Time) error {
+	if !h.IsExpired(now) {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("hold is %s and cannot expire before %s", h.Status, h.ExpiresAt.Format(time.RFC3339)))
+	}
+
+	h.finish(EXPIRED_HOLD, now)
+	return nil
+}
+
+// checkActive refuses to change a hold that is final or already expired, even when the
+// sweeper did not mark it as EXPIRED yet.
+func (h *Hold) checkActive(action string, now time.Time) error {
+	if h.Status != ACTIVE_HOLD {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("hold is %s and cannot be %s", h.Status, action))
+	}
+
+	if h.IsExpired(now) {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("hold expired at %s and cannot be %s", h.ExpiresAt.Format(time.RFC3339), action))
+	}
+
+	return nil
+}
+
+func (h *Hold) finish(status HoldStatus, now time.Time) {
+	h.OriginAccount.releaseHold(h.Amount)
+	h.Status = status
+	h.UpdatedAt = &now
+}
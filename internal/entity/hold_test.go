@@ -0,0 +1,143 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHold_NewHold(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+
+	t.Run("Testing NewHold reserves the amount without changing the balance", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+
+		hold, err := entity.NewHold("", originAccount, GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, hold.ID)
+		assert.Equal(t, entity.ACTIVE_HOLD, hold.Status)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), hold.CapturedAmount)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), originAccount.Balance)
+		assert.Equal(t, entity.NewMoney(60, entity.BRL), originAccount.Held)
+		assert.Equal(t, entity.NewMoney(40, entity.BRL), originAccount.AvailableBalance())
+	})
+
+	t.Run("Testing NewHold when amount is greater than the available balance", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+
+		_, err := entity.NewHold("", originAccount, GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		hold, err := entity.NewHold("", originAccount, GetBaseDestinationAccount(t), entity.NewMoney(41, entity.BRL), &expiresAt, &createdAt)
+
+		assert.Nil(t, hold)
+		assert.Equal(t, "insufficient available balance for hold", err.Error())
+		assert.Equal(t, entity.NewMoney(60, entity.BRL), originAccount.Held)
+	})
+
+	t.Run("Testing NewHold when returning an invalid hold", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+
+		hold, err := entity.NewHold("", originAccount, originAccount, entity.NewMoney(0, entity.USD), &createdAt, &createdAt)
+
+		assert.Nil(t, hold)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"origin account id must be different to destination account id",
+			"amount must be greater than zero",
+			"hold currency USD must be the currency of the origin account",
+			"expires at must be in the future",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestHold_Capture(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	now := createdAt.Add(time.Hour)
+
+	t.Run("Testing Capture takes part of the hold and gives back the rest", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		hold, err := entity.NewHold("", originAccount, GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = hold.Capture(entity.NewMoney(45, entity.BRL), now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CAPTURED_HOLD, hold.Status)
+		assert.Equal(t, entity.NewMoney(45, entity.BRL), hold.CapturedAmount)
+		assert.Equal(t, &now, hold.UpdatedAt)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), originAccount.Held)
+	})
+
+	t.Run("Testing Capture when amount is greater than the hold", func(t *testing.T) {
+		hold, err := entity.NewHold("", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = hold.Capture(entity.NewMoney(61, entity.BRL), now)
+
+		assert.Equal(t, "capture amount cannot be greater than the held amount of 0.60 BRL", err.Error())
+		assert.Equal(t, entity.ACTIVE_HOLD, hold.Status)
+	})
+
+	t.Run("Testing Capture when the hold is expired", func(t *testing.T) {
+		hold, err := entity.NewHold("", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = hold.Capture(entity.NewMoney(60, entity.BRL), expiresAt)
+
+		assert.Equal(t, "hold expired at 2023-08-15T10:00:00Z and cannot be captured", err.Error())
+	})
+
+	t.Run("Testing Capture when the hold was released", func(t *testing.T) {
+		hold, err := entity.NewHold("", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, hold.Release(now))
+
+		err = hold.Capture(entity.NewMoney(60, entity.BRL), now)
+
+		assert.Equal(t, "hold is RELEASED and cannot be captured", err.Error())
+	})
+}
+
+func TestHold_ReleaseAndExpire(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+
+	t.Run("Testing Release gives back the whole hold", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		hold, err := entity.NewHold("", originAccount, GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = hold.Release(createdAt.Add(time.Hour))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.RELEASED_HOLD, hold.Status)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), originAccount.AvailableBalance())
+	})
+
+	t.Run("Testing Expire gives back a stale hold", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		hold, err := entity.NewHold("", originAccount, GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = hold.Expire(expiresAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.EXPIRED_HOLD, hold.Status)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), originAccount.Held)
+	})
+
+	t.Run("Testing Expire before the expiry", func(t *testing.T) {
+		hold, err := entity.NewHold("", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(60, entity.BRL), &expiresAt, &createdAt)
+		assert.Nil(t, err)
+
+		err = hold.Expire(createdAt.Add(time.Hour))
+
+		assert.Equal(t, "hold is ACTIVE and cannot expire before 2023-08-15T10:00:00Z", err.Error())
+	})
+}
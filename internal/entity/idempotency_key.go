@@ -0,0 +1,84 @@
+package entity
+
+import "time"
+
+type IdempotencyKey struct {
+	Key         string
+	AccountID   string
+	Fingerprint string
+	StatusCode  int
+	Response    string
+	CreatedAt   *time.Time
+	ExpiresAt   *time.Time
+}
+
+func NewIdempotencyKey(key string, accountID string, fingerprint string, createdAt *time.Time, retention time.Duration) (*IdempotencyKey, error) {
+
+	idempotencyKey := &IdempotencyKey{
+		Key:         key,
+		AccountID:   accountID,
+		Fingerprint: fingerprint,
+		CreatedAt:   createdAt,
+	}
+
+	if createdAt != nil {
+		expiresAt := createdAt.Add(retention)
+		idempotencyKey.ExpiresAt = &expiresAt
+	}
+
+	err := idempotencyKey.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return idempotencyKey, nil
+}
+
+func (i *IdempotencyKey) isValid() error {
+	validationError := NewErrorHandler(BAD_REQUEST)
+
+	if i.Key == "" {
+		validationError.Add("idempotency key cannot be empty")
+	} else if len(i.Key) > 100 {
+		validationError.Add("idempotency key cannot be longer than 100 characters")
+	}
+
+	if i.AccountID == "" {
+		validationError.Add("account id cannot be empty")
+	}
+
+	if i.Fingerprint == "" {
+		validationError.Add("fingerprint cannot be empty")
+	}
+
+	if i.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	} else if !i.ExpiresAt.After(*i.CreatedAt) {
+		validationError.Add("retention must be greater than zero")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// Matches reports whether a replayed request carries the same payload as the original one.
+func (i *IdempotencyKey) Matches(fingerprint string) bool {
+	return i.Fingerprint == fingerprint
+}
+
+// IsCompleted is false while the original request is still in flight.
+func (i *IdempotencyKey) IsCompleted() bool {
+	return i.StatusCode != 0
+}
+
+func (i *IdempotencyKey) IsExpired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+func (i *IdempotencyKey) Complete(statusCode int, response string) {
+	i.StatusCode = statusCode
+	i.Response = response
+}
@@ -0,0 +1,83 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKey_NewIdempotencyKey(t *testing.T) {
+	t.Run("Testing NewIdempotencyKey when returning a valid key", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		idempotencyKey, err := entity.NewIdempotencyKey("0b8b418c", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, time.Hour)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, idempotencyKey)
+		assert.Equal(t, "0b8b418c", idempotencyKey.Key)
+		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", idempotencyKey.AccountID)
+		assert.Equal(t, createdAt.Add(time.Hour), *idempotencyKey.ExpiresAt)
+		assert.False(t, idempotencyKey.IsCompleted())
+	})
+
+	t.Run("Testing NewIdempotencyKey when returning an invalid key (empty fields)", func(t *testing.T) {
+		idempotencyKey, err := entity.NewIdempotencyKey("", "", "", nil, time.Hour)
+
+		assert.Nil(t, idempotencyKey)
+		assert.NotNil(t, err)
+		assert.Equal(t, "idempotency key cannot be empty, account id cannot be empty, fingerprint cannot be empty, created at cannot be nil", err.Error())
+	})
+
+	t.Run("Testing NewIdempotencyKey when returning an invalid key (too long key)", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+		key := "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d0b8b418c-da4a-4856-8b6a-eec63d6c7a6d0b8b418c-da4a-4856-8b6a-eec63d6c7a6d"
+
+		idempotencyKey, err := entity.NewIdempotencyKey(key, "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, time.Hour)
+
+		assert.Nil(t, idempotencyKey)
+		assert.NotNil(t, err)
+		assert.Equal(t, "idempotency key cannot be longer than 100 characters", err.Error())
+	})
+
+	t.Run("Testing NewIdempotencyKey when returning an invalid key (retention is zero)", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		idempotencyKey, err := entity.NewIdempotencyKey("0b8b418c", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, 0)
+
+		assert.Nil(t, idempotencyKey)
+		assert.NotNil(t, err)
+		assert.Equal(t, "retention must be greater than zero", err.Error())
+	})
+}
+
+func TestIdempotencyKey_Matches(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	idempotencyKey, err := entity.NewIdempotencyKey("0b8b418c", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, time.Hour)
+	assert.Nil(t, err)
+
+	assert.True(t, idempotencyKey.Matches("fingerprint"))
+	assert.False(t, idempotencyKey.Matches("another fingerprint"))
+}
+
+func TestIdempotencyKey_IsExpired(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	idempotencyKey, err := entity.NewIdempotencyKey("0b8b418c", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, time.Hour)
+	assert.Nil(t, err)
+
+	assert.False(t, idempotencyKey.IsExpired(createdAt.Add(59*time.Minute)))
+	assert.True(t, idempotencyKey.IsExpired(createdAt.Add(time.Hour)))
+}
+
+func TestIdempotencyKey_Complete(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	idempotencyKey, err := entity.NewIdempotencyKey("0b8b418c", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, time.Hour)
+	assert.Nil(t, err)
+
+	idempotencyKey.Complete(201, `{"id":"fc84682a"}`)
+
+	assert.True(t, idempotencyKey.IsCompleted())
+	assert.Equal(t, 201, idempotencyKey.StatusCode)
+	assert.Equal(t, `{"id":"fc84682a"}`, idempotencyKey.Response)
+}
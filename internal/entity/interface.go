@@ -3,19 +3,208 @@ package entity
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type AccountRepository interface {
-	Find(ctx context.Context, limit, offset int) ([]Account, error)
+	Find(ctx context.Context, page Pagination) ([]Account, error)
 	FindByID(ctx context.Context, ID string) (Account, error)
-	Create(ctx context.Context, account *Account) (Account, error)
-	UpdateBalance(ctx context.Context, accountID string, newBalance int, tx ...TransactionHandler) (Account, error)
+	FindByIDForUpdate(ctx context.Context, ID string, tx ...TransactionHandler) (Account, error)
+	FindByIDs(ctx context.Context, IDs []string) ([]Account, error)
+	Create(ctx context.Context, account *Account, tx ...TransactionHandler) (Account, error)
 	FindByCPF(ctx context.Context, CPF string) (Account, error)
+	UpdateRole(ctx context.Context, ID string, role Role) error
+	UpdateSecret(ctx context.Context, ID string, secret string, tx ...TransactionHandler) error
+	UpdateCreditLimit(ctx context.Context, ID string, creditLimit Money, tx ...TransactionHandler) error
 }
 
 type TransferRepository interface {
-	FindByAccountID(ctx context.Context, AccountID string, limit, offset int) ([]Transfer, error)
+	FindByAccountID(ctx context.Context, AccountID string, filter TransferFilter, page Pagination) ([]Transfer, error)
 	Create(ctx context.Context, transfer *Transfer, tx ...TransactionHandler) (Transfer, error)
+	FindByID(ctx context.Context, ID string) (Transfer, error)
+	FindByIDForUpdate(ctx context.Context, ID string, tx ...TransactionHandler) (Transfer, error)
+	UpdateReversedAmount(ctx context.Context, transfer *Transfer, tx ...TransactionHandler) error
+	// UpdateStatus saves the status of the transfer and adds its last change to the history.
+	UpdateStatus(ctx context.Context, transfer *Transfer, tx ...TransactionHandler) error
+	// SumSent adds up what the account sent since the given time, in the currency of the
+	// account. Only transfers that were completed count, reversals are left out.
+	SumSent(ctx context.Context, accountID string, currency Currency, since time.Time, tx ...TransactionHandler) (Money, error)
+	// CountSent counts the transfers the account sent since the given time, the same transfers
+	// SumSent adds up.
+	CountSent(ctx context.Context, accountID string, since time.Time, tx ...TransactionHandler) (int, error)
+	// FindFrequentDestinations lists the accounts that received at least
+	// FREQUENT_DESTINATION_MIN_TRANSFERS of the transfers SumSent adds up since the given time,
+	// the ones that received more transfers first.
+	FindFrequentDestinations(ctx context.Context, accountID string, since time.Time, limit int) ([]FrequentDestination, error)
+}
+
+type TransferLimitRepository interface {
+	FindByAccountID(ctx context.Context, accountID string, tx ...TransactionHandler) (TransferLimit, error)
+	Save(ctx context.Context, transferLimit *TransferLimit) error
+}
+
+type OverdraftInterestRepository interface {
+	// FindOverdrawnAccountIDs lists, in id order after the given id, the overdrawn accounts not
+	// charged yet for the accrual date.
+	FindOverdrawnAccountIDs(ctx context.Context, accrualDate string, after string, limit int) ([]string, error)
+	// Create records the interest, it fails with a conflict when the account was already
+	// charged for the accrual date.
+	Create(ctx context.Context, interest *OverdraftInterest, tx ...TransactionHandler) error
+}
+
+type HoldRepository interface {
+	// Create saves the hold and adds its amount to the funds held on the origin account.
+	Create(ctx context.Context, hold *Hold, tx ...TransactionHandler) error
+	FindByID(ctx context.Context, ID string) (Hold, error)
+	FindByIDForUpdate(ctx context.Context, ID string, tx ...TransactionHandler) (Hold, error)
+	// Finish saves a hold that was captured, released or expired and takes its amount out of
+	// the funds held on the origin account.
+	Finish(ctx context.Context, hold *Hold, tx ...TransactionHandler) error
+	// FindExpiredIDs lists the holds still active whose expiry is at or before now, the ones
+	// that expired first come first.
+	FindExpiredIDs(ctx context.Context, now time.Time, limit int) ([]string, error)
+}
+
+type TransferBatchRepository interface {
+	// Create saves the batch along with its items.
+	Create(ctx context.Context, batch *TransferBatch, tx ...TransactionHandler) error
+	FindByID(ctx context.Context, ID string) (TransferBatch, error)
+	// FindPendingIDs lists the batches waiting to be processed, the oldest first.
+	FindPendingIDs(ctx context.Context, limit int) ([]string, error)
+	// FindStuckIDs lists the batches still processing that were started before startedBefore,
+	// the worker that claimed them stopped without finishing them.
+	FindStuckIDs(ctx context.Context, startedBefore time.Time, limit int) ([]string, error)
+	// Start saves a batch that started processing only while it is still pending, so a batch
+	// claimed by one worker is never processed by another.
+	Start(ctx context.Context, batch *TransferBatch) (bool, error)
+	UpdateItem(ctx context.Context, batchID string, item *TransferBatchItem) error
+	Finish(ctx context.Context, batch *TransferBatch) error
+}
+
+type TransferKeyRepository interface {
+	// Create saves the key, it fails with a conflict when the key is already registered by any
+	// account.
+	Create(ctx context.Context, key *TransferKey, tx ...TransactionHandler) error
+	FindByKey(ctx context.Context, key string) (TransferKey, error)
+	// FindByAccountID lists the keys of the account, the oldest first.
+	FindByAccountID(ctx context.Context, accountID string) ([]TransferKey, error)
+	CountByAccountID(ctx context.Context, accountID string, tx ...TransactionHandler) (int, error)
+	// Delete removes the key only when it belongs to the account.
+	Delete(ctx context.Context, accountID string, key string) error
+}
+
+type BeneficiaryRepository interface {
+	// Create saves the beneficiary, it fails with a conflict when the account already saved
+	// the destination account.
+	Create(ctx context.Context, beneficiary *Beneficiary) error
+	FindByID(ctx context.Context, ID string) (Beneficiary, error)
+	// FindByAccountID lists the beneficiaries of the account, the favorites first and then by
+	// nickname.
+	FindByAccountID(ctx context.Context, accountID string) ([]Beneficiary, error)
+	Update(ctx context.Context, beneficiary *Beneficiary) error
+	// Delete removes the beneficiary only when it belongs to the account.
+	Delete(ctx context.Context, accountID string, ID string) error
+}
+
+type ScheduledTransferRepository interface {
+	Create(ctx context.Context, scheduledTransfer *ScheduledTransfer) (ScheduledTransfer, error)
+	FindByID(ctx context.Context, ID string) (ScheduledTransfer, error)
+	FindByAccountID(ctx context.Context, accountID string, page Pagination) ([]ScheduledTransfer, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]ScheduledTransfer, error)
+	// Update saves the schedule only while it is still in expectedStatus with expectedNextRunAt,
+	// so an occurrence claimed by one worker is never run again by another and a concurrent
+	// pause or cancel is never overwritten.
+	Update(ctx context.Context, scheduledTransfer *ScheduledTransfer, expectedStatus ScheduleStatus, expectedNextRunAt *time.Time) (bool, error)
+	CreateExecution(ctx context.Context, execution *ScheduledTransferExecution) error
+	FindExecutions(ctx context.Context, scheduledTransferID string) ([]ScheduledTransferExecution, error)
+}
+
+type MovementRepository interface {
+	Create(ctx context.Context, movement *Movement, tx ...TransactionHandler) (Movement, error)
+}
+
+type LedgerRepository interface {
+	Post(ctx context.Context, entry *JournalEntry, tx ...TransactionHandler) (JournalEntry, error)
+	FindBalances(ctx context.Context) ([]LedgerBalance, error)
+	FindUnbalancedEntries(ctx context.Context) ([]string, error)
+}
+
+type IdempotencyKeyRepository interface {
+	FindByKey(ctx context.Context, accountID string, key string) (IdempotencyKey, error)
+	Create(ctx context.Context, idempotencyKey *IdempotencyKey) (IdempotencyKey, error)
+	Complete(ctx context.Context, idempotencyKey *IdempotencyKey) error
+	Delete(ctx context.Context, accountID string, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, refreshToken *RefreshToken, tx ...TransactionHandler) (RefreshToken, error)
+	FindByHashForUpdate(ctx context.Context, tokenHash string, tx ...TransactionHandler) (RefreshToken, error)
+	Revoke(ctx context.Context, ID string, replacedBy string, revokedAt time.Time, tx ...TransactionHandler) error
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time, tx ...TransactionHandler) error
+	RevokeByAccountID(ctx context.Context, accountID string, revokedAt time.Time, tx ...TransactionHandler) error
+}
+
+type RevokedTokenRepository interface {
+	Create(ctx context.Context, revokedToken *RevokedToken) error
+	Exists(ctx context.Context, JTI string) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, loginAttempt *LoginAttempt) error
+}
+
+type LoginThrottleRepository interface {
+	FindByKey(ctx context.Context, key string) (LoginThrottle, error)
+	FindByKeyForUpdate(ctx context.Context, key string, now time.Time, tx ...TransactionHandler) (LoginThrottle, error)
+	RegisterFailure(ctx context.Context, key string, now time.Time, resetBefore time.Time, tx ...TransactionHandler) (LoginThrottle, error)
+	Reset(ctx context.Context, key string, tx ...TransactionHandler) error
+}
+
+type TOTPRepository interface {
+	Save(ctx context.Context, totp *TOTP) error
+	FindByAccountID(ctx context.Context, accountID string) (TOTP, error)
+	Confirm(ctx context.Context, accountID string, confirmedAt time.Time, tx ...TransactionHandler) error
+	UseStep(ctx context.Context, accountID string, step int64) (bool, error)
+}
+
+type RecoveryCodeRepository interface {
+	Replace(ctx context.Context, accountID string, recoveryCodes []*RecoveryCode, tx ...TransactionHandler) error
+	Use(ctx context.Context, accountID string, codeHash string, usedAt time.Time) (bool, error)
+}
+
+type SecretResetTokenRepository interface {
+	Create(ctx context.Context, secretResetToken *SecretResetToken, tx ...TransactionHandler) error
+	FindByHashForUpdate(ctx context.Context, tokenHash string, tx ...TransactionHandler) (SecretResetToken, error)
+	InvalidateByAccountID(ctx context.Context, accountID string, usedAt time.Time, tx ...TransactionHandler) error
+}
+
+// Notifier delivers notifications to the owner of an account, like the secret reset tokens.
+type Notifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+// ExchangeRateProvider quotes the rate to convert money from one currency to another, it
+// returns an error when it has no rate for the pair.
+type ExchangeRateProvider interface {
+	FindRate(ctx context.Context, from Currency, to Currency) (ExchangeRate, error)
+}
+
+// SecretCipher encrypts the secrets that have to be read back, like the TOTP secrets.
+type SecretCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// TokenSigner signs the claims of an access token.
+type TokenSigner interface {
+	Sign(claims map[string]interface{}) (string, error)
+}
+
+// TokenVerifier checks the signature of an access token and returns its claims.
+type TokenVerifier interface {
+	Verify(token string) (map[string]interface{}, error)
 }
 
 type Repository interface {
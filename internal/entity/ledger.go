@@ -0,0 +1,254 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type PostingDirection string
+
+const (
+	DEBIT  PostingDirection = "DEBIT"
+	CREDIT PostingDirection = "CREDIT"
+)
+
+type JournalEntryType string
+
+const (
+	OPENING_BALANCE_ENTRY    JournalEntryType = "OPENING_BALANCE"
+	TRANSFER_ENTRY           JournalEntryType = "TRANSFER"
+	REVERSAL_ENTRY           JournalEntryType = "REVERSAL"
+	DEPOSIT_ENTRY            JournalEntryType = "DEPOSIT"
+	WITHDRAWAL_ENTRY         JournalEntryType = "WITHDRAWAL"
+	OVERDRAFT_INTEREST_ENTRY JournalEntryType = "OVERDRAFT_INTEREST"
+)
+
+// EXTERNAL_LEDGER_ACCOUNT is the counterpart of the money that enters or leaves the bank,
+// it has no row in the account table.
+const EXTERNAL_LEDGER_ACCOUNT = "external"
+
+// FX_LEDGER_ACCOUNT is the counterpart of the currency exchange of transfers between accounts
+// of different currencies, it buys the origin currency and sells the destination currency.
+const FX_LEDGER_ACCOUNT = "fx"
+
+// INTEREST_LEDGER_ACCOUNT is the income of the bank from the interest charged on overdrawn
+// accounts, it has no row in the account table.
+const INTEREST_LEDGER_ACCOUNT = "interest"
+
+// FEES_LEDGER_ACCOUNT is the revenue of the bank from the fees charged on transfers, it has no
+// row in the account table.
+const FEES_LEDGER_ACCOUNT = "fees"
+
+type Posting struct {
+	ID        string
+	AccountID string
+	Direction PostingDirection
+	Amount    Money
+}
+
+func NewPosting(ID string, accountID string, direction PostingDirection, amount Money) Posting {
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	return Posting{
+		ID:        ID,
+		AccountID: accountID,
+		Direction: direction,
+		Amount:    amount,
+	}
+}
+
+// SignedAmount is the effect of the posting on the balance of its account.
+func (p Posting) SignedAmount() Money {
+	if p.Direction == DEBIT {
+		return NewMoney(-p.Amount.Amount, p.Amount.Currency)
+	}
+	return p.Amount
+}
+
+// IsExternal tells whether the posting is against an account without a row in the account table.
+func (p Posting) IsExternal() bool {
+	return p.AccountID == EXTERNAL_LEDGER_ACCOUNT || p.AccountID == FX_LEDGER_ACCOUNT || p.AccountID == INTEREST_LEDGER_ACCOUNT || p.AccountID == FEES_LEDGER_ACCOUNT
+}
+
+type JournalEntry struct {
+	ID          string
+	Type        JournalEntryType
+	ReferenceID string
+	Postings    []Posting
+	CreatedAt   *time.Time
+}
+
+func NewJournalEntry(ID string, entryType JournalEntryType, referenceID string, postings []Posting, createdAt *time.Time) (*JournalEntry, error) {
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	journalEntry := &JournalEntry{
+		ID:          ID,
+		Type:        entryType,
+		ReferenceID: referenceID,
+		Postings:    postings,
+		CreatedAt:   createdAt,
+	}
+
+	err := journalEntry.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return journalEntry, nil
+}
+
+// NewTransferJournalEntry debits the origin account and credits the destination account. A
+// cross-currency transfer goes through the fx account, so each currency balances on its own.
+// The fee is debited from the origin account and credited to the fees account in the same entry.
+// Reversals are posted the same way, with their own entry type.
+func NewTransferJournalEntry(transfer *Transfer) (*JournalEntry, error) {
+	entryType := TRANSFER_ENTRY
+	if transfer.IsReversal() {
+		entryType = REVERSAL_ENTRY
+	}
+
+	postings := []Posting{
+		NewPosting("", transfer.OriginAccount.ID, DEBIT, transfer.Amount),
+		NewPosting("", transfer.DestinationAccount.ID, CREDIT, transfer.DestinationAmount),
+	}
+
+	if transfer.IsCrossCurrency() {
+		postings = []Posting{
+			NewPosting("", transfer.OriginAccount.ID, DEBIT, transfer.Amount),
+			NewPosting("", FX_LEDGER_ACCOUNT, CREDIT, transfer.Amount),
+			NewPosting("", FX_LEDGER_ACCOUNT, DEBIT, transfer.DestinationAmount),
+			NewPosting("", transfer.DestinationAccount.ID, CREDIT, transfer.DestinationAmount),
+		}
+	}
+
+	if transfer.Fee.IsPositive() {
+		postings = append(postings,
+			NewPosting("", transfer.OriginAccount.ID, DEBIT, transfer.Fee),
+			NewPosting("", FEES_LEDGER_ACCOUNT, CREDIT, transfer.Fee),
+		)
+	}
+
+	return NewJournalEntry("", entryType, transfer.ID, postings, transfer.CreatedAt)
+}
+
+// NewOpeningBalanceJournalEntry credits the initial balance of an account against the external account.
+func NewOpeningBalanceJournalEntry(accountID string, amount Money, createdAt *time.Time) (*JournalEntry, error) {
+	postings := []Posting{
+		NewPosting("", EXTERNAL_LEDGER_ACCOUNT, DEBIT, amount),
+		NewPosting("", accountID, CREDIT, amount),
+	}
+
+	return NewJournalEntry("", OPENING_BALANCE_ENTRY, accountID, postings, createdAt)
+}
+
+// NewMovementJournalEntry moves the money between the account and the external account:
+// a deposit credits the account and a withdrawal debits it.
+func NewMovementJournalEntry(movement *Movement) (*JournalEntry, error) {
+	if movement.Type == WITHDRAWAL {
+		postings := []Posting{
+			NewPosting("", movement.Account.ID, DEBIT, movement.Amount),
+			NewPosting("", EXTERNAL_LEDGER_ACCOUNT, CREDIT, movement.Amount),
+		}
+
+		return NewJournalEntry("", WITHDRAWAL_ENTRY, movement.ID, postings, movement.CreatedAt)
+	}
+
+	postings := []Posting{
+		NewPosting("", EXTERNAL_LEDGER_ACCOUNT, DEBIT, movement.Amount),
+		NewPosting("", movement.Account.ID, CREDIT, movement.Amount),
+	}
+
+	return NewJournalEntry("", DEPOSIT_ENTRY, movement.ID, postings, movement.CreatedAt)
+}
+
+// NewOverdraftInterestJournalEntry debits the interest from the overdrawn account and credits
+// it to the interest account.
+func NewOverdraftInterestJournalEntry(interest *OverdraftInterest) (*JournalEntry, error) {
+	postings := []Posting{
+		NewPosting("", interest.Account.ID, DEBIT, interest.Amount),
+		NewPosting("", INTEREST_LEDGER_ACCOUNT, CREDIT, interest.Amount),
+	}
+
+	return NewJournalEntry("", OVERDRAFT_INTEREST_ENTRY, interest.ID, postings, interest.CreatedAt)
+}
+
+func (j *JournalEntry) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if j.Type == "" {
+		validationError.Add("journal entry type cannot be empty")
+	}
+
+	if j.ReferenceID == "" {
+		validationError.Add("reference id cannot be empty")
+	}
+
+	if len(j.Postings) < 2 {
+		validationError.Add("journal entry must have at least two postings")
+	}
+
+	// debits and credits must balance within each currency
+	balances := map[Currency]int64{}
+	for i, posting := range j.Postings {
+		if posting.AccountID == "" {
+			validationError.Add(fmt.Sprintf("posting %d: account id cannot be empty", i))
+		}
+
+		if !posting.Amount.IsPositive() {
+			validationError.Add(fmt.Sprintf("posting %d: amount must be greater than zero", i))
+		}
+
+		if !posting.Amount.Currency.IsValid() {
+			validationError.Add(fmt.Sprintf("posting %d: currency is invalid: %s", i, posting.Amount.Currency))
+		}
+
+		switch posting.Direction {
+		case DEBIT, CREDIT:
+			balance, err := NewMoney(balances[posting.Amount.Currency], posting.Amount.Currency).Add(posting.SignedAmount())
+			if err != nil {
+				validationError.Add(fmt.Sprintf("posting %d: %s", i, err.Error()))
+				continue
+			}
+			balances[posting.Amount.Currency] = balance.Amount
+		default:
+			validationError.Add(fmt.Sprintf("posting %d: direction must be DEBIT or CREDIT", i))
+		}
+	}
+
+	for _, balance := range balances {
+		if balance != 0 {
+			validationError.Add("journal entry is unbalanced: debits must be equal to credits")
+			break
+		}
+	}
+
+	if j.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// LedgerBalance compares the cached balance of an account with the balance derived from its postings.
+type LedgerBalance struct {
+	AccountID     string
+	CachedBalance Money
+	LedgerBalance Money
+}
+
+func (l LedgerBalance) Drift() Money {
+	return NewMoney(l.CachedBalance.Amount-l.LedgerBalance.Amount, l.CachedBalance.Currency)
+}
+
+func (l LedgerBalance) HasDrift() bool {
+	return !l.Drift().IsZero()
+}
@@ -0,0 +1,199 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_NewJournalEntry(t *testing.T) {
+	t.Run("Testing NewJournalEntry when returning a balanced journal entry", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+		postings := []entity.Posting{
+			entity.NewPosting("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.DEBIT, entity.NewMoney(50, entity.BRL)),
+			entity.NewPosting("", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CREDIT, entity.NewMoney(30, entity.BRL)),
+			entity.NewPosting("", "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", entity.CREDIT, entity.NewMoney(20, entity.BRL)),
+		}
+
+		entry, err := entity.NewJournalEntry("", entity.TRANSFER_ENTRY, "fc84682a-3045-4bdf-b91c-10be19f89452", postings, &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, entry.ID)
+		assert.Len(t, entry.Postings, 3)
+		assert.NotEmpty(t, entry.Postings[0].ID)
+	})
+
+	t.Run("Testing NewJournalEntry when debits are different from credits", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+		postings := []entity.Posting{
+			entity.NewPosting("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.DEBIT, entity.NewMoney(50, entity.BRL)),
+			entity.NewPosting("", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CREDIT, entity.NewMoney(40, entity.BRL)),
+		}
+
+		entry, err := entity.NewJournalEntry("", entity.TRANSFER_ENTRY, "fc84682a-3045-4bdf-b91c-10be19f89452", postings, &createdAt)
+
+		assert.Nil(t, entry)
+		assert.Equal(t, "journal entry is unbalanced: debits must be equal to credits", err.Error())
+	})
+
+	t.Run("Testing NewJournalEntry when fields are invalid", func(t *testing.T) {
+		postings := []entity.Posting{
+			entity.NewPosting("", "", "OTHER", entity.NewMoney(0, entity.BRL)),
+		}
+
+		entry, err := entity.NewJournalEntry("", "", "", postings, nil)
+
+		assert.Nil(t, entry)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"journal entry type cannot be empty",
+			"reference id cannot be empty",
+			"journal entry must have at least two postings",
+			"posting 0: account id cannot be empty",
+			"posting 0: amount must be greater than zero",
+			"posting 0: direction must be DEBIT or CREDIT",
+			"created at cannot be nil",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestLedger_NewTransferJournalEntry(t *testing.T) {
+	t.Run("Testing NewTransferJournalEntry debits the origin and credits the destination", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		entry, err := entity.NewTransferJournalEntry(transfer)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TRANSFER_ENTRY, entry.Type)
+		assert.Equal(t, transfer.ID, entry.ReferenceID)
+		assert.Equal(t, originAccount.ID, entry.Postings[0].AccountID)
+		assert.Equal(t, entity.NewMoney(-50, entity.BRL), entry.Postings[0].SignedAmount())
+		assert.Equal(t, destinationAccount.ID, entry.Postings[1].AccountID)
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), entry.Postings[1].SignedAmount())
+	})
+
+	t.Run("Testing NewTransferJournalEntry credits the fee to the fees account", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.ChargeFees([]entity.TransferFee{{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.BRL)}}))
+
+		entry, err := entity.NewTransferJournalEntry(transfer)
+
+		assert.Nil(t, err)
+		assert.Len(t, entry.Postings, 4)
+		assert.Equal(t, originAccount.ID, entry.Postings[2].AccountID)
+		assert.Equal(t, entity.NewMoney(-10, entity.BRL), entry.Postings[2].SignedAmount())
+		assert.Equal(t, entity.FEES_LEDGER_ACCOUNT, entry.Postings[3].AccountID)
+		assert.Equal(t, entity.NewMoney(10, entity.BRL), entry.Postings[3].SignedAmount())
+		assert.True(t, entry.Postings[3].IsExternal())
+	})
+
+	t.Run("Testing NewTransferJournalEntry exchanges the currencies through the fx account", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(500, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		exchangeRate, err := entity.NewExchangeRate(entity.BRL, entity.USD, "0.2", &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.ApplyExchangeRate(*exchangeRate))
+
+		entry, err := entity.NewTransferJournalEntry(transfer)
+
+		assert.Nil(t, err)
+		assert.Len(t, entry.Postings, 4)
+		assert.Equal(t, originAccount.ID, entry.Postings[0].AccountID)
+		assert.Equal(t, entity.NewMoney(-500, entity.BRL), entry.Postings[0].SignedAmount())
+		assert.Equal(t, entity.FX_LEDGER_ACCOUNT, entry.Postings[1].AccountID)
+		assert.Equal(t, entity.NewMoney(500, entity.BRL), entry.Postings[1].SignedAmount())
+		assert.Equal(t, entity.FX_LEDGER_ACCOUNT, entry.Postings[2].AccountID)
+		assert.Equal(t, entity.NewMoney(-100, entity.USD), entry.Postings[2].SignedAmount())
+		assert.Equal(t, destinationAccount.ID, entry.Postings[3].AccountID)
+		assert.Equal(t, entity.NewMoney(100, entity.USD), entry.Postings[3].SignedAmount())
+		assert.True(t, entry.Postings[1].IsExternal())
+	})
+
+	t.Run("Testing NewTransferJournalEntry of a reversal", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		reversal, err := transfer.Reverse("", entity.NewMoney(50, entity.BRL), &createdAt, time.Hour)
+		assert.Nil(t, err)
+
+		entry, err := entity.NewTransferJournalEntry(reversal)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.REVERSAL_ENTRY, entry.Type)
+		assert.Equal(t, reversal.ID, entry.ReferenceID)
+		assert.Equal(t, destinationAccount.ID, entry.Postings[0].AccountID)
+		assert.Equal(t, entity.NewMoney(-50, entity.BRL), entry.Postings[0].SignedAmount())
+		assert.Equal(t, originAccount.ID, entry.Postings[1].AccountID)
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), entry.Postings[1].SignedAmount())
+	})
+}
+
+func TestLedger_NewOpeningBalanceJournalEntry(t *testing.T) {
+	t.Run("Testing NewOpeningBalanceJournalEntry credits the account against the external account", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		entry, err := entity.NewOpeningBalanceJournalEntry("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(100, entity.BRL), &createdAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.OPENING_BALANCE_ENTRY, entry.Type)
+		assert.True(t, entry.Postings[0].IsExternal())
+		assert.Equal(t, entity.DEBIT, entry.Postings[0].Direction)
+		assert.False(t, entry.Postings[1].IsExternal())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), entry.Postings[1].SignedAmount())
+	})
+}
+
+func TestLedger_NewOverdraftInterestJournalEntry(t *testing.T) {
+	t.Run("Testing NewOverdraftInterestJournalEntry debits the account against the interest account", func(t *testing.T) {
+		account := GetOverdrawnAccount(t)
+		createdAt := time.Date(2023, 8, 14, 3, 00, 00, 00, time.UTC)
+
+		interest, err := entity.NewOverdraftInterest("7c9e6679-7425-40de-944b-e07fc1f90ae7", account, "2023-08-14", "0.0026", &createdAt)
+		assert.Nil(t, err)
+
+		entry, err := entity.NewOverdraftInterestJournalEntry(interest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.OVERDRAFT_INTEREST_ENTRY, entry.Type)
+		assert.Equal(t, interest.ID, entry.ReferenceID)
+		assert.Equal(t, account.ID, entry.Postings[0].AccountID)
+		assert.Equal(t, entity.NewMoney(-260, entity.BRL), entry.Postings[0].SignedAmount())
+		assert.Equal(t, entity.INTEREST_LEDGER_ACCOUNT, entry.Postings[1].AccountID)
+		assert.True(t, entry.Postings[1].IsExternal())
+	})
+}
+
+func TestLedger_LedgerBalance(t *testing.T) {
+	t.Run("Testing LedgerBalance drift", func(t *testing.T) {
+		balance := entity.LedgerBalance{AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", CachedBalance: entity.NewMoney(120, entity.BRL), LedgerBalance: entity.NewMoney(100, entity.BRL)}
+
+		assert.Equal(t, entity.NewMoney(20, entity.BRL), balance.Drift())
+		assert.True(t, balance.HasDrift())
+
+		balance.CachedBalance = entity.NewMoney(100, entity.BRL)
+		assert.False(t, balance.HasDrift())
+	})
+}
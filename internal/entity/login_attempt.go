@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"sync"
+	"time"
+)
+
+type LoginFailureReason string
+
+const (
+	UNKNOWN_CPF_LOGIN_FAILURE  LoginFailureReason = "unknown_cpf"
+	WRONG_SECRET_LOGIN_FAILURE LoginFailureReason = "wrong_secret"
+	WRONG_TOTP_LOGIN_FAILURE   LoginFailureReason = "wrong_totp"
+	THROTTLED_LOGIN_FAILURE    LoginFailureReason = "throttled"
+)
+
+// LoginAttempt is the audit record of a failed login.
+type LoginAttempt struct {
+	ID        string
+	CPF       string
+	IP        string
+	Reason    LoginFailureReason
+	CreatedAt *time.Time
+}
+
+func NewLoginAttempt(CPF string, IP string, reason LoginFailureReason, createdAt *time.Time) (*LoginAttempt, error) {
+	loginAttempt := &LoginAttempt{
+		ID:        NewUUID(),
+		CPF:       CPF,
+		IP:        IP,
+		Reason:    reason,
+		CreatedAt: createdAt,
+	}
+
+	err := loginAttempt.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return loginAttempt, nil
+}
+
+func (l *LoginAttempt) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if l.Reason == "" {
+		validationError.Add("reason cannot be empty")
+	}
+
+	if l.CreatedAt == nil {
+		validationError.Add("created at cannot be empty")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// LoginThrottle counts the consecutive failed logins of a key, which identifies a CPF, an IP,
+// the account whose TOTP codes are being checked, the account looking up transfer keys or the
+// CPF and the IP requesting secret resets.
+type LoginThrottle struct {
+	Key           string
+	Failures      int
+	LastFailureAt *time.Time
+}
+
+func CPFLoginThrottleKey(CPF string) string {
+	return "cpf:" + CPF
+}
+
+func IPLoginThrottleKey(IP string) string {
+	return "ip:" + IP
+}
+
+func TOTPThrottleKey(accountID string) string {
+	return "totp:" + accountID
+}
+
+func TransferKeyLookupThrottleKey(accountID string) string {
+	return "key_lookup:" + accountID
+}
+
+func CPFSecretResetThrottleKey(CPF string) string {
+	return "reset_cpf:" + CPF
+}
+
+func IPSecretResetThrottleKey(IP string) string {
+	return "reset_ip:" + IP
+}
+
+// LoginThrottlePolicy doubles the wait between attempts after every failure, starting at
+// BaseDelay and never above MaxDelay, and locks the key for Lockout once MaxAttempts
+// consecutive failures are reached. Failures older than Lockout are forgotten.
+type LoginThrottlePolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Lockout     time.Duration
+}
+
+func NewLoginThrottlePolicy(maxAttempts int, baseDelay time.Duration, maxDelay time.Duration, lockout time.Duration) LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   baseDelay,
+		MaxDelay:    maxDelay,
+		Lockout:     lockout,
+	}
+}
+
+// RetryAt returns when the key may try to login again, a zero time means it is not throttled.
+func (p LoginThrottlePolicy) RetryAt(throttle LoginThrottle) time.Time {
+	if throttle.Failures <= 0 || throttle.LastFailureAt == nil {
+		return time.Time{}
+	}
+
+	if throttle.Failures >= p.MaxAttempts {
+		return throttle.LastFailureAt.Add(p.Lockout)
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < throttle.Failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return throttle.LastFailureAt.Add(delay)
+}
+
+var (
+	unknownAccountSecret     []byte
+	unknownAccountSecretOnce sync.Once
+)
+
+// CompareUnknownAccountSecret spends the same time as checking the secret of an account, so
+// a login with an unknown CPF cannot be told apart from one with a wrong secret.
+func CompareUnknownAccountSecret(secret string) {
+	unknownAccountSecretOnce.Do(func() {
+		unknownAccountSecret, _ = hash(NewUUID())
+	})
+
+	hashIsValid(string(unknownAccountSecret), secret)
+}
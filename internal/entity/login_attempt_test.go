@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoginAttempt(t *testing.T) {
+	t.Run("Testing NewLoginAttempt with success", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		loginAttempt, err := entity.NewLoginAttempt("34688151071", "203.0.113.7", entity.WRONG_SECRET_LOGIN_FAILURE, &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, loginAttempt.ID)
+		assert.Equal(t, "34688151071", loginAttempt.CPF)
+		assert.Equal(t, "203.0.113.7", loginAttempt.IP)
+		assert.Equal(t, entity.WRONG_SECRET_LOGIN_FAILURE, loginAttempt.Reason)
+	})
+
+	t.Run("Testing NewLoginAttempt without reason and created at", func(t *testing.T) {
+		loginAttempt, err := entity.NewLoginAttempt("34688151071", "203.0.113.7", "", nil)
+
+		assert.Nil(t, loginAttempt)
+		assert.Equal(t, "reason cannot be empty, created at cannot be empty", err.Error())
+	})
+}
+
+func TestLoginThrottlePolicy_RetryAt(t *testing.T) {
+	policy := entity.NewLoginThrottlePolicy(5, time.Second, 4*time.Second, 15*time.Minute)
+	lastFailureAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+	t.Run("Testing RetryAt without failures", func(t *testing.T) {
+		assert.True(t, policy.RetryAt(entity.LoginThrottle{}).IsZero())
+	})
+
+	t.Run("Testing RetryAt doubles the delay after every failure up to the max delay", func(t *testing.T) {
+		expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+
+		for i, delay := range expected {
+			throttle := entity.LoginThrottle{Failures: i + 1, LastFailureAt: &lastFailureAt}
+			assert.Equal(t, lastFailureAt.Add(delay), policy.RetryAt(throttle))
+		}
+	})
+
+	t.Run("Testing RetryAt locks out after the max attempts", func(t *testing.T) {
+		throttle := entity.LoginThrottle{Failures: 5, LastFailureAt: &lastFailureAt}
+
+		assert.Equal(t, lastFailureAt.Add(15*time.Minute), policy.RetryAt(throttle))
+	})
+}
+
+func TestCompareUnknownAccountSecret(t *testing.T) {
+	t.Run("Testing CompareUnknownAccountSecret takes as long as checking a secret", func(t *testing.T) {
+		start := time.Now()
+		entity.CompareUnknownAccountSecret("123456")
+
+		assert.Greater(t, time.Since(start), time.Millisecond)
+	})
+}
@@ -16,8 +16,8 @@ func NewAccountRepositoryMock() *AccountRepositoryMock {
 	return &AccountRepositoryMock{}
 }
 
-func (a *AccountRepositoryMock) Find(ctx context.Context, limit, offset int) ([]entity.Account, error) {
-	args := a.Called(ctx, limit, offset)
+func (a *AccountRepositoryMock) Find(ctx context.Context, page entity.Pagination) ([]entity.Account, error) {
+	args := a.Called(ctx, page)
 	return args.Get(0).([]entity.Account), args.Error(1)
 }
 
@@ -26,13 +26,18 @@ func (a *AccountRepositoryMock) FindByID(ctx context.Context, ID string) (entity
 	return args.Get(0).(entity.Account), args.Error(1)
 }
 
-func (a *AccountRepositoryMock) Create(ctx context.Context, account *entity.Account) (entity.Account, error) {
-	args := a.Called(ctx, account)
+func (a *AccountRepositoryMock) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Account, error) {
+	args := a.Called(ctx, ID)
 	return args.Get(0).(entity.Account), args.Error(1)
 }
 
-func (a *AccountRepositoryMock) UpdateBalance(ctx context.Context, accountID string, newBalance int, tx ...entity.TransactionHandler) (entity.Account, error) {
-	args := a.Called(ctx, accountID, newBalance)
+func (a *AccountRepositoryMock) FindByIDs(ctx context.Context, IDs []string) ([]entity.Account, error) {
+	args := a.Called(ctx, IDs)
+	return args.Get(0).([]entity.Account), args.Error(1)
+}
+
+func (a *AccountRepositoryMock) Create(ctx context.Context, account *entity.Account, tx ...entity.TransactionHandler) (entity.Account, error) {
+	args := a.Called(ctx, account)
 	return args.Get(0).(entity.Account), args.Error(1)
 }
 
@@ -41,6 +46,21 @@ func (a *AccountRepositoryMock) FindByCPF(ctx context.Context, CPF string) (enti
 	return args.Get(0).(entity.Account), args.Error(1)
 }
 
+func (a *AccountRepositoryMock) UpdateRole(ctx context.Context, ID string, role entity.Role) error {
+	args := a.Called(ctx, ID, role)
+	return args.Error(0)
+}
+
+func (a *AccountRepositoryMock) UpdateSecret(ctx context.Context, ID string, secret string, tx ...entity.TransactionHandler) error {
+	args := a.Called(ctx, ID, secret)
+	return args.Error(0)
+}
+
+func (a *AccountRepositoryMock) UpdateCreditLimit(ctx context.Context, ID string, creditLimit entity.Money, tx ...entity.TransactionHandler) error {
+	args := a.Called(ctx, ID, creditLimit)
+	return args.Error(0)
+}
+
 func GetAccounts() []entity.Account {
 	date := time.Date(2023, 8, 5, 16, 00, 00, 00, time.UTC)
 	return []entity.Account{
@@ -49,7 +69,7 @@ func GetAccounts() []entity.Account {
 			Name:      "Lucas",
 			CPF:       "",
 			Secret:    "",
-			Balance:   0,
+			Balance:   entity.NewMoney(0, entity.BRL),
 			CreatedAt: &date,
 		},
 	}
@@ -62,7 +82,8 @@ func CreateAccount() entity.Account {
 		Name:      "Lucas",
 		CPF:       "34688151071",
 		Secret:    "5e0542f964858f96ae7194fb2a7dd365",
-		Balance:   500,
+		Balance:   entity.NewMoney(500, entity.BRL),
+		Role:      entity.CUSTOMER_ROLE,
 		CreatedAt: &date,
 	}
 }
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BeneficiaryRepositoryMock struct {
+	mock.Mock
+}
+
+func NewBeneficiaryRepositoryMock() *BeneficiaryRepositoryMock {
+	return &BeneficiaryRepositoryMock{}
+}
+
+func (b *BeneficiaryRepositoryMock) Create(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	args := b.Called(ctx, beneficiary)
+	return args.Error(0)
+}
+
+func (b *BeneficiaryRepositoryMock) FindByID(ctx context.Context, ID string) (entity.Beneficiary, error) {
+	args := b.Called(ctx, ID)
+	return args.Get(0).(entity.Beneficiary), args.Error(1)
+}
+
+func (b *BeneficiaryRepositoryMock) FindByAccountID(ctx context.Context, accountID string) ([]entity.Beneficiary, error) {
+	args := b.Called(ctx, accountID)
+	return args.Get(0).([]entity.Beneficiary), args.Error(1)
+}
+
+func (b *BeneficiaryRepositoryMock) Update(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	args := b.Called(ctx, beneficiary)
+	return args.Error(0)
+}
+
+func (b *BeneficiaryRepositoryMock) Delete(ctx context.Context, accountID string, ID string) error {
+	args := b.Called(ctx, accountID, ID)
+	return args.Error(0)
+}
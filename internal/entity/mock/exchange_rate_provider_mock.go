@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ExchangeRateProviderMock struct {
+	mock.Mock
+}
+
+func NewExchangeRateProviderMock() *ExchangeRateProviderMock {
+	return &ExchangeRateProviderMock{}
+}
+
+func (e *ExchangeRateProviderMock) FindRate(ctx context.Context, from entity.Currency, to entity.Currency) (entity.ExchangeRate, error) {
+	args := e.Called(ctx, from, to)
+	return args.Get(0).(entity.ExchangeRate), args.Error(1)
+}
@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type HoldRepositoryMock struct {
+	mock.Mock
+}
+
+func NewHoldRepositoryMock() *HoldRepositoryMock {
+	return &HoldRepositoryMock{}
+}
+
+func (h *HoldRepositoryMock) Create(ctx context.Context, hold *entity.Hold, tx ...entity.TransactionHandler) error {
+	args := h.Called(ctx, hold, tx)
+	return args.Error(0)
+}
+
+func (h *HoldRepositoryMock) FindByID(ctx context.Context, ID string) (entity.Hold, error) {
+	args := h.Called(ctx, ID)
+	return args.Get(0).(entity.Hold), args.Error(1)
+}
+
+func (h *HoldRepositoryMock) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Hold, error) {
+	args := h.Called(ctx, ID, tx)
+	return args.Get(0).(entity.Hold), args.Error(1)
+}
+
+func (h *HoldRepositoryMock) Finish(ctx context.Context, hold *entity.Hold, tx ...entity.TransactionHandler) error {
+	args := h.Called(ctx, hold, tx)
+	return args.Error(0)
+}
+
+func (h *HoldRepositoryMock) FindExpiredIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	args := h.Called(ctx, now, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
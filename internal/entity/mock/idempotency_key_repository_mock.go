@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type IdempotencyKeyRepositoryMock struct {
+	mock.Mock
+}
+
+func NewIdempotencyKeyRepositoryMock() *IdempotencyKeyRepositoryMock {
+	return &IdempotencyKeyRepositoryMock{}
+}
+
+func (i *IdempotencyKeyRepositoryMock) FindByKey(ctx context.Context, accountID string, key string) (entity.IdempotencyKey, error) {
+	args := i.Called(ctx, accountID, key)
+	return args.Get(0).(entity.IdempotencyKey), args.Error(1)
+}
+
+func (i *IdempotencyKeyRepositoryMock) Create(ctx context.Context, idempotencyKey *entity.IdempotencyKey) (entity.IdempotencyKey, error) {
+	args := i.Called(ctx, idempotencyKey)
+	return args.Get(0).(entity.IdempotencyKey), args.Error(1)
+}
+
+func (i *IdempotencyKeyRepositoryMock) Complete(ctx context.Context, idempotencyKey *entity.IdempotencyKey) error {
+	args := i.Called(ctx, idempotencyKey)
+	return args.Error(0)
+}
+
+func (i *IdempotencyKeyRepositoryMock) Delete(ctx context.Context, accountID string, key string) error {
+	args := i.Called(ctx, accountID, key)
+	return args.Error(0)
+}
+
+func (i *IdempotencyKeyRepositoryMock) DeleteExpired(ctx context.Context, now time.Time) error {
+	args := i.Called(ctx, now)
+	return args.Error(0)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LedgerRepositoryMock struct {
+	mock.Mock
+}
+
+func NewLedgerRepositoryMock() *LedgerRepositoryMock {
+	return &LedgerRepositoryMock{}
+}
+
+func (l *LedgerRepositoryMock) Post(ctx context.Context, entry *entity.JournalEntry, tx ...entity.TransactionHandler) (entity.JournalEntry, error) {
+	args := l.Called(ctx, entry, tx)
+	return args.Get(0).(entity.JournalEntry), args.Error(1)
+}
+
+func (l *LedgerRepositoryMock) FindBalances(ctx context.Context) ([]entity.LedgerBalance, error) {
+	args := l.Called(ctx)
+	return args.Get(0).([]entity.LedgerBalance), args.Error(1)
+}
+
+func (l *LedgerRepositoryMock) FindUnbalancedEntries(ctx context.Context) ([]string, error) {
+	args := l.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
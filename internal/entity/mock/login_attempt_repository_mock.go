@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginAttemptRepositoryMock struct {
+	mock.Mock
+}
+
+func NewLoginAttemptRepositoryMock() *LoginAttemptRepositoryMock {
+	return &LoginAttemptRepositoryMock{}
+}
+
+func (l *LoginAttemptRepositoryMock) Create(ctx context.Context, loginAttempt *entity.LoginAttempt) error {
+	args := l.Called(ctx, loginAttempt)
+	return args.Error(0)
+}
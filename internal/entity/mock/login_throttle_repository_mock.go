@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginThrottleRepositoryMock struct {
+	mock.Mock
+}
+
+func NewLoginThrottleRepositoryMock() *LoginThrottleRepositoryMock {
+	return &LoginThrottleRepositoryMock{}
+}
+
+func (l *LoginThrottleRepositoryMock) FindByKey(ctx context.Context, key string) (entity.LoginThrottle, error) {
+	args := l.Called(ctx, key)
+	return args.Get(0).(entity.LoginThrottle), args.Error(1)
+}
+
+func (l *LoginThrottleRepositoryMock) FindByKeyForUpdate(ctx context.Context, key string, now time.Time, tx ...entity.TransactionHandler) (entity.LoginThrottle, error) {
+	args := l.Called(ctx, key, now)
+	return args.Get(0).(entity.LoginThrottle), args.Error(1)
+}
+
+func (l *LoginThrottleRepositoryMock) RegisterFailure(ctx context.Context, key string, now time.Time, resetBefore time.Time, tx ...entity.TransactionHandler) (entity.LoginThrottle, error) {
+	args := l.Called(ctx, key, now, resetBefore)
+	return args.Get(0).(entity.LoginThrottle), args.Error(1)
+}
+
+func (l *LoginThrottleRepositoryMock) Reset(ctx context.Context, key string, tx ...entity.TransactionHandler) error {
+	args := l.Called(ctx, key)
+	return args.Error(0)
+}
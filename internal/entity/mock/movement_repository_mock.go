@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MovementRepositoryMock struct {
+	mock.Mock
+}
+
+func NewMovementRepositoryMock() *MovementRepositoryMock {
+	return &MovementRepositoryMock{}
+}
+
+func (m *MovementRepositoryMock) Create(ctx context.Context, movement *entity.Movement, tx ...entity.TransactionHandler) (entity.Movement, error) {
+	args := m.Called(ctx, movement, tx)
+	return args.Get(0).(entity.Movement), args.Error(1)
+}
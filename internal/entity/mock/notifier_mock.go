@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type NotifierMock struct {
+	mock.Mock
+}
+
+func NewNotifierMock() *NotifierMock {
+	return &NotifierMock{}
+}
+
+func (n *NotifierMock) Notify(ctx context.Context, notification *entity.Notification) error {
+	args := n.Called(ctx, notification)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type OverdraftInterestRepositoryMock struct {
+	mock.Mock
+}
+
+func NewOverdraftInterestRepositoryMock() *OverdraftInterestRepositoryMock {
+	return &OverdraftInterestRepositoryMock{}
+}
+
+func (o *OverdraftInterestRepositoryMock) FindOverdrawnAccountIDs(ctx context.Context, accrualDate string, after string, limit int) ([]string, error) {
+	args := o.Called(ctx, accrualDate, after, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (o *OverdraftInterestRepositoryMock) Create(ctx context.Context, interest *entity.OverdraftInterest, tx ...entity.TransactionHandler) error {
+	args := o.Called(ctx, interest, tx)
+	return args.Error(0)
+}
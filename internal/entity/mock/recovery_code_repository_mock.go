@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RecoveryCodeRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRecoveryCodeRepositoryMock() *RecoveryCodeRepositoryMock {
+	return &RecoveryCodeRepositoryMock{}
+}
+
+func (r *RecoveryCodeRepositoryMock) Replace(ctx context.Context, accountID string, recoveryCodes []*entity.RecoveryCode, tx ...entity.TransactionHandler) error {
+	args := r.Called(ctx, accountID, recoveryCodes)
+	return args.Error(0)
+}
+
+func (r *RecoveryCodeRepositoryMock) Use(ctx context.Context, accountID string, codeHash string, usedAt time.Time) (bool, error) {
+	args := r.Called(ctx, accountID, codeHash, usedAt)
+	return args.Bool(0), args.Error(1)
+}
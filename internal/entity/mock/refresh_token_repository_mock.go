@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RefreshTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRefreshTokenRepositoryMock() *RefreshTokenRepositoryMock {
+	return &RefreshTokenRepositoryMock{}
+}
+
+func (r *RefreshTokenRepositoryMock) Create(ctx context.Context, refreshToken *entity.RefreshToken, tx ...entity.TransactionHandler) (entity.RefreshToken, error) {
+	args := r.Called(ctx, refreshToken)
+	return args.Get(0).(entity.RefreshToken), args.Error(1)
+}
+
+func (r *RefreshTokenRepositoryMock) FindByHashForUpdate(ctx context.Context, tokenHash string, tx ...entity.TransactionHandler) (entity.RefreshToken, error) {
+	args := r.Called(ctx, tokenHash)
+	return args.Get(0).(entity.RefreshToken), args.Error(1)
+}
+
+func (r *RefreshTokenRepositoryMock) Revoke(ctx context.Context, ID string, replacedBy string, revokedAt time.Time, tx ...entity.TransactionHandler) error {
+	args := r.Called(ctx, ID, replacedBy, revokedAt)
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time, tx ...entity.TransactionHandler) error {
+	args := r.Called(ctx, familyID, revokedAt)
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) RevokeByAccountID(ctx context.Context, accountID string, revokedAt time.Time, tx ...entity.TransactionHandler) error {
+	args := r.Called(ctx, accountID, revokedAt)
+	return args.Error(0)
+}
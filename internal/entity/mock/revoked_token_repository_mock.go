@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RevokedTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRevokedTokenRepositoryMock() *RevokedTokenRepositoryMock {
+	return &RevokedTokenRepositoryMock{}
+}
+
+func (r *RevokedTokenRepositoryMock) Create(ctx context.Context, revokedToken *entity.RevokedToken) error {
+	args := r.Called(ctx, revokedToken)
+	return args.Error(0)
+}
+
+func (r *RevokedTokenRepositoryMock) Exists(ctx context.Context, JTI string) (bool, error) {
+	args := r.Called(ctx, JTI)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RevokedTokenRepositoryMock) DeleteExpired(ctx context.Context, now time.Time) error {
+	args := r.Called(ctx, now)
+	return args.Error(0)
+}
@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ScheduledTransferRepositoryMock struct {
+	mock.Mock
+}
+
+func NewScheduledTransferRepositoryMock() *ScheduledTransferRepositoryMock {
+	return &ScheduledTransferRepositoryMock{}
+}
+
+func (s *ScheduledTransferRepositoryMock) Create(ctx context.Context, scheduledTransfer *entity.ScheduledTransfer) (entity.ScheduledTransfer, error) {
+	args := s.Called(ctx, scheduledTransfer)
+	return args.Get(0).(entity.ScheduledTransfer), args.Error(1)
+}
+
+func (s *ScheduledTransferRepositoryMock) FindByID(ctx context.Context, ID string) (entity.ScheduledTransfer, error) {
+	args := s.Called(ctx, ID)
+	return args.Get(0).(entity.ScheduledTransfer), args.Error(1)
+}
+
+func (s *ScheduledTransferRepositoryMock) FindByAccountID(ctx context.Context, accountID string, page entity.Pagination) ([]entity.ScheduledTransfer, error) {
+	args := s.Called(ctx, accountID, page)
+	return args.Get(0).([]entity.ScheduledTransfer), args.Error(1)
+}
+
+func (s *ScheduledTransferRepositoryMock) FindDue(ctx context.Context, now time.Time, limit int) ([]entity.ScheduledTransfer, error) {
+	args := s.Called(ctx, now, limit)
+	return args.Get(0).([]entity.ScheduledTransfer), args.Error(1)
+}
+
+func (s *ScheduledTransferRepositoryMock) Update(ctx context.Context, scheduledTransfer *entity.ScheduledTransfer, expectedStatus entity.ScheduleStatus, expectedNextRunAt *time.Time) (bool, error) {
+	args := s.Called(ctx, scheduledTransfer, expectedStatus, expectedNextRunAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (s *ScheduledTransferRepositoryMock) CreateExecution(ctx context.Context, execution *entity.ScheduledTransferExecution) error {
+	args := s.Called(ctx, execution)
+	return args.Error(0)
+}
+
+func (s *ScheduledTransferRepositoryMock) FindExecutions(ctx context.Context, scheduledTransferID string) ([]entity.ScheduledTransferExecution, error) {
+	args := s.Called(ctx, scheduledTransferID)
+	return args.Get(0).([]entity.ScheduledTransferExecution), args.Error(1)
+}
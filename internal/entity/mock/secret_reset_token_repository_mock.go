@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SecretResetTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func NewSecretResetTokenRepositoryMock() *SecretResetTokenRepositoryMock {
+	return &SecretResetTokenRepositoryMock{}
+}
+
+func (s *SecretResetTokenRepositoryMock) Create(ctx context.Context, secretResetToken *entity.SecretResetToken, tx ...entity.TransactionHandler) error {
+	args := s.Called(ctx, secretResetToken)
+	return args.Error(0)
+}
+
+func (s *SecretResetTokenRepositoryMock) FindByHashForUpdate(ctx context.Context, tokenHash string, tx ...entity.TransactionHandler) (entity.SecretResetToken, error) {
+	args := s.Called(ctx, tokenHash)
+	return args.Get(0).(entity.SecretResetToken), args.Error(1)
+}
+
+func (s *SecretResetTokenRepositoryMock) InvalidateByAccountID(ctx context.Context, accountID string, usedAt time.Time, tx ...entity.TransactionHandler) error {
+	args := s.Called(ctx, accountID, usedAt)
+	return args.Error(0)
+}
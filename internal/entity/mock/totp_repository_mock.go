@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TOTPRepositoryMock struct {
+	mock.Mock
+}
+
+func NewTOTPRepositoryMock() *TOTPRepositoryMock {
+	return &TOTPRepositoryMock{}
+}
+
+func (t *TOTPRepositoryMock) Save(ctx context.Context, totp *entity.TOTP) error {
+	args := t.Called(ctx, totp)
+	return args.Error(0)
+}
+
+func (t *TOTPRepositoryMock) FindByAccountID(ctx context.Context, accountID string) (entity.TOTP, error) {
+	args := t.Called(ctx, accountID)
+	return args.Get(0).(entity.TOTP), args.Error(1)
+}
+
+func (t *TOTPRepositoryMock) Confirm(ctx context.Context, accountID string, confirmedAt time.Time, tx ...entity.TransactionHandler) error {
+	args := t.Called(ctx, accountID, confirmedAt)
+	return args.Error(0)
+}
+
+func (t *TOTPRepositoryMock) UseStep(ctx context.Context, accountID string, step int64) (bool, error) {
+	args := t.Called(ctx, accountID, step)
+	return args.Bool(0), args.Error(1)
+}
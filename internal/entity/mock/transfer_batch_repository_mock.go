@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TransferBatchRepositoryMock struct {
+	mock.Mock
+}
+
+func NewTransferBatchRepositoryMock() *TransferBatchRepositoryMock {
+	return &TransferBatchRepositoryMock{}
+}
+
+func (t *TransferBatchRepositoryMock) Create(ctx context.Context, batch *entity.TransferBatch, tx ...entity.TransactionHandler) error {
+	args := t.Called(ctx, batch, tx)
+	return args.Error(0)
+}
+
+func (t *TransferBatchRepositoryMock) FindByID(ctx context.Context, ID string) (entity.TransferBatch, error) {
+	args := t.Called(ctx, ID)
+	return args.Get(0).(entity.TransferBatch), args.Error(1)
+}
+
+func (t *TransferBatchRepositoryMock) FindPendingIDs(ctx context.Context, limit int) ([]string, error) {
+	args := t.Called(ctx, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (t *TransferBatchRepositoryMock) FindStuckIDs(ctx context.Context, startedBefore time.Time, limit int) ([]string, error) {
+	args := t.Called(ctx, startedBefore, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (t *TransferBatchRepositoryMock) Start(ctx context.Context, batch *entity.TransferBatch) (bool, error) {
+	args := t.Called(ctx, batch)
+	return args.Bool(0), args.Error(1)
+}
+
+func (t *TransferBatchRepositoryMock) UpdateItem(ctx context.Context, batchID string, item *entity.TransferBatchItem) error {
+	args := t.Called(ctx, batchID, item)
+	return args.Error(0)
+}
+
+func (t *TransferBatchRepositoryMock) Finish(ctx context.Context, batch *entity.TransferBatch) error {
+	args := t.Called(ctx, batch)
+	return args.Error(0)
+}
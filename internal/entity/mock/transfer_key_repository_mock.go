@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TransferKeyRepositoryMock struct {
+	mock.Mock
+}
+
+func NewTransferKeyRepositoryMock() *TransferKeyRepositoryMock {
+	return &TransferKeyRepositoryMock{}
+}
+
+func (t *TransferKeyRepositoryMock) Create(ctx context.Context, key *entity.TransferKey, tx ...entity.TransactionHandler) error {
+	args := t.Called(ctx, key, tx)
+	return args.Error(0)
+}
+
+func (t *TransferKeyRepositoryMock) FindByKey(ctx context.Context, key string) (entity.TransferKey, error) {
+	args := t.Called(ctx, key)
+	return args.Get(0).(entity.TransferKey), args.Error(1)
+}
+
+func (t *TransferKeyRepositoryMock) FindByAccountID(ctx context.Context, accountID string) ([]entity.TransferKey, error) {
+	args := t.Called(ctx, accountID)
+	return args.Get(0).([]entity.TransferKey), args.Error(1)
+}
+
+func (t *TransferKeyRepositoryMock) CountByAccountID(ctx context.Context, accountID string, tx ...entity.TransactionHandler) (int, error) {
+	args := t.Called(ctx, accountID, tx)
+	return args.Int(0), args.Error(1)
+}
+
+func (t *TransferKeyRepositoryMock) Delete(ctx context.Context, accountID string, key string) error {
+	args := t.Called(ctx, accountID, key)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TransferLimitRepositoryMock struct {
+	mock.Mock
+}
+
+func NewTransferLimitRepositoryMock() *TransferLimitRepositoryMock {
+	return &TransferLimitRepositoryMock{}
+}
+
+func (t *TransferLimitRepositoryMock) FindByAccountID(ctx context.Context, accountID string, tx ...entity.TransactionHandler) (entity.TransferLimit, error) {
+	args := t.Called(ctx, accountID, tx)
+	return args.Get(0).(entity.TransferLimit), args.Error(1)
+}
+
+func (t *TransferLimitRepositoryMock) Save(ctx context.Context, transferLimit *entity.TransferLimit) error {
+	args := t.Called(ctx, transferLimit)
+	return args.Error(0)
+}
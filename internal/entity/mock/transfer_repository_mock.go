@@ -16,8 +16,8 @@ func NewTransferRepositoryMock() *TransfersRepositoryMock {
 	return &TransfersRepositoryMock{}
 }
 
-func (a *TransfersRepositoryMock) FindByAccountID(ctx context.Context, accountID string, limit, offset int) ([]entity.Transfer, error) {
-	args := a.Called(ctx, accountID, limit, offset)
+func (a *TransfersRepositoryMock) FindByAccountID(ctx context.Context, accountID string, filter entity.TransferFilter, page entity.Pagination) ([]entity.Transfer, error) {
+	args := a.Called(ctx, accountID, filter, page)
 	return args.Get(0).([]entity.Transfer), args.Error(1)
 }
 
@@ -26,6 +26,41 @@ func (t *TransfersRepositoryMock) Create(ctx context.Context, transfer *entity.T
 	return args.Get(0).(entity.Transfer), args.Error(1)
 }
 
+func (t *TransfersRepositoryMock) FindByID(ctx context.Context, ID string) (entity.Transfer, error) {
+	args := t.Called(ctx, ID)
+	return args.Get(0).(entity.Transfer), args.Error(1)
+}
+
+func (t *TransfersRepositoryMock) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Transfer, error) {
+	args := t.Called(ctx, ID, tx)
+	return args.Get(0).(entity.Transfer), args.Error(1)
+}
+
+func (t *TransfersRepositoryMock) UpdateReversedAmount(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) error {
+	args := t.Called(ctx, transfer, tx)
+	return args.Error(0)
+}
+
+func (t *TransfersRepositoryMock) UpdateStatus(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) error {
+	args := t.Called(ctx, transfer, tx)
+	return args.Error(0)
+}
+
+func (t *TransfersRepositoryMock) SumSent(ctx context.Context, accountID string, currency entity.Currency, since time.Time, tx ...entity.TransactionHandler) (entity.Money, error) {
+	args := t.Called(ctx, accountID, currency, since, tx)
+	return args.Get(0).(entity.Money), args.Error(1)
+}
+
+func (t *TransfersRepositoryMock) CountSent(ctx context.Context, accountID string, since time.Time, tx ...entity.TransactionHandler) (int, error) {
+	args := t.Called(ctx, accountID, since, tx)
+	return args.Int(0), args.Error(1)
+}
+
+func (t *TransfersRepositoryMock) FindFrequentDestinations(ctx context.Context, accountID string, since time.Time, limit int) ([]entity.FrequentDestination, error) {
+	args := t.Called(ctx, accountID, since, limit)
+	return args.Get(0).([]entity.FrequentDestination), args.Error(1)
+}
+
 func GetTransfererences() []entity.Transfer {
 	date := time.Date(2023, 8, 5, 16, 00, 00, 00, time.UTC)
 
@@ -34,15 +69,19 @@ func GetTransfererences() []entity.Transfer {
 
 	return []entity.Transfer{
 		{
-			ID:        "2bd765a6-47bd-4731-9eb2-1e65542f4477",
-			Amount:    500,
-			CreatedAt: &date,
+			ID:                "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+			Amount:            entity.NewMoney(500, entity.BRL),
+			DestinationAmount: entity.NewMoney(500, entity.BRL),
+			ExchangeRate:      "1",
+			ReversedAmount:    entity.NewMoney(0, entity.BRL),
+			Status:            entity.COMPLETED_TRANSFER,
+			CreatedAt:         &date,
 			OriginAccount: &entity.Account{
 				ID:        "2bd765a6-47bd-4731-9eb2-1e65542f4477",
 				Name:      "Lucas",
 				CPF:       "",
 				Secret:    "",
-				Balance:   0,
+				Balance:   entity.NewMoney(0, entity.BRL),
 				CreatedAt: &originAccountDate,
 			},
 			DestinationAccount: &entity.Account{
@@ -50,7 +89,7 @@ func GetTransfererences() []entity.Transfer {
 				Name:      "Rogerio",
 				CPF:       "",
 				Secret:    "",
-				Balance:   0,
+				Balance:   entity.NewMoney(0, entity.BRL),
 				CreatedAt: &destinationAccountDate,
 			},
 		},
@@ -65,15 +104,19 @@ func CreateTransfer() entity.Transfer {
 
 	return entity.Transfer{
 
-		ID:        "2bd765a6-47bd-4731-9eb2-1e65542f4477",
-		Amount:    500,
-		CreatedAt: &date,
+		ID:                "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+		Amount:            entity.NewMoney(500, entity.BRL),
+		DestinationAmount: entity.NewMoney(500, entity.BRL),
+		ExchangeRate:      "1",
+		ReversedAmount:    entity.NewMoney(0, entity.BRL),
+		Status:            entity.COMPLETED_TRANSFER,
+		CreatedAt:         &date,
 		OriginAccount: &entity.Account{
 			ID:        "2bd765a6-47bd-4731-9eb2-1e65542f4477",
 			Name:      "Lucas",
 			CPF:       "CPF",
 			Secret:    "XPTO",
-			Balance:   1000,
+			Balance:   entity.NewMoney(1000, entity.BRL),
 			CreatedAt: &originAccountDate,
 		},
 		DestinationAccount: &entity.Account{
@@ -81,7 +124,7 @@ func CreateTransfer() entity.Transfer {
 			Name:      "Rogerio",
 			CPF:       "CPF",
 			Secret:    "XPTO",
-			Balance:   1000,
+			Balance:   entity.NewMoney(1000, entity.BRL),
 			CreatedAt: &destinationAccountDate,
 		},
 	}
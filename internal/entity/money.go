@@ -0,0 +1,224 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+const (
+	BRL Currency = "BRL"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	JPY Currency = "JPY"
+)
+
+// DEFAULT_CURRENCY is the currency of the accounts opened without a balance.
+const DEFAULT_CURRENCY = BRL
+
+// currencyExponents is the number of decimal places of the minor unit of each supported currency.
+var currencyExponents = map[Currency]int{
+	BRL: 2,
+	USD: 2,
+	EUR: 2,
+	GBP: 2,
+	JPY: 0,
+}
+
+func NewCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(code))
+	if !currency.IsValid() {
+		return "", NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("currency is invalid: %s", code))
+	}
+
+	return currency, nil
+}
+
+func (c Currency) IsValid() bool {
+	_, ok := currencyExponents[c]
+	return ok
+}
+
+func (c Currency) Exponent() int {
+	return currencyExponents[c]
+}
+
+// Money is an amount in the minor unit of its currency, centavos for BRL. Operations between
+// different currencies and operations that overflow return an error instead of a wrong value.
+type Money struct {
+	Amount   int64    `json:"amount" swaggertype:"string" example:"10.50"`
+	Currency Currency `json:"currency" swaggertype:"string" example:"BRL"`
+}
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// ParseMoney reads a decimal string such as "10.50", it cannot have more decimal places than
+// the currency has.
+func ParseMoney(amount string, currencyCode string) (Money, error) {
+	currency, err := NewCurrency(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	invalid := NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("amount is invalid: %s", amount))
+
+	digits := amount
+	negative := strings.HasPrefix(digits, "-")
+	if negative {
+		digits = digits[1:]
+	}
+
+	integerPart, fractionPart, hasFraction := strings.Cut(digits, ".")
+	if integerPart == "" || (hasFraction && fractionPart == "") || !isDigits(integerPart) || !isDigits(fractionPart) {
+		return Money{}, invalid
+	}
+
+	exponent := currency.Exponent()
+	if len(fractionPart) > exponent {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("amount cannot have more than %d decimal places for %s", exponent, currency))
+	}
+
+	fractionPart += strings.Repeat("0", exponent-len(fractionPart))
+
+	minorUnits, err := strconv.ParseInt(integerPart+fractionPart, 10, 64)
+	if err != nil {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("amount is out of range: %s", amount))
+	}
+
+	if negative {
+		minorUnits = -minorUnits
+	}
+
+	return NewMoney(minorUnits, currency), nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount as a decimal string with the decimal places of the currency.
+func (m Money) String() string {
+	exponent := m.Currency.Exponent()
+
+	// the absolute value of math.MinInt64 only fits in an unsigned integer
+	absolute := uint64(m.Amount)
+	sign := ""
+	if m.Amount < 0 {
+		absolute = uint64(-(m.Amount + 1)) + 1
+		sign = "-"
+	}
+
+	if exponent == 0 {
+		return sign + strconv.FormatUint(absolute, 10)
+	}
+
+	unit := uint64(math.Pow10(exponent))
+	return fmt.Sprintf("%s%d.%0*d", sign, absolute/unit, exponent, absolute%unit)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("currency mismatch: %s and %s", m.Currency, other.Currency))
+	}
+	return nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) || (other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add("amount overflow")
+	}
+
+	return NewMoney(m.Amount+other.Amount, m.Currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if (other.Amount < 0 && m.Amount > math.MaxInt64+other.Amount) || (other.Amount > 0 && m.Amount < math.MinInt64+other.Amount) {
+		return Money{}, NewErrorHandler(ENTITY_ERROR).Add("amount overflow")
+	}
+
+	return NewMoney(m.Amount-other.Amount, m.Currency), nil
+}
+
+// Compare returns -1, 0 or 1 when the money is less than, equal to or greater than the other.
+func (m Money) Compare(other Money) (int, error) {
+	err := m.sameCurrency(other)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON writes the amount as a decimal string, so clients never guess the unit.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:   m.String(),
+		Currency: string(m.Currency),
+	})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value moneyJSON
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	money, err := ParseMoney(value.Amount, value.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
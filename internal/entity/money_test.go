@@ -0,0 +1,153 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_ParseMoney(t *testing.T) {
+	t.Run("Testing ParseMoney with a decimal amount", func(t *testing.T) {
+		money, err := entity.ParseMoney("10.50", "brl")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1050, entity.BRL), money)
+	})
+
+	t.Run("Testing ParseMoney with fewer decimal places than the currency", func(t *testing.T) {
+		money, err := entity.ParseMoney("10.5", "USD")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1050, entity.USD), money)
+
+		money, err = entity.ParseMoney("-3", "EUR")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-300, entity.EUR), money)
+	})
+
+	t.Run("Testing ParseMoney with a currency without minor unit", func(t *testing.T) {
+		money, err := entity.ParseMoney("1500", "JPY")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1500, entity.JPY), money)
+
+		_, err = entity.ParseMoney("1500.5", "JPY")
+
+		assert.Equal(t, "amount cannot have more than 0 decimal places for JPY", err.Error())
+	})
+
+	t.Run("Testing ParseMoney with more decimal places than the currency", func(t *testing.T) {
+		_, err := entity.ParseMoney("10.505", "BRL")
+
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "amount cannot have more than 2 decimal places for BRL", err.Error())
+	})
+
+	for _, amount := range []string{"", "ten", "1.", ".50", "1,50", "1.5a", "--1"} {
+		t.Run("Testing ParseMoney with invalid amount "+amount, func(t *testing.T) {
+			_, err := entity.ParseMoney(amount, "BRL")
+
+			assert.Equal(t, "amount is invalid: "+amount, err.Error())
+		})
+	}
+
+	t.Run("Testing ParseMoney with an amount out of range", func(t *testing.T) {
+		_, err := entity.ParseMoney("92233720368547758.08", "BRL")
+
+		assert.Equal(t, "amount is out of range: 92233720368547758.08", err.Error())
+	})
+
+	t.Run("Testing ParseMoney with an invalid currency", func(t *testing.T) {
+		_, err := entity.ParseMoney("10.50", "XYZ")
+
+		assert.Equal(t, "currency is invalid: XYZ", err.Error())
+	})
+}
+
+func TestMoney_String(t *testing.T) {
+	t.Run("Testing String formats the decimal places of the currency", func(t *testing.T) {
+		assert.Equal(t, "10.50", entity.NewMoney(1050, entity.BRL).String())
+		assert.Equal(t, "0.05", entity.NewMoney(5, entity.USD).String())
+		assert.Equal(t, "-0.05", entity.NewMoney(-5, entity.USD).String())
+		assert.Equal(t, "1500", entity.NewMoney(1500, entity.JPY).String())
+		assert.Equal(t, "-92233720368547758.08", entity.NewMoney(math.MinInt64, entity.BRL).String())
+	})
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	t.Run("Testing Add and Sub with the same currency", func(t *testing.T) {
+		sum, err := entity.NewMoney(1050, entity.BRL).Add(entity.NewMoney(50, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1100, entity.BRL), sum)
+
+		difference, err := entity.NewMoney(1050, entity.BRL).Sub(entity.NewMoney(2000, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-950, entity.BRL), difference)
+	})
+
+	t.Run("Testing Add and Sub with different currencies", func(t *testing.T) {
+		_, err := entity.NewMoney(1050, entity.BRL).Add(entity.NewMoney(50, entity.USD))
+
+		assert.Equal(t, "currency mismatch: BRL and USD", err.Error())
+
+		_, err = entity.NewMoney(1050, entity.BRL).Sub(entity.NewMoney(50, entity.USD))
+
+		assert.Equal(t, "currency mismatch: BRL and USD", err.Error())
+	})
+
+	t.Run("Testing Add and Sub when the result overflows", func(t *testing.T) {
+		_, err := entity.NewMoney(math.MaxInt64, entity.BRL).Add(entity.NewMoney(1, entity.BRL))
+
+		assert.Equal(t, "amount overflow", err.Error())
+
+		_, err = entity.NewMoney(math.MinInt64, entity.BRL).Sub(entity.NewMoney(1, entity.BRL))
+
+		assert.Equal(t, "amount overflow", err.Error())
+	})
+
+	t.Run("Testing Compare", func(t *testing.T) {
+		comparison, err := entity.NewMoney(100, entity.BRL).Compare(entity.NewMoney(200, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, -1, comparison)
+
+		comparison, err = entity.NewMoney(100, entity.BRL).Compare(entity.NewMoney(100, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, comparison)
+
+		_, err = entity.NewMoney(100, entity.BRL).Compare(entity.NewMoney(100, entity.JPY))
+
+		assert.Equal(t, "currency mismatch: BRL and JPY", err.Error())
+	})
+}
+
+func TestMoney_JSON(t *testing.T) {
+	t.Run("Testing Money marshals the amount as a decimal string", func(t *testing.T) {
+		data, err := json.Marshal(entity.NewMoney(1050, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"amount":"10.50","currency":"BRL"}`, string(data))
+	})
+
+	t.Run("Testing Money unmarshals a decimal string", func(t *testing.T) {
+		var money entity.Money
+		err := json.Unmarshal([]byte(`{"amount":"10.50","currency":"BRL"}`), &money)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1050, entity.BRL), money)
+	})
+
+	t.Run("Testing Money unmarshal with an invalid amount", func(t *testing.T) {
+		var money entity.Money
+		err := json.Unmarshal([]byte(`{"amount":"10.505","currency":"BRL"}`), &money)
+
+		assert.Equal(t, "amount cannot have more than 2 decimal places for BRL", err.Error())
+	})
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type MovementType string
+
+const (
+	DEPOSIT    MovementType = "DEPOSIT"
+	WITHDRAWAL MovementType = "WITHDRAWAL"
+)
+
+type MovementChannel string
+
+const (
+	BRANCH_CHANNEL MovementChannel = "BRANCH"
+	ATM_CHANNEL    MovementChannel = "ATM"
+	ONLINE_CHANNEL MovementChannel = "ONLINE"
+)
+
+const MOVEMENT_REFERENCE_MAX_LENGTH = 100
+
+// Movement is money entering (deposit) or leaving (withdrawal) the bank through an account.
+type Movement struct {
+	ID        string
+	Account   *Account
+	Type      MovementType
+	Channel   MovementChannel
+	Reference string
+	Amount    Money
+	CreatedAt *time.Time
+}
+
+func NewMovement(ID string, account *Account, movementType MovementType, channel MovementChannel, reference string, amount Money, createdAt *time.Time) (*Movement, error) {
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	movement := &Movement{
+		ID:        ID,
+		Account:   account,
+		Type:      movementType,
+		Channel:   channel,
+		Reference: reference,
+		Amount:    amount,
+		CreatedAt: createdAt,
+	}
+
+	err := movement.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+func (m *Movement) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if m.Account == nil {
+		validationError.Add("account cannot be nil")
+	}
+
+	if m.Type != DEPOSIT && m.Type != WITHDRAWAL {
+		validationError.Add("movement type must be DEPOSIT or WITHDRAWAL")
+	}
+
+	switch m.Channel {
+	case BRANCH_CHANNEL, ATM_CHANNEL, ONLINE_CHANNEL:
+	default:
+		validationError.Add(fmt.Sprintf("channel must be one of %s, %s or %s", BRANCH_CHANNEL, ATM_CHANNEL, ONLINE_CHANNEL))
+	}
+
+	if len(m.Reference) > MOVEMENT_REFERENCE_MAX_LENGTH {
+		validationError.Add(fmt.Sprintf("reference cannot be longer than %d characters", MOVEMENT_REFERENCE_MAX_LENGTH))
+	}
+
+	if !m.Amount.IsPositive() {
+		validationError.Add("amount must be greater than zero")
+	}
+
+	if !m.Amount.Currency.IsValid() {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", m.Amount.Currency))
+	}
+
+	if m.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// Apply updates the balance of the account according to the movement type.
+func (m *Movement) Apply() error {
+	if m.Type == WITHDRAWAL {
+		return m.Account.Withdraw(m.Amount)
+	}
+
+	return m.Account.Deposit(m.Amount)
+}
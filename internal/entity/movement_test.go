@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovement_NewMovement(t *testing.T) {
+	t.Run("Testing NewMovement when returning a valid movement", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		movement, err := entity.NewMovement("", account, entity.DEPOSIT, entity.BRANCH_CHANNEL, "receipt 42", entity.NewMoney(50, entity.BRL), &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, movement.ID)
+		assert.Equal(t, entity.DEPOSIT, movement.Type)
+		assert.Equal(t, entity.BRANCH_CHANNEL, movement.Channel)
+		assert.Equal(t, "receipt 42", movement.Reference)
+	})
+
+	t.Run("Testing NewMovement when returning an invalid movement", func(t *testing.T) {
+		movement, err := entity.NewMovement("", nil, "LOAN", "PHONE", strings.Repeat("x", 101), entity.NewMoney(0, entity.BRL), nil)
+
+		assert.Nil(t, movement)
+		assert.Equal(t, []string{
+			"account cannot be nil",
+			"movement type must be DEPOSIT or WITHDRAWAL",
+			"channel must be one of BRANCH, ATM or ONLINE",
+			"reference cannot be longer than 100 characters",
+			"amount must be greater than zero",
+			"created at cannot be nil",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestMovement_Apply(t *testing.T) {
+	t.Run("Testing Apply on a deposit", func(t *testing.T) {
+		account := GetBaseOriginAccount(t) // Balance = 100
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		movement, err := entity.NewMovement("", account, entity.DEPOSIT, entity.ATM_CHANNEL, "", entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		assert.Nil(t, movement.Apply())
+		assert.Equal(t, entity.NewMoney(150, entity.BRL), account.Balance)
+
+		entry, err := entity.NewMovementJournalEntry(movement)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.DEPOSIT_ENTRY, entry.Type)
+		assert.Equal(t, movement.ID, entry.ReferenceID)
+		assert.True(t, entry.Postings[0].IsExternal())
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), entry.Postings[1].SignedAmount())
+	})
+
+	t.Run("Testing Apply on a withdrawal", func(t *testing.T) {
+		account := GetBaseOriginAccount(t) // Balance = 100
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		movement, err := entity.NewMovement("", account, entity.WITHDRAWAL, entity.ATM_CHANNEL, "", entity.NewMoney(30, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		assert.Nil(t, movement.Apply())
+		assert.Equal(t, entity.NewMoney(70, entity.BRL), account.Balance)
+
+		entry, err := entity.NewMovementJournalEntry(movement)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.WITHDRAWAL_ENTRY, entry.Type)
+		assert.Equal(t, entity.NewMoney(-30, entity.BRL), entry.Postings[0].SignedAmount())
+		assert.True(t, entry.Postings[1].IsExternal())
+	})
+
+	t.Run("Testing Apply on a withdrawal with insufficient balance", func(t *testing.T) {
+		account := GetBaseOriginAccount(t) // Balance = 100
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		movement, err := entity.NewMovement("", account, entity.WITHDRAWAL, entity.ATM_CHANNEL, "", entity.NewMoney(130, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = movement.Apply()
+		assert.Equal(t, "insufficient balance for withdrawal", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+	})
+}
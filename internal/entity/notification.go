@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// Notification is a message to the owner of an account, how it is delivered is up to the
+// Notifier.
+type Notification struct {
+	AccountID string
+	Subject   string
+	Message   string
+	CreatedAt *time.Time
+}
+
+func NewNotification(accountID string, subject string, message string, createdAt *time.Time) (*Notification, error) {
+	notification := &Notification{
+		AccountID: accountID,
+		Subject:   subject,
+		Message:   message,
+		CreatedAt: createdAt,
+	}
+
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if accountID == "" {
+		validationError.Add("account id cannot be empty")
+	}
+
+	if message == "" {
+		validationError.Add("message cannot be empty")
+	}
+
+	if createdAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,28 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotification(t *testing.T) {
+	t.Run("Testing NewNotification with success", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		notification, err := entity.NewNotification("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Secret reset", "Use the token abc", &createdAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Secret reset", notification.Subject)
+		assert.Equal(t, "Use the token abc", notification.Message)
+	})
+
+	t.Run("Testing NewNotification with invalid parameters", func(t *testing.T) {
+		notification, err := entity.NewNotification("", "Secret reset", "", nil)
+
+		assert.Nil(t, notification)
+		assert.Equal(t, "account id cannot be empty, message cannot be empty, created at cannot be nil", err.Error())
+	})
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const ACCRUAL_DATE_LAYOUT = "2006-01-02"
+
+// OverdraftInterestRate is the daily rate charged on negative balances, as a decimal string
+// such as "0.0026".
+type OverdraftInterestRate string
+
+func NewOverdraftInterestRate(rate string) (OverdraftInterestRate, error) {
+	dailyRate, ok := parseRate(rate)
+	if !ok || dailyRate.Sign() <= 0 {
+		return "", NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("interest rate is invalid: %s", rate))
+	}
+
+	return OverdraftInterestRate(rate), nil
+}
+
+// OverdraftInterest is the interest of one day charged to an overdrawn account, Amount is
+// Rate applied to the negative Balance of the account when it was accrued. An account is
+// charged at most once per AccrualDate.
+type OverdraftInterest struct {
+	ID          string
+	Account     *Account
+	AccrualDate string
+	Balance     Money
+	Rate        OverdraftInterestRate
+	Amount      Money
+	CreatedAt   *time.Time
+}
+
+// NewOverdraftInterest accrues the daily rate on the negative balance of the account, rounded
+// half away from zero to the minor unit of its currency.
+func NewOverdraftInterest(ID string, account *Account, accrualDate string, rate OverdraftInterestRate, createdAt *time.Time) (*OverdraftInterest, error) {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if account == nil {
+		return nil, validationError.Add("account cannot be nil")
+	}
+
+	if !account.Balance.IsNegative() {
+		return nil, validationError.Add("account is not overdrawn")
+	}
+
+	if _, err := time.Parse(ACCRUAL_DATE_LAYOUT, accrualDate); err != nil {
+		validationError.Add(fmt.Sprintf("accrual date is invalid: %s", accrualDate))
+	}
+
+	if createdAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	dailyRate, ok := parseRate(string(rate))
+	if !ok || dailyRate.Sign() <= 0 {
+		validationError.Add(fmt.Sprintf("interest rate is invalid: %s", rate))
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	interest := new(big.Rat).Mul(new(big.Rat).SetInt64(-account.Balance.Amount), dailyRate)
+
+	quotient, remainder := new(big.Int).QuoRem(interest.Num(), interest.Denom(), new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(interest.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if !quotient.IsInt64() {
+		return nil, NewErrorHandler(ENTITY_ERROR).Add("amount overflow")
+	}
+
+	return &OverdraftInterest{
+		ID:          ID,
+		Account:     account,
+		AccrualDate: accrualDate,
+		Balance:     account.Balance,
+		Rate:        rate,
+		Amount:      NewMoney(quotient.Int64(), account.Currency()),
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+// Apply debits the interest from the account. The interest is owed to the bank, so it is
+// charged even when it takes the balance beyond the credit limit.
+func (o *OverdraftInterest) Apply() error {
+	balance, err := o.Account.Balance.Sub(o.Amount)
+	if err != nil {
+		return err
+	}
+
+	o.Account.Balance = balance
+	return nil
+}
@@ -0,0 +1,108 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetOverdrawnAccount has a credit limit of 2000.00 BRL and a balance of -1000.00 BRL.
+func GetOverdrawnAccount(t *testing.T) *entity.Account {
+	account := GetBaseOriginAccount(t)
+
+	assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(200000, entity.BRL)))
+	assert.Nil(t, account.Withdraw(entity.NewMoney(100100, entity.BRL)))
+
+	return account
+}
+
+func TestOverdraftInterest_NewOverdraftInterestRate(t *testing.T) {
+	t.Run("Testing NewOverdraftInterestRate when the rate is valid", func(t *testing.T) {
+		rate, err := entity.NewOverdraftInterestRate("0.0026")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.OverdraftInterestRate("0.0026"), rate)
+	})
+
+	t.Run("Testing NewOverdraftInterestRate when the rate is invalid", func(t *testing.T) {
+		for _, rate := range []string{"", "0", "-0.01", "1/2", "2e-3"} {
+			_, err := entity.NewOverdraftInterestRate(rate)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, "interest rate is invalid: "+rate, err.Error())
+		}
+	})
+}
+
+func TestOverdraftInterest_NewOverdraftInterest(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 3, 00, 00, 00, time.UTC)
+
+	t.Run("Testing NewOverdraftInterest applies the rate to the negative balance", func(t *testing.T) {
+		account := GetOverdrawnAccount(t)
+
+		interest, err := entity.NewOverdraftInterest("7c9e6679-7425-40de-944b-e07fc1f90ae7", account, "2023-08-14", "0.0026", &createdAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2023-08-14", interest.AccrualDate)
+		assert.Equal(t, entity.NewMoney(-100000, entity.BRL), interest.Balance)
+		assert.Equal(t, entity.NewMoney(260, entity.BRL), interest.Amount)
+
+		err = interest.Apply()
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-100260, entity.BRL), account.Balance)
+	})
+
+	t.Run("Testing NewOverdraftInterest rounds half away from zero", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(1000, entity.BRL)))
+		assert.Nil(t, account.Withdraw(entity.NewMoney(292, entity.BRL)))
+
+		interest, err := entity.NewOverdraftInterest("", account, "2023-08-14", "0.0026", &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, interest.ID)
+		// 192 * 0.0026 = 0.4992
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), interest.Amount)
+
+		assert.Nil(t, account.Withdraw(entity.NewMoney(1, entity.BRL)))
+
+		interest, err = entity.NewOverdraftInterest("", account, "2023-08-14", "0.0026", &createdAt)
+		assert.Nil(t, err)
+		// 193 * 0.0026 = 0.5018
+		assert.Equal(t, entity.NewMoney(1, entity.BRL), interest.Amount)
+	})
+
+	t.Run("Testing Apply goes beyond the credit limit", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+		assert.Nil(t, account.ChangeCreditLimit(entity.NewMoney(100000, entity.BRL)))
+		assert.Nil(t, account.Withdraw(entity.NewMoney(100100, entity.BRL)))
+
+		interest, err := entity.NewOverdraftInterest("", account, "2023-08-14", "0.0026", &createdAt)
+		assert.Nil(t, err)
+
+		err = interest.Apply()
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-100260, entity.BRL), account.Balance)
+		assert.True(t, account.AvailableBalance().IsNegative())
+	})
+
+	t.Run("Testing NewOverdraftInterest when the account is not overdrawn", func(t *testing.T) {
+		interest, err := entity.NewOverdraftInterest("", GetBaseOriginAccount(t), "2023-08-14", "0.0026", &createdAt)
+
+		assert.Nil(t, interest)
+		assert.NotNil(t, err)
+		assert.Equal(t, "account is not overdrawn", err.Error())
+	})
+
+	t.Run("Testing NewOverdraftInterest when fields are invalid", func(t *testing.T) {
+		interest, err := entity.NewOverdraftInterest("", GetOverdrawnAccount(t), "14/08/2023", "abc", nil)
+
+		assert.Nil(t, interest)
+		assert.NotNil(t, err)
+		assert.Equal(t, "accrual date is invalid: 14/08/2023", err.(*entity.ErrorHandler).Messages[0])
+		assert.Equal(t, "created at cannot be nil", err.(*entity.ErrorHandler).Messages[1])
+		assert.Equal(t, "interest rate is invalid: abc", err.(*entity.ErrorHandler).Messages[2])
+	})
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// PageCursor points at the last row of a page, the next page starts right after it
+// in the (created_at, id) order of the listing.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Pagination is either keyset based (After is set) or offset based, never both.
+type Pagination struct {
+	Limit  int
+	Offset int
+	After  *PageCursor
+}
+
+// Paginator validates page requests and signs the cursors handed to the clients,
+// so a cursor cannot be forged to point anywhere else than a row it returned.
+type Paginator struct {
+	secret       []byte
+	defaultLimit int
+	maxLimit     int
+}
+
+// NewPaginator refuses an empty secret, with it anyone could sign a cursor.
+func NewPaginator(secret string, defaultLimit int, maxLimit int) (*Paginator, error) {
+	if secret == "" {
+		return nil, NewErrorHandler(ENTITY_ERROR).Add("cursor secret cannot be empty")
+	}
+
+	return &Paginator{
+		secret:       []byte(secret),
+		defaultLimit: defaultLimit,
+		maxLimit:     maxLimit,
+	}, nil
+}
+
+func (p *Paginator) NewPagination(limit int, offset int, cursor string) (*Pagination, error) {
+	validationError := NewErrorHandler(BAD_REQUEST)
+
+	if limit < 0 {
+		validationError.Add("limit cannot be minor than zero")
+	}
+
+	if offset < 0 {
+		validationError.Add("offset cannot be minor than zero")
+	}
+
+	if cursor != "" && offset > 0 {
+		validationError.Add("cursor and offset cannot be used together")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	if limit == 0 {
+		limit = p.defaultLimit
+	}
+
+	if limit > p.maxLimit {
+		limit = p.maxLimit
+	}
+
+	pagination := &Pagination{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if cursor != "" {
+		after, err := p.decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		pagination.After = after
+	}
+
+	return pagination, nil
+}
+
+func (p *Paginator) EncodeCursor(cursor PageCursor) string {
+	payload := []byte(cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(p.sign(payload))
+}
+
+func (p *Paginator) decodeCursor(cursor string) (*PageCursor, error) {
+	invalidCursor := NewErrorHandler(BAD_REQUEST).Add("invalid cursor")
+
+	encodedPayload, encodedSignature, found := strings.Cut(cursor, ".")
+	if !found {
+		return nil, invalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, invalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, p.sign(payload)) {
+		return nil, invalidCursor
+	}
+
+	createdAt, ID, found := strings.Cut(string(payload), "|")
+	if !found || ID == "" {
+		return nil, invalidCursor
+	}
+
+	parsedCreatedAt, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, invalidCursor
+	}
+
+	return &PageCursor{CreatedAt: parsedCreatedAt, ID: ID}, nil
+}
+
+func (p *Paginator) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,71 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginator_NewPagination(t *testing.T) {
+	paginator, err := entity.NewPaginator("cursor-secret", 20, 100)
+	assert.Nil(t, err)
+
+	t.Run("Testing NewPagination applies the default and the maximum page size", func(t *testing.T) {
+		page, err := paginator.NewPagination(0, 0, "")
+		assert.Nil(t, err)
+		assert.Equal(t, 20, page.Limit)
+
+		page, err = paginator.NewPagination(1000, 40, "")
+		assert.Nil(t, err)
+		assert.Equal(t, 100, page.Limit)
+		assert.Equal(t, 40, page.Offset)
+		assert.Nil(t, page.After)
+	})
+
+	t.Run("Testing NewPagination decodes a cursor it signed", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 123, time.UTC)
+		cursor := paginator.EncodeCursor(entity.PageCursor{CreatedAt: createdAt, ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477"})
+
+		page, err := paginator.NewPagination(10, 0, cursor)
+
+		assert.Nil(t, err)
+		assert.True(t, createdAt.Equal(page.After.CreatedAt))
+		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", page.After.ID)
+	})
+
+	t.Run("Testing NewPagination rejects forged or malformed cursors", func(t *testing.T) {
+		otherPaginator, err := entity.NewPaginator("other-secret", 20, 100)
+		assert.Nil(t, err)
+
+		forged := otherPaginator.EncodeCursor(entity.PageCursor{CreatedAt: time.Now(), ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477"})
+
+		for _, cursor := range []string{forged, "abc", "abc.def", "!!!.???"} {
+			page, err := paginator.NewPagination(10, 0, cursor)
+
+			assert.Nil(t, page)
+			assert.Equal(t, "invalid cursor", err.Error())
+			assert.Equal(t, entity.BAD_REQUEST, err.(*entity.ErrorHandler).TypeError)
+		}
+	})
+
+	t.Run("Testing NewPagination with invalid parameters", func(t *testing.T) {
+		page, err := paginator.NewPagination(-1, 5, "abc.def")
+
+		assert.Nil(t, page)
+		assert.Equal(t, []string{
+			"limit cannot be minor than zero",
+			"cursor and offset cannot be used together",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestPaginator_NewPaginator(t *testing.T) {
+	t.Run("Testing NewPaginator without a secret", func(t *testing.T) {
+		paginator, err := entity.NewPaginator("", 20, 100)
+
+		assert.Nil(t, paginator)
+		assert.Equal(t, "cursor secret cannot be empty", err.Error())
+	})
+}
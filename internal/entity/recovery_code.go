@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const RECOVERY_CODES_COUNT = 10
+
+// 32 symbols without the ones easily mistaken for each other (i, l, o, 0)
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz123456789"
+
+// RecoveryCode replaces a TOTP code once, when the authenticator is lost. Only its hash is
+// stored and the raw codes are shown a single time.
+type RecoveryCode struct {
+	ID        string
+	AccountID string
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+}
+
+// NewRecoveryCodes returns the codes to be stored and the raw values to be handed to the client.
+func NewRecoveryCodes(accountID string, createdAt *time.Time) ([]*RecoveryCode, []string, error) {
+	recoveryCodes := make([]*RecoveryCode, 0, RECOVERY_CODES_COUNT)
+	raws := make([]string, 0, RECOVERY_CODES_COUNT)
+
+	for i := 0; i < RECOVERY_CODES_COUNT; i++ {
+		random := make([]byte, 10)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, NewErrorHandler(INTERNAL_ERROR).Add("error on generating recovery code")
+		}
+
+		var raw strings.Builder
+		for j, b := range random {
+			if j == 5 {
+				raw.WriteByte('-')
+			}
+			raw.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+
+		recoveryCodes = append(recoveryCodes, &RecoveryCode{
+			ID:        NewUUID(),
+			AccountID: accountID,
+			CodeHash:  HashRecoveryCode(accountID, raw.String()),
+			CreatedAt: createdAt,
+		})
+		raws = append(raws, raw.String())
+	}
+
+	return recoveryCodes, raws, nil
+}
+
+// HashRecoveryCode ignores case and surrounding spaces, the way people type the codes back.
+func HashRecoveryCode(accountID string, code string) string {
+	sum := sha256.Sum256([]byte(accountID + ":" + strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsRecoveryCode(code string) bool {
+	return strings.Contains(code, "-")
+}
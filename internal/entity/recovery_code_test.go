@@ -0,0 +1,39 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	t.Run("Testing NewRecoveryCodes with success", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		recoveryCodes, raws, err := entity.NewRecoveryCodes("8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", &createdAt)
+
+		assert.Nil(t, err)
+		assert.Len(t, recoveryCodes, entity.RECOVERY_CODES_COUNT)
+		assert.Len(t, raws, entity.RECOVERY_CODES_COUNT)
+
+		for i, raw := range raws {
+			assert.Regexp(t, regexp.MustCompile(`^[a-z1-9]{5}-[a-z1-9]{5}$`), raw)
+			assert.True(t, entity.IsRecoveryCode(raw))
+			assert.Equal(t, entity.HashRecoveryCode("8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", raw), recoveryCodes[i].CodeHash)
+			assert.Nil(t, recoveryCodes[i].UsedAt)
+		}
+	})
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	t.Run("Testing HashRecoveryCode ignores case and surrounding spaces", func(t *testing.T) {
+		assert.Equal(t, entity.HashRecoveryCode("account", "abcde-fghjk"), entity.HashRecoveryCode("account", " ABCDE-FGHJK "))
+	})
+
+	t.Run("Testing HashRecoveryCode depends on the account", func(t *testing.T) {
+		assert.NotEqual(t, entity.HashRecoveryCode("account", "abcde-fghjk"), entity.HashRecoveryCode("other", "abcde-fghjk"))
+	})
+}
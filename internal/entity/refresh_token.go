@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshToken is a single use token that trades for a new access token. Every login starts a
+// family and each refresh rotates the token inside it, only the hash of the token is stored.
+type RefreshToken struct {
+	ID         string
+	FamilyID   string
+	AccountID  string
+	TokenHash  string
+	ReplacedBy string
+	CreatedAt  *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+}
+
+// NewRefreshToken returns the token to be stored and the raw value to be handed to the client.
+// An empty familyID starts a new family.
+func NewRefreshToken(accountID string, familyID string, createdAt *time.Time, ttl time.Duration) (*RefreshToken, string, error) {
+	if familyID == "" {
+		familyID = NewUUID()
+	}
+
+	refreshToken := &RefreshToken{
+		ID:        NewUUID(),
+		FamilyID:  familyID,
+		AccountID: accountID,
+		CreatedAt: createdAt,
+	}
+
+	if createdAt != nil {
+		expiresAt := createdAt.Add(ttl)
+		refreshToken.ExpiresAt = &expiresAt
+	}
+
+	err := refreshToken.isValid()
+	if err != nil {
+		return nil, "", err
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", NewErrorHandler(INTERNAL_ERROR).Add("error on generating refresh token")
+	}
+
+	raw := base64.RawURLEncoding.EncodeToString(random)
+	refreshToken.TokenHash = HashRefreshToken(raw)
+
+	return refreshToken, raw, nil
+}
+
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *RefreshToken) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if r.AccountID == "" {
+		validationError.Add("account id cannot be empty")
+	}
+
+	if r.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	} else if !r.ExpiresAt.After(*r.CreatedAt) {
+		validationError.Add("refresh token ttl must be greater than zero")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+func (r *RefreshToken) IsRevoked() bool {
+	return r.RevokedAt != nil
+}
+
+func (r *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(*r.ExpiresAt)
+}
+
+// RevokedToken is an access token that cannot be used anymore even though it has not
+// expired yet. It only needs to be kept until ExpiresAt.
+type RevokedToken struct {
+	JTI       string
+	ExpiresAt *time.Time
+}
+
+func NewRevokedToken(JTI string, expiresAt *time.Time) (*RevokedToken, error) {
+	revokedToken := &RevokedToken{
+		JTI:       JTI,
+		ExpiresAt: expiresAt,
+	}
+
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if JTI == "" {
+		validationError.Add("jti cannot be empty")
+	}
+
+	if expiresAt == nil {
+		validationError.Add("expires at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	return revokedToken, nil
+}
@@ -0,0 +1,80 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	t.Run("Testing NewRefreshToken starts a new family", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		refreshToken, raw, err := entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", &createdAt, time.Hour)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, raw)
+		assert.NotEmpty(t, refreshToken.ID)
+		assert.NotEmpty(t, refreshToken.FamilyID)
+		assert.Equal(t, entity.HashRefreshToken(raw), refreshToken.TokenHash)
+		assert.NotEqual(t, raw, refreshToken.TokenHash)
+		assert.Equal(t, createdAt.Add(time.Hour), *refreshToken.ExpiresAt)
+		assert.False(t, refreshToken.IsRevoked())
+	})
+
+	t.Run("Testing NewRefreshToken keeps the family when rotating", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		first, firstRaw, err := entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", &createdAt, time.Hour)
+		assert.Nil(t, err)
+
+		second, secondRaw, err := entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", first.FamilyID, &createdAt, time.Hour)
+		assert.Nil(t, err)
+
+		assert.Equal(t, first.FamilyID, second.FamilyID)
+		assert.NotEqual(t, first.ID, second.ID)
+		assert.NotEqual(t, firstRaw, secondRaw)
+	})
+
+	t.Run("Testing NewRefreshToken with invalid parameters", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		refreshToken, raw, err := entity.NewRefreshToken("", "", &createdAt, 0)
+
+		assert.Nil(t, refreshToken)
+		assert.Empty(t, raw)
+		assert.Equal(t, []string{"account id cannot be empty", "refresh token ttl must be greater than zero"}, err.(*entity.ErrorHandler).Messages)
+
+		_, _, err = entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", nil, time.Hour)
+		assert.Equal(t, "created at cannot be nil", err.Error())
+	})
+}
+
+func TestRefreshToken_IsExpired(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	refreshToken, _, err := entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", &createdAt, time.Hour)
+	assert.Nil(t, err)
+
+	assert.False(t, refreshToken.IsExpired(createdAt.Add(59*time.Minute)))
+	assert.True(t, refreshToken.IsExpired(createdAt.Add(time.Hour)))
+}
+
+func TestNewRevokedToken(t *testing.T) {
+	t.Run("Testing NewRevokedToken with success", func(t *testing.T) {
+		expiresAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		revokedToken, err := entity.NewRevokedToken("fc84682a-3045-4bdf-b91c-10be19f89452", &expiresAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "fc84682a-3045-4bdf-b91c-10be19f89452", revokedToken.JTI)
+	})
+
+	t.Run("Testing NewRevokedToken with invalid parameters", func(t *testing.T) {
+		revokedToken, err := entity.NewRevokedToken("", nil)
+
+		assert.Nil(t, revokedToken)
+		assert.Equal(t, "jti cannot be empty, expires at cannot be nil", err.Error())
+	})
+}
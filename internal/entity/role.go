@@ -0,0 +1,70 @@
+package entity
+
+import "fmt"
+
+type Role string
+
+const (
+	CUSTOMER_ROLE Role = "CUSTOMER"
+	ADMIN_ROLE    Role = "ADMIN"
+)
+
+// Permission is what a route declares it needs, roles grant a set of permissions.
+type Permission string
+
+const (
+	// PUBLIC_PERMISSION routes are served without a token.
+	PUBLIC_PERMISSION Permission = "public"
+	// AUTHENTICATED_PERMISSION routes accept any valid token.
+	AUTHENTICATED_PERMISSION Permission = "authenticated"
+
+	LIST_ACCOUNTS_PERMISSION        Permission = "accounts:list"
+	ACCESS_ANY_ACCOUNT_PERMISSION   Permission = "accounts:any"
+	MANAGE_ROLES_PERMISSION         Permission = "accounts:roles"
+	RECONCILE_LEDGER_PERMISSION     Permission = "ledger:reconcile"
+	REVERSE_ANY_TRANSFER_PERMISSION Permission = "transfers:reverse"
+	RAISE_TRANSFER_LIMIT_PERMISSION Permission = "limits:raise"
+	MANAGE_CREDIT_LIMIT_PERMISSION  Permission = "accounts:credit_limit"
+	// DEPOSIT_PERMISSION creates money in an account, debited against the external ledger
+	// account, so only the back office is granted it.
+	DEPOSIT_PERMISSION Permission = "accounts:deposit"
+)
+
+var rolePermissions = map[Role][]Permission{
+	CUSTOMER_ROLE: {
+		AUTHENTICATED_PERMISSION,
+	},
+	ADMIN_ROLE: {
+		AUTHENTICATED_PERMISSION,
+		LIST_ACCOUNTS_PERMISSION,
+		ACCESS_ANY_ACCOUNT_PERMISSION,
+		MANAGE_ROLES_PERMISSION,
+		RECONCILE_LEDGER_PERMISSION,
+		REVERSE_ANY_TRANSFER_PERMISSION,
+		RAISE_TRANSFER_LIMIT_PERMISSION,
+		MANAGE_CREDIT_LIMIT_PERMISSION,
+		DEPOSIT_PERMISSION,
+	},
+}
+
+func NewRole(role string) (Role, error) {
+	if _, ok := rolePermissions[Role(role)]; !ok {
+		return "", NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("role is invalid: %s", role))
+	}
+
+	return Role(role), nil
+}
+
+func (r Role) Can(permission Permission) bool {
+	if permission == PUBLIC_PERMISSION {
+		return true
+	}
+
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
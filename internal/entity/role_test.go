@@ -0,0 +1,57 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRole(t *testing.T) {
+	t.Run("Testing NewRole with known roles", func(t *testing.T) {
+		role, err := entity.NewRole("ADMIN")
+		assert.Nil(t, err)
+		assert.Equal(t, entity.ADMIN_ROLE, role)
+
+		role, err = entity.NewRole("CUSTOMER")
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CUSTOMER_ROLE, role)
+	})
+
+	t.Run("Testing NewRole with an unknown role", func(t *testing.T) {
+		role, err := entity.NewRole("ROOT")
+
+		assert.Empty(t, role)
+		assert.Equal(t, "role is invalid: ROOT", err.Error())
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestRole_Can(t *testing.T) {
+	t.Run("Testing Can with a customer", func(t *testing.T) {
+		assert.True(t, entity.CUSTOMER_ROLE.Can(entity.PUBLIC_PERMISSION))
+		assert.True(t, entity.CUSTOMER_ROLE.Can(entity.AUTHENTICATED_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.LIST_ACCOUNTS_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.ACCESS_ANY_ACCOUNT_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.MANAGE_ROLES_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.RECONCILE_LEDGER_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.REVERSE_ANY_TRANSFER_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.MANAGE_CREDIT_LIMIT_PERMISSION))
+		assert.False(t, entity.CUSTOMER_ROLE.Can(entity.DEPOSIT_PERMISSION))
+	})
+
+	t.Run("Testing Can with an admin", func(t *testing.T) {
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.AUTHENTICATED_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.LIST_ACCOUNTS_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.ACCESS_ANY_ACCOUNT_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.MANAGE_ROLES_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.RECONCILE_LEDGER_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.REVERSE_ANY_TRANSFER_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.MANAGE_CREDIT_LIMIT_PERMISSION))
+		assert.True(t, entity.ADMIN_ROLE.Can(entity.DEPOSIT_PERMISSION))
+	})
+
+	t.Run("Testing Can with an unknown role", func(t *testing.T) {
+		assert.False(t, entity.Role("ROOT").Can(entity.AUTHENTICATED_PERMISSION))
+	})
+}
@@ -0,0 +1,294 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type ScheduleFrequency string
+
+const (
+	ONCE    ScheduleFrequency = "ONCE"
+	DAILY   ScheduleFrequency = "DAILY"
+	WEEKLY  ScheduleFrequency = "WEEKLY"
+	MONTHLY ScheduleFrequency = "MONTHLY"
+)
+
+type ScheduleStatus string
+
+const (
+	ACTIVE_SCHEDULE   ScheduleStatus = "ACTIVE"
+	PAUSED_SCHEDULE   ScheduleStatus = "PAUSED"
+	CANCELED_SCHEDULE ScheduleStatus = "CANCELED"
+	FINISHED_SCHEDULE ScheduleStatus = "FINISHED"
+)
+
+type ExecutionStatus string
+
+const (
+	SUCCEEDED_EXECUTION ExecutionStatus = "SUCCEEDED"
+	FAILED_EXECUTION    ExecutionStatus = "FAILED"
+)
+
+const EXECUTION_FAILURE_REASON_MAX_LENGTH = 255
+
+// ScheduledTransfer is a transfer that runs at StartAt and, unless it runs only ONCE, again on
+// every occurrence of its frequency until EndAt or MaxExecutions, when set, is reached.
+// NextRunAt is nil once the schedule is canceled or finished.
+type ScheduledTransfer struct {
+	ID                   string
+	OriginAccountID      string
+	DestinationAccountID string
+	Amount               Money
+	Frequency            ScheduleFrequency
+	StartAt              *time.Time
+	EndAt                *time.Time
+	MaxExecutions        int
+	Executions           int
+	NextRunAt            *time.Time
+	Status               ScheduleStatus
+	CreatedAt            *time.Time
+}
+
+func NewScheduledTransfer(ID string, originAccountID string, destinationAccountID string, amount Money, frequency ScheduleFrequency, startAt *time.Time, endAt *time.Time, maxExecutions int, createdAt *time.Time) (*ScheduledTransfer, error) {
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	scheduledTransfer := &ScheduledTransfer{
+		ID:                   ID,
+		OriginAccountID:      originAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               amount,
+		Frequency:            frequency,
+		StartAt:              startAt,
+		EndAt:                endAt,
+		MaxExecutions:        maxExecutions,
+		NextRunAt:            startAt,
+		Status:               ACTIVE_SCHEDULE,
+		CreatedAt:            createdAt,
+	}
+
+	err := scheduledTransfer.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransfer, nil
+}
+
+func (s *ScheduledTransfer) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if s.OriginAccountID == "" {
+		validationError.Add("origin account id cannot be empty")
+	}
+
+	if s.DestinationAccountID == "" {
+		validationError.Add("destination account id cannot be empty")
+	}
+
+	if s.OriginAccountID != "" && s.OriginAccountID == s.DestinationAccountID {
+		validationError.Add("origin account id must be different to destination account id")
+	}
+
+	if !s.Amount.IsPositive() {
+		validationError.Add("amount must be greater than zero")
+	}
+
+	if !s.Amount.Currency.IsValid() {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", s.Amount.Currency))
+	}
+
+	switch s.Frequency {
+	case ONCE, DAILY, WEEKLY, MONTHLY:
+	default:
+		validationError.Add(fmt.Sprintf("frequency must be one of %s, %s, %s or %s", ONCE, DAILY, WEEKLY, MONTHLY))
+	}
+
+	if s.Frequency == ONCE && (s.EndAt != nil || s.MaxExecutions != 0) {
+		validationError.Add("a transfer scheduled once cannot have end at or max executions")
+	}
+
+	if s.MaxExecutions < 0 {
+		validationError.Add("max executions cannot be minor than zero")
+	}
+
+	if s.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if s.StartAt == nil {
+		validationError.Add("start at cannot be nil")
+	} else {
+		if s.CreatedAt != nil && !s.StartAt.After(*s.CreatedAt) {
+			validationError.Add("start at must be in the future")
+		}
+
+		if s.EndAt != nil && s.EndAt.Before(*s.StartAt) {
+			validationError.Add("end at cannot be before start at")
+		}
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// IsDue tells whether the schedule has an occurrence to run at now.
+func (s *ScheduledTransfer) IsDue(now time.Time) bool {
+	return s.Status == ACTIVE_SCHEDULE && s.NextRunAt != nil && !s.NextRunAt.After(now)
+}
+
+// RegisterExecution counts the run of the current occurrence and moves NextRunAt to the next
+// occurrence after now. Occurrences missed while the scheduler was down are not made up.
+func (s *ScheduledTransfer) RegisterExecution(now time.Time) {
+	s.Executions++
+
+	if s.Frequency == ONCE || (s.MaxExecutions > 0 && s.Executions >= s.MaxExecutions) {
+		s.finish()
+		return
+	}
+
+	s.moveToNextOccurrenceAfter(now)
+}
+
+func (s *ScheduledTransfer) Pause() error {
+	if s.Status != ACTIVE_SCHEDULE {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("scheduled transfer is %s and cannot be paused", s.Status))
+	}
+
+	s.Status = PAUSED_SCHEDULE
+	return nil
+}
+
+// Resume activates a paused schedule, the occurrences that passed while it was paused are skipped.
+func (s *ScheduledTransfer) Resume(now time.Time) error {
+	if s.Status != PAUSED_SCHEDULE {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("scheduled transfer is %s and cannot be resumed", s.Status))
+	}
+
+	s.Status = ACTIVE_SCHEDULE
+
+	if s.NextRunAt.Before(now) {
+		if s.Frequency == ONCE {
+			s.finish()
+			return nil
+		}
+
+		s.moveToNextOccurrenceAfter(now)
+	}
+
+	return nil
+}
+
+func (s *ScheduledTransfer) Cancel() error {
+	if s.Status != ACTIVE_SCHEDULE && s.Status != PAUSED_SCHEDULE {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("scheduled transfer is %s and cannot be canceled", s.Status))
+	}
+
+	s.Status = CANCELED_SCHEDULE
+	s.NextRunAt = nil
+	return nil
+}
+
+// ChangeStatus moves the schedule to the status asked by its owner.
+func (s *ScheduledTransfer) ChangeStatus(status ScheduleStatus, now time.Time) error {
+	switch status {
+	case PAUSED_SCHEDULE:
+		return s.Pause()
+	case ACTIVE_SCHEDULE:
+		return s.Resume(now)
+	case CANCELED_SCHEDULE:
+		return s.Cancel()
+	default:
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("status must be one of %s, %s or %s", ACTIVE_SCHEDULE, PAUSED_SCHEDULE, CANCELED_SCHEDULE))
+	}
+}
+
+func (s *ScheduledTransfer) finish() {
+	s.Status = FINISHED_SCHEDULE
+	s.NextRunAt = nil
+}
+
+func (s *ScheduledTransfer) moveToNextOccurrenceAfter(now time.Time) {
+	after := *s.NextRunAt
+	if now.After(after) {
+		after = now
+	}
+
+	occurrence := 1
+	next := s.occurrence(occurrence)
+	for !next.After(after) {
+		occurrence++
+		next = s.occurrence(occurrence)
+	}
+
+	if s.EndAt != nil && next.After(*s.EndAt) {
+		s.finish()
+		return
+	}
+
+	s.NextRunAt = &next
+}
+
+// occurrence is the nth run after StartAt. Monthly runs keep the day of StartAt, or the last
+// day of the month when it is shorter, instead of drifting like time.AddDate does.
+func (s *ScheduledTransfer) occurrence(n int) time.Time {
+	switch s.Frequency {
+	case DAILY:
+		return s.StartAt.AddDate(0, 0, n)
+	case WEEKLY:
+		return s.StartAt.AddDate(0, 0, 7*n)
+	default:
+		firstOfMonth := time.Date(s.StartAt.Year(), s.StartAt.Month()+time.Month(n), 1, s.StartAt.Hour(), s.StartAt.Minute(), s.StartAt.Second(), s.StartAt.Nanosecond(), s.StartAt.Location())
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+		day := s.StartAt.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+
+		return firstOfMonth.AddDate(0, 0, day-1)
+	}
+}
+
+// ScheduledTransferExecution records the outcome of one occurrence of a scheduled transfer,
+// with the transfer it created or the reason it failed.
+type ScheduledTransferExecution struct {
+	ID                  string
+	ScheduledTransferID string
+	TransferID          string
+	Status              ExecutionStatus
+	FailureReason       string
+	ExecutedAt          *time.Time
+}
+
+func NewScheduledTransferExecution(ID string, scheduledTransferID string, transferID string, failure error, executedAt *time.Time) *ScheduledTransferExecution {
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	execution := &ScheduledTransferExecution{
+		ID:                  ID,
+		ScheduledTransferID: scheduledTransferID,
+		TransferID:          transferID,
+		Status:              SUCCEEDED_EXECUTION,
+		ExecutedAt:          executedAt,
+	}
+
+	if failure != nil {
+		execution.Status = FAILED_EXECUTION
+		execution.TransferID = ""
+		execution.FailureReason = failure.Error()
+
+		if len(execution.FailureReason) > EXECUTION_FAILURE_REASON_MAX_LENGTH {
+			execution.FailureReason = execution.FailureReason[:EXECUTION_FAILURE_REASON_MAX_LENGTH]
+		}
+	}
+
+	return execution
+}
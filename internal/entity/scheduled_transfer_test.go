@@ -0,0 +1,216 @@
+package entity_test
+
+import (
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetScheduledTransfer(t *testing.T, frequency entity.ScheduleFrequency, startAt time.Time, endAt *time.Time, maxExecutions int) *entity.ScheduledTransfer {
+	createdAt := startAt.Add(-time.Hour)
+
+	scheduledTransfer, err := entity.NewScheduledTransfer("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(1000, entity.BRL), frequency, &startAt, endAt, maxExecutions, &createdAt)
+
+	assert.Nil(t, err)
+	return scheduledTransfer
+}
+
+func TestScheduledTransfer_NewScheduledTransfer(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+
+	t.Run("Testing NewScheduledTransfer when successful", func(t *testing.T) {
+		scheduledTransfer, err := entity.NewScheduledTransfer("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(1000, entity.BRL), entity.WEEKLY, &startAt, nil, 4, &createdAt)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, scheduledTransfer.ID)
+		assert.Equal(t, entity.ACTIVE_SCHEDULE, scheduledTransfer.Status)
+		assert.Equal(t, &startAt, scheduledTransfer.NextRunAt)
+		assert.Equal(t, 0, scheduledTransfer.Executions)
+	})
+
+	t.Run("Testing NewScheduledTransfer with invalid fields", func(t *testing.T) {
+		_, err := entity.NewScheduledTransfer("", "", "", entity.NewMoney(0, "XYZ"), "YEARLY", nil, nil, -1, nil)
+
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"origin account id cannot be empty",
+			"destination account id cannot be empty",
+			"amount must be greater than zero",
+			"currency is invalid: XYZ",
+			"frequency must be one of ONCE, DAILY, WEEKLY or MONTHLY",
+			"max executions cannot be minor than zero",
+			"created at cannot be nil",
+			"start at cannot be nil",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+
+	t.Run("Testing NewScheduledTransfer to the origin account", func(t *testing.T) {
+		_, err := entity.NewScheduledTransfer("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(1000, entity.BRL), entity.ONCE, &startAt, nil, 0, &createdAt)
+
+		assert.Equal(t, "origin account id must be different to destination account id", err.Error())
+	})
+
+	t.Run("Testing NewScheduledTransfer once with an end", func(t *testing.T) {
+		endAt := startAt.AddDate(0, 1, 0)
+		_, err := entity.NewScheduledTransfer("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(1000, entity.BRL), entity.ONCE, &startAt, &endAt, 0, &createdAt)
+
+		assert.Equal(t, "a transfer scheduled once cannot have end at or max executions", err.Error())
+	})
+
+	t.Run("Testing NewScheduledTransfer starting in the past", func(t *testing.T) {
+		startAt := createdAt.Add(-time.Minute)
+		_, err := entity.NewScheduledTransfer("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(1000, entity.BRL), entity.ONCE, &startAt, nil, 0, &createdAt)
+
+		assert.Equal(t, "start at must be in the future", err.Error())
+	})
+
+	t.Run("Testing NewScheduledTransfer ending before the start", func(t *testing.T) {
+		endAt := startAt.Add(-time.Minute)
+		_, err := entity.NewScheduledTransfer("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(1000, entity.BRL), entity.DAILY, &startAt, &endAt, 0, &createdAt)
+
+		assert.Equal(t, "end at cannot be before start at", err.Error())
+	})
+}
+
+func TestScheduledTransfer_RegisterExecution(t *testing.T) {
+	t.Run("Testing RegisterExecution finishes a transfer scheduled once", func(t *testing.T) {
+		startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+		scheduledTransfer := GetScheduledTransfer(t, entity.ONCE, startAt, nil, 0)
+
+		assert.False(t, scheduledTransfer.IsDue(startAt.Add(-time.Second)))
+		assert.True(t, scheduledTransfer.IsDue(startAt))
+
+		scheduledTransfer.RegisterExecution(startAt)
+
+		assert.Equal(t, 1, scheduledTransfer.Executions)
+		assert.Equal(t, entity.FINISHED_SCHEDULE, scheduledTransfer.Status)
+		assert.Nil(t, scheduledTransfer.NextRunAt)
+		assert.False(t, scheduledTransfer.IsDue(startAt))
+	})
+
+	t.Run("Testing RegisterExecution keeps the day of month on monthly transfers", func(t *testing.T) {
+		startAt := time.Date(2023, 1, 31, 9, 00, 00, 00, time.UTC)
+		scheduledTransfer := GetScheduledTransfer(t, entity.MONTHLY, startAt, nil, 0)
+
+		scheduledTransfer.RegisterExecution(startAt)
+		assert.Equal(t, time.Date(2023, 2, 28, 9, 00, 00, 00, time.UTC), *scheduledTransfer.NextRunAt)
+
+		scheduledTransfer.RegisterExecution(*scheduledTransfer.NextRunAt)
+		assert.Equal(t, time.Date(2023, 3, 31, 9, 00, 00, 00, time.UTC), *scheduledTransfer.NextRunAt)
+		assert.Equal(t, entity.ACTIVE_SCHEDULE, scheduledTransfer.Status)
+	})
+
+	t.Run("Testing RegisterExecution skips the occurrences missed", func(t *testing.T) {
+		startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+		scheduledTransfer := GetScheduledTransfer(t, entity.DAILY, startAt, nil, 0)
+
+		scheduledTransfer.RegisterExecution(startAt.Add(50 * time.Hour))
+
+		assert.Equal(t, time.Date(2023, 8, 13, 9, 00, 00, 00, time.UTC), *scheduledTransfer.NextRunAt)
+	})
+
+	t.Run("Testing RegisterExecution finishes at max executions", func(t *testing.T) {
+		startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+		scheduledTransfer := GetScheduledTransfer(t, entity.WEEKLY, startAt, nil, 2)
+
+		scheduledTransfer.RegisterExecution(startAt)
+		assert.Equal(t, time.Date(2023, 8, 17, 9, 00, 00, 00, time.UTC), *scheduledTransfer.NextRunAt)
+
+		scheduledTransfer.RegisterExecution(*scheduledTransfer.NextRunAt)
+		assert.Equal(t, entity.FINISHED_SCHEDULE, scheduledTransfer.Status)
+		assert.Equal(t, 2, scheduledTransfer.Executions)
+	})
+
+	t.Run("Testing RegisterExecution finishes after the end", func(t *testing.T) {
+		startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+		endAt := time.Date(2023, 8, 11, 12, 00, 00, 00, time.UTC)
+		scheduledTransfer := GetScheduledTransfer(t, entity.DAILY, startAt, &endAt, 0)
+
+		scheduledTransfer.RegisterExecution(startAt)
+		assert.Equal(t, entity.ACTIVE_SCHEDULE, scheduledTransfer.Status)
+
+		scheduledTransfer.RegisterExecution(*scheduledTransfer.NextRunAt)
+		assert.Equal(t, entity.FINISHED_SCHEDULE, scheduledTransfer.Status)
+		assert.Nil(t, scheduledTransfer.NextRunAt)
+	})
+}
+
+func TestScheduledTransfer_ChangeStatus(t *testing.T) {
+	startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+
+	t.Run("Testing ChangeStatus pauses and resumes skipping the occurrences missed", func(t *testing.T) {
+		scheduledTransfer := GetScheduledTransfer(t, entity.WEEKLY, startAt, nil, 0)
+
+		err := scheduledTransfer.ChangeStatus(entity.PAUSED_SCHEDULE, startAt)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.PAUSED_SCHEDULE, scheduledTransfer.Status)
+		assert.False(t, scheduledTransfer.IsDue(startAt))
+
+		err = scheduledTransfer.ChangeStatus(entity.ACTIVE_SCHEDULE, startAt.AddDate(0, 0, 10))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.ACTIVE_SCHEDULE, scheduledTransfer.Status)
+		assert.Equal(t, time.Date(2023, 8, 24, 9, 00, 00, 00, time.UTC), *scheduledTransfer.NextRunAt)
+	})
+
+	t.Run("Testing ChangeStatus resumes a transfer scheduled once after its date", func(t *testing.T) {
+		scheduledTransfer := GetScheduledTransfer(t, entity.ONCE, startAt, nil, 0)
+
+		assert.Nil(t, scheduledTransfer.ChangeStatus(entity.PAUSED_SCHEDULE, startAt))
+		assert.Nil(t, scheduledTransfer.ChangeStatus(entity.ACTIVE_SCHEDULE, startAt.Add(time.Hour)))
+
+		assert.Equal(t, entity.FINISHED_SCHEDULE, scheduledTransfer.Status)
+		assert.Nil(t, scheduledTransfer.NextRunAt)
+	})
+
+	t.Run("Testing ChangeStatus cancels", func(t *testing.T) {
+		scheduledTransfer := GetScheduledTransfer(t, entity.DAILY, startAt, nil, 0)
+
+		assert.Nil(t, scheduledTransfer.ChangeStatus(entity.CANCELED_SCHEDULE, startAt))
+		assert.Equal(t, entity.CANCELED_SCHEDULE, scheduledTransfer.Status)
+		assert.Nil(t, scheduledTransfer.NextRunAt)
+
+		err := scheduledTransfer.ChangeStatus(entity.ACTIVE_SCHEDULE, startAt)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "scheduled transfer is CANCELED and cannot be resumed", err.Error())
+
+		err = scheduledTransfer.ChangeStatus(entity.PAUSED_SCHEDULE, startAt)
+		assert.Equal(t, "scheduled transfer is CANCELED and cannot be paused", err.Error())
+
+		err = scheduledTransfer.ChangeStatus(entity.CANCELED_SCHEDULE, startAt)
+		assert.Equal(t, "scheduled transfer is CANCELED and cannot be canceled", err.Error())
+	})
+
+	t.Run("Testing ChangeStatus to an invalid status", func(t *testing.T) {
+		scheduledTransfer := GetScheduledTransfer(t, entity.DAILY, startAt, nil, 0)
+
+		err := scheduledTransfer.ChangeStatus(entity.FINISHED_SCHEDULE, startAt)
+
+		assert.Equal(t, "status must be one of ACTIVE, PAUSED or CANCELED", err.Error())
+	})
+}
+
+func TestScheduledTransfer_NewScheduledTransferExecution(t *testing.T) {
+	executedAt := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+
+	t.Run("Testing NewScheduledTransferExecution when the transfer succeeded", func(t *testing.T) {
+		execution := entity.NewScheduledTransferExecution("", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "fc84682a-3045-4bdf-b91c-10be19f89452", nil, &executedAt)
+
+		assert.NotEmpty(t, execution.ID)
+		assert.Equal(t, entity.SUCCEEDED_EXECUTION, execution.Status)
+		assert.Equal(t, "fc84682a-3045-4bdf-b91c-10be19f89452", execution.TransferID)
+		assert.Empty(t, execution.FailureReason)
+	})
+
+	t.Run("Testing NewScheduledTransferExecution when the transfer failed", func(t *testing.T) {
+		execution := entity.NewScheduledTransferExecution("", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "fc84682a-3045-4bdf-b91c-10be19f89452", errors.New(strings.Repeat("a", 300)), &executedAt)
+
+		assert.Equal(t, entity.FAILED_EXECUTION, execution.Status)
+		assert.Empty(t, execution.TransferID)
+		assert.Len(t, execution.FailureReason, entity.EXECUTION_FAILURE_REASON_MAX_LENGTH)
+	})
+}
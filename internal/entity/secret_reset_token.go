@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// SecretResetToken lets the owner of an account choose a new secret without knowing the current
+// one. It is delivered by the Notifier, can be used once and only its hash is stored.
+type SecretResetToken struct {
+	ID        string
+	AccountID string
+	TokenHash string
+	CreatedAt *time.Time
+	ExpiresAt *time.Time
+	UsedAt    *time.Time
+}
+
+// NewSecretResetToken returns the token to be stored and the raw value to be delivered.
+func NewSecretResetToken(accountID string, createdAt *time.Time, ttl time.Duration) (*SecretResetToken, string, error) {
+	secretResetToken := &SecretResetToken{
+		ID:        NewUUID(),
+		AccountID: accountID,
+		CreatedAt: createdAt,
+	}
+
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if accountID == "" {
+		validationError.Add("account id cannot be empty")
+	}
+
+	if createdAt == nil {
+		validationError.Add("created at cannot be nil")
+	} else if ttl <= 0 {
+		validationError.Add("secret reset token ttl must be greater than zero")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, "", validationError
+	}
+
+	expiresAt := createdAt.Add(ttl)
+	secretResetToken.ExpiresAt = &expiresAt
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", NewErrorHandler(INTERNAL_ERROR).Add("error on generating secret reset token")
+	}
+
+	raw := base64.RawURLEncoding.EncodeToString(random)
+	secretResetToken.TokenHash = HashSecretResetToken(raw)
+
+	return secretResetToken, raw, nil
+}
+
+func HashSecretResetToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *SecretResetToken) IsUsed() bool {
+	return s.UsedAt != nil
+}
+
+func (s *SecretResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(*s.ExpiresAt)
+}
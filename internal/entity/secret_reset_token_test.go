@@ -0,0 +1,47 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSecretResetToken(t *testing.T) {
+	t.Run("Testing NewSecretResetToken with success", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		secretResetToken, raw, err := entity.NewSecretResetToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", &createdAt, 15*time.Minute)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, raw)
+		assert.NotEmpty(t, secretResetToken.ID)
+		assert.Equal(t, entity.HashSecretResetToken(raw), secretResetToken.TokenHash)
+		assert.NotEqual(t, raw, secretResetToken.TokenHash)
+		assert.Equal(t, createdAt.Add(15*time.Minute), *secretResetToken.ExpiresAt)
+		assert.False(t, secretResetToken.IsUsed())
+	})
+
+	t.Run("Testing NewSecretResetToken with invalid parameters", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		secretResetToken, raw, err := entity.NewSecretResetToken("", &createdAt, 0)
+
+		assert.Nil(t, secretResetToken)
+		assert.Empty(t, raw)
+		assert.Equal(t, []string{"account id cannot be empty", "secret reset token ttl must be greater than zero"}, err.(*entity.ErrorHandler).Messages)
+
+		_, _, err = entity.NewSecretResetToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", nil, time.Hour)
+		assert.Equal(t, "created at cannot be nil", err.Error())
+	})
+}
+
+func TestSecretResetToken_IsExpired(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	secretResetToken, _, err := entity.NewSecretResetToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", &createdAt, 15*time.Minute)
+	assert.Nil(t, err)
+
+	assert.False(t, secretResetToken.IsExpired(createdAt.Add(14*time.Minute)))
+	assert.True(t, secretResetToken.IsExpired(createdAt.Add(15*time.Minute)))
+}
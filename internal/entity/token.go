@@ -0,0 +1,11 @@
+package entity
+
+// TokenType is the "typ" claim of the tokens signed by the API. Only access tokens
+// authenticate requests, an MFA token only proves the secret was correct and can just be
+// traded for an access token with a TOTP code.
+type TokenType string
+
+const (
+	ACCESS_TOKEN_TYPE TokenType = "access"
+	MFA_TOKEN_TYPE    TokenType = "mfa"
+)
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	TOTP_DIGITS = 6
+	TOTP_PERIOD = 30 * time.Second
+
+	// TOTP_SKEW is how many steps before or after the current one are accepted, to tolerate
+	// the clock drift of the authenticator.
+	TOTP_SKEW = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP is the time based one time password authenticator of an account (RFC 6238, SHA1,
+// 6 digits, 30 seconds). The secret only counts as a second factor after a code generated
+// from it is confirmed, and each time step is accepted only once.
+type TOTP struct {
+	AccountID    string
+	Secret       string
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+	CreatedAt    *time.Time
+}
+
+func NewTOTP(accountID string, createdAt *time.Time) (*TOTP, error) {
+	random := make([]byte, 20)
+	if _, err := rand.Read(random); err != nil {
+		return nil, NewErrorHandler(INTERNAL_ERROR).Add("error on generating totp secret")
+	}
+
+	totp := &TOTP{
+		AccountID: accountID,
+		Secret:    totpEncoding.EncodeToString(random),
+		CreatedAt: createdAt,
+	}
+
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if accountID == "" {
+		validationError.Add("account id cannot be empty")
+	}
+
+	if createdAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	return totp, nil
+}
+
+func (t *TOTP) IsConfirmed() bool {
+	return t.ConfirmedAt != nil
+}
+
+// ProvisioningURI is the otpauth URI shown as a QR code to enroll the authenticator.
+func (t *TOTP) ProvisioningURI(issuer string, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", t.Secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTP_DIGITS))
+	query.Set("period", fmt.Sprint(int(TOTP_PERIOD.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// MatchStep returns the time step of the code when it is valid around now and newer than the
+// last step used, so a code cannot be replayed.
+func (t *TOTP) MatchStep(code string, now time.Time) (int64, bool) {
+	if len(code) != TOTP_DIGITS {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	for step := current - TOTP_SKEW; step <= current+TOTP_SKEW; step++ {
+		if step <= t.LastUsedStep {
+			continue
+		}
+
+		expected, err := GenerateTOTPCode(t.Secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func TOTPStep(now time.Time) int64 {
+	return now.Unix() / int64(TOTP_PERIOD.Seconds())
+}
+
+// GenerateTOTPCode computes the code of a time step as described by RFC 4226.
+func GenerateTOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", NewErrorHandler(INTERNAL_ERROR).Add("totp secret is invalid")
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < TOTP_DIGITS; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TOTP_DIGITS, value%modulo), nil
+}
@@ -0,0 +1,122 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret "12345678901234567890" of the RFC 6238 test vectors
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestNewTOTP(t *testing.T) {
+	t.Run("Testing NewTOTP with success", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		totp, err := entity.NewTOTP("8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", &createdAt)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", totp.AccountID)
+		assert.Len(t, totp.Secret, 32)
+		assert.False(t, totp.IsConfirmed())
+	})
+
+	t.Run("Testing NewTOTP without account id and created at", func(t *testing.T) {
+		totp, err := entity.NewTOTP("", nil)
+
+		assert.Nil(t, totp)
+		assert.Equal(t, "account id cannot be empty, created at cannot be nil", err.Error())
+	})
+}
+
+func TestGenerateTOTPCode(t *testing.T) {
+	t.Run("Testing GenerateTOTPCode with the RFC 6238 test vectors", func(t *testing.T) {
+		vectors := map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		}
+
+		for seconds, expected := range vectors {
+			code, err := entity.GenerateTOTPCode(rfcTOTPSecret, entity.TOTPStep(time.Unix(seconds, 0)))
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, code)
+		}
+	})
+
+	t.Run("Testing GenerateTOTPCode with an invalid secret", func(t *testing.T) {
+		_, err := entity.GenerateTOTPCode("not base32!", 1)
+
+		assert.Equal(t, "totp secret is invalid", err.Error())
+	})
+}
+
+func TestTOTP_MatchStep(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := entity.TOTPStep(now)
+
+	t.Run("Testing MatchStep with the code of the current step", func(t *testing.T) {
+		totp := entity.TOTP{Secret: rfcTOTPSecret}
+
+		matched, ok := totp.MatchStep("050471", now)
+
+		assert.True(t, ok)
+		assert.Equal(t, step, matched)
+	})
+
+	t.Run("Testing MatchStep accepts the code of the previous step", func(t *testing.T) {
+		totp := entity.TOTP{Secret: rfcTOTPSecret}
+		code, _ := entity.GenerateTOTPCode(rfcTOTPSecret, step-1)
+
+		matched, ok := totp.MatchStep(code, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, step-1, matched)
+	})
+
+	t.Run("Testing MatchStep rejects a code out of the skew", func(t *testing.T) {
+		totp := entity.TOTP{Secret: rfcTOTPSecret}
+		code, _ := entity.GenerateTOTPCode(rfcTOTPSecret, step-2)
+
+		_, ok := totp.MatchStep(code, now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Testing MatchStep rejects a step already used", func(t *testing.T) {
+		totp := entity.TOTP{Secret: rfcTOTPSecret, LastUsedStep: step}
+
+		_, ok := totp.MatchStep("050471", now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Testing MatchStep rejects a code with the wrong length", func(t *testing.T) {
+		totp := entity.TOTP{Secret: rfcTOTPSecret}
+
+		_, ok := totp.MatchStep("50471", now)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestTOTP_ProvisioningURI(t *testing.T) {
+	t.Run("Testing ProvisioningURI", func(t *testing.T) {
+		totp := entity.TOTP{Secret: rfcTOTPSecret}
+
+		uri := totp.ProvisioningURI("bank-api", "8c3ea5f6")
+
+		assert.True(t, strings.HasPrefix(uri, "otpauth://totp/bank-api:8c3ea5f6?"))
+		assert.Contains(t, uri, "secret="+rfcTOTPSecret)
+		assert.Contains(t, uri, "issuer=bank-api")
+		assert.Contains(t, uri, "digits=6")
+		assert.Contains(t, uri, "period=30")
+	})
+}
@@ -2,18 +2,58 @@ package entity
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 )
 
+type TransferStatus string
+
+const (
+	PENDING_TRANSFER   TransferStatus = "PENDING"
+	COMPLETED_TRANSFER TransferStatus = "COMPLETED"
+	FAILED_TRANSFER    TransferStatus = "FAILED"
+	CANCELLED_TRANSFER TransferStatus = "CANCELLED"
+	REVERSED_TRANSFER  TransferStatus = "REVERSED"
+)
+
+const TRANSFER_FAILURE_REASON_MAX_LENGTH = 255
+
+// transferTransitions lists the statuses a transfer may move to from each status. FAILED,
+// CANCELLED and REVERSED are final.
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	PENDING_TRANSFER:   {COMPLETED_TRANSFER, FAILED_TRANSFER, CANCELLED_TRANSFER},
+	COMPLETED_TRANSFER: {REVERSED_TRANSFER},
+}
+
+// Transfer moves Amount out of the origin account and DestinationAmount into the destination
+// account. A reversal is a transfer back from the destination to the origin of the transfer
+// in ReversalOf, ReversedAmount sums the reversals already made against a transfer.
+// StatusHistory holds every status the transfer went through, oldest first. Fee is charged to
+// the origin account on top of the Amount, Fees is the part of it charged by each fee rule.
 type Transfer struct {
 	ID                 string
 	OriginAccount      *Account
 	DestinationAccount *Account
-	Amount             int
+	Amount             Money
+	DestinationAmount  Money
+	ExchangeRate       string
+	ReversalOf         string
+	ReversedAmount     Money
+	Fee                Money
+	Fees               []TransferFee
+	Status             TransferStatus
+	FailureReason      string
+	StatusHistory      []TransferStatusChange
 	CreatedAt          *time.Time
 }
 
-func NewTransfer(ID string, originAccount *Account, destinationAccount *Account, amount int, createdAt *time.Time) (*Transfer, error) {
+type TransferStatusChange struct {
+	Status    TransferStatus
+	Reason    string
+	ChangedAt *time.Time
+}
+
+func NewTransfer(ID string, originAccount *Account, destinationAccount *Account, amount Money, createdAt *time.Time) (*Transfer, error) {
 
 	if ID == "" {
 		ID = NewUUID()
@@ -24,6 +64,12 @@ func NewTransfer(ID string, originAccount *Account, destinationAccount *Account,
 		OriginAccount:      originAccount,
 		DestinationAccount: destinationAccount,
 		Amount:             amount,
+		DestinationAmount:  amount,
+		ExchangeRate:       "1",
+		ReversedAmount:     NewMoney(0, amount.Currency),
+		Fee:                NewMoney(0, amount.Currency),
+		Status:             PENDING_TRANSFER,
+		StatusHistory:      []TransferStatusChange{{Status: PENDING_TRANSFER, ChangedAt: createdAt}},
 		CreatedAt:          createdAt,
 	}
 
@@ -46,8 +92,13 @@ func (t *Transfer) isValid() error {
 		validationError.Add("destinationAccount cannot be nil")
 	}
 
-	if t.Amount < 0 {
-		validationError.Add("amount cannot be minor than zero")
+	// a transfer of nothing would be saved and only fail when its postings are made
+	if !t.Amount.IsPositive() {
+		validationError.Add("amount must be greater than zero")
+	}
+
+	if !t.Amount.Currency.IsValid() {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", t.Amount.Currency))
 	}
 
 	if t.CreatedAt == nil {
@@ -61,21 +112,257 @@ func (t *Transfer) isValid() error {
 	return nil
 }
 
+// MakeTransfer moves the money between the accounts and completes the pending transfer.
 func (t *Transfer) MakeTransfer() error {
 	validationError := NewErrorHandler(ENTITY_ERROR)
 
+	if t.Status != PENDING_TRANSFER {
+		validationError.Add(fmt.Sprintf("transfer is %s and cannot be made", t.Status))
+		return validationError
+	}
+
 	if t.OriginAccount.ID == t.DestinationAccount.ID {
 		validationError.Add("origin account id must be different to destination account id")
 		return validationError
 	}
 
-	err := t.OriginAccount.removeFromBalance(t.Amount)
+	if t.Amount.Currency != t.OriginAccount.Currency() {
+		validationError.Add(fmt.Sprintf("transfer currency %s must be the currency of the origin account", t.Amount.Currency))
+		return validationError
+	}
+
+	if t.DestinationAmount.Currency != t.DestinationAccount.Currency() {
+		validationError.Add(fmt.Sprintf("no exchange rate applied from %s to %s", t.Amount.Currency, t.DestinationAccount.Currency()))
+		return validationError
+	}
+
+	debit := t.Amount
+	if t.Fee.IsPositive() {
+		var err error
+		debit, err = t.Amount.Add(t.Fee)
+		if err != nil {
+			validationError.Add(fmt.Sprintf("error on add the fee to the amount: %s", err.Error()))
+			return validationError
+		}
+	}
+
+	err := t.OriginAccount.removeFromBalance(debit)
 	if err != nil {
 		validationError.Add(fmt.Sprintf("error on update balance of origin account: %s", err.Error()))
 		return validationError
 	}
 
-	t.DestinationAccount.addFromBalance(t.Amount)
+	err = t.DestinationAccount.addFromBalance(t.DestinationAmount)
+	if err != nil {
+		validationError.Add(fmt.Sprintf("error on update balance of destination account: %s", err.Error()))
+		return validationError
+	}
+
+	return t.changeStatus(COMPLETED_TRANSFER, "", t.CreatedAt)
+}
+
+// ChargeFees sets the fees of a pending transfer, Fee is the sum of the fees that were not
+// waived. The fees are in the currency of the amount.
+func (t *Transfer) ChargeFees(fees []TransferFee) error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if t.Status != PENDING_TRANSFER {
+		return validationError.Add(fmt.Sprintf("transfer is %s and cannot be charged", t.Status))
+	}
+
+	total := NewMoney(0, t.Amount.Currency)
+	for _, fee := range fees {
+		if fee.Amount.Currency != t.Amount.Currency {
+			return validationError.Add(fmt.Sprintf("fee %s must be in %s", fee.Rule, t.Amount.Currency))
+		}
+
+		if fee.Amount.IsNegative() {
+			return validationError.Add(fmt.Sprintf("fee %s cannot be negative", fee.Rule))
+		}
+
+		if fee.Waived {
+			continue
+		}
+
+		var err error
+		total, err = total.Add(fee.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.Fee = total
+	t.Fees = fees
 
 	return nil
 }
+
+// Fail records why a pending transfer could not be made, the balances are not touched.
+func (t *Transfer) Fail(failure error) error {
+	reason := failure.Error()
+	if len(reason) > TRANSFER_FAILURE_REASON_MAX_LENGTH {
+		reason = reason[:TRANSFER_FAILURE_REASON_MAX_LENGTH]
+	}
+
+	err := t.changeStatus(FAILED_TRANSFER, reason, t.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	t.FailureReason = reason
+	return nil
+}
+
+// Cancel withdraws a transfer that is still pending.
+func (t *Transfer) Cancel(now time.Time) error {
+	return t.changeStatus(CANCELLED_TRANSFER, "", &now)
+}
+
+func (t *Transfer) changeStatus(status TransferStatus, reason string, changedAt *time.Time) error {
+	for _, allowed := range transferTransitions[t.Status] {
+		if allowed == status {
+			t.Status = status
+			t.StatusHistory = append(t.StatusHistory, TransferStatusChange{Status: status, Reason: reason, ChangedAt: changedAt})
+			return nil
+		}
+	}
+
+	return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer is %s and cannot move to %s", t.Status, status))
+}
+
+// ApplyExchangeRate converts the amount to the currency of the destination account, the
+// origin account is still debited in its own currency.
+func (t *Transfer) ApplyExchangeRate(exchangeRate ExchangeRate) error {
+	if exchangeRate.From != t.Amount.Currency || exchangeRate.To != t.DestinationAccount.Currency() {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("exchange rate from %s to %s cannot be applied to a transfer from %s to %s", exchangeRate.From, exchangeRate.To, t.Amount.Currency, t.DestinationAccount.Currency()))
+	}
+
+	destinationAmount, err := exchangeRate.Convert(t.Amount)
+	if err != nil {
+		return err
+	}
+
+	t.DestinationAmount = destinationAmount
+	t.ExchangeRate = exchangeRate.Rate
+
+	return nil
+}
+
+// IsCrossCurrency tells whether the money changes currency between the accounts.
+func (t *Transfer) IsCrossCurrency() bool {
+	return t.Amount.Currency != t.DestinationAmount.Currency
+}
+
+func (t *Transfer) IsReversal() bool {
+	return t.ReversalOf != ""
+}
+
+// ReversibleAmount is the part of the amount that was not reversed yet.
+func (t *Transfer) ReversibleAmount() Money {
+	return NewMoney(t.Amount.Amount-t.ReversedAmount.Amount, t.Amount.Currency)
+}
+
+// Reverse creates the transfer that gives back the amount, in the currency of the transfer,
+// to the origin account and adds it to ReversedAmount. A cross-currency transfer is reversed
+// at its own rate: the destination account is debited the same share of the DestinationAmount,
+// so reversing the whole amount, at once or in parts, returns exactly what each side moved.
+// The transfer is REVERSED once nothing is left to reverse.
+func (t *Transfer) Reverse(ID string, amount Money, createdAt *time.Time, window time.Duration) (*Transfer, error) {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if t.IsReversal() {
+		return nil, validationError.Add("a reversal cannot be reversed")
+	}
+
+	if t.Status != COMPLETED_TRANSFER {
+		return nil, validationError.Add(fmt.Sprintf("transfer is %s and cannot be reversed", t.Status))
+	}
+
+	if createdAt == nil || createdAt.Sub(*t.CreatedAt) > window {
+		return nil, validationError.Add(fmt.Sprintf("transfer can only be reversed within %s of its creation", window))
+	}
+
+	if amount.Currency != t.Amount.Currency {
+		return nil, validationError.Add(fmt.Sprintf("reversal currency %s must be the currency of the transfer", amount.Currency))
+	}
+
+	if !amount.IsPositive() {
+		return nil, validationError.Add("reversal amount must be greater than zero")
+	}
+
+	reversible := t.ReversibleAmount()
+	if amount.Amount > reversible.Amount {
+		return nil, validationError.Add(fmt.Sprintf("reversal amount cannot be greater than the reversible amount of %s %s", reversible, reversible.Currency))
+	}
+
+	reversedAmount := NewMoney(t.ReversedAmount.Amount+amount.Amount, t.Amount.Currency)
+
+	// the debit is the difference between the shares reversed after and before this reversal,
+	// so the rounding of the parts never adds up to more than the DestinationAmount
+	debit := t.destinationShare(reversedAmount) - t.destinationShare(t.ReversedAmount)
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	reversal := &Transfer{
+		ID:                 ID,
+		OriginAccount:      t.DestinationAccount,
+		DestinationAccount: t.OriginAccount,
+		Amount:             NewMoney(debit, t.DestinationAmount.Currency),
+		DestinationAmount:  amount,
+		ExchangeRate:       t.ExchangeRate,
+		ReversalOf:         t.ID,
+		ReversedAmount:     NewMoney(0, t.DestinationAmount.Currency),
+		Fee:                NewMoney(0, t.DestinationAmount.Currency),
+		Status:             PENDING_TRANSFER,
+		StatusHistory:      []TransferStatusChange{{Status: PENDING_TRANSFER, ChangedAt: createdAt}},
+		CreatedAt:          createdAt,
+	}
+
+	if !reversal.Amount.IsPositive() {
+		return nil, validationError.Add("reversal amount is too small to be converted")
+	}
+
+	t.ReversedAmount = reversedAmount
+	if t.ReversibleAmount().IsZero() {
+		err := t.changeStatus(REVERSED_TRANSFER, "", createdAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reversal, nil
+}
+
+// destinationShare is the part of the DestinationAmount that corresponds to the given part of
+// the Amount, rounded half away from zero.
+func (t *Transfer) destinationShare(amount Money) int64 {
+	if t.Amount.Amount == 0 {
+		return 0
+	}
+
+	share := new(big.Int).Mul(big.NewInt(t.DestinationAmount.Amount), big.NewInt(amount.Amount))
+	quotient, remainder := new(big.Int).QuoRem(share, big.NewInt(t.Amount.Amount), new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(big.NewInt(t.Amount.Amount)) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	return quotient.Int64()
+}
+
+// DirectionFor tells whether the transfer left (OUTGOING) or reached (INCOMING) the given account.
+func (t *Transfer) DirectionFor(accountID string) TransferDirection {
+	if t.OriginAccount != nil && t.OriginAccount.ID == accountID {
+		return OUTGOING
+	}
+	return INCOMING
+}
+
+// CounterpartyFor returns the other side of the transfer from the point of view of the given account.
+func (t *Transfer) CounterpartyFor(accountID string) *Account {
+	if t.DirectionFor(accountID) == OUTGOING {
+		return t.DestinationAccount
+	}
+	return t.OriginAccount
+}
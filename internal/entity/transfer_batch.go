@@ -0,0 +1,289 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type TransferBatchMode string
+
+const (
+	ATOMIC_BATCH      TransferBatchMode = "ATOMIC"
+	BEST_EFFORT_BATCH TransferBatchMode = "BEST_EFFORT"
+)
+
+type TransferBatchStatus string
+
+const (
+	PENDING_BATCH             TransferBatchStatus = "PENDING"
+	PROCESSING_BATCH          TransferBatchStatus = "PROCESSING"
+	COMPLETED_BATCH           TransferBatchStatus = "COMPLETED"
+	PARTIALLY_COMPLETED_BATCH TransferBatchStatus = "PARTIALLY_COMPLETED"
+	FAILED_BATCH              TransferBatchStatus = "FAILED"
+)
+
+type TransferBatchItemStatus string
+
+const (
+	PENDING_BATCH_ITEM   TransferBatchItemStatus = "PENDING"
+	SUCCEEDED_BATCH_ITEM TransferBatchItemStatus = "SUCCEEDED"
+	FAILED_BATCH_ITEM    TransferBatchItemStatus = "FAILED"
+	CANCELLED_BATCH_ITEM TransferBatchItemStatus = "CANCELLED"
+)
+
+const BATCH_FAILURE_REASON_MAX_LENGTH = 255
+
+// TransferBatch is a set of transfers from one origin account made in the background. An
+// ATOMIC batch makes all of its transfers or none of them, a BEST_EFFORT batch makes each
+// transfer on its own and reports the outcome of every item. Total is the sum of the items,
+// all of them in the currency of the origin account. FailureReason tells why an ATOMIC batch
+// was rolled back.
+type TransferBatch struct {
+	ID              string
+	OriginAccountID string
+	Mode            TransferBatchMode
+	Status          TransferBatchStatus
+	Total           Money
+	FailureReason   string
+	Items           []TransferBatchItem
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+}
+
+// TransferBatchItem is one transfer of a batch, Position is its index in the request. A
+// CANCELLED item was rolled back, or never made, because another item of its ATOMIC batch
+// failed.
+type TransferBatchItem struct {
+	Position             int
+	DestinationAccountID string
+	Amount               Money
+	Status               TransferBatchItemStatus
+	TransferID           string
+	FailureReason        string
+}
+
+func NewTransferBatch(ID string, originAccountID string, mode TransferBatchMode, items []TransferBatchItem, createdAt *time.Time) (*TransferBatch, error) {
+
+	if ID == "" {
+		ID = NewUUID()
+	}
+
+	batch := &TransferBatch{
+		ID:              ID,
+		OriginAccountID: originAccountID,
+		Mode:            mode,
+		Status:          PENDING_BATCH,
+		Items:           make([]TransferBatchItem, len(items)),
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+
+	for i, item := range items {
+		batch.Items[i] = TransferBatchItem{
+			Position:             i,
+			DestinationAccountID: item.DestinationAccountID,
+			Amount:               item.Amount,
+			Status:               PENDING_BATCH_ITEM,
+		}
+	}
+
+	err := batch.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (b *TransferBatch) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if b.OriginAccountID == "" {
+		validationError.Add("origin account id cannot be empty")
+	}
+
+	if b.Mode != ATOMIC_BATCH && b.Mode != BEST_EFFORT_BATCH {
+		validationError.Add(fmt.Sprintf("mode must be %s or %s", ATOMIC_BATCH, BEST_EFFORT_BATCH))
+	}
+
+	if b.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(b.Items) == 0 {
+		validationError.Add("items cannot be empty")
+	}
+
+	var currency Currency
+	if len(b.Items) > 0 {
+		currency = b.Items[0].Amount.Currency
+	}
+
+	b.Total = NewMoney(0, currency)
+	for _, item := range b.Items {
+		invalid := func(message string) {
+			validationError.Add(fmt.Sprintf("item %d: %s", item.Position, message))
+		}
+
+		if item.DestinationAccountID == "" {
+			invalid("destination account id cannot be empty")
+		} else if item.DestinationAccountID == b.OriginAccountID {
+			invalid("origin account id must be different to destination account id")
+		}
+
+		if !item.Amount.IsPositive() {
+			invalid("amount must be greater than zero")
+		}
+
+		if item.Amount.Currency != currency {
+			invalid(fmt.Sprintf("amount must be in %s like the other items", currency))
+			continue
+		}
+
+		total, err := b.Total.Add(item.Amount)
+		if err != nil {
+			invalid(err.Error())
+			continue
+		}
+		b.Total = total
+	}
+
+	if !currency.IsValid() && len(b.Items) > 0 {
+		validationError.Add(fmt.Sprintf("currency is invalid: %s", currency))
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// Start claims a pending batch for processing.
+func (b *TransferBatch) Start(now time.Time) error {
+	if b.Status != PENDING_BATCH {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer batch is %s and cannot be started", b.Status))
+	}
+
+	b.Status = PROCESSING_BATCH
+	b.UpdatedAt = &now
+	return nil
+}
+
+// RecordItem keeps the outcome of the transfer of an item, the transfer made or the reason it
+// failed.
+func (b *TransferBatch) RecordItem(position int, transferID string, failure error) {
+	item := &b.Items[position]
+
+	if failure != nil {
+		item.Status = FAILED_BATCH_ITEM
+		item.TransferID = ""
+		item.FailureReason = batchFailureReason(failure.Error())
+		return
+	}
+
+	item.Status = SUCCEEDED_BATCH_ITEM
+	item.TransferID = transferID
+	item.FailureReason = ""
+}
+
+// RollBack cancels every item of an ATOMIC batch after the failure, the item at position
+// failed with it. A negative position is a failure of the batch as a whole.
+func (b *TransferBatch) RollBack(position int, failure error) {
+	for i := range b.Items {
+		b.Items[i].Status = CANCELLED_BATCH_ITEM
+		b.Items[i].TransferID = ""
+		b.Items[i].FailureReason = ""
+	}
+
+	if position >= 0 && position < len(b.Items) {
+		b.RecordItem(position, "", failure)
+		b.FailureReason = batchFailureReason(fmt.Sprintf("item %d: %s", position, failure.Error()))
+		return
+	}
+
+	b.FailureReason = batchFailureReason(failure.Error())
+}
+
+// Finish ends the processing with the outcome of the items: COMPLETED when every transfer
+// was made, FAILED when none was and PARTIALLY_COMPLETED otherwise.
+func (b *TransferBatch) Finish(now time.Time) error {
+	if b.Status != PROCESSING_BATCH {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer batch is %s and cannot be finished", b.Status))
+	}
+
+	succeeded := b.Count(SUCCEEDED_BATCH_ITEM)
+
+	switch succeeded {
+	case len(b.Items):
+		b.Status = COMPLETED_BATCH
+	case 0:
+		b.Status = FAILED_BATCH
+	default:
+		b.Status = PARTIALLY_COMPLETED_BATCH
+	}
+
+	b.UpdatedAt = &now
+	return nil
+}
+
+// Fail ends a processing that broke before the batch could be finished. The items keep the
+// outcome already recorded, the ones still PENDING were not made or their outcome was lost.
+func (b *TransferBatch) Fail(failure error, now time.Time) error {
+	if b.Status != PROCESSING_BATCH {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer batch is %s and cannot be failed", b.Status))
+	}
+
+	b.Status = FAILED_BATCH
+	b.FailureReason = batchFailureReason(failure.Error())
+	b.UpdatedAt = &now
+	return nil
+}
+
+// Count returns how many items are in the status.
+func (b *TransferBatch) Count(status TransferBatchItemStatus) int {
+	count := 0
+	for _, item := range b.Items {
+		if item.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// DestinationAccountIDs lists the destination accounts of the batch once each, in the order
+// they first appear.
+func (b *TransferBatch) DestinationAccountIDs() []string {
+	seen := map[string]bool{}
+	IDs := []string{}
+
+	for _, item := range b.Items {
+		if seen[item.DestinationAccountID] {
+			continue
+		}
+
+		seen[item.DestinationAccountID] = true
+		IDs = append(IDs, item.DestinationAccountID)
+	}
+
+	return IDs
+}
+
+// Amounts lists the amounts of the items in their order.
+func (b *TransferBatch) Amounts() []Money {
+	amounts := make([]Money, len(b.Items))
+	for i, item := range b.Items {
+		amounts[i] = item.Amount
+	}
+
+	return amounts
+}
+
+func batchFailureReason(reason string) string {
+	if len(reason) > BATCH_FAILURE_REASON_MAX_LENGTH {
+		return reason[:BATCH_FAILURE_REASON_MAX_LENGTH]
+	}
+
+	return reason
+}
@@ -0,0 +1,167 @@
+package entity_test
+
+import (
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetBaseTransferBatch(t *testing.T, mode entity.TransferBatchMode) *entity.TransferBatch {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	batch, err := entity.NewTransferBatch("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", mode, []entity.TransferBatchItem{
+		{DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Amount: entity.NewMoney(5000, entity.BRL)},
+		{DestinationAccountID: "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", Amount: entity.NewMoney(2500, entity.BRL)},
+		{DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Amount: entity.NewMoney(1000, entity.BRL)},
+	}, &createdAt)
+	assert.Nil(t, err)
+
+	return batch
+}
+
+func TestTransferBatch_NewTransferBatch(t *testing.T) {
+	t.Run("Testing NewTransferBatch sums the items and leaves them pending", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.ATOMIC_BATCH)
+
+		assert.NotEmpty(t, batch.ID)
+		assert.Equal(t, entity.PENDING_BATCH, batch.Status)
+		assert.Equal(t, entity.NewMoney(8500, entity.BRL), batch.Total)
+		assert.Equal(t, 3, batch.Count(entity.PENDING_BATCH_ITEM))
+		assert.Equal(t, 2, batch.Items[2].Position)
+		assert.Equal(t, []string{"d18551d3-cf13-49ec-b1dc-741a1f8715f6", "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"}, batch.DestinationAccountIDs())
+		assert.Equal(t, []entity.Money{entity.NewMoney(5000, entity.BRL), entity.NewMoney(2500, entity.BRL), entity.NewMoney(1000, entity.BRL)}, batch.Amounts())
+	})
+
+	t.Run("Testing NewTransferBatch when returning invalid items", func(t *testing.T) {
+		createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+		_, err := entity.NewTransferBatch("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "SOME", []entity.TransferBatchItem{
+			{DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Amount: entity.NewMoney(5000, entity.BRL)},
+			{DestinationAccountID: "", Amount: entity.NewMoney(0, entity.BRL)},
+			{DestinationAccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", Amount: entity.NewMoney(100, entity.USD)},
+		}, &createdAt)
+
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"mode must be ATOMIC or BEST_EFFORT",
+			"item 1: destination account id cannot be empty",
+			"item 1: amount must be greater than zero",
+			"item 2: origin account id must be different to destination account id",
+			"item 2: amount must be in BRL like the other items",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+
+	t.Run("Testing NewTransferBatch without items", func(t *testing.T) {
+		_, err := entity.NewTransferBatch("", "", entity.ATOMIC_BATCH, nil, nil)
+
+		assert.Equal(t, []string{
+			"origin account id cannot be empty",
+			"created at cannot be nil",
+			"items cannot be empty",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestTransferBatch_Finish(t *testing.T) {
+	now := time.Date(2023, 8, 14, 10, 00, 10, 00, time.UTC)
+
+	t.Run("Testing Finish of a batch whose items partially succeeded", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		assert.Nil(t, batch.Start(now))
+
+		batch.RecordItem(0, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", nil)
+		batch.RecordItem(1, "", errors.New("insufficient balance"))
+		batch.RecordItem(2, "237d3e7e-2f46-44e7-bf2b-f79721459241", nil)
+
+		assert.Nil(t, batch.Finish(now))
+		assert.Equal(t, entity.PARTIALLY_COMPLETED_BATCH, batch.Status)
+		assert.Equal(t, 2, batch.Count(entity.SUCCEEDED_BATCH_ITEM))
+		assert.Equal(t, "insufficient balance", batch.Items[1].FailureReason)
+		assert.Equal(t, &now, batch.UpdatedAt)
+	})
+
+	t.Run("Testing Finish of a batch whose items all succeeded or all failed", func(t *testing.T) {
+		completed := GetBaseTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		assert.Nil(t, completed.Start(now))
+		for i := range completed.Items {
+			completed.RecordItem(i, entity.NewUUID(), nil)
+		}
+		assert.Nil(t, completed.Finish(now))
+		assert.Equal(t, entity.COMPLETED_BATCH, completed.Status)
+
+		failed := GetBaseTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		assert.Nil(t, failed.Start(now))
+		for i := range failed.Items {
+			failed.RecordItem(i, "", errors.New("not found account"))
+		}
+		assert.Nil(t, failed.Finish(now))
+		assert.Equal(t, entity.FAILED_BATCH, failed.Status)
+	})
+
+	t.Run("Testing Start and Finish out of order", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.ATOMIC_BATCH)
+
+		err := batch.Finish(now)
+		assert.Equal(t, "transfer batch is PENDING and cannot be finished", err.Error())
+
+		assert.Nil(t, batch.Start(now))
+		err = batch.Start(now)
+		assert.Equal(t, "transfer batch is PROCESSING and cannot be started", err.Error())
+	})
+}
+
+func TestTransferBatch_Fail(t *testing.T) {
+	now := time.Date(2023, 8, 14, 10, 00, 10, 00, time.UTC)
+
+	t.Run("Testing Fail keeps the outcome of the items already made", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		assert.Nil(t, batch.Start(now))
+
+		batch.RecordItem(0, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", nil)
+
+		assert.Nil(t, batch.Fail(errors.New("connection closed"), now))
+		assert.Equal(t, entity.FAILED_BATCH, batch.Status)
+		assert.Equal(t, "connection closed", batch.FailureReason)
+		assert.Equal(t, entity.SUCCEEDED_BATCH_ITEM, batch.Items[0].Status)
+		assert.Equal(t, 2, batch.Count(entity.PENDING_BATCH_ITEM))
+		assert.Equal(t, &now, batch.UpdatedAt)
+	})
+
+	t.Run("Testing Fail of a batch that is not processing", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.ATOMIC_BATCH)
+
+		err := batch.Fail(errors.New("connection closed"), now)
+		assert.Equal(t, "transfer batch is PENDING and cannot be failed", err.Error())
+	})
+}
+
+func TestTransferBatch_RollBack(t *testing.T) {
+	t.Run("Testing RollBack cancels every item and fails the item at the position", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.ATOMIC_BATCH)
+		assert.Nil(t, batch.Start(time.Now()))
+		batch.RecordItem(0, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", nil)
+
+		batch.RollBack(1, errors.New("insufficient balance"))
+		assert.Nil(t, batch.Finish(time.Now()))
+
+		assert.Equal(t, entity.FAILED_BATCH, batch.Status)
+		assert.Equal(t, "item 1: insufficient balance", batch.FailureReason)
+		assert.Equal(t, entity.CANCELLED_BATCH_ITEM, batch.Items[0].Status)
+		assert.Empty(t, batch.Items[0].TransferID)
+		assert.Equal(t, entity.FAILED_BATCH_ITEM, batch.Items[1].Status)
+		assert.Equal(t, entity.CANCELLED_BATCH_ITEM, batch.Items[2].Status)
+	})
+
+	t.Run("Testing RollBack of a failure of the whole batch truncates the reason", func(t *testing.T) {
+		batch := GetBaseTransferBatch(t, entity.ATOMIC_BATCH)
+
+		batch.RollBack(-1, errors.New(strings.Repeat("a", 300)))
+
+		assert.Len(t, batch.FailureReason, entity.BATCH_FAILURE_REASON_MAX_LENGTH)
+		assert.Equal(t, 3, batch.Count(entity.CANCELLED_BATCH_ITEM))
+	})
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+type TransferDirection string
+
+const (
+	INCOMING TransferDirection = "INCOMING"
+	OUTGOING TransferDirection = "OUTGOING"
+)
+
+// TransferFilter narrows the transfer history of an account. Zero values mean "no restriction":
+// an empty direction returns both incoming and outgoing transfers.
+type TransferFilter struct {
+	Direction      TransferDirection
+	From           *time.Time
+	To             *time.Time
+	MinAmount      *Money
+	MaxAmount      *Money
+	CounterpartyID string
+}
+
+func NewTransferFilter(direction string, from *time.Time, to *time.Time, minAmount *Money, maxAmount *Money, counterpartyID string) (*TransferFilter, error) {
+	filter := &TransferFilter{
+		Direction:      TransferDirection(strings.ToUpper(direction)),
+		From:           from,
+		To:             to,
+		MinAmount:      minAmount,
+		MaxAmount:      maxAmount,
+		CounterpartyID: counterpartyID,
+	}
+
+	err := filter.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+func (f *TransferFilter) isValid() error {
+	validationError := NewErrorHandler(BAD_REQUEST)
+
+	if f.Direction != "" && f.Direction != INCOMING && f.Direction != OUTGOING {
+		validationError.Add("direction must be incoming or outgoing")
+	}
+
+	if f.From != nil && f.To != nil && f.From.After(*f.To) {
+		validationError.Add("from cannot be after to")
+	}
+
+	if f.MinAmount != nil && f.MinAmount.IsNegative() {
+		validationError.Add("min_amount cannot be minor than zero")
+	}
+
+	if f.MaxAmount != nil && f.MaxAmount.IsNegative() {
+		validationError.Add("max_amount cannot be minor than zero")
+	}
+
+	if f.MinAmount != nil && f.MaxAmount != nil {
+		comparison, err := f.MinAmount.Compare(*f.MaxAmount)
+		if err != nil {
+			validationError.Add("min_amount and max_amount must have the same currency")
+		} else if comparison > 0 {
+			validationError.Add("min_amount cannot be greater than max_amount")
+		}
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
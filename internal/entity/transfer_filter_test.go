@@ -0,0 +1,60 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferFilter_NewTransferFilter(t *testing.T) {
+	t.Run("Testing NewTransferFilter normalizes the direction", func(t *testing.T) {
+		filter, err := entity.NewTransferFilter("Outgoing", nil, nil, nil, nil, "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.OUTGOING, filter.Direction)
+	})
+
+	t.Run("Testing NewTransferFilter without restrictions", func(t *testing.T) {
+		filter, err := entity.NewTransferFilter("", nil, nil, nil, nil, "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TransferFilter{}, *filter)
+	})
+
+	t.Run("Testing NewTransferFilter when returning an invalid filter", func(t *testing.T) {
+		from := time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+		minAmount := entity.NewMoney(-1, entity.BRL)
+		maxAmount := entity.NewMoney(-2, entity.BRL)
+
+		filter, err := entity.NewTransferFilter("both", &from, &to, &minAmount, &maxAmount, "")
+
+		assert.Nil(t, filter)
+		assert.Equal(t, entity.BAD_REQUEST, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"direction must be incoming or outgoing",
+			"from cannot be after to",
+			"min_amount cannot be minor than zero",
+			"max_amount cannot be minor than zero",
+			"min_amount cannot be greater than max_amount",
+		}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestTransfer_DirectionFor(t *testing.T) {
+	t.Run("Testing DirectionFor and CounterpartyFor on both sides of a transfer", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		assert.Equal(t, entity.OUTGOING, transfer.DirectionFor(originAccount.ID))
+		assert.Equal(t, destinationAccount, transfer.CounterpartyFor(originAccount.ID))
+		assert.Equal(t, entity.INCOMING, transfer.DirectionFor(destinationAccount.ID))
+		assert.Equal(t, originAccount, transfer.CounterpartyFor(destinationAccount.ID))
+	})
+}
@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type TransferKeyType string
+
+const (
+	CPF_KEY    TransferKeyType = "CPF"
+	EMAIL_KEY  TransferKeyType = "EMAIL"
+	PHONE_KEY  TransferKeyType = "PHONE"
+	RANDOM_KEY TransferKeyType = "EVP"
+
+	// TRANSFER_KEY_MAX_PER_ACCOUNT is how many keys an account can have registered at once.
+	TRANSFER_KEY_MAX_PER_ACCOUNT = 5
+
+	EMAIL_KEY_MAX_LENGTH = 77
+)
+
+var (
+	REGEXEMAILKEY = regexp.MustCompile(`^[a-z0-9.!#$%&'*+/=?^_{|}~-]+@[a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)+$`)
+
+	// REGEXPHONEKEY is a phone number in the E.164 format, as +5511987654321.
+	REGEXPHONEKEY = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)
+)
+
+// TransferKey is an alias of an account that customers can share instead of the account ID,
+// so a transfer can be sent to a CPF, an email, a phone or a random key. A key points to a
+// single account, and a CPF key must be the CPF of the account it points to. The Key is kept
+// normalized, the way it is looked up.
+type TransferKey struct {
+	Key       string
+	Type      TransferKeyType
+	AccountID string
+	CreatedAt *time.Time
+}
+
+// NewTransferKey registers key for the account, the key of a random (EVP) key is generated
+// and must not be informed.
+func NewTransferKey(account *Account, keyType TransferKeyType, key string, createdAt *time.Time) (*TransferKey, error) {
+	transferKey := &TransferKey{
+		Type:      keyType,
+		CreatedAt: createdAt,
+	}
+
+	if account != nil {
+		transferKey.AccountID = account.ID
+	}
+
+	if keyType == RANDOM_KEY {
+		if strings.TrimSpace(key) != "" {
+			return nil, NewErrorHandler(ENTITY_ERROR).Add("key of type EVP is generated and cannot be informed")
+		}
+
+		key = NewUUID()
+	}
+
+	transferKey.Key = NormalizeTransferKey(keyType, key)
+
+	err := transferKey.isValid(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return transferKey, nil
+}
+
+func (t *TransferKey) isValid(account *Account) error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if account == nil {
+		validationError.Add("account cannot be nil")
+	}
+
+	switch t.Type {
+	case CPF_KEY:
+		if !isCPF(t.Key) {
+			validationError.Add("key must be a valid CPF")
+		} else if account != nil && t.Key != normalizedCPF(account.CPF) {
+			validationError.Add("key must be the CPF of the account")
+		}
+	case EMAIL_KEY:
+		if utf8.RuneCountInString(t.Key) > EMAIL_KEY_MAX_LENGTH {
+			validationError.Add(fmt.Sprintf("key must have at most %d characters", EMAIL_KEY_MAX_LENGTH))
+		} else if !REGEXEMAILKEY.MatchString(t.Key) {
+			validationError.Add("key must be a valid email")
+		}
+	case PHONE_KEY:
+		if !REGEXPHONEKEY.MatchString(t.Key) {
+			validationError.Add("key must be a phone number in the format +5511987654321")
+		}
+	case RANDOM_KEY:
+		// generated, nothing to check
+	default:
+		validationError.Add("type must be CPF, EMAIL, PHONE or EVP")
+	}
+
+	if t.CreatedAt == nil {
+		validationError.Add("created at cannot be nil")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+// NormalizeTransferKey writes key the way it is stored: CPFs only with their digits, emails in
+// lower case and phones without spaces, dashes or parentheses.
+func NormalizeTransferKey(keyType TransferKeyType, key string) string {
+	key = strings.TrimSpace(key)
+
+	switch keyType {
+	case CPF_KEY:
+		return normalizedCPF(key)
+	case EMAIL_KEY:
+		return strings.ToLower(key)
+	case PHONE_KEY:
+		return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(key)
+	}
+
+	return key
+}
+
+// GuessTransferKeyType tells the type of a key informed without one, as when a transfer is sent
+// to a key. Phones start with a plus sign, so they are never taken for a CPF.
+func GuessTransferKeyType(key string) TransferKeyType {
+	key = strings.TrimSpace(key)
+
+	switch {
+	case strings.Contains(key, "@"):
+		return EMAIL_KEY
+	case strings.HasPrefix(key, "+"):
+		return PHONE_KEY
+	case isCPF(key):
+		return CPF_KEY
+	}
+
+	return RANDOM_KEY
+}
+
+// MaskName hides the name of the owner of a key, leaving the first name and the initials of
+// the others, so the sender can confirm the recipient without learning the full name.
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i := 1; i < len(words); i++ {
+		first, size := utf8.DecodeRuneInString(words[i])
+		words[i] = string(first) + strings.Repeat("*", utf8.RuneCountInString(words[i][size:]))
+	}
+
+	return strings.Join(words, " ")
+}
+
+func normalizedCPF(CPF string) string {
+	cleanNonDigits(&CPF)
+	return CPF
+}
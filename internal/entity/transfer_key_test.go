@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferKey_NewTransferKey(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing NewTransferKey normalizes the key", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		CPFKey, err := entity.NewTransferKey(account, entity.CPF_KEY, "528.492.540-88", &createdAt)
+		assert.Nil(t, err)
+		assert.Equal(t, "52849254088", CPFKey.Key)
+		assert.Equal(t, account.ID, CPFKey.AccountID)
+
+		emailKey, err := entity.NewTransferKey(account, entity.EMAIL_KEY, " Lucas.Santos@Example.com ", &createdAt)
+		assert.Nil(t, err)
+		assert.Equal(t, "lucas.santos@example.com", emailKey.Key)
+
+		phoneKey, err := entity.NewTransferKey(account, entity.PHONE_KEY, "+55 (11) 98765-4321", &createdAt)
+		assert.Nil(t, err)
+		assert.Equal(t, "+5511987654321", phoneKey.Key)
+	})
+
+	t.Run("Testing NewTransferKey generates the random key", func(t *testing.T) {
+		randomKey, err := entity.NewTransferKey(GetBaseOriginAccount(t), entity.RANDOM_KEY, "", &createdAt)
+		assert.Nil(t, err)
+		assert.Len(t, randomKey.Key, 36)
+
+		_, err = entity.NewTransferKey(GetBaseOriginAccount(t), entity.RANDOM_KEY, "my-own-key", &createdAt)
+		assert.Equal(t, "key of type EVP is generated and cannot be informed", err.Error())
+	})
+
+	t.Run("Testing NewTransferKey when returning invalid keys", func(t *testing.T) {
+		account := GetBaseOriginAccount(t)
+
+		testCases := []struct {
+			keyType entity.TransferKeyType
+			key     string
+			message string
+		}{
+			{entity.CPF_KEY, "123.456.789-00", "key must be a valid CPF"},
+			{entity.CPF_KEY, "11144477735", "key must be the CPF of the account"},
+			{entity.EMAIL_KEY, "lucas.example.com", "key must be a valid email"},
+			{entity.EMAIL_KEY, strings.Repeat("a", 70) + "@example.com", "key must have at most 77 characters"},
+			{entity.PHONE_KEY, "11987654321", "key must be a phone number in the format +5511987654321"},
+			{"IBAN", "BR1500000000000010932840814P2", "type must be CPF, EMAIL, PHONE or EVP"},
+		}
+
+		for _, testCase := range testCases {
+			transferKey, err := entity.NewTransferKey(account, testCase.keyType, testCase.key, &createdAt)
+
+			assert.Nil(t, transferKey)
+			assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+			assert.Equal(t, testCase.message, err.Error())
+		}
+	})
+
+	t.Run("Testing NewTransferKey without account", func(t *testing.T) {
+		_, err := entity.NewTransferKey(nil, entity.EMAIL_KEY, "lucas@example.com", nil)
+
+		assert.Equal(t, []string{"account cannot be nil", "created at cannot be nil"}, err.(*entity.ErrorHandler).Messages)
+	})
+}
+
+func TestTransferKey_GuessTransferKeyType(t *testing.T) {
+	t.Run("Testing GuessTransferKeyType", func(t *testing.T) {
+		assert.Equal(t, entity.EMAIL_KEY, entity.GuessTransferKeyType("lucas@example.com"))
+		assert.Equal(t, entity.PHONE_KEY, entity.GuessTransferKeyType("+5511987654321"))
+		assert.Equal(t, entity.CPF_KEY, entity.GuessTransferKeyType("528.492.540-88"))
+		assert.Equal(t, entity.RANDOM_KEY, entity.GuessTransferKeyType("7c9e6679-7425-40de-944b-e07fc1f90ae7"))
+	})
+}
+
+func TestTransferKey_MaskName(t *testing.T) {
+	t.Run("Testing MaskName keeps the first name and the initials", func(t *testing.T) {
+		assert.Equal(t, "Lucas S***** d* O*******", entity.MaskName("Lucas Santos da Oliveira"))
+		assert.Equal(t, "Jaque", entity.MaskName("Jaque"))
+		assert.Equal(t, "João Á*****", entity.MaskName(" João  Álvaro "))
+	})
+}
@@ -0,0 +1,279 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransferLimit caps what an account can send: PerTransaction caps each transfer, Daily and
+// Monthly cap the sum sent in the calendar day and month, and Nighttime caps the sum sent
+// during the current night. The limits are in the currency of the account.
+type TransferLimit struct {
+	AccountID      string
+	PerTransaction Money
+	Daily          Money
+	Monthly        Money
+	Nighttime      Money
+	UpdatedAt      *time.Time
+}
+
+// TransferUsage is what an account already sent in each period its limits apply to,
+// Nighttime is zero outside the night.
+type TransferUsage struct {
+	Daily     Money
+	Monthly   Money
+	Nighttime Money
+}
+
+func NewTransferLimit(accountID string, perTransaction Money, daily Money, monthly Money, nighttime Money, updatedAt *time.Time) (*TransferLimit, error) {
+	transferLimit := &TransferLimit{
+		AccountID:      accountID,
+		PerTransaction: perTransaction,
+		Daily:          daily,
+		Monthly:        monthly,
+		Nighttime:      nighttime,
+		UpdatedAt:      updatedAt,
+	}
+
+	err := transferLimit.isValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return transferLimit, nil
+}
+
+func (l *TransferLimit) isValid() error {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	if l.AccountID == "" {
+		validationError.Add("account id is required")
+	}
+
+	for _, limit := range l.limits() {
+		if !limit.value.IsPositive() {
+			validationError.Add(fmt.Sprintf("%s limit must be greater than zero", limit.name))
+		}
+
+		if limit.value.Currency != l.Daily.Currency {
+			validationError.Add(fmt.Sprintf("%s limit must be in %s", limit.name, l.Daily.Currency))
+		}
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	if exceeds(l.PerTransaction, l.Daily) {
+		validationError.Add("per-transaction limit cannot be greater than the daily limit")
+	}
+
+	if exceeds(l.Nighttime, l.Daily) {
+		validationError.Add("nighttime limit cannot be greater than the daily limit")
+	}
+
+	if exceeds(l.Daily, l.Monthly) {
+		validationError.Add("daily limit cannot be greater than the monthly limit")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return validationError
+	}
+
+	return nil
+}
+
+type namedLimit struct {
+	name  string
+	value Money
+}
+
+func (l *TransferLimit) limits() []namedLimit {
+	return []namedLimit{
+		{name: "per-transaction", value: l.PerTransaction},
+		{name: "daily", value: l.Daily},
+		{name: "monthly", value: l.Monthly},
+		{name: "nighttime", value: l.Nighttime},
+	}
+}
+
+type limitPeriod struct {
+	name  string
+	limit Money
+	used  Money
+}
+
+// Check refuses an amount that would take the account over one of its limits, the error names
+// the limit and how much of it is still available.
+func (l *TransferLimit) Check(amount Money, usage TransferUsage, night bool) error {
+	if amount.Currency != l.PerTransaction.Currency {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer limits are in %s, the amount is in %s", l.PerTransaction.Currency, amount.Currency))
+	}
+
+	if exceeds(amount, l.PerTransaction) {
+		return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer exceeds the per-transaction limit of %s %s", l.PerTransaction, l.PerTransaction.Currency))
+	}
+
+	periods := []limitPeriod{
+		{name: "daily", limit: l.Daily, used: usage.Daily},
+		{name: "monthly", limit: l.Monthly, used: usage.Monthly},
+	}
+	if night {
+		periods = append([]limitPeriod{{name: "nighttime", limit: l.Nighttime, used: usage.Nighttime}}, periods...)
+	}
+
+	for _, period := range periods {
+		total, err := period.used.Add(amount)
+		if err != nil {
+			return err
+		}
+
+		if exceeds(total, period.limit) {
+			available, _ := period.limit.Sub(period.used)
+			if available.IsNegative() {
+				available = NewMoney(0, available.Currency)
+			}
+
+			return NewErrorHandler(ENTITY_ERROR).Add(fmt.Sprintf("transfer exceeds the %s limit of %s %s, %s %s available", period.name, period.limit, period.limit.Currency, available, available.Currency))
+		}
+	}
+
+	return nil
+}
+
+// Add counts the amount as sent in every period, and in the night when night is true.
+func (u TransferUsage) Add(amount Money, night bool) (TransferUsage, error) {
+	var err error
+
+	u.Daily, err = u.Daily.Add(amount)
+	if err != nil {
+		return TransferUsage{}, err
+	}
+
+	u.Monthly, err = u.Monthly.Add(amount)
+	if err != nil {
+		return TransferUsage{}, err
+	}
+
+	if night {
+		u.Nighttime, err = u.Nighttime.Add(amount)
+		if err != nil {
+			return TransferUsage{}, err
+		}
+	}
+
+	return u, nil
+}
+
+// Raises tells whether any limit is greater than the same limit of current.
+func (l *TransferLimit) Raises(current TransferLimit) bool {
+	currentLimits := current.limits()
+
+	for i, limit := range l.limits() {
+		if exceeds(limit.value, currentLimits[i].value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exceeds compares amounts of the same currency, the callers check the currencies first.
+func exceeds(amount Money, limit Money) bool {
+	comparison, err := amount.Compare(limit)
+	return err == nil && comparison > 0
+}
+
+// TransferLimitDefaults are the limits of the accounts that never changed them, as decimal
+// amounts such as "5000" read in the currency of each account.
+type TransferLimitDefaults struct {
+	PerTransaction string
+	Daily          string
+	Monthly        string
+	Nighttime      string
+}
+
+func (d TransferLimitDefaults) For(accountID string, currency Currency) (*TransferLimit, error) {
+	amounts := make([]Money, 0, 4)
+
+	for _, value := range []string{d.PerTransaction, d.Daily, d.Monthly, d.Nighttime} {
+		amount, err := ParseMoney(value, string(currency))
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, amount)
+	}
+
+	return NewTransferLimit(accountID, amounts[0], amounts[1], amounts[2], amounts[3], nil)
+}
+
+// TransferLimitCalendar tells when the day, the month and the night of an instant started,
+// in the time zone of the bank. A NightStart after NightEnd spans midnight, such as 20:00
+// to 06:00.
+type TransferLimitCalendar struct {
+	NightStart time.Duration
+	NightEnd   time.Duration
+	Location   *time.Location
+}
+
+func NewTransferLimitCalendar(nightStart time.Duration, nightEnd time.Duration, location *time.Location) (*TransferLimitCalendar, error) {
+	validationError := NewErrorHandler(ENTITY_ERROR)
+
+	for _, clock := range []time.Duration{nightStart, nightEnd} {
+		if clock < 0 || clock >= 24*time.Hour {
+			validationError.Add(fmt.Sprintf("night time must be within a day: %s", clock))
+		}
+	}
+
+	if nightStart == nightEnd {
+		validationError.Add("night must start and end at different times")
+	}
+
+	if location == nil {
+		validationError.Add("location is required")
+	}
+
+	if len(validationError.Messages) > 0 {
+		return nil, validationError
+	}
+
+	return &TransferLimitCalendar{
+		NightStart: nightStart,
+		NightEnd:   nightEnd,
+		Location:   location,
+	}, nil
+}
+
+func (c *TransferLimitCalendar) DayStart(now time.Time) time.Time {
+	local := now.In(c.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.Location)
+}
+
+func (c *TransferLimitCalendar) MonthStart(now time.Time) time.Time {
+	local := now.In(c.Location)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, c.Location)
+}
+
+// NightStartOf returns when the night that now belongs to started, false when now is daytime.
+func (c *TransferLimitCalendar) NightStartOf(now time.Time) (time.Time, bool) {
+	dayStart := c.DayStart(now)
+	clock := now.In(c.Location).Sub(dayStart)
+
+	if c.NightStart < c.NightEnd {
+		if clock >= c.NightStart && clock < c.NightEnd {
+			return dayStart.Add(c.NightStart), true
+		}
+		return time.Time{}, false
+	}
+
+	if clock >= c.NightStart {
+		return dayStart.Add(c.NightStart), true
+	}
+
+	if clock < c.NightEnd {
+		previousDay := dayStart.AddDate(0, 0, -1)
+		return previousDay.Add(c.NightStart), true
+	}
+
+	return time.Time{}, false
+}
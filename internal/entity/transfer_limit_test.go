@@ -0,0 +1,164 @@
+package entity_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTransferLimit is a limit of 10.00 per transfer, 50.00 a day, 500.00 a month and 20.00 a night.
+func GetTransferLimit(t *testing.T) *entity.TransferLimit {
+	transferLimit, err := entity.NewTransferLimit("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(1000, entity.BRL), entity.NewMoney(5000, entity.BRL), entity.NewMoney(50000, entity.BRL), entity.NewMoney(2000, entity.BRL), nil)
+	assert.Nil(t, err)
+
+	return transferLimit
+}
+
+func TestNewTransferLimit(t *testing.T) {
+	t.Run("Testing NewTransferLimit with a limit that is not positive", func(t *testing.T) {
+		_, err := entity.NewTransferLimit("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(0, entity.BRL), entity.NewMoney(5000, entity.BRL), entity.NewMoney(50000, entity.BRL), entity.NewMoney(2000, entity.BRL), nil)
+		assert.Equal(t, "per-transaction limit must be greater than zero", err.Error())
+	})
+
+	t.Run("Testing NewTransferLimit with limits in different currencies", func(t *testing.T) {
+		_, err := entity.NewTransferLimit("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(1000, entity.BRL), entity.NewMoney(5000, entity.BRL), entity.NewMoney(50000, entity.USD), entity.NewMoney(2000, entity.BRL), nil)
+		assert.Equal(t, "monthly limit must be in BRL", err.Error())
+	})
+
+	t.Run("Testing NewTransferLimit with a daily limit above the monthly limit", func(t *testing.T) {
+		_, err := entity.NewTransferLimit("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(1000, entity.BRL), entity.NewMoney(5000, entity.BRL), entity.NewMoney(4000, entity.BRL), entity.NewMoney(2000, entity.BRL), nil)
+		assert.Equal(t, "daily limit cannot be greater than the monthly limit", err.Error())
+	})
+
+	t.Run("Testing NewTransferLimit with a nighttime limit above the daily limit", func(t *testing.T) {
+		_, err := entity.NewTransferLimit("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(1000, entity.BRL), entity.NewMoney(5000, entity.BRL), entity.NewMoney(50000, entity.BRL), entity.NewMoney(6000, entity.BRL), nil)
+		assert.Equal(t, "nighttime limit cannot be greater than the daily limit", err.Error())
+	})
+}
+
+func TestTransferLimit_Check(t *testing.T) {
+	noUsage := entity.TransferUsage{Daily: entity.NewMoney(0, entity.BRL), Monthly: entity.NewMoney(0, entity.BRL), Nighttime: entity.NewMoney(0, entity.BRL)}
+
+	t.Run("Testing Check with an amount within every limit", func(t *testing.T) {
+		usage := entity.TransferUsage{Daily: entity.NewMoney(4000, entity.BRL), Monthly: entity.NewMoney(49000, entity.BRL), Nighttime: entity.NewMoney(0, entity.BRL)}
+		assert.Nil(t, GetTransferLimit(t).Check(entity.NewMoney(1000, entity.BRL), usage, false))
+	})
+
+	t.Run("Testing Check with an amount above the per-transaction limit", func(t *testing.T) {
+		err := GetTransferLimit(t).Check(entity.NewMoney(1001, entity.BRL), noUsage, false)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer exceeds the per-transaction limit of 10.00 BRL", err.Error())
+	})
+
+	t.Run("Testing Check when the day is used up", func(t *testing.T) {
+		usage := entity.TransferUsage{Daily: entity.NewMoney(4500, entity.BRL), Monthly: entity.NewMoney(4500, entity.BRL), Nighttime: entity.NewMoney(0, entity.BRL)}
+		err := GetTransferLimit(t).Check(entity.NewMoney(600, entity.BRL), usage, false)
+		assert.Equal(t, "transfer exceeds the daily limit of 50.00 BRL, 5.00 BRL available", err.Error())
+	})
+
+	t.Run("Testing Check when the month is used up", func(t *testing.T) {
+		usage := entity.TransferUsage{Daily: entity.NewMoney(0, entity.BRL), Monthly: entity.NewMoney(49500, entity.BRL), Nighttime: entity.NewMoney(0, entity.BRL)}
+		err := GetTransferLimit(t).Check(entity.NewMoney(600, entity.BRL), usage, false)
+		assert.Equal(t, "transfer exceeds the monthly limit of 500.00 BRL, 5.00 BRL available", err.Error())
+	})
+
+	t.Run("Testing Check applies the nighttime limit only at night", func(t *testing.T) {
+		usage := entity.TransferUsage{Daily: entity.NewMoney(1500, entity.BRL), Monthly: entity.NewMoney(1500, entity.BRL), Nighttime: entity.NewMoney(1500, entity.BRL)}
+
+		assert.Nil(t, GetTransferLimit(t).Check(entity.NewMoney(1000, entity.BRL), usage, false))
+
+		err := GetTransferLimit(t).Check(entity.NewMoney(1000, entity.BRL), usage, true)
+		assert.Equal(t, "transfer exceeds the nighttime limit of 20.00 BRL, 5.00 BRL available", err.Error())
+	})
+
+	t.Run("Testing Check with a lowered limit already used up", func(t *testing.T) {
+		usage := entity.TransferUsage{Daily: entity.NewMoney(6000, entity.BRL), Monthly: entity.NewMoney(6000, entity.BRL), Nighttime: entity.NewMoney(0, entity.BRL)}
+		err := GetTransferLimit(t).Check(entity.NewMoney(100, entity.BRL), usage, false)
+		assert.Equal(t, "transfer exceeds the daily limit of 50.00 BRL, 0.00 BRL available", err.Error())
+	})
+
+	t.Run("Testing Check with an amount in another currency", func(t *testing.T) {
+		err := GetTransferLimit(t).Check(entity.NewMoney(100, entity.USD), noUsage, false)
+		assert.Equal(t, "transfer limits are in BRL, the amount is in USD", err.Error())
+	})
+}
+
+func TestTransferLimit_Raises(t *testing.T) {
+	current := GetTransferLimit(t)
+
+	lowered, err := entity.NewTransferLimit(current.AccountID, current.PerTransaction, entity.NewMoney(4000, entity.BRL), current.Monthly, current.Nighttime, nil)
+	assert.Nil(t, err)
+	assert.False(t, lowered.Raises(*current))
+
+	raised, err := entity.NewTransferLimit(current.AccountID, current.PerTransaction, entity.NewMoney(4000, entity.BRL), current.Monthly, entity.NewMoney(3000, entity.BRL), nil)
+	assert.Nil(t, err)
+	assert.True(t, raised.Raises(*current))
+}
+
+func TestTransferLimitDefaults_For(t *testing.T) {
+	defaults := entity.TransferLimitDefaults{PerTransaction: "5000", Daily: "10000", Monthly: "50000", Nighttime: "1000"}
+
+	transferLimit, err := defaults.For("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.JPY)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(5000, entity.JPY), transferLimit.PerTransaction)
+	assert.Nil(t, transferLimit.UpdatedAt)
+
+	transferLimit, err = defaults.For("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.BRL)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(100000, entity.BRL), transferLimit.Nighttime)
+
+	_, err = entity.TransferLimitDefaults{PerTransaction: "5000", Daily: "ten", Monthly: "50000", Nighttime: "1000"}.For("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.BRL)
+	assert.Equal(t, "amount is invalid: ten", err.Error())
+}
+
+func TestTransferLimitCalendar(t *testing.T) {
+	saoPaulo := time.FixedZone("America/Sao_Paulo", -3*60*60)
+
+	t.Run("Testing NewTransferLimitCalendar with an invalid night", func(t *testing.T) {
+		_, err := entity.NewTransferLimitCalendar(20*time.Hour, 20*time.Hour, saoPaulo)
+		assert.Equal(t, "night must start and end at different times", err.Error())
+
+		_, err = entity.NewTransferLimitCalendar(25*time.Hour, 6*time.Hour, saoPaulo)
+		assert.Equal(t, "night time must be within a day: 25h0m0s", err.Error())
+	})
+
+	t.Run("Testing the periods are computed in the time zone of the calendar", func(t *testing.T) {
+		calendar, err := entity.NewTransferLimitCalendar(20*time.Hour, 6*time.Hour, saoPaulo)
+		assert.Nil(t, err)
+
+		// 01:30 of September 1st in UTC is still August 31st in Sao Paulo
+		now := time.Date(2023, 9, 1, 1, 30, 0, 0, time.UTC)
+		assert.True(t, calendar.DayStart(now).Equal(time.Date(2023, 8, 31, 3, 0, 0, 0, time.UTC)))
+		assert.True(t, calendar.MonthStart(now).Equal(time.Date(2023, 8, 1, 3, 0, 0, 0, time.UTC)))
+
+		nightStart, night := calendar.NightStartOf(now)
+		assert.True(t, night)
+		assert.True(t, nightStart.Equal(time.Date(2023, 8, 31, 23, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Testing NightStartOf after midnight and during the day", func(t *testing.T) {
+		calendar, err := entity.NewTransferLimitCalendar(20*time.Hour, 6*time.Hour, time.UTC)
+		assert.Nil(t, err)
+
+		nightStart, night := calendar.NightStartOf(time.Date(2023, 8, 7, 5, 59, 0, 0, time.UTC))
+		assert.True(t, night)
+		assert.Equal(t, time.Date(2023, 8, 6, 20, 0, 0, 0, time.UTC), nightStart)
+
+		_, night = calendar.NightStartOf(time.Date(2023, 8, 7, 6, 0, 0, 0, time.UTC))
+		assert.False(t, night)
+	})
+
+	t.Run("Testing NightStartOf with a night within the same day", func(t *testing.T) {
+		calendar, err := entity.NewTransferLimitCalendar(time.Hour, 5*time.Hour, time.UTC)
+		assert.Nil(t, err)
+
+		nightStart, night := calendar.NightStartOf(time.Date(2023, 8, 7, 3, 0, 0, 0, time.UTC))
+		assert.True(t, night)
+		assert.Equal(t, time.Date(2023, 8, 7, 1, 0, 0, 0, time.UTC), nightStart)
+
+		_, night = calendar.NightStartOf(time.Date(2023, 8, 7, 23, 0, 0, 0, time.UTC))
+		assert.False(t, night)
+	})
+}
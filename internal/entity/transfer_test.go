@@ -1,7 +1,9 @@
 package entity_test
 
 import (
+	"errors"
 	"lucassantoss1701/bank/internal/entity"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,8 +14,8 @@ func GetBaseOriginAccount(t *testing.T) *entity.Account {
 	originAccountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 	originAccountName := "lucas"
 	originAccountCPF := "52849254088"
-	originAccountsecret := "4578405"
-	originAccountBalance := 100
+	originAccountsecret := "lucas4578405"
+	originAccountBalance := entity.NewMoney(100, entity.BRL)
 	originAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	originAccount, err := entity.NewAccount(originAccountID, originAccountName, originAccountCPF, originAccountsecret, originAccountBalance, &originAccountCreatedAt)
 
@@ -26,8 +28,8 @@ func GetBaseDestinationAccount(t *testing.T) *entity.Account {
 	destionationAccountID := "d18551d3-cf13-49ec-b1dc-741a1f8715f6"
 	destionationAccountName := "joao"
 	destionationAccountCPF := "35768297090"
-	destionationAccountSecret := "744637"
-	destionationAccountBalance := 200
+	destionationAccountSecret := "jaque744637"
+	destionationAccountBalance := entity.NewMoney(200, entity.BRL)
 	destionationAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	destinationAccount, err := entity.NewAccount(destionationAccountID, destionationAccountName, destionationAccountCPF, destionationAccountSecret, destionationAccountBalance, &destionationAccountCreatedAt)
 
@@ -43,7 +45,7 @@ func TestTransfer_NewTransfer(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
@@ -61,7 +63,7 @@ func TestTransfer_NewTransfer(t *testing.T) {
 
 		transferID := ""
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
@@ -77,7 +79,7 @@ func TestTransfer_NewTransfer(t *testing.T) {
 
 		destinationAccount := GetBaseDestinationAccount(t)
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, nil, destinationAccount, amount, &transferCreatedAt)
@@ -90,7 +92,7 @@ func TestTransfer_NewTransfer(t *testing.T) {
 	t.Run("Testing invalid transfer (nil destinationAccount)", func(t *testing.T) {
 		originAccount := GetBaseDestinationAccount(t)
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, nil, amount, &transferCreatedAt)
@@ -105,13 +107,28 @@ func TestTransfer_NewTransfer(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := -100
+		amount := entity.NewMoney(-100, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
 		assert.Nil(t, transfer)
 		assert.NotNil(t, err)
-		assert.Equal(t, "amount cannot be minor than zero", err.Error())
+		assert.Equal(t, "amount must be greater than zero", err.Error())
+	})
+
+	t.Run("Testing invalid transfer (zero amount)", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
+
+		amount := entity.NewMoney(0, entity.BRL)
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
+		assert.Nil(t, transfer)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "amount must be greater than zero", err.Error())
 	})
 
 	t.Run("Testing invalid transfer (nil createdAt)", func(t *testing.T) {
@@ -120,7 +137,7 @@ func TestTransfer_NewTransfer(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 100
+		amount := entity.NewMoney(100, entity.BRL)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, nil)
 		assert.Nil(t, transfer)
@@ -136,7 +153,7 @@ func TestTransfer_MakeTransfer(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
@@ -144,8 +161,8 @@ func TestTransfer_MakeTransfer(t *testing.T) {
 		assert.Nil(t, err)
 		assert.NotNil(t, transfer)
 
-		expectedBalanceAfterTransferOfOriginAccount := originAccount.Balance - 50
-		expectedBalanceAfterTransferOfDestinationAccount := destinationAccount.Balance + 50
+		expectedBalanceAfterTransferOfOriginAccount := entity.NewMoney(originAccount.Balance.Amount-50, entity.BRL)
+		expectedBalanceAfterTransferOfDestinationAccount := entity.NewMoney(destinationAccount.Balance.Amount+50, entity.BRL)
 
 		err = transfer.MakeTransfer()
 		assert.Nil(t, err)
@@ -154,13 +171,65 @@ func TestTransfer_MakeTransfer(t *testing.T) {
 		assert.Equal(t, expectedBalanceAfterTransferOfDestinationAccount, destinationAccount.Balance)
 	})
 
+	t.Run("Testing MakeTransfer debits the fee from the origin account on top of the amount", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.ChargeFees([]entity.TransferFee{
+			{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.BRL)},
+			{Rule: "percentage", Type: entity.PERCENTAGE_FEE, Amount: entity.NewMoney(0, entity.BRL), Waived: true},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(10, entity.BRL), transfer.Fee)
+
+		err = transfer.MakeTransfer()
+		assert.Nil(t, err)
+
+		assert.Equal(t, entity.NewMoney(40, entity.BRL), originAccount.Balance)
+		assert.Equal(t, entity.NewMoney(250, entity.BRL), destinationAccount.Balance)
+
+		err = transfer.ChargeFees(nil)
+		assert.Equal(t, "transfer is COMPLETED and cannot be charged", err.Error())
+	})
+
+	t.Run("Testing MakeTransfer when the fee takes the origin account over its balance", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(95, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.ChargeFees([]entity.TransferFee{{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.BRL)}}))
+
+		err = transfer.MakeTransfer()
+
+		assert.Equal(t, "error on update balance of origin account: new balance cannot be minor than 0(insufficient balance)", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), originAccount.Balance)
+	})
+
+	t.Run("Testing ChargeFees when a fee is in another currency", func(t *testing.T) {
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.ChargeFees([]entity.TransferFee{{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.USD)}})
+
+		assert.Equal(t, "fee flat must be in BRL", err.Error())
+		assert.True(t, transfer.Fee.IsZero())
+	})
+
 	t.Run("Testing MakeTransfer when transfer cannot be performed with success(error on update balance of origin account)", func(t *testing.T) {
 		originAccount := GetBaseOriginAccount(t)
 		destinationAccount := GetBaseDestinationAccount(t)
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 5000
+		amount := entity.NewMoney(5000, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
@@ -173,13 +242,45 @@ func TestTransfer_MakeTransfer(t *testing.T) {
 		assert.Equal(t, "error on update balance of origin account: new balance cannot be minor than 0(insufficient balance)", err.Error())
 	})
 
+	t.Run("Testing MakeTransfer when the origin account goes into its overdraft", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		assert.Nil(t, originAccount.ChangeCreditLimit(entity.NewMoney(1000, entity.BRL)))
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(1100, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.MakeTransfer()
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-1000, entity.BRL), originAccount.Balance)
+		assert.Equal(t, entity.NewMoney(1300, entity.BRL), destinationAccount.Balance)
+	})
+
+	t.Run("Testing MakeTransfer when the transfer goes beyond the overdraft", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		assert.Nil(t, originAccount.ChangeCreditLimit(entity.NewMoney(1000, entity.BRL)))
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(1101, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.MakeTransfer()
+		assert.NotNil(t, err)
+		assert.Equal(t, "error on update balance of origin account: new balance cannot be minor than -10.00(overdraft limit exceeded)", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), originAccount.Balance)
+	})
+
 	t.Run("Testing MakeTransfer when transfer cannot be performed with success(origin account is equal destination account)", func(t *testing.T) {
 		originAccount := GetBaseOriginAccount(t)
 		destinationAccount := GetBaseOriginAccount(t)
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 5000
+		amount := entity.NewMoney(5000, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
@@ -192,4 +293,269 @@ func TestTransfer_MakeTransfer(t *testing.T) {
 		assert.Equal(t, "origin account id must be different to destination account id", err.Error())
 	})
 
+	t.Run("Testing MakeTransfer when transfer cannot be performed with success(currency is not the currency of the origin account)", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
+
+		amount := entity.NewMoney(50, entity.USD)
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, transfer)
+
+		err = transfer.MakeTransfer()
+		assert.NotNil(t, err)
+		assert.Equal(t, "transfer currency USD must be the currency of the origin account", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), originAccount.Balance)
+		assert.Equal(t, entity.NewMoney(200, entity.BRL), destinationAccount.Balance)
+	})
+
+	t.Run("Testing MakeTransfer when transfer cannot be performed with success(no exchange rate applied)", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.MakeTransfer()
+		assert.NotNil(t, err)
+		assert.Equal(t, "no exchange rate applied from BRL to USD", err.Error())
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), originAccount.Balance)
+	})
+
+	t.Run("Testing MakeTransfer when transfer converts the amount to the destination currency", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		exchangeRate, err := entity.NewExchangeRate(entity.BRL, entity.USD, "0.2", &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.ApplyExchangeRate(*exchangeRate)
+		assert.Nil(t, err)
+		assert.True(t, transfer.IsCrossCurrency())
+		assert.Equal(t, entity.NewMoney(10, entity.USD), transfer.DestinationAmount)
+		assert.Equal(t, "0.2", transfer.ExchangeRate)
+
+		err = transfer.MakeTransfer()
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), originAccount.Balance)
+		assert.Equal(t, entity.NewMoney(210, entity.USD), destinationAccount.Balance)
+	})
+
+	t.Run("Testing ApplyExchangeRate when the rate is for other currencies", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		exchangeRate, err := entity.NewExchangeRate(entity.BRL, entity.EUR, "0.18", &transferCreatedAt)
+		assert.Nil(t, err)
+
+		err = transfer.ApplyExchangeRate(*exchangeRate)
+		assert.Equal(t, "exchange rate from BRL to EUR cannot be applied to a transfer from BRL to USD", err.Error())
+		assert.False(t, transfer.IsCrossCurrency())
+	})
+
+}
+
+func TestTransfer_Reverse(t *testing.T) {
+	transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+	reversalCreatedAt := transferCreatedAt.Add(time.Hour)
+
+	t.Run("Testing Reverse gives the amount back to the origin account", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		reversal, err := transfer.Reverse("", entity.NewMoney(20, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Nil(t, err)
+
+		assert.NotEmpty(t, reversal.ID)
+		assert.True(t, reversal.IsReversal())
+		assert.Equal(t, transfer.ID, reversal.ReversalOf)
+		assert.Equal(t, destinationAccount, reversal.OriginAccount)
+		assert.Equal(t, originAccount, reversal.DestinationAccount)
+		assert.Equal(t, entity.NewMoney(20, entity.BRL), reversal.Amount)
+		assert.Equal(t, entity.NewMoney(20, entity.BRL), reversal.DestinationAmount)
+		assert.Equal(t, entity.NewMoney(20, entity.BRL), transfer.ReversedAmount)
+		assert.Equal(t, entity.NewMoney(30, entity.BRL), transfer.ReversibleAmount())
+		assert.Equal(t, entity.COMPLETED_TRANSFER, transfer.Status)
+		assert.Equal(t, entity.PENDING_TRANSFER, reversal.Status)
+
+		err = reversal.MakeTransfer()
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(70, entity.BRL), originAccount.Balance)
+		assert.Equal(t, entity.NewMoney(230, entity.BRL), destinationAccount.Balance)
+	})
+
+	t.Run("Testing Reverse of a cross-currency transfer in parts returns exactly the destination amount", func(t *testing.T) {
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
+
+		originAccount := GetBaseOriginAccount(t)
+		originAccount.Balance = entity.NewMoney(1000, entity.BRL)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", originAccount, destinationAccount, entity.NewMoney(1000, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		exchangeRate, err := entity.NewExchangeRate(entity.BRL, entity.USD, "0.2019", &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.ApplyExchangeRate(*exchangeRate))
+		assert.Nil(t, transfer.MakeTransfer())
+		assert.Equal(t, entity.NewMoney(202, entity.USD), transfer.DestinationAmount)
+
+		debited := int64(0)
+		for _, amount := range []int64{333, 333, 334} {
+			reversal, err := transfer.Reverse("", entity.NewMoney(amount, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+			assert.Nil(t, err)
+			assert.Equal(t, entity.USD, reversal.Amount.Currency)
+			assert.Equal(t, entity.NewMoney(amount, entity.BRL), reversal.DestinationAmount)
+			assert.True(t, reversal.IsCrossCurrency())
+
+			debited += reversal.Amount.Amount
+		}
+
+		assert.Equal(t, int64(202), debited)
+		assert.True(t, transfer.ReversibleAmount().IsZero())
+		assert.Equal(t, entity.REVERSED_TRANSFER, transfer.Status)
+	})
+
+	t.Run("Testing Reverse with more than the reversible amount", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		_, err = transfer.Reverse("", entity.NewMoney(40, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Nil(t, err)
+
+		_, err = transfer.Reverse("", entity.NewMoney(11, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "reversal amount cannot be greater than the reversible amount of 0.10 BRL", err.Error())
+		assert.Equal(t, entity.NewMoney(40, entity.BRL), transfer.ReversedAmount)
+	})
+
+	t.Run("Testing Reverse after the window", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		createdAt := transferCreatedAt.Add(25 * time.Hour)
+		_, err = transfer.Reverse("", entity.NewMoney(50, entity.BRL), &createdAt, 24*time.Hour)
+
+		assert.Equal(t, "transfer can only be reversed within 24h0m0s of its creation", err.Error())
+	})
+
+	t.Run("Testing Reverse with an invalid amount", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		_, err = transfer.Reverse("", entity.NewMoney(0, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Equal(t, "reversal amount must be greater than zero", err.Error())
+
+		_, err = transfer.Reverse("", entity.NewMoney(10, entity.USD), &reversalCreatedAt, 24*time.Hour)
+		assert.Equal(t, "reversal currency USD must be the currency of the transfer", err.Error())
+	})
+
+	t.Run("Testing Reverse of a reversal", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		reversal, err := transfer.Reverse("", entity.NewMoney(50, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Nil(t, err)
+
+		_, err = reversal.Reverse("", entity.NewMoney(50, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Equal(t, "a reversal cannot be reversed", err.Error())
+	})
+
+	t.Run("Testing Reverse of a transfer that was not completed", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		_, err = transfer.Reverse("", entity.NewMoney(50, entity.BRL), &reversalCreatedAt, 24*time.Hour)
+		assert.Equal(t, "transfer is PENDING and cannot be reversed", err.Error())
+	})
+}
+
+func TestTransfer_Status(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+	t.Run("Testing NewTransfer starts pending", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		assert.Equal(t, entity.PENDING_TRANSFER, transfer.Status)
+		assert.Equal(t, []entity.TransferStatusChange{{Status: entity.PENDING_TRANSFER, ChangedAt: &createdAt}}, transfer.StatusHistory)
+	})
+
+	t.Run("Testing MakeTransfer completes the transfer", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		assert.Nil(t, transfer.MakeTransfer())
+		assert.Equal(t, entity.COMPLETED_TRANSFER, transfer.Status)
+		assert.Len(t, transfer.StatusHistory, 2)
+		assert.Equal(t, entity.COMPLETED_TRANSFER, transfer.StatusHistory[1].Status)
+
+		err = transfer.MakeTransfer()
+		assert.Equal(t, "transfer is COMPLETED and cannot be made", err.Error())
+	})
+
+	t.Run("Testing Fail records the reason", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		assert.Nil(t, transfer.Fail(errors.New(strings.Repeat("x", 300))))
+		assert.Equal(t, entity.FAILED_TRANSFER, transfer.Status)
+		assert.Len(t, transfer.FailureReason, entity.TRANSFER_FAILURE_REASON_MAX_LENGTH)
+		assert.Equal(t, transfer.FailureReason, transfer.StatusHistory[1].Reason)
+
+		err = transfer.MakeTransfer()
+		assert.Equal(t, "transfer is FAILED and cannot be made", err.Error())
+	})
+
+	t.Run("Testing Cancel of a pending transfer", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		cancelledAt := createdAt.Add(time.Minute)
+		assert.Nil(t, transfer.Cancel(cancelledAt))
+		assert.Equal(t, entity.CANCELLED_TRANSFER, transfer.Status)
+		assert.Equal(t, &cancelledAt, transfer.StatusHistory[1].ChangedAt)
+	})
+
+	t.Run("Testing a completed transfer cannot fail or be cancelled", func(t *testing.T) {
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		err = transfer.Fail(errors.New("database error"))
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer is COMPLETED and cannot move to FAILED", err.Error())
+
+		err = transfer.Cancel(createdAt)
+		assert.Equal(t, "transfer is COMPLETED and cannot move to CANCELLED", err.Error())
+		assert.Equal(t, entity.COMPLETED_TRANSFER, transfer.Status)
+	})
 }
@@ -16,14 +16,24 @@ func NewAccountRepository(db *sql.DB) *AccountRepository {
 	return &AccountRepository{Db: db}
 }
 
-func (r *AccountRepository) Find(ctx context.Context, limit, offset int) ([]entity.Account, error) {
-	if limit == 0 {
-		limit = 10
+// Find lists the accounts in (created_at, id) order, starting after the cursor when there is one.
+func (r *AccountRepository) Find(ctx context.Context, page entity.Pagination) ([]entity.Account, error) {
+	if page.Limit == 0 {
+		page.Limit = 10
 	}
 
-	query := fmt.Sprintf("SELECT id, name, balance, created_at FROM account LIMIT %d OFFSET %d", limit, offset)
+	query := "SELECT id, name, balance, currency, created_at FROM account"
+	var args []interface{}
 
-	rows, err := r.Db.QueryContext(ctx, query)
+	if page.After != nil {
+		query += " WHERE (created_at > ? OR (created_at = ? AND id > ?))"
+		args = append(args, page.After.CreatedAt, page.After.CreatedAt, page.After.ID)
+	}
+
+	query += " ORDER BY created_at, id LIMIT ? OFFSET ?"
+	args = append(args, page.Limit, page.Offset)
+
+	rows, err := r.Db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 	}
@@ -32,7 +42,7 @@ func (r *AccountRepository) Find(ctx context.Context, limit, offset int) ([]enti
 	var accounts []entity.Account
 	for rows.Next() {
 		var account entity.Account
-		rows.Scan(&account.ID, &account.Name, &account.Balance, &account.CreatedAt)
+		rows.Scan(&account.ID, &account.Name, &account.Balance.Amount, &account.Balance.Currency, &account.CreatedAt)
 		accounts = append(accounts, account)
 	}
 
@@ -40,38 +50,80 @@ func (r *AccountRepository) Find(ctx context.Context, limit, offset int) ([]enti
 }
 
 func (r *AccountRepository) FindByID(ctx context.Context, ID string) (entity.Account, error) {
-	query := "SELECT id, name, balance FROM account WHERE id = ?"
+	query := "SELECT id, name, cpf, secret, balance, credit_limit, held, currency, role FROM account WHERE id = ?"
+
+	return r.findByID(ctx, r.Db, query, ID)
+}
+
+func (r *AccountRepository) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Account, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
 
-	row := r.Db.QueryRowContext(ctx, query, ID)
+	query := "SELECT id, name, cpf, secret, balance, credit_limit, held, currency, role FROM account WHERE id = ? FOR UPDATE"
+
+	return r.findByID(ctx, executor, query, ID)
+}
+
+func (r *AccountRepository) findByID(ctx context.Context, executor entity.TransactionHandler, query string, ID string) (entity.Account, error) {
+	row := executor.QueryRowContext(ctx, query, ID)
 
 	var account entity.Account
-	err := row.Scan(&account.ID, &account.Name, &account.Balance)
+	err := row.Scan(&account.ID, &account.Name, &account.CPF, &account.Secret, &account.Balance.Amount, &account.CreditLimit.Amount, &account.Held.Amount, &account.Balance.Currency, &account.Role)
 	if err != nil {
 		if err.Error() == sql.ErrNoRows.Error() {
 			return entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found account: %s", ID))
 		}
+
 		return entity.Account{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 	}
 
+	account.CreditLimit.Currency = account.Balance.Currency
+	account.Held.Currency = account.Balance.Currency
+
 	return account, nil
 }
 
-func (r *AccountRepository) Create(ctx context.Context, account *entity.Account) (entity.Account, error) {
+// FindByIDs returns the accounts of the IDs that exist, the caller tells the missing ones apart.
+func (r *AccountRepository) FindByIDs(ctx context.Context, IDs []string) ([]entity.Account, error) {
+	if len(IDs) == 0 {
+		return []entity.Account{}, nil
+	}
 
-	query := "INSERT INTO account (id, name, cpf, secret, balance, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(IDs)), ", ")
+	query := "SELECT id, name, balance, credit_limit, held, currency, role FROM account WHERE id IN (" + placeholders + ")"
 
-	_, err := r.Db.ExecContext(ctx, query, account.ID, account.Name, account.CPF, account.Secret, account.Balance, account.CreatedAt)
+	args := make([]interface{}, 0, len(IDs))
+	for _, ID := range IDs {
+		args = append(args, ID)
+	}
+
+	rows, err := r.Db.QueryContext(ctx, query, args...)
 	if err != nil {
-		if strings.Contains(err.Error(), "1062") {
-			return entity.Account{}, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(err.Error())
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	accounts := []entity.Account{}
+	for rows.Next() {
+		var account entity.Account
+		err := rows.Scan(&account.ID, &account.Name, &account.Balance.Amount, &account.CreditLimit.Amount, &account.Held.Amount, &account.Balance.Currency, &account.Role)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 		}
-		return entity.Account{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+
+		account.CreditLimit.Currency = account.Balance.Currency
+		account.Held.Currency = account.Balance.Currency
+		accounts = append(accounts, account)
 	}
 
-	return *account, nil
+	return accounts, nil
 }
 
-func (r *AccountRepository) UpdateBalance(ctx context.Context, accountID string, newBalance int, tx ...entity.TransactionHandler) (entity.Account, error) {
+func (r *AccountRepository) Create(ctx context.Context, account *entity.Account, tx ...entity.TransactionHandler) (entity.Account, error) {
 	var executor entity.TransactionHandler
 	if len(tx) > 0 {
 		executor = tx[0]
@@ -79,23 +131,26 @@ func (r *AccountRepository) UpdateBalance(ctx context.Context, accountID string,
 		executor = r.Db
 	}
 
-	query := "UPDATE account SET balance = ? WHERE id = ?"
+	query := "INSERT INTO account (id, name, cpf, secret, balance, currency, role, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
 
-	_, err := executor.ExecContext(ctx, query, newBalance, accountID)
+	_, err := executor.ExecContext(ctx, query, account.ID, account.Name, account.CPF, account.Secret, account.Balance.Amount, account.Balance.Currency, account.Role, account.CreatedAt)
 	if err != nil {
+		if strings.Contains(err.Error(), "1062") {
+			return entity.Account{}, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(err.Error())
+		}
 		return entity.Account{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 	}
 
-	return r.FindByID(ctx, accountID)
+	return *account, nil
 }
 
 func (r *AccountRepository) FindByCPF(ctx context.Context, CPF string) (entity.Account, error) {
-	query := "SELECT id, secret FROM account WHERE cpf = ?"
+	query := "SELECT id, secret, role FROM account WHERE cpf = ?"
 
 	var account entity.Account
 
 	row := r.Db.QueryRowContext(ctx, query, CPF)
-	err := row.Scan(&account.ID, &account.Secret)
+	err := row.Scan(&account.ID, &account.Secret, &account.Role)
 	if err != nil {
 		if err.Error() == sql.ErrNoRows.Error() {
 			return entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found account by CPF: %s", CPF))
@@ -103,5 +158,53 @@ func (r *AccountRepository) FindByCPF(ctx context.Context, CPF string) (entity.A
 		return entity.Account{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 	}
 
+	account.CPF = CPF
 	return account, nil
 }
+
+func (r *AccountRepository) UpdateRole(ctx context.Context, ID string, role entity.Role) error {
+	query := "UPDATE account SET role = ? WHERE id = ?"
+
+	_, err := r.Db.ExecContext(ctx, query, role, ID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *AccountRepository) UpdateSecret(ctx context.Context, ID string, secret string, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE account SET secret = ? WHERE id = ?"
+
+	_, err := executor.ExecContext(ctx, query, secret, ID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *AccountRepository) UpdateCreditLimit(ctx context.Context, ID string, creditLimit entity.Money, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE account SET credit_limit = ? WHERE id = ? AND currency = ?"
+
+	_, err := executor.ExecContext(ctx, query, creditLimit.Amount, ID, creditLimit.Currency)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
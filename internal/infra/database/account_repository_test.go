@@ -14,23 +14,43 @@ import (
 )
 
 func GetSQLFindAccounts() string {
-	return "SELECT id, name, balance, created_at FROM account LIMIT 10 OFFSET 0"
+	return regexp.QuoteMeta("SELECT id, name, balance, currency, created_at FROM account ORDER BY created_at, id LIMIT ? OFFSET ?")
+}
+
+func GetSQLFindAccountsAfterCursor() string {
+	return regexp.QuoteMeta("SELECT id, name, balance, currency, created_at FROM account WHERE (created_at > ? OR (created_at = ? AND id > ?)) ORDER BY created_at, id LIMIT ? OFFSET ?")
 }
 
 func GetSQLFindAccountByID() string {
-	return "SELECT id, name, balance FROM account WHERE id = ?"
+	return "SELECT id, name, cpf, secret, balance, credit_limit, held, currency, role FROM account WHERE id = ?"
 }
 
-func GetSQLFindByCPF() string {
-	return "SELECT id, secret FROM account WHERE cpf = ?"
+func GetSQLFindAccountByIDForUpdate() string {
+	return regexp.QuoteMeta("SELECT id, name, cpf, secret, balance, credit_limit, held, currency, role FROM account WHERE id = ? FOR UPDATE")
 }
 
-func GetSQLInsertAccount() string {
-	return regexp.QuoteMeta("INSERT INTO account (id, name, cpf, secret, balance, created_at) VALUES (?, ?, ?, ?, ?, ?)")
+func GetSQLFindAccountsByIDs() string {
+	return regexp.QuoteMeta("SELECT id, name, balance, credit_limit, held, currency, role FROM account WHERE id IN (?, ?)")
 }
 
-func GetSQLUpdateBalanceAccount() string {
-	return regexp.QuoteMeta("UPDATE account SET balance = ? WHERE id = ?")
+func GetSQLUpdateRole() string {
+	return regexp.QuoteMeta("UPDATE account SET role = ? WHERE id = ?")
+}
+
+func GetSQLUpdateSecret() string {
+	return regexp.QuoteMeta("UPDATE account SET secret = ? WHERE id = ?")
+}
+
+func GetSQLUpdateCreditLimit() string {
+	return regexp.QuoteMeta("UPDATE account SET credit_limit = ? WHERE id = ? AND currency = ?")
+}
+
+func GetSQLFindByCPF() string {
+	return "SELECT id, secret, role FROM account WHERE cpf = ?"
+}
+
+func GetSQLInsertAccount() string {
+	return regexp.QuoteMeta("INSERT INTO account (id, name, cpf, secret, balance, currency, role, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
 }
 
 func TestAccountRepository_Find(t *testing.T) {
@@ -43,25 +63,25 @@ func TestAccountRepository_Find(t *testing.T) {
 
 		createAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "balance", "created_at"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100, createAt).
-			AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger", 200, createAt)
+		rows := sqlmock.NewRows([]string{"id", "name", "balance", "currency", "created_at"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100, "BRL", createAt).
+			AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger", 200, "BRL", createAt)
 
-		mock.ExpectQuery(GetSQLFindAccounts()).WillReturnRows(rows)
+		mock.ExpectQuery(GetSQLFindAccounts()).WithArgs(10, 0).WillReturnRows(rows)
 
-		accounts, err := accountRepository.Find(context.Background(), 10, 0)
+		accounts, err := accountRepository.Find(context.Background(), entity.Pagination{Limit: 10})
 		assert.Nil(t, err)
 		assert.Len(t, accounts, 2)
 
 		assert.Equal(t, accounts[0].ID, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
 		assert.Equal(t, accounts[0].Name, "Lucas")
-		assert.Equal(t, accounts[0].Balance, 100)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), accounts[0].Balance)
 		assert.Equal(t, accounts[0].CPF, "")
 		assert.Equal(t, accounts[0].Secret, "")
 
 		assert.Equal(t, accounts[1].ID, "d18551d3-cf13-49ec-b1dc-741a1f8715f6")
 		assert.Equal(t, accounts[1].Name, "Roger")
-		assert.Equal(t, accounts[1].Balance, 200)
+		assert.Equal(t, entity.NewMoney(200, entity.BRL), accounts[1].Balance)
 		assert.Equal(t, accounts[1].CPF, "")
 		assert.Equal(t, accounts[1].Secret, "")
 	})
@@ -75,25 +95,25 @@ func TestAccountRepository_Find(t *testing.T) {
 
 		createAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "balance", "created_at"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100, createAt).
-			AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger", 200, createAt)
+		rows := sqlmock.NewRows([]string{"id", "name", "balance", "currency", "created_at"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100, "BRL", createAt).
+			AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger", 200, "BRL", createAt)
 
-		mock.ExpectQuery(GetSQLFindAccounts()).WillReturnRows(rows)
+		mock.ExpectQuery(GetSQLFindAccounts()).WithArgs(10, 0).WillReturnRows(rows)
 
-		accounts, err := accountRepository.Find(context.Background(), 0, 0)
+		accounts, err := accountRepository.Find(context.Background(), entity.Pagination{})
 		assert.Nil(t, err)
 		assert.Len(t, accounts, 2)
 
 		assert.Equal(t, accounts[0].ID, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
 		assert.Equal(t, accounts[0].Name, "Lucas")
-		assert.Equal(t, accounts[0].Balance, 100)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), accounts[0].Balance)
 		assert.Equal(t, accounts[0].CPF, "")
 		assert.Equal(t, accounts[0].Secret, "")
 
 		assert.Equal(t, accounts[1].ID, "d18551d3-cf13-49ec-b1dc-741a1f8715f6")
 		assert.Equal(t, accounts[1].Name, "Roger")
-		assert.Equal(t, accounts[1].Balance, 200)
+		assert.Equal(t, entity.NewMoney(200, entity.BRL), accounts[1].Balance)
 		assert.Equal(t, accounts[1].CPF, "")
 		assert.Equal(t, accounts[1].Secret, "")
 	})
@@ -107,12 +127,36 @@ func TestAccountRepository_Find(t *testing.T) {
 
 		mock.ExpectQuery(GetSQLFindAccounts()).WillReturnError(errors.New("connection closed"))
 
-		accounts, err := accountRepository.Find(context.Background(), 10, 0)
+		accounts, err := accountRepository.Find(context.Background(), entity.Pagination{Limit: 10})
 		assert.NotNil(t, err)
 		assert.Equal(t, "connection closed", err.Error())
 		assert.Len(t, accounts, 0)
 	})
 
+	t.Run("Testing Find after a cursor", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		accountRepository := database.NewAccountRepository(db)
+
+		createAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+		after := &entity.PageCursor{CreatedAt: createAt, ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477"}
+
+		rows := sqlmock.NewRows([]string{"id", "name", "balance", "currency", "created_at"}).
+			AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger", 200, "BRL", createAt)
+
+		mock.ExpectQuery(GetSQLFindAccountsAfterCursor()).
+			WithArgs(createAt, createAt, "2bd765a6-47bd-4731-9eb2-1e65542f4477", 5, 0).
+			WillReturnRows(rows)
+
+		accounts, err := accountRepository.Find(context.Background(), entity.Pagination{Limit: 5, After: after})
+		assert.Nil(t, err)
+		assert.Len(t, accounts, 1)
+		assert.Equal(t, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", accounts[0].ID)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
 }
 
 func TestAccountRepository_FindByID(t *testing.T) {
@@ -124,8 +168,8 @@ func TestAccountRepository_FindByID(t *testing.T) {
 
 		accountRepository := database.NewAccountRepository(db)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "balance"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100)
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", "34688151071", "hashed", 100, 0, 0, "BRL", "CUSTOMER")
 
 		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
 
@@ -133,7 +177,44 @@ func TestAccountRepository_FindByID(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", account.ID)
 		assert.Equal(t, "Lucas", account.Name)
-		assert.Equal(t, 100, account.Balance)
+		assert.Equal(t, "34688151071", account.CPF)
+		assert.Equal(t, "hashed", account.Secret)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), account.CreditLimit)
+	})
+
+	t.Run("Testing findByID when the account has a credit limit", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", "34688151071", "hashed", -2500, 50000, 0, "USD", "CUSTOMER")
+
+		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
+
+		account, err := database.NewAccountRepository(db).FindByID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-2500, entity.USD), account.Balance)
+		assert.Equal(t, entity.NewMoney(50000, entity.USD), account.CreditLimit)
+		assert.Equal(t, entity.NewMoney(47500, entity.USD), account.AvailableBalance())
+	})
+
+	t.Run("Testing findByID when the account has funds on hold", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", "34688151071", "hashed", 10000, 0, 2500, "BRL", "CUSTOMER")
+
+		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
+
+		account, err := database.NewAccountRepository(db).FindByID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(10000, entity.BRL), account.Balance)
+		assert.Equal(t, entity.NewMoney(2500, entity.BRL), account.Held)
+		assert.Equal(t, entity.NewMoney(7500, entity.BRL), account.AvailableBalance())
 	})
 
 	t.Run("Testing FindByID when execute returns an error", func(t *testing.T) {
@@ -159,8 +240,8 @@ func TestAccountRepository_FindByID(t *testing.T) {
 
 		accountRepository := database.NewAccountRepository(db)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "balance"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100).CloseError(errors.New("error on scan"))
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", "34688151071", "hashed", 100, 0, 0, "BRL", "CUSTOMER").CloseError(errors.New("error on scan"))
 
 		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
 
@@ -177,8 +258,8 @@ func TestAccountRepository_FindByID(t *testing.T) {
 
 		accountRepository := database.NewAccountRepository(db)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "balance"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100).CloseError(errors.New("sql: no rows in result set"))
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", "34688151071", "hashed", 100, 0, 0, "BRL", "CUSTOMER").CloseError(errors.New("sql: no rows in result set"))
 
 		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
 
@@ -190,6 +271,95 @@ func TestAccountRepository_FindByID(t *testing.T) {
 
 }
 
+func TestAccountRepository_FindByIDForUpdate(t *testing.T) {
+	t.Run("Testing FindByIDForUpdate when returns one account inside a transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		accountRepository := database.NewAccountRepository(db)
+
+		mock.ExpectBegin()
+		tx, err := db.Begin()
+		assert.Nil(t, err)
+
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", "34688151071", "hashed", 100, 0, 0, "BRL", "CUSTOMER")
+
+		mock.ExpectQuery(GetSQLFindAccountByIDForUpdate()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
+
+		account, err := accountRepository.FindByIDForUpdate(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", tx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", account.ID)
+		assert.Equal(t, "Lucas", account.Name)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), account.Balance)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByIDForUpdate when scan returns an error (no rows)", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		accountRepository := database.NewAccountRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "name", "cpf", "secret", "balance", "credit_limit", "held", "currency", "role"})
+
+		mock.ExpectQuery(GetSQLFindAccountByIDForUpdate()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").WillReturnRows(rows)
+
+		account, err := accountRepository.FindByIDForUpdate(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "not found account: 2bd765a6-47bd-4731-9eb2-1e65542f4477", err.Error())
+		assert.Empty(t, account.ID)
+	})
+}
+
+func TestAccountRepository_FindByIDs(t *testing.T) {
+	t.Run("Testing FindByIDs returns the accounts that exist", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "balance", "credit_limit", "held", "currency", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas", 100, 500, 20, "BRL", "CUSTOMER")
+
+		mock.ExpectQuery(GetSQLFindAccountsByIDs()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6").WillReturnRows(rows)
+
+		accounts, err := database.NewAccountRepository(db).FindByIDs(context.Background(), []string{"2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6"})
+		assert.Nil(t, err)
+		assert.Len(t, accounts, 1)
+		assert.Equal(t, "Lucas", accounts[0].Name)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), accounts[0].Balance)
+		assert.Equal(t, entity.NewMoney(500, entity.BRL), accounts[0].CreditLimit)
+		assert.Equal(t, entity.NewMoney(20, entity.BRL), accounts[0].Held)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByIDs does not query without IDs", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		accounts, err := database.NewAccountRepository(db).FindByIDs(context.Background(), nil)
+		assert.Nil(t, err)
+		assert.Empty(t, accounts)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByIDs when execute query returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindAccountsByIDs()).WillReturnError(errors.New("connection closed"))
+
+		_, err = database.NewAccountRepository(db).FindByIDs(context.Background(), []string{"2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6"})
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
 func TestAccountRepository_Create(t *testing.T) {
 	t.Run("Testing Create when account is create with successful", func(t *testing.T) {
 		db, mock, _ := sqlmock.New()
@@ -203,13 +373,14 @@ func TestAccountRepository_Create(t *testing.T) {
 			ID:        "2bd765a6-47bd-4731-9eb2-1e65542f4477",
 			Name:      "John",
 			CPF:       "00634020099",
-			Secret:    "4578405",
-			Balance:   200,
+			Secret:    "lucas4578405",
+			Balance:   entity.NewMoney(200, entity.BRL),
+			Role:      entity.CUSTOMER_ROLE,
 			CreatedAt: &createdAt,
 		}
 
 		mock.ExpectExec(GetSQLInsertAccount()).
-			WithArgs(account.ID, account.Name, account.CPF, account.Secret, account.Balance, account.CreatedAt).
+			WithArgs(account.ID, account.Name, account.CPF, account.Secret, account.Balance.Amount, account.Balance.Currency, account.Role, account.CreatedAt).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		createdAccount, err := accountRepository.Create(context.Background(), account)
@@ -231,13 +402,14 @@ func TestAccountRepository_Create(t *testing.T) {
 			ID:        "2bd765a6-47bd-4731-9eb2-1e65542f4477",
 			Name:      "John",
 			CPF:       "00634020099",
-			Secret:    "4578405",
-			Balance:   200,
+			Secret:    "lucas4578405",
+			Balance:   entity.NewMoney(200, entity.BRL),
+			Role:      entity.CUSTOMER_ROLE,
 			CreatedAt: &createdAt,
 		}
 
 		mock.ExpectExec(GetSQLInsertAccount()).
-			WithArgs(account.ID, account.Name, account.CPF, account.Secret, account.Balance, account.CreatedAt).
+			WithArgs(account.ID, account.Name, account.CPF, account.Secret, account.Balance.Amount, account.Balance.Currency, account.Role, account.CreatedAt).
 			WillReturnError(errors.New("connection closed"))
 
 		createdAccount, err := accountRepository.Create(context.Background(), account)
@@ -247,158 +419,171 @@ func TestAccountRepository_Create(t *testing.T) {
 	})
 }
 
-func TestAccountRepository_UpdateBalance(t *testing.T) {
-	t.Run("Testing UpdateBalance when successful", func(t *testing.T) {
+func TestAccountRepository_FindByCPF(t *testing.T) {
+
+	t.Run("Testing FindByCPF when returns one account", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
-		assert.NoError(t, err)
+		assert.Nil(t, err)
 		defer db.Close()
 
 		accountRepository := database.NewAccountRepository(db)
 
-		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
-		newBalance := 150
-		accountName := "Lucas"
-
-		mock.ExpectExec(GetSQLUpdateBalanceAccount()).
-			WithArgs(newBalance, accountID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
-
-		rows := sqlmock.NewRows([]string{"id", "name", "balance"}).
-			AddRow(accountID, accountName, newBalance)
+		rows := sqlmock.NewRows([]string{"id", "secret", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "secret", "ADMIN")
 
-		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs(accountID).WillReturnRows(rows)
+		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnRows(rows)
 
-		updatedAccount, err := accountRepository.UpdateBalance(context.Background(), accountID, newBalance)
+		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
 		assert.Nil(t, err)
-		assert.Equal(t, accountID, updatedAccount.ID)
-		assert.Equal(t, accountName, updatedAccount.Name)
-		assert.Equal(t, newBalance, updatedAccount.Balance)
+		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", account.ID)
+		assert.Equal(t, "35768297090", account.CPF)
+		assert.Equal(t, "secret", account.Secret)
+		assert.Equal(t, entity.ADMIN_ROLE, account.Role)
 	})
 
-	t.Run("Testing UpdateBalance when ExecContext returns an error", func(t *testing.T) {
+	t.Run("Testing FindByID when execute returns an error", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
-		assert.NoError(t, err)
+		assert.Nil(t, err)
 		defer db.Close()
 
 		accountRepository := database.NewAccountRepository(db)
 
-		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
-		newBalance := 150
-		accountName := "Lucas"
+		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnError(errors.New("connection closed"))
 
-		mock.ExpectExec(GetSQLUpdateBalanceAccount()).
-			WithArgs(newBalance, accountID).
-			WillReturnError(errors.New("error on update balance"))
+		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
+		assert.NotNil(t, err)
+		assert.Equal(t, "connection closed", err.Error())
+		assert.Empty(t, account.ID)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "balance"}).
-			AddRow(accountID, accountName, newBalance)
+	})
 
-		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs(accountID).WillReturnRows(rows)
+	t.Run("Testing findByID when scan returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		accountRepository := database.NewAccountRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "secret", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "secret", "ADMIN").CloseError(errors.New("error on scan"))
+
+		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnRows(rows)
 
-		updatedAccount, err := accountRepository.UpdateBalance(context.Background(), accountID, newBalance)
+		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
 		assert.NotNil(t, err)
-		assert.Equal(t, "error on update balance", err.Error())
-		assert.Empty(t, updatedAccount.ID)
+		assert.Equal(t, "error on scan", err.Error())
+		assert.Empty(t, account.ID)
 	})
 
-	t.Run("Testing UpdateBalance when successful with transaction", func(t *testing.T) {
+	t.Run("Testing findByID when scan returns an error (no rows)", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
-		assert.NoError(t, err)
+		assert.Nil(t, err)
 		defer db.Close()
 
 		accountRepository := database.NewAccountRepository(db)
 
-		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
-		newBalance := 150
-		accountName := "Lucas"
+		rows := sqlmock.NewRows([]string{"id", "secret", "role"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "secret", "ADMIN").CloseError(errors.New("sql: no rows in result set"))
 
-		mock.ExpectExec(GetSQLUpdateBalanceAccount()).
-			WithArgs(newBalance, accountID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
-
-		rows := sqlmock.NewRows([]string{"id", "name", "balance"}).
-			AddRow(accountID, accountName, newBalance)
-
-		mock.ExpectQuery(GetSQLFindAccountByID()).WithArgs(accountID).WillReturnRows(rows)
+		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnRows(rows)
 
-		updatedAccount, err := accountRepository.UpdateBalance(context.Background(), accountID, newBalance, db)
-		assert.Nil(t, err)
-		assert.Equal(t, accountID, updatedAccount.ID)
-		assert.Equal(t, accountName, updatedAccount.Name)
-		assert.Equal(t, newBalance, updatedAccount.Balance)
+		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
+		assert.NotNil(t, err)
+		assert.Equal(t, "not found account by CPF: 35768297090", err.Error())
+		assert.Empty(t, account.ID)
 	})
+
 }
 
-func TestAccountRepository_FindByCPF(t *testing.T) {
+func TestAccountRepository_UpdateRole(t *testing.T) {
 
-	t.Run("Testing FindByCPF when returns one account", func(t *testing.T) {
+	t.Run("Testing UpdateRole with success", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.Nil(t, err)
 		defer db.Close()
 
 		accountRepository := database.NewAccountRepository(db)
 
-		rows := sqlmock.NewRows([]string{"id", "secret"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "secret")
-
-		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnRows(rows)
+		mock.ExpectExec(GetSQLUpdateRole()).
+			WithArgs(entity.ADMIN_ROLE, "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
+		err = accountRepository.UpdateRole(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.ADMIN_ROLE)
 		assert.Nil(t, err)
-		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", account.ID)
-		assert.Equal(t, "secret", account.Secret)
+		assert.Nil(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("Testing FindByID when execute returns an error", func(t *testing.T) {
+	t.Run("Testing UpdateRole when execute returns an error", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.Nil(t, err)
 		defer db.Close()
 
 		accountRepository := database.NewAccountRepository(db)
 
-		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnError(errors.New("connection closed"))
+		mock.ExpectExec(GetSQLUpdateRole()).
+			WithArgs(entity.ADMIN_ROLE, "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnError(errors.New("connection closed"))
 
-		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
+		err = accountRepository.UpdateRole(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.ADMIN_ROLE)
 		assert.NotNil(t, err)
 		assert.Equal(t, "connection closed", err.Error())
-		assert.Empty(t, account.ID)
-
 	})
+}
 
-	t.Run("Testing findByID when scan returns an error", func(t *testing.T) {
+func TestAccountRepository_UpdateSecret(t *testing.T) {
+	t.Run("Testing UpdateSecret when successful", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.Nil(t, err)
 		defer db.Close()
 
-		accountRepository := database.NewAccountRepository(db)
-
-		rows := sqlmock.NewRows([]string{"id", "secret"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "secret").CloseError(errors.New("error on scan"))
+		mock.ExpectExec(GetSQLUpdateSecret()).
+			WithArgs("hashed", "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnRows(rows)
+		err = database.NewAccountRepository(db).UpdateSecret(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "hashed")
 
-		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
-		assert.NotNil(t, err)
-		assert.Equal(t, "error on scan", err.Error())
-		assert.Empty(t, account.ID)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("Testing findByID when scan returns an error (no rows)", func(t *testing.T) {
+	t.Run("Testing UpdateSecret when ExecContext returns an error", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
 		assert.Nil(t, err)
 		defer db.Close()
 
-		accountRepository := database.NewAccountRepository(db)
+		mock.ExpectExec(GetSQLUpdateSecret()).WillReturnError(errors.New("connection closed"))
 
-		rows := sqlmock.NewRows([]string{"id", "secret"}).
-			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", "secret").CloseError(errors.New("sql: no rows in result set"))
+		err = database.NewAccountRepository(db).UpdateSecret(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "hashed")
 
-		mock.ExpectQuery(GetSQLFindByCPF()).WithArgs("35768297090").WillReturnRows(rows)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
 
-		account, err := accountRepository.FindByCPF(context.Background(), "35768297090")
-		assert.NotNil(t, err)
-		assert.Equal(t, "not found account by CPF: 35768297090", err.Error())
-		assert.Empty(t, account.ID)
+func TestAccountRepository_UpdateCreditLimit(t *testing.T) {
+	t.Run("Testing UpdateCreditLimit when successful", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUpdateCreditLimit()).
+			WithArgs(int64(50000), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.BRL).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = database.NewAccountRepository(db).UpdateCreditLimit(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(50000, entity.BRL))
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("Testing UpdateCreditLimit when ExecContext returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUpdateCreditLimit()).WillReturnError(errors.New("connection closed"))
+
+		err = database.NewAccountRepository(db).UpdateCreditLimit(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(50000, entity.BRL))
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
 }
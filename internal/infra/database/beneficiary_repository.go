@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+)
+
+type BeneficiaryRepository struct {
+	Db *sql.DB
+}
+
+func NewBeneficiaryRepository(db *sql.DB) *BeneficiaryRepository {
+	return &BeneficiaryRepository{
+		Db: db,
+	}
+}
+
+func (r *BeneficiaryRepository) Create(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	query := "INSERT INTO beneficiary (id, account_id, destination_account_id, nickname, favorite, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	_, err := r.Db.ExecContext(ctx, query, beneficiary.ID, beneficiary.AccountID, beneficiary.DestinationAccountID, beneficiary.Nickname, beneficiary.Favorite, beneficiary.CreatedAt, beneficiary.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "1062") {
+			return entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(fmt.Sprintf("beneficiary is already saved: %s", beneficiary.DestinationAccountID))
+		}
+
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *BeneficiaryRepository) FindByID(ctx context.Context, ID string) (entity.Beneficiary, error) {
+	query := `
+		SELECT b.id, b.account_id, b.destination_account_id, a.name, b.nickname, b.favorite, b.created_at, b.updated_at
+		FROM beneficiary b
+		INNER JOIN account a ON a.id = b.destination_account_id
+		WHERE b.id = ?
+	`
+
+	beneficiary, err := scanBeneficiary(r.Db.QueryRowContext(ctx, query, ID))
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.Beneficiary{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found beneficiary: %s", ID))
+		}
+
+		return entity.Beneficiary{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return beneficiary, nil
+}
+
+func (r *BeneficiaryRepository) FindByAccountID(ctx context.Context, accountID string) ([]entity.Beneficiary, error) {
+	query := `
+		SELECT b.id, b.account_id, b.destination_account_id, a.name, b.nickname, b.favorite, b.created_at, b.updated_at
+		FROM beneficiary b
+		INNER JOIN account a ON a.id = b.destination_account_id
+		WHERE b.account_id = ?
+		ORDER BY b.favorite DESC, b.nickname, b.created_at
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	beneficiaries := []entity.Beneficiary{}
+	for rows.Next() {
+		beneficiary, err := scanBeneficiary(rows)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		beneficiaries = append(beneficiaries, beneficiary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return beneficiaries, nil
+}
+
+func (r *BeneficiaryRepository) Update(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	query := "UPDATE beneficiary SET nickname = ?, favorite = ?, updated_at = ? WHERE id = ?"
+
+	_, err := r.Db.ExecContext(ctx, query, beneficiary.Nickname, beneficiary.Favorite, beneficiary.UpdatedAt, beneficiary.ID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *BeneficiaryRepository) Delete(ctx context.Context, accountID string, ID string) error {
+	// beneficiaries of other accounts are left untouched and reported as not found
+	query := "DELETE FROM beneficiary WHERE id = ? AND account_id = ?"
+
+	result, err := r.Db.ExecContext(ctx, query, ID, accountID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found beneficiary: %s", ID))
+	}
+
+	return nil
+}
+
+func scanBeneficiary(row scanner) (entity.Beneficiary, error) {
+	var beneficiary entity.Beneficiary
+
+	err := row.Scan(
+		&beneficiary.ID, &beneficiary.AccountID, &beneficiary.DestinationAccountID, &beneficiary.DestinationName,
+		&beneficiary.Nickname, &beneficiary.Favorite, &beneficiary.CreatedAt, &beneficiary.UpdatedAt,
+	)
+
+	return beneficiary, err
+}
@@ -0,0 +1,173 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLFindBeneficiaryByID() string {
+	return regexp.QuoteMeta("SELECT b.id, b.account_id, b.destination_account_id, a.name, b.nickname, b.favorite, b.created_at, b.updated_at FROM beneficiary b INNER JOIN account a ON a.id = b.destination_account_id WHERE b.id = ?")
+}
+
+func GetSQLFindBeneficiariesByAccountID() string {
+	return regexp.QuoteMeta("SELECT b.id, b.account_id, b.destination_account_id, a.name, b.nickname, b.favorite, b.created_at, b.updated_at FROM beneficiary b INNER JOIN account a ON a.id = b.destination_account_id WHERE b.account_id = ? ORDER BY b.favorite DESC, b.nickname, b.created_at")
+}
+
+func GetBeneficiaryRows() *sqlmock.Rows {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	return sqlmock.NewRows([]string{"id", "account_id", "destination_account_id", "name", "nickname", "favorite", "created_at", "updated_at"}).
+		AddRow("6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", "joao", "Joao", true, createdAt, createdAt)
+}
+
+func TestBeneficiaryRepository_Create(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	beneficiary := &entity.Beneficiary{
+		ID:                   "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60",
+		AccountID:            "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+		DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6",
+		Nickname:             "Joao",
+		CreatedAt:            &createdAt,
+		UpdatedAt:            &createdAt,
+	}
+
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO beneficiary (id, account_id, destination_account_id, nickname, favorite, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)")).
+			WithArgs(beneficiary.ID, beneficiary.AccountID, beneficiary.DestinationAccountID, "Joao", false, &createdAt, &createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewBeneficiaryRepository(db).Create(context.Background(), beneficiary)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when the destination account is already saved", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO beneficiary")).
+			WillReturnError(errors.New("Error 1062 (23000): Duplicate entry for key 'beneficiary.idx_beneficiary_account_destination'"))
+
+		err := database.NewBeneficiaryRepository(db).Create(context.Background(), beneficiary)
+
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "beneficiary is already saved: d18551d3-cf13-49ec-b1dc-741a1f8715f6", err.Error())
+	})
+}
+
+func TestBeneficiaryRepository_FindByID(t *testing.T) {
+	t.Run("Testing FindByID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindBeneficiaryByID()).
+			WithArgs("6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").
+			WillReturnRows(GetBeneficiaryRows())
+
+		beneficiary, err := database.NewBeneficiaryRepository(db).FindByID(context.Background(), "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", beneficiary.DestinationAccountID)
+		assert.Equal(t, "joao", beneficiary.DestinationName)
+		assert.True(t, beneficiary.Favorite)
+	})
+
+	t.Run("Testing FindByID when the beneficiary does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindBeneficiaryByID()).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := database.NewBeneficiaryRepository(db).FindByID(context.Background(), "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found beneficiary: 6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", err.Error())
+	})
+}
+
+func TestBeneficiaryRepository_FindByAccountID(t *testing.T) {
+	t.Run("Testing FindByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindBeneficiariesByAccountID()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnRows(GetBeneficiaryRows())
+
+		beneficiaries, err := database.NewBeneficiaryRepository(db).FindByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+
+		assert.Nil(t, err)
+		assert.Len(t, beneficiaries, 1)
+		assert.Equal(t, "Joao", beneficiaries[0].Nickname)
+	})
+
+	t.Run("Testing FindByAccountID when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindBeneficiariesByAccountID()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewBeneficiaryRepository(db).FindByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestBeneficiaryRepository_Update(t *testing.T) {
+	t.Run("Testing Update when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		updatedAt := time.Date(2023, 8, 15, 10, 00, 00, 00, time.UTC)
+		beneficiary := &entity.Beneficiary{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", Nickname: "Mom", Favorite: true, UpdatedAt: &updatedAt}
+
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE beneficiary SET nickname = ?, favorite = ?, updated_at = ? WHERE id = ?")).
+			WithArgs("Mom", true, &updatedAt, "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewBeneficiaryRepository(db).Update(context.Background(), beneficiary)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBeneficiaryRepository_Delete(t *testing.T) {
+	t.Run("Testing Delete when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM beneficiary WHERE id = ? AND account_id = ?")).
+			WithArgs("6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewBeneficiaryRepository(db).Delete(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60")
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Delete when the beneficiary belongs to another account", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM beneficiary WHERE id = ? AND account_id = ?")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := database.NewBeneficiaryRepository(db).Delete(context.Background(), "d18551d3-cf13-49ec-b1dc-741a1f8715f6", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
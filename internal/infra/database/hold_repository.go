@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type HoldRepository struct {
+	Db *sql.DB
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{
+		Db: db,
+	}
+}
+
+const holdColumns = `id, origin_account_id, destination_account_id, amount, captured_amount, currency, transfer_id,
+	status, expires_at, created_at, updated_at`
+
+func (r *HoldRepository) Create(ctx context.Context, hold *entity.Hold, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO hold (` + holdColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := executor.ExecContext(
+		ctx, query, hold.ID, hold.OriginAccount.ID, hold.DestinationAccount.ID, hold.Amount.Amount, hold.CapturedAmount.Amount,
+		hold.Amount.Currency, hold.TransferID, hold.Status, hold.ExpiresAt, hold.CreatedAt, hold.UpdatedAt,
+	)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return r.changeHeld(ctx, executor, hold.OriginAccount.ID, hold.Amount)
+}
+
+func (r *HoldRepository) FindByID(ctx context.Context, ID string) (entity.Hold, error) {
+	query := "SELECT " + holdColumns + " FROM hold WHERE id = ?"
+
+	return r.findByID(ctx, r.Db, query, ID)
+}
+
+// FindByIDForUpdate reads the hold with a row lock inside the transaction, the accounts only
+// carry their IDs.
+func (r *HoldRepository) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Hold, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "SELECT " + holdColumns + " FROM hold WHERE id = ? FOR UPDATE"
+
+	return r.findByID(ctx, executor, query, ID)
+}
+
+func (r *HoldRepository) findByID(ctx context.Context, executor entity.TransactionHandler, query string, ID string) (entity.Hold, error) {
+	var hold entity.Hold
+	var originAccount entity.Account
+	var destinationAccount entity.Account
+
+	err := executor.QueryRowContext(ctx, query, ID).Scan(
+		&hold.ID, &originAccount.ID, &destinationAccount.ID, &hold.Amount.Amount, &hold.CapturedAmount.Amount,
+		&hold.Amount.Currency, &hold.TransferID, &hold.Status, &hold.ExpiresAt, &hold.CreatedAt, &hold.UpdatedAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.Hold{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found hold: %s", ID))
+		}
+
+		return entity.Hold{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	hold.CapturedAmount.Currency = hold.Amount.Currency
+	hold.OriginAccount = &originAccount
+	hold.DestinationAccount = &destinationAccount
+
+	return hold, nil
+}
+
+func (r *HoldRepository) Finish(ctx context.Context, hold *entity.Hold, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	// the status condition keeps a hold from being finished, and given back, twice
+	query := "UPDATE hold SET captured_amount = ?, transfer_id = ?, status = ?, updated_at = ? WHERE id = ? AND status = ?"
+
+	result, err := executor.ExecContext(ctx, query, hold.CapturedAmount.Amount, hold.TransferID, hold.Status, hold.UpdatedAt, hold.ID, entity.ACTIVE_HOLD)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(fmt.Sprintf("hold is no longer active: %s", hold.ID))
+	}
+
+	return r.changeHeld(ctx, executor, hold.OriginAccount.ID, entity.NewMoney(-hold.Amount.Amount, hold.Amount.Currency))
+}
+
+func (r *HoldRepository) FindExpiredIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	query := "SELECT id FROM hold WHERE status = ? AND expires_at <= ? ORDER BY expires_at, id LIMIT ?"
+
+	rows, err := r.Db.QueryContext(ctx, query, entity.ACTIVE_HOLD, now, limit)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	holdIDs := []string{}
+	for rows.Next() {
+		var holdID string
+
+		err := rows.Scan(&holdID)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		holdIDs = append(holdIDs, holdID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return holdIDs, nil
+}
+
+func (r *HoldRepository) changeHeld(ctx context.Context, executor entity.TransactionHandler, accountID string, amount entity.Money) error {
+	// the currency condition keeps a hold from changing the funds held of an account in another currency
+	query := "UPDATE account SET held = held + ? WHERE id = ? AND currency = ?"
+
+	result, err := executor.ExecContext(ctx, query, amount.Amount, accountID, amount.Currency)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("unexpected number of affected rows")
+	}
+
+	return nil
+}
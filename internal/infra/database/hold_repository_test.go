@@ -0,0 +1,192 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertHold() string {
+	return regexp.QuoteMeta("INSERT INTO hold (id, origin_account_id, destination_account_id, amount, captured_amount, currency, transfer_id,")
+}
+
+func GetSQLFindHoldByIDForUpdate() string {
+	return regexp.QuoteMeta("FROM hold WHERE id = ? FOR UPDATE")
+}
+
+func GetSQLFinishHold() string {
+	return regexp.QuoteMeta("UPDATE hold SET captured_amount = ?, transfer_id = ?, status = ?, updated_at = ? WHERE id = ? AND status = ?")
+}
+
+func GetSQLUpdateAccountHeld() string {
+	return regexp.QuoteMeta("UPDATE account SET held = held + ? WHERE id = ? AND currency = ?")
+}
+
+func GetHoldColumns() []string {
+	return []string{"id", "origin_account_id", "destination_account_id", "amount", "captured_amount", "currency", "transfer_id", "status", "expires_at", "created_at", "updated_at"}
+}
+
+func GetBaseHold() *entity.Hold {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+
+	return &entity.Hold{
+		ID:                 "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		OriginAccount:      &entity.Account{ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477"},
+		DestinationAccount: &entity.Account{ID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6"},
+		Amount:             entity.NewMoney(5000, entity.BRL),
+		CapturedAmount:     entity.NewMoney(0, entity.BRL),
+		Status:             entity.ACTIVE_HOLD,
+		ExpiresAt:          &expiresAt,
+		CreatedAt:          &createdAt,
+		UpdatedAt:          &createdAt,
+	}
+}
+
+func TestHoldRepository_Create(t *testing.T) {
+	t.Run("Testing Create saves the hold and holds the funds of the origin account", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		hold := GetBaseHold()
+
+		mock.ExpectExec(GetSQLInsertHold()).
+			WithArgs(hold.ID, hold.OriginAccount.ID, hold.DestinationAccount.ID, int64(5000), int64(0), entity.BRL, "", entity.ACTIVE_HOLD, hold.ExpiresAt, hold.CreatedAt, hold.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLUpdateAccountHeld()).
+			WithArgs(int64(5000), hold.OriginAccount.ID, entity.BRL).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewHoldRepository(db).Create(context.Background(), hold)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when the account is in another currency", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertHold()).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLUpdateAccountHeld()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := database.NewHoldRepository(db).Create(context.Background(), GetBaseHold())
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "unexpected number of affected rows", err.Error())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertHold()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewHoldRepository(db).Create(context.Background(), GetBaseHold())
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestHoldRepository_FindByIDForUpdate(t *testing.T) {
+	t.Run("Testing FindByIDForUpdate returns the hold", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		hold := GetBaseHold()
+
+		mock.ExpectQuery(GetSQLFindHoldByIDForUpdate()).WithArgs(hold.ID).WillReturnRows(sqlmock.NewRows(GetHoldColumns()).
+			AddRow(hold.ID, hold.OriginAccount.ID, hold.DestinationAccount.ID, 5000, 0, "BRL", "", "ACTIVE", *hold.ExpiresAt, *hold.CreatedAt, *hold.UpdatedAt))
+
+		found, err := database.NewHoldRepository(db).FindByIDForUpdate(context.Background(), hold.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, *hold, found)
+	})
+
+	t.Run("Testing FindByIDForUpdate when the hold does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindHoldByIDForUpdate()).WillReturnRows(sqlmock.NewRows(GetHoldColumns()))
+
+		_, err := database.NewHoldRepository(db).FindByIDForUpdate(context.Background(), "7c9e6679-7425-40de-944b-e07fc1f90ae7")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found hold: 7c9e6679-7425-40de-944b-e07fc1f90ae7", err.Error())
+	})
+}
+
+func TestHoldRepository_Finish(t *testing.T) {
+	t.Run("Testing Finish saves the hold and gives back the funds of the origin account", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		hold := GetBaseHold()
+		hold.Status = entity.CAPTURED_HOLD
+		hold.CapturedAmount = entity.NewMoney(3000, entity.BRL)
+		hold.TransferID = "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+
+		mock.ExpectExec(GetSQLFinishHold()).
+			WithArgs(int64(3000), hold.TransferID, entity.CAPTURED_HOLD, hold.UpdatedAt, hold.ID, entity.ACTIVE_HOLD).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(GetSQLUpdateAccountHeld()).
+			WithArgs(int64(-5000), hold.OriginAccount.ID, entity.BRL).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewHoldRepository(db).Finish(context.Background(), hold)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Finish when the hold is no longer active", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLFinishHold()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := database.NewHoldRepository(db).Finish(context.Background(), GetBaseHold())
+
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "hold is no longer active: 7c9e6679-7425-40de-944b-e07fc1f90ae7", err.Error())
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestHoldRepository_FindExpiredIDs(t *testing.T) {
+	now := time.Date(2023, 8, 15, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing FindExpiredIDs when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM hold WHERE status = ? AND expires_at <= ? ORDER BY expires_at, id LIMIT ?")).
+			WithArgs(entity.ACTIVE_HOLD, now, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("7c9e6679-7425-40de-944b-e07fc1f90ae7"))
+
+		holdIDs, err := database.NewHoldRepository(db).FindExpiredIDs(context.Background(), now, 100)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"7c9e6679-7425-40de-944b-e07fc1f90ae7"}, holdIDs)
+	})
+
+	t.Run("Testing FindExpiredIDs when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM hold")).WillReturnError(errors.New("connection closed"))
+
+		holdIDs, err := database.NewHoldRepository(db).FindExpiredIDs(context.Background(), now, 100)
+
+		assert.Nil(t, holdIDs)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type IdempotencyKeyRepository struct {
+	Db *sql.DB
+}
+
+func NewIdempotencyKeyRepository(db *sql.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{
+		Db: db,
+	}
+}
+
+func (r *IdempotencyKeyRepository) FindByKey(ctx context.Context, accountID string, key string) (entity.IdempotencyKey, error) {
+	query := `
+		SELECT request_key, account_id, fingerprint, status_code, response, created_at, expires_at
+		FROM idempotency_key
+		WHERE account_id = ? AND request_key = ?
+	`
+
+	row := r.Db.QueryRowContext(ctx, query, accountID, key)
+
+	var idempotencyKey entity.IdempotencyKey
+	var response sql.NullString
+
+	err := row.Scan(
+		&idempotencyKey.Key, &idempotencyKey.AccountID, &idempotencyKey.Fingerprint, &idempotencyKey.StatusCode,
+		&response, &idempotencyKey.CreatedAt, &idempotencyKey.ExpiresAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.IdempotencyKey{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found idempotency key: %s", key))
+		}
+
+		return entity.IdempotencyKey{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	idempotencyKey.Response = response.String
+
+	return idempotencyKey, nil
+}
+
+func (r *IdempotencyKeyRepository) Create(ctx context.Context, idempotencyKey *entity.IdempotencyKey) (entity.IdempotencyKey, error) {
+	query := `
+		INSERT INTO idempotency_key (request_key, account_id, fingerprint, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Db.ExecContext(
+		ctx, query, idempotencyKey.Key, idempotencyKey.AccountID, idempotencyKey.Fingerprint, idempotencyKey.CreatedAt, idempotencyKey.ExpiresAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "1062") {
+			return entity.IdempotencyKey{}, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(err.Error())
+		}
+
+		return entity.IdempotencyKey{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return *idempotencyKey, nil
+}
+
+func (r *IdempotencyKeyRepository) Complete(ctx context.Context, idempotencyKey *entity.IdempotencyKey) error {
+	query := "UPDATE idempotency_key SET status_code = ?, response = ? WHERE account_id = ? AND request_key = ?"
+
+	_, err := r.Db.ExecContext(ctx, query, idempotencyKey.StatusCode, idempotencyKey.Response, idempotencyKey.AccountID, idempotencyKey.Key)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *IdempotencyKeyRepository) Delete(ctx context.Context, accountID string, key string) error {
+	query := "DELETE FROM idempotency_key WHERE account_id = ? AND request_key = ?"
+
+	_, err := r.Db.ExecContext(ctx, query, accountID, key)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := "DELETE FROM idempotency_key WHERE expires_at <= ?"
+
+	_, err := r.Db.ExecContext(ctx, query, now)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
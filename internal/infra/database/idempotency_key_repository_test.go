@@ -0,0 +1,223 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLFindIdempotencyKey() string {
+	return regexp.QuoteMeta(`SELECT request_key, account_id, fingerprint, status_code, response, created_at, expires_at FROM idempotency_key WHERE account_id = ? AND request_key = ?`)
+}
+
+func GetSQLInsertIdempotencyKey() string {
+	return regexp.QuoteMeta(`INSERT INTO idempotency_key (request_key, account_id, fingerprint, created_at, expires_at) VALUES (?, ?, ?, ?, ?)`)
+}
+
+func GetSQLCompleteIdempotencyKey() string {
+	return regexp.QuoteMeta("UPDATE idempotency_key SET status_code = ?, response = ? WHERE account_id = ? AND request_key = ?")
+}
+
+func GetSQLDeleteIdempotencyKey() string {
+	return regexp.QuoteMeta("DELETE FROM idempotency_key WHERE account_id = ? AND request_key = ?")
+}
+
+func GetSQLDeleteExpiredIdempotencyKeys() string {
+	return regexp.QuoteMeta("DELETE FROM idempotency_key WHERE expires_at <= ?")
+}
+
+func GetBaseIdempotencyKey(t *testing.T) *entity.IdempotencyKey {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	idempotencyKey, err := entity.NewIdempotencyKey("0b8b418c", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "fingerprint", &createdAt, time.Hour)
+
+	assert.Nil(t, err)
+	return idempotencyKey
+}
+
+func TestIdempotencyKeyRepository_FindByKey(t *testing.T) {
+	t.Run("Testing FindByKey when returns one key", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		idempotencyKey := GetBaseIdempotencyKey(t)
+
+		rows := sqlmock.NewRows([]string{"request_key", "account_id", "fingerprint", "status_code", "response", "created_at", "expires_at"}).
+			AddRow(idempotencyKey.Key, idempotencyKey.AccountID, idempotencyKey.Fingerprint, 201, `{"id":"fc84682a"}`, *idempotencyKey.CreatedAt, *idempotencyKey.ExpiresAt)
+
+		mock.ExpectQuery(GetSQLFindIdempotencyKey()).WithArgs(idempotencyKey.AccountID, idempotencyKey.Key).WillReturnRows(rows)
+
+		found, err := repository.FindByKey(context.Background(), idempotencyKey.AccountID, idempotencyKey.Key)
+
+		assert.Nil(t, err)
+		assert.Equal(t, idempotencyKey.Key, found.Key)
+		assert.Equal(t, 201, found.StatusCode)
+		assert.Equal(t, `{"id":"fc84682a"}`, found.Response)
+	})
+
+	t.Run("Testing FindByKey when key does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+
+		rows := sqlmock.NewRows([]string{"request_key", "account_id", "fingerprint", "status_code", "response", "created_at", "expires_at"})
+		mock.ExpectQuery(GetSQLFindIdempotencyKey()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", "0b8b418c").WillReturnRows(rows)
+
+		_, err := repository.FindByKey(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "0b8b418c")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "not found idempotency key: 0b8b418c", err.Error())
+	})
+
+	t.Run("Testing FindByKey when QueryRowContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+
+		mock.ExpectQuery(GetSQLFindIdempotencyKey()).WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", "0b8b418c").WillReturnError(errors.New("connection closed"))
+
+		_, err := repository.FindByKey(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "0b8b418c")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestIdempotencyKeyRepository_Create(t *testing.T) {
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		idempotencyKey := GetBaseIdempotencyKey(t)
+
+		mock.ExpectExec(GetSQLInsertIdempotencyKey()).
+			WithArgs(idempotencyKey.Key, idempotencyKey.AccountID, idempotencyKey.Fingerprint, idempotencyKey.CreatedAt, idempotencyKey.ExpiresAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		created, err := repository.Create(context.Background(), idempotencyKey)
+
+		assert.Nil(t, err)
+		assert.Equal(t, *idempotencyKey, created)
+	})
+
+	t.Run("Testing Create when key already exists", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		idempotencyKey := GetBaseIdempotencyKey(t)
+
+		mock.ExpectExec(GetSQLInsertIdempotencyKey()).
+			WithArgs(idempotencyKey.Key, idempotencyKey.AccountID, idempotencyKey.Fingerprint, idempotencyKey.CreatedAt, idempotencyKey.ExpiresAt).
+			WillReturnError(errors.New("Error 1062: Duplicate entry"))
+
+		_, err := repository.Create(context.Background(), idempotencyKey)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).GetTypeError())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		idempotencyKey := GetBaseIdempotencyKey(t)
+
+		mock.ExpectExec(GetSQLInsertIdempotencyKey()).
+			WithArgs(idempotencyKey.Key, idempotencyKey.AccountID, idempotencyKey.Fingerprint, idempotencyKey.CreatedAt, idempotencyKey.ExpiresAt).
+			WillReturnError(errors.New("connection closed"))
+
+		_, err := repository.Create(context.Background(), idempotencyKey)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).GetTypeError())
+	})
+}
+
+func TestIdempotencyKeyRepository_Complete(t *testing.T) {
+	t.Run("Testing Complete when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		idempotencyKey := GetBaseIdempotencyKey(t)
+		idempotencyKey.Complete(201, `{"id":"fc84682a"}`)
+
+		mock.ExpectExec(GetSQLCompleteIdempotencyKey()).
+			WithArgs(201, `{"id":"fc84682a"}`, idempotencyKey.AccountID, idempotencyKey.Key).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.Complete(context.Background(), idempotencyKey)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Complete when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		idempotencyKey := GetBaseIdempotencyKey(t)
+
+		mock.ExpectExec(GetSQLCompleteIdempotencyKey()).WillReturnError(errors.New("connection closed"))
+
+		err := repository.Complete(context.Background(), idempotencyKey)
+		assert.NotNil(t, err)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestIdempotencyKeyRepository_Delete(t *testing.T) {
+	t.Run("Testing Delete when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+
+		mock.ExpectExec(GetSQLDeleteIdempotencyKey()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", "0b8b418c").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.Delete(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "0b8b418c")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing DeleteExpired when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLDeleteExpiredIdempotencyKeys()).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := repository.DeleteExpired(context.Background(), now)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing DeleteExpired when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := database.NewIdempotencyKeyRepository(db)
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLDeleteExpiredIdempotencyKeys()).WillReturnError(errors.New("connection closed"))
+
+		err := repository.DeleteExpired(context.Background(), now)
+		assert.NotNil(t, err)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type LedgerRepository struct {
+	Db *sql.DB
+}
+
+func NewLedgerRepository(db *sql.DB) *LedgerRepository {
+	return &LedgerRepository{
+		Db: db,
+	}
+}
+
+// Post appends the journal entry with its postings and applies the postings to the cached
+// balance of the accounts. Journal entries and postings are never updated or deleted.
+func (r *LedgerRepository) Post(ctx context.Context, entry *entity.JournalEntry, tx ...entity.TransactionHandler) (entity.JournalEntry, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "INSERT INTO journal_entry (id, entry_type, reference_id, created_at) VALUES (?, ?, ?, ?)"
+
+	_, err := executor.ExecContext(ctx, query, entry.ID, entry.Type, entry.ReferenceID, entry.CreatedAt)
+	if err != nil {
+		return entity.JournalEntry{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	for _, posting := range entry.Postings {
+		query = "INSERT INTO posting (id, journal_entry_id, account_id, direction, amount, currency, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+		_, err = executor.ExecContext(ctx, query, posting.ID, entry.ID, posting.AccountID, posting.Direction, posting.Amount.Amount, posting.Amount.Currency, entry.CreatedAt)
+		if err != nil {
+			return entity.JournalEntry{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		if posting.IsExternal() {
+			continue
+		}
+
+		// the currency condition keeps a posting from changing the balance of an account in another currency
+		query = "UPDATE account SET balance = balance + ? WHERE id = ? AND currency = ?"
+
+		result, err := executor.ExecContext(ctx, query, posting.SignedAmount().Amount, posting.AccountID, posting.Amount.Currency)
+		if err != nil {
+			return entity.JournalEntry{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		affectedRows, err := result.RowsAffected()
+		if err != nil {
+			return entity.JournalEntry{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		if affectedRows != 1 {
+			return entity.JournalEntry{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("unexpected number of affected rows")
+		}
+	}
+
+	return *entry, nil
+}
+
+func (r *LedgerRepository) FindBalances(ctx context.Context) ([]entity.LedgerBalance, error) {
+	query := `
+		SELECT a.id, a.balance, a.currency,
+			COALESCE(SUM(CASE WHEN p.direction = 'CREDIT' THEN p.amount ELSE -p.amount END), 0) AS ledger_balance
+		FROM account a
+		LEFT JOIN posting p ON p.account_id = a.id
+		GROUP BY a.id, a.balance, a.currency
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	balances := []entity.LedgerBalance{}
+	for rows.Next() {
+		var balance entity.LedgerBalance
+
+		err := rows.Scan(&balance.AccountID, &balance.CachedBalance.Amount, &balance.CachedBalance.Currency, &balance.LedgerBalance.Amount)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		balance.LedgerBalance.Currency = balance.CachedBalance.Currency
+
+		balances = append(balances, balance)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return balances, nil
+}
+
+func (r *LedgerRepository) FindUnbalancedEntries(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT journal_entry_id
+		FROM (
+			SELECT journal_entry_id
+			FROM posting
+			GROUP BY journal_entry_id, currency
+			HAVING SUM(CASE WHEN direction = 'CREDIT' THEN amount ELSE -amount END) <> 0
+		) unbalanced
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	entryIDs := []string{}
+	for rows.Next() {
+		var entryID string
+
+		err := rows.Scan(&entryID)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		entryIDs = append(entryIDs, entryID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return entryIDs, nil
+}
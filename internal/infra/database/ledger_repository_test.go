@@ -0,0 +1,202 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertJournalEntry() string {
+	return regexp.QuoteMeta("INSERT INTO journal_entry (id, entry_type, reference_id, created_at) VALUES (?, ?, ?, ?)")
+}
+
+func GetSQLInsertPosting() string {
+	return regexp.QuoteMeta("INSERT INTO posting (id, journal_entry_id, account_id, direction, amount, currency, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)")
+}
+
+func GetSQLApplyPosting() string {
+	return regexp.QuoteMeta("UPDATE account SET balance = balance + ? WHERE id = ? AND currency = ?")
+}
+
+func GetTransferJournalEntry(t *testing.T) *entity.JournalEntry {
+	createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+	postings := []entity.Posting{
+		entity.NewPosting("a1f3c7d2-0c55-4c2b-9a51-6b8f0e0e2a11", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.DEBIT, entity.NewMoney(50, entity.BRL)),
+		entity.NewPosting("b7e2a9c4-3d1f-4e8a-8c6b-2f9d1a0b3c22", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CREDIT, entity.NewMoney(50, entity.BRL)),
+	}
+
+	entry, err := entity.NewJournalEntry("fc84682a-3045-4bdf-b91c-10be19f89452", entity.TRANSFER_ENTRY, "237d3e7e-2f46-44e7-bf2b-f79721459241", postings, &createdAt)
+	assert.Nil(t, err)
+	return entry
+}
+
+func TestLedgerRepository_Post(t *testing.T) {
+	t.Run("Testing Post when successful", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		entry := GetTransferJournalEntry(t)
+
+		mock.ExpectExec(GetSQLInsertJournalEntry()).
+			WithArgs(entry.ID, entry.Type, entry.ReferenceID, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLInsertPosting()).
+			WithArgs(entry.Postings[0].ID, entry.ID, entry.Postings[0].AccountID, entity.DEBIT, int64(50), entity.BRL, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLApplyPosting()).
+			WithArgs(int64(-50), entry.Postings[0].AccountID, entity.BRL).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(GetSQLInsertPosting()).
+			WithArgs(entry.Postings[1].ID, entry.ID, entry.Postings[1].AccountID, entity.CREDIT, int64(50), entity.BRL, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLApplyPosting()).
+			WithArgs(int64(50), entry.Postings[1].AccountID, entity.BRL).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		postedEntry, err := ledgerRepository.Post(context.Background(), entry)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entry.ID, postedEntry.ID)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Post does not update the balance of the external account", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		entry, err := entity.NewOpeningBalanceJournalEntry("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(100, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		mock.ExpectExec(GetSQLInsertJournalEntry()).
+			WithArgs(entry.ID, entry.Type, entry.ReferenceID, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLInsertPosting()).
+			WithArgs(entry.Postings[0].ID, entry.ID, entity.EXTERNAL_LEDGER_ACCOUNT, entity.DEBIT, int64(100), entity.BRL, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLInsertPosting()).
+			WithArgs(entry.Postings[1].ID, entry.ID, "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CREDIT, int64(100), entity.BRL, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLApplyPosting()).
+			WithArgs(int64(100), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.BRL).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		_, err = ledgerRepository.Post(context.Background(), entry, db)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Post when the account does not exist", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		entry := GetTransferJournalEntry(t)
+
+		mock.ExpectExec(GetSQLInsertJournalEntry()).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLInsertPosting()).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLApplyPosting()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		_, err = ledgerRepository.Post(context.Background(), entry)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "unexpected number of affected rows", err.Error())
+	})
+
+	t.Run("Testing Post when ExecContext returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		entry := GetTransferJournalEntry(t)
+
+		mock.ExpectExec(GetSQLInsertJournalEntry()).WillReturnError(errors.New("connection closed"))
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		_, err = ledgerRepository.Post(context.Background(), entry)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestLedgerRepository_FindBalances(t *testing.T) {
+	t.Run("Testing FindBalances when returns two accounts", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "balance", "currency", "ledger_balance"}).
+			AddRow("2bd765a6-47bd-4731-9eb2-1e65542f4477", 100, "BRL", 100).
+			AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", 250, "BRL", 200)
+
+		mock.ExpectQuery("SELECT a.id, a.balance").WillReturnRows(rows)
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		balances, err := ledgerRepository.FindBalances(context.Background())
+
+		assert.Nil(t, err)
+		assert.Len(t, balances, 2)
+		assert.False(t, balances[0].HasDrift())
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), balances[1].Drift())
+	})
+
+	t.Run("Testing FindBalances when QueryContext returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT a.id, a.balance").WillReturnError(errors.New("connection closed"))
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		balances, err := ledgerRepository.FindBalances(context.Background())
+
+		assert.Nil(t, balances)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestLedgerRepository_FindUnbalancedEntries(t *testing.T) {
+	t.Run("Testing FindUnbalancedEntries when returns one entry", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"journal_entry_id"}).AddRow("fc84682a-3045-4bdf-b91c-10be19f89452")
+
+		mock.ExpectQuery("SELECT DISTINCT journal_entry_id").WillReturnRows(rows)
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		entryIDs, err := ledgerRepository.FindUnbalancedEntries(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"fc84682a-3045-4bdf-b91c-10be19f89452"}, entryIDs)
+	})
+
+	t.Run("Testing FindUnbalancedEntries when QueryContext returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT DISTINCT journal_entry_id").WillReturnError(errors.New("connection closed"))
+
+		ledgerRepository := database.NewLedgerRepository(db)
+		entryIDs, err := ledgerRepository.FindUnbalancedEntries(context.Background())
+
+		assert.Nil(t, entryIDs)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
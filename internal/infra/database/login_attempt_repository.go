@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type LoginAttemptRepository struct {
+	Db *sql.DB
+}
+
+func NewLoginAttemptRepository(db *sql.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		Db: db,
+	}
+}
+
+func (r *LoginAttemptRepository) Create(ctx context.Context, loginAttempt *entity.LoginAttempt) error {
+	query := "INSERT INTO login_attempt (id, cpf, ip, reason, created_at) VALUES (?, ?, ?, ?, ?)"
+
+	_, err := r.Db.ExecContext(ctx, query, loginAttempt.ID, loginAttempt.CPF, loginAttempt.IP, loginAttempt.Reason, loginAttempt.CreatedAt)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
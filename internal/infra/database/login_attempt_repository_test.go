@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertLoginAttempt() string {
+	return regexp.QuoteMeta("INSERT INTO login_attempt (id, cpf, ip, reason, created_at) VALUES (?, ?, ?, ?, ?)")
+}
+
+func TestLoginAttemptRepository_Create(t *testing.T) {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	loginAttempt, err := entity.NewLoginAttempt("34688151071", "203.0.113.7", entity.WRONG_SECRET_LOGIN_FAILURE, &createdAt)
+	assert.Nil(t, err)
+
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertLoginAttempt()).
+			WithArgs(loginAttempt.ID, loginAttempt.CPF, loginAttempt.IP, loginAttempt.Reason, loginAttempt.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewLoginAttemptRepository(db).Create(context.Background(), loginAttempt)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertLoginAttempt()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewLoginAttemptRepository(db).Create(context.Background(), loginAttempt)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
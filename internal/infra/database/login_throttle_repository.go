@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type LoginThrottleRepository struct {
+	Db *sql.DB
+}
+
+func NewLoginThrottleRepository(db *sql.DB) *LoginThrottleRepository {
+	return &LoginThrottleRepository{
+		Db: db,
+	}
+}
+
+// FindByKey returns the failures of the key, a key without failures is not an error.
+func (r *LoginThrottleRepository) FindByKey(ctx context.Context, key string) (entity.LoginThrottle, error) {
+	return r.findByKey(ctx, r.Db, "SELECT throttle_key, failures, last_failure_at FROM login_throttle WHERE throttle_key = ?", key)
+}
+
+// FindByKeyForUpdate locks the failures of the key inside the transaction, so concurrent attempts
+// are checked one at a time. A key without failures gets an empty row first, otherwise there would
+// be no row to lock.
+func (r *LoginThrottleRepository) FindByKeyForUpdate(ctx context.Context, key string, now time.Time, tx ...entity.TransactionHandler) (entity.LoginThrottle, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO login_throttle (throttle_key, failures, last_failure_at)
+		VALUES (?, 0, ?)
+		ON DUPLICATE KEY UPDATE throttle_key = throttle_key
+	`
+
+	_, err := executor.ExecContext(ctx, query, key, now)
+	if err != nil {
+		return entity.LoginThrottle{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return r.findByKey(ctx, executor, "SELECT throttle_key, failures, last_failure_at FROM login_throttle WHERE throttle_key = ? FOR UPDATE", key)
+}
+
+func (r *LoginThrottleRepository) findByKey(ctx context.Context, executor entity.TransactionHandler, query string, key string) (entity.LoginThrottle, error) {
+	var throttle entity.LoginThrottle
+	err := executor.QueryRowContext(ctx, query, key).Scan(&throttle.Key, &throttle.Failures, &throttle.LastFailureAt)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.LoginThrottle{Key: key}, nil
+		}
+
+		return entity.LoginThrottle{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return throttle, nil
+}
+
+// RegisterFailure increments the failures in a single statement, so concurrent attempts are
+// all counted. The count starts over when the last failure happened before resetBefore.
+func (r *LoginThrottleRepository) RegisterFailure(ctx context.Context, key string, now time.Time, resetBefore time.Time, tx ...entity.TransactionHandler) (entity.LoginThrottle, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO login_throttle (throttle_key, failures, last_failure_at)
+		VALUES (?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			failures = IF(last_failure_at < ?, 1, failures + 1),
+			last_failure_at = VALUES(last_failure_at)
+	`
+
+	_, err := executor.ExecContext(ctx, query, key, now, resetBefore)
+	if err != nil {
+		return entity.LoginThrottle{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return r.findByKey(ctx, executor, "SELECT throttle_key, failures, last_failure_at FROM login_throttle WHERE throttle_key = ?", key)
+}
+
+func (r *LoginThrottleRepository) Reset(ctx context.Context, key string, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "DELETE FROM login_throttle WHERE throttle_key = ?"
+
+	_, err := executor.ExecContext(ctx, query, key)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
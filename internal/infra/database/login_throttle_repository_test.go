@@ -0,0 +1,179 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLFindLoginThrottle() string {
+	return regexp.QuoteMeta("SELECT throttle_key, failures, last_failure_at FROM login_throttle WHERE throttle_key = ?")
+}
+
+func GetSQLCreateEmptyLoginThrottle() string {
+	return regexp.QuoteMeta("INSERT INTO login_throttle (throttle_key, failures, last_failure_at) VALUES (?, 0, ?)")
+}
+
+func GetSQLFindLoginThrottleForUpdate() string {
+	return regexp.QuoteMeta("SELECT throttle_key, failures, last_failure_at FROM login_throttle WHERE throttle_key = ? FOR UPDATE")
+}
+
+func GetSQLRegisterLoginFailure() string {
+	return regexp.QuoteMeta("INSERT INTO login_throttle (throttle_key, failures, last_failure_at)")
+}
+
+func GetSQLResetLoginThrottle() string {
+	return regexp.QuoteMeta("DELETE FROM login_throttle WHERE throttle_key = ?")
+}
+
+func TestLoginThrottleRepository_FindByKey(t *testing.T) {
+	lastFailureAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+	t.Run("Testing FindByKey when key has failures", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindLoginThrottle()).
+			WithArgs("cpf:34688151071").
+			WillReturnRows(sqlmock.NewRows([]string{"throttle_key", "failures", "last_failure_at"}).AddRow("cpf:34688151071", 3, lastFailureAt))
+
+		throttle, err := database.NewLoginThrottleRepository(db).FindByKey(context.Background(), "cpf:34688151071")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, throttle.Failures)
+		assert.Equal(t, lastFailureAt, *throttle.LastFailureAt)
+	})
+
+	t.Run("Testing FindByKey when key has no failures", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindLoginThrottle()).
+			WithArgs("cpf:34688151071").
+			WillReturnRows(sqlmock.NewRows([]string{"throttle_key", "failures", "last_failure_at"}))
+
+		throttle, err := database.NewLoginThrottleRepository(db).FindByKey(context.Background(), "cpf:34688151071")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "cpf:34688151071", throttle.Key)
+		assert.Equal(t, 0, throttle.Failures)
+		assert.Nil(t, throttle.LastFailureAt)
+	})
+
+	t.Run("Testing FindByKey when QueryRowContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindLoginThrottle()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewLoginThrottleRepository(db).FindByKey(context.Background(), "cpf:34688151071")
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestLoginThrottleRepository_FindByKeyForUpdate(t *testing.T) {
+	now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	lastFailureAt := now.Add(-time.Minute)
+
+	t.Run("Testing FindByKeyForUpdate locks the row of the key", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(GetSQLCreateEmptyLoginThrottle()).
+			WithArgs("totp:8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(GetSQLFindLoginThrottleForUpdate()).
+			WithArgs("totp:8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f").
+			WillReturnRows(sqlmock.NewRows([]string{"throttle_key", "failures", "last_failure_at"}).AddRow("totp:8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", 2, lastFailureAt))
+
+		tx, err := db.Begin()
+		assert.Nil(t, err)
+
+		throttle, err := database.NewLoginThrottleRepository(db).FindByKeyForUpdate(context.Background(), "totp:8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", now, tx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, throttle.Failures)
+		assert.Equal(t, lastFailureAt, *throttle.LastFailureAt)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByKeyForUpdate when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLCreateEmptyLoginThrottle()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewLoginThrottleRepository(db).FindByKeyForUpdate(context.Background(), "cpf:34688151071", now)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestLoginThrottleRepository_RegisterFailure(t *testing.T) {
+	now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	resetBefore := now.Add(-15 * time.Minute)
+
+	t.Run("Testing RegisterFailure when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLRegisterLoginFailure()).
+			WithArgs("ip:203.0.113.7", now, resetBefore).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectQuery(GetSQLFindLoginThrottle()).
+			WithArgs("ip:203.0.113.7").
+			WillReturnRows(sqlmock.NewRows([]string{"throttle_key", "failures", "last_failure_at"}).AddRow("ip:203.0.113.7", 2, now))
+
+		throttle, err := database.NewLoginThrottleRepository(db).RegisterFailure(context.Background(), "ip:203.0.113.7", now, resetBefore)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, throttle.Failures)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing RegisterFailure when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLRegisterLoginFailure()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewLoginThrottleRepository(db).RegisterFailure(context.Background(), "ip:203.0.113.7", now, resetBefore)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestLoginThrottleRepository_Reset(t *testing.T) {
+	t.Run("Testing Reset when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLResetLoginThrottle()).
+			WithArgs("cpf:34688151071").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewLoginThrottleRepository(db).Reset(context.Background(), "cpf:34688151071")
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Reset when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLResetLoginThrottle()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewLoginThrottleRepository(db).Reset(context.Background(), "cpf:34688151071")
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
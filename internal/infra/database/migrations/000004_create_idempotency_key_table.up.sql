@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    request_key VARCHAR(100) NOT NULL,
+    account_id  VARCHAR(36) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    response    TEXT NULL,
+    created_at  DATETIME NOT NULL,
+    expires_at  DATETIME NOT NULL,
+    PRIMARY KEY (account_id, request_key),
+    INDEX idx_idempotency_key_expires_at (expires_at),
+    CONSTRAINT fk_idempotency_key_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
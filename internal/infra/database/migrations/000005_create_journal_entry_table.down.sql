@@ -0,0 +1 @@
+DROP TABLE IF EXISTS journal_entry;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS journal_entry (
+    id              VARCHAR(36) PRIMARY KEY,
+    entry_type      VARCHAR(30) NOT NULL,
+    reference_id    VARCHAR(36) NOT NULL,
+    created_at      DATETIME NOT NULL,
+    INDEX idx_journal_entry_reference (reference_id)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS posting;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS posting (
+    id                  VARCHAR(36) PRIMARY KEY,
+    journal_entry_id    VARCHAR(36) NOT NULL,
+    account_id          VARCHAR(36) NOT NULL,
+    direction           VARCHAR(6) NOT NULL,
+    amount              INT NOT NULL,
+    created_at          DATETIME NOT NULL,
+    INDEX idx_posting_account (account_id),
+    CONSTRAINT fk_posting_journal_entry FOREIGN KEY (journal_entry_id) REFERENCES journal_entry (id)
+);
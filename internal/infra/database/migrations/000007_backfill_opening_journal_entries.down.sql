@@ -0,0 +1 @@
+DELETE FROM journal_entry WHERE entry_type = 'OPENING_BALANCE' AND id NOT IN (SELECT journal_entry_id FROM posting);
\ No newline at end of file
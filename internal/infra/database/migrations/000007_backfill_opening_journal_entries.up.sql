@@ -0,0 +1,4 @@
+INSERT INTO journal_entry (id, entry_type, reference_id, created_at)
+SELECT UUID(), 'OPENING_BALANCE', a.id, NOW()
+FROM account a
+WHERE a.balance <> 0;
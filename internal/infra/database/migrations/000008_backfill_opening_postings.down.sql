@@ -0,0 +1 @@
+DELETE FROM posting WHERE journal_entry_id IN (SELECT id FROM journal_entry WHERE entry_type = 'OPENING_BALANCE');
\ No newline at end of file
@@ -0,0 +1,10 @@
+INSERT INTO posting (id, journal_entry_id, account_id, direction, amount, created_at)
+SELECT UUID(), j.id, a.id, 'CREDIT', a.balance, j.created_at
+FROM journal_entry j
+INNER JOIN account a ON a.id = j.reference_id
+WHERE j.entry_type = 'OPENING_BALANCE'
+UNION ALL
+SELECT UUID(), j.id, 'external', 'DEBIT', a.balance, j.created_at
+FROM journal_entry j
+INNER JOIN account a ON a.id = j.reference_id
+WHERE j.entry_type = 'OPENING_BALANCE';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS movement;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS movement (
+    id            VARCHAR(36) PRIMARY KEY,
+    account_id    VARCHAR(36) NOT NULL,
+    movement_type VARCHAR(20) NOT NULL,
+    channel       VARCHAR(20) NOT NULL,
+    reference     VARCHAR(100) NOT NULL DEFAULT '',
+    amount        INT NOT NULL,
+    created_at    DATETIME NOT NULL,
+    INDEX idx_movement_account_id_created_at (account_id, created_at),
+    CONSTRAINT fk_movement_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE transfer
+    ADD INDEX idx_transfer_origin_account_id (origin_account_id),
+    ADD INDEX idx_transfer_destination_account_id (destination_account_id),
+    DROP INDEX idx_transfer_origin_created_at,
+    DROP INDEX idx_transfer_destination_created_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE transfer
+    ADD INDEX idx_transfer_origin_created_at (origin_account_id, created_at, id),
+    ADD INDEX idx_transfer_destination_created_at (destination_account_id, created_at, id);
\ No newline at end of file
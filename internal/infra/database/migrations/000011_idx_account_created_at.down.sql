@@ -0,0 +1 @@
+DROP INDEX idx_account_created_at ON account;
\ No newline at end of file
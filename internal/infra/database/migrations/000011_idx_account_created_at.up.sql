@@ -0,0 +1 @@
+CREATE INDEX idx_account_created_at ON account (created_at, id);
\ No newline at end of file
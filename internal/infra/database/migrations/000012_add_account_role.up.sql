@@ -0,0 +1 @@
+ALTER TABLE account ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'CUSTOMER';
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS refresh_token (
+    id          VARCHAR(36) PRIMARY KEY,
+    family_id   VARCHAR(36) NOT NULL,
+    account_id  VARCHAR(36) NOT NULL,
+    token_hash  VARCHAR(64) NOT NULL,
+    replaced_by VARCHAR(36) NOT NULL DEFAULT '',
+    created_at  DATETIME NOT NULL,
+    expires_at  DATETIME NOT NULL,
+    revoked_at  DATETIME NULL,
+    UNIQUE INDEX idx_refresh_token_token_hash (token_hash),
+    INDEX idx_refresh_token_family_id (family_id),
+    CONSTRAINT fk_refresh_token_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS revoked_token (
+    jti        VARCHAR(36) PRIMARY KEY,
+    expires_at DATETIME NOT NULL,
+    INDEX idx_revoked_token_expires_at (expires_at)
+);
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS login_attempt (
+    id         VARCHAR(36) PRIMARY KEY,
+    cpf        VARCHAR(14) NOT NULL,
+    ip         VARCHAR(45) NOT NULL,
+    reason     VARCHAR(20) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_login_attempt_cpf_created_at (cpf, created_at)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS login_throttle;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS login_throttle (
+    throttle_key    VARCHAR(64) PRIMARY KEY,
+    failures        INT NOT NULL,
+    last_failure_at DATETIME NOT NULL,
+    INDEX idx_login_throttle_last_failure_at (last_failure_at)
+);
\ No newline at end of file
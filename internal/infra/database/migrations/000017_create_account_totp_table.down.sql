@@ -0,0 +1 @@
+DROP TABLE IF EXISTS account_totp;
\ No newline at end of file
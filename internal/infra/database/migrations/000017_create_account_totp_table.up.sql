@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS account_totp (
+    account_id     VARCHAR(36) PRIMARY KEY,
+    secret         VARCHAR(255) NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    confirmed_at   DATETIME NULL,
+    created_at     DATETIME NOT NULL,
+    CONSTRAINT fk_account_totp_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS recovery_code;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS recovery_code (
+    id         VARCHAR(36) PRIMARY KEY,
+    account_id VARCHAR(36) NOT NULL,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE INDEX idx_recovery_code_account_hash (account_id, code_hash),
+    CONSTRAINT fk_recovery_code_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
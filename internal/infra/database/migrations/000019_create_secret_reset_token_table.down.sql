@@ -0,0 +1 @@
+DROP TABLE IF EXISTS secret_reset_token;
\ No newline at end of file
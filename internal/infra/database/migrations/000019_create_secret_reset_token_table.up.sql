@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS secret_reset_token (
+    id          VARCHAR(36) PRIMARY KEY,
+    account_id  VARCHAR(36) NOT NULL,
+    token_hash  VARCHAR(64) NOT NULL,
+    created_at  DATETIME NOT NULL,
+    expires_at  DATETIME NOT NULL,
+    used_at     DATETIME NULL,
+    UNIQUE INDEX idx_secret_reset_token_token_hash (token_hash),
+    INDEX idx_secret_reset_token_account_id (account_id),
+    CONSTRAINT fk_secret_reset_token_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE account DROP COLUMN currency, MODIFY COLUMN balance INT NOT NULL;
\ No newline at end of file
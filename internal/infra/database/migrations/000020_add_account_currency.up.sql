@@ -0,0 +1 @@
+ALTER TABLE account MODIFY COLUMN balance BIGINT NOT NULL, ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
\ No newline at end of file
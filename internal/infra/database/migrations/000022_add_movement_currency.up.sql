@@ -0,0 +1 @@
+ALTER TABLE movement MODIFY COLUMN amount BIGINT NOT NULL, ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
\ No newline at end of file
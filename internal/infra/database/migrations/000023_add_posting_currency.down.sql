@@ -0,0 +1 @@
+ALTER TABLE posting DROP COLUMN currency, MODIFY COLUMN amount INT NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transfer DROP COLUMN exchange_rate, DROP COLUMN destination_currency, DROP COLUMN destination_amount;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transfer ADD COLUMN destination_amount BIGINT NULL, ADD COLUMN destination_currency CHAR(3) NULL, ADD COLUMN exchange_rate VARCHAR(32) NOT NULL DEFAULT '1';
\ No newline at end of file
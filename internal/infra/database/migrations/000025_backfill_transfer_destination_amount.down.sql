@@ -0,0 +1 @@
+UPDATE transfer SET destination_amount = NULL, destination_currency = NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE transfer SET destination_amount = amount, destination_currency = currency WHERE destination_amount IS NULL;
\ No newline at end of file
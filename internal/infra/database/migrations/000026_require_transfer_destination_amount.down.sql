@@ -0,0 +1 @@
+ALTER TABLE transfer MODIFY COLUMN destination_amount BIGINT NULL, MODIFY COLUMN destination_currency CHAR(3) NULL;
\ No newline at end of file
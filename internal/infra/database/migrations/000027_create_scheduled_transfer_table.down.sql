@@ -0,0 +1 @@
+DROP TABLE IF EXISTS scheduled_transfer;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scheduled_transfer (
+    id                     VARCHAR(36) PRIMARY KEY,
+    origin_account_id      VARCHAR(36) NOT NULL,
+    destination_account_id VARCHAR(36) NOT NULL,
+    amount                 BIGINT NOT NULL,
+    currency               CHAR(3) NOT NULL,
+    frequency              VARCHAR(10) NOT NULL,
+    start_at               DATETIME NOT NULL,
+    end_at                 DATETIME NULL,
+    max_executions         INT NOT NULL DEFAULT 0,
+    executions             INT NOT NULL DEFAULT 0,
+    next_run_at            DATETIME NULL,
+    status                 VARCHAR(10) NOT NULL,
+    created_at             DATETIME NOT NULL,
+    INDEX idx_scheduled_transfer_status_next_run_at (status, next_run_at),
+    INDEX idx_scheduled_transfer_origin_created_at (origin_account_id, created_at, id),
+    CONSTRAINT fk_scheduled_transfer_origin_account FOREIGN KEY (origin_account_id) REFERENCES account (id),
+    CONSTRAINT fk_scheduled_transfer_destination_account FOREIGN KEY (destination_account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS scheduled_transfer_execution (
+    id                    VARCHAR(36) PRIMARY KEY,
+    scheduled_transfer_id VARCHAR(36) NOT NULL,
+    transfer_id           VARCHAR(36) NOT NULL DEFAULT '',
+    status                VARCHAR(10) NOT NULL,
+    failure_reason        VARCHAR(255) NOT NULL DEFAULT '',
+    executed_at           DATETIME NOT NULL,
+    INDEX idx_scheduled_transfer_execution_executed_at (scheduled_transfer_id, executed_at),
+    CONSTRAINT fk_scheduled_transfer_execution_schedule FOREIGN KEY (scheduled_transfer_id) REFERENCES scheduled_transfer (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transfer DROP FOREIGN KEY fk_transfer_reversal_of, DROP COLUMN reversed_amount, DROP COLUMN reversal_of;
\ No newline at end of file
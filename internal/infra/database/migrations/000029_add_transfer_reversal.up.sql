@@ -0,0 +1 @@
+ALTER TABLE transfer ADD COLUMN reversal_of VARCHAR(36) NULL, ADD COLUMN reversed_amount BIGINT NOT NULL DEFAULT 0, ADD CONSTRAINT fk_transfer_reversal_of FOREIGN KEY (reversal_of) REFERENCES transfer (id);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transfer DROP COLUMN failure_reason, DROP COLUMN status;
\ No newline at end of file
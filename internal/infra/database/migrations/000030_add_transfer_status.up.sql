@@ -0,0 +1 @@
+ALTER TABLE transfer ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'COMPLETED', ADD COLUMN failure_reason VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
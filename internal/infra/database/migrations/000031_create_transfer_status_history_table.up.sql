@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS transfer_status_history (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transfer_id VARCHAR(36) NOT NULL,
+    status      VARCHAR(10) NOT NULL,
+    reason      VARCHAR(255) NOT NULL DEFAULT '',
+    changed_at  DATETIME NOT NULL,
+    INDEX idx_transfer_status_history_transfer_id (transfer_id, id),
+    CONSTRAINT fk_transfer_status_history_transfer FOREIGN KEY (transfer_id) REFERENCES transfer (id)
+);
\ No newline at end of file
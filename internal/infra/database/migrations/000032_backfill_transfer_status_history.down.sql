@@ -0,0 +1 @@
+DELETE FROM transfer_status_history;
\ No newline at end of file
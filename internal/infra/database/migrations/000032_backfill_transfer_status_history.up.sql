@@ -0,0 +1 @@
+INSERT INTO transfer_status_history (transfer_id, status, reason, changed_at) SELECT id, status, failure_reason, created_at FROM transfer;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transfer_limit;
\ No newline at end of file
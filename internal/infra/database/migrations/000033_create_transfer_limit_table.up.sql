@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS transfer_limit (
+    account_id      VARCHAR(36) PRIMARY KEY,
+    per_transaction BIGINT NOT NULL,
+    daily           BIGINT NOT NULL,
+    monthly         BIGINT NOT NULL,
+    nighttime       BIGINT NOT NULL,
+    currency        CHAR(3) NOT NULL,
+    updated_at      DATETIME NOT NULL,
+    CONSTRAINT fk_transfer_limit_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
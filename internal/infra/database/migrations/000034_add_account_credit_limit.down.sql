@@ -0,0 +1 @@
+ALTER TABLE account DROP COLUMN credit_limit;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS overdraft_interest;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS overdraft_interest (
+    id              VARCHAR(36) PRIMARY KEY,
+    account_id      VARCHAR(36) NOT NULL,
+    accrual_date    DATE NOT NULL,
+    balance         BIGINT NOT NULL,
+    rate            VARCHAR(32) NOT NULL,
+    amount          BIGINT NOT NULL,
+    currency        CHAR(3) NOT NULL,
+    created_at      DATETIME NOT NULL,
+    UNIQUE INDEX idx_overdraft_interest_account_accrual (account_id, accrual_date),
+    CONSTRAINT fk_overdraft_interest_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
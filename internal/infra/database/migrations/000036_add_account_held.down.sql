@@ -0,0 +1 @@
+ALTER TABLE account DROP COLUMN held;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE account ADD COLUMN held BIGINT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS hold (
+    id                     VARCHAR(36) PRIMARY KEY,
+    origin_account_id      VARCHAR(36) NOT NULL,
+    destination_account_id VARCHAR(36) NOT NULL,
+    amount                 BIGINT NOT NULL,
+    captured_amount        BIGINT NOT NULL DEFAULT 0,
+    currency               CHAR(3) NOT NULL,
+    transfer_id            VARCHAR(36) NOT NULL DEFAULT '',
+    status                 VARCHAR(10) NOT NULL,
+    expires_at             DATETIME NOT NULL,
+    created_at             DATETIME NOT NULL,
+    updated_at             DATETIME NOT NULL,
+    INDEX idx_hold_status_expires_at (status, expires_at),
+    CONSTRAINT fk_hold_origin_account FOREIGN KEY (origin_account_id) REFERENCES account (id),
+    CONSTRAINT fk_hold_destination_account FOREIGN KEY (destination_account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transfer DROP COLUMN fee;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transfer ADD COLUMN fee BIGINT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transfer_fee;
\ No newline at end of file
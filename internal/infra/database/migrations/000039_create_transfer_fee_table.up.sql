@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS transfer_fee (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transfer_id VARCHAR(36) NOT NULL,
+    rule        VARCHAR(100) NOT NULL,
+    fee_type    VARCHAR(10) NOT NULL,
+    amount      BIGINT NOT NULL,
+    currency    CHAR(3) NOT NULL,
+    waived      BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX idx_transfer_fee_transfer_id (transfer_id, id),
+    CONSTRAINT fk_transfer_fee_transfer FOREIGN KEY (transfer_id) REFERENCES transfer (id)
+);
\ No newline at end of file
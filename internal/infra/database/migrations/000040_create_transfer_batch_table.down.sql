@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transfer_batch;
\ No newline at end of file
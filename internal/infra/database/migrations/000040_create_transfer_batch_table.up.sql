@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS transfer_batch (
+    id                VARCHAR(36) PRIMARY KEY,
+    origin_account_id VARCHAR(36) NOT NULL,
+    mode              VARCHAR(11) NOT NULL,
+    status            VARCHAR(20) NOT NULL,
+    total             BIGINT NOT NULL,
+    currency          CHAR(3) NOT NULL,
+    failure_reason    VARCHAR(255) NOT NULL DEFAULT '',
+    created_at        DATETIME NOT NULL,
+    updated_at        DATETIME NOT NULL,
+    INDEX idx_transfer_batch_status_created_at (status, created_at),
+    CONSTRAINT fk_transfer_batch_origin_account FOREIGN KEY (origin_account_id) REFERENCES account (id)
+);
\ No newline at end of file
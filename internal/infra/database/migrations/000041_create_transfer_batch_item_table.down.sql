@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transfer_batch_item;
\ No newline at end of file
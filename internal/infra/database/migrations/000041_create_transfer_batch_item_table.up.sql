@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS transfer_batch_item (
+    batch_id               VARCHAR(36) NOT NULL,
+    position               INT NOT NULL,
+    destination_account_id VARCHAR(36) NOT NULL,
+    amount                 BIGINT NOT NULL,
+    currency               CHAR(3) NOT NULL,
+    status                 VARCHAR(10) NOT NULL,
+    transfer_id            VARCHAR(36) NOT NULL DEFAULT '',
+    failure_reason         VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (batch_id, position),
+    CONSTRAINT fk_transfer_batch_item_batch FOREIGN KEY (batch_id) REFERENCES transfer_batch (id),
+    CONSTRAINT fk_transfer_batch_item_destination_account FOREIGN KEY (destination_account_id) REFERENCES account (id)
+);
\ No newline at end of file
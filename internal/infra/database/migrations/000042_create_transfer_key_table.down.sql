@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transfer_key;
\ No newline at end of file
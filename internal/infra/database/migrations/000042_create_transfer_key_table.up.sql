@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS transfer_key (
+    key_value  VARCHAR(77) PRIMARY KEY,
+    type       VARCHAR(5) NOT NULL,
+    account_id VARCHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_transfer_key_account_id (account_id, created_at),
+    CONSTRAINT fk_transfer_key_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS beneficiary;
\ No newline at end of file
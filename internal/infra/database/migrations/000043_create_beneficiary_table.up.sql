@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS beneficiary (
+    id                     VARCHAR(36) PRIMARY KEY,
+    account_id             VARCHAR(36) NOT NULL,
+    destination_account_id VARCHAR(36) NOT NULL,
+    nickname               VARCHAR(50) NOT NULL,
+    favorite               BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at             DATETIME NOT NULL,
+    updated_at             DATETIME NOT NULL,
+    UNIQUE INDEX idx_beneficiary_account_destination (account_id, destination_account_id),
+    CONSTRAINT fk_beneficiary_account FOREIGN KEY (account_id) REFERENCES account (id),
+    CONSTRAINT fk_beneficiary_destination_account FOREIGN KEY (destination_account_id) REFERENCES account (id)
+);
\ No newline at end of file
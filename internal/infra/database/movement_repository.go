@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type MovementRepository struct {
+	Db *sql.DB
+}
+
+func NewMovementRepository(db *sql.DB) *MovementRepository {
+	return &MovementRepository{
+		Db: db,
+	}
+}
+
+func (r *MovementRepository) Create(ctx context.Context, movement *entity.Movement, tx ...entity.TransactionHandler) (entity.Movement, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO movement (id, account_id, movement_type, channel, reference, amount, currency, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := executor.ExecContext(
+		ctx, query, movement.ID, movement.Account.ID, movement.Type, movement.Channel, movement.Reference, movement.Amount.Amount, movement.Amount.Currency, movement.CreatedAt,
+	)
+	if err != nil {
+		return entity.Movement{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.Movement{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.Movement{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("unexpected number of affected rows")
+	}
+
+	return *movement, nil
+}
@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertMovement() string {
+	return regexp.QuoteMeta("INSERT INTO movement (id, account_id, movement_type, channel, reference, amount, currency, created_at)")
+}
+
+func GetDepositMovement(t *testing.T) *entity.Movement {
+	createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+	account := &entity.Account{ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", Name: "lucas", Balance: entity.NewMoney(100, entity.BRL), CreatedAt: &createdAt}
+
+	movement, err := entity.NewMovement("fc84682a-3045-4bdf-b91c-10be19f89452", account, entity.DEPOSIT, entity.ATM_CHANNEL, "envelope 123", entity.NewMoney(50, entity.BRL), &createdAt)
+	assert.Nil(t, err)
+	return movement
+}
+
+func TestMovementRepository_Create(t *testing.T) {
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		movement := GetDepositMovement(t)
+
+		mock.ExpectExec(GetSQLInsertMovement()).
+			WithArgs(movement.ID, movement.Account.ID, entity.DEPOSIT, entity.ATM_CHANNEL, "envelope 123", int64(50), entity.BRL, movement.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		movementRepository := database.NewMovementRepository(db)
+		createdMovement, err := movementRepository.Create(context.Background(), movement, db)
+
+		assert.Nil(t, err)
+		assert.Equal(t, movement.ID, createdMovement.ID)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertMovement()).WillReturnError(errors.New("connection closed"))
+
+		movementRepository := database.NewMovementRepository(db)
+		createdMovement, err := movementRepository.Create(context.Background(), GetDepositMovement(t))
+
+		assert.Equal(t, "connection closed", err.Error())
+		assert.Empty(t, createdMovement.ID)
+	})
+
+	t.Run("Testing Create when no row is affected", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertMovement()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		movementRepository := database.NewMovementRepository(db)
+		_, err = movementRepository.Create(context.Background(), GetDepositMovement(t))
+
+		assert.Equal(t, "unexpected number of affected rows", err.Error())
+	})
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+)
+
+type OverdraftInterestRepository struct {
+	Db *sql.DB
+}
+
+func NewOverdraftInterestRepository(db *sql.DB) *OverdraftInterestRepository {
+	return &OverdraftInterestRepository{
+		Db: db,
+	}
+}
+
+func (r *OverdraftInterestRepository) FindOverdrawnAccountIDs(ctx context.Context, accrualDate string, after string, limit int) ([]string, error) {
+	query := `
+		SELECT a.id FROM account a
+		WHERE a.balance < 0 AND a.id > ?
+			AND NOT EXISTS (SELECT 1 FROM overdraft_interest o WHERE o.account_id = a.id AND o.accrual_date = ?)
+		ORDER BY a.id LIMIT ?
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, after, accrualDate, limit)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	accountIDs := []string{}
+	for rows.Next() {
+		var accountID string
+
+		err := rows.Scan(&accountID)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return accountIDs, nil
+}
+
+func (r *OverdraftInterestRepository) Create(ctx context.Context, interest *entity.OverdraftInterest, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO overdraft_interest (id, account_id, accrual_date, balance, rate, amount, currency, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := executor.ExecContext(ctx, query, interest.ID, interest.Account.ID, interest.AccrualDate, interest.Balance.Amount,
+		interest.Rate, interest.Amount.Amount, interest.Amount.Currency, interest.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "1062") {
+			return entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(fmt.Sprintf("interest already accrued on %s: %s", interest.AccrualDate, interest.Account.ID))
+		}
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLFindOverdrawnAccountIDs() string {
+	return regexp.QuoteMeta("SELECT a.id FROM account a")
+}
+
+func GetSQLInsertOverdraftInterest() string {
+	return regexp.QuoteMeta("INSERT INTO overdraft_interest (id, account_id, accrual_date, balance, rate, amount, currency, created_at)")
+}
+
+func TestOverdraftInterestRepository_FindOverdrawnAccountIDs(t *testing.T) {
+	t.Run("Testing FindOverdrawnAccountIDs when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindOverdrawnAccountIDs()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", "2023-08-14", 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow("8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f").
+				AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6"))
+
+		accountIDs, err := database.NewOverdraftInterestRepository(db).FindOverdrawnAccountIDs(context.Background(), "2023-08-14", "2bd765a6-47bd-4731-9eb2-1e65542f4477", 100)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", "d18551d3-cf13-49ec-b1dc-741a1f8715f6"}, accountIDs)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindOverdrawnAccountIDs when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindOverdrawnAccountIDs()).WillReturnError(errors.New("connection closed"))
+
+		accountIDs, err := database.NewOverdraftInterestRepository(db).FindOverdrawnAccountIDs(context.Background(), "2023-08-14", "", 100)
+
+		assert.Nil(t, accountIDs)
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestOverdraftInterestRepository_Create(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 3, 00, 00, 00, time.UTC)
+
+	interest := &entity.OverdraftInterest{
+		ID:          "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		Account:     &entity.Account{ID: "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"},
+		AccrualDate: "2023-08-14",
+		Balance:     entity.NewMoney(-100000, entity.BRL),
+		Rate:        "0.0026",
+		Amount:      entity.NewMoney(260, entity.BRL),
+		CreatedAt:   &createdAt,
+	}
+
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertOverdraftInterest()).
+			WithArgs(interest.ID, interest.Account.ID, "2023-08-14", int64(-100000), entity.OverdraftInterestRate("0.0026"), int64(260), entity.BRL, &createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewOverdraftInterestRepository(db).Create(context.Background(), interest)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when the account was already charged on the day", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertOverdraftInterest()).WillReturnError(errors.New("Error 1062: Duplicate entry"))
+
+		err := database.NewOverdraftInterestRepository(db).Create(context.Background(), interest)
+
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "interest already accrued on 2023-08-14: 8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", err.Error())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertOverdraftInterest()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewOverdraftInterestRepository(db).Create(context.Background(), interest)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type RecoveryCodeRepository struct {
+	Db *sql.DB
+}
+
+func NewRecoveryCodeRepository(db *sql.DB) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		Db: db,
+	}
+}
+
+// Replace discards the previous recovery codes of the account and stores the new ones.
+func (r *RecoveryCodeRepository) Replace(ctx context.Context, accountID string, recoveryCodes []*entity.RecoveryCode, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	_, err := executor.ExecContext(ctx, "DELETE FROM recovery_code WHERE account_id = ?", accountID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	query := "INSERT INTO recovery_code (id, account_id, code_hash, created_at) VALUES (?, ?, ?, ?)"
+
+	for _, recoveryCode := range recoveryCodes {
+		_, err := executor.ExecContext(ctx, query, recoveryCode.ID, recoveryCode.AccountID, recoveryCode.CodeHash, recoveryCode.CreatedAt)
+		if err != nil {
+			return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Use spends the recovery code, it returns false when the code does not exist or was used.
+func (r *RecoveryCodeRepository) Use(ctx context.Context, accountID string, codeHash string, usedAt time.Time) (bool, error) {
+	query := "UPDATE recovery_code SET used_at = ? WHERE account_id = ? AND code_hash = ? AND used_at IS NULL"
+
+	result, err := r.Db.ExecContext(ctx, query, usedAt, accountID, codeHash)
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return affectedRows == 1, nil
+}
@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLDeleteRecoveryCodes() string {
+	return regexp.QuoteMeta("DELETE FROM recovery_code WHERE account_id = ?")
+}
+
+func GetSQLCreateRecoveryCode() string {
+	return regexp.QuoteMeta("INSERT INTO recovery_code (id, account_id, code_hash, created_at) VALUES (?, ?, ?, ?)")
+}
+
+func GetSQLUseRecoveryCode() string {
+	return regexp.QuoteMeta("UPDATE recovery_code SET used_at = ? WHERE account_id = ? AND code_hash = ? AND used_at IS NULL")
+}
+
+func TestRecoveryCodeRepository_Replace(t *testing.T) {
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	recoveryCodes, _, _ := entity.NewRecoveryCodes(accountID, &createdAt)
+
+	t.Run("Testing Replace when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLDeleteRecoveryCodes()).
+			WithArgs(accountID).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		for _, recoveryCode := range recoveryCodes {
+			mock.ExpectExec(GetSQLCreateRecoveryCode()).
+				WithArgs(recoveryCode.ID, accountID, recoveryCode.CodeHash, &createdAt).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+
+		err := database.NewRecoveryCodeRepository(db).Replace(context.Background(), accountID, recoveryCodes)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Replace when delete returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLDeleteRecoveryCodes()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRecoveryCodeRepository(db).Replace(context.Background(), accountID, recoveryCodes)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+
+	t.Run("Testing Replace when insert returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLDeleteRecoveryCodes()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(GetSQLCreateRecoveryCode()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRecoveryCodeRepository(db).Replace(context.Background(), accountID, recoveryCodes)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestRecoveryCodeRepository_Use(t *testing.T) {
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+	usedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	codeHash := entity.HashRecoveryCode(accountID, "abcde-fghjk")
+
+	t.Run("Testing Use when the code was not used", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUseRecoveryCode()).
+			WithArgs(usedAt, accountID, codeHash).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		used, err := database.NewRecoveryCodeRepository(db).Use(context.Background(), accountID, codeHash, usedAt)
+
+		assert.Nil(t, err)
+		assert.True(t, used)
+	})
+
+	t.Run("Testing Use when the code does not exist or was used", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUseRecoveryCode()).
+			WithArgs(usedAt, accountID, codeHash).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		used, err := database.NewRecoveryCodeRepository(db).Use(context.Background(), accountID, codeHash, usedAt)
+
+		assert.Nil(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("Testing Use when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUseRecoveryCode()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewRecoveryCodeRepository(db).Use(context.Background(), accountID, codeHash, usedAt)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type RefreshTokenRepository struct {
+	Db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		Db: db,
+	}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, refreshToken *entity.RefreshToken, tx ...entity.TransactionHandler) (entity.RefreshToken, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO refresh_token (id, family_id, account_id, token_hash, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := executor.ExecContext(
+		ctx, query, refreshToken.ID, refreshToken.FamilyID, refreshToken.AccountID, refreshToken.TokenHash, refreshToken.CreatedAt, refreshToken.ExpiresAt,
+	)
+	if err != nil {
+		return entity.RefreshToken{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return *refreshToken, nil
+}
+
+// FindByHashForUpdate locks the token so two refreshes racing with the same token are
+// serialized and the second one is seen as a reuse.
+func (r *RefreshTokenRepository) FindByHashForUpdate(ctx context.Context, tokenHash string, tx ...entity.TransactionHandler) (entity.RefreshToken, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		SELECT id, family_id, account_id, token_hash, replaced_by, created_at, expires_at, revoked_at
+		FROM refresh_token
+		WHERE token_hash = ?
+		FOR UPDATE
+	`
+
+	row := executor.QueryRowContext(ctx, query, tokenHash)
+
+	var refreshToken entity.RefreshToken
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&refreshToken.ID, &refreshToken.FamilyID, &refreshToken.AccountID, &refreshToken.TokenHash, &refreshToken.ReplacedBy,
+		&refreshToken.CreatedAt, &refreshToken.ExpiresAt, &revokedAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.RefreshToken{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found refresh token")
+		}
+
+		return entity.RefreshToken{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if revokedAt.Valid {
+		refreshToken.RevokedAt = &revokedAt.Time
+	}
+
+	return refreshToken, nil
+}
+
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, ID string, replacedBy string, revokedAt time.Time, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE refresh_token SET revoked_at = ?, replaced_by = ? WHERE id = ? AND revoked_at IS NULL"
+
+	_, err := executor.ExecContext(ctx, query, revokedAt, replacedBy, ID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE refresh_token SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL"
+
+	_, err := executor.ExecContext(ctx, query, revokedAt, familyID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+// RevokeByAccountID ends every session of the account, like after its secret changes.
+func (r *RefreshTokenRepository) RevokeByAccountID(ctx context.Context, accountID string, revokedAt time.Time, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE refresh_token SET revoked_at = ? WHERE account_id = ? AND revoked_at IS NULL"
+
+	_, err := executor.ExecContext(ctx, query, revokedAt, accountID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertRefreshToken() string {
+	return regexp.QuoteMeta("INSERT INTO refresh_token (id, family_id, account_id, token_hash, created_at, expires_at)")
+}
+
+func GetSQLFindRefreshTokenByHash() string {
+	return regexp.QuoteMeta("FROM refresh_token") + `\s+` + regexp.QuoteMeta("WHERE token_hash = ?") + `\s+FOR UPDATE`
+}
+
+func GetSQLRevokeRefreshToken() string {
+	return regexp.QuoteMeta("UPDATE refresh_token SET revoked_at = ?, replaced_by = ? WHERE id = ? AND revoked_at IS NULL")
+}
+
+func GetSQLRevokeRefreshTokenFamily() string {
+	return regexp.QuoteMeta("UPDATE refresh_token SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL")
+}
+
+func GetSQLRevokeRefreshTokenByAccountID() string {
+	return regexp.QuoteMeta("UPDATE refresh_token SET revoked_at = ? WHERE account_id = ? AND revoked_at IS NULL")
+}
+
+func GetBaseRefreshToken(t *testing.T) *entity.RefreshToken {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	refreshToken, _, err := entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", &createdAt, time.Hour)
+
+	assert.Nil(t, err)
+	return refreshToken
+}
+
+func TestRefreshTokenRepository_Create(t *testing.T) {
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		refreshToken := GetBaseRefreshToken(t)
+
+		mock.ExpectExec(GetSQLInsertRefreshToken()).
+			WithArgs(refreshToken.ID, refreshToken.FamilyID, refreshToken.AccountID, refreshToken.TokenHash, refreshToken.CreatedAt, refreshToken.ExpiresAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		created, err := database.NewRefreshTokenRepository(db).Create(context.Background(), refreshToken, db)
+
+		assert.Nil(t, err)
+		assert.Equal(t, refreshToken.ID, created.ID)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertRefreshToken()).WillReturnError(errors.New("connection closed"))
+
+		created, err := database.NewRefreshTokenRepository(db).Create(context.Background(), GetBaseRefreshToken(t))
+
+		assert.Equal(t, "connection closed", err.Error())
+		assert.Empty(t, created.ID)
+	})
+}
+
+func TestRefreshTokenRepository_FindByHashForUpdate(t *testing.T) {
+	t.Run("Testing FindByHashForUpdate when returns a revoked token", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		refreshToken := GetBaseRefreshToken(t)
+		revokedAt := refreshToken.CreatedAt.Add(time.Minute)
+
+		rows := sqlmock.NewRows([]string{"id", "family_id", "account_id", "token_hash", "replaced_by", "created_at", "expires_at", "revoked_at"}).
+			AddRow(refreshToken.ID, refreshToken.FamilyID, refreshToken.AccountID, refreshToken.TokenHash, "fc84682a-3045-4bdf-b91c-10be19f89452", *refreshToken.CreatedAt, *refreshToken.ExpiresAt, revokedAt)
+
+		mock.ExpectQuery(GetSQLFindRefreshTokenByHash()).WithArgs(refreshToken.TokenHash).WillReturnRows(rows)
+
+		stored, err := database.NewRefreshTokenRepository(db).FindByHashForUpdate(context.Background(), refreshToken.TokenHash, db)
+
+		assert.Nil(t, err)
+		assert.Equal(t, refreshToken.ID, stored.ID)
+		assert.Equal(t, refreshToken.FamilyID, stored.FamilyID)
+		assert.Equal(t, "fc84682a-3045-4bdf-b91c-10be19f89452", stored.ReplacedBy)
+		assert.True(t, stored.IsRevoked())
+		assert.Equal(t, revokedAt, *stored.RevokedAt)
+	})
+
+	t.Run("Testing FindByHashForUpdate when returns an active token", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		refreshToken := GetBaseRefreshToken(t)
+
+		rows := sqlmock.NewRows([]string{"id", "family_id", "account_id", "token_hash", "replaced_by", "created_at", "expires_at", "revoked_at"}).
+			AddRow(refreshToken.ID, refreshToken.FamilyID, refreshToken.AccountID, refreshToken.TokenHash, "", *refreshToken.CreatedAt, *refreshToken.ExpiresAt, nil)
+
+		mock.ExpectQuery(GetSQLFindRefreshTokenByHash()).WithArgs(refreshToken.TokenHash).WillReturnRows(rows)
+
+		stored, err := database.NewRefreshTokenRepository(db).FindByHashForUpdate(context.Background(), refreshToken.TokenHash)
+
+		assert.Nil(t, err)
+		assert.False(t, stored.IsRevoked())
+	})
+
+	t.Run("Testing FindByHashForUpdate when token does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "family_id", "account_id", "token_hash", "replaced_by", "created_at", "expires_at", "revoked_at"})
+		mock.ExpectQuery(GetSQLFindRefreshTokenByHash()).WithArgs("hash").WillReturnRows(rows)
+
+		_, err := database.NewRefreshTokenRepository(db).FindByHashForUpdate(context.Background(), "hash")
+
+		assert.Equal(t, "not found refresh token", err.Error())
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing FindByHashForUpdate when query returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindRefreshTokenByHash()).WithArgs("hash").WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewRefreshTokenRepository(db).FindByHashForUpdate(context.Background(), "hash")
+
+		assert.Equal(t, "connection closed", err.Error())
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestRefreshTokenRepository_Revoke(t *testing.T) {
+	t.Run("Testing Revoke when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLRevokeRefreshToken()).
+			WithArgs(now, "fc84682a-3045-4bdf-b91c-10be19f89452", "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewRefreshTokenRepository(db).Revoke(context.Background(), "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", "fc84682a-3045-4bdf-b91c-10be19f89452", now, db)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Revoke when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLRevokeRefreshToken()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRefreshTokenRepository(db).Revoke(context.Background(), "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", "", time.Now())
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	t.Run("Testing RevokeFamily when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLRevokeRefreshTokenFamily()).
+			WithArgs(now, "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := database.NewRefreshTokenRepository(db).RevokeFamily(context.Background(), "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", now)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing RevokeFamily when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLRevokeRefreshTokenFamily()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRefreshTokenRepository(db).RevokeFamily(context.Background(), "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", time.Now())
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestRefreshTokenRepository_RevokeByAccountID(t *testing.T) {
+	t.Run("Testing RevokeByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLRevokeRefreshTokenByAccountID()).
+			WithArgs(now, "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := database.NewRefreshTokenRepository(db).RevokeByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", now, db)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing RevokeByAccountID when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLRevokeRefreshTokenByAccountID()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRefreshTokenRepository(db).RevokeByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", time.Now())
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type RevokedTokenRepository struct {
+	Db *sql.DB
+}
+
+func NewRevokedTokenRepository(db *sql.DB) *RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		Db: db,
+	}
+}
+
+func (r *RevokedTokenRepository) Create(ctx context.Context, revokedToken *entity.RevokedToken) error {
+	query := "INSERT INTO revoked_token (jti, expires_at) VALUES (?, ?)"
+
+	_, err := r.Db.ExecContext(ctx, query, revokedToken.JTI, revokedToken.ExpiresAt)
+	if err != nil {
+		// the token was already revoked
+		if strings.Contains(err.Error(), "1062") {
+			return nil
+		}
+
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *RevokedTokenRepository) Exists(ctx context.Context, JTI string) (bool, error) {
+	query := "SELECT COUNT(1) FROM revoked_token WHERE jti = ?"
+
+	var count int
+	err := r.Db.QueryRowContext(ctx, query, JTI).Scan(&count)
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return count > 0, nil
+}
+
+func (r *RevokedTokenRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := "DELETE FROM revoked_token WHERE expires_at <= ?"
+
+	_, err := r.Db.ExecContext(ctx, query, now)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
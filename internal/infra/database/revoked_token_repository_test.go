@@ -0,0 +1,137 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertRevokedToken() string {
+	return regexp.QuoteMeta("INSERT INTO revoked_token (jti, expires_at) VALUES (?, ?)")
+}
+
+func GetSQLRevokedTokenExists() string {
+	return regexp.QuoteMeta("SELECT COUNT(1) FROM revoked_token WHERE jti = ?")
+}
+
+func GetSQLDeleteExpiredRevokedTokens() string {
+	return regexp.QuoteMeta("DELETE FROM revoked_token WHERE expires_at <= ?")
+}
+
+func TestRevokedTokenRepository_Create(t *testing.T) {
+	expiresAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	revokedToken, err := entity.NewRevokedToken("fc84682a-3045-4bdf-b91c-10be19f89452", &expiresAt)
+	assert.Nil(t, err)
+
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertRevokedToken()).
+			WithArgs(revokedToken.JTI, revokedToken.ExpiresAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewRevokedTokenRepository(db).Create(context.Background(), revokedToken)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when token was already revoked", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertRevokedToken()).WillReturnError(errors.New("Error 1062: Duplicate entry"))
+
+		err := database.NewRevokedTokenRepository(db).Create(context.Background(), revokedToken)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertRevokedToken()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRevokedTokenRepository(db).Create(context.Background(), revokedToken)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestRevokedTokenRepository_Exists(t *testing.T) {
+	t.Run("Testing Exists when token is revoked", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLRevokedTokenExists()).
+			WithArgs("fc84682a-3045-4bdf-b91c-10be19f89452").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		exists, err := database.NewRevokedTokenRepository(db).Exists(context.Background(), "fc84682a-3045-4bdf-b91c-10be19f89452")
+
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Testing Exists when token is not revoked", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLRevokedTokenExists()).
+			WithArgs("fc84682a-3045-4bdf-b91c-10be19f89452").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		exists, err := database.NewRevokedTokenRepository(db).Exists(context.Background(), "fc84682a-3045-4bdf-b91c-10be19f89452")
+
+		assert.Nil(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Testing Exists when query returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLRevokedTokenExists()).WillReturnError(errors.New("connection closed"))
+
+		exists, err := database.NewRevokedTokenRepository(db).Exists(context.Background(), "fc84682a-3045-4bdf-b91c-10be19f89452")
+
+		assert.False(t, exists)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestRevokedTokenRepository_DeleteExpired(t *testing.T) {
+	t.Run("Testing DeleteExpired when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLDeleteExpiredRevokedTokens()).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := database.NewRevokedTokenRepository(db).DeleteExpired(context.Background(), now)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing DeleteExpired when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLDeleteExpiredRevokedTokens()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewRevokedTokenRepository(db).DeleteExpired(context.Background(), time.Now())
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type ScheduledTransferRepository struct {
+	Db *sql.DB
+}
+
+func NewScheduledTransferRepository(db *sql.DB) *ScheduledTransferRepository {
+	return &ScheduledTransferRepository{
+		Db: db,
+	}
+}
+
+const scheduledTransferColumns = `id, origin_account_id, destination_account_id, amount, currency, frequency,
+	start_at, end_at, max_executions, executions, next_run_at, status, created_at`
+
+func (r *ScheduledTransferRepository) Create(ctx context.Context, scheduledTransfer *entity.ScheduledTransfer) (entity.ScheduledTransfer, error) {
+	query := `
+		INSERT INTO scheduled_transfer (` + scheduledTransferColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Db.ExecContext(
+		ctx, query, scheduledTransfer.ID, scheduledTransfer.OriginAccountID, scheduledTransfer.DestinationAccountID,
+		scheduledTransfer.Amount.Amount, scheduledTransfer.Amount.Currency, scheduledTransfer.Frequency,
+		scheduledTransfer.StartAt, scheduledTransfer.EndAt, scheduledTransfer.MaxExecutions, scheduledTransfer.Executions,
+		scheduledTransfer.NextRunAt, scheduledTransfer.Status, scheduledTransfer.CreatedAt,
+	)
+	if err != nil {
+		return entity.ScheduledTransfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return *scheduledTransfer, nil
+}
+
+func (r *ScheduledTransferRepository) FindByID(ctx context.Context, ID string) (entity.ScheduledTransfer, error) {
+	query := "SELECT " + scheduledTransferColumns + " FROM scheduled_transfer WHERE id = ?"
+
+	scheduledTransfer, err := scanScheduledTransfer(r.Db.QueryRowContext(ctx, query, ID))
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.ScheduledTransfer{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found scheduled transfer: %s", ID))
+		}
+
+		return entity.ScheduledTransfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return scheduledTransfer, nil
+}
+
+// FindByAccountID lists the schedules created by the account, newest first, starting after
+// the cursor when there is one.
+func (r *ScheduledTransferRepository) FindByAccountID(ctx context.Context, accountID string, page entity.Pagination) ([]entity.ScheduledTransfer, error) {
+	query := "SELECT " + scheduledTransferColumns + " FROM scheduled_transfer WHERE origin_account_id = ?"
+	args := []interface{}{accountID}
+
+	if page.After != nil {
+		query += " AND (created_at < ? OR (created_at = ? AND id < ?))"
+		args = append(args, page.After.CreatedAt, page.After.CreatedAt, page.After.ID)
+	}
+
+	query += " ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+	args = append(args, page.Limit, page.Offset)
+
+	return r.query(ctx, query, args...)
+}
+
+// FindDue returns the active schedules whose next run is at or before now, oldest first.
+func (r *ScheduledTransferRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]entity.ScheduledTransfer, error) {
+	query := "SELECT " + scheduledTransferColumns + " FROM scheduled_transfer WHERE status = ? AND next_run_at <= ? ORDER BY next_run_at, id LIMIT ?"
+
+	return r.query(ctx, query, entity.ACTIVE_SCHEDULE, now, limit)
+}
+
+func (r *ScheduledTransferRepository) Update(ctx context.Context, scheduledTransfer *entity.ScheduledTransfer, expectedStatus entity.ScheduleStatus, expectedNextRunAt *time.Time) (bool, error) {
+	// <=> also matches when both sides are NULL
+	query := "UPDATE scheduled_transfer SET executions = ?, next_run_at = ?, status = ? WHERE id = ? AND status = ? AND next_run_at <=> ?"
+
+	result, err := r.Db.ExecContext(ctx, query, scheduledTransfer.Executions, scheduledTransfer.NextRunAt, scheduledTransfer.Status, scheduledTransfer.ID, expectedStatus, expectedNextRunAt)
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return affectedRows == 1, nil
+}
+
+func (r *ScheduledTransferRepository) CreateExecution(ctx context.Context, execution *entity.ScheduledTransferExecution) error {
+	query := "INSERT INTO scheduled_transfer_execution (id, scheduled_transfer_id, transfer_id, status, failure_reason, executed_at) VALUES (?, ?, ?, ?, ?, ?)"
+
+	_, err := r.Db.ExecContext(ctx, query, execution.ID, execution.ScheduledTransferID, execution.TransferID, execution.Status, execution.FailureReason, execution.ExecutedAt)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+// FindExecutions returns the executions of the schedule, newest first.
+func (r *ScheduledTransferRepository) FindExecutions(ctx context.Context, scheduledTransferID string) ([]entity.ScheduledTransferExecution, error) {
+	query := "SELECT id, scheduled_transfer_id, transfer_id, status, failure_reason, executed_at FROM scheduled_transfer_execution WHERE scheduled_transfer_id = ? ORDER BY executed_at DESC, id DESC"
+
+	rows, err := r.Db.QueryContext(ctx, query, scheduledTransferID)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	executions := []entity.ScheduledTransferExecution{}
+	for rows.Next() {
+		var execution entity.ScheduledTransferExecution
+
+		err := rows.Scan(&execution.ID, &execution.ScheduledTransferID, &execution.TransferID, &execution.Status, &execution.FailureReason, &execution.ExecutedAt)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		executions = append(executions, execution)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return executions, nil
+}
+
+func (r *ScheduledTransferRepository) query(ctx context.Context, query string, args ...interface{}) ([]entity.ScheduledTransfer, error) {
+	rows, err := r.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	scheduledTransfers := []entity.ScheduledTransfer{}
+	for rows.Next() {
+		scheduledTransfer, err := scanScheduledTransfer(rows)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		scheduledTransfers = append(scheduledTransfers, scheduledTransfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return scheduledTransfers, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScheduledTransfer(row scanner) (entity.ScheduledTransfer, error) {
+	var scheduledTransfer entity.ScheduledTransfer
+
+	err := row.Scan(
+		&scheduledTransfer.ID, &scheduledTransfer.OriginAccountID, &scheduledTransfer.DestinationAccountID,
+		&scheduledTransfer.Amount.Amount, &scheduledTransfer.Amount.Currency, &scheduledTransfer.Frequency,
+		&scheduledTransfer.StartAt, &scheduledTransfer.EndAt, &scheduledTransfer.MaxExecutions, &scheduledTransfer.Executions,
+		&scheduledTransfer.NextRunAt, &scheduledTransfer.Status, &scheduledTransfer.CreatedAt,
+	)
+
+	return scheduledTransfer, err
+}
@@ -0,0 +1,344 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertScheduledTransfer() string {
+	return regexp.QuoteMeta("INSERT INTO scheduled_transfer (id, origin_account_id, destination_account_id, amount, currency, frequency, start_at, end_at, max_executions, executions, next_run_at, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+}
+
+func GetSQLFindScheduledTransferByID() string {
+	return regexp.QuoteMeta("FROM scheduled_transfer WHERE id = ?")
+}
+
+func GetSQLFindScheduledTransfersByAccountID() string {
+	return regexp.QuoteMeta("FROM scheduled_transfer WHERE origin_account_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?")
+}
+
+func GetSQLFindDueScheduledTransfers() string {
+	return regexp.QuoteMeta("FROM scheduled_transfer WHERE status = ? AND next_run_at <= ? ORDER BY next_run_at, id LIMIT ?")
+}
+
+func GetSQLUpdateScheduledTransfer() string {
+	return regexp.QuoteMeta("UPDATE scheduled_transfer SET executions = ?, next_run_at = ?, status = ? WHERE id = ? AND status = ? AND next_run_at <=> ?")
+}
+
+func GetSQLInsertScheduledTransferExecution() string {
+	return regexp.QuoteMeta("INSERT INTO scheduled_transfer_execution (id, scheduled_transfer_id, transfer_id, status, failure_reason, executed_at) VALUES (?, ?, ?, ?, ?, ?)")
+}
+
+func GetSQLFindScheduledTransferExecutions() string {
+	return regexp.QuoteMeta("FROM scheduled_transfer_execution WHERE scheduled_transfer_id = ? ORDER BY executed_at DESC, id DESC")
+}
+
+func GetScheduledTransferColumns() []string {
+	return []string{
+		"id", "origin_account_id", "destination_account_id", "amount", "currency", "frequency",
+		"start_at", "end_at", "max_executions", "executions", "next_run_at", "status", "created_at",
+	}
+}
+
+func GetBaseScheduledTransfer(t *testing.T) *entity.ScheduledTransfer {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+
+	scheduledTransfer, err := entity.NewScheduledTransfer("c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(1000, entity.BRL), entity.MONTHLY, &startAt, nil, 12, &createdAt)
+
+	assert.Nil(t, err)
+	return scheduledTransfer
+}
+
+func TestScheduledTransferRepository_Create(t *testing.T) {
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+
+		mock.ExpectExec(GetSQLInsertScheduledTransfer()).
+			WithArgs(
+				scheduledTransfer.ID, scheduledTransfer.OriginAccountID, scheduledTransfer.DestinationAccountID,
+				int64(1000), entity.BRL, entity.MONTHLY, scheduledTransfer.StartAt, scheduledTransfer.EndAt, 12, 0,
+				scheduledTransfer.NextRunAt, entity.ACTIVE_SCHEDULE, scheduledTransfer.CreatedAt,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		created, err := database.NewScheduledTransferRepository(db).Create(context.Background(), scheduledTransfer)
+
+		assert.Nil(t, err)
+		assert.Equal(t, *scheduledTransfer, created)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertScheduledTransfer()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewScheduledTransferRepository(db).Create(context.Background(), GetBaseScheduledTransfer(t))
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestScheduledTransferRepository_FindByID(t *testing.T) {
+	t.Run("Testing FindByID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+		scheduledTransfer.Status = entity.PAUSED_SCHEDULE
+		scheduledTransfer.Executions = 2
+
+		rows := sqlmock.NewRows(GetScheduledTransferColumns()).AddRow(
+			scheduledTransfer.ID, scheduledTransfer.OriginAccountID, scheduledTransfer.DestinationAccountID,
+			1000, "BRL", "MONTHLY", *scheduledTransfer.StartAt, nil, 12, 2, *scheduledTransfer.NextRunAt, "PAUSED", *scheduledTransfer.CreatedAt,
+		)
+
+		mock.ExpectQuery(GetSQLFindScheduledTransferByID()).WithArgs(scheduledTransfer.ID).WillReturnRows(rows)
+
+		stored, err := database.NewScheduledTransferRepository(db).FindByID(context.Background(), scheduledTransfer.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, *scheduledTransfer, stored)
+	})
+
+	t.Run("Testing FindByID when not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindScheduledTransferByID()).WillReturnError(sql.ErrNoRows)
+
+		_, err := database.NewScheduledTransferRepository(db).FindByID(context.Background(), "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found scheduled transfer: c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", err.Error())
+	})
+
+	t.Run("Testing FindByID when QueryRowContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindScheduledTransferByID()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewScheduledTransferRepository(db).FindByID(context.Background(), "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26")
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestScheduledTransferRepository_FindByAccountID(t *testing.T) {
+	t.Run("Testing FindByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+
+		rows := sqlmock.NewRows(GetScheduledTransferColumns()).AddRow(
+			scheduledTransfer.ID, scheduledTransfer.OriginAccountID, scheduledTransfer.DestinationAccountID,
+			1000, "BRL", "MONTHLY", *scheduledTransfer.StartAt, nil, 12, 0, *scheduledTransfer.NextRunAt, "ACTIVE", *scheduledTransfer.CreatedAt,
+		)
+
+		mock.ExpectQuery(GetSQLFindScheduledTransfersByAccountID()).
+			WithArgs(scheduledTransfer.OriginAccountID, 10, 0).
+			WillReturnRows(rows)
+
+		scheduledTransfers, err := database.NewScheduledTransferRepository(db).FindByAccountID(context.Background(), scheduledTransfer.OriginAccountID, entity.Pagination{Limit: 10})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []entity.ScheduledTransfer{*scheduledTransfer}, scheduledTransfers)
+	})
+
+	t.Run("Testing FindByAccountID after a cursor", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		after := entity.PageCursor{CreatedAt: time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC), ID: "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"}
+
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE origin_account_id = ? AND (created_at < ? OR (created_at = ? AND id < ?)) ORDER BY")).
+			WithArgs(accountID, after.CreatedAt, after.CreatedAt, after.ID, 10, 0).
+			WillReturnRows(sqlmock.NewRows(GetScheduledTransferColumns()))
+
+		scheduledTransfers, err := database.NewScheduledTransferRepository(db).FindByAccountID(context.Background(), accountID, entity.Pagination{Limit: 10, After: &after})
+
+		assert.Nil(t, err)
+		assert.Len(t, scheduledTransfers, 0)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByAccountID when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindScheduledTransfersByAccountID()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewScheduledTransferRepository(db).FindByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.Pagination{Limit: 10})
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestScheduledTransferRepository_FindDue(t *testing.T) {
+	t.Run("Testing FindDue when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		now := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+
+		rows := sqlmock.NewRows(GetScheduledTransferColumns()).AddRow(
+			scheduledTransfer.ID, scheduledTransfer.OriginAccountID, scheduledTransfer.DestinationAccountID,
+			1000, "BRL", "MONTHLY", *scheduledTransfer.StartAt, nil, 12, 0, *scheduledTransfer.NextRunAt, "ACTIVE", *scheduledTransfer.CreatedAt,
+		)
+
+		mock.ExpectQuery(GetSQLFindDueScheduledTransfers()).
+			WithArgs(entity.ACTIVE_SCHEDULE, now, 50).
+			WillReturnRows(rows)
+
+		scheduledTransfers, err := database.NewScheduledTransferRepository(db).FindDue(context.Background(), now, 50)
+
+		assert.Nil(t, err)
+		assert.Len(t, scheduledTransfers, 1)
+		assert.True(t, scheduledTransfers[0].IsDue(now))
+	})
+
+	t.Run("Testing FindDue when a row cannot be scanned", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindDueScheduledTransfers()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"))
+
+		_, err := database.NewScheduledTransferRepository(db).FindDue(context.Background(), time.Now(), 50)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestScheduledTransferRepository_Update(t *testing.T) {
+	t.Run("Testing Update when the expected status and next run still match", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+		expectedNextRunAt := *scheduledTransfer.NextRunAt
+		scheduledTransfer.RegisterExecution(expectedNextRunAt)
+
+		mock.ExpectExec(GetSQLUpdateScheduledTransfer()).
+			WithArgs(1, scheduledTransfer.NextRunAt, entity.ACTIVE_SCHEDULE, scheduledTransfer.ID, entity.ACTIVE_SCHEDULE, &expectedNextRunAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		updated, err := database.NewScheduledTransferRepository(db).Update(context.Background(), scheduledTransfer, entity.ACTIVE_SCHEDULE, &expectedNextRunAt)
+
+		assert.Nil(t, err)
+		assert.True(t, updated)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Update when the schedule was changed concurrently", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUpdateScheduledTransfer()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+		updated, err := database.NewScheduledTransferRepository(db).Update(context.Background(), scheduledTransfer, scheduledTransfer.Status, scheduledTransfer.NextRunAt)
+
+		assert.Nil(t, err)
+		assert.False(t, updated)
+	})
+
+	t.Run("Testing Update when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUpdateScheduledTransfer()).WillReturnError(errors.New("connection closed"))
+
+		scheduledTransfer := GetBaseScheduledTransfer(t)
+		_, err := database.NewScheduledTransferRepository(db).Update(context.Background(), scheduledTransfer, scheduledTransfer.Status, scheduledTransfer.NextRunAt)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestScheduledTransferRepository_CreateExecution(t *testing.T) {
+	t.Run("Testing CreateExecution when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		executedAt := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+		execution := entity.NewScheduledTransferExecution("5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "", errors.New("insufficient balance"), &executedAt)
+
+		mock.ExpectExec(GetSQLInsertScheduledTransferExecution()).
+			WithArgs(execution.ID, execution.ScheduledTransferID, "", entity.FAILED_EXECUTION, "insufficient balance", &executedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewScheduledTransferRepository(db).CreateExecution(context.Background(), execution)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing CreateExecution when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertScheduledTransferExecution()).WillReturnError(errors.New("connection closed"))
+
+		executedAt := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+		err := database.NewScheduledTransferRepository(db).CreateExecution(context.Background(), entity.NewScheduledTransferExecution("", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "fc84682a-3045-4bdf-b91c-10be19f89452", nil, &executedAt))
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestScheduledTransferRepository_FindExecutions(t *testing.T) {
+	t.Run("Testing FindExecutions when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		executedAt := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+		scheduledTransferID := "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"
+
+		rows := sqlmock.NewRows([]string{"id", "scheduled_transfer_id", "transfer_id", "status", "failure_reason", "executed_at"}).
+			AddRow("5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11", scheduledTransferID, "fc84682a-3045-4bdf-b91c-10be19f89452", "SUCCEEDED", "", executedAt)
+
+		mock.ExpectQuery(GetSQLFindScheduledTransferExecutions()).WithArgs(scheduledTransferID).WillReturnRows(rows)
+
+		executions, err := database.NewScheduledTransferRepository(db).FindExecutions(context.Background(), scheduledTransferID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []entity.ScheduledTransferExecution{{
+			ID:                  "5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11",
+			ScheduledTransferID: scheduledTransferID,
+			TransferID:          "fc84682a-3045-4bdf-b91c-10be19f89452",
+			Status:              entity.SUCCEEDED_EXECUTION,
+			ExecutedAt:          &executedAt,
+		}}, executions)
+	})
+
+	t.Run("Testing FindExecutions when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindScheduledTransferExecutions()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewScheduledTransferRepository(db).FindExecutions(context.Background(), "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26")
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type SecretResetTokenRepository struct {
+	Db *sql.DB
+}
+
+func NewSecretResetTokenRepository(db *sql.DB) *SecretResetTokenRepository {
+	return &SecretResetTokenRepository{
+		Db: db,
+	}
+}
+
+func (r *SecretResetTokenRepository) Create(ctx context.Context, secretResetToken *entity.SecretResetToken, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "INSERT INTO secret_reset_token (id, account_id, token_hash, created_at, expires_at) VALUES (?, ?, ?, ?, ?)"
+
+	_, err := executor.ExecContext(ctx, query, secretResetToken.ID, secretResetToken.AccountID, secretResetToken.TokenHash, secretResetToken.CreatedAt, secretResetToken.ExpiresAt)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+// FindByHashForUpdate locks the token until the end of the transaction, so it cannot be used
+// twice by concurrent requests.
+func (r *SecretResetTokenRepository) FindByHashForUpdate(ctx context.Context, tokenHash string, tx ...entity.TransactionHandler) (entity.SecretResetToken, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "SELECT id, account_id, token_hash, created_at, expires_at, used_at FROM secret_reset_token WHERE token_hash = ? FOR UPDATE"
+
+	var secretResetToken entity.SecretResetToken
+	var usedAt sql.NullTime
+
+	err := executor.QueryRowContext(ctx, query, tokenHash).Scan(
+		&secretResetToken.ID, &secretResetToken.AccountID, &secretResetToken.TokenHash,
+		&secretResetToken.CreatedAt, &secretResetToken.ExpiresAt, &usedAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.SecretResetToken{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found secret reset token")
+		}
+
+		return entity.SecretResetToken{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if usedAt.Valid {
+		secretResetToken.UsedAt = &usedAt.Time
+	}
+
+	return secretResetToken, nil
+}
+
+// InvalidateByAccountID marks every token of the account not used yet as used.
+func (r *SecretResetTokenRepository) InvalidateByAccountID(ctx context.Context, accountID string, usedAt time.Time, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE secret_reset_token SET used_at = ? WHERE account_id = ? AND used_at IS NULL"
+
+	_, err := executor.ExecContext(ctx, query, usedAt, accountID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertSecretResetToken() string {
+	return regexp.QuoteMeta("INSERT INTO secret_reset_token (id, account_id, token_hash, created_at, expires_at)")
+}
+
+func GetSQLFindSecretResetTokenByHash() string {
+	return regexp.QuoteMeta("FROM secret_reset_token WHERE token_hash = ? FOR UPDATE")
+}
+
+func GetSQLInvalidateSecretResetTokens() string {
+	return regexp.QuoteMeta("UPDATE secret_reset_token SET used_at = ? WHERE account_id = ? AND used_at IS NULL")
+}
+
+func GetBaseSecretResetToken(t *testing.T) *entity.SecretResetToken {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	secretResetToken, _, err := entity.NewSecretResetToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", &createdAt, 15*time.Minute)
+
+	assert.Nil(t, err)
+	return secretResetToken
+}
+
+func TestSecretResetTokenRepository_Create(t *testing.T) {
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		secretResetToken := GetBaseSecretResetToken(t)
+
+		mock.ExpectExec(GetSQLInsertSecretResetToken()).
+			WithArgs(secretResetToken.ID, secretResetToken.AccountID, secretResetToken.TokenHash, secretResetToken.CreatedAt, secretResetToken.ExpiresAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewSecretResetTokenRepository(db).Create(context.Background(), secretResetToken, db)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertSecretResetToken()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewSecretResetTokenRepository(db).Create(context.Background(), GetBaseSecretResetToken(t))
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestSecretResetTokenRepository_FindByHashForUpdate(t *testing.T) {
+	t.Run("Testing FindByHashForUpdate when returns a used token", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		secretResetToken := GetBaseSecretResetToken(t)
+		usedAt := secretResetToken.CreatedAt.Add(time.Minute)
+
+		rows := sqlmock.NewRows([]string{"id", "account_id", "token_hash", "created_at", "expires_at", "used_at"}).
+			AddRow(secretResetToken.ID, secretResetToken.AccountID, secretResetToken.TokenHash, *secretResetToken.CreatedAt, *secretResetToken.ExpiresAt, usedAt)
+
+		mock.ExpectQuery(GetSQLFindSecretResetTokenByHash()).WithArgs(secretResetToken.TokenHash).WillReturnRows(rows)
+
+		stored, err := database.NewSecretResetTokenRepository(db).FindByHashForUpdate(context.Background(), secretResetToken.TokenHash, db)
+
+		assert.Nil(t, err)
+		assert.Equal(t, secretResetToken.ID, stored.ID)
+		assert.Equal(t, secretResetToken.AccountID, stored.AccountID)
+		assert.True(t, stored.IsUsed())
+		assert.Equal(t, usedAt, *stored.UsedAt)
+	})
+
+	t.Run("Testing FindByHashForUpdate when returns an unused token", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		secretResetToken := GetBaseSecretResetToken(t)
+
+		rows := sqlmock.NewRows([]string{"id", "account_id", "token_hash", "created_at", "expires_at", "used_at"}).
+			AddRow(secretResetToken.ID, secretResetToken.AccountID, secretResetToken.TokenHash, *secretResetToken.CreatedAt, *secretResetToken.ExpiresAt, nil)
+
+		mock.ExpectQuery(GetSQLFindSecretResetTokenByHash()).WithArgs(secretResetToken.TokenHash).WillReturnRows(rows)
+
+		stored, err := database.NewSecretResetTokenRepository(db).FindByHashForUpdate(context.Background(), secretResetToken.TokenHash)
+
+		assert.Nil(t, err)
+		assert.False(t, stored.IsUsed())
+	})
+
+	t.Run("Testing FindByHashForUpdate when token does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "account_id", "token_hash", "created_at", "expires_at", "used_at"})
+		mock.ExpectQuery(GetSQLFindSecretResetTokenByHash()).WithArgs("hash").WillReturnRows(rows)
+
+		_, err := database.NewSecretResetTokenRepository(db).FindByHashForUpdate(context.Background(), "hash")
+
+		assert.Equal(t, "not found secret reset token", err.Error())
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestSecretResetTokenRepository_InvalidateByAccountID(t *testing.T) {
+	t.Run("Testing InvalidateByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		now := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+		mock.ExpectExec(GetSQLInvalidateSecretResetTokens()).
+			WithArgs(now, "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewSecretResetTokenRepository(db).InvalidateByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", now)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing InvalidateByAccountID when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInvalidateSecretResetTokens()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewSecretResetTokenRepository(db).InvalidateByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", time.Now())
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TOTPRepository keeps the TOTP secrets encrypted, they are only decrypted when read.
+type TOTPRepository struct {
+	Db     *sql.DB
+	cipher entity.SecretCipher
+}
+
+func NewTOTPRepository(db *sql.DB, cipher entity.SecretCipher) *TOTPRepository {
+	return &TOTPRepository{
+		Db:     db,
+		cipher: cipher,
+	}
+}
+
+// Save stores the authenticator of the account, replacing the one not confirmed yet.
+func (r *TOTPRepository) Save(ctx context.Context, totp *entity.TOTP) error {
+	secret, err := r.cipher.Encrypt(totp.Secret)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	query := `
+		INSERT INTO account_totp (account_id, secret, last_used_step, confirmed_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			secret = VALUES(secret),
+			last_used_step = VALUES(last_used_step),
+			confirmed_at = VALUES(confirmed_at),
+			created_at = VALUES(created_at)
+	`
+
+	_, err = r.Db.ExecContext(ctx, query, totp.AccountID, secret, totp.LastUsedStep, totp.ConfirmedAt, totp.CreatedAt)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *TOTPRepository) FindByAccountID(ctx context.Context, accountID string) (entity.TOTP, error) {
+	query := "SELECT account_id, secret, last_used_step, confirmed_at, created_at FROM account_totp WHERE account_id = ?"
+
+	var totp entity.TOTP
+	var secret string
+	var confirmedAt sql.NullTime
+
+	err := r.Db.QueryRowContext(ctx, query, accountID).Scan(&totp.AccountID, &secret, &totp.LastUsedStep, &confirmedAt, &totp.CreatedAt)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.TOTP{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found totp")
+		}
+
+		return entity.TOTP{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	totp.Secret, err = r.cipher.Decrypt(secret)
+	if err != nil {
+		return entity.TOTP{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if confirmedAt.Valid {
+		totp.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return totp, nil
+}
+
+func (r *TOTPRepository) Confirm(ctx context.Context, accountID string, confirmedAt time.Time, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE account_totp SET confirmed_at = ? WHERE account_id = ?"
+
+	_, err := executor.ExecContext(ctx, query, confirmedAt, accountID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+// UseStep marks the time step as used, it returns false when the step or a later one was
+// already used, which means the code was replayed.
+func (r *TOTPRepository) UseStep(ctx context.Context, accountID string, step int64) (bool, error) {
+	query := "UPDATE account_totp SET last_used_step = ? WHERE account_id = ? AND last_used_step < ?"
+
+	result, err := r.Db.ExecContext(ctx, query, step, accountID, step)
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return affectedRows == 1, nil
+}
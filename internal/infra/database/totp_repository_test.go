@@ -0,0 +1,184 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"lucassantoss1701/bank/internal/infra/security"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLSaveTOTP() string {
+	return regexp.QuoteMeta("INSERT INTO account_totp (account_id, secret, last_used_step, confirmed_at, created_at)")
+}
+
+func GetSQLFindTOTPByAccountID() string {
+	return regexp.QuoteMeta("SELECT account_id, secret, last_used_step, confirmed_at, created_at FROM account_totp WHERE account_id = ?")
+}
+
+func GetSQLConfirmTOTP() string {
+	return regexp.QuoteMeta("UPDATE account_totp SET confirmed_at = ? WHERE account_id = ?")
+}
+
+func GetSQLUseTOTPStep() string {
+	return regexp.QuoteMeta("UPDATE account_totp SET last_used_step = ? WHERE account_id = ? AND last_used_step < ?")
+}
+
+func TestTOTPRepository_Save(t *testing.T) {
+	cipher, _ := security.NewAESCipher("mfa")
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	totp := &entity.TOTP{AccountID: "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", CreatedAt: &createdAt}
+
+	t.Run("Testing Save when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLSaveTOTP()).
+			WithArgs(totp.AccountID, sqlmock.AnyArg(), int64(0), nil, &createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewTOTPRepository(db, cipher).Save(context.Background(), totp)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Save when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLSaveTOTP()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewTOTPRepository(db, cipher).Save(context.Background(), totp)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestTOTPRepository_FindByAccountID(t *testing.T) {
+	cipher, _ := security.NewAESCipher("mfa")
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+
+	t.Run("Testing FindByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		secret, _ := cipher.Encrypt("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+		mock.ExpectQuery(GetSQLFindTOTPByAccountID()).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "secret", "last_used_step", "confirmed_at", "created_at"}).AddRow(accountID, secret, 37037037, createdAt, createdAt))
+
+		totp, err := database.NewTOTPRepository(db, cipher).FindByAccountID(context.Background(), accountID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", totp.Secret)
+		assert.Equal(t, int64(37037037), totp.LastUsedStep)
+		assert.Equal(t, createdAt, *totp.ConfirmedAt)
+	})
+
+	t.Run("Testing FindByAccountID when not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindTOTPByAccountID()).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "secret", "last_used_step", "confirmed_at", "created_at"}))
+
+		_, err := database.NewTOTPRepository(db, cipher).FindByAccountID(context.Background(), accountID)
+
+		assert.Equal(t, "not found totp", err.Error())
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing FindByAccountID when the secret cannot be decrypted", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		other, _ := security.NewAESCipher("other")
+		secret, _ := other.Encrypt("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+		mock.ExpectQuery(GetSQLFindTOTPByAccountID()).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "secret", "last_used_step", "confirmed_at", "created_at"}).AddRow(accountID, secret, 0, nil, createdAt))
+
+		_, err := database.NewTOTPRepository(db, cipher).FindByAccountID(context.Background(), accountID)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestTOTPRepository_Confirm(t *testing.T) {
+	confirmedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+
+	t.Run("Testing Confirm when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLConfirmTOTP()).
+			WithArgs(confirmedAt, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewTOTPRepository(db, nil).Confirm(context.Background(), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", confirmedAt)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Confirm when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLConfirmTOTP()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewTOTPRepository(db, nil).Confirm(context.Background(), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", confirmedAt)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestTOTPRepository_UseStep(t *testing.T) {
+	t.Run("Testing UseStep when the step is newer than the last used", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUseTOTPStep()).
+			WithArgs(int64(37037037), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", int64(37037037)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		used, err := database.NewTOTPRepository(db, nil).UseStep(context.Background(), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", 37037037)
+
+		assert.Nil(t, err)
+		assert.True(t, used)
+	})
+
+	t.Run("Testing UseStep when the step was already used", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUseTOTPStep()).
+			WithArgs(int64(37037037), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", int64(37037037)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		used, err := database.NewTOTPRepository(db, nil).UseStep(context.Background(), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", 37037037)
+
+		assert.Nil(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("Testing UseStep when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLUseTOTPStep()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewTOTPRepository(db, nil).UseStep(context.Background(), "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", 37037037)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
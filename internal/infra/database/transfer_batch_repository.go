@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type TransferBatchRepository struct {
+	Db *sql.DB
+}
+
+func NewTransferBatchRepository(db *sql.DB) *TransferBatchRepository {
+	return &TransferBatchRepository{
+		Db: db,
+	}
+}
+
+func (r *TransferBatchRepository) Create(ctx context.Context, batch *entity.TransferBatch, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		INSERT INTO transfer_batch (id, origin_account_id, mode, status, total, currency, failure_reason, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := executor.ExecContext(
+		ctx, query, batch.ID, batch.OriginAccountID, batch.Mode, batch.Status, batch.Total.Amount, batch.Total.Currency,
+		batch.FailureReason, batch.CreatedAt, batch.UpdatedAt,
+	)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	values := make([]string, 0, len(batch.Items))
+	args := make([]interface{}, 0, 8*len(batch.Items))
+	for _, item := range batch.Items {
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, batch.ID, item.Position, item.DestinationAccountID, item.Amount.Amount, item.Amount.Currency, item.Status, item.TransferID, item.FailureReason)
+	}
+
+	query = "INSERT INTO transfer_batch_item (batch_id, position, destination_account_id, amount, currency, status, transfer_id, failure_reason) VALUES " + strings.Join(values, ", ")
+
+	_, err = executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+// FindByID returns the batch with its items in the order of the request.
+func (r *TransferBatchRepository) FindByID(ctx context.Context, ID string) (entity.TransferBatch, error) {
+	query := "SELECT id, origin_account_id, mode, status, total, currency, failure_reason, created_at, updated_at FROM transfer_batch WHERE id = ?"
+
+	var batch entity.TransferBatch
+	err := r.Db.QueryRowContext(ctx, query, ID).Scan(
+		&batch.ID, &batch.OriginAccountID, &batch.Mode, &batch.Status, &batch.Total.Amount, &batch.Total.Currency,
+		&batch.FailureReason, &batch.CreatedAt, &batch.UpdatedAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.TransferBatch{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer batch: %s", ID))
+		}
+
+		return entity.TransferBatch{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	query = "SELECT position, destination_account_id, amount, currency, status, transfer_id, failure_reason FROM transfer_batch_item WHERE batch_id = ? ORDER BY position"
+
+	rows, err := r.Db.QueryContext(ctx, query, ID)
+	if err != nil {
+		return entity.TransferBatch{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	batch.Items = []entity.TransferBatchItem{}
+	for rows.Next() {
+		var item entity.TransferBatchItem
+
+		err := rows.Scan(&item.Position, &item.DestinationAccountID, &item.Amount.Amount, &item.Amount.Currency, &item.Status, &item.TransferID, &item.FailureReason)
+		if err != nil {
+			return entity.TransferBatch{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		batch.Items = append(batch.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return entity.TransferBatch{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return batch, nil
+}
+
+func (r *TransferBatchRepository) FindPendingIDs(ctx context.Context, limit int) ([]string, error) {
+	query := "SELECT id FROM transfer_batch WHERE status = ? ORDER BY created_at, id LIMIT ?"
+
+	return r.findIDs(ctx, query, entity.PENDING_BATCH, limit)
+}
+
+func (r *TransferBatchRepository) FindStuckIDs(ctx context.Context, startedBefore time.Time, limit int) ([]string, error) {
+	query := "SELECT id FROM transfer_batch WHERE status = ? AND updated_at < ? ORDER BY updated_at, id LIMIT ?"
+
+	return r.findIDs(ctx, query, entity.PROCESSING_BATCH, startedBefore, limit)
+}
+
+func (r *TransferBatchRepository) findIDs(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	batchIDs := []string{}
+	for rows.Next() {
+		var batchID string
+
+		err := rows.Scan(&batchID)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		batchIDs = append(batchIDs, batchID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return batchIDs, nil
+}
+
+func (r *TransferBatchRepository) Start(ctx context.Context, batch *entity.TransferBatch) (bool, error) {
+	query := "UPDATE transfer_batch SET status = ?, updated_at = ? WHERE id = ? AND status = ?"
+
+	result, err := r.Db.ExecContext(ctx, query, batch.Status, batch.UpdatedAt, batch.ID, entity.PENDING_BATCH)
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return false, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return affectedRows == 1, nil
+}
+
+func (r *TransferBatchRepository) UpdateItem(ctx context.Context, batchID string, item *entity.TransferBatchItem) error {
+	query := "UPDATE transfer_batch_item SET status = ?, transfer_id = ?, failure_reason = ? WHERE batch_id = ? AND position = ?"
+
+	_, err := r.Db.ExecContext(ctx, query, item.Status, item.TransferID, item.FailureReason, batchID, item.Position)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *TransferBatchRepository) Finish(ctx context.Context, batch *entity.TransferBatch) error {
+	// the status condition keeps a batch from being finished twice
+	query := "UPDATE transfer_batch SET status = ?, failure_reason = ?, updated_at = ? WHERE id = ? AND status = ?"
+
+	result, err := r.Db.ExecContext(ctx, query, batch.Status, batch.FailureReason, batch.UpdatedAt, batch.ID, entity.PROCESSING_BATCH)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(fmt.Sprintf("transfer batch is no longer processing: %s", batch.ID))
+	}
+
+	return nil
+}
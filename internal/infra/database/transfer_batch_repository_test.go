@@ -0,0 +1,248 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLInsertTransferBatch() string {
+	return regexp.QuoteMeta("INSERT INTO transfer_batch (id, origin_account_id, mode, status, total, currency, failure_reason, created_at, updated_at)")
+}
+
+func GetSQLInsertTransferBatchItems() string {
+	return regexp.QuoteMeta("INSERT INTO transfer_batch_item (batch_id, position, destination_account_id, amount, currency, status, transfer_id, failure_reason) VALUES (?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?)")
+}
+
+func GetSQLFindTransferBatchByID() string {
+	return regexp.QuoteMeta("SELECT id, origin_account_id, mode, status, total, currency, failure_reason, created_at, updated_at FROM transfer_batch WHERE id = ?")
+}
+
+func GetSQLFindTransferBatchItems() string {
+	return regexp.QuoteMeta("SELECT position, destination_account_id, amount, currency, status, transfer_id, failure_reason FROM transfer_batch_item WHERE batch_id = ? ORDER BY position")
+}
+
+func GetSQLStartTransferBatch() string {
+	return regexp.QuoteMeta("UPDATE transfer_batch SET status = ?, updated_at = ? WHERE id = ? AND status = ?")
+}
+
+func GetSQLFinishTransferBatch() string {
+	return regexp.QuoteMeta("UPDATE transfer_batch SET status = ?, failure_reason = ?, updated_at = ? WHERE id = ? AND status = ?")
+}
+
+func GetBaseTransferBatch(t *testing.T) *entity.TransferBatch {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	batch, err := entity.NewTransferBatch("5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.BEST_EFFORT_BATCH, []entity.TransferBatchItem{
+		{DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Amount: entity.NewMoney(5000, entity.BRL)},
+		{DestinationAccountID: "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", Amount: entity.NewMoney(2500, entity.BRL)},
+	}, &createdAt)
+	assert.Nil(t, err)
+
+	return batch
+}
+
+func TestTransferBatchRepository_Create(t *testing.T) {
+	t.Run("Testing Create saves the batch and its items", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		batch := GetBaseTransferBatch(t)
+
+		mock.ExpectExec(GetSQLInsertTransferBatch()).
+			WithArgs(batch.ID, batch.OriginAccountID, entity.BEST_EFFORT_BATCH, entity.PENDING_BATCH, int64(7500), entity.BRL, "", batch.CreatedAt, batch.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLInsertTransferBatchItems()).
+			WithArgs(
+				batch.ID, 0, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", int64(5000), entity.BRL, entity.PENDING_BATCH_ITEM, "", "",
+				batch.ID, 1, "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", int64(2500), entity.BRL, entity.PENDING_BATCH_ITEM, "", "",
+			).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+
+		err := database.NewTransferBatchRepository(db).Create(context.Background(), batch)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when saving the items returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLInsertTransferBatch()).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLInsertTransferBatchItems()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewTransferBatchRepository(db).Create(context.Background(), GetBaseTransferBatch(t))
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestTransferBatchRepository_FindByID(t *testing.T) {
+	t.Run("Testing FindByID returns the batch with its items in order", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		batch := GetBaseTransferBatch(t)
+
+		mock.ExpectQuery(GetSQLFindTransferBatchByID()).WithArgs(batch.ID).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "origin_account_id", "mode", "status", "total", "currency", "failure_reason", "created_at", "updated_at"}).
+				AddRow(batch.ID, batch.OriginAccountID, "BEST_EFFORT", "PENDING", 7500, "BRL", "", *batch.CreatedAt, *batch.UpdatedAt))
+		mock.ExpectQuery(GetSQLFindTransferBatchItems()).WithArgs(batch.ID).WillReturnRows(
+			sqlmock.NewRows([]string{"position", "destination_account_id", "amount", "currency", "status", "transfer_id", "failure_reason"}).
+				AddRow(0, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", 5000, "BRL", "PENDING", "", "").
+				AddRow(1, "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", 2500, "BRL", "PENDING", "", ""))
+
+		found, err := database.NewTransferBatchRepository(db).FindByID(context.Background(), batch.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, *batch, found)
+	})
+
+	t.Run("Testing FindByID when the batch does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindTransferBatchByID()).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := database.NewTransferBatchRepository(db).FindByID(context.Background(), "5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer batch: 5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", err.Error())
+	})
+}
+
+func TestTransferBatchRepository_FindPendingIDs(t *testing.T) {
+	t.Run("Testing FindPendingIDs when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM transfer_batch WHERE status = ? ORDER BY created_at, id LIMIT ?")).
+			WithArgs(entity.PENDING_BATCH, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e"))
+
+		batchIDs, err := database.NewTransferBatchRepository(db).FindPendingIDs(context.Background(), 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e"}, batchIDs)
+	})
+
+	t.Run("Testing FindPendingIDs when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM transfer_batch")).WillReturnError(errors.New("connection closed"))
+
+		batchIDs, err := database.NewTransferBatchRepository(db).FindPendingIDs(context.Background(), 10)
+
+		assert.Nil(t, batchIDs)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestTransferBatchRepository_FindStuckIDs(t *testing.T) {
+	t.Run("Testing FindStuckIDs lists the batches processing since before the timeout", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		startedBefore := time.Date(2023, 8, 14, 9, 50, 00, 00, time.UTC)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM transfer_batch WHERE status = ? AND updated_at < ? ORDER BY updated_at, id LIMIT ?")).
+			WithArgs(entity.PROCESSING_BATCH, startedBefore, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e"))
+
+		batchIDs, err := database.NewTransferBatchRepository(db).FindStuckIDs(context.Background(), startedBefore, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e"}, batchIDs)
+	})
+}
+
+func TestTransferBatchRepository_Start(t *testing.T) {
+	t.Run("Testing Start claims a pending batch", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		batch := GetBaseTransferBatch(t)
+		assert.Nil(t, batch.Start(time.Date(2023, 8, 14, 10, 00, 10, 00, time.UTC)))
+
+		mock.ExpectExec(GetSQLStartTransferBatch()).
+			WithArgs(entity.PROCESSING_BATCH, batch.UpdatedAt, batch.ID, entity.PENDING_BATCH).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		started, err := database.NewTransferBatchRepository(db).Start(context.Background(), batch)
+
+		assert.Nil(t, err)
+		assert.True(t, started)
+	})
+
+	t.Run("Testing Start when another worker claimed the batch first", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLStartTransferBatch()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		started, err := database.NewTransferBatchRepository(db).Start(context.Background(), GetBaseTransferBatch(t))
+
+		assert.Nil(t, err)
+		assert.False(t, started)
+	})
+}
+
+func TestTransferBatchRepository_UpdateItem(t *testing.T) {
+	t.Run("Testing UpdateItem saves the outcome of the item", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		batch := GetBaseTransferBatch(t)
+		batch.RecordItem(1, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", nil)
+
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE transfer_batch_item SET status = ?, transfer_id = ?, failure_reason = ? WHERE batch_id = ? AND position = ?")).
+			WithArgs(entity.SUCCEEDED_BATCH_ITEM, "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", "", batch.ID, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewTransferBatchRepository(db).UpdateItem(context.Background(), batch.ID, &batch.Items[1])
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTransferBatchRepository_Finish(t *testing.T) {
+	t.Run("Testing Finish saves the outcome of the batch", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		batch := GetBaseTransferBatch(t)
+		batch.Status = entity.PARTIALLY_COMPLETED_BATCH
+
+		mock.ExpectExec(GetSQLFinishTransferBatch()).
+			WithArgs(entity.PARTIALLY_COMPLETED_BATCH, "", batch.UpdatedAt, batch.ID, entity.PROCESSING_BATCH).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewTransferBatchRepository(db).Finish(context.Background(), batch)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Finish when the batch is no longer processing", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLFinishTransferBatch()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := database.NewTransferBatchRepository(db).Finish(context.Background(), GetBaseTransferBatch(t))
+
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer batch is no longer processing: 5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", err.Error())
+	})
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+)
+
+type TransferKeyRepository struct {
+	Db *sql.DB
+}
+
+func NewTransferKeyRepository(db *sql.DB) *TransferKeyRepository {
+	return &TransferKeyRepository{
+		Db: db,
+	}
+}
+
+func (r *TransferKeyRepository) Create(ctx context.Context, key *entity.TransferKey, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "INSERT INTO transfer_key (key_value, type, account_id, created_at) VALUES (?, ?, ?, ?)"
+
+	_, err := executor.ExecContext(ctx, query, key.Key, key.Type, key.AccountID, key.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "1062") {
+			return entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(fmt.Sprintf("key is already registered: %s", key.Key))
+		}
+
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func (r *TransferKeyRepository) FindByKey(ctx context.Context, key string) (entity.TransferKey, error) {
+	query := "SELECT key_value, type, account_id, created_at FROM transfer_key WHERE key_value = ?"
+
+	var transferKey entity.TransferKey
+	err := r.Db.QueryRowContext(ctx, query, key).Scan(&transferKey.Key, &transferKey.Type, &transferKey.AccountID, &transferKey.CreatedAt)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.TransferKey{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found key: %s", key))
+		}
+
+		return entity.TransferKey{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return transferKey, nil
+}
+
+func (r *TransferKeyRepository) FindByAccountID(ctx context.Context, accountID string) ([]entity.TransferKey, error) {
+	query := "SELECT key_value, type, account_id, created_at FROM transfer_key WHERE account_id = ? ORDER BY created_at, key_value"
+
+	rows, err := r.Db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	keys := []entity.TransferKey{}
+	for rows.Next() {
+		var transferKey entity.TransferKey
+
+		err := rows.Scan(&transferKey.Key, &transferKey.Type, &transferKey.AccountID, &transferKey.CreatedAt)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		keys = append(keys, transferKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return keys, nil
+}
+
+func (r *TransferKeyRepository) CountByAccountID(ctx context.Context, accountID string, tx ...entity.TransactionHandler) (int, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "SELECT COUNT(*) FROM transfer_key WHERE account_id = ?"
+
+	var count int
+	err := executor.QueryRowContext(ctx, query, accountID).Scan(&count)
+	if err != nil {
+		return 0, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return count, nil
+}
+
+func (r *TransferKeyRepository) Delete(ctx context.Context, accountID string, key string) error {
+	// keys of other accounts are left untouched and reported as not found
+	query := "DELETE FROM transfer_key WHERE key_value = ? AND account_id = ?"
+
+	result, err := r.Db.ExecContext(ctx, query, key, accountID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found key: %s", key))
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLFindTransferKeysByAccountID() string {
+	return regexp.QuoteMeta("SELECT key_value, type, account_id, created_at FROM transfer_key WHERE account_id = ? ORDER BY created_at, key_value")
+}
+
+func GetSQLDeleteTransferKey() string {
+	return regexp.QuoteMeta("DELETE FROM transfer_key WHERE key_value = ? AND account_id = ?")
+}
+
+func TestTransferKeyRepository_Create(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	transferKey := &entity.TransferKey{Key: "lucas@example.com", Type: entity.EMAIL_KEY, AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", CreatedAt: &createdAt}
+
+	t.Run("Testing Create when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO transfer_key (key_value, type, account_id, created_at) VALUES (?, ?, ?, ?)")).
+			WithArgs("lucas@example.com", entity.EMAIL_KEY, "2bd765a6-47bd-4731-9eb2-1e65542f4477", &createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewTransferKeyRepository(db).Create(context.Background(), transferKey)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when the key is already registered", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO transfer_key")).
+			WillReturnError(errors.New("Error 1062 (23000): Duplicate entry 'lucas@example.com' for key 'transfer_key.PRIMARY'"))
+
+		err := database.NewTransferKeyRepository(db).Create(context.Background(), transferKey)
+
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "key is already registered: lucas@example.com", err.Error())
+	})
+}
+
+func TestTransferKeyRepository_FindByKey(t *testing.T) {
+	t.Run("Testing FindByKey when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT key_value, type, account_id, created_at FROM transfer_key WHERE key_value = ?")).
+			WithArgs("+5511987654321").
+			WillReturnRows(sqlmock.NewRows([]string{"key_value", "type", "account_id", "created_at"}).
+				AddRow("+5511987654321", "PHONE", "2bd765a6-47bd-4731-9eb2-1e65542f4477", createdAt))
+
+		transferKey, err := database.NewTransferKeyRepository(db).FindByKey(context.Background(), "+5511987654321")
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TransferKey{Key: "+5511987654321", Type: entity.PHONE_KEY, AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", CreatedAt: &createdAt}, transferKey)
+	})
+
+	t.Run("Testing FindByKey when the key does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT key_value")).WillReturnRows(sqlmock.NewRows([]string{"key_value"}))
+
+		_, err := database.NewTransferKeyRepository(db).FindByKey(context.Background(), "+5511987654321")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found key: +5511987654321", err.Error())
+	})
+}
+
+func TestTransferKeyRepository_FindByAccountID(t *testing.T) {
+	t.Run("Testing FindByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+		mock.ExpectQuery(GetSQLFindTransferKeysByAccountID()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnRows(sqlmock.NewRows([]string{"key_value", "type", "account_id", "created_at"}).
+				AddRow("52849254088", "CPF", "2bd765a6-47bd-4731-9eb2-1e65542f4477", createdAt).
+				AddRow("lucas@example.com", "EMAIL", "2bd765a6-47bd-4731-9eb2-1e65542f4477", createdAt))
+
+		keys, err := database.NewTransferKeyRepository(db).FindByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+
+		assert.Nil(t, err)
+		assert.Len(t, keys, 2)
+		assert.Equal(t, entity.CPF_KEY, keys[0].Type)
+		assert.Equal(t, "lucas@example.com", keys[1].Key)
+	})
+
+	t.Run("Testing FindByAccountID when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindTransferKeysByAccountID()).WillReturnError(errors.New("connection closed"))
+
+		keys, err := database.NewTransferKeyRepository(db).FindByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+
+		assert.Nil(t, keys)
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestTransferKeyRepository_CountByAccountID(t *testing.T) {
+	t.Run("Testing CountByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM transfer_key WHERE account_id = ?")).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := database.NewTransferKeyRepository(db).CountByAccountID(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
+
+func TestTransferKeyRepository_Delete(t *testing.T) {
+	t.Run("Testing Delete when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLDeleteTransferKey()).
+			WithArgs("lucas@example.com", "2bd765a6-47bd-4731-9eb2-1e65542f4477").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := database.NewTransferKeyRepository(db).Delete(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "lucas@example.com")
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Delete when the key belongs to another account", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLDeleteTransferKey()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := database.NewTransferKeyRepository(db).Delete(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", "lucas@example.com")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found key: lucas@example.com", err.Error())
+	})
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type TransferLimitRepository struct {
+	Db *sql.DB
+}
+
+func NewTransferLimitRepository(db *sql.DB) *TransferLimitRepository {
+	return &TransferLimitRepository{
+		Db: db,
+	}
+}
+
+// FindByAccountID returns a NOT_FOUND_ERROR when the account never changed its limits.
+func (r *TransferLimitRepository) FindByAccountID(ctx context.Context, accountID string, tx ...entity.TransactionHandler) (entity.TransferLimit, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "SELECT account_id, per_transaction, daily, monthly, nighttime, currency, updated_at FROM transfer_limit WHERE account_id = ?"
+
+	var transferLimit entity.TransferLimit
+	var currency entity.Currency
+
+	err := executor.QueryRowContext(ctx, query, accountID).Scan(
+		&transferLimit.AccountID, &transferLimit.PerTransaction.Amount, &transferLimit.Daily.Amount,
+		&transferLimit.Monthly.Amount, &transferLimit.Nighttime.Amount, &currency, &transferLimit.UpdatedAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.TransferLimit{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer limit: %s", accountID))
+		}
+
+		return entity.TransferLimit{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	transferLimit.PerTransaction.Currency = currency
+	transferLimit.Daily.Currency = currency
+	transferLimit.Monthly.Currency = currency
+	transferLimit.Nighttime.Currency = currency
+
+	return transferLimit, nil
+}
+
+// Save stores the limits of the account, replacing the previous ones.
+func (r *TransferLimitRepository) Save(ctx context.Context, transferLimit *entity.TransferLimit) error {
+	query := `
+		INSERT INTO transfer_limit (account_id, per_transaction, daily, monthly, nighttime, currency, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			per_transaction = VALUES(per_transaction),
+			daily = VALUES(daily),
+			monthly = VALUES(monthly),
+			nighttime = VALUES(nighttime),
+			currency = VALUES(currency),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.Db.ExecContext(ctx, query, transferLimit.AccountID, transferLimit.PerTransaction.Amount, transferLimit.Daily.Amount,
+		transferLimit.Monthly.Amount, transferLimit.Nighttime.Amount, transferLimit.Daily.Currency, transferLimit.UpdatedAt)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetSQLSaveTransferLimit() string {
+	return regexp.QuoteMeta("INSERT INTO transfer_limit (account_id, per_transaction, daily, monthly, nighttime, currency, updated_at)")
+}
+
+func GetSQLFindTransferLimitByAccountID() string {
+	return regexp.QuoteMeta("SELECT account_id, per_transaction, daily, monthly, nighttime, currency, updated_at FROM transfer_limit WHERE account_id = ?")
+}
+
+func TestTransferLimitRepository_Save(t *testing.T) {
+	updatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	transferLimit, err := entity.NewTransferLimit("8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", entity.NewMoney(1000, entity.BRL), entity.NewMoney(5000, entity.BRL), entity.NewMoney(50000, entity.BRL), entity.NewMoney(2000, entity.BRL), &updatedAt)
+	assert.Nil(t, err)
+
+	t.Run("Testing Save when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLSaveTransferLimit()).
+			WithArgs(transferLimit.AccountID, int64(1000), int64(5000), int64(50000), int64(2000), entity.BRL, &updatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := database.NewTransferLimitRepository(db).Save(context.Background(), transferLimit)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Save when ExecContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(GetSQLSaveTransferLimit()).WillReturnError(errors.New("connection closed"))
+
+		err := database.NewTransferLimitRepository(db).Save(context.Background(), transferLimit)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestTransferLimitRepository_FindByAccountID(t *testing.T) {
+	updatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+
+	t.Run("Testing FindByAccountID when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindTransferLimitByAccountID()).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "per_transaction", "daily", "monthly", "nighttime", "currency", "updated_at"}).
+				AddRow(accountID, 1000, 5000, 50000, 2000, "USD", updatedAt))
+
+		transferLimit, err := database.NewTransferLimitRepository(db).FindByAccountID(context.Background(), accountID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TransferLimit{
+			AccountID:      accountID,
+			PerTransaction: entity.NewMoney(1000, entity.USD),
+			Daily:          entity.NewMoney(5000, entity.USD),
+			Monthly:        entity.NewMoney(50000, entity.USD),
+			Nighttime:      entity.NewMoney(2000, entity.USD),
+			UpdatedAt:      &updatedAt,
+		}, transferLimit)
+	})
+
+	t.Run("Testing FindByAccountID when the account never changed its limits", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindTransferLimitByAccountID()).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+
+		_, err := database.NewTransferLimitRepository(db).FindByAccountID(context.Background(), accountID, db)
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer limit: 8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f", err.Error())
+	})
+
+	t.Run("Testing FindByAccountID when QueryRowContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindTransferLimitByAccountID()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewTransferLimitRepository(db).FindByAccountID(context.Background(), accountID)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -3,7 +3,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
 )
 
 type TransferRepository struct {
@@ -16,18 +19,79 @@ func NewTransferRepository(db *sql.DB) *TransferRepository {
 	}
 }
 
-func (r *TransferRepository) FindByAccountID(ctx context.Context, AccountID string, limit, offset int) ([]entity.Transfer, error) {
-	query := `
-		SELECT t.id, t.amount, t.created_at,
-			d.id AS destination_account_id, d.name AS destination_account_name
-		FROM transfer t
-		INNER JOIN account o ON t.origin_account_id = o.id
-		INNER JOIN account d ON t.destination_account_id = d.id
-		WHERE t.origin_account_id = ?
+const transferSelect = `
+	SELECT t.id, t.amount, t.currency, t.destination_amount, t.destination_currency, t.exchange_rate,
+		COALESCE(t.reversal_of, ''), t.reversed_amount, t.fee, t.status, t.failure_reason, t.created_at,
+		o.id AS origin_account_id, o.name AS origin_account_name,
+		d.id AS destination_account_id, d.name AS destination_account_name
+	FROM transfer t
+	INNER JOIN account o ON t.origin_account_id = o.id
+	INNER JOIN account d ON t.destination_account_id = d.id`
+
+// FindByID returns the transfer with its status history and fees.
+func (r *TransferRepository) FindByID(ctx context.Context, ID string) (entity.Transfer, error) {
+	transfer, err := scanTransfer(r.Db.QueryRowContext(ctx, transferSelect+" WHERE t.id = ?", ID))
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.Transfer{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer: %s", ID))
+		}
+
+		return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	query := "SELECT status, reason, changed_at FROM transfer_status_history WHERE transfer_id = ? ORDER BY id"
+
+	rows, err := r.Db.QueryContext(ctx, query, ID)
+	if err != nil {
+		return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	transfer.StatusHistory = []entity.TransferStatusChange{}
+	for rows.Next() {
+		var change entity.TransferStatusChange
+
+		err := rows.Scan(&change.Status, &change.Reason, &change.ChangedAt)
+		if err != nil {
+			return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		transfer.StatusHistory = append(transfer.StatusHistory, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	fees, err := r.findFees(ctx, ID)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	transfer.Fees = fees[ID]
+
+	return transfer, nil
+}
+
+// FindByAccountID returns the transfers sent or received by the account, newest first,
+// starting after the cursor when there is one.
+func (r *TransferRepository) FindByAccountID(ctx context.Context, AccountID string, filter entity.TransferFilter, page entity.Pagination) ([]entity.Transfer, error) {
+	conditions, args := transferFilterConditions(AccountID, filter)
+
+	if page.After != nil {
+		conditions = append(conditions, "(t.created_at < ? OR (t.created_at = ? AND t.id < ?))")
+		args = append(args, page.After.CreatedAt, page.After.CreatedAt, page.After.ID)
+	}
+
+	query := transferSelect + `
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY t.created_at DESC, t.id DESC
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.Db.QueryContext(ctx, query, AccountID, limit, offset)
+	args = append(args, page.Limit, page.Offset)
+
+	rows, err := r.Db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 	}
@@ -35,21 +99,11 @@ func (r *TransferRepository) FindByAccountID(ctx context.Context, AccountID stri
 
 	transfers := []entity.Transfer{}
 	for rows.Next() {
-		var transfer entity.Transfer
-		var originAccount entity.Account
-		var destinationAccount entity.Account
-
-		err := rows.Scan(
-			&transfer.ID, &transfer.Amount, &transfer.CreatedAt,
-			&destinationAccount.ID, &destinationAccount.Name,
-		)
+		transfer, err := scanTransfer(rows)
 		if err != nil {
 			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 		}
 
-		transfer.OriginAccount = &originAccount
-		transfer.DestinationAccount = &destinationAccount
-
 		transfers = append(transfers, transfer)
 	}
 
@@ -57,9 +111,109 @@ func (r *TransferRepository) FindByAccountID(ctx context.Context, AccountID stri
 		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
 	}
 
+	if len(transfers) == 0 {
+		return transfers, nil
+	}
+
+	IDs := make([]string, 0, len(transfers))
+	for _, transfer := range transfers {
+		IDs = append(IDs, transfer.ID)
+	}
+
+	fees, err := r.findFees(ctx, IDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transfers {
+		transfers[i].Fees = fees[transfers[i].ID]
+	}
+
 	return transfers, nil
 }
 
+// findFees returns the fees of each transfer in the order they were charged.
+func (r *TransferRepository) findFees(ctx context.Context, transferIDs ...string) (map[string][]entity.TransferFee, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(transferIDs)), ", ")
+	query := "SELECT transfer_id, rule, fee_type, amount, currency, waived FROM transfer_fee WHERE transfer_id IN (" + placeholders + ") ORDER BY id"
+
+	args := make([]interface{}, 0, len(transferIDs))
+	for _, ID := range transferIDs {
+		args = append(args, ID)
+	}
+
+	rows, err := r.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	fees := map[string][]entity.TransferFee{}
+	for rows.Next() {
+		var transferID string
+		var fee entity.TransferFee
+
+		err := rows.Scan(&transferID, &fee.Rule, &fee.Type, &fee.Amount.Amount, &fee.Amount.Currency, &fee.Waived)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		fees[transferID] = append(fees[transferID], fee)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return fees, nil
+}
+
+func transferFilterConditions(accountID string, filter entity.TransferFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	switch filter.Direction {
+	case entity.OUTGOING:
+		conditions = append(conditions, "t.origin_account_id = ?")
+		args = append(args, accountID)
+	case entity.INCOMING:
+		conditions = append(conditions, "t.destination_account_id = ?")
+		args = append(args, accountID)
+	default:
+		conditions = append(conditions, "(t.origin_account_id = ? OR t.destination_account_id = ?)")
+		args = append(args, accountID, accountID)
+	}
+
+	// an account never transfers to itself, so the counterparty is whichever side matches
+	if filter.CounterpartyID != "" {
+		conditions = append(conditions, "(t.origin_account_id = ? OR t.destination_account_id = ?)")
+		args = append(args, filter.CounterpartyID, filter.CounterpartyID)
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, "t.created_at >= ?")
+		args = append(args, filter.From)
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, "t.created_at <= ?")
+		args = append(args, filter.To)
+	}
+
+	// amounts are only comparable within the same currency
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "t.currency = ?", "t.amount >= ?")
+		args = append(args, filter.MinAmount.Currency, filter.MinAmount.Amount)
+	}
+
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "t.currency = ?", "t.amount <= ?")
+		args = append(args, filter.MaxAmount.Currency, filter.MaxAmount.Amount)
+	}
+
+	return conditions, args
+}
+
 func (r *TransferRepository) Create(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) (entity.Transfer, error) {
 	var executor entity.TransactionHandler
 	if len(tx) > 0 {
@@ -69,12 +223,18 @@ func (r *TransferRepository) Create(ctx context.Context, transfer *entity.Transf
 	}
 
 	query := `
-		INSERT INTO transfer (id, origin_account_id, destination_account_id, amount, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO transfer (id, origin_account_id, destination_account_id, amount, currency, destination_amount, destination_currency, exchange_rate, reversal_of, reversed_amount, fee, status, failure_reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	var reversalOf *string
+	if transfer.IsReversal() {
+		reversalOf = &transfer.ReversalOf
+	}
+
 	result, err := executor.ExecContext(
-		ctx, query, transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount, transfer.CreatedAt,
+		ctx, query, transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount.Amount, transfer.Amount.Currency,
+		transfer.DestinationAmount.Amount, transfer.DestinationAmount.Currency, transfer.ExchangeRate, reversalOf, transfer.ReversedAmount.Amount, transfer.Fee.Amount, transfer.Status, transfer.FailureReason, transfer.CreatedAt,
 	)
 	if err != nil {
 		return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
@@ -89,5 +249,269 @@ func (r *TransferRepository) Create(ctx context.Context, transfer *entity.Transf
 		return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("unexpected number of affected rows")
 	}
 
+	err = insertStatusChanges(ctx, executor, transfer.ID, transfer.StatusHistory...)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	err = insertFees(ctx, executor, transfer.ID, transfer.Fees...)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
 	return *transfer, nil
 }
+
+// FindByIDForUpdate reads the transfer with a row lock inside the transaction, the accounts
+// only carry their IDs.
+func (r *TransferRepository) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Transfer, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		SELECT id, origin_account_id, destination_account_id, amount, currency, destination_amount, destination_currency, exchange_rate,
+			COALESCE(reversal_of, ''), reversed_amount, fee, status, failure_reason, created_at
+		FROM transfer
+		WHERE id = ?
+		FOR UPDATE
+	`
+
+	var transfer entity.Transfer
+	var originAccount entity.Account
+	var destinationAccount entity.Account
+
+	err := executor.QueryRowContext(ctx, query, ID).Scan(
+		&transfer.ID, &originAccount.ID, &destinationAccount.ID, &transfer.Amount.Amount, &transfer.Amount.Currency,
+		&transfer.DestinationAmount.Amount, &transfer.DestinationAmount.Currency, &transfer.ExchangeRate,
+		&transfer.ReversalOf, &transfer.ReversedAmount.Amount, &transfer.Fee.Amount, &transfer.Status, &transfer.FailureReason, &transfer.CreatedAt,
+	)
+	if err != nil {
+		if err.Error() == sql.ErrNoRows.Error() {
+			return entity.Transfer{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer: %s", ID))
+		}
+
+		return entity.Transfer{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	transfer.ReversedAmount.Currency = transfer.Amount.Currency
+	transfer.Fee.Currency = transfer.Amount.Currency
+	transfer.OriginAccount = &originAccount
+	transfer.DestinationAccount = &destinationAccount
+
+	return transfer, nil
+}
+
+func (r *TransferRepository) UpdateReversedAmount(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE transfer SET reversed_amount = ? WHERE id = ?"
+
+	result, err := executor.ExecContext(ctx, query, transfer.ReversedAmount.Amount, transfer.ID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("unexpected number of affected rows")
+	}
+
+	return nil
+}
+
+// UpdateStatus saves the current status of the transfer and adds its last change to the history.
+func (r *TransferRepository) UpdateStatus(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) error {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := "UPDATE transfer SET status = ?, failure_reason = ? WHERE id = ?"
+
+	result, err := executor.ExecContext(ctx, query, transfer.Status, transfer.FailureReason, transfer.ID)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if affectedRows != 1 {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("unexpected number of affected rows")
+	}
+
+	if len(transfer.StatusHistory) == 0 {
+		return nil
+	}
+
+	return insertStatusChanges(ctx, executor, transfer.ID, transfer.StatusHistory[len(transfer.StatusHistory)-1])
+}
+
+func insertStatusChanges(ctx context.Context, executor entity.TransactionHandler, transferID string, changes ...entity.TransferStatusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(changes))
+	args := make([]interface{}, 0, 4*len(changes))
+	for _, change := range changes {
+		values = append(values, "(?, ?, ?, ?)")
+		args = append(args, transferID, change.Status, change.Reason, change.ChangedAt)
+	}
+
+	query := "INSERT INTO transfer_status_history (transfer_id, status, reason, changed_at) VALUES " + strings.Join(values, ", ")
+
+	_, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func insertFees(ctx context.Context, executor entity.TransactionHandler, transferID string, fees ...entity.TransferFee) error {
+	if len(fees) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(fees))
+	args := make([]interface{}, 0, 6*len(fees))
+	for _, fee := range fees {
+		values = append(values, "(?, ?, ?, ?, ?, ?)")
+		args = append(args, transferID, fee.Rule, fee.Type, fee.Amount.Amount, fee.Amount.Currency, fee.Waived)
+	}
+
+	query := "INSERT INTO transfer_fee (transfer_id, rule, fee_type, amount, currency, waived) VALUES " + strings.Join(values, ", ")
+
+	_, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
+
+func scanTransfer(row scanner) (entity.Transfer, error) {
+	var transfer entity.Transfer
+	var originAccount entity.Account
+	var destinationAccount entity.Account
+
+	err := row.Scan(
+		&transfer.ID, &transfer.Amount.Amount, &transfer.Amount.Currency,
+		&transfer.DestinationAmount.Amount, &transfer.DestinationAmount.Currency, &transfer.ExchangeRate,
+		&transfer.ReversalOf, &transfer.ReversedAmount.Amount, &transfer.Fee.Amount, &transfer.Status, &transfer.FailureReason, &transfer.CreatedAt,
+		&originAccount.ID, &originAccount.Name,
+		&destinationAccount.ID, &destinationAccount.Name,
+	)
+
+	transfer.ReversedAmount.Currency = transfer.Amount.Currency
+	transfer.Fee.Currency = transfer.Amount.Currency
+	transfer.OriginAccount = &originAccount
+	transfer.DestinationAccount = &destinationAccount
+
+	return transfer, err
+}
+
+// SumSent reads inside the transaction that locked the origin account, so the transfers of the
+// account are serialized and the sum cannot miss a transfer made concurrently.
+func (r *TransferRepository) SumSent(ctx context.Context, accountID string, currency entity.Currency, since time.Time, tx ...entity.TransactionHandler) (entity.Money, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transfer
+		WHERE origin_account_id = ? AND created_at >= ? AND reversal_of IS NULL AND status IN (?, ?)
+	`
+
+	var sum int64
+	err := executor.QueryRowContext(ctx, query, accountID, since, entity.COMPLETED_TRANSFER, entity.REVERSED_TRANSFER).Scan(&sum)
+	if err != nil {
+		return entity.Money{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return entity.NewMoney(sum, currency), nil
+}
+
+func (r *TransferRepository) CountSent(ctx context.Context, accountID string, since time.Time, tx ...entity.TransactionHandler) (int, error) {
+	var executor entity.TransactionHandler
+	if len(tx) > 0 {
+		executor = tx[0]
+	} else {
+		executor = r.Db
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM transfer
+		WHERE origin_account_id = ? AND created_at >= ? AND reversal_of IS NULL AND status IN (?, ?)
+	`
+
+	var count int
+	err := executor.QueryRowContext(ctx, query, accountID, since, entity.COMPLETED_TRANSFER, entity.REVERSED_TRANSFER).Scan(&count)
+	if err != nil {
+		return 0, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return count, nil
+}
+
+// FindFrequentDestinations counts the same transfers SumSent adds up, grouped by the account
+// that received them.
+func (r *TransferRepository) FindFrequentDestinations(ctx context.Context, accountID string, since time.Time, limit int) ([]entity.FrequentDestination, error) {
+	query := `
+		SELECT t.destination_account_id, a.name, COUNT(*) AS transfers, MAX(t.created_at) AS last_transfer_at
+		FROM transfer t
+		INNER JOIN account a ON a.id = t.destination_account_id
+		WHERE t.origin_account_id = ? AND t.created_at >= ? AND t.reversal_of IS NULL AND t.status IN (?, ?)
+		GROUP BY t.destination_account_id, a.name
+		HAVING COUNT(*) >= ?
+		ORDER BY transfers DESC, last_transfer_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, accountID, since, entity.COMPLETED_TRANSFER, entity.REVERSED_TRANSFER, entity.FREQUENT_DESTINATION_MIN_TRANSFERS, limit)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+	defer rows.Close()
+
+	destinations := []entity.FrequentDestination{}
+	for rows.Next() {
+		var destination entity.FrequentDestination
+
+		err := rows.Scan(&destination.AccountID, &destination.Name, &destination.Transfers, &destination.LastTransferAt)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+		}
+
+		destinations = append(destinations, destination)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return destinations, nil
+}
@@ -6,6 +6,7 @@ import (
 	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/database"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,11 +15,62 @@ import (
 )
 
 func GetSQLFindTransfersByAccountID() string {
-	return regexp.QuoteMeta(`SELECT t.id, t.amount, t.created_at, d.id AS destination_account_id, d.name AS destination_account_name FROM transfer t INNER JOIN account o ON t.origin_account_id = o.id INNER JOIN account d ON t.destination_account_id = d.id WHERE t.origin_account_id = ? LIMIT ? OFFSET ?`)
+	return regexp.QuoteMeta(`SELECT t.id, t.amount, t.currency, t.destination_amount, t.destination_currency, t.exchange_rate, COALESCE(t.reversal_of, ''), t.reversed_amount, t.fee, t.status, t.failure_reason, t.created_at, o.id AS origin_account_id, o.name AS origin_account_name, d.id AS destination_account_id, d.name AS destination_account_name FROM transfer t INNER JOIN account o ON t.origin_account_id = o.id INNER JOIN account d ON t.destination_account_id = d.id WHERE (t.origin_account_id = ? OR t.destination_account_id = ?) ORDER BY t.created_at DESC, t.id DESC LIMIT ? OFFSET ?`)
+}
+
+func GetSQLFindTransfersByAccountIDWithFilters() string {
+	return regexp.QuoteMeta(`WHERE t.destination_account_id = ? AND (t.origin_account_id = ? OR t.destination_account_id = ?) AND t.created_at >= ? AND t.created_at <= ? AND t.currency = ? AND t.amount >= ? AND t.currency = ? AND t.amount <= ? ORDER BY t.created_at DESC, t.id DESC LIMIT ? OFFSET ?`)
+}
+
+func GetSQLFindTransferByIDForUpdate() string {
+	return regexp.QuoteMeta(`SELECT id, origin_account_id, destination_account_id, amount, currency, destination_amount, destination_currency, exchange_rate, COALESCE(reversal_of, ''), reversed_amount, fee, status, failure_reason, created_at FROM transfer WHERE id = ? FOR UPDATE`)
+}
+
+func GetSQLUpdateTransferReversedAmount() string {
+	return regexp.QuoteMeta(`UPDATE transfer SET reversed_amount = ? WHERE id = ?`)
+}
+
+func GetSQLFindTransferByID() string {
+	return regexp.QuoteMeta(`SELECT t.id, t.amount, t.currency, t.destination_amount, t.destination_currency, t.exchange_rate, COALESCE(t.reversal_of, ''), t.reversed_amount, t.fee, t.status, t.failure_reason, t.created_at, o.id AS origin_account_id, o.name AS origin_account_name, d.id AS destination_account_id, d.name AS destination_account_name FROM transfer t INNER JOIN account o ON t.origin_account_id = o.id INNER JOIN account d ON t.destination_account_id = d.id WHERE t.id = ?`)
+}
+
+func GetSQLFindTransferStatusHistory() string {
+	return regexp.QuoteMeta(`SELECT status, reason, changed_at FROM transfer_status_history WHERE transfer_id = ? ORDER BY id`)
+}
+
+func GetSQLTransferStatusHistoryInsertQuery(changes int) string {
+	values := make([]string, changes)
+	for i := range values {
+		values[i] = "(?, ?, ?, ?)"
+	}
+
+	return regexp.QuoteMeta(`INSERT INTO transfer_status_history (transfer_id, status, reason, changed_at) VALUES ` + strings.Join(values, ", "))
+}
+
+func GetSQLFindTransferFees(transfers int) string {
+	placeholders := make([]string, transfers)
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+
+	return regexp.QuoteMeta(`SELECT transfer_id, rule, fee_type, amount, currency, waived FROM transfer_fee WHERE transfer_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY id`)
+}
+
+func GetSQLTransferFeeInsertQuery(fees int) string {
+	values := make([]string, fees)
+	for i := range values {
+		values[i] = "(?, ?, ?, ?, ?, ?)"
+	}
+
+	return regexp.QuoteMeta(`INSERT INTO transfer_fee (transfer_id, rule, fee_type, amount, currency, waived) VALUES ` + strings.Join(values, ", "))
+}
+
+func GetSQLUpdateTransferStatus() string {
+	return regexp.QuoteMeta(`UPDATE transfer SET status = ?, failure_reason = ? WHERE id = ?`)
 }
 
 func GetSQLTransferInsertQuery() string {
-	return regexp.QuoteMeta(`INSERT INTO transfer (id, origin_account_id, destination_account_id, amount, created_at) VALUES (?, ?, ?, ?, ?)`)
+	return regexp.QuoteMeta(`INSERT INTO transfer (id, origin_account_id, destination_account_id, amount, currency, destination_amount, destination_currency, exchange_rate, reversal_of, reversed_amount, fee, status, failure_reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 }
 
 func TestTransferRepository_FindByAccountID(t *testing.T) {
@@ -38,27 +90,111 @@ func TestTransferRepository_FindByAccountID(t *testing.T) {
 		offset := 0
 
 		rows := sqlmock.NewRows([]string{
-			"id", "amount", "created_at",
+			"id", "amount", "currency", "destination_amount", "destination_currency", "exchange_rate", "reversal_of", "reversed_amount", "fee", "status", "failure_reason", "created_at",
+			"origin_account_id", "origin_account_name",
 			"destination_account_id", "destination_account_name",
 		}).AddRow(
-			transferID, 100, time.Now(),
+			transferID, 100, "BRL", 20, "USD", "0.2", "", 30, 15, "COMPLETED", "", time.Now(),
+			originAccountID, "Lucas",
 			destinationAccountID, destinationAccountName,
 		)
 
 		mock.ExpectQuery(GetSQLFindTransfersByAccountID()).
-			WithArgs(originAccountID, limit, offset).
+			WithArgs(originAccountID, originAccountID, limit, offset).
 			WillReturnRows(rows)
+		mock.ExpectQuery(GetSQLFindTransferFees(1)).
+			WithArgs(transferID).
+			WillReturnRows(sqlmock.NewRows([]string{"transfer_id", "rule", "fee_type", "amount", "currency", "waived"}).
+				AddRow(transferID, "flat", "FLAT", 0, "BRL", true).
+				AddRow(transferID, "percentage", "PERCENTAGE", 15, "BRL", false))
 
-		transfers, err := transferRepository.FindByAccountID(context.Background(), originAccountID, limit, offset)
+		transfers, err := transferRepository.FindByAccountID(context.Background(), originAccountID, entity.TransferFilter{}, entity.Pagination{Limit: limit, Offset: offset})
 		assert.Nil(t, err)
 		assert.Len(t, transfers, 1)
 		assert.Equal(t, transferID, transfers[0].ID)
-		assert.Equal(t, 100, transfers[0].Amount)
-
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), transfers[0].Amount)
+		assert.Equal(t, entity.NewMoney(20, entity.USD), transfers[0].DestinationAmount)
+		assert.Equal(t, "0.2", transfers[0].ExchangeRate)
+		assert.Equal(t, "", transfers[0].ReversalOf)
+		assert.Equal(t, entity.NewMoney(30, entity.BRL), transfers[0].ReversedAmount)
+		assert.Equal(t, entity.NewMoney(15, entity.BRL), transfers[0].Fee)
+		assert.Equal(t, []entity.TransferFee{
+			{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(0, entity.BRL), Waived: true},
+			{Rule: "percentage", Type: entity.PERCENTAGE_FEE, Amount: entity.NewMoney(15, entity.BRL)},
+		}, transfers[0].Fees)
+		assert.Equal(t, entity.COMPLETED_TRANSFER, transfers[0].Status)
+
+		assert.Equal(t, originAccountID, transfers[0].OriginAccount.ID)
 		assert.Equal(t, destinationAccountID, transfers[0].DestinationAccount.ID)
 		assert.Equal(t, destinationAccountName, transfers[0].DestinationAccount.Name)
 	})
 
+	t.Run("Testing FindByAccountID with every filter", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		counterpartyID := "d18551d3-cf13-49ec-b1dc-741a1f8715f6"
+		from := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+		minAmount := entity.NewMoney(1000, entity.BRL)
+		maxAmount := entity.NewMoney(50000, entity.BRL)
+
+		filter, err := entity.NewTransferFilter("incoming", &from, &to, &minAmount, &maxAmount, counterpartyID)
+		assert.Nil(t, err)
+
+		mock.ExpectQuery(GetSQLFindTransfersByAccountIDWithFilters()).
+			WithArgs(accountID, counterpartyID, counterpartyID, &from, &to, entity.BRL, int64(1000), entity.BRL, int64(50000), 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "amount", "currency", "destination_amount", "destination_currency", "exchange_rate", "reversal_of", "reversed_amount", "fee", "status", "failure_reason", "created_at",
+				"origin_account_id", "origin_account_name",
+				"destination_account_id", "destination_account_name",
+			}))
+
+		transfers, err := transferRepository.FindByAccountID(context.Background(), accountID, *filter, entity.Pagination{Limit: 10})
+		assert.Nil(t, err)
+		assert.Len(t, transfers, 0)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByAccountID with outgoing direction", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE t.origin_account_id = ? ORDER BY")).
+			WithArgs(accountID, 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := transferRepository.FindByAccountID(context.Background(), accountID, entity.TransferFilter{Direction: entity.OUTGOING}, entity.Pagination{Limit: 10})
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing FindByAccountID after a cursor", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+		after := &entity.PageCursor{CreatedAt: createdAt, ID: "fc84682a-3045-4bdf-b91c-10be19f89452"}
+
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE (t.origin_account_id = ? OR t.destination_account_id = ?) AND (t.created_at < ? OR (t.created_at = ? AND t.id < ?)) ORDER BY t.created_at DESC, t.id DESC LIMIT ? OFFSET ?")).
+			WithArgs(accountID, accountID, createdAt, createdAt, after.ID, 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := transferRepository.FindByAccountID(context.Background(), accountID, entity.TransferFilter{}, entity.Pagination{Limit: 10, After: after})
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("Testing FindByAccountID when QueryContext returns a error", func(t *testing.T) {
 		db, mock, _ := sqlmock.New()
 		defer db.Close()
@@ -71,10 +207,10 @@ func TestTransferRepository_FindByAccountID(t *testing.T) {
 		offset := 0
 
 		mock.ExpectQuery(GetSQLFindTransfersByAccountID()).
-			WithArgs(originAccountID, limit, offset).
+			WithArgs(originAccountID, originAccountID, limit, offset).
 			WillReturnError(errors.New("connection closed"))
 
-		transfers, err := transferRepository.FindByAccountID(context.Background(), originAccountID, limit, offset)
+		transfers, err := transferRepository.FindByAccountID(context.Background(), originAccountID, entity.TransferFilter{}, entity.Pagination{Limit: limit, Offset: offset})
 		assert.NotNil(t, err)
 		assert.Equal(t, "connection closed", err.Error())
 		assert.Len(t, transfers, 0)
@@ -92,16 +228,16 @@ func TestTransferRepository_FindByAccountID(t *testing.T) {
 		offset := 0
 
 		rows := sqlmock.NewRows([]string{
-			"id", "amount", "created_at",
+			"id", "amount", "currency", "destination_amount", "destination_currency", "exchange_rate", "reversal_of", "reversed_amount", "fee", "status", "failure_reason", "created_at",
 			"origin_account_id", "origin_account_name", "origin_account_balance",
 			"destination_account_id", "destination_account_name", "destination_account_balance",
 		}).CloseError(errors.New("error on scan"))
 
 		mock.ExpectQuery(GetSQLFindTransfersByAccountID()).
-			WithArgs(originAccountID, limit, offset).
+			WithArgs(originAccountID, originAccountID, limit, offset).
 			WillReturnRows(rows)
 
-		transfers, err := transferRepository.FindByAccountID(context.Background(), originAccountID, limit, offset)
+		transfers, err := transferRepository.FindByAccountID(context.Background(), originAccountID, entity.TransferFilter{}, entity.Pagination{Limit: limit, Offset: offset})
 		assert.NotNil(t, err)
 		assert.Equal(t, "error on scan", err.Error())
 		assert.Len(t, transfers, 0)
@@ -123,14 +259,17 @@ func TestTransferRepository_Create(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
 		assert.Nil(t, err)
 
 		mock.ExpectExec(GetSQLTransferInsertQuery()).
-			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount, transfer.CreatedAt).
+			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount.Amount, transfer.Amount.Currency, transfer.DestinationAmount.Amount, transfer.DestinationAmount.Currency, transfer.ExchangeRate, nil, transfer.ReversedAmount.Amount, transfer.Fee.Amount, transfer.Status, transfer.FailureReason, transfer.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(1)).
+			WithArgs(transfer.ID, entity.PENDING_TRANSFER, "", transfer.CreatedAt).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		createdTransfer, err := transferRepository.Create(ctx, transfer)
@@ -150,14 +289,17 @@ func TestTransferRepository_Create(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
 		assert.Nil(t, err)
 
 		mock.ExpectExec(GetSQLTransferInsertQuery()).
-			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount, transfer.CreatedAt).
+			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount.Amount, transfer.Amount.Currency, transfer.DestinationAmount.Amount, transfer.DestinationAmount.Currency, transfer.ExchangeRate, nil, transfer.ReversedAmount.Amount, transfer.Fee.Amount, transfer.Status, transfer.FailureReason, transfer.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(1)).
+			WithArgs(transfer.ID, entity.PENDING_TRANSFER, "", transfer.CreatedAt).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		createdTransfer, err := transferRepository.Create(ctx, transfer, db)
@@ -177,14 +319,14 @@ func TestTransferRepository_Create(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
 		assert.Nil(t, err)
 
 		mock.ExpectExec(GetSQLTransferInsertQuery()).
-			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount, transfer.CreatedAt).
+			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount.Amount, transfer.Amount.Currency, transfer.DestinationAmount.Amount, transfer.DestinationAmount.Currency, transfer.ExchangeRate, nil, transfer.ReversedAmount.Amount, transfer.Fee.Amount, transfer.Status, transfer.FailureReason, transfer.CreatedAt).
 			WillReturnError(errors.New("database error"))
 
 		createdTransfer, err := transferRepository.Create(ctx, transfer)
@@ -205,13 +347,13 @@ func TestTransferRepository_Create(t *testing.T) {
 
 		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
 
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
 
 		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &transferCreatedAt)
 		assert.Nil(t, err)
 		mock.ExpectExec(GetSQLTransferInsertQuery()).
-			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount, transfer.CreatedAt).
+			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, transfer.Amount.Amount, transfer.Amount.Currency, transfer.DestinationAmount.Amount, transfer.DestinationAmount.Currency, transfer.ExchangeRate, nil, transfer.ReversedAmount.Amount, transfer.Fee.Amount, transfer.Status, transfer.FailureReason, transfer.CreatedAt).
 			WillReturnResult(sqlmock.NewResult(1, 0))
 
 		createdTransfer, err := transferRepository.Create(ctx, transfer)
@@ -219,14 +361,331 @@ func TestTransferRepository_Create(t *testing.T) {
 		assert.Equal(t, entity.Transfer{}, createdTransfer)
 		assert.Equal(t, "unexpected number of affected rows", err.Error())
 	})
+
+	t.Run("Testing Create of a reversal", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.MakeTransfer())
+
+		reversal, err := transfer.Reverse("4f1b9a6e-5d0c-4a55-8d2f-2a8f0a4c1e77", entity.NewMoney(20, entity.BRL), &transferCreatedAt, time.Hour)
+		assert.Nil(t, err)
+
+		reversalOf := transfer.ID
+		mock.ExpectExec(GetSQLTransferInsertQuery()).
+			WithArgs(reversal.ID, reversal.OriginAccount.ID, reversal.DestinationAccount.ID, int64(20), entity.BRL, int64(20), entity.BRL, "1", &reversalOf, int64(0), int64(0), entity.PENDING_TRANSFER, "", reversal.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(1)).
+			WithArgs(reversal.ID, entity.PENDING_TRANSFER, "", reversal.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		_, err = transferRepository.Create(context.Background(), reversal)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTransferRepository_CreateFailed(t *testing.T) {
+	t.Run("Testing Create of a failed transfer records every status change", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(500, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.Fail(errors.New("insufficient balance")))
+
+		mock.ExpectExec(GetSQLTransferInsertQuery()).
+			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, int64(500), entity.BRL, int64(500), entity.BRL, "1", nil, int64(0), int64(0), entity.FAILED_TRANSFER, "insufficient balance", transfer.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(2)).
+			WithArgs(transfer.ID, entity.PENDING_TRANSFER, "", transfer.CreatedAt, transfer.ID, entity.FAILED_TRANSFER, "insufficient balance", transfer.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+
+		_, err = transferRepository.Create(context.Background(), transfer)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create of a transfer with fees records every fee", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.ChargeFees([]entity.TransferFee{
+			{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(0, entity.BRL), Waived: true},
+			{Rule: "percentage", Type: entity.PERCENTAGE_FEE, Amount: entity.NewMoney(5, entity.BRL)},
+		}))
+
+		mock.ExpectExec(GetSQLTransferInsertQuery()).
+			WithArgs(transfer.ID, transfer.OriginAccount.ID, transfer.DestinationAccount.ID, int64(50), entity.BRL, int64(50), entity.BRL, "1", nil, int64(0), int64(5), entity.PENDING_TRANSFER, "", transfer.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(1)).
+			WithArgs(transfer.ID, entity.PENDING_TRANSFER, "", transfer.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferFeeInsertQuery(2)).
+			WithArgs(transfer.ID, "flat", entity.FLAT_FEE, int64(0), entity.BRL, true, transfer.ID, "percentage", entity.PERCENTAGE_FEE, int64(5), entity.BRL, false).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+
+		_, err = transferRepository.Create(context.Background(), transfer)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing Create when the status history insert fails", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transferCreatedAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		transfer, err := entity.NewTransfer("fc84682a-3045-4bdf-b91c-10be19f89452", GetBaseOriginAccount(t), GetBaseDestinationAccount(t), entity.NewMoney(50, entity.BRL), &transferCreatedAt)
+		assert.Nil(t, err)
+
+		mock.ExpectExec(GetSQLTransferInsertQuery()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(1)).
+			WillReturnError(errors.New("database error"))
+
+		createdTransfer, err := transferRepository.Create(context.Background(), transfer)
+		assert.Equal(t, entity.Transfer{}, createdTransfer)
+		assert.Equal(t, "database error", err.Error())
+	})
+}
+
+func TestTransferRepository_FindByID(t *testing.T) {
+	t.Run("Testing FindByID returns the transfer with its status history", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+		reversedAt := createdAt.Add(time.Hour)
+
+		mock.ExpectQuery(GetSQLFindTransferByID()).
+			WithArgs(transferID).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "amount", "currency", "destination_amount", "destination_currency", "exchange_rate", "reversal_of", "reversed_amount", "fee", "status", "failure_reason", "created_at",
+				"origin_account_id", "origin_account_name",
+				"destination_account_id", "destination_account_name",
+			}).AddRow(
+				transferID, 100, "BRL", 100, "BRL", "1", "", 100, 0, "REVERSED", "", createdAt,
+				"2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas",
+				"d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger",
+			))
+		mock.ExpectQuery(GetSQLFindTransferStatusHistory()).
+			WithArgs(transferID).
+			WillReturnRows(sqlmock.NewRows([]string{"status", "reason", "changed_at"}).
+				AddRow("PENDING", "", createdAt).
+				AddRow("COMPLETED", "", createdAt).
+				AddRow("REVERSED", "", reversedAt))
+		mock.ExpectQuery(GetSQLFindTransferFees(1)).
+			WithArgs(transferID).
+			WillReturnRows(sqlmock.NewRows([]string{"transfer_id", "rule", "fee_type", "amount", "currency", "waived"}))
+
+		transfer, err := transferRepository.FindByID(context.Background(), transferID)
+		assert.Nil(t, err)
+		assert.Equal(t, transferID, transfer.ID)
+		assert.Equal(t, entity.REVERSED_TRANSFER, transfer.Status)
+		assert.Equal(t, "Lucas", transfer.OriginAccount.Name)
+		assert.Equal(t, "Roger", transfer.DestinationAccount.Name)
+		assert.Equal(t, []entity.TransferStatusChange{
+			{Status: entity.PENDING_TRANSFER, ChangedAt: &createdAt},
+			{Status: entity.COMPLETED_TRANSFER, ChangedAt: &createdAt},
+			{Status: entity.REVERSED_TRANSFER, ChangedAt: &reversedAt},
+		}, transfer.StatusHistory)
+	})
+
+	t.Run("Testing FindByID when the transfer does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		mock.ExpectQuery(GetSQLFindTransferByID()).
+			WithArgs("fc84682a-3045-4bdf-b91c-10be19f89452").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := transferRepository.FindByID(context.Background(), "fc84682a-3045-4bdf-b91c-10be19f89452")
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer: fc84682a-3045-4bdf-b91c-10be19f89452", err.Error())
+	})
+
+	t.Run("Testing FindByID when the status history query fails", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		mock.ExpectQuery(GetSQLFindTransferByID()).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "amount", "currency", "destination_amount", "destination_currency", "exchange_rate", "reversal_of", "reversed_amount", "fee", "status", "failure_reason", "created_at",
+				"origin_account_id", "origin_account_name",
+				"destination_account_id", "destination_account_name",
+			}).AddRow(
+				"fc84682a-3045-4bdf-b91c-10be19f89452", 100, "BRL", 100, "BRL", "1", "", 0, 0, "COMPLETED", "", time.Now(),
+				"2bd765a6-47bd-4731-9eb2-1e65542f4477", "Lucas",
+				"d18551d3-cf13-49ec-b1dc-741a1f8715f6", "Roger",
+			))
+		mock.ExpectQuery(GetSQLFindTransferStatusHistory()).
+			WillReturnError(errors.New("connection closed"))
+
+		_, err := transferRepository.FindByID(context.Background(), "fc84682a-3045-4bdf-b91c-10be19f89452")
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func TestTransferRepository_UpdateStatus(t *testing.T) {
+	t.Run("Testing UpdateStatus saves the status and its last change", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		reversedAt := time.Date(2023, 8, 5, 10, 55, 00, 00, time.UTC)
+		transfer := &entity.Transfer{
+			ID:     "fc84682a-3045-4bdf-b91c-10be19f89452",
+			Status: entity.REVERSED_TRANSFER,
+			StatusHistory: []entity.TransferStatusChange{
+				{Status: entity.REVERSED_TRANSFER, ChangedAt: &reversedAt},
+			},
+		}
+
+		mock.ExpectExec(GetSQLUpdateTransferStatus()).
+			WithArgs(entity.REVERSED_TRANSFER, "", transfer.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(GetSQLTransferStatusHistoryInsertQuery(1)).
+			WithArgs(transfer.ID, entity.REVERSED_TRANSFER, "", &reversedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := transferRepository.UpdateStatus(context.Background(), transfer, db)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing UpdateStatus when no row is updated", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transfer := &entity.Transfer{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", Status: entity.REVERSED_TRANSFER}
+
+		mock.ExpectExec(GetSQLUpdateTransferStatus()).
+			WithArgs(entity.REVERSED_TRANSFER, "", transfer.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := transferRepository.UpdateStatus(context.Background(), transfer)
+		assert.Equal(t, "unexpected number of affected rows", err.Error())
+	})
+}
+
+func TestTransferRepository_FindByIDForUpdate(t *testing.T) {
+	t.Run("Testing FindByIDForUpdate when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transferID := "fc84682a-3045-4bdf-b91c-10be19f89452"
+		createdAt := time.Date(2023, 8, 5, 9, 55, 00, 00, time.UTC)
+
+		mock.ExpectQuery(GetSQLFindTransferByIDForUpdate()).
+			WithArgs(transferID).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "origin_account_id", "destination_account_id", "amount", "currency", "destination_amount", "destination_currency",
+				"exchange_rate", "reversal_of", "reversed_amount", "fee", "status", "failure_reason", "created_at",
+			}).AddRow(
+				transferID, "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", 100, "BRL", 20, "USD",
+				"0.2", "", 40, 0, "COMPLETED", "", createdAt,
+			))
+
+		transfer, err := transferRepository.FindByIDForUpdate(context.Background(), transferID, db)
+		assert.Nil(t, err)
+		assert.Equal(t, transferID, transfer.ID)
+		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", transfer.OriginAccount.ID)
+		assert.Equal(t, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", transfer.DestinationAccount.ID)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), transfer.Amount)
+		assert.Equal(t, entity.NewMoney(20, entity.USD), transfer.DestinationAmount)
+		assert.Equal(t, entity.NewMoney(40, entity.BRL), transfer.ReversedAmount)
+		assert.Equal(t, &createdAt, transfer.CreatedAt)
+	})
+
+	t.Run("Testing FindByIDForUpdate when the transfer does not exist", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		mock.ExpectQuery(GetSQLFindTransferByIDForUpdate()).
+			WithArgs("fc84682a-3045-4bdf-b91c-10be19f89452").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := transferRepository.FindByIDForUpdate(context.Background(), "fc84682a-3045-4bdf-b91c-10be19f89452")
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer: fc84682a-3045-4bdf-b91c-10be19f89452", err.Error())
+	})
+}
+
+func TestTransferRepository_UpdateReversedAmount(t *testing.T) {
+	t.Run("Testing UpdateReversedAmount when successful", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transfer := &entity.Transfer{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", ReversedAmount: entity.NewMoney(40, entity.BRL)}
+
+		mock.ExpectExec(GetSQLUpdateTransferReversedAmount()).
+			WithArgs(int64(40), transfer.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := transferRepository.UpdateReversedAmount(context.Background(), transfer, db)
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testing UpdateReversedAmount when no row is updated", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		transferRepository := database.NewTransferRepository(db)
+
+		transfer := &entity.Transfer{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", ReversedAmount: entity.NewMoney(40, entity.BRL)}
+
+		mock.ExpectExec(GetSQLUpdateTransferReversedAmount()).
+			WithArgs(int64(40), transfer.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := transferRepository.UpdateReversedAmount(context.Background(), transfer)
+		assert.Equal(t, "unexpected number of affected rows", err.Error())
+	})
 }
 
 func GetBaseOriginAccount(t *testing.T) *entity.Account {
 	originAccountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 	originAccountName := "lucas"
 	originAccountCPF := "52849254088"
-	originAccountsecret := "4578405"
-	originAccountBalance := 100
+	originAccountsecret := "lucas4578405"
+	originAccountBalance := entity.NewMoney(100, entity.BRL)
 	originAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	originAccount, err := entity.NewAccount(originAccountID, originAccountName, originAccountCPF, originAccountsecret, originAccountBalance, &originAccountCreatedAt)
 
@@ -239,8 +698,8 @@ func GetBaseDestinationAccount(t *testing.T) *entity.Account {
 	destionationAccountID := "d18551d3-cf13-49ec-b1dc-741a1f8715f6"
 	destionationAccountName := "joao"
 	destionationAccountCPF := "35768297090"
-	destionationAccountSecret := "744637"
-	destionationAccountBalance := 200
+	destionationAccountSecret := "jaque744637"
+	destionationAccountBalance := entity.NewMoney(200, entity.BRL)
 	destionationAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	destinationAccount, err := entity.NewAccount(destionationAccountID, destionationAccountName, destionationAccountCPF, destionationAccountSecret, destionationAccountBalance, &destionationAccountCreatedAt)
 
@@ -248,3 +707,109 @@ func GetBaseDestinationAccount(t *testing.T) *entity.Account {
 	assert.NotNil(t, destinationAccount)
 	return destinationAccount
 }
+
+func GetSQLSumSentTransfers() string {
+	return regexp.QuoteMeta("SELECT COALESCE(SUM(amount), 0) FROM transfer WHERE origin_account_id = ? AND created_at >= ? AND reversal_of IS NULL AND status IN (?, ?)")
+}
+
+func TestTransferRepository_SumSent(t *testing.T) {
+	since := time.Date(2023, 8, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Testing SumSent counts the completed transfers in the currency of the account", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLSumSentTransfers()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", since, entity.COMPLETED_TRANSFER, entity.REVERSED_TRANSFER).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1500))
+
+		sum, err := database.NewTransferRepository(db).SumSent(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.USD, since, db)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(1500, entity.USD), sum)
+	})
+
+	t.Run("Testing SumSent when QueryRowContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLSumSentTransfers()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewTransferRepository(db).SumSent(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.BRL, since)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func GetSQLCountSentTransfers() string {
+	return regexp.QuoteMeta("SELECT COUNT(*) FROM transfer WHERE origin_account_id = ? AND created_at >= ? AND reversal_of IS NULL AND status IN (?, ?)")
+}
+
+func TestTransferRepository_CountSent(t *testing.T) {
+	since := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Testing CountSent counts the completed transfers of the account", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLCountSentTransfers()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", since, entity.COMPLETED_TRANSFER, entity.REVERSED_TRANSFER).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		sent, err := database.NewTransferRepository(db).CountSent(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", since, db)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, sent)
+	})
+
+	t.Run("Testing CountSent when QueryRowContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLCountSentTransfers()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewTransferRepository(db).CountSent(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", since)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
+
+func GetSQLFindFrequentDestinations() string {
+	return regexp.QuoteMeta("SELECT t.destination_account_id, a.name, COUNT(*) AS transfers, MAX(t.created_at) AS last_transfer_at FROM transfer t INNER JOIN account a ON a.id = t.destination_account_id WHERE t.origin_account_id = ? AND t.created_at >= ? AND t.reversal_of IS NULL AND t.status IN (?, ?) GROUP BY t.destination_account_id, a.name HAVING COUNT(*) >= ? ORDER BY transfers DESC, last_transfer_at DESC LIMIT ?")
+}
+
+func TestTransferRepository_FindFrequentDestinations(t *testing.T) {
+	since := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	lastTransferAt := time.Date(2023, 8, 10, 9, 30, 0, 0, time.UTC)
+
+	t.Run("Testing FindFrequentDestinations lists the accounts that received the most transfers", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindFrequentDestinations()).
+			WithArgs("2bd765a6-47bd-4731-9eb2-1e65542f4477", since, entity.COMPLETED_TRANSFER, entity.REVERSED_TRANSFER, entity.FREQUENT_DESTINATION_MIN_TRANSFERS, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"destination_account_id", "name", "transfers", "last_transfer_at"}).
+				AddRow("d18551d3-cf13-49ec-b1dc-741a1f8715f6", "joao", 4, lastTransferAt))
+
+		destinations, err := database.NewTransferRepository(db).FindFrequentDestinations(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", since, 5)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []entity.FrequentDestination{
+			{AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "joao", Transfers: 4, LastTransferAt: &lastTransferAt},
+		}, destinations)
+	})
+
+	t.Run("Testing FindFrequentDestinations when QueryContext returns an error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectQuery(GetSQLFindFrequentDestinations()).WillReturnError(errors.New("connection closed"))
+
+		_, err := database.NewTransferRepository(db).FindFrequentDestinations(context.Background(), "2bd765a6-47bd-4731-9eb2-1e65542f4477", since, 5)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileExchangeRateProvider quotes from a JSON file kept up to date by another process. The
+// file is read again whenever its modification time changes, and a file that cannot be read
+// or parsed leaves no rate available instead of the previous ones.
+type FileExchangeRateProvider struct {
+	mu       sync.Mutex
+	path     string
+	modTime  time.Time
+	provider *StaticExchangeRateProvider
+	err      error
+}
+
+type rateFile struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Rates     []struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Rate string `json:"rate"`
+	} `json:"rates"`
+}
+
+func NewFileExchangeRateProvider(path string) *FileExchangeRateProvider {
+	return &FileExchangeRateProvider{
+		path: path,
+	}
+}
+
+func (p *FileExchangeRateProvider) FindRate(ctx context.Context, from entity.Currency, to entity.Currency) (entity.ExchangeRate, error) {
+	provider, err := p.load()
+	if err != nil {
+		return entity.ExchangeRate{}, err
+	}
+
+	return provider.FindRate(ctx, from, to)
+}
+
+func (p *FileExchangeRateProvider) load() (*StaticExchangeRateProvider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	if (p.provider != nil || p.err != nil) && info.ModTime().Equal(p.modTime) {
+		return p.provider, p.err
+	}
+
+	p.modTime = info.ModTime()
+	p.provider, p.err = readRateFile(p.path)
+
+	return p.provider, p.err
+}
+
+func readRateFile(path string) (*StaticExchangeRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	var file rateFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(fmt.Sprintf("exchange rate file is invalid: %s", err.Error()))
+	}
+
+	rates := make([]entity.ExchangeRate, 0, len(file.Rates))
+	for _, rate := range file.Rates {
+		exchangeRate, err := entity.NewExchangeRate(entity.Currency(rate.From), entity.Currency(rate.To), rate.Rate, &file.UpdatedAt)
+		if err != nil {
+			return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(fmt.Sprintf("exchange rate file is invalid: %s", err.Error()))
+		}
+
+		rates = append(rates, *exchangeRate)
+	}
+
+	return NewStaticExchangeRateProvider(rates...), nil
+}
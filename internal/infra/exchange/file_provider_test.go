@@ -0,0 +1,73 @@
+package exchange_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/exchange"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func WriteRateFile(t *testing.T, path string, content string, modTime time.Time) {
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	assert.Nil(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestFileExchangeRateProvider_FindRate(t *testing.T) {
+	t.Run("Testing FindRate reads the rates of the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		WriteRateFile(t, path, `{"updated_at":"2023-08-05T08:00:00Z","rates":[{"from":"USD","to":"BRL","rate":"4.95"}]}`, time.Now())
+
+		exchangeRate, err := exchange.NewFileExchangeRateProvider(path).FindRate(context.Background(), entity.USD, entity.BRL)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "4.95", exchangeRate.Rate)
+		assert.Equal(t, time.Date(2023, 8, 5, 8, 00, 00, 00, time.UTC), *exchangeRate.UpdatedAt)
+	})
+
+	t.Run("Testing FindRate reloads the file when it changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		WriteRateFile(t, path, `{"updated_at":"2023-08-05T08:00:00Z","rates":[{"from":"USD","to":"BRL","rate":"4.95"}]}`, time.Now().Add(-time.Minute))
+
+		provider := exchange.NewFileExchangeRateProvider(path)
+		exchangeRate, err := provider.FindRate(context.Background(), entity.USD, entity.BRL)
+		assert.Nil(t, err)
+		assert.Equal(t, "4.95", exchangeRate.Rate)
+
+		WriteRateFile(t, path, `{"updated_at":"2023-08-05T09:00:00Z","rates":[{"from":"USD","to":"BRL","rate":"4.97"}]}`, time.Now())
+
+		exchangeRate, err = provider.FindRate(context.Background(), entity.USD, entity.BRL)
+		assert.Nil(t, err)
+		assert.Equal(t, "4.97", exchangeRate.Rate)
+	})
+
+	t.Run("Testing FindRate when the pair is not in the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		WriteRateFile(t, path, `{"updated_at":"2023-08-05T08:00:00Z","rates":[]}`, time.Now())
+
+		_, err := exchange.NewFileExchangeRateProvider(path).FindRate(context.Background(), entity.USD, entity.BRL)
+
+		assert.Equal(t, "exchange rate from USD to BRL is not available", err.Error())
+	})
+
+	t.Run("Testing FindRate when the file has an invalid rate", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		WriteRateFile(t, path, `{"updated_at":"2023-08-05T08:00:00Z","rates":[{"from":"USD","to":"BRL","rate":"4,95"}]}`, time.Now())
+
+		_, err := exchange.NewFileExchangeRateProvider(path).FindRate(context.Background(), entity.USD, entity.BRL)
+
+		assert.Equal(t, "exchange rate file is invalid: exchange rate is invalid: 4,95", err.Error())
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing FindRate when the file does not exist", func(t *testing.T) {
+		_, err := exchange.NewFileExchangeRateProvider(filepath.Join(t.TempDir(), "rates.json")).FindRate(context.Background(), entity.USD, entity.BRL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+// StaticExchangeRateProvider quotes from a fixed table of rates. Each pair has to be in the
+// table, the inverse rate is never derived since it would not be exact.
+type StaticExchangeRateProvider struct {
+	rates map[string]entity.ExchangeRate
+}
+
+func NewStaticExchangeRateProvider(rates ...entity.ExchangeRate) *StaticExchangeRateProvider {
+	table := make(map[string]entity.ExchangeRate, len(rates))
+	for _, rate := range rates {
+		table[pairKey(rate.From, rate.To)] = rate
+	}
+
+	return &StaticExchangeRateProvider{
+		rates: table,
+	}
+}
+
+func (p *StaticExchangeRateProvider) FindRate(ctx context.Context, from entity.Currency, to entity.Currency) (entity.ExchangeRate, error) {
+	rate, ok := p.rates[pairKey(from, to)]
+	if !ok {
+		return entity.ExchangeRate{}, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("exchange rate from %s to %s is not available", from, to))
+	}
+
+	return rate, nil
+}
+
+func pairKey(from entity.Currency, to entity.Currency) string {
+	return string(from) + "/" + string(to)
+}
@@ -0,0 +1,33 @@
+package exchange_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/exchange"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticExchangeRateProvider_FindRate(t *testing.T) {
+	updatedAt := time.Date(2023, 8, 5, 8, 00, 00, 00, time.UTC)
+	usdToBRL, err := entity.NewExchangeRate(entity.USD, entity.BRL, "4.95", &updatedAt)
+	assert.Nil(t, err)
+
+	provider := exchange.NewStaticExchangeRateProvider(*usdToBRL)
+
+	t.Run("Testing FindRate when the pair is in the table", func(t *testing.T) {
+		exchangeRate, err := provider.FindRate(context.Background(), entity.USD, entity.BRL)
+
+		assert.Nil(t, err)
+		assert.Equal(t, *usdToBRL, exchangeRate)
+	})
+
+	t.Run("Testing FindRate does not derive the inverse rate", func(t *testing.T) {
+		_, err := provider.FindRate(context.Background(), entity.BRL, entity.USD)
+
+		assert.Equal(t, "exchange rate from BRL to USD is not available", err.Error())
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"lucassantoss1701/bank/internal/entity"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogNotifier writes each notification as a JSON line instead of sending it. It is meant for
+// local use, where there is no e-mail or SMS provider configured.
+type LogNotifier struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+type logNotification struct {
+	AccountID string     `json:"account_id"`
+	Subject   string     `json:"subject"`
+	Message   string     `json:"message"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func NewLogNotifier(writer io.Writer) *LogNotifier {
+	return &LogNotifier{
+		writer: writer,
+	}
+}
+
+// NewFileNotifier appends the notifications to the file, which is created readable only by its
+// owner since the notifications carry tokens.
+func NewFileNotifier(path string) (*LogNotifier, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLogNotifier(file), nil
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification *entity.Notification) error {
+	line, err := json.Marshal(logNotification{
+		AccountID: notification.AccountID,
+		Subject:   notification.Subject,
+		Message:   notification.Message,
+		CreatedAt: notification.CreatedAt,
+	})
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err = n.writer.Write(append(line, '\n'))
+	if err != nil {
+		return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package notification_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/notification"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetBaseNotification(t *testing.T) *entity.Notification {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	baseNotification, err := entity.NewNotification("2bd765a6-47bd-4731-9eb2-1e65542f4477", "Secret reset", "Use the token abc", &createdAt)
+
+	assert.Nil(t, err)
+	return baseNotification
+}
+
+func TestLogNotifier_Notify(t *testing.T) {
+	t.Run("Testing Notify writes a JSON line", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		err := notification.NewLogNotifier(&buffer).Notify(context.Background(), GetBaseNotification(t))
+
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"account_id":"2bd765a6-47bd-4731-9eb2-1e65542f4477","subject":"Secret reset","message":"Use the token abc","created_at":"2023-08-05T08:22:00Z"}`, buffer.String())
+		assert.Equal(t, byte('\n'), buffer.Bytes()[buffer.Len()-1])
+	})
+
+	t.Run("Testing Notify appends to the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notifications.log")
+
+		fileNotifier, err := notification.NewFileNotifier(path)
+		assert.Nil(t, err)
+
+		assert.Nil(t, fileNotifier.Notify(context.Background(), GetBaseNotification(t)))
+		assert.Nil(t, fileNotifier.Notify(context.Background(), GetBaseNotification(t)))
+
+		content, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, bytes.Count(content, []byte("\n")))
+
+		info, err := os.Stat(path)
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+}
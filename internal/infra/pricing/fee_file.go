@@ -0,0 +1,103 @@
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"os"
+	"strings"
+)
+
+type feeFile struct {
+	Rules []struct {
+		Name         string `json:"name"`
+		Type         string `json:"type"`
+		Currency     string `json:"currency"`
+		AccountRole  string `json:"account_role"`
+		Amount       string `json:"amount"`
+		Rate         string `json:"rate"`
+		Minimum      string `json:"minimum"`
+		Maximum      string `json:"maximum"`
+		FreePerMonth int    `json:"free_per_month"`
+		Tiers        []struct {
+			UpTo   string `json:"up_to"`
+			Amount string `json:"amount"`
+			Rate   string `json:"rate"`
+		} `json:"tiers"`
+	} `json:"rules"`
+}
+
+// ReadFeeScheduleFile reads the fee rules from a JSON file. The amounts are decimal strings such
+// as "2.50" in the currency of their rule, and the amounts left out are zero.
+func ReadFeeScheduleFile(path string) (*entity.FeeSchedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	var file feeFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(fmt.Sprintf("fee file is invalid: %s", err.Error()))
+	}
+
+	rules := make([]entity.FeeRule, 0, len(file.Rules))
+	for _, rule := range file.Rules {
+		feeRule := entity.FeeRule{
+			Name:         rule.Name,
+			Type:         entity.FeeType(strings.ToUpper(rule.Type)),
+			Currency:     entity.Currency(strings.ToUpper(rule.Currency)),
+			AccountRole:  entity.Role(strings.ToUpper(rule.AccountRole)),
+			Rate:         rule.Rate,
+			FreePerMonth: rule.FreePerMonth,
+		}
+
+		amounts := []*entity.Money{&feeRule.Amount, &feeRule.Minimum, &feeRule.Maximum}
+		for i, value := range []string{rule.Amount, rule.Minimum, rule.Maximum} {
+			*amounts[i], err = parseAmount(value, rule.Currency)
+			if err != nil {
+				return nil, invalidRule(rule.Name, err)
+			}
+		}
+
+		for _, tier := range rule.Tiers {
+			feeTier := entity.FeeTier{Rate: tier.Rate}
+
+			feeTier.Amount, err = parseAmount(tier.Amount, rule.Currency)
+			if err != nil {
+				return nil, invalidRule(rule.Name, err)
+			}
+
+			if tier.UpTo != "" {
+				upTo, err := parseAmount(tier.UpTo, rule.Currency)
+				if err != nil {
+					return nil, invalidRule(rule.Name, err)
+				}
+				feeTier.UpTo = &upTo
+			}
+
+			feeRule.Tiers = append(feeRule.Tiers, feeTier)
+		}
+
+		rules = append(rules, feeRule)
+	}
+
+	schedule, err := entity.NewFeeSchedule(rules...)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(fmt.Sprintf("fee file is invalid: %s", err.Error()))
+	}
+
+	return schedule, nil
+}
+
+func parseAmount(value string, currency string) (entity.Money, error) {
+	if value == "" {
+		value = "0"
+	}
+
+	return entity.ParseMoney(value, currency)
+}
+
+func invalidRule(name string, err error) error {
+	return entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(fmt.Sprintf("fee file is invalid: fee rule %s: %s", name, err.Error()))
+}
@@ -0,0 +1,71 @@
+package pricing_test
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/pricing"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func WriteFeeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "fees.json")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestReadFeeScheduleFile(t *testing.T) {
+	t.Run("Testing ReadFeeScheduleFile reads every type of rule", func(t *testing.T) {
+		path := WriteFeeFile(t, `{"rules":[
+			{"name":"flat","type":"flat","currency":"BRL","amount":"1.00","free_per_month":5},
+			{"name":"percentage","type":"percentage","currency":"brl","account_role":"customer","rate":"0.005","minimum":"0.50","maximum":"10"},
+			{"name":"tiered","type":"tiered","currency":"USD","tiers":[{"up_to":"100","amount":"0.50"},{"amount":"1.00","rate":"0.001"}]}
+		]}`)
+
+		schedule, err := pricing.ReadFeeScheduleFile(path)
+
+		assert.Nil(t, err)
+		assert.Len(t, schedule.Rules, 3)
+		assert.Equal(t, entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(100, entity.BRL), Minimum: entity.NewMoney(0, entity.BRL), Maximum: entity.NewMoney(0, entity.BRL), FreePerMonth: 5}, schedule.Rules[0])
+		assert.Equal(t, entity.CUSTOMER_ROLE, schedule.Rules[1].AccountRole)
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), schedule.Rules[1].Minimum)
+		assert.Equal(t, entity.NewMoney(1000, entity.BRL), schedule.Rules[1].Maximum)
+		assert.Equal(t, entity.NewMoney(10000, entity.USD), *schedule.Rules[2].Tiers[0].UpTo)
+		assert.Nil(t, schedule.Rules[2].Tiers[1].UpTo)
+		assert.Equal(t, "0.001", schedule.Rules[2].Tiers[1].Rate)
+	})
+
+	t.Run("Testing ReadFeeScheduleFile when an amount is invalid", func(t *testing.T) {
+		path := WriteFeeFile(t, `{"rules":[{"name":"flat","type":"flat","currency":"BRL","amount":"1,00"}]}`)
+
+		_, err := pricing.ReadFeeScheduleFile(path)
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "fee file is invalid: fee rule flat: amount is invalid: 1,00", err.Error())
+	})
+
+	t.Run("Testing ReadFeeScheduleFile when a rule is invalid", func(t *testing.T) {
+		path := WriteFeeFile(t, `{"rules":[{"name":"flat","type":"flat","currency":"BRL"}]}`)
+
+		_, err := pricing.ReadFeeScheduleFile(path)
+
+		assert.Equal(t, "fee file is invalid: fee rule flat: amount must be greater than zero in BRL", err.Error())
+	})
+
+	t.Run("Testing ReadFeeScheduleFile when the file is not JSON", func(t *testing.T) {
+		path := WriteFeeFile(t, `rules`)
+
+		_, err := pricing.ReadFeeScheduleFile(path)
+
+		assert.Contains(t, err.Error(), "fee file is invalid")
+	})
+
+	t.Run("Testing ReadFeeScheduleFile when the file does not exist", func(t *testing.T) {
+		_, err := pricing.ReadFeeScheduleFile(filepath.Join(t.TempDir(), "fees.json"))
+
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
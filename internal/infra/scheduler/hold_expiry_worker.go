@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HoldExpiryWorker sweeps the holds that passed their expiry once per interval, inside the
+// API process. A stale hold keeps its funds unavailable until the next sweep, so the interval
+// bounds how late the funds come back.
+type HoldExpiryWorker struct {
+	useCase  usecase.IExpireHoldsUseCase
+	interval time.Duration
+	logger   logrus.FieldLogger
+}
+
+func NewHoldExpiryWorker(useCase usecase.IExpireHoldsUseCase, interval time.Duration, logger logrus.FieldLogger) *HoldExpiryWorker {
+	return &HoldExpiryWorker{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run sweeps the holds on every tick until ctx is done.
+func (w *HoldExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.RunOnce(ctx, now)
+		}
+	}
+}
+
+func (w *HoldExpiryWorker) RunOnce(ctx context.Context, now time.Time) {
+	output, err := w.useCase.Execute(ctx, usecase.NewExpireHoldsUseCaseInput(now))
+	if err != nil {
+		w.logger.WithError(err).Error("hold expiry failed")
+		return
+	}
+
+	if output.Expired > 0 {
+		w.logger.WithField("expired", output.Expired).Info("holds expired")
+	}
+}
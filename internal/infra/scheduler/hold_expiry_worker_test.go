@@ -0,0 +1,54 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/infra/scheduler"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestHoldExpiryWorker_RunOnce(t *testing.T) {
+	now := time.Date(2023, 8, 21, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing RunOnce logs the holds expired", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewExpireHoldsUseCaseMock()
+		useCase.On("Execute", ctx, usecase.NewExpireHoldsUseCaseInput(now)).Return(&usecase.ExpireHoldsUseCaseOutput{Expired: 2}, nil)
+
+		scheduler.NewHoldExpiryWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `msg="holds expired" expired=2`)
+	})
+
+	t.Run("Testing RunOnce logs nothing when no hold expired", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewExpireHoldsUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.ExpireHoldsUseCaseOutput{}, nil)
+
+		scheduler.NewHoldExpiryWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("Testing RunOnce logs the error of the sweep", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewExpireHoldsUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return((*usecase.ExpireHoldsUseCaseOutput)(nil), errors.New("connection closed"))
+
+		scheduler.NewHoldExpiryWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `level=error msg="hold expiry failed" error="connection closed"`)
+	})
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// OverdraftInterestWorker accrues the interest of the overdrawn accounts once per interval,
+// inside the API process. Each account is charged once per day however often it runs, so the
+// interval only bounds how late in the day the interest is charged.
+type OverdraftInterestWorker struct {
+	useCase  usecase.IAccrueOverdraftInterestUseCase
+	interval time.Duration
+	logger   logrus.FieldLogger
+}
+
+func NewOverdraftInterestWorker(useCase usecase.IAccrueOverdraftInterestUseCase, interval time.Duration, logger logrus.FieldLogger) *OverdraftInterestWorker {
+	return &OverdraftInterestWorker{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run accrues the interest when it starts and on every tick until ctx is done.
+func (w *OverdraftInterestWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.RunOnce(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.RunOnce(ctx, now)
+		}
+	}
+}
+
+func (w *OverdraftInterestWorker) RunOnce(ctx context.Context, now time.Time) {
+	output, err := w.useCase.Execute(ctx, usecase.NewAccrueOverdraftInterestUseCaseInput(now))
+	if err != nil {
+		w.logger.WithError(err).Error("overdraft interest accrual failed")
+		return
+	}
+
+	if output.Accrued > 0 {
+		w.logger.WithFields(logrus.Fields{
+			"accrual_date": output.AccrualDate,
+			"accrued":      output.Accrued,
+		}).Info("overdraft interest accrued")
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/infra/scheduler"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestOverdraftInterestWorker_RunOnce(t *testing.T) {
+	now := time.Date(2023, 8, 14, 3, 00, 00, 00, time.UTC)
+
+	t.Run("Testing RunOnce logs the accounts charged", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewAccrueOverdraftInterestUseCaseMock()
+		useCase.On("Execute", ctx, usecase.NewAccrueOverdraftInterestUseCaseInput(now)).Return(&usecase.AccrueOverdraftInterestUseCaseOutput{AccrualDate: "2023-08-14", Accrued: 3}, nil)
+
+		scheduler.NewOverdraftInterestWorker(useCase, time.Hour, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `msg="overdraft interest accrued" accrual_date=2023-08-14 accrued=3`)
+	})
+
+	t.Run("Testing RunOnce logs nothing when no account was charged", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewAccrueOverdraftInterestUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.AccrueOverdraftInterestUseCaseOutput{AccrualDate: "2023-08-14"}, nil)
+
+		scheduler.NewOverdraftInterestWorker(useCase, time.Hour, logger).RunOnce(ctx, now)
+
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("Testing RunOnce logs the error of the run", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewAccrueOverdraftInterestUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return((*usecase.AccrueOverdraftInterestUseCaseOutput)(nil), errors.New("connection closed"))
+
+		scheduler.NewOverdraftInterestWorker(useCase, time.Hour, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `level=error msg="overdraft interest accrual failed" error="connection closed"`)
+	})
+}
+
+func TestOverdraftInterestWorker_Run(t *testing.T) {
+	t.Run("Testing Run accrues when it starts and stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		logger, _ := GetLogger()
+
+		useCase := usecaseMock.NewAccrueOverdraftInterestUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.AccrueOverdraftInterestUseCaseOutput{}, nil).Run(func(args testify.Arguments) {
+			cancel()
+		})
+
+		done := make(chan struct{})
+		go func() {
+			scheduler.NewOverdraftInterestWorker(useCase, time.Hour, logger).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("worker did not stop")
+		}
+
+		useCase.AssertNumberOfCalls(t, "Execute", 1)
+	})
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ScheduledTransferWorker runs the due scheduled transfers once per interval, inside the API
+// process. Several instances can run together since each occurrence is claimed before it runs.
+type ScheduledTransferWorker struct {
+	useCase  usecase.IRunScheduledTransfersUseCase
+	interval time.Duration
+	logger   logrus.FieldLogger
+}
+
+func NewScheduledTransferWorker(useCase usecase.IRunScheduledTransfersUseCase, interval time.Duration, logger logrus.FieldLogger) *ScheduledTransferWorker {
+	return &ScheduledTransferWorker{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run executes the due transfers on every tick until ctx is done.
+func (w *ScheduledTransferWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.RunOnce(ctx, now)
+		}
+	}
+}
+
+func (w *ScheduledTransferWorker) RunOnce(ctx context.Context, now time.Time) {
+	output, err := w.useCase.Execute(ctx, usecase.NewRunScheduledTransfersUseCaseInput(now))
+	if err != nil {
+		w.logger.WithError(err).Error("scheduled transfers run failed")
+		return
+	}
+
+	if output.Succeeded > 0 || output.Failed > 0 {
+		w.logger.WithFields(logrus.Fields{
+			"succeeded": output.Succeeded,
+			"failed":    output.Failed,
+		}).Info("scheduled transfers executed")
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/infra/scheduler"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetLogger() (*logrus.Logger, *bytes.Buffer) {
+	buffer := &bytes.Buffer{}
+
+	logger := logrus.New()
+	logger.SetOutput(buffer)
+	logger.SetFormatter(&logrus.TextFormatter{DisableColors: true, DisableTimestamp: true})
+
+	return logger, buffer
+}
+
+func TestScheduledTransferWorker_RunOnce(t *testing.T) {
+	now := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+
+	t.Run("Testing RunOnce logs the transfers executed", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewRunScheduledTransfersUseCaseMock()
+		useCase.On("Execute", ctx, usecase.NewRunScheduledTransfersUseCaseInput(now)).Return(&usecase.RunScheduledTransfersUseCaseOutput{Succeeded: 2, Failed: 1}, nil)
+
+		scheduler.NewScheduledTransferWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `msg="scheduled transfers executed" failed=1 succeeded=2`)
+	})
+
+	t.Run("Testing RunOnce logs nothing when there was nothing due", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewRunScheduledTransfersUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.RunScheduledTransfersUseCaseOutput{}, nil)
+
+		scheduler.NewScheduledTransferWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("Testing RunOnce logs the error of the run", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewRunScheduledTransfersUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return((*usecase.RunScheduledTransfersUseCaseOutput)(nil), errors.New("connection closed"))
+
+		scheduler.NewScheduledTransferWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `level=error msg="scheduled transfers run failed" error="connection closed"`)
+	})
+}
+
+func TestScheduledTransferWorker_Run(t *testing.T) {
+	t.Run("Testing Run executes on every tick until the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		logger, _ := GetLogger()
+
+		useCase := usecaseMock.NewRunScheduledTransfersUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.RunScheduledTransfersUseCaseOutput{}, nil).Run(func(args testify.Arguments) {
+			cancel()
+		})
+
+		done := make(chan struct{})
+		go func() {
+			scheduler.NewScheduledTransferWorker(useCase, time.Millisecond, logger).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("worker did not stop")
+		}
+
+		useCase.AssertCalled(t, "Execute", ctx, testify.Anything)
+	})
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TransferBatchWorker processes the pending transfer batches once per interval, inside the API
+// process. A batch stays pending until the next tick, so the interval bounds how long a client
+// polls before the batch starts.
+type TransferBatchWorker struct {
+	useCase  usecase.IProcessTransferBatchesUseCase
+	interval time.Duration
+	logger   logrus.FieldLogger
+}
+
+func NewTransferBatchWorker(useCase usecase.IProcessTransferBatchesUseCase, interval time.Duration, logger logrus.FieldLogger) *TransferBatchWorker {
+	return &TransferBatchWorker{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run processes the pending batches on every tick until ctx is done.
+func (w *TransferBatchWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.RunOnce(ctx, now)
+		}
+	}
+}
+
+func (w *TransferBatchWorker) RunOnce(ctx context.Context, now time.Time) {
+	output, err := w.useCase.Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+	if err != nil {
+		w.logger.WithError(err).Error("transfer batches processing failed")
+		return
+	}
+
+	if output.Processed > 0 {
+		w.logger.WithFields(logrus.Fields{
+			"batches":   output.Processed,
+			"succeeded": output.Succeeded,
+			"failed":    output.Failed,
+		}).Info("transfer batches processed")
+	}
+
+	// the reason of each aborted batch is kept in its failure_reason
+	if output.Aborted > 0 {
+		w.logger.WithField("batches", output.Aborted).Warn("transfer batches aborted")
+	}
+}
@@ -0,0 +1,67 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/infra/scheduler"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestTransferBatchWorker_RunOnce(t *testing.T) {
+	now := time.Date(2023, 8, 14, 10, 00, 30, 00, time.UTC)
+
+	t.Run("Testing RunOnce logs the batches processed", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewProcessTransferBatchesUseCaseMock()
+		useCase.On("Execute", ctx, usecase.NewProcessTransferBatchesUseCaseInput(now)).Return(&usecase.ProcessTransferBatchesUseCaseOutput{Processed: 2, Succeeded: 5, Failed: 1}, nil)
+
+		scheduler.NewTransferBatchWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `msg="transfer batches processed" batches=2 failed=1 succeeded=5`)
+	})
+
+	t.Run("Testing RunOnce warns about the batches aborted", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewProcessTransferBatchesUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.ProcessTransferBatchesUseCaseOutput{Aborted: 1}, nil)
+
+		scheduler.NewTransferBatchWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `level=warning msg="transfer batches aborted" batches=1`)
+		assert.NotContains(t, buffer.String(), "transfer batches processed")
+	})
+
+	t.Run("Testing RunOnce logs nothing when no batch was pending", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewProcessTransferBatchesUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return(&usecase.ProcessTransferBatchesUseCaseOutput{}, nil)
+
+		scheduler.NewTransferBatchWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("Testing RunOnce logs the error of the processing", func(t *testing.T) {
+		ctx := context.Background()
+		logger, buffer := GetLogger()
+
+		useCase := usecaseMock.NewProcessTransferBatchesUseCaseMock()
+		useCase.On("Execute", ctx, testify.Anything).Return((*usecase.ProcessTransferBatchesUseCaseOutput)(nil), errors.New("connection closed"))
+
+		scheduler.NewTransferBatchWorker(useCase, time.Minute, logger).RunOnce(ctx, now)
+
+		assert.Contains(t, buffer.String(), `level=error msg="transfer batches processing failed" error="connection closed"`)
+	})
+}
@@ -0,0 +1,56 @@
+package security_test
+
+import (
+	"lucassantoss1701/bank/internal/infra/security"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESCipher(t *testing.T) {
+	t.Run("Testing Encrypt and Decrypt", func(t *testing.T) {
+		cipher, err := security.NewAESCipher("mfa")
+		assert.Nil(t, err)
+
+		ciphertext, err := cipher.Encrypt("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+		assert.Nil(t, err)
+		assert.NotContains(t, ciphertext, "GEZDGNBVGY3TQOJQ")
+
+		plaintext, err := cipher.Decrypt(ciphertext)
+		assert.Nil(t, err)
+		assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", plaintext)
+	})
+
+	t.Run("Testing Encrypt uses a new nonce every time", func(t *testing.T) {
+		cipher, _ := security.NewAESCipher("mfa")
+
+		first, _ := cipher.Encrypt("secret")
+		second, _ := cipher.Encrypt("secret")
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Testing Decrypt with another key", func(t *testing.T) {
+		cipher, _ := security.NewAESCipher("mfa")
+		other, _ := security.NewAESCipher("other")
+
+		ciphertext, _ := cipher.Encrypt("secret")
+		_, err := other.Decrypt(ciphertext)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Testing Decrypt with a short ciphertext", func(t *testing.T) {
+		cipher, _ := security.NewAESCipher("mfa")
+
+		_, err := cipher.Decrypt("YWJj")
+
+		assert.Equal(t, "ciphertext is too short", err.Error())
+	})
+
+	t.Run("Testing NewAESCipher without key", func(t *testing.T) {
+		_, err := security.NewAESCipher("")
+
+		assert.Equal(t, "encryption key cannot be empty", err.Error())
+	})
+}
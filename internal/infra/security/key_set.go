@@ -0,0 +1,281 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// verificationKey is a public key accepted to verify tokens, it is bound to a single algorithm
+// so a token cannot choose how it is verified.
+type verificationKey struct {
+	publicKey crypto.PublicKey
+	method    jwt.SigningMethod
+}
+
+// KeySet signs access tokens with the current private key and verifies them with any of the
+// active public keys. Keeping the previous public keys active lets the signing key be rotated
+// without invalidating the tokens already issued.
+type KeySet struct {
+	signingKeyID     string
+	signingKey       crypto.PrivateKey
+	signingMethod    jwt.SigningMethod
+	verificationKeys map[string]verificationKey
+}
+
+// LoadKeySet reads the PEM encoded private key used to sign tokens and the public keys that are
+// still accepted, given as "kid=path" separated by commas. RSA keys sign with RS256 and P-256
+// keys with ES256. Without a signing key file an ephemeral key is generated.
+func LoadKeySet(signingKeyID string, signingKeyFile string, verificationKeys string) (*KeySet, error) {
+	var keySet *KeySet
+	var err error
+
+	if signingKeyFile == "" {
+		fmt.Println("[Method: security.LoadKeySet()] JWT_SIGNING_KEY_FILE not set, using an ephemeral signing key, tokens will not survive a restart")
+		keySet, err = GenerateKeySet()
+	} else {
+		keySet, err = readKeySet(signingKeyID, signingKeyFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range strings.Split(verificationKeys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("verification key must be kid=path: %s", entry)
+		}
+
+		pem, err := os.ReadFile(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("error on reading verification key %s: %w", parts[0], err)
+		}
+
+		publicKey, err := parsePublicKey(pem)
+		if err != nil {
+			return nil, err
+		}
+
+		err = keySet.AddVerificationKey(parts[0], publicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keySet, nil
+}
+
+func readKeySet(signingKeyID string, signingKeyFile string) (*KeySet, error) {
+	if signingKeyID == "" {
+		return nil, errors.New("signing key id cannot be empty")
+	}
+
+	pem, err := os.ReadFile(signingKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading signing key: %w", err)
+	}
+
+	signingKey, err := parsePrivateKey(pem)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeySet(signingKeyID, signingKey)
+}
+
+// NewKeySet creates a key set that signs with the given key, its public key is also used
+// for verification.
+func NewKeySet(signingKeyID string, signingKey crypto.PrivateKey) (*KeySet, error) {
+	var publicKey crypto.PublicKey
+	switch key := signingKey.(type) {
+	case *rsa.PrivateKey:
+		publicKey = &key.PublicKey
+	case *ecdsa.PrivateKey:
+		publicKey = &key.PublicKey
+	default:
+		return nil, errors.New("signing key must be an RSA or an ECDSA P-256 key")
+	}
+
+	method, err := signingMethodFor(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeySet{
+		signingKeyID:  signingKeyID,
+		signingKey:    signingKey,
+		signingMethod: method,
+		verificationKeys: map[string]verificationKey{
+			signingKeyID: {publicKey: publicKey, method: method},
+		},
+	}, nil
+}
+
+// GenerateKeySet creates a key set with a new ES256 key, tokens signed by it do not survive
+// a restart, so it is only meant for development and tests.
+func GenerateKeySet() (*KeySet, error) {
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	return NewKeySet("ephemeral-"+base64.RawURLEncoding.EncodeToString(random), signingKey)
+}
+
+func (k *KeySet) AddVerificationKey(keyID string, publicKey crypto.PublicKey) error {
+	if _, ok := k.verificationKeys[keyID]; ok {
+		return fmt.Errorf("duplicated key id: %s", keyID)
+	}
+
+	method, err := signingMethodFor(publicKey)
+	if err != nil {
+		return err
+	}
+
+	k.verificationKeys[keyID] = verificationKey{publicKey: publicKey, method: method}
+	return nil
+}
+
+// Sign signs the claims with the current key and identifies the key in the "kid" header.
+func (k *KeySet) Sign(claims map[string]interface{}) (string, error) {
+	token := jwt.NewWithClaims(k.signingMethod, jwt.MapClaims(claims))
+	token.Header["kid"] = k.signingKeyID
+
+	return token.SignedString(k.signingKey)
+}
+
+// Verify checks the signature with the key named by the "kid" header and only accepts the
+// algorithm of that key.
+func (k *KeySet) Verify(token string) (map[string]interface{}, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		keyID, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("token without kid")
+		}
+
+		key, ok := k.verificationKeys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid: %s", keyID)
+		}
+
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+
+		return key.publicKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS publishes every verification key so other services can validate the tokens.
+func (k *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+
+	for keyID, key := range k.verificationKeys {
+		jwk := JWK{KeyID: keyID, Use: "sig", Algorithm: key.method.Alg()}
+
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = publicKey.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+
+	return jwks
+}
+
+func signingMethodFor(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must have at least 2048 bits")
+		}
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return nil, errors.New("ECDSA keys must use the P-256 curve")
+		}
+		return jwt.SigningMethodES256, nil
+	}
+
+	return nil, errors.New("key must be an RSA or an ECDSA P-256 key")
+}
+
+func parsePrivateKey(pem []byte) (crypto.PrivateKey, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+
+	if key, err := jwt.ParseECPrivateKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("signing key must be a PEM encoded RSA or ECDSA private key")
+}
+
+func parsePublicKey(pem []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+
+	if key, err := jwt.ParseECPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("verification key must be a PEM encoded RSA or ECDSA public key")
+}
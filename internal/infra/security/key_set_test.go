@@ -0,0 +1,221 @@
+package security_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"lucassantoss1701/bank/internal/infra/security"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func GetClaims() map[string]interface{} {
+	return map[string]interface{}{"jti": "fc84682a", "account_id": "8c5f5f5e", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func WritePEM(t *testing.T, blockType string, bytes []byte) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+	assert.Nil(t, err)
+	return path
+}
+
+func TestKeySet_SignAndVerify(t *testing.T) {
+	t.Run("Testing Sign and Verify with an RSA key loaded from a PKCS1 PEM file", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Nil(t, err)
+
+		keySet, err := security.LoadKeySet("rsa-1", WritePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)), "")
+		assert.Nil(t, err)
+
+		token, err := keySet.Sign(GetClaims())
+		assert.Nil(t, err)
+
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		assert.Nil(t, err)
+		assert.Equal(t, "RS256", parsed.Header["alg"])
+		assert.Equal(t, "rsa-1", parsed.Header["kid"])
+
+		claims, err := keySet.Verify(token)
+		assert.Nil(t, err)
+		assert.Equal(t, "8c5f5f5e", claims["account_id"])
+	})
+
+	t.Run("Testing Sign and Verify with an ECDSA key loaded from a PKCS8 PEM file", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		assert.Nil(t, err)
+
+		keySet, err := security.LoadKeySet("ec-1", WritePEM(t, "PRIVATE KEY", der), "")
+		assert.Nil(t, err)
+
+		token, err := keySet.Sign(GetClaims())
+		assert.Nil(t, err)
+
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		assert.Nil(t, err)
+		assert.Equal(t, "ES256", parsed.Header["alg"])
+
+		_, err = keySet.Verify(token)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Verify accepts tokens of a rotated key while it is a verification key", func(t *testing.T) {
+		oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+
+		oldKeySet, err := security.NewKeySet("ec-1", oldKey)
+		assert.Nil(t, err)
+
+		token, err := oldKeySet.Sign(GetClaims())
+		assert.Nil(t, err)
+
+		publicKey, err := x509.MarshalPKIXPublicKey(&oldKey.PublicKey)
+		assert.Nil(t, err)
+
+		newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+
+		der, err := x509.MarshalPKCS8PrivateKey(newKey)
+		assert.Nil(t, err)
+
+		keySet, err := security.LoadKeySet("ec-2", WritePEM(t, "PRIVATE KEY", der), "ec-1="+WritePEM(t, "PUBLIC KEY", publicKey))
+		assert.Nil(t, err)
+
+		_, err = keySet.Verify(token)
+		assert.Nil(t, err)
+
+		withoutOldKey, err := security.NewKeySet("ec-2", newKey)
+		assert.Nil(t, err)
+
+		_, err = withoutOldKey.Verify(token)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Testing Verify rejects a token with an unknown kid", func(t *testing.T) {
+		keySet, err := security.GenerateKeySet()
+		assert.Nil(t, err)
+
+		otherKeySet, err := security.GenerateKeySet()
+		assert.Nil(t, err)
+
+		token, err := otherKeySet.Sign(GetClaims())
+		assert.Nil(t, err)
+
+		_, err = keySet.Verify(token)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Testing Verify rejects a token signed with HS256 using the public key as secret", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Nil(t, err)
+
+		keySet, err := security.NewKeySet("rsa-1", key)
+		assert.Nil(t, err)
+
+		publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		assert.Nil(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(GetClaims()))
+		token.Header["kid"] = "rsa-1"
+		signed, err := token.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+		assert.Nil(t, err)
+
+		_, err = keySet.Verify(signed)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Testing Verify rejects an unsigned token", func(t *testing.T) {
+		keySet, err := security.GenerateKeySet()
+		assert.Nil(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims(GetClaims()))
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		assert.Nil(t, err)
+
+		_, err = keySet.Verify(signed)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestLoadKeySet(t *testing.T) {
+	t.Run("Testing LoadKeySet without signing key file generates an ephemeral key", func(t *testing.T) {
+		keySet, err := security.LoadKeySet("", "", "")
+		assert.Nil(t, err)
+		assert.Len(t, keySet.JWKS().Keys, 1)
+	})
+
+	t.Run("Testing LoadKeySet with a signing key that does not exist", func(t *testing.T) {
+		_, err := security.LoadKeySet("ec-1", filepath.Join(t.TempDir(), "missing.pem"), "")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Testing LoadKeySet with a malformed verification key entry", func(t *testing.T) {
+		_, err := security.LoadKeySet("", "", "ec-1")
+		assert.EqualError(t, err, "verification key must be kid=path: ec-1")
+	})
+
+	t.Run("Testing LoadKeySet with an RSA key smaller than 2048 bits", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		assert.Nil(t, err)
+
+		_, err = security.LoadKeySet("rsa-1", WritePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)), "")
+		assert.EqualError(t, err, "RSA keys must have at least 2048 bits")
+	})
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	t.Run("Testing JWKS publishes the RSA and ECDSA verification keys", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Nil(t, err)
+
+		keySet, err := security.NewKeySet("rsa-1", rsaKey)
+		assert.Nil(t, err)
+
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+
+		err = keySet.AddVerificationKey("ec-1", &ecKey.PublicKey)
+		assert.Nil(t, err)
+
+		jwks := keySet.JWKS()
+		assert.Len(t, jwks.Keys, 2)
+
+		ec := jwks.Keys[0]
+		assert.Equal(t, "ec-1", ec.KeyID)
+		assert.Equal(t, "EC", ec.KeyType)
+		assert.Equal(t, "ES256", ec.Algorithm)
+		assert.Equal(t, "P-256", ec.Curve)
+		assert.Len(t, ec.X, 43)
+		assert.Len(t, ec.Y, 43)
+
+		rsa := jwks.Keys[1]
+		assert.Equal(t, "rsa-1", rsa.KeyID)
+		assert.Equal(t, "RSA", rsa.KeyType)
+		assert.Equal(t, "RS256", rsa.Algorithm)
+		assert.Equal(t, "sig", rsa.Use)
+		assert.Equal(t, "AQAB", rsa.E)
+		assert.NotEmpty(t, rsa.N)
+	})
+
+	t.Run("Testing AddVerificationKey with a duplicated kid", func(t *testing.T) {
+		keySet, err := security.GenerateKeySet()
+		assert.Nil(t, err)
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+
+		err = keySet.AddVerificationKey(keySet.JWKS().Keys[0].KeyID, &key.PublicKey)
+		assert.NotNil(t, err)
+	})
+}
@@ -2,10 +2,10 @@ package web
 
 import (
 	"encoding/json"
-	"lucassantoss1701/bank/configs"
 	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/web/responses"
 	"lucassantoss1701/bank/internal/usecase"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
@@ -65,8 +65,9 @@ func (h *WebAccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Param       limit query int false "number of items to be returned per page"
 // @Param       offset query int false "page offset"
-// @Success     200 {array} usecase.FindAccountUseCaseOutput
-// @Failure     400,401,404,500
+// @Param       cursor query string false "next_cursor returned by the previous page"
+// @Success     200 {object} usecase.FindAccountUseCasePageOutput
+// @Failure     400,401,403,404,500
 // @Security    ApiKeyAuth
 // @Router /accounts [get]
 func (h *WebAccountHandler) Find(w http.ResponseWriter, r *http.Request) {
@@ -84,9 +85,6 @@ func (h *WebAccountHandler) Find(w http.ResponseWriter, r *http.Request) {
 			responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
 			return
 		}
-		if limit == 0 {
-			limit = 20
-		}
 	}
 
 	offSetStr := queryParams.Get("offset")
@@ -98,7 +96,7 @@ func (h *WebAccountHandler) Find(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	input := usecase.NewFindAccountUseCaseInput(limit, offSet)
+	input := usecase.NewFindAccountUseCaseInput(limit, offSet, queryParams.Get("cursor"))
 	output, err := h.findAccount.Execute(ctx, input)
 	if err != nil {
 		responses.Err(w, err)
@@ -113,7 +111,7 @@ func (h *WebAccountHandler) Find(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Success     200 {array} usecase.FindBalanceByAccountUseCaseOutput
 // @Param       account_id path string true "account_id"
-// @Failure     400,401,404,500
+// @Failure     400,401,403,404,500
 // @Security    ApiKeyAuth
 // @Router /accounts/{account_id}/balance [get]
 func (h *WebAccountHandler) FindBalanceByAccount(w http.ResponseWriter, r *http.Request) {
@@ -133,12 +131,12 @@ func (h *WebAccountHandler) FindBalanceByAccount(w http.ResponseWriter, r *http.
 }
 
 // @Summary     Login
-// @Description Login checks that the user can use the API and returns a token
+// @Description Login checks that the user can use the API and returns an access token and a refresh token
 // @Tags        accounts
 // @Produce     json
 // @Param       body body usecase.LoginUseCaseInput true "login request body"
-// @Success     200 {object} usecase.LoginUseCaseOutput
-// @Failure     400,401,404,500
+// @Success     200 {object} usecase.TokenUseCaseOutput
+// @Failure     400,401,429,500
 // @Router /login [post]
 func (h *WebAccountHandler) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -150,8 +148,7 @@ func (h *WebAccountHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	secretJWT := configs.Get().Security.Secret
-	input := usecase.NewLoginUseCaseInput(dto.CPF, dto.Secret, secretJWT)
+	input := usecase.NewLoginUseCaseInput(dto.CPF, dto.Secret, clientIP(r))
 
 	output, err := h.login.Execute(ctx, input)
 	if err != nil {
@@ -162,3 +159,13 @@ func (h *WebAccountHandler) Login(w http.ResponseWriter, r *http.Request) {
 	responses.Success(w, http.StatusOK, output)
 
 }
+
+// clientIP is the address of the connection, without the port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
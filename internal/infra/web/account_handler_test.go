@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	testify "github.com/stretchr/testify/mock"
@@ -86,8 +87,8 @@ func TestAccountHandler_Find(t *testing.T) {
 		account := mock.CreateAccount()
 		req, _ := http.NewRequest("GET", "/accounts?limit=0&offset=0", nil)
 		recorder := httptest.NewRecorder()
-		var output []usecase.FindAccountUseCaseOutput
-		output = append(output, *usecase.NewFindAccountUseCaseOutput(account.ID, account.Name, account.Balance, account.CreatedAt))
+		output := &usecase.FindAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindAccountUseCaseOutput(account.ID, account.Name, account.Balance, account.CreatedAt))
 		usecase := usecaseMock.NewFindAccountUseCaseMock()
 
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
@@ -120,8 +121,8 @@ func TestAccountHandler_Find(t *testing.T) {
 		account := mock.CreateAccount()
 		req, _ := http.NewRequest("GET", "/accounts?limit=0&offset=0", nil)
 		recorder := httptest.NewRecorder()
-		var output []usecase.FindAccountUseCaseOutput
-		output = append(output, *usecase.NewFindAccountUseCaseOutput(account.ID, account.Name, account.Balance, account.CreatedAt))
+		output := &usecase.FindAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindAccountUseCaseOutput(account.ID, account.Name, account.Balance, account.CreatedAt))
 		usecase := usecaseMock.NewFindAccountUseCaseMock()
 
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, entity.NewErrorHandler(entity.INTERNAL_ERROR))
@@ -139,7 +140,7 @@ func TestAccountHandler_FindBalanceByAccount(t *testing.T) {
 		account := mock.CreateAccount()
 		req, _ := http.NewRequest("GET", "/accounts/id", nil)
 		recorder := httptest.NewRecorder()
-		output := usecase.NewFindBalanceByAccountUseCaseOutput(account.Balance)
+		output := usecase.NewFindBalanceByAccountUseCaseOutput(&account)
 		usecase := usecaseMock.NewFindBalanceByAccountUseCaseMock()
 
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
@@ -154,7 +155,7 @@ func TestAccountHandler_FindBalanceByAccount(t *testing.T) {
 		account := mock.CreateAccount()
 		req, _ := http.NewRequest("GET", "/accounts/id", nil)
 		recorder := httptest.NewRecorder()
-		output := usecase.NewFindBalanceByAccountUseCaseOutput(account.Balance)
+		output := usecase.NewFindBalanceByAccountUseCaseOutput(&account)
 		usecase := usecaseMock.NewFindBalanceByAccountUseCaseMock()
 
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, entity.NewErrorHandler(entity.INTERNAL_ERROR))
@@ -178,7 +179,7 @@ func TestAccountHandler_Login(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		output := usecase.NewLoginUseCaseOutput(&account, "")
+		output := &usecase.TokenUseCaseOutput{Token: "xpto", RefreshToken: "refresh", ExpiresIn: 900}
 
 		usecase := usecaseMock.NewLoginUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
@@ -215,7 +216,7 @@ func TestAccountHandler_Login(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		output := usecase.NewLoginUseCaseOutput(&account, "")
+		output := &usecase.TokenUseCaseOutput{Token: "xpto", RefreshToken: "refresh", ExpiresIn: 900}
 		usecase := usecaseMock.NewLoginUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, entity.NewErrorHandler(entity.INTERNAL_ERROR))
 
@@ -225,4 +226,25 @@ func TestAccountHandler_Login(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
 	})
+
+	t.Run("Testing Login passes the client IP and answers throttled attempts with Retry-After", func(t *testing.T) {
+
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(`{"cpf":"34688151071","secret":"123456"}`))
+		req.RemoteAddr = "203.0.113.7:52144"
+
+		recorder := httptest.NewRecorder()
+
+		loginUseCase := usecaseMock.NewLoginUseCaseMock()
+		loginUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.LoginUseCaseInput) bool {
+			return input.IP == "203.0.113.7"
+		})).Return(&usecase.TokenUseCaseOutput{}, entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).Add("too many failed login attempts, try again later").WithRetryAfter(1500*time.Millisecond))
+
+		handler := web.NewWebAccountHandler(nil, nil, nil, loginUseCase)
+
+		handler.Login(recorder, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+		loginUseCase.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,103 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebBackOfficeHandler struct {
+	changeAccountRole usecase.IChangeAccountRoleUseCase
+	reconcileLedger   usecase.IReconcileLedgerUseCase
+	changeCreditLimit usecase.IChangeCreditLimitUseCase
+}
+
+func NewWebBackOfficeHandler(changeAccountRole usecase.IChangeAccountRoleUseCase, reconcileLedger usecase.IReconcileLedgerUseCase, changeCreditLimit usecase.IChangeCreditLimitUseCase) *WebBackOfficeHandler {
+	return &WebBackOfficeHandler{
+		changeAccountRole: changeAccountRole,
+		reconcileLedger:   reconcileLedger,
+		changeCreditLimit: changeCreditLimit,
+	}
+}
+
+// @Summary     Change account role
+// @Description Change the role of an account, only admins can do it
+// @Tags        back-office
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Param       body body usecase.ChangeAccountRoleUseCaseInput true "change role request body"
+// @Success     200 {object} usecase.ChangeAccountRoleUseCaseOutput
+// @Failure     400,401,403,404,500,422
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/role [put]
+func (h *WebBackOfficeHandler) ChangeAccountRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto usecase.ChangeAccountRoleUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	input := usecase.NewChangeAccountRoleUseCaseInput(chi.URLParam(r, "account_id"), dto.Role)
+	output, err := h.changeAccountRole.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Change credit limit
+// @Description Change the overdraft approved for an account, only admins can do it
+// @Tags        back-office
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Param       body body usecase.ChangeCreditLimitUseCaseInput true "change credit limit request body"
+// @Success     200 {object} usecase.ChangeCreditLimitUseCaseOutput
+// @Failure     400,401,403,404,500,422
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/credit-limit [put]
+func (h *WebBackOfficeHandler) ChangeCreditLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto usecase.ChangeCreditLimitUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	input := usecase.NewChangeCreditLimitUseCaseInput(chi.URLParam(r, "account_id"), dto.CreditLimit)
+	output, err := h.changeCreditLimit.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Reconcile ledger
+// @Description Compare the cached balance of every account with the ledger, only admins can do it
+// @Tags        back-office
+// @Produce     json
+// @Success     200 {object} usecase.ReconcileLedgerUseCaseOutput
+// @Failure     401,403,500
+// @Security    ApiKeyAuth
+// @Router /ledger/reconciliation [get]
+func (h *WebBackOfficeHandler) ReconcileLedger(w http.ResponseWriter, r *http.Request) {
+	output, err := h.reconcileLedger.Execute(r.Context(), usecase.NewReconcileLedgerUseCaseInput())
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
@@ -0,0 +1,167 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func NewChangeAccountRoleRequest(t *testing.T, accountID string, body string) *http.Request {
+	req, err := http.NewRequest("PUT", "/accounts/"+accountID+"/role", bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("account_id", accountID)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+}
+
+func TestBackOfficeHandler_ChangeAccountRole(t *testing.T) {
+	t.Run("Testing ChangeAccountRole with success", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewChangeAccountRoleRequest(t, accountID, `{"role":"ADMIN"}`)
+		recorder := httptest.NewRecorder()
+
+		changeAccountRoleUseCase := usecaseMock.NewChangeAccountRoleUseCaseMock()
+		changeAccountRoleUseCase.On("Execute", req.Context(), usecase.NewChangeAccountRoleUseCaseInput(accountID, "ADMIN")).
+			Return(usecase.NewChangeAccountRoleUseCaseOutput(accountID, entity.ADMIN_ROLE), nil)
+
+		handler := web.NewWebBackOfficeHandler(changeAccountRoleUseCase, nil, nil)
+		handler.ChangeAccountRole(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"account_id":"2bd765a6-47bd-4731-9eb2-1e65542f4477","role":"ADMIN"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing ChangeAccountRole occurs error on decode body", func(t *testing.T) {
+		req := NewChangeAccountRoleRequest(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", `{"role":`)
+		recorder := httptest.NewRecorder()
+
+		changeAccountRoleUseCase := usecaseMock.NewChangeAccountRoleUseCaseMock()
+
+		handler := web.NewWebBackOfficeHandler(changeAccountRoleUseCase, nil, nil)
+		handler.ChangeAccountRole(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		changeAccountRoleUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeAccountRole when usecase returns an error", func(t *testing.T) {
+		req := NewChangeAccountRoleRequest(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", `{"role":"ROOT"}`)
+		recorder := httptest.NewRecorder()
+
+		changeAccountRoleUseCase := usecaseMock.NewChangeAccountRoleUseCaseMock()
+		changeAccountRoleUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(&usecase.ChangeAccountRoleUseCaseOutput{}, entity.NewErrorHandler(entity.ENTITY_ERROR).Add("role is invalid: ROOT"))
+
+		handler := web.NewWebBackOfficeHandler(changeAccountRoleUseCase, nil, nil)
+		handler.ChangeAccountRole(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func NewChangeCreditLimitRequest(t *testing.T, accountID string, body string) *http.Request {
+	req, err := http.NewRequest("PUT", "/accounts/"+accountID+"/credit-limit", bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("account_id", accountID)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+}
+
+func TestBackOfficeHandler_ChangeCreditLimit(t *testing.T) {
+	t.Run("Testing ChangeCreditLimit with success", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewChangeCreditLimitRequest(t, accountID, `{"credit_limit":{"amount":"1500.00","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		account := entity.Account{ID: accountID, Balance: entity.NewMoney(-100000, entity.BRL), CreditLimit: entity.NewMoney(150000, entity.BRL)}
+
+		changeCreditLimitUseCase := usecaseMock.NewChangeCreditLimitUseCaseMock()
+		changeCreditLimitUseCase.On("Execute", req.Context(), usecase.NewChangeCreditLimitUseCaseInput(accountID, entity.NewMoney(150000, entity.BRL))).
+			Return(usecase.NewChangeCreditLimitUseCaseOutput(&account), nil)
+
+		handler := web.NewWebBackOfficeHandler(nil, nil, changeCreditLimitUseCase)
+		handler.ChangeCreditLimit(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{
+			"account_id":"2bd765a6-47bd-4731-9eb2-1e65542f4477",
+			"balance":{"amount":"-1000.00","currency":"BRL"},
+			"held":{"amount":"0.00","currency":"BRL"},
+			"credit_limit":{"amount":"1500.00","currency":"BRL"},
+			"available_balance":{"amount":"500.00","currency":"BRL"}
+		}`, recorder.Body.String())
+	})
+
+	t.Run("Testing ChangeCreditLimit occurs error on decode body", func(t *testing.T) {
+		req := NewChangeCreditLimitRequest(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", `{"credit_limit":`)
+		recorder := httptest.NewRecorder()
+
+		changeCreditLimitUseCase := usecaseMock.NewChangeCreditLimitUseCaseMock()
+
+		handler := web.NewWebBackOfficeHandler(nil, nil, changeCreditLimitUseCase)
+		handler.ChangeCreditLimit(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		changeCreditLimitUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeCreditLimit when usecase returns an error", func(t *testing.T) {
+		req := NewChangeCreditLimitRequest(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", `{"credit_limit":{"amount":"0","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		changeCreditLimitUseCase := usecaseMock.NewChangeCreditLimitUseCaseMock()
+		changeCreditLimitUseCase.On("Execute", req.Context(), testify.Anything).
+			Return((*usecase.ChangeCreditLimitUseCaseOutput)(nil), entity.NewErrorHandler(entity.ENTITY_ERROR).Add("credit limit cannot be lower than the overdrawn amount of 1000.00 BRL"))
+
+		handler := web.NewWebBackOfficeHandler(nil, nil, changeCreditLimitUseCase)
+		handler.ChangeCreditLimit(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestBackOfficeHandler_ReconcileLedger(t *testing.T) {
+	t.Run("Testing ReconcileLedger with success", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/ledger/reconciliation", nil)
+		recorder := httptest.NewRecorder()
+
+		reconcileLedgerUseCase := usecaseMock.NewReconcileLedgerUseCaseMock()
+		reconcileLedgerUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(usecase.NewReconcileLedgerUseCaseOutput(2, []usecase.ReconcileLedgerUseCaseDriftOutput{}, []string{}), nil)
+
+		handler := web.NewWebBackOfficeHandler(nil, reconcileLedgerUseCase, nil)
+		handler.ReconcileLedger(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"accounts_checked":2,"drifts":[],"unbalanced_entries":[]}`, recorder.Body.String())
+	})
+
+	t.Run("Testing ReconcileLedger when usecase returns an error", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/ledger/reconciliation", nil)
+		recorder := httptest.NewRecorder()
+
+		reconcileLedgerUseCase := usecaseMock.NewReconcileLedgerUseCaseMock()
+		reconcileLedgerUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(&usecase.ReconcileLedgerUseCaseOutput{}, errors.New("connection closed"))
+
+		handler := web.NewWebBackOfficeHandler(nil, reconcileLedgerUseCase, nil)
+		handler.ReconcileLedger(recorder, req)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+}
@@ -0,0 +1,184 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebBeneficiaryHandler struct {
+	createBeneficiary          usecase.ICreateBeneficiaryUseCase
+	findBeneficiariesByAccount usecase.IFindBeneficiariesByAccountUseCase
+	updateBeneficiary          usecase.IUpdateBeneficiaryUseCase
+	deleteBeneficiary          usecase.IDeleteBeneficiaryUseCase
+	findSuggestions            usecase.IFindBeneficiarySuggestionsUseCase
+}
+
+func NewWebBeneficiaryHandler(createBeneficiary usecase.ICreateBeneficiaryUseCase, findBeneficiariesByAccount usecase.IFindBeneficiariesByAccountUseCase, updateBeneficiary usecase.IUpdateBeneficiaryUseCase, deleteBeneficiary usecase.IDeleteBeneficiaryUseCase, findSuggestions usecase.IFindBeneficiarySuggestionsUseCase) *WebBeneficiaryHandler {
+	return &WebBeneficiaryHandler{
+		createBeneficiary:          createBeneficiary,
+		findBeneficiariesByAccount: findBeneficiariesByAccount,
+		updateBeneficiary:          updateBeneficiary,
+		deleteBeneficiary:          deleteBeneficiary,
+		findSuggestions:            findSuggestions,
+	}
+}
+
+// @Summary     Create beneficiary
+// @Description Save a recipient of the authenticated account, informed by its account id or by one of its keys, so transfers can be sent to the beneficiary id. Keys that do not exist are counted as the lookups of transfer keys, and after too many of them no beneficiary is saved by key until the account waits
+// @Tags        beneficiaries
+// @Produce     json
+// @Param       body body usecase.CreateBeneficiaryUseCaseInput true "create beneficiary request body"
+// @Success     201 {object} usecase.BeneficiaryUseCaseOutput
+// @Failure     400,401,404,409,429,500,422
+// @Security    ApiKeyAuth
+// @Router /beneficiaries [post]
+func (h *WebBeneficiaryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.CreateBeneficiaryUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewCreateBeneficiaryUseCaseInput(accountID, dto.DestinationAccountID, dto.Key, dto.Nickname, dto.Favorite, &createdAt)
+
+	output, err := h.createBeneficiary.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Find beneficiaries
+// @Description Find the beneficiaries of the authenticated account, favorites first and then by nickname
+// @Tags        beneficiaries
+// @Produce     json
+// @Success     200 {array} usecase.BeneficiaryUseCaseOutput
+// @Failure     400,401,500
+// @Security    ApiKeyAuth
+// @Router /beneficiaries [get]
+func (h *WebBeneficiaryHandler) FindByAccountID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	output, err := h.findBeneficiariesByAccount.Execute(ctx, usecase.NewFindBeneficiariesByAccountUseCaseInput(accountID))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Find beneficiary suggestions
+// @Description Suggest as beneficiaries the accounts that most received transfers from the authenticated account recently and are not saved yet
+// @Tags        beneficiaries
+// @Produce     json
+// @Success     200 {array} usecase.BeneficiarySuggestionUseCaseOutput
+// @Failure     400,401,500
+// @Security    ApiKeyAuth
+// @Router /beneficiaries/suggestions [get]
+func (h *WebBeneficiaryHandler) FindSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	output, err := h.findSuggestions.Execute(ctx, usecase.NewFindBeneficiarySuggestionsUseCaseInput(accountID, time.Now()))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Update beneficiary
+// @Description Rename a beneficiary of the authenticated account or mark it as favorite, the fields not informed are kept
+// @Tags        beneficiaries
+// @Produce     json
+// @Param       beneficiary_id path string true "beneficiary id"
+// @Param       body body usecase.UpdateBeneficiaryUseCaseInput true "update beneficiary request body"
+// @Success     200 {object} usecase.BeneficiaryUseCaseOutput
+// @Failure     400,401,404,500,422
+// @Security    ApiKeyAuth
+// @Router /beneficiaries/{beneficiary_id} [put]
+func (h *WebBeneficiaryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.UpdateBeneficiaryUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	updatedAt := time.Now()
+	input := usecase.NewUpdateBeneficiaryUseCaseInput(accountID, chi.URLParam(r, "beneficiary_id"), dto.Nickname, dto.Favorite, &updatedAt)
+
+	output, err := h.updateBeneficiary.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Delete beneficiary
+// @Description Remove a beneficiary of the authenticated account, the transfers already sent to it are kept
+// @Tags        beneficiaries
+// @Produce     json
+// @Param       beneficiary_id path string true "beneficiary id"
+// @Success     204
+// @Failure     400,401,404,500
+// @Security    ApiKeyAuth
+// @Router /beneficiaries/{beneficiary_id} [delete]
+func (h *WebBeneficiaryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	err := h.deleteBeneficiary.Execute(ctx, usecase.NewDeleteBeneficiaryUseCaseInput(accountID, chi.URLParam(r, "beneficiary_id")))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusNoContent, nil)
+}
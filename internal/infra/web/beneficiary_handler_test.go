@@ -0,0 +1,125 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetBeneficiaryRequest(t *testing.T, method string, path string, beneficiaryID string, body string, accountID string) *http.Request {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("beneficiary_id", beneficiaryID)
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if accountID != "" {
+		ctx = context.WithValue(ctx, web.AccountIDKey, accountID)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestBeneficiaryHandler_Create(t *testing.T) {
+	t.Run("Testing Create saves a beneficiary of the logged account", func(t *testing.T) {
+		req := GetBeneficiaryRequest(t, "POST", "/beneficiaries", "", `{"key":"joao@example.com","nickname":"Joao","favorite":true}`, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		createBeneficiaryUseCase := usecaseMock.NewCreateBeneficiaryUseCaseMock()
+		createBeneficiaryUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CreateBeneficiaryUseCaseInput) bool {
+			return input.AccountID == "2bd765a6-47bd-4731-9eb2-1e65542f4477" && input.Key == "joao@example.com" && input.Nickname == "Joao" && input.Favorite && input.CreatedAt != nil
+		})).Return(&usecase.BeneficiaryUseCaseOutput{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", Name: "joao", Nickname: "Joao", Favorite: true, CreatedAt: "2023-08-14T10:00:00Z", UpdatedAt: "2023-08-14T10:00:00Z"}, nil)
+
+		handler := web.NewWebBeneficiaryHandler(createBeneficiaryUseCase, nil, nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.JSONEq(t, `{"id":"6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60","name":"joao","nickname":"Joao","favorite":true,"created_at":"2023-08-14T10:00:00Z","updated_at":"2023-08-14T10:00:00Z"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Create when the destination account is already saved", func(t *testing.T) {
+		req := GetBeneficiaryRequest(t, "POST", "/beneficiaries", "", `{"destination_account_id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6"}`, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		createBeneficiaryUseCase := usecaseMock.NewCreateBeneficiaryUseCaseMock()
+		createBeneficiaryUseCase.On("Execute", req.Context(), testify.Anything).
+			Return((*usecase.BeneficiaryUseCaseOutput)(nil), entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("beneficiary is already saved: d18551d3-cf13-49ec-b1dc-741a1f8715f6"))
+
+		handler := web.NewWebBeneficiaryHandler(createBeneficiaryUseCase, nil, nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestBeneficiaryHandler_FindSuggestions(t *testing.T) {
+	t.Run("Testing FindSuggestions lists the frequent destinations of the logged account", func(t *testing.T) {
+		req := GetBeneficiaryRequest(t, "GET", "/beneficiaries/suggestions", "", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		findSuggestionsUseCase := usecaseMock.NewFindBeneficiarySuggestionsUseCaseMock()
+		findSuggestionsUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.FindBeneficiarySuggestionsUseCaseInput) bool {
+			return input.AccountID == "2bd765a6-47bd-4731-9eb2-1e65542f4477" && !input.Now.IsZero()
+		})).Return([]usecase.BeneficiarySuggestionUseCaseOutput{{DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "joao", Transfers: 3, LastTransferAt: "2023-08-10T09:30:00Z"}}, nil)
+
+		handler := web.NewWebBeneficiaryHandler(nil, nil, nil, nil, findSuggestionsUseCase)
+		handler.FindSuggestions(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `[{"destination_account_id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6","name":"joao","transfers":3,"last_transfer_at":"2023-08-10T09:30:00Z"}]`, recorder.Body.String())
+	})
+}
+
+func TestBeneficiaryHandler_Update(t *testing.T) {
+	t.Run("Testing Update keeps the fields not informed", func(t *testing.T) {
+		req := GetBeneficiaryRequest(t, "PUT", "/beneficiaries/6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", `{"favorite":false}`, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		updateBeneficiaryUseCase := usecaseMock.NewUpdateBeneficiaryUseCaseMock()
+		updateBeneficiaryUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.UpdateBeneficiaryUseCaseInput) bool {
+			return input.ID == "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60" && input.Nickname == nil && input.Favorite != nil && !*input.Favorite
+		})).Return(&usecase.BeneficiaryUseCaseOutput{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60"}, nil)
+
+		handler := web.NewWebBeneficiaryHandler(nil, nil, updateBeneficiaryUseCase, nil, nil)
+		handler.Update(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		updateBeneficiaryUseCase.AssertExpectations(t)
+	})
+}
+
+func TestBeneficiaryHandler_Delete(t *testing.T) {
+	t.Run("Testing Delete removes the beneficiary of the logged account", func(t *testing.T) {
+		req := GetBeneficiaryRequest(t, "DELETE", "/beneficiaries/6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		deleteBeneficiaryUseCase := usecaseMock.NewDeleteBeneficiaryUseCaseMock()
+		deleteBeneficiaryUseCase.On("Execute", req.Context(), usecase.NewDeleteBeneficiaryUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60")).Return(nil)
+
+		handler := web.NewWebBeneficiaryHandler(nil, nil, nil, deleteBeneficiaryUseCase, nil)
+		handler.Delete(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+
+	t.Run("Testing Delete without account_id in context", func(t *testing.T) {
+		req := GetBeneficiaryRequest(t, "DELETE", "/beneficiaries/6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", "", "")
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebBeneficiaryHandler(nil, nil, nil, nil, nil)
+		handler.Delete(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
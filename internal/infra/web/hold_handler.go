@@ -0,0 +1,164 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebHoldHandler struct {
+	createHold  usecase.ICreateHoldUseCase
+	captureHold usecase.ICaptureHoldUseCase
+	releaseHold usecase.IReleaseHoldUseCase
+	findHold    usecase.IFindHoldUseCase
+}
+
+func NewWebHoldHandler(createHold usecase.ICreateHoldUseCase, captureHold usecase.ICaptureHoldUseCase, releaseHold usecase.IReleaseHoldUseCase, findHold usecase.IFindHoldUseCase) *WebHoldHandler {
+	return &WebHoldHandler{
+		createHold:  createHold,
+		captureHold: captureHold,
+		releaseHold: releaseHold,
+		findHold:    findHold,
+	}
+}
+
+// @Summary     Create hold
+// @Description Reserve funds of the authenticated account for a later capture by the destination account, the funds stay in the balance but are not available until the hold is captured, released or expires
+// @Tags        holds
+// @Produce     json
+// @Param       body body usecase.CreateHoldUseCaseInput true "create hold request body"
+// @Param       Idempotency-Key header string false "key that makes retries of the same hold safe"
+// @Success     201 {object} usecase.HoldUseCaseOutput
+// @Failure     400,401,403,404,409,429,500,422
+// @Security    ApiKeyAuth
+// @Router /holds [post]
+func (h *WebHoldHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.CreateHoldUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewCreateHoldUseCaseInput(dto.ID, accountID, dto.DestinationAccount.ID, dto.Amount, dto.ExpiresAt, dto.TOTPCode, &createdAt)
+
+	output, err := h.createHold.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Capture hold
+// @Description Move all or part of a hold to the destination account with a transfer, the rest goes back to the origin account. The transfer pays the fees of the origin account as any other transfer. Only the recipient can capture the hold
+// @Tags        holds
+// @Produce     json
+// @Param       hold_id path string true "hold_id"
+// @Param       body body usecase.CaptureHoldUseCaseInput false "capture hold request body, the whole hold when empty"
+// @Param       Idempotency-Key header string false "key that makes retries of the same capture safe"
+// @Success     201 {object} usecase.CaptureHoldUseCaseOutput
+// @Failure     400,401,403,404,409,429,500,422
+// @Security    ApiKeyAuth
+// @Router /holds/{hold_id}/capture [post]
+func (h *WebHoldHandler) Capture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	var dto usecase.CaptureHoldUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil && err != io.EOF {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewCaptureHoldUseCaseInput(dto.ID, chi.URLParam(r, "hold_id"), principal, dto.Amount, &createdAt)
+
+	output, err := h.captureHold.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Release hold
+// @Description Give back the whole hold to the origin account. Only the recipient can release the hold
+// @Tags        holds
+// @Produce     json
+// @Param       hold_id path string true "hold_id"
+// @Success     200 {object} usecase.HoldUseCaseOutput
+// @Failure     401,403,404,409,500,422
+// @Security    ApiKeyAuth
+// @Router /holds/{hold_id}/release [post]
+func (h *WebHoldHandler) Release(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	input := usecase.NewReleaseHoldUseCaseInput(chi.URLParam(r, "hold_id"), principal, time.Now())
+
+	output, err := h.releaseHold.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Find hold
+// @Description Find a hold, customers can only read the holds of their own account
+// @Tags        holds
+// @Produce     json
+// @Param       hold_id path string true "hold_id"
+// @Success     200 {object} usecase.HoldUseCaseOutput
+// @Failure     401,404,500
+// @Security    ApiKeyAuth
+// @Router /holds/{hold_id} [get]
+func (h *WebHoldHandler) FindByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	input := usecase.NewFindHoldUseCaseInput(chi.URLParam(r, "hold_id"), principal)
+
+	output, err := h.findHold.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
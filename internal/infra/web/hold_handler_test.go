@@ -0,0 +1,227 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetHoldOutput() *usecase.HoldUseCaseOutput {
+	return &usecase.HoldUseCaseOutput{
+		ID:                   "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		OriginAccountID:      "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+		DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6",
+		Amount:               entity.NewMoney(5000, entity.BRL),
+		CapturedAmount:       entity.NewMoney(0, entity.BRL),
+		Status:               "active",
+		ExpiresAt:            "2023-08-21T10:00:00Z",
+		CreatedAt:            "2023-08-14T10:00:00Z",
+		UpdatedAt:            "2023-08-14T10:00:00Z",
+	}
+}
+
+func GetHoldRequest(t *testing.T, method string, path string, body string, principal *entity.Principal) *http.Request {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("hold_id", "7c9e6679-7425-40de-944b-e07fc1f90ae7")
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if principal != nil {
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
+		ctx = context.WithValue(ctx, web.AccountIDKey, principal.AccountID)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestHoldHandler_Create(t *testing.T) {
+	t.Run("Testing Create with success", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds", `{"destination_account":{"id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6"},"amount":{"amount":"50.00","currency":"BRL"},"expires_at":"2023-08-21T10:00:00Z"}`, principal)
+		recorder := httptest.NewRecorder()
+
+		expiresAt := time.Date(2023, 8, 21, 10, 00, 00, 00, time.UTC)
+		createHoldUseCase := usecaseMock.NewCreateHoldUseCaseMock()
+		createHoldUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CreateHoldUseCaseInput) bool {
+			return input.OriginAccount.ID == principal.AccountID && input.DestinationAccount.ID == "d18551d3-cf13-49ec-b1dc-741a1f8715f6" &&
+				input.Amount == entity.NewMoney(5000, entity.BRL) && input.ExpiresAt.Equal(expiresAt) && input.CreatedAt != nil
+		})).Return(GetHoldOutput(), nil)
+
+		handler := web.NewWebHoldHandler(createHoldUseCase, nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.JSONEq(t, `{
+			"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7",
+			"origin_account_id":"2bd765a6-47bd-4731-9eb2-1e65542f4477",
+			"destination_account_id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6",
+			"amount":{"amount":"50.00","currency":"BRL"},
+			"captured_amount":{"amount":"0.00","currency":"BRL"},
+			"status":"active",
+			"expires_at":"2023-08-21T10:00:00Z",
+			"created_at":"2023-08-14T10:00:00Z",
+			"updated_at":"2023-08-14T10:00:00Z"
+		}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Create with an invalid body", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds", "{invalid", principal)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebHoldHandler(usecaseMock.NewCreateHoldUseCaseMock(), nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Testing Create when the available balance is insufficient", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds", `{"destination_account":{"id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6"},"amount":{"amount":"50.00","currency":"BRL"}}`, principal)
+		recorder := httptest.NewRecorder()
+
+		createHoldUseCase := usecaseMock.NewCreateHoldUseCaseMock()
+		createHoldUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.HoldUseCaseOutput)(nil), entity.NewErrorHandler(entity.ENTITY_ERROR).Add("insufficient available balance for hold"))
+
+		handler := web.NewWebHoldHandler(createHoldUseCase, nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestHoldHandler_Capture(t *testing.T) {
+	t.Run("Testing Capture with success", func(t *testing.T) {
+		principal := entity.NewPrincipal("d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7/capture", `{"amount":{"amount":"30.00","currency":"BRL"}}`, principal)
+		recorder := httptest.NewRecorder()
+
+		holdOutput := GetHoldOutput()
+		holdOutput.Status = "captured"
+		holdOutput.CapturedAmount = entity.NewMoney(3000, entity.BRL)
+		holdOutput.TransferID = "4f1b9a6e-5d0c-4a55-8d2f-2a8f0a4c1e77"
+		output := &usecase.CaptureHoldUseCaseOutput{
+			HoldUseCaseOutput: *holdOutput,
+			Transfer:          usecase.MakeTransferUseCaseOutput{ID: "4f1b9a6e-5d0c-4a55-8d2f-2a8f0a4c1e77", Status: "completed"},
+		}
+
+		captureHoldUseCase := usecaseMock.NewCaptureHoldUseCaseMock()
+		captureHoldUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CaptureHoldUseCaseInput) bool {
+			return input.HoldID == "7c9e6679-7425-40de-944b-e07fc1f90ae7" && input.Principal == principal &&
+				*input.Amount == entity.NewMoney(3000, entity.BRL) && input.CreatedAt != nil
+		})).Return(output, nil)
+
+		handler := web.NewWebHoldHandler(nil, captureHoldUseCase, nil, nil)
+		handler.Capture(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"captured"`)
+		assert.Contains(t, recorder.Body.String(), `"transfer_id":"4f1b9a6e-5d0c-4a55-8d2f-2a8f0a4c1e77"`)
+	})
+
+	t.Run("Testing Capture without a body captures the whole hold", func(t *testing.T) {
+		principal := entity.NewPrincipal("d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7/capture", "", principal)
+		recorder := httptest.NewRecorder()
+
+		captureHoldUseCase := usecaseMock.NewCaptureHoldUseCaseMock()
+		captureHoldUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CaptureHoldUseCaseInput) bool {
+			return input.Amount == nil
+		})).Return(&usecase.CaptureHoldUseCaseOutput{HoldUseCaseOutput: *GetHoldOutput()}, nil)
+
+		handler := web.NewWebHoldHandler(nil, captureHoldUseCase, nil, nil)
+		handler.Capture(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+	})
+
+	t.Run("Testing Capture without principal", func(t *testing.T) {
+		req := GetHoldRequest(t, "POST", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7/capture", "", nil)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebHoldHandler(nil, usecaseMock.NewCaptureHoldUseCaseMock(), nil, nil)
+		handler.Capture(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
+func TestHoldHandler_Release(t *testing.T) {
+	t.Run("Testing Release with success", func(t *testing.T) {
+		principal := entity.NewPrincipal("d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7/release", "", principal)
+		recorder := httptest.NewRecorder()
+
+		output := GetHoldOutput()
+		output.Status = "released"
+
+		releaseHoldUseCase := usecaseMock.NewReleaseHoldUseCaseMock()
+		releaseHoldUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.ReleaseHoldUseCaseInput) bool {
+			return input.HoldID == "7c9e6679-7425-40de-944b-e07fc1f90ae7" && input.Principal == principal
+		})).Return(output, nil)
+
+		handler := web.NewWebHoldHandler(nil, nil, releaseHoldUseCase, nil)
+		handler.Release(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"released"`)
+	})
+
+	t.Run("Testing Release when the sender asks for the release", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "POST", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7/release", "", principal)
+		recorder := httptest.NewRecorder()
+
+		releaseHoldUseCase := usecaseMock.NewReleaseHoldUseCaseMock()
+		releaseHoldUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.HoldUseCaseOutput)(nil), entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("only the recipient can release the hold"))
+
+		handler := web.NewWebHoldHandler(nil, nil, releaseHoldUseCase, nil)
+		handler.Release(recorder, req)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
+
+func TestHoldHandler_FindByID(t *testing.T) {
+	t.Run("Testing FindByID with success", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "GET", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7", "", principal)
+		recorder := httptest.NewRecorder()
+
+		findHoldUseCase := usecaseMock.NewFindHoldUseCaseMock()
+		findHoldUseCase.On("Execute", req.Context(), usecase.NewFindHoldUseCaseInput("7c9e6679-7425-40de-944b-e07fc1f90ae7", principal)).Return(GetHoldOutput(), nil)
+
+		handler := web.NewWebHoldHandler(nil, nil, nil, findHoldUseCase)
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7"`)
+	})
+
+	t.Run("Testing FindByID when the hold is not found", func(t *testing.T) {
+		principal := entity.NewPrincipal("f0b6b3f4-9c8a-4a0e-8d8f-3b7d3d7c2e11", entity.CUSTOMER_ROLE)
+		req := GetHoldRequest(t, "GET", "/holds/7c9e6679-7425-40de-944b-e07fc1f90ae7", "", principal)
+		recorder := httptest.NewRecorder()
+
+		findHoldUseCase := usecaseMock.NewFindHoldUseCaseMock()
+		findHoldUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.HoldUseCaseOutput)(nil), entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found hold: 7c9e6679-7425-40de-944b-e07fc1f90ae7"))
+
+		handler := web.NewWebHoldHandler(nil, nil, nil, findHoldUseCase)
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
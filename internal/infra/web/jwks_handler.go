@@ -0,0 +1,28 @@
+package web
+
+import (
+	"lucassantoss1701/bank/internal/infra/security"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"net/http"
+)
+
+type WebJWKSHandler struct {
+	keySet *security.KeySet
+}
+
+func NewWebJWKSHandler(keySet *security.KeySet) *WebJWKSHandler {
+	return &WebJWKSHandler{
+		keySet: keySet,
+	}
+}
+
+// @Summary     JSON Web Key Set
+// @Description Public keys accepted to verify the access tokens, identified by the kid header of the token
+// @Tags        accounts
+// @Produce     json
+// @Success     200 {object} security.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *WebJWKSHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	responses.Success(w, http.StatusOK, h.keySet.JWKS())
+}
@@ -0,0 +1,32 @@
+package web_test
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/infra/security"
+	"lucassantoss1701/bank/internal/infra/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKSHandler_JWKS(t *testing.T) {
+	t.Run("Testing JWKS with success", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+		recorder := httptest.NewRecorder()
+
+		keySet, err := security.GenerateKeySet()
+		assert.Nil(t, err)
+
+		handler := web.NewWebJWKSHandler(keySet)
+		handler.JWKS(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var jwks security.JWKS
+		err = json.Unmarshal(recorder.Body.Bytes(), &jwks)
+		assert.Nil(t, err)
+		assert.Equal(t, keySet.JWKS(), jwks)
+	})
+}
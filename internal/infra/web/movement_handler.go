@@ -0,0 +1,93 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebMovementHandler struct {
+	deposit  usecase.IDepositUseCase
+	withdraw usecase.IWithdrawUseCase
+}
+
+func NewWebMovementHandler(deposit usecase.IDepositUseCase, withdraw usecase.IWithdrawUseCase) *WebMovementHandler {
+	return &WebMovementHandler{
+		deposit:  deposit,
+		withdraw: withdraw,
+	}
+}
+
+// @Summary     Deposit
+// @Description Deposit money into an account, only administrators can deposit
+// @Tags        accounts
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Param       body body usecase.MovementUseCaseInput true "deposit request body"
+// @Param       Idempotency-Key header string false "key that makes retries of the same deposit safe"
+// @Success     201 {object} usecase.MovementUseCaseOutput
+// @Failure     400,401,403,404,409,500,422
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/deposits [post]
+func (h *WebMovementHandler) Deposit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input, err := decodeMovementInput(r)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	output, err := h.deposit.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Withdraw
+// @Description Withdraw money from the logged account
+// @Tags        accounts
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Param       body body usecase.MovementUseCaseInput true "withdrawal request body"
+// @Param       Idempotency-Key header string false "key that makes retries of the same withdrawal safe"
+// @Success     201 {object} usecase.MovementUseCaseOutput
+// @Failure     400,401,403,404,409,500,422
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/withdrawals [post]
+func (h *WebMovementHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input, err := decodeMovementInput(r)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	output, err := h.withdraw.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+func decodeMovementInput(r *http.Request) (*usecase.MovementUseCaseInput, error) {
+	var dto usecase.MovementUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error())
+	}
+
+	createdAt := time.Now()
+	return usecase.NewMovementUseCaseInput("", chi.URLParam(r, "account_id"), dto.Channel, dto.Reference, dto.Amount, &createdAt), nil
+}
@@ -0,0 +1,112 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func NewMovementRequest(t *testing.T, path string, accountID string, loggedAccountID string, body string) *http.Request {
+	req, err := http.NewRequest("POST", path, bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("account_id", accountID)
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	ctx = context.WithValue(ctx, web.AccountIDKey, loggedAccountID)
+
+	return req.WithContext(ctx)
+}
+
+func TestMovementHandler_Deposit(t *testing.T) {
+	t.Run("Testing Deposit with success", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewMovementRequest(t, "/accounts/"+accountID+"/deposits", accountID, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", `{"channel":"ATM","reference":"envelope 123","amount":{"amount":"0.50","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		output := &usecase.MovementUseCaseOutput{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", AccountID: accountID, Type: "DEPOSIT", Channel: "ATM", Amount: entity.NewMoney(50, entity.BRL), Balance: entity.NewMoney(150, entity.BRL)}
+		depositUseCase := usecaseMock.NewDepositUseCaseMock()
+		depositUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.MovementUseCaseInput) bool {
+			return input.AccountID == accountID && input.Channel == entity.ATM_CHANNEL && input.Reference == "envelope 123" && input.Amount == entity.NewMoney(50, entity.BRL) && input.CreatedAt != nil
+		})).Return(output, nil)
+
+		handler := web.NewWebMovementHandler(depositUseCase, nil)
+		handler.Deposit(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+
+		var response usecase.MovementUseCaseOutput
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "DEPOSIT", response.Type)
+	})
+
+	t.Run("Testing Deposit when body is invalid", func(t *testing.T) {
+		req := NewMovementRequest(t, "/accounts/x/deposits", "x", "x", `{"amount":{"amount":"fifty","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebMovementHandler(usecaseMock.NewDepositUseCaseMock(), nil)
+		handler.Deposit(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Testing Deposit when usecase returns an error", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewMovementRequest(t, "/accounts/"+accountID+"/deposits", accountID, accountID, `{"channel":"PHONE","amount":{"amount":"0.50","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		var output *usecase.MovementUseCaseOutput
+		depositUseCase := usecaseMock.NewDepositUseCaseMock()
+		depositUseCase.On("Execute", req.Context(), testify.Anything).Return(output, entity.NewErrorHandler(entity.ENTITY_ERROR).Add("channel must be one of BRANCH, ATM or ONLINE"))
+
+		handler := web.NewWebMovementHandler(depositUseCase, nil)
+		handler.Deposit(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestMovementHandler_Withdraw(t *testing.T) {
+	t.Run("Testing Withdraw with success", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewMovementRequest(t, "/accounts/"+accountID+"/withdrawals", accountID, accountID, `{"channel":"ATM","amount":{"amount":"0.50","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		output := &usecase.MovementUseCaseOutput{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", AccountID: accountID, Type: "WITHDRAWAL", Channel: "ATM", Amount: entity.NewMoney(50, entity.BRL), Balance: entity.NewMoney(150, entity.BRL)}
+		withdrawUseCase := usecaseMock.NewWithdrawUseCaseMock()
+		withdrawUseCase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
+
+		handler := web.NewWebMovementHandler(nil, withdrawUseCase)
+		handler.Withdraw(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+	})
+
+	t.Run("Testing Withdraw when usecase returns an error", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewMovementRequest(t, "/accounts/"+accountID+"/withdrawals", accountID, accountID, `{"channel":"ATM","amount":{"amount":"0.50","currency":"BRL"}}`)
+		recorder := httptest.NewRecorder()
+
+		var output *usecase.MovementUseCaseOutput
+		withdrawUseCase := usecaseMock.NewWithdrawUseCaseMock()
+		withdrawUseCase.On("Execute", req.Context(), testify.Anything).Return(output, errors.New("error on withdraw"))
+
+		handler := web.NewWebMovementHandler(nil, withdrawUseCase)
+		handler.Withdraw(recorder, req)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"log"
 	"lucassantoss1701/bank/internal/entity"
+	"math"
 	"net/http"
+	"strconv"
 )
 
 func encode(w http.ResponseWriter, data interface{}) {
@@ -34,15 +36,23 @@ func Err(w http.ResponseWriter, err error) {
 			statusCode = http.StatusNotFound
 		case entity.UNAUTHORIZED_ERROR:
 			statusCode = http.StatusUnauthorized
+		case entity.FORBIDDEN_ERROR:
+			statusCode = http.StatusForbidden
 		case entity.NOT_ALLOWED_ERROR:
 			statusCode = http.StatusMethodNotAllowed
 		case entity.BAD_REQUEST:
 			statusCode = http.StatusBadRequest
 		case entity.CONFLICT_ERROR:
 			statusCode = http.StatusConflict
+		case entity.TOO_MANY_REQUESTS_ERROR:
+			statusCode = http.StatusTooManyRequests
 		default:
 			statusCode = http.StatusInternalServerError
 		}
+
+		if errorHandler.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(errorHandler.RetryAfter.Seconds()))))
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
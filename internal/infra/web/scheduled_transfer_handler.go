@@ -0,0 +1,187 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebScheduledTransferHandler struct {
+	createScheduledTransfer         usecase.ICreateScheduledTransferUseCase
+	findScheduledTransfersByAccount usecase.IFindScheduledTransfersByAccountUseCase
+	changeScheduledTransferStatus   usecase.IChangeScheduledTransferStatusUseCase
+	findScheduledTransferExecutions usecase.IFindScheduledTransferExecutionsUseCase
+}
+
+func NewWebScheduledTransferHandler(createScheduledTransfer usecase.ICreateScheduledTransferUseCase, findScheduledTransfersByAccount usecase.IFindScheduledTransfersByAccountUseCase, changeScheduledTransferStatus usecase.IChangeScheduledTransferStatusUseCase, findScheduledTransferExecutions usecase.IFindScheduledTransferExecutionsUseCase) *WebScheduledTransferHandler {
+	return &WebScheduledTransferHandler{
+		createScheduledTransfer:         createScheduledTransfer,
+		findScheduledTransfersByAccount: findScheduledTransfersByAccount,
+		changeScheduledTransferStatus:   changeScheduledTransferStatus,
+		findScheduledTransferExecutions: findScheduledTransferExecutions,
+	}
+}
+
+// @Summary     Schedule transfer
+// @Description Schedule a transfer for a future date, once or recurring daily, weekly or monthly until end_at or max_executions
+// @Tags        scheduled transfers
+// @Produce     json
+// @Param       body body usecase.CreateScheduledTransferUseCaseInput true "schedule transfer request body"
+// @Param       Idempotency-Key header string false "key that makes retries of the same schedule safe"
+// @Success     201 {object} usecase.ScheduledTransferUseCaseOutput
+// @Failure     400,401,403,404,409,429,500,422
+// @Security    ApiKeyAuth
+// @Router /scheduled-transfers [post]
+func (h *WebScheduledTransferHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.CreateScheduledTransferUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewCreateScheduledTransferUseCaseInput(dto.ID, accountID, dto.DestinationAccount.ID, dto.Amount, dto.Frequency, dto.StartAt, dto.EndAt, dto.MaxExecutions, dto.TOTPCode, &createdAt)
+
+	output, err := h.createScheduledTransfer.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Find scheduled transfers
+// @Description Find the transfers scheduled by the authenticated account, newest first
+// @Tags        scheduled transfers
+// @Produce     json
+// @Param       limit query int false "number of items to be returned per page"
+// @Param       offset query int false "page offset"
+// @Param       cursor query string false "next_cursor returned by the previous page"
+// @Success     200 {object} usecase.FindScheduledTransfersByAccountUseCasePageOutput
+// @Failure     400,401,500
+// @Security    ApiKeyAuth
+// @Router /scheduled-transfers [get]
+func (h *WebScheduledTransferHandler) FindByAccountID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var err error
+	limit := 0
+	offSet := 0
+
+	queryParams := r.URL.Query()
+
+	limitStr := queryParams.Get("limit")
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+			return
+		}
+	}
+
+	offSetStr := queryParams.Get("offset")
+	if offSetStr != "" {
+		offSet, err = strconv.Atoi(offSetStr)
+		if err != nil {
+			responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+			return
+		}
+	}
+
+	input := usecase.NewFindScheduledTransfersByAccountUseCaseInput(accountID, limit, offSet, queryParams.Get("cursor"))
+	output, err := h.findScheduledTransfersByAccount.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Change scheduled transfer status
+// @Description Pause, resume or cancel a transfer scheduled by the authenticated account, status is paused, active or canceled
+// @Tags        scheduled transfers
+// @Produce     json
+// @Param       scheduled_transfer_id path string true "scheduled_transfer_id"
+// @Param       body body usecase.ChangeScheduledTransferStatusUseCaseInput true "change status request body"
+// @Success     200 {object} usecase.ScheduledTransferUseCaseOutput
+// @Failure     400,401,404,409,500,422
+// @Security    ApiKeyAuth
+// @Router /scheduled-transfers/{scheduled_transfer_id}/status [put]
+func (h *WebScheduledTransferHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.ChangeScheduledTransferStatusUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	input := usecase.NewChangeScheduledTransferStatusUseCaseInput(accountID, chi.URLParam(r, "scheduled_transfer_id"), dto.Status)
+
+	output, err := h.changeScheduledTransferStatus.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Find scheduled transfer executions
+// @Description Find the executions of a transfer scheduled by the authenticated account, newest first, with the transfer made or the reason it failed
+// @Tags        scheduled transfers
+// @Produce     json
+// @Param       scheduled_transfer_id path string true "scheduled_transfer_id"
+// @Success     200 {array} usecase.FindScheduledTransferExecutionsUseCaseOutput
+// @Failure     400,401,404,500
+// @Security    ApiKeyAuth
+// @Router /scheduled-transfers/{scheduled_transfer_id}/executions [get]
+func (h *WebScheduledTransferHandler) FindExecutions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	input := usecase.NewFindScheduledTransferExecutionsUseCaseInput(accountID, chi.URLParam(r, "scheduled_transfer_id"))
+
+	output, err := h.findScheduledTransferExecutions.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
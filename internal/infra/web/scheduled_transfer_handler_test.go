@@ -0,0 +1,160 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func NewScheduledTransferRequest(t *testing.T, method string, path string, scheduledTransferID string, loggedAccountID string, body string) *http.Request {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("scheduled_transfer_id", scheduledTransferID)
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if loggedAccountID != "" {
+		ctx = context.WithValue(ctx, web.AccountIDKey, loggedAccountID)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func GetScheduledTransferOutput() *usecase.ScheduledTransferUseCaseOutput {
+	return &usecase.ScheduledTransferUseCaseOutput{
+		ID:                   "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26",
+		OriginAccountID:      "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+		DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6",
+		Amount:               entity.NewMoney(1050, entity.BRL),
+		Frequency:            "monthly",
+		StartAt:              "2023-08-10T09:00:00Z",
+		NextRunAt:            "2023-08-10T09:00:00Z",
+		Status:               "active",
+		CreatedAt:            "2023-08-05T08:22:00Z",
+	}
+}
+
+func TestScheduledTransferHandler_Create(t *testing.T) {
+	t.Run("Testing Create with success", func(t *testing.T) {
+		body := `{"destination_account":{"id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6"},"amount":{"amount":"10.50","currency":"BRL"},"frequency":"monthly","start_at":"2023-08-10T09:00:00Z"}`
+		req := NewScheduledTransferRequest(t, http.MethodPost, "/scheduled-transfers", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477", body)
+		recorder := httptest.NewRecorder()
+
+		startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+		useCase := usecaseMock.NewCreateScheduledTransferUseCaseMock()
+		useCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CreateScheduledTransferUseCaseInput) bool {
+			return input.OriginAccount.ID == "2bd765a6-47bd-4731-9eb2-1e65542f4477" &&
+				input.DestinationAccount.ID == "d18551d3-cf13-49ec-b1dc-741a1f8715f6" &&
+				input.Amount == entity.NewMoney(1050, entity.BRL) &&
+				input.Frequency == "monthly" &&
+				input.StartAt.Equal(startAt) &&
+				input.CreatedAt != nil
+		})).Return(GetScheduledTransferOutput(), nil)
+
+		web.NewWebScheduledTransferHandler(useCase, nil, nil, nil).Create(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Testing Create with an invalid body", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodPost, "/scheduled-transfers", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477", `{"start_at":"tomorrow"}`)
+		recorder := httptest.NewRecorder()
+
+		web.NewWebScheduledTransferHandler(usecaseMock.NewCreateScheduledTransferUseCaseMock(), nil, nil, nil).Create(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Testing Create when account id not exists in context", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodPost, "/scheduled-transfers", "", "", `{}`)
+		recorder := httptest.NewRecorder()
+
+		web.NewWebScheduledTransferHandler(usecaseMock.NewCreateScheduledTransferUseCaseMock(), nil, nil, nil).Create(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestScheduledTransferHandler_FindByAccountID(t *testing.T) {
+	t.Run("Testing FindByAccountID with success", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodGet, "/scheduled-transfers?limit=5", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "")
+		recorder := httptest.NewRecorder()
+
+		useCase := usecaseMock.NewFindScheduledTransfersByAccountUseCaseMock()
+		useCase.On("Execute", req.Context(), usecase.NewFindScheduledTransfersByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", 5, 0, "")).
+			Return(&usecase.FindScheduledTransfersByAccountUseCasePageOutput{Data: []usecase.ScheduledTransferUseCaseOutput{*GetScheduledTransferOutput()}}, nil)
+
+		web.NewWebScheduledTransferHandler(nil, useCase, nil, nil).FindByAccountID(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"next_run_at":"2023-08-10T09:00:00Z"`)
+	})
+
+	t.Run("Testing FindByAccountID with an invalid limit", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodGet, "/scheduled-transfers?limit=ten", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "")
+		recorder := httptest.NewRecorder()
+
+		web.NewWebScheduledTransferHandler(nil, usecaseMock.NewFindScheduledTransfersByAccountUseCaseMock(), nil, nil).FindByAccountID(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestScheduledTransferHandler_ChangeStatus(t *testing.T) {
+	t.Run("Testing ChangeStatus with success", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodPut, "/scheduled-transfers/c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26/status", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "2bd765a6-47bd-4731-9eb2-1e65542f4477", `{"status":"paused"}`)
+		recorder := httptest.NewRecorder()
+
+		output := GetScheduledTransferOutput()
+		output.Status = "paused"
+
+		useCase := usecaseMock.NewChangeScheduledTransferStatusUseCaseMock()
+		useCase.On("Execute", req.Context(), usecase.NewChangeScheduledTransferStatusUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "paused")).Return(output, nil)
+
+		web.NewWebScheduledTransferHandler(nil, nil, useCase, nil).ChangeStatus(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"paused"`)
+	})
+
+	t.Run("Testing ChangeStatus of a schedule of another account", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodPut, "/scheduled-transfers/c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26/status", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", `{"status":"canceled"}`)
+		recorder := httptest.NewRecorder()
+
+		useCase := usecaseMock.NewChangeScheduledTransferStatusUseCaseMock()
+		useCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.ScheduledTransferUseCaseOutput)(nil), entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found scheduled transfer: c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"))
+
+		web.NewWebScheduledTransferHandler(nil, nil, useCase, nil).ChangeStatus(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestScheduledTransferHandler_FindExecutions(t *testing.T) {
+	t.Run("Testing FindExecutions with success", func(t *testing.T) {
+		req := NewScheduledTransferRequest(t, http.MethodGet, "/scheduled-transfers/c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26/executions", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "")
+		recorder := httptest.NewRecorder()
+
+		useCase := usecaseMock.NewFindScheduledTransferExecutionsUseCaseMock()
+		useCase.On("Execute", req.Context(), usecase.NewFindScheduledTransferExecutionsUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26")).
+			Return([]usecase.FindScheduledTransferExecutionsUseCaseOutput{{ID: "5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11", Status: "failed", FailureReason: "insufficient balance", ExecutedAt: "2023-08-10T09:00:30Z"}}, nil)
+
+		web.NewWebScheduledTransferHandler(nil, nil, nil, useCase).FindExecutions(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `[{"id":"5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11","status":"failed","failure_reason":"insufficient balance","executed_at":"2023-08-10T09:00:30Z"}]`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+)
+
+type WebSecretHandler struct {
+	changeSecret       usecase.IChangeSecretUseCase
+	requestSecretReset usecase.IRequestSecretResetUseCase
+	resetSecret        usecase.IResetSecretUseCase
+}
+
+func NewWebSecretHandler(changeSecret usecase.IChangeSecretUseCase, requestSecretReset usecase.IRequestSecretResetUseCase, resetSecret usecase.IResetSecretUseCase) *WebSecretHandler {
+	return &WebSecretHandler{
+		changeSecret:       changeSecret,
+		requestSecretReset: requestSecretReset,
+		resetSecret:        resetSecret,
+	}
+}
+
+// @Summary     Change secret
+// @Description Change the secret of the logged account with the current secret, every session of the account is revoked
+// @Tags        accounts
+// @Produce     json
+// @Param       body body usecase.ChangeSecretUseCaseInput true "change secret request body"
+// @Success     204
+// @Failure     400,401,403,422,429,500
+// @Security    ApiKeyAuth
+// @Router /accounts/me/secret [put]
+func (h *WebSecretHandler) ChangeSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	var dto usecase.ChangeSecretUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	input := usecase.NewChangeSecretUseCaseInput(principal.AccountID, dto.CurrentSecret, dto.NewSecret, clientIP(r), principal.TokenID, principal.TokenExpiresAt)
+
+	err = h.changeSecret.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusNoContent, nil)
+}
+
+// @Summary     Request secret reset
+// @Description Send a secret reset token to the owner of the account, the answer is the same whether the CPF has an account or not. Requests are limited per CPF and per IP with the wait and the lockout of the login
+// @Tags        accounts
+// @Produce     json
+// @Param       body body usecase.RequestSecretResetUseCaseInput true "request secret reset request body"
+// @Success     202
+// @Failure     400,422,429,500
+// @Router /secret/reset [post]
+func (h *WebSecretHandler) RequestSecretReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto usecase.RequestSecretResetUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	err = h.requestSecretReset.Execute(ctx, usecase.NewRequestSecretResetUseCaseInput(dto.CPF, clientIP(r)))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusAccepted, nil)
+}
+
+// @Summary     Reset secret
+// @Description Choose a new secret with a secret reset token, every session of the account is revoked
+// @Tags        accounts
+// @Produce     json
+// @Param       body body usecase.ResetSecretUseCaseInput true "reset secret request body"
+// @Success     204
+// @Failure     400,401,422,500
+// @Router /secret/reset/confirm [post]
+func (h *WebSecretHandler) ResetSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto usecase.ResetSecretUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	err = h.resetSecret.Execute(ctx, usecase.NewResetSecretUseCaseInput(dto.Token, dto.NewSecret))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusNoContent, nil)
+}
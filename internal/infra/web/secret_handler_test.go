@@ -0,0 +1,127 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestSecretHandler_ChangeSecret(t *testing.T) {
+	t.Run("Testing ChangeSecret with success", func(t *testing.T) {
+		expiresAt := time.Date(2023, 8, 5, 8, 37, 00, 00, time.UTC)
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		principal.TokenID = "fc84682a-3045-4bdf-b91c-10be19f89452"
+		principal.TokenExpiresAt = &expiresAt
+
+		req, _ := http.NewRequest("PUT", "/accounts/me/secret", bytes.NewBufferString(`{"current_secret":"lucas4578405","new_secret":"n3wSecretValue"}`))
+		req.RemoteAddr = "203.0.113.7:52314"
+		req = req.WithContext(context.WithValue(req.Context(), web.PrincipalKey, principal))
+		recorder := httptest.NewRecorder()
+
+		changeSecretUseCase := usecaseMock.NewChangeSecretUseCaseMock()
+		changeSecretUseCase.On("Execute", req.Context(), usecase.NewChangeSecretUseCaseInput(principal.AccountID, "lucas4578405", "n3wSecretValue", "203.0.113.7", principal.TokenID, &expiresAt)).Return(nil)
+
+		handler := web.NewWebSecretHandler(changeSecretUseCase, nil, nil)
+		handler.ChangeSecret(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		changeSecretUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Testing ChangeSecret when principal not exists in context", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", "/accounts/me/secret", bytes.NewBufferString(`{}`))
+		recorder := httptest.NewRecorder()
+
+		changeSecretUseCase := usecaseMock.NewChangeSecretUseCaseMock()
+
+		handler := web.NewWebSecretHandler(changeSecretUseCase, nil, nil)
+		handler.ChangeSecret(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		changeSecretUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeSecret when current secret is incorrect", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+
+		req, _ := http.NewRequest("PUT", "/accounts/me/secret", bytes.NewBufferString(`{"current_secret":"wrong1234","new_secret":"n3wSecretValue"}`))
+		req = req.WithContext(context.WithValue(req.Context(), web.PrincipalKey, principal))
+		recorder := httptest.NewRecorder()
+
+		changeSecretUseCase := usecaseMock.NewChangeSecretUseCaseMock()
+		changeSecretUseCase.On("Execute", req.Context(), testify.Anything).Return(entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("current secret is incorrect"))
+
+		handler := web.NewWebSecretHandler(changeSecretUseCase, nil, nil)
+		handler.ChangeSecret(recorder, req)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
+
+func TestSecretHandler_RequestSecretReset(t *testing.T) {
+	t.Run("Testing RequestSecretReset with success", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/secret/reset", bytes.NewBufferString(`{"cpf":"34688151071"}`))
+		req.RemoteAddr = "10.0.0.1:52314"
+		recorder := httptest.NewRecorder()
+
+		requestSecretResetUseCase := usecaseMock.NewRequestSecretResetUseCaseMock()
+		requestSecretResetUseCase.On("Execute", req.Context(), usecase.NewRequestSecretResetUseCaseInput("34688151071", "10.0.0.1")).Return(nil)
+
+		handler := web.NewWebSecretHandler(nil, requestSecretResetUseCase, nil)
+		handler.RequestSecretReset(recorder, req)
+
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+
+	t.Run("Testing RequestSecretReset occurs error on decode body", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/secret/reset", bytes.NewBufferString(`invalid json`))
+		recorder := httptest.NewRecorder()
+
+		requestSecretResetUseCase := usecaseMock.NewRequestSecretResetUseCaseMock()
+
+		handler := web.NewWebSecretHandler(nil, requestSecretResetUseCase, nil)
+		handler.RequestSecretReset(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		requestSecretResetUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+}
+
+func TestSecretHandler_ResetSecret(t *testing.T) {
+	t.Run("Testing ResetSecret with success", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/secret/reset/confirm", bytes.NewBufferString(`{"token":"reset","new_secret":"n3wSecretValue"}`))
+		recorder := httptest.NewRecorder()
+
+		resetSecretUseCase := usecaseMock.NewResetSecretUseCaseMock()
+		resetSecretUseCase.On("Execute", req.Context(), usecase.NewResetSecretUseCaseInput("reset", "n3wSecretValue")).Return(nil)
+
+		handler := web.NewWebSecretHandler(nil, nil, resetSecretUseCase)
+		handler.ResetSecret(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+
+	t.Run("Testing ResetSecret when token is invalid", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/secret/reset/confirm", bytes.NewBufferString(`{"token":"reset","new_secret":"n3wSecretValue"}`))
+		recorder := httptest.NewRecorder()
+
+		resetSecretUseCase := usecaseMock.NewResetSecretUseCaseMock()
+		resetSecretUseCase.On("Execute", req.Context(), testify.Anything).Return(entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("secret reset token is invalid or expired"))
+
+		handler := web.NewWebSecretHandler(nil, nil, resetSecretUseCase)
+		handler.ResetSecret(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+)
+
+type WebSessionHandler struct {
+	refreshToken usecase.IRefreshTokenUseCase
+	logout       usecase.ILogoutUseCase
+}
+
+func NewWebSessionHandler(refreshToken usecase.IRefreshTokenUseCase, logout usecase.ILogoutUseCase) *WebSessionHandler {
+	return &WebSessionHandler{
+		refreshToken: refreshToken,
+		logout:       logout,
+	}
+}
+
+// @Summary     Refresh token
+// @Description Trade a refresh token for a new access token and a new refresh token, each refresh token can be used only once
+// @Tags        accounts
+// @Produce     json
+// @Param       body body usecase.RefreshTokenUseCaseInput true "refresh token request body"
+// @Success     200 {object} usecase.TokenUseCaseOutput
+// @Failure     400,401,404,500
+// @Router /token/refresh [post]
+func (h *WebSessionHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto usecase.RefreshTokenUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	input := usecase.NewRefreshTokenUseCaseInput(dto.RefreshToken)
+
+	output, err := h.refreshToken.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Logout
+// @Description Revoke the access token used in the request and the refresh tokens of its session
+// @Tags        accounts
+// @Produce     json
+// @Success     204
+// @Failure     401,500
+// @Security    ApiKeyAuth
+// @Router /logout [post]
+func (h *WebSessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	input := usecase.NewLogoutUseCaseInput(principal.TokenID, principal.SessionID, principal.TokenExpiresAt)
+
+	err := h.logout.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,100 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/configs"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestSessionHandler_RefreshToken(t *testing.T) {
+	configs.Load()
+
+	t.Run("Testing RefreshToken with success", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBufferString(`{"refresh_token":"refresh"}`))
+		recorder := httptest.NewRecorder()
+
+		output := &usecase.TokenUseCaseOutput{Token: "xpto", RefreshToken: "new-refresh", ExpiresIn: 900}
+		refreshTokenUseCase := usecaseMock.NewRefreshTokenUseCaseMock()
+		refreshTokenUseCase.On("Execute", req.Context(), usecase.NewRefreshTokenUseCaseInput("refresh")).Return(output, nil)
+
+		handler := web.NewWebSessionHandler(refreshTokenUseCase, nil)
+		handler.RefreshToken(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"token":"xpto","refresh_token":"new-refresh","expires_in":900}`, recorder.Body.String())
+	})
+
+	t.Run("Testing RefreshToken occurs error on decode body", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBufferString(`invalid json`))
+		recorder := httptest.NewRecorder()
+
+		refreshTokenUseCase := usecaseMock.NewRefreshTokenUseCaseMock()
+
+		handler := web.NewWebSessionHandler(refreshTokenUseCase, nil)
+		handler.RefreshToken(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		refreshTokenUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing RefreshToken when usecase returns an error", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBufferString(`{"refresh_token":"refresh"}`))
+		recorder := httptest.NewRecorder()
+
+		refreshTokenUseCase := usecaseMock.NewRefreshTokenUseCaseMock()
+		refreshTokenUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(&usecase.TokenUseCaseOutput{}, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("refresh token is invalid"))
+
+		handler := web.NewWebSessionHandler(refreshTokenUseCase, nil)
+		handler.RefreshToken(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
+func TestSessionHandler_Logout(t *testing.T) {
+	t.Run("Testing Logout with success", func(t *testing.T) {
+		expiresAt := time.Date(2023, 8, 5, 8, 37, 00, 00, time.UTC)
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		principal.TokenID = "fc84682a-3045-4bdf-b91c-10be19f89452"
+		principal.SessionID = "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d"
+		principal.TokenExpiresAt = &expiresAt
+
+		req, _ := http.NewRequest("POST", "/logout", nil)
+		req = req.WithContext(context.WithValue(req.Context(), web.PrincipalKey, principal))
+		recorder := httptest.NewRecorder()
+
+		logoutUseCase := usecaseMock.NewLogoutUseCaseMock()
+		logoutUseCase.On("Execute", req.Context(), usecase.NewLogoutUseCaseInput(principal.TokenID, principal.SessionID, &expiresAt)).Return(nil)
+
+		handler := web.NewWebSessionHandler(nil, logoutUseCase)
+		handler.Logout(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		logoutUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Testing Logout when principal not exists in context", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/logout", nil)
+		recorder := httptest.NewRecorder()
+
+		logoutUseCase := usecaseMock.NewLogoutUseCaseMock()
+
+		handler := web.NewWebSessionHandler(nil, logoutUseCase)
+		handler.Logout(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		logoutUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebTransferBatchHandler struct {
+	createTransferBatch usecase.ICreateTransferBatchUseCase
+	findTransferBatch   usecase.IFindTransferBatchUseCase
+}
+
+func NewWebTransferBatchHandler(createTransferBatch usecase.ICreateTransferBatchUseCase, findTransferBatch usecase.IFindTransferBatchUseCase) *WebTransferBatchHandler {
+	return &WebTransferBatchHandler{
+		createTransferBatch: createTransferBatch,
+		findTransferBatch:   findTransferBatch,
+	}
+}
+
+// @Summary     Create transfer batch
+// @Description Send many transfers from the authenticated account, the batch is checked up front and processed in the background. An atomic batch makes every transfer or none, a best_effort batch makes each transfer on its own. Poll the batch for the outcome of each item
+// @Tags        transfers
+// @Produce     json
+// @Param       body body usecase.CreateTransferBatchUseCaseInput true "create transfer batch request body"
+// @Param       Idempotency-Key header string false "key that makes retries of the same batch safe"
+// @Success     202 {object} usecase.TransferBatchUseCaseOutput
+// @Failure     400,401,403,404,409,429,500,422
+// @Security    ApiKeyAuth
+// @Router /transfers/batch [post]
+func (h *WebTransferBatchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.CreateTransferBatchUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewCreateTransferBatchUseCaseInput(dto.ID, accountID, dto.Mode, dto.Items, dto.TOTPCode, &createdAt)
+
+	output, err := h.createTransferBatch.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusAccepted, output)
+}
+
+// @Summary     Find transfer batch
+// @Description Find a transfer batch with the outcome of each item, customers can only read the batches of their own account
+// @Tags        transfers
+// @Produce     json
+// @Param       batch_id path string true "batch_id"
+// @Success     200 {object} usecase.TransferBatchUseCaseOutput
+// @Failure     401,404,500
+// @Security    ApiKeyAuth
+// @Router /transfers/batch/{batch_id} [get]
+func (h *WebTransferBatchHandler) FindByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	input := usecase.NewFindTransferBatchUseCaseInput(chi.URLParam(r, "batch_id"), principal)
+
+	output, err := h.findTransferBatch.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
@@ -0,0 +1,138 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetTransferBatchOutput() *usecase.TransferBatchUseCaseOutput {
+	return &usecase.TransferBatchUseCaseOutput{
+		ID:              "5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e",
+		OriginAccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+		Mode:            "atomic",
+		Status:          "pending",
+		Total:           entity.NewMoney(8000, entity.BRL),
+		Items: []usecase.TransferBatchItemUseCaseOutput{
+			{Position: 0, DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Amount: entity.NewMoney(5000, entity.BRL), Status: "pending"},
+			{Position: 1, DestinationAccountID: "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", Amount: entity.NewMoney(3000, entity.BRL), Status: "pending"},
+		},
+		CreatedAt: "2023-08-14T10:00:00Z",
+		UpdatedAt: "2023-08-14T10:00:00Z",
+	}
+}
+
+func GetTransferBatchRequest(t *testing.T, method string, path string, body string, principal *entity.Principal) *http.Request {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("batch_id", "5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e")
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if principal != nil {
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
+		ctx = context.WithValue(ctx, web.AccountIDKey, principal.AccountID)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestTransferBatchHandler_Create(t *testing.T) {
+	t.Run("Testing Create accepts the batch", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetTransferBatchRequest(t, "POST", "/transfers/batch", `{"mode":"atomic","items":[
+			{"destination_account":{"id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6"},"amount":{"amount":"50.00","currency":"BRL"}},
+			{"destination_account":{"id":"6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"},"amount":{"amount":"30.00","currency":"BRL"}}
+		]}`, principal)
+		recorder := httptest.NewRecorder()
+
+		createTransferBatchUseCase := usecaseMock.NewCreateTransferBatchUseCaseMock()
+		createTransferBatchUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CreateTransferBatchUseCaseInput) bool {
+			return input.OriginAccount.ID == principal.AccountID && input.Mode == "atomic" && len(input.Items) == 2 &&
+				input.Items[1].DestinationAccount.ID == "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6" && input.Items[1].Amount == entity.NewMoney(3000, entity.BRL) && input.CreatedAt != nil
+		})).Return(GetTransferBatchOutput(), nil)
+
+		handler := web.NewWebTransferBatchHandler(createTransferBatchUseCase, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+		assert.JSONEq(t, `{
+			"id":"5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e",
+			"origin_account_id":"2bd765a6-47bd-4731-9eb2-1e65542f4477",
+			"mode":"atomic",
+			"status":"pending",
+			"total":{"amount":"80.00","currency":"BRL"},
+			"succeeded":0,
+			"failed":0,
+			"items":[
+				{"position":0,"destination_account_id":"d18551d3-cf13-49ec-b1dc-741a1f8715f6","amount":{"amount":"50.00","currency":"BRL"},"status":"pending"},
+				{"position":1,"destination_account_id":"6ac7ebbf-568b-45f2-a295-bfbab73f1cf6","amount":{"amount":"30.00","currency":"BRL"},"status":"pending"}
+			],
+			"created_at":"2023-08-14T10:00:00Z",
+			"updated_at":"2023-08-14T10:00:00Z"
+		}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Create with an invalid body", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetTransferBatchRequest(t, "POST", "/transfers/batch", "{invalid", principal)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferBatchHandler(usecaseMock.NewCreateTransferBatchUseCaseMock(), nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Testing Create when a destination account does not exist", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetTransferBatchRequest(t, "POST", "/transfers/batch", `{"mode":"best_effort","items":[{"destination_account":{"id":"fc84682a-3045-4bdf-b91c-10be19f89452"},"amount":{"amount":"50.00","currency":"BRL"}}]}`, principal)
+		recorder := httptest.NewRecorder()
+
+		createTransferBatchUseCase := usecaseMock.NewCreateTransferBatchUseCaseMock()
+		createTransferBatchUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.TransferBatchUseCaseOutput)(nil), entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("item 0: not found account: fc84682a-3045-4bdf-b91c-10be19f89452"))
+
+		handler := web.NewWebTransferBatchHandler(createTransferBatchUseCase, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestTransferBatchHandler_FindByID(t *testing.T) {
+	t.Run("Testing FindByID with success", func(t *testing.T) {
+		principal := entity.NewPrincipal("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.CUSTOMER_ROLE)
+		req := GetTransferBatchRequest(t, "GET", "/transfers/batch/5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", "", principal)
+		recorder := httptest.NewRecorder()
+
+		findTransferBatchUseCase := usecaseMock.NewFindTransferBatchUseCaseMock()
+		findTransferBatchUseCase.On("Execute", req.Context(), usecase.NewFindTransferBatchUseCaseInput("5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", principal)).Return(GetTransferBatchOutput(), nil)
+
+		handler := web.NewWebTransferBatchHandler(nil, findTransferBatchUseCase)
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"id":"5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e"`)
+	})
+
+	t.Run("Testing FindByID without principal", func(t *testing.T) {
+		req := GetTransferBatchRequest(t, "GET", "/transfers/batch/5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", "", nil)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferBatchHandler(nil, usecaseMock.NewFindTransferBatchUseCaseMock())
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -2,37 +2,48 @@ package web
 
 import (
 	"encoding/json"
+	"io"
 	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/web/responses"
 	"lucassantoss1701/bank/internal/usecase"
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type ContextKey string
 
-const AccountIDKey ContextKey = "account_id"
+const (
+	AccountIDKey ContextKey = "account_id"
+	PrincipalKey ContextKey = "principal"
+)
 
 type WebTransferHandler struct {
 	makeTransfer          usecase.IMakeTransferUseCase
 	findTransferByAccount usecase.IFindTransfersByAccountUseCase
+	reverseTransfer       usecase.IReverseTransferUseCase
+	findTransfer          usecase.IFindTransferUseCase
 }
 
-func NewWebTransferHandler(makeTransfer usecase.IMakeTransferUseCase, findTransferByAccount usecase.IFindTransfersByAccountUseCase) *WebTransferHandler {
+func NewWebTransferHandler(makeTransfer usecase.IMakeTransferUseCase, findTransferByAccount usecase.IFindTransfersByAccountUseCase, reverseTransfer usecase.IReverseTransferUseCase, findTransfer usecase.IFindTransferUseCase) *WebTransferHandler {
 	return &WebTransferHandler{
 		makeTransfer:          makeTransfer,
 		findTransferByAccount: findTransferByAccount,
+		reverseTransfer:       reverseTransfer,
+		findTransfer:          findTransfer,
 	}
 }
 
 // @Summary     Create transfer
-// @Description Create transfer between two accounts
+// @Description Create transfer between two accounts, the destination account is informed by its id, by one of its keys or by a saved beneficiary
 // @Tags        transfers
 // @Produce     json
 // @Param       body body usecase.MakeTransferUseCaseInput true "make transfer request body"
+// @Param       Idempotency-Key header string false "key that makes retries of the same transfer safe"
 // @Success     201 {object} usecase.MakeTransferUseCaseOutput
-// @Failure     400,401,404,500,422
+// @Failure     400,401,403,404,409,429,500,422
 // @Security    ApiKeyAuth
 // @Router /transfers [post]
 func (h *WebTransferHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -52,7 +63,9 @@ func (h *WebTransferHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	createdAt := time.Now()
-	input := usecase.NewMakeTransferUseCaseInput(dto.ID, accountID, dto.DestinationAccount.ID, dto.Amount, &createdAt)
+	input := usecase.NewMakeTransferUseCaseInput(dto.ID, accountID, dto.DestinationAccount.ID, dto.Amount, dto.TOTPCode, &createdAt)
+	input.DestinationAccount.Key = dto.DestinationAccount.Key
+	input.DestinationAccount.BeneficiaryID = dto.DestinationAccount.BeneficiaryID
 
 	output, err := h.makeTransfer.Execute(ctx, input)
 	if err != nil {
@@ -63,26 +76,129 @@ func (h *WebTransferHandler) Create(w http.ResponseWriter, r *http.Request) {
 	responses.Success(w, http.StatusCreated, output)
 }
 
+// @Summary     Reverse transfer
+// @Description Give back all or part of a transfer with a transfer linked to it, the recipient can refund the transfers it received and admins can reverse any transfer
+// @Tags        transfers
+// @Produce     json
+// @Param       transfer_id path string true "transfer_id"
+// @Param       body body usecase.ReverseTransferUseCaseInput false "reverse transfer request body, the whole reversible amount when empty"
+// @Param       Idempotency-Key header string false "key that makes retries of the same reversal safe"
+// @Success     201 {object} usecase.ReverseTransferUseCaseOutput
+// @Failure     400,401,403,404,409,429,500,422
+// @Security    ApiKeyAuth
+// @Router /transfers/{transfer_id}/reversals [post]
+func (h *WebTransferHandler) Reverse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	var dto usecase.ReverseTransferUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil && err != io.EOF {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewReverseTransferUseCaseInput(dto.ID, chi.URLParam(r, "transfer_id"), principal, dto.Amount, &createdAt)
+
+	output, err := h.reverseTransfer.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Find transfer
+// @Description Find a transfer with its status history, customers can only read the transfers of their own account
+// @Tags        transfers
+// @Produce     json
+// @Param       transfer_id path string true "transfer_id"
+// @Success     200 {object} usecase.FindTransferUseCaseOutput
+// @Failure     401,404,500
+// @Security    ApiKeyAuth
+// @Router /transfers/{transfer_id} [get]
+func (h *WebTransferHandler) FindByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	input := usecase.NewFindTransferUseCaseInput(chi.URLParam(r, "transfer_id"), principal)
+
+	output, err := h.findTransfer.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
 // @Summary     Find transfers by account
-// @Description Find transfers from an account(user needs to be authenticated)
+// @Description Find transfers sent and received by an account, newest first(user needs to be authenticated)
 // @Tags        transfers
 // @Produce     json
 // @Param       limit query int false "number of items to be returned per page"
 // @Param       offset query int false "page offset"
-// @Success     200 {array} usecase.MakeTransferUseCaseOutput
+// @Param       cursor query string false "next_cursor returned by the previous page"
+// @Param       direction query string false "incoming or outgoing, both when empty"
+// @Param       from query string false "transfers created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param       to query string false "transfers created at or before (RFC3339 or YYYY-MM-DD)"
+// @Param       min_amount query string false "minimum amount, as a decimal such as 10.50"
+// @Param       max_amount query string false "maximum amount, as a decimal such as 10.50"
+// @Param       currency query string false "currency of min_amount and max_amount, BRL when empty"
+// @Param       counterparty_id query string false "id of the other account of the transfer"
+// @Success     200 {object} usecase.FindTransfersByAccountUseCasePageOutput
 // @Failure     400,401,404,500
 // @Security    ApiKeyAuth
 // @Router /transfers [get]
 func (h *WebTransferHandler) FindByAccountID(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	var err error
-	accountID, ok := ctx.Value(AccountIDKey).(string)
+	accountID, ok := r.Context().Value(AccountIDKey).(string)
 	if !ok {
 		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
 		return
 	}
 
+	h.findTransfers(w, r, accountID)
+}
+
+// @Summary     Find transfers of an account
+// @Description Find the transfers sent and received by a specific account, customers can only read their own account
+// @Tags        transfers
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Param       limit query int false "number of items to be returned per page"
+// @Param       offset query int false "page offset"
+// @Param       cursor query string false "next_cursor returned by the previous page"
+// @Param       direction query string false "incoming or outgoing, both when empty"
+// @Param       from query string false "transfers created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param       to query string false "transfers created at or before (RFC3339 or YYYY-MM-DD)"
+// @Param       min_amount query string false "minimum amount, as a decimal such as 10.50"
+// @Param       max_amount query string false "maximum amount, as a decimal such as 10.50"
+// @Param       currency query string false "currency of min_amount and max_amount, BRL when empty"
+// @Param       counterparty_id query string false "id of the other account of the transfer"
+// @Success     200 {object} usecase.FindTransfersByAccountUseCasePageOutput
+// @Failure     400,401,403,404,500
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/transfers [get]
+func (h *WebTransferHandler) FindByAccount(w http.ResponseWriter, r *http.Request) {
+	h.findTransfers(w, r, chi.URLParam(r, "account_id"))
+}
+
+func (h *WebTransferHandler) findTransfers(w http.ResponseWriter, r *http.Request, accountID string) {
+	ctx := r.Context()
+
+	var err error
 	limit := 0
 	offSet := 0
 
@@ -97,10 +213,6 @@ func (h *WebTransferHandler) FindByAccountID(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	if limit == 0 {
-		limit = 20
-	}
-
 	offSetStr := queryParams.Get("offset")
 	if offSetStr != "" {
 		offSet, err = strconv.Atoi(offSetStr)
@@ -110,7 +222,37 @@ func (h *WebTransferHandler) FindByAccountID(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, limit, offSet)
+	from, err := parseDateQueryParam(queryParams.Get("from"), false)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	to, err := parseDateQueryParam(queryParams.Get("to"), true)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	currency := queryParams.Get("currency")
+	if currency == "" {
+		currency = string(entity.DEFAULT_CURRENCY)
+	}
+
+	minAmount, err := parseMoneyQueryParam(queryParams.Get("min_amount"), currency)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	maxAmount, err := parseMoneyQueryParam(queryParams.Get("max_amount"), currency)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, limit, offSet, queryParams.Get("cursor")).
+		WithFilter(queryParams.Get("direction"), from, to, minAmount, maxAmount, queryParams.Get("counterparty_id"))
 	output, err := h.findTransferByAccount.Execute(ctx, input)
 	if err != nil {
 		responses.Err(w, err)
@@ -119,3 +261,39 @@ func (h *WebTransferHandler) FindByAccountID(w http.ResponseWriter, r *http.Requ
 
 	responses.Success(w, http.StatusOK, output)
 }
+
+// parseDateQueryParam accepts RFC3339 or a plain date; a plain date used as upper bound covers the whole day.
+func parseDateQueryParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return &date, nil
+	}
+
+	date, err = time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Second)
+	}
+
+	return &date, nil
+}
+
+func parseMoneyQueryParam(value string, currency string) (*entity.Money, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	money, err := entity.ParseMoney(value, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &money, nil
+}
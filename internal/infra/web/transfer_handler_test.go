@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	testify "github.com/stretchr/testify/mock"
 )
@@ -22,8 +23,8 @@ func GetBaseOriginAccount(t *testing.T) *entity.Account {
 	originAccountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 	originAccountName := "lucas"
 	originAccountCPF := "52849254088"
-	originAccountsecret := "4578405"
-	originAccountBalance := 100
+	originAccountsecret := "lucas4578405"
+	originAccountBalance := entity.NewMoney(100, entity.BRL)
 	originAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	originAccount, err := entity.NewAccount(originAccountID, originAccountName, originAccountCPF, originAccountsecret, originAccountBalance, &originAccountCreatedAt)
 
@@ -36,8 +37,8 @@ func GetBaseDestinationAccount(t *testing.T) *entity.Account {
 	destionationAccountID := "d18551d3-cf13-49ec-b1dc-741a1f8715f6"
 	destionationAccountName := "joao"
 	destionationAccountCPF := "35768297090"
-	destionationAccountSecret := "744637"
-	destionationAccountBalance := 200
+	destionationAccountSecret := "jaque744637"
+	destionationAccountBalance := entity.NewMoney(200, entity.BRL)
 	destionationAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	destinationAccount, err := entity.NewAccount(destionationAccountID, destionationAccountName, destionationAccountCPF, destionationAccountSecret, destionationAccountBalance, &destionationAccountCreatedAt)
 
@@ -52,7 +53,7 @@ func TestTransferHandler_Create(t *testing.T) {
 		originAccount := GetBaseOriginAccount(t)
 		destinationAccount := GetBaseDestinationAccount(t)
 
-		input := usecase.NewMakeTransferUseCaseInput(transfer.ID, originAccount.ID, destinationAccount.ID, transfer.Amount, transfer.CreatedAt)
+		input := usecase.NewMakeTransferUseCaseInput(transfer.ID, originAccount.ID, destinationAccount.ID, transfer.Amount, "", transfer.CreatedAt)
 
 		jsonData, _ := json.Marshal(input)
 		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBuffer(jsonData))
@@ -65,19 +66,63 @@ func TestTransferHandler_Create(t *testing.T) {
 		usecase := usecaseMock.NewMakeTransferUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(usecase, nil)
+		handler := web.NewWebTransferHandler(usecase, nil, nil, nil)
 
 		handler.Create(recorder, req)
 
 		assert.Equal(t, http.StatusCreated, recorder.Code)
 	})
 
+	t.Run("Testing Create to a key of the destination account", func(t *testing.T) {
+		transfer := mock.CreateTransfer()
+		originAccount := GetBaseOriginAccount(t)
+
+		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBufferString(`{"destination_account":{"key":"joao@example.com"},"amount":{"amount":"50.00","currency":"BRL"}}`))
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, originAccount.ID))
+
+		recorder := httptest.NewRecorder()
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.MakeTransferUseCaseInput) bool {
+			return input.DestinationAccount.ID == "" && input.DestinationAccount.Key == "joao@example.com"
+		})).Return(usecase.NewMakeTransferUseCaseOutput(&transfer), nil)
+
+		handler := web.NewWebTransferHandler(makeTransferUseCase, nil, nil, nil)
+
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		makeTransferUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Testing Create to a beneficiary of the origin account", func(t *testing.T) {
+		transfer := mock.CreateTransfer()
+		originAccount := GetBaseOriginAccount(t)
+
+		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBufferString(`{"destination_account":{"beneficiary_id":"6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60"},"amount":{"amount":"50.00","currency":"BRL"}}`))
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, originAccount.ID))
+
+		recorder := httptest.NewRecorder()
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.MakeTransferUseCaseInput) bool {
+			return input.DestinationAccount.ID == "" && input.DestinationAccount.BeneficiaryID == "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60"
+		})).Return(usecase.NewMakeTransferUseCaseOutput(&transfer), nil)
+
+		handler := web.NewWebTransferHandler(makeTransferUseCase, nil, nil, nil)
+
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		makeTransferUseCase.AssertExpectations(t)
+	})
+
 	t.Run("Testing Create when account id not exists in context", func(t *testing.T) {
 		transfer := mock.CreateTransfer()
 		originAccount := GetBaseOriginAccount(t)
 		destinationAccount := GetBaseDestinationAccount(t)
 
-		input := usecase.NewMakeTransferUseCaseInput(transfer.ID, originAccount.ID, destinationAccount.ID, transfer.Amount, transfer.CreatedAt)
+		input := usecase.NewMakeTransferUseCaseInput(transfer.ID, originAccount.ID, destinationAccount.ID, transfer.Amount, "", transfer.CreatedAt)
 
 		jsonData, _ := json.Marshal(input)
 		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBuffer(jsonData))
@@ -88,7 +133,7 @@ func TestTransferHandler_Create(t *testing.T) {
 		usecase := usecaseMock.NewMakeTransferUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(usecase, nil)
+		handler := web.NewWebTransferHandler(usecase, nil, nil, nil)
 
 		handler.Create(recorder, req)
 
@@ -109,7 +154,7 @@ func TestTransferHandler_Create(t *testing.T) {
 		usecase := usecaseMock.NewMakeTransferUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(usecase, nil)
+		handler := web.NewWebTransferHandler(usecase, nil, nil, nil)
 
 		handler.Create(recorder, req)
 
@@ -121,7 +166,7 @@ func TestTransferHandler_Create(t *testing.T) {
 		originAccount := GetBaseOriginAccount(t)
 		destinationAccount := GetBaseDestinationAccount(t)
 
-		input := usecase.NewMakeTransferUseCaseInput(transfer.ID, originAccount.ID, destinationAccount.ID, transfer.Amount, transfer.CreatedAt)
+		input := usecase.NewMakeTransferUseCaseInput(transfer.ID, originAccount.ID, destinationAccount.ID, transfer.Amount, "", transfer.CreatedAt)
 
 		jsonData, _ := json.Marshal(input)
 		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBuffer(jsonData))
@@ -134,7 +179,7 @@ func TestTransferHandler_Create(t *testing.T) {
 		usecase := usecaseMock.NewMakeTransferUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, entity.NewErrorHandler(entity.INTERNAL_ERROR))
 
-		handler := web.NewWebTransferHandler(usecase, nil)
+		handler := web.NewWebTransferHandler(usecase, nil, nil, nil)
 
 		handler.Create(recorder, req)
 
@@ -154,12 +199,12 @@ func TestTransferHandler_FindByAccountID(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		var output []usecase.FindTransfersByAccountUseCaseOutput
-		output = append(output, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer))
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer, originAccount.ID))
 		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(nil, usecase)
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
 
 		handler.FindByAccountID(recorder, req)
 
@@ -173,12 +218,12 @@ func TestTransferHandler_FindByAccountID(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		var output []usecase.FindTransfersByAccountUseCaseOutput
-		output = append(output, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer))
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer, transfer.OriginAccount.ID))
 		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(nil, usecase)
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
 
 		handler.FindByAccountID(recorder, req)
 
@@ -195,12 +240,12 @@ func TestTransferHandler_FindByAccountID(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		var output []usecase.FindTransfersByAccountUseCaseOutput
-		output = append(output, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer))
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer, originAccount.ID))
 		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(nil, usecase)
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
 
 		handler.FindByAccountID(recorder, req)
 
@@ -217,12 +262,12 @@ func TestTransferHandler_FindByAccountID(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		var output []usecase.FindTransfersByAccountUseCaseOutput
-		output = append(output, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer))
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer, originAccount.ID))
 		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, nil)
 
-		handler := web.NewWebTransferHandler(nil, usecase)
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
 
 		handler.FindByAccountID(recorder, req)
 
@@ -239,12 +284,12 @@ func TestTransferHandler_FindByAccountID(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		var output []usecase.FindTransfersByAccountUseCaseOutput
-		output = append(output, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer))
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		output.Data = append(output.Data, *usecase.NewFindTransfersByAccountUseCaseOutput(transfer, originAccount.ID))
 		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
 		usecase.On("Execute", req.Context(), testify.Anything).Return(output, entity.NewErrorHandler(entity.INTERNAL_ERROR))
 
-		handler := web.NewWebTransferHandler(nil, usecase)
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
 
 		handler.FindByAccountID(recorder, req)
 
@@ -252,3 +297,242 @@ func TestTransferHandler_FindByAccountID(t *testing.T) {
 	})
 
 }
+
+func TestTransferHandler_FindByAccountIDWithFilters(t *testing.T) {
+	t.Run("Testing FindByAccountID passes the filters to the usecase", func(t *testing.T) {
+		originAccount := GetBaseOriginAccount(t)
+
+		req, _ := http.NewRequest("GET", "/transfers?direction=incoming&from=2023-08-01&to=2023-08-31&min_amount=10.50&max_amount=500&counterparty_id=d18551d3-cf13-49ec-b1dc-741a1f8715f6", nil)
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, originAccount.ID))
+
+		recorder := httptest.NewRecorder()
+
+		minAmount := entity.NewMoney(1050, entity.BRL)
+		maxAmount := entity.NewMoney(50000, entity.BRL)
+		from := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 8, 31, 23, 59, 59, 0, time.UTC)
+		expectedInput := usecase.NewFindTransfersByAccountUseCaseInput(originAccount.ID, 0, 0, "").
+			WithFilter("incoming", &from, &to, &minAmount, &maxAmount, "d18551d3-cf13-49ec-b1dc-741a1f8715f6")
+
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
+		usecase.On("Execute", req.Context(), expectedInput).Return(output, nil)
+
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
+
+		handler.FindByAccountID(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		usecase.AssertExpectations(t)
+	})
+
+	for _, query := range []string{"from=yesterday", "to=2023-13-01", "min_amount=ten", "max_amount=1.505", "min_amount=10&currency=XYZ"} {
+		t.Run("Testing FindByAccountID with invalid filter "+query, func(t *testing.T) {
+			originAccount := GetBaseOriginAccount(t)
+
+			req, _ := http.NewRequest("GET", "/transfers?"+query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, originAccount.ID))
+
+			recorder := httptest.NewRecorder()
+
+			usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
+			handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
+
+			handler.FindByAccountID(recorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			usecase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+		})
+	}
+}
+
+func TestTransferHandler_FindByAccount(t *testing.T) {
+	t.Run("Testing FindByAccount reads the account from the path", func(t *testing.T) {
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		req, _ := http.NewRequest("GET", "/accounts/"+destinationAccount.ID+"/transfers?direction=incoming", nil)
+
+		routeContext := chi.NewRouteContext()
+		routeContext.URLParams.Add("account_id", destinationAccount.ID)
+
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+		ctx = context.WithValue(ctx, web.AccountIDKey, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		req = req.WithContext(ctx)
+
+		recorder := httptest.NewRecorder()
+
+		expectedInput := usecase.NewFindTransfersByAccountUseCaseInput(destinationAccount.ID, 0, 0, "").
+			WithFilter("incoming", nil, nil, nil, nil, "")
+
+		output := &usecase.FindTransfersByAccountUseCasePageOutput{}
+		usecase := usecaseMock.NewFindTransfersByAccountUseCaseMock()
+		usecase.On("Execute", req.Context(), expectedInput).Return(output, nil)
+
+		handler := web.NewWebTransferHandler(nil, usecase, nil, nil)
+
+		handler.FindByAccount(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		usecase.AssertExpectations(t)
+	})
+}
+
+func GetReverseTransferRequest(t *testing.T, body string, principal *entity.Principal) *http.Request {
+	req, err := http.NewRequest("POST", "/transfers/237d3e7e-2f46-44e7-bf2b-f79721459241/reversals", bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("transfer_id", "237d3e7e-2f46-44e7-bf2b-f79721459241")
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if principal != nil {
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestTransferHandler_Reverse(t *testing.T) {
+	t.Run("Testing Reverse with success", func(t *testing.T) {
+		transfer := mock.CreateTransfer()
+		transfer.ReversalOf = "237d3e7e-2f46-44e7-bf2b-f79721459241"
+
+		principal := entity.NewPrincipal(GetBaseDestinationAccount(t).ID, entity.CUSTOMER_ROLE)
+		req := GetReverseTransferRequest(t, `{"amount": {"amount": "2.00", "currency": "BRL"}}`, principal)
+		recorder := httptest.NewRecorder()
+
+		output := &usecase.ReverseTransferUseCaseOutput{
+			MakeTransferUseCaseOutput: *usecase.NewMakeTransferUseCaseOutput(&transfer),
+			ReversibleAmount:          entity.NewMoney(300, entity.BRL),
+		}
+		reverseTransferUseCase := usecaseMock.NewReverseTransferUseCaseMock()
+		reverseTransferUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.ReverseTransferUseCaseInput) bool {
+			return input.TransferID == "237d3e7e-2f46-44e7-bf2b-f79721459241" && input.Principal == principal &&
+				*input.Amount == entity.NewMoney(200, entity.BRL) && input.CreatedAt != nil
+		})).Return(output, nil)
+
+		handler := web.NewWebTransferHandler(nil, nil, reverseTransferUseCase, nil)
+		handler.Reverse(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"reversal_of":"237d3e7e-2f46-44e7-bf2b-f79721459241"`)
+		assert.Contains(t, recorder.Body.String(), `"reversible_amount":{"amount":"3.00","currency":"BRL"}`)
+	})
+
+	t.Run("Testing Reverse without a body reverses the whole reversible amount", func(t *testing.T) {
+		transfer := mock.CreateTransfer()
+		principal := entity.NewPrincipal(GetBaseDestinationAccount(t).ID, entity.CUSTOMER_ROLE)
+		req := GetReverseTransferRequest(t, "", principal)
+		recorder := httptest.NewRecorder()
+
+		output := &usecase.ReverseTransferUseCaseOutput{MakeTransferUseCaseOutput: *usecase.NewMakeTransferUseCaseOutput(&transfer)}
+		reverseTransferUseCase := usecaseMock.NewReverseTransferUseCaseMock()
+		reverseTransferUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.ReverseTransferUseCaseInput) bool {
+			return input.Amount == nil
+		})).Return(output, nil)
+
+		handler := web.NewWebTransferHandler(nil, nil, reverseTransferUseCase, nil)
+		handler.Reverse(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+	})
+
+	t.Run("Testing Reverse without principal", func(t *testing.T) {
+		req := GetReverseTransferRequest(t, "", nil)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferHandler(nil, nil, usecaseMock.NewReverseTransferUseCaseMock(), nil)
+		handler.Reverse(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("Testing Reverse with an invalid body", func(t *testing.T) {
+		principal := entity.NewPrincipal(GetBaseDestinationAccount(t).ID, entity.CUSTOMER_ROLE)
+		req := GetReverseTransferRequest(t, "{invalid", principal)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferHandler(nil, nil, usecaseMock.NewReverseTransferUseCaseMock(), nil)
+		handler.Reverse(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Testing Reverse when the sender asks for the reversal", func(t *testing.T) {
+		principal := entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE)
+		req := GetReverseTransferRequest(t, "", principal)
+		recorder := httptest.NewRecorder()
+
+		reverseTransferUseCase := usecaseMock.NewReverseTransferUseCaseMock()
+		reverseTransferUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.ReverseTransferUseCaseOutput)(nil), entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("only the recipient can refund the transfer"))
+
+		handler := web.NewWebTransferHandler(nil, nil, reverseTransferUseCase, nil)
+		handler.Reverse(recorder, req)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
+
+func GetFindTransferRequest(t *testing.T, principal *entity.Principal) *http.Request {
+	req, err := http.NewRequest("GET", "/transfers/237d3e7e-2f46-44e7-bf2b-f79721459241", nil)
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("transfer_id", "237d3e7e-2f46-44e7-bf2b-f79721459241")
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if principal != nil {
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestTransferHandler_FindByID(t *testing.T) {
+	t.Run("Testing FindByID with success", func(t *testing.T) {
+		transfer := mock.CreateTransfer()
+		changedAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		transfer.StatusHistory = []entity.TransferStatusChange{
+			{Status: entity.PENDING_TRANSFER, ChangedAt: &changedAt},
+			{Status: entity.COMPLETED_TRANSFER, ChangedAt: &changedAt},
+		}
+
+		principal := entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE)
+		req := GetFindTransferRequest(t, principal)
+		recorder := httptest.NewRecorder()
+
+		findTransferUseCase := usecaseMock.NewFindTransferUseCaseMock()
+		findTransferUseCase.On("Execute", req.Context(), usecase.NewFindTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", principal)).Return(usecase.NewFindTransferUseCaseOutput(&transfer), nil)
+
+		handler := web.NewWebTransferHandler(nil, nil, nil, findTransferUseCase)
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"completed"`)
+		assert.Contains(t, recorder.Body.String(), `"status_history":[{"status":"pending","changed_at":"2023-08-07T10:00:00Z"},{"status":"completed","changed_at":"2023-08-07T10:00:00Z"}]`)
+	})
+
+	t.Run("Testing FindByID without principal", func(t *testing.T) {
+		req := GetFindTransferRequest(t, nil)
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferHandler(nil, nil, nil, usecaseMock.NewFindTransferUseCaseMock())
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("Testing FindByID when the transfer is not found", func(t *testing.T) {
+		principal := entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE)
+		req := GetFindTransferRequest(t, principal)
+		recorder := httptest.NewRecorder()
+
+		findTransferUseCase := usecaseMock.NewFindTransferUseCaseMock()
+		findTransferUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.FindTransferUseCaseOutput)(nil), entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found transfer: 237d3e7e-2f46-44e7-bf2b-f79721459241"))
+
+		handler := web.NewWebTransferHandler(nil, nil, nil, findTransferUseCase)
+		handler.FindByID(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
@@ -0,0 +1,160 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebTransferKeyHandler struct {
+	createTransferKey         usecase.ICreateTransferKeyUseCase
+	findTransferKeysByAccount usecase.IFindTransferKeysByAccountUseCase
+	findTransferKey           usecase.IFindTransferKeyUseCase
+	deleteTransferKey         usecase.IDeleteTransferKeyUseCase
+}
+
+func NewWebTransferKeyHandler(createTransferKey usecase.ICreateTransferKeyUseCase, findTransferKeysByAccount usecase.IFindTransferKeysByAccountUseCase, findTransferKey usecase.IFindTransferKeyUseCase, deleteTransferKey usecase.IDeleteTransferKeyUseCase) *WebTransferKeyHandler {
+	return &WebTransferKeyHandler{
+		createTransferKey:         createTransferKey,
+		findTransferKeysByAccount: findTransferKeysByAccount,
+		findTransferKey:           findTransferKey,
+		deleteTransferKey:         deleteTransferKey,
+	}
+}
+
+// @Summary     Create transfer key
+// @Description Register a key of the authenticated account, a CPF, an email, a phone or a random key (evp) generated by the bank, that can be used instead of the account id to receive transfers
+// @Tags        transfer keys
+// @Produce     json
+// @Param       body body usecase.CreateTransferKeyUseCaseInput true "create transfer key request body"
+// @Success     201 {object} usecase.TransferKeyUseCaseOutput
+// @Failure     400,401,409,500,422
+// @Security    ApiKeyAuth
+// @Router /transfer-keys [post]
+func (h *WebTransferKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.CreateTransferKeyUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	createdAt := time.Now()
+	input := usecase.NewCreateTransferKeyUseCaseInput(accountID, dto.Type, dto.Key, &createdAt)
+
+	output, err := h.createTransferKey.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Find transfer keys
+// @Description Find the keys of the authenticated account, oldest first
+// @Tags        transfer keys
+// @Produce     json
+// @Success     200 {array} usecase.TransferKeyUseCaseOutput
+// @Failure     400,401,500
+// @Security    ApiKeyAuth
+// @Router /transfer-keys [get]
+func (h *WebTransferKeyHandler) FindByAccountID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	input := usecase.NewFindTransferKeysByAccountUseCaseInput(accountID)
+
+	output, err := h.findTransferKeysByAccount.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Find transfer key
+// @Description Look up a key before sending a transfer to it, the owner name is masked and the account id is not shown. Lookups of keys that do not exist are limited per account, after too many of them the account waits as after failed logins
+// @Tags        transfer keys
+// @Produce     json
+// @Param       key path string true "key, a CPF with or without punctuation, an email, a phone as +5511987654321 or a random key"
+// @Success     200 {object} usecase.FindTransferKeyUseCaseOutput
+// @Failure     400,401,404,429,500
+// @Security    ApiKeyAuth
+// @Router /transfer-keys/{key} [get]
+func (h *WebTransferKeyHandler) FindByKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	key, err := url.PathUnescape(chi.URLParam(r, "key"))
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	output, err := h.findTransferKey.Execute(ctx, usecase.NewFindTransferKeyUseCaseInput(accountID, key))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Delete transfer key
+// @Description Remove a key of the authenticated account, it can then be registered again by any account
+// @Tags        transfer keys
+// @Produce     json
+// @Param       key path string true "key"
+// @Success     204
+// @Failure     400,401,404,500
+// @Security    ApiKeyAuth
+// @Router /transfer-keys/{key} [delete]
+func (h *WebTransferKeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	key, err := url.PathUnescape(chi.URLParam(r, "key"))
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	err = h.deleteTransferKey.Execute(ctx, usecase.NewDeleteTransferKeyUseCaseInput(accountID, key))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,149 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetTransferKeyRequest(t *testing.T, method string, path string, key string, body string, accountID string) *http.Request {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("key", key)
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if accountID != "" {
+		ctx = context.WithValue(ctx, web.AccountIDKey, accountID)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestTransferKeyHandler_Create(t *testing.T) {
+	t.Run("Testing Create registers the key of the logged account", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "POST", "/transfer-keys", "", `{"type":"email","key":"lucas@example.com"}`, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		createTransferKeyUseCase := usecaseMock.NewCreateTransferKeyUseCaseMock()
+		createTransferKeyUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.CreateTransferKeyUseCaseInput) bool {
+			return input.AccountID == "2bd765a6-47bd-4731-9eb2-1e65542f4477" && input.Type == "email" && input.Key == "lucas@example.com" && input.CreatedAt != nil
+		})).Return(&usecase.TransferKeyUseCaseOutput{Key: "lucas@example.com", Type: "email", CreatedAt: "2023-08-14T10:00:00Z"}, nil)
+
+		handler := web.NewWebTransferKeyHandler(createTransferKeyUseCase, nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.JSONEq(t, `{"key":"lucas@example.com","type":"email","created_at":"2023-08-14T10:00:00Z"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Create when the key is already registered", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "POST", "/transfer-keys", "", `{"type":"email","key":"lucas@example.com"}`, "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		createTransferKeyUseCase := usecaseMock.NewCreateTransferKeyUseCaseMock()
+		createTransferKeyUseCase.On("Execute", req.Context(), testify.Anything).
+			Return((*usecase.TransferKeyUseCaseOutput)(nil), entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("key is already registered: lucas@example.com"))
+
+		handler := web.NewWebTransferKeyHandler(createTransferKeyUseCase, nil, nil, nil)
+		handler.Create(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestTransferKeyHandler_FindByKey(t *testing.T) {
+	t.Run("Testing FindByKey unescapes the key", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "GET", "/transfer-keys/lucas%40example.com", "lucas%40example.com", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		findTransferKeyUseCase := usecaseMock.NewFindTransferKeyUseCaseMock()
+		findTransferKeyUseCase.On("Execute", req.Context(), usecase.NewFindTransferKeyUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "lucas@example.com")).
+			Return(&usecase.FindTransferKeyUseCaseOutput{Key: "lucas@example.com", Type: "email", OwnerName: "Lucas S*****"}, nil)
+
+		handler := web.NewWebTransferKeyHandler(nil, nil, findTransferKeyUseCase, nil)
+		handler.FindByKey(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"key":"lucas@example.com","type":"email","owner_name":"Lucas S*****"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing FindByKey when the account looked up too many keys that do not exist", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "GET", "/transfer-keys/lucas%40example.com", "lucas%40example.com", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		findTransferKeyUseCase := usecaseMock.NewFindTransferKeyUseCaseMock()
+		findTransferKeyUseCase.On("Execute", req.Context(), testify.Anything).
+			Return((*usecase.FindTransferKeyUseCaseOutput)(nil), entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).Add("too many lookups of keys that do not exist, try again later").WithRetryAfter(time.Minute))
+
+		handler := web.NewWebTransferKeyHandler(nil, nil, findTransferKeyUseCase, nil)
+		handler.FindByKey(recorder, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("Testing FindByKey without account_id in context", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "GET", "/transfer-keys/lucas%40example.com", "lucas%40example.com", "", "")
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferKeyHandler(nil, nil, nil, nil)
+		handler.FindByKey(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestTransferKeyHandler_FindByAccountID(t *testing.T) {
+	t.Run("Testing FindByAccountID lists the keys of the logged account", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "GET", "/transfer-keys", "", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		findTransferKeysByAccountUseCase := usecaseMock.NewFindTransferKeysByAccountUseCaseMock()
+		findTransferKeysByAccountUseCase.On("Execute", req.Context(), usecase.NewFindTransferKeysByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477")).
+			Return([]usecase.TransferKeyUseCaseOutput{{Key: "+5511987654321", Type: "phone", CreatedAt: "2023-08-14T10:00:00Z"}}, nil)
+
+		handler := web.NewWebTransferKeyHandler(nil, findTransferKeysByAccountUseCase, nil, nil)
+		handler.FindByAccountID(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `[{"key":"+5511987654321","type":"phone","created_at":"2023-08-14T10:00:00Z"}]`, recorder.Body.String())
+	})
+}
+
+func TestTransferKeyHandler_Delete(t *testing.T) {
+	t.Run("Testing Delete removes the key of the logged account", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "DELETE", "/transfer-keys/+5511987654321", "+5511987654321", "", "2bd765a6-47bd-4731-9eb2-1e65542f4477")
+		recorder := httptest.NewRecorder()
+
+		deleteTransferKeyUseCase := usecaseMock.NewDeleteTransferKeyUseCaseMock()
+		deleteTransferKeyUseCase.On("Execute", req.Context(), usecase.NewDeleteTransferKeyUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "+5511987654321")).Return(nil)
+
+		handler := web.NewWebTransferKeyHandler(nil, nil, nil, deleteTransferKeyUseCase)
+		handler.Delete(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+
+	t.Run("Testing Delete without account_id in context", func(t *testing.T) {
+		req := GetTransferKeyRequest(t, "DELETE", "/transfer-keys/+5511987654321", "+5511987654321", "", "")
+		recorder := httptest.NewRecorder()
+
+		handler := web.NewWebTransferKeyHandler(nil, nil, nil, nil)
+		handler.Delete(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
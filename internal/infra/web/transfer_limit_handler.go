@@ -0,0 +1,83 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebTransferLimitHandler struct {
+	findTransferLimit   usecase.IFindTransferLimitUseCase
+	changeTransferLimit usecase.IChangeTransferLimitUseCase
+}
+
+func NewWebTransferLimitHandler(findTransferLimit usecase.IFindTransferLimitUseCase, changeTransferLimit usecase.IChangeTransferLimitUseCase) *WebTransferLimitHandler {
+	return &WebTransferLimitHandler{
+		findTransferLimit:   findTransferLimit,
+		changeTransferLimit: changeTransferLimit,
+	}
+}
+
+// @Summary     Find transfer limits
+// @Description Find the per-transaction, daily, monthly and nighttime transfer limits of an account, customers can only read their own account
+// @Tags        limits
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Success     200 {object} usecase.FindTransferLimitUseCaseOutput
+// @Failure     401,403,404,500
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/limits [get]
+func (h *WebTransferLimitHandler) Find(w http.ResponseWriter, r *http.Request) {
+	input := usecase.NewFindTransferLimitUseCaseInput(chi.URLParam(r, "account_id"))
+
+	output, err := h.findTransferLimit.Execute(r.Context(), input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Change transfer limits
+// @Description Change the transfer limits of an account, customers can only lower the limits of their own account and admins can raise the limits of any account
+// @Tags        limits
+// @Produce     json
+// @Param       account_id path string true "account_id"
+// @Param       body body usecase.ChangeTransferLimitUseCaseInput true "change limits request body, limits left empty are kept"
+// @Success     200 {object} usecase.FindTransferLimitUseCaseOutput
+// @Failure     400,401,403,404,500,422
+// @Security    ApiKeyAuth
+// @Router /accounts/{account_id}/limits [put]
+func (h *WebTransferLimitHandler) Change(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, ok := ctx.Value(PrincipalKey).(*entity.Principal)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+		return
+	}
+
+	var dto usecase.ChangeTransferLimitUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	updatedAt := time.Now()
+	input := usecase.NewChangeTransferLimitUseCaseInput(chi.URLParam(r, "account_id"), principal, dto.PerTransaction, dto.Daily, dto.Monthly, dto.Nighttime, &updatedAt)
+
+	output, err := h.changeTransferLimit.Execute(ctx, input)
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
@@ -0,0 +1,142 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func NewTransferLimitRequest(t *testing.T, method string, accountID string, body string, principal *entity.Principal) *http.Request {
+	req, err := http.NewRequest(method, "/accounts/"+accountID+"/limits", bytes.NewBufferString(body))
+	assert.Nil(t, err)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("account_id", accountID)
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if principal != nil {
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func GetTransferLimitOutput(accountID string) *usecase.FindTransferLimitUseCaseOutput {
+	return &usecase.FindTransferLimitUseCaseOutput{
+		AccountID:      accountID,
+		PerTransaction: entity.NewMoney(500000, entity.BRL),
+		Daily:          entity.NewMoney(1000000, entity.BRL),
+		Monthly:        entity.NewMoney(5000000, entity.BRL),
+		Nighttime:      entity.NewMoney(100000, entity.BRL),
+		NightStart:     "20:00",
+		NightEnd:       "06:00",
+	}
+}
+
+func TestTransferLimitHandler_Find(t *testing.T) {
+	t.Run("Testing Find with success", func(t *testing.T) {
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		req := NewTransferLimitRequest(t, "GET", accountID, "", nil)
+		recorder := httptest.NewRecorder()
+
+		findTransferLimitUseCase := usecaseMock.NewFindTransferLimitUseCaseMock()
+		findTransferLimitUseCase.On("Execute", req.Context(), usecase.NewFindTransferLimitUseCaseInput(accountID)).Return(GetTransferLimitOutput(accountID), nil)
+
+		handler := web.NewWebTransferLimitHandler(findTransferLimitUseCase, nil)
+		handler.Find(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{
+			"account_id":"2bd765a6-47bd-4731-9eb2-1e65542f4477",
+			"per_transaction":{"amount":"5000.00","currency":"BRL"},
+			"daily":{"amount":"10000.00","currency":"BRL"},
+			"monthly":{"amount":"50000.00","currency":"BRL"},
+			"nighttime":{"amount":"1000.00","currency":"BRL"},
+			"night_start":"20:00",
+			"night_end":"06:00"
+		}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Find when the account does not exist", func(t *testing.T) {
+		req := NewTransferLimitRequest(t, "GET", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "", nil)
+		recorder := httptest.NewRecorder()
+
+		findTransferLimitUseCase := usecaseMock.NewFindTransferLimitUseCaseMock()
+		findTransferLimitUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.FindTransferLimitUseCaseOutput)(nil), entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account"))
+
+		handler := web.NewWebTransferLimitHandler(findTransferLimitUseCase, nil)
+		handler.Find(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestTransferLimitHandler_Change(t *testing.T) {
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+	t.Run("Testing Change with success", func(t *testing.T) {
+		principal := entity.NewPrincipal(accountID, entity.CUSTOMER_ROLE)
+		req := NewTransferLimitRequest(t, "PUT", accountID, `{"daily": {"amount": "8000.00", "currency": "BRL"}}`, principal)
+		recorder := httptest.NewRecorder()
+
+		changeTransferLimitUseCase := usecaseMock.NewChangeTransferLimitUseCaseMock()
+		changeTransferLimitUseCase.On("Execute", req.Context(), testify.MatchedBy(func(input *usecase.ChangeTransferLimitUseCaseInput) bool {
+			return input.AccountID == accountID && input.Principal == principal && *input.Daily == entity.NewMoney(800000, entity.BRL) &&
+				input.PerTransaction == nil && input.Monthly == nil && input.Nighttime == nil && input.UpdatedAt != nil
+		})).Return(GetTransferLimitOutput(accountID), nil)
+
+		handler := web.NewWebTransferLimitHandler(nil, changeTransferLimitUseCase)
+		handler.Change(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Testing Change without principal", func(t *testing.T) {
+		req := NewTransferLimitRequest(t, "PUT", accountID, `{}`, nil)
+		recorder := httptest.NewRecorder()
+
+		changeTransferLimitUseCase := usecaseMock.NewChangeTransferLimitUseCaseMock()
+
+		handler := web.NewWebTransferLimitHandler(nil, changeTransferLimitUseCase)
+		handler.Change(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		changeTransferLimitUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Change occurs error on decode body", func(t *testing.T) {
+		req := NewTransferLimitRequest(t, "PUT", accountID, `{"daily":`, entity.NewPrincipal(accountID, entity.CUSTOMER_ROLE))
+		recorder := httptest.NewRecorder()
+
+		changeTransferLimitUseCase := usecaseMock.NewChangeTransferLimitUseCaseMock()
+
+		handler := web.NewWebTransferLimitHandler(nil, changeTransferLimitUseCase)
+		handler.Change(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		changeTransferLimitUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Change when the customer raises a limit", func(t *testing.T) {
+		req := NewTransferLimitRequest(t, "PUT", accountID, `{"daily": {"amount": "20000.00", "currency": "BRL"}}`, entity.NewPrincipal(accountID, entity.CUSTOMER_ROLE))
+		recorder := httptest.NewRecorder()
+
+		changeTransferLimitUseCase := usecaseMock.NewChangeTransferLimitUseCaseMock()
+		changeTransferLimitUseCase.On("Execute", req.Context(), testify.Anything).Return((*usecase.FindTransferLimitUseCaseOutput)(nil), entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("transfer limits can only be lowered, ask the bank to raise them"))
+
+		handler := web.NewWebTransferLimitHandler(nil, changeTransferLimitUseCase)
+		handler.Change(recorder, req)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,110 @@
+package web
+
+import (
+	"encoding/json"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"lucassantoss1701/bank/internal/usecase"
+	"net/http"
+)
+
+type WebTwoFactorHandler struct {
+	enrollTOTP  usecase.IEnrollTOTPUseCase
+	confirmTOTP usecase.IConfirmTOTPUseCase
+	loginMFA    usecase.ILoginMFAUseCase
+}
+
+func NewWebTwoFactorHandler(enrollTOTP usecase.IEnrollTOTPUseCase, confirmTOTP usecase.IConfirmTOTPUseCase, loginMFA usecase.ILoginMFAUseCase) *WebTwoFactorHandler {
+	return &WebTwoFactorHandler{
+		enrollTOTP:  enrollTOTP,
+		confirmTOTP: confirmTOTP,
+		loginMFA:    loginMFA,
+	}
+}
+
+// @Summary     Enroll TOTP
+// @Description Generate the TOTP secret of the logged account, it has to be confirmed with a code before being required
+// @Tags        two-factor
+// @Produce     json
+// @Success     201 {object} usecase.EnrollTOTPUseCaseOutput
+// @Failure     400,401,409,500
+// @Security    ApiKeyAuth
+// @Router /mfa/totp [post]
+func (h *WebTwoFactorHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	output, err := h.enrollTOTP.Execute(ctx, usecase.NewEnrollTOTPUseCaseInput(accountID))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusCreated, output)
+}
+
+// @Summary     Confirm TOTP
+// @Description Enable two-factor authentication with a code of the enrolled authenticator, returns the recovery codes only once
+// @Tags        two-factor
+// @Produce     json
+// @Param       body body usecase.ConfirmTOTPUseCaseInput true "confirm totp request body"
+// @Success     200 {object} usecase.ConfirmTOTPUseCaseOutput
+// @Failure     400,401,404,409,422,429,500
+// @Security    ApiKeyAuth
+// @Router /mfa/totp/confirm [post]
+func (h *WebTwoFactorHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, ok := ctx.Value(AccountIDKey).(string)
+	if !ok {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+		return
+	}
+
+	var dto usecase.ConfirmTOTPUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	output, err := h.confirmTOTP.Execute(ctx, usecase.NewConfirmTOTPUseCaseInput(accountID, dto.Code))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
+
+// @Summary     Login second step
+// @Description Trade the mfa_token returned by the login and a TOTP or recovery code for the access token and the refresh token
+// @Tags        two-factor
+// @Produce     json
+// @Param       body body usecase.LoginMFAUseCaseInput true "login mfa request body"
+// @Success     200 {object} usecase.TokenUseCaseOutput
+// @Failure     400,401,404,429,500
+// @Router /login/mfa [post]
+func (h *WebTwoFactorHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto usecase.LoginMFAUseCaseInput
+	err := json.NewDecoder(r.Body).Decode(&dto)
+	if err != nil {
+		responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+		return
+	}
+
+	output, err := h.loginMFA.Execute(ctx, usecase.NewLoginMFAUseCaseInput(dto.MFAToken, dto.Code, clientIP(r)))
+	if err != nil {
+		responses.Err(w, err)
+		return
+	}
+
+	responses.Success(w, http.StatusOK, output)
+}
@@ -0,0 +1,133 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestTwoFactorHandler_EnrollTOTP(t *testing.T) {
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+	t.Run("Testing EnrollTOTP with success", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/mfa/totp", nil)
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, accountID))
+		recorder := httptest.NewRecorder()
+
+		output := usecase.NewEnrollTOTPUseCaseOutput("GEZDGNBVGY3TQOJQ", "otpauth://totp/bank-api:"+accountID+"?secret=GEZDGNBVGY3TQOJQ")
+		enrollTOTPUseCase := usecaseMock.NewEnrollTOTPUseCaseMock()
+		enrollTOTPUseCase.On("Execute", req.Context(), usecase.NewEnrollTOTPUseCaseInput(accountID)).Return(output, nil)
+
+		handler := web.NewWebTwoFactorHandler(enrollTOTPUseCase, nil, nil)
+		handler.EnrollTOTP(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.JSONEq(t, `{"secret":"GEZDGNBVGY3TQOJQ","provisioning_uri":"otpauth://totp/bank-api:`+accountID+`?secret=GEZDGNBVGY3TQOJQ"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing EnrollTOTP when two-factor authentication is already enabled", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/mfa/totp", nil)
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, accountID))
+		recorder := httptest.NewRecorder()
+
+		enrollTOTPUseCase := usecaseMock.NewEnrollTOTPUseCaseMock()
+		enrollTOTPUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(&usecase.EnrollTOTPUseCaseOutput{}, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("two-factor authentication is already enabled"))
+
+		handler := web.NewWebTwoFactorHandler(enrollTOTPUseCase, nil, nil)
+		handler.EnrollTOTP(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestTwoFactorHandler_ConfirmTOTP(t *testing.T) {
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+	t.Run("Testing ConfirmTOTP with success", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/mfa/totp/confirm", bytes.NewBufferString(`{"code":"287082"}`))
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, accountID))
+		recorder := httptest.NewRecorder()
+
+		confirmTOTPUseCase := usecaseMock.NewConfirmTOTPUseCaseMock()
+		confirmTOTPUseCase.On("Execute", req.Context(), usecase.NewConfirmTOTPUseCaseInput(accountID, "287082")).
+			Return(usecase.NewConfirmTOTPUseCaseOutput([]string{"abcde-fghjk"}), nil)
+
+		handler := web.NewWebTwoFactorHandler(nil, confirmTOTPUseCase, nil)
+		handler.ConfirmTOTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"recovery_codes":["abcde-fghjk"]}`, recorder.Body.String())
+	})
+
+	t.Run("Testing ConfirmTOTP occurs error on decode body", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/mfa/totp/confirm", bytes.NewBufferString(`invalid json`))
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, accountID))
+		recorder := httptest.NewRecorder()
+
+		confirmTOTPUseCase := usecaseMock.NewConfirmTOTPUseCaseMock()
+
+		handler := web.NewWebTwoFactorHandler(nil, confirmTOTPUseCase, nil)
+		handler.ConfirmTOTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		confirmTOTPUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ConfirmTOTP with an invalid code", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/mfa/totp/confirm", bytes.NewBufferString(`{"code":"000000"}`))
+		req = req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, accountID))
+		recorder := httptest.NewRecorder()
+
+		confirmTOTPUseCase := usecaseMock.NewConfirmTOTPUseCaseMock()
+		confirmTOTPUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(&usecase.ConfirmTOTPUseCaseOutput{}, entity.NewErrorHandler(entity.ENTITY_ERROR).Add("totp code is invalid"))
+
+		handler := web.NewWebTwoFactorHandler(nil, confirmTOTPUseCase, nil)
+		handler.ConfirmTOTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestTwoFactorHandler_LoginMFA(t *testing.T) {
+	t.Run("Testing LoginMFA with success", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBufferString(`{"mfa_token":"mfa","code":"287082"}`))
+		req.RemoteAddr = "203.0.113.7:52114"
+		recorder := httptest.NewRecorder()
+
+		output := &usecase.TokenUseCaseOutput{Token: "xpto", RefreshToken: "refresh", ExpiresIn: 900}
+		loginMFAUseCase := usecaseMock.NewLoginMFAUseCaseMock()
+		loginMFAUseCase.On("Execute", req.Context(), usecase.NewLoginMFAUseCaseInput("mfa", "287082", "203.0.113.7")).Return(output, nil)
+
+		handler := web.NewWebTwoFactorHandler(nil, nil, loginMFAUseCase)
+		handler.LoginMFA(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"token":"xpto","refresh_token":"refresh","expires_in":900}`, recorder.Body.String())
+	})
+
+	t.Run("Testing LoginMFA when the code is throttled", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBufferString(`{"mfa_token":"mfa","code":"287082"}`))
+		req.RemoteAddr = "203.0.113.7:52114"
+		recorder := httptest.NewRecorder()
+
+		loginMFAUseCase := usecaseMock.NewLoginMFAUseCaseMock()
+		loginMFAUseCase.On("Execute", req.Context(), testify.Anything).
+			Return(&usecase.TokenUseCaseOutput{}, entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).Add("too many invalid two-factor codes, try again later").WithRetryAfter(90))
+
+		handler := web.NewWebTwoFactorHandler(nil, nil, loginMFAUseCase)
+		handler.LoginMFA(recorder, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+}
@@ -3,22 +3,35 @@ package middleware
 import (
 	"context"
 	"errors"
-	"lucassantoss1701/bank/configs"
+	"fmt"
 	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/web"
 	"lucassantoss1701/bank/internal/infra/web/responses"
 	"net/http"
 	"strings"
-
-	"github.com/golang-jwt/jwt"
+	"time"
 )
 
-func Auth(next http.Handler) http.Handler {
+type Auth struct {
+	verifier               entity.TokenVerifier
+	revokedTokenRepository entity.RevokedTokenRepository
+}
+
+func NewAuth(verifier entity.TokenVerifier, revokedTokenRepository entity.RevokedTokenRepository) *Auth {
+	return &Auth{
+		verifier:               verifier,
+		revokedTokenRepository: revokedTokenRepository,
+	}
+}
+
+// Handle authenticates the request with the bearer token and puts the principal in the
+// context. Tokens in the denylist are rejected even though they have not expired yet.
+func (a *Auth) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
 		token := r.Header.Get("authorization")
 
-		claims, err := validateToken(token)
+		claims, err := a.validateToken(token)
 		if err != nil {
 			message := err.Error()
 			err := entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR)
@@ -36,20 +49,122 @@ func Auth(next http.Handler) http.Handler {
 			return
 		}
 
+		role, err := getRole(claims)
+		if err != nil {
+			message := err.Error()
+			err := entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR)
+			err.Add(message)
+			responses.Err(w, err)
+			return
+		}
+
+		tokenID, expiresAt, err := getTokenIDAndExpiration(claims)
+		if err != nil {
+			message := err.Error()
+			err := entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR)
+			err.Add(message)
+			responses.Err(w, err)
+			return
+		}
+
+		revoked, err := a.revokedTokenRepository.Exists(r.Context(), tokenID)
+		if err != nil {
+			responses.Err(w, err)
+			return
+		}
+
+		if revoked {
+			responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("token has been revoked"))
+			return
+		}
+
+		principal := entity.NewPrincipal(accountID, role)
+		principal.TokenID = tokenID
+		principal.SessionID, _ = claims["sid"].(string)
+		principal.TokenExpiresAt = expiresAt
+
 		ctx := context.WithValue(r.Context(), web.AccountIDKey, accountID)
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
 		next.ServeHTTP(w, r.WithContext(ctx))
 
 	})
 }
 
-func getAccountID(claims jwt.MapClaims) (string, error) {
+func getAccountID(claims map[string]interface{}) (string, error) {
 	if accountID, ok := claims["account_id"].(string); ok {
 		return accountID, nil
 	}
 	return "", errors.New("token claims without account id")
 }
 
-func validateToken(token string) (jwt.MapClaims, error) {
+// PurgeExpired removes the revoked tokens that already expired once per interval until ctx is done.
+func (a *Auth) PurgeExpired(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			err := a.revokedTokenRepository.DeleteExpired(ctx, now)
+			if err != nil {
+				getLoggerInstance().Error(err.Error())
+			}
+		}
+	}
+}
+
+func getTokenIDAndExpiration(claims map[string]interface{}) (string, *time.Time, error) {
+	tokenID, ok := claims["jti"].(string)
+	if !ok || tokenID == "" {
+		return "", nil, errors.New("token claims without jti")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return "", nil, errors.New("token claims without exp")
+	}
+
+	expiresAt := time.Unix(int64(exp), 0).UTC()
+	return tokenID, &expiresAt, nil
+}
+
+// getRole reads the role claim, tokens issued before roles existed belong to customers.
+func getRole(claims map[string]interface{}) (entity.Role, error) {
+	role, ok := claims["role"].(string)
+	if !ok || role == "" {
+		return entity.CUSTOMER_ROLE, nil
+	}
+
+	if _, err := entity.NewRole(role); err != nil {
+		return "", errors.New("token has an invalid role")
+	}
+
+	return entity.Role(role), nil
+}
+
+// RequirePermission only lets through principals whose role grants the permission.
+func RequirePermission(permission entity.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := r.Context().Value(web.PrincipalKey).(*entity.Principal)
+			if !ok {
+				responses.Err(w, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found"))
+				return
+			}
+
+			if !principal.Can(permission) {
+				responses.Err(w, entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add(fmt.Sprintf("permission required: %s", permission)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (a *Auth) validateToken(token string) (map[string]interface{}, error) {
 	if token == "" {
 		return nil, errors.New("token must not be empty")
 	}
@@ -61,22 +176,14 @@ func validateToken(token string) (jwt.MapClaims, error) {
 
 	token = authParts[1]
 
-	claims, err := parseToken(token)
+	claims, err := a.verifier.Verify(token)
 	if err != nil {
 		return nil, errors.New("token is invalid")
 	}
-	return claims, nil
-}
 
-func parseToken(token string) (jwt.MapClaims, error) {
-	var secret = configs.Get().Security.Secret
-	claims := jwt.MapClaims{}
-	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
-
-	if err != nil {
-		return nil, err
+	// MFA tokens are signed by the same keys but must not authenticate requests
+	if typ, ok := claims["typ"]; ok && typ != string(entity.ACCESS_TOKEN_TYPE) {
+		return nil, errors.New("token is invalid")
 	}
 
 	return claims, nil
@@ -0,0 +1,199 @@
+package middleware_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/infra/security"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+var keySet, _ = security.GenerateKeySet()
+
+func NewAuthenticatedRequest(t *testing.T, claims jwt.MapClaims) *http.Request {
+	token, err := keySet.Sign(claims)
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest("GET", "/accounts", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func NewAuth(revoked bool) (*middleware.Auth, *mock.RevokedTokenRepositoryMock) {
+	repository := mock.NewRevokedTokenRepositoryMock()
+	repository.On("Exists", testify.Anything, testify.Anything).Return(revoked, nil)
+	return middleware.NewAuth(keySet, repository), repository
+}
+
+func TestAuth_Handle(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+
+	t.Run("Testing Handle puts the principal with its role and session in the context", func(t *testing.T) {
+		req := NewAuthenticatedRequest(t, jwt.MapClaims{"jti": "fc84682a", "sid": "0b8b418c", "account_id": accountID, "role": "ADMIN", "exp": exp})
+		recorder := httptest.NewRecorder()
+		auth, repository := NewAuth(false)
+
+		var principal *entity.Principal
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal = r.Context().Value(web.PrincipalKey).(*entity.Principal)
+		})).ServeHTTP(recorder, req)
+
+		assert.Equal(t, accountID, principal.AccountID)
+		assert.Equal(t, entity.ADMIN_ROLE, principal.Role)
+		assert.Equal(t, "fc84682a", principal.TokenID)
+		assert.Equal(t, "0b8b418c", principal.SessionID)
+		assert.Equal(t, exp, principal.TokenExpiresAt.Unix())
+		repository.AssertCalled(t, "Exists", testify.Anything, "fc84682a")
+	})
+
+	t.Run("Testing Handle treats tokens without role as customers", func(t *testing.T) {
+		req := NewAuthenticatedRequest(t, jwt.MapClaims{"jti": "fc84682a", "account_id": accountID, "exp": exp})
+		recorder := httptest.NewRecorder()
+		auth, _ := NewAuth(false)
+
+		var principal *entity.Principal
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal = r.Context().Value(web.PrincipalKey).(*entity.Principal)
+		})).ServeHTTP(recorder, req)
+
+		assert.Equal(t, entity.CUSTOMER_ROLE, principal.Role)
+	})
+
+	t.Run("Testing Handle with an unknown role", func(t *testing.T) {
+		req := NewAuthenticatedRequest(t, jwt.MapClaims{"jti": "fc84682a", "account_id": accountID, "role": "ROOT", "exp": exp})
+		recorder := httptest.NewRecorder()
+		auth, _ := NewAuth(false)
+		called := false
+
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})).ServeHTTP(recorder, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, `{"error":"token has an invalid role"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Handle with a token without jti", func(t *testing.T) {
+		req := NewAuthenticatedRequest(t, jwt.MapClaims{"account_id": accountID, "exp": exp})
+		recorder := httptest.NewRecorder()
+		auth, repository := NewAuth(false)
+
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, `{"error":"token claims without jti"}`, recorder.Body.String())
+		repository.AssertNotCalled(t, "Exists", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Handle with a revoked token", func(t *testing.T) {
+		req := NewAuthenticatedRequest(t, jwt.MapClaims{"jti": "fc84682a", "account_id": accountID, "exp": exp})
+		recorder := httptest.NewRecorder()
+		auth, _ := NewAuth(true)
+		called := false
+
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})).ServeHTTP(recorder, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, `{"error":"token has been revoked"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Handle with a token signed by an unknown key", func(t *testing.T) {
+		otherKeySet, err := security.GenerateKeySet()
+		assert.Nil(t, err)
+
+		token, err := otherKeySet.Sign(jwt.MapClaims{"jti": "fc84682a", "account_id": accountID, "exp": exp})
+		assert.Nil(t, err)
+
+		req, _ := http.NewRequest("GET", "/accounts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		auth, _ := NewAuth(false)
+
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, `{"error":"token is invalid"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing Handle with an mfa token", func(t *testing.T) {
+		req := NewAuthenticatedRequest(t, jwt.MapClaims{"jti": "fc84682a", "typ": "mfa", "account_id": accountID, "exp": exp})
+		recorder := httptest.NewRecorder()
+		auth, _ := NewAuth(false)
+		called := false
+
+		auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})).ServeHTTP(recorder, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, `{"error":"token is invalid"}`, recorder.Body.String())
+	})
+}
+
+func TestRequirePermission(t *testing.T) {
+	newRequest := func(role entity.Role) *http.Request {
+		req, _ := http.NewRequest("GET", "/accounts", nil)
+		return req.WithContext(context.WithValue(req.Context(), web.PrincipalKey, entity.NewPrincipal(accountID, role)))
+	}
+
+	t.Run("Testing RequirePermission when role grants the permission", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		called := false
+
+		middleware.RequirePermission(entity.LIST_ACCOUNTS_PERMISSION)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})).ServeHTTP(recorder, newRequest(entity.ADMIN_ROLE))
+
+		assert.True(t, called)
+	})
+
+	t.Run("Testing RequirePermission when role does not grant the permission", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		called := false
+
+		middleware.RequirePermission(entity.LIST_ACCOUNTS_PERMISSION)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})).ServeHTTP(recorder, newRequest(entity.CUSTOMER_ROLE))
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.JSONEq(t, `{"error":"permission required: accounts:list"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing RequirePermission when a customer deposits", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/accounts/d18551d3-cf13-49ec-b1dc-741a1f8715f6/deposits", nil)
+		req = req.WithContext(context.WithValue(req.Context(), web.PrincipalKey, entity.NewPrincipal(accountID, entity.CUSTOMER_ROLE)))
+		recorder := httptest.NewRecorder()
+		called := false
+
+		middleware.RequirePermission(entity.DEPOSIT_PERMISSION)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})).ServeHTTP(recorder, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("Testing RequirePermission without principal in context", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/accounts", nil)
+		recorder := httptest.NewRecorder()
+
+		middleware.RequirePermission(entity.AUTHENTICATED_PERMISSION)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
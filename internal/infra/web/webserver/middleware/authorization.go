@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Authorization struct {
+	policy entity.AuthorizationPolicy
+}
+
+func NewAuthorization(policy entity.AuthorizationPolicy) *Authorization {
+	return &Authorization{
+		policy: policy,
+	}
+}
+
+// OwnAccount only lets the request through when the {account_id} of the route belongs to
+// the logged principal, or when the principal is privileged.
+func (a *Authorization) OwnAccount(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := r.Context().Value(web.PrincipalKey).(*entity.Principal)
+
+		err := a.policy.CanAccessAccount(principal, chi.URLParam(r, "account_id"))
+		if err != nil {
+			responses.Err(w, err)
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func NewAccountRequest(pathAccountID string, principal *entity.Principal) *http.Request {
+	req, _ := http.NewRequest("GET", "/accounts/"+pathAccountID+"/balance", nil)
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("account_id", pathAccountID)
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)
+	if principal != nil {
+		ctx = context.WithValue(ctx, web.PrincipalKey, principal)
+	}
+
+	return req.WithContext(ctx)
+}
+
+func TestAuthorization_OwnAccount(t *testing.T) {
+	authorization := middleware.NewAuthorization(entity.NewOwnershipPolicy())
+
+	t.Run("Testing OwnAccount when account belongs to the principal", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		called := false
+
+		authorization.OwnAccount(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		})(recorder, NewAccountRequest(accountID, entity.NewPrincipal(accountID, entity.CUSTOMER_ROLE)))
+
+		assert.True(t, called)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Testing OwnAccount when account belongs to someone else", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		called := false
+
+		authorization.OwnAccount(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})(recorder, NewAccountRequest("d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewPrincipal(accountID, entity.CUSTOMER_ROLE)))
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.JSONEq(t, `{"error":"account does not belong to the logged account"}`, recorder.Body.String())
+	})
+
+	t.Run("Testing OwnAccount when principal is an admin", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		called := false
+
+		authorization.OwnAccount(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		})(recorder, NewAccountRequest("d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewPrincipal(accountID, entity.ADMIN_ROLE)))
+
+		assert.True(t, called)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Testing OwnAccount without principal in context", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		authorization.OwnAccount(func(w http.ResponseWriter, r *http.Request) {})(recorder, NewAccountRequest(accountID, nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/responses"
+	"net/http"
+	"time"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// completeTimeout bounds how long storing the response may take once the request is done.
+const completeTimeout = 5 * time.Second
+
+type Idempotency struct {
+	repository entity.IdempotencyKeyRepository
+	retention  time.Duration
+}
+
+func NewIdempotency(repository entity.IdempotencyKeyRepository, retention time.Duration) *Idempotency {
+	return &Idempotency{
+		repository: repository,
+		retention:  retention,
+	}
+}
+
+// Handle makes the wrapped handler safe to retry: the first request with a given
+// Idempotency-Key is executed and its response stored, later requests with the same key
+// receive the stored response instead of being executed again.
+func (i *Idempotency) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		ctx := r.Context()
+
+		accountID, ok := ctx.Value(web.AccountIDKey).(string)
+		if !ok {
+			responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add("account_id not found in context"))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			responses.Err(w, entity.NewErrorHandler(entity.BAD_REQUEST).Add(err.Error()))
+			return
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		createdAt := time.Now()
+		idempotencyKey, err := entity.NewIdempotencyKey(key, accountID, fingerprint(r, body), &createdAt, i.retention)
+		if err != nil {
+			responses.Err(w, err)
+			return
+		}
+
+		stored, err := i.reserve(ctx, idempotencyKey)
+		if err != nil {
+			responses.Err(w, err)
+			return
+		}
+
+		if stored != nil {
+			replay(w, stored, idempotencyKey.Fingerprint)
+			return
+		}
+
+		rw := &responseWriter{w, &responseData{status: http.StatusOK}}
+
+		completed := false
+		defer func() {
+			if !completed {
+				_ = i.repository.Delete(context.Background(), accountID, key)
+			}
+		}()
+
+		next(rw, r)
+
+		// server errors are not stored so the client is able to retry with the same key
+		if rw.ResponseData.status >= http.StatusInternalServerError {
+			return
+		}
+
+		completed = true
+		idempotencyKey.Complete(rw.ResponseData.status, rw.ResponseData.buf.String())
+
+		// the response is stored even when the client is already gone, otherwise the key stays in
+		// flight and every retry receives a conflict instead of the committed result
+		completeCtx, cancel := context.WithTimeout(context.Background(), completeTimeout)
+		defer cancel()
+
+		err = i.repository.Complete(completeCtx, idempotencyKey)
+		if err != nil {
+			getLoggerInstance().WithField("idempotency_key", key).Error(err.Error())
+		}
+	}
+}
+
+// PurgeExpired removes the expired keys once per retention window until ctx is done. Without
+// a positive retention there is no window to purge by, so nothing is done.
+func (i *Idempotency) PurgeExpired(ctx context.Context) {
+	if i.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(i.retention)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			err := i.repository.DeleteExpired(ctx, now)
+			if err != nil {
+				getLoggerInstance().Error(err.Error())
+			}
+		}
+	}
+}
+
+// reserve stores the key as in flight. When the key already exists and has not expired the
+// stored key is returned so the caller can replay it.
+func (i *Idempotency) reserve(ctx context.Context, idempotencyKey *entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	_, err := i.repository.Create(ctx, idempotencyKey)
+	if err == nil {
+		return nil, nil
+	}
+
+	if !isConflict(err) {
+		return nil, err
+	}
+
+	stored, err := i.repository.FindByKey(ctx, idempotencyKey.AccountID, idempotencyKey.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !stored.IsExpired(*idempotencyKey.CreatedAt) {
+		return &stored, nil
+	}
+
+	err = i.repository.Delete(ctx, idempotencyKey.AccountID, idempotencyKey.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = i.repository.Create(ctx, idempotencyKey)
+	if err != nil {
+		if isConflict(err) {
+			return nil, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("a request with this idempotency key is still being processed")
+		}
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func replay(w http.ResponseWriter, stored *entity.IdempotencyKey, fingerprint string) {
+	if !stored.Matches(fingerprint) {
+		responses.Err(w, entity.NewErrorHandler(entity.ENTITY_ERROR).Add("idempotency key was already used with a different request"))
+		return
+	}
+
+	if !stored.IsCompleted() {
+		responses.Err(w, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("a request with this idempotency key is still being processed"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write([]byte(stored.Response))
+}
+
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte(r.URL.Path))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func isConflict(err error) bool {
+	errorHandler, ok := err.(*entity.ErrorHandler)
+	return ok && errorHandler.GetTypeError() == entity.CONFLICT_ERROR
+}
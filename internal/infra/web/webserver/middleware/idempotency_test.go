@@ -0,0 +1,220 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+const accountID = "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+func NewTransferRequest(body string) *http.Request {
+	req, _ := http.NewRequest("POST", "/transfers", bytes.NewBufferString(body))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "0b8b418c")
+	return req.WithContext(context.WithValue(req.Context(), web.AccountIDKey, accountID))
+}
+
+func GetStoredKey(t *testing.T, body string, statusCode int, response string) entity.IdempotencyKey {
+	req := NewTransferRequest(body)
+
+	repository := mock.NewIdempotencyKeyRepositoryMock()
+	repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, nil)
+	repository.On("Complete", testify.Anything, testify.Anything).Return(nil)
+
+	idempotency := middleware.NewIdempotency(repository, time.Hour)
+	idempotency.Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	})(httptest.NewRecorder(), req)
+
+	stored := repository.Calls[0].Arguments.Get(1).(*entity.IdempotencyKey)
+	return *stored
+}
+
+func TestIdempotency_Handle(t *testing.T) {
+	t.Run("Testing Handle when request has no idempotency key", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBufferString(`{"amount":10}`))
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		called := false
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusCreated)
+		})(recorder, req)
+
+		assert.True(t, called)
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		repository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Handle when key is used for the first time", func(t *testing.T) {
+		req := NewTransferRequest(`{"amount":10}`)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, nil)
+		repository.On("Complete", testify.Anything, testify.Anything).Return(nil)
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"fc84682a"}`))
+		})(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		repository.AssertCalled(t, "Complete", testify.Anything, testify.MatchedBy(func(idempotencyKey *entity.IdempotencyKey) bool {
+			return idempotencyKey.StatusCode == http.StatusCreated && idempotencyKey.Response == `{"id":"fc84682a"}`
+		}))
+		repository.AssertNotCalled(t, "Delete", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Handle when key is replayed with the same body", func(t *testing.T) {
+		stored := GetStoredKey(t, `{"amount":10}`, http.StatusCreated, `{"id":"fc84682a"}`)
+
+		req := NewTransferRequest(`{"amount":10}`)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, entity.NewErrorHandler(entity.CONFLICT_ERROR))
+		repository.On("FindByKey", testify.Anything, accountID, "0b8b418c").Return(stored, nil)
+
+		called := false
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})(recorder, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, `{"id":"fc84682a"}`, recorder.Body.String())
+		assert.Equal(t, "true", recorder.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("Testing Handle when key is replayed with a different body", func(t *testing.T) {
+		stored := GetStoredKey(t, `{"amount":10}`, http.StatusCreated, `{"id":"fc84682a"}`)
+
+		req := NewTransferRequest(`{"amount":20}`)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, entity.NewErrorHandler(entity.CONFLICT_ERROR))
+		repository.On("FindByKey", testify.Anything, accountID, "0b8b418c").Return(stored, nil)
+
+		called := false
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})(recorder, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.Run("Testing Handle when the original request is still in flight", func(t *testing.T) {
+		stored := GetStoredKey(t, `{"amount":10}`, http.StatusCreated, `{"id":"fc84682a"}`)
+		stored.StatusCode = 0
+
+		req := NewTransferRequest(`{"amount":10}`)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, entity.NewErrorHandler(entity.CONFLICT_ERROR))
+		repository.On("FindByKey", testify.Anything, accountID, "0b8b418c").Return(stored, nil)
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {})(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("Testing Handle when stored key is expired", func(t *testing.T) {
+		stored := GetStoredKey(t, `{"amount":10}`, http.StatusCreated, `{"id":"fc84682a"}`)
+		expiredAt := time.Now().Add(-time.Minute)
+		stored.ExpiresAt = &expiredAt
+
+		req := NewTransferRequest(`{"amount":20}`)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, entity.NewErrorHandler(entity.CONFLICT_ERROR)).Once()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, nil).Once()
+		repository.On("FindByKey", testify.Anything, accountID, "0b8b418c").Return(stored, nil)
+		repository.On("Delete", testify.Anything, accountID, "0b8b418c").Return(nil)
+		repository.On("Complete", testify.Anything, testify.Anything).Return(nil)
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		repository.AssertNumberOfCalls(t, "Create", 2)
+		repository.AssertCalled(t, "Complete", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Handle when handler returns a server error", func(t *testing.T) {
+		req := NewTransferRequest(`{"amount":10}`)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, nil)
+		repository.On("Delete", testify.Anything, accountID, "0b8b418c").Return(nil)
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})(recorder, req)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		repository.AssertCalled(t, "Delete", testify.Anything, accountID, "0b8b418c")
+		repository.AssertNotCalled(t, "Complete", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Handle stores the response when the client disconnects", func(t *testing.T) {
+		req := NewTransferRequest(`{"amount":10}`)
+		ctx, cancel := context.WithCancel(req.Context())
+		req = req.WithContext(ctx)
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+		repository.On("Create", testify.Anything, testify.Anything).Return(entity.IdempotencyKey{}, nil)
+		repository.On("Complete", testify.MatchedBy(func(ctx context.Context) bool {
+			return ctx.Err() == nil
+		}), testify.Anything).Return(nil)
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			cancel()
+		})(recorder, req)
+
+		repository.AssertNumberOfCalls(t, "Complete", 1)
+	})
+
+	t.Run("Testing Handle when account id not exists in context", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/transfers", bytes.NewBufferString(`{"amount":10}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "0b8b418c")
+		recorder := httptest.NewRecorder()
+
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+
+		middleware.NewIdempotency(repository, time.Hour).Handle(func(w http.ResponseWriter, r *http.Request) {})(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestIdempotency_PurgeExpired(t *testing.T) {
+	t.Run("Testing PurgeExpired without a positive retention", func(t *testing.T) {
+		repository := mock.NewIdempotencyKeyRepositoryMock()
+
+		middleware.NewIdempotency(repository, 0).PurgeExpired(context.Background())
+
+		repository.AssertNotCalled(t, "DeleteExpired", testify.Anything, testify.Anything)
+	})
+}
@@ -1,15 +1,17 @@
 package routes
 
 import (
+	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/web"
 	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
 	"net/http"
 )
 
-func HandleAccountRoutes(webserver *webserver.WebServer, webAccountHandler *web.WebAccountHandler) {
-	webserver.AddHandler("/accounts", http.MethodGet, webAccountHandler.Find, true)
-	webserver.AddHandler("/accounts", http.MethodPost, webAccountHandler.Create, false)
-	webserver.AddHandler("/accounts/{account_id}/balance", http.MethodGet, webAccountHandler.FindBalanceByAccount, true)
-	webserver.AddHandler("/login", http.MethodPost, webAccountHandler.Login, false)
+func HandleAccountRoutes(webserver *webserver.WebServer, webAccountHandler *web.WebAccountHandler, authorization *middleware.Authorization) {
+	webserver.AddHandler("/accounts", http.MethodGet, webAccountHandler.Find, entity.LIST_ACCOUNTS_PERMISSION)
+	webserver.AddHandler("/accounts", http.MethodPost, webAccountHandler.Create, entity.PUBLIC_PERMISSION)
+	webserver.AddHandler("/accounts/{account_id}/balance", http.MethodGet, authorization.OwnAccount(webAccountHandler.FindBalanceByAccount), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/login", http.MethodPost, webAccountHandler.Login, entity.PUBLIC_PERMISSION)
 
 }
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleBackOfficeRoutes(webserver *webserver.WebServer, webBackOfficeHandler *web.WebBackOfficeHandler) {
+	webserver.AddHandler("/accounts/{account_id}/role", http.MethodPut, webBackOfficeHandler.ChangeAccountRole, entity.MANAGE_ROLES_PERMISSION)
+	webserver.AddHandler("/accounts/{account_id}/credit-limit", http.MethodPut, webBackOfficeHandler.ChangeCreditLimit, entity.MANAGE_CREDIT_LIMIT_PERMISSION)
+	webserver.AddHandler("/ledger/reconciliation", http.MethodGet, webBackOfficeHandler.ReconcileLedger, entity.RECONCILE_LEDGER_PERMISSION)
+
+}
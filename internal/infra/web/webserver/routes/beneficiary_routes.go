@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleBeneficiaryRoutes(webserver *webserver.WebServer, webBeneficiaryHandler *web.WebBeneficiaryHandler) {
+	webserver.AddHandler("/beneficiaries", http.MethodPost, webBeneficiaryHandler.Create, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/beneficiaries", http.MethodGet, webBeneficiaryHandler.FindByAccountID, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/beneficiaries/suggestions", http.MethodGet, webBeneficiaryHandler.FindSuggestions, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/beneficiaries/{beneficiary_id}", http.MethodPut, webBeneficiaryHandler.Update, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/beneficiaries/{beneficiary_id}", http.MethodDelete, webBeneficiaryHandler.Delete, entity.AUTHENTICATED_PERMISSION)
+}
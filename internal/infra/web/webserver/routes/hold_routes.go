@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+)
+
+func HandleHoldRoutes(webserver *webserver.WebServer, webHoldHandler *web.WebHoldHandler, idempotency *middleware.Idempotency) {
+	webserver.AddHandler("/holds", http.MethodPost, idempotency.Handle(webHoldHandler.Create), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/holds/{hold_id}", http.MethodGet, webHoldHandler.FindByID, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/holds/{hold_id}/capture", http.MethodPost, idempotency.Handle(webHoldHandler.Capture), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/holds/{hold_id}/release", http.MethodPost, webHoldHandler.Release, entity.AUTHENTICATED_PERMISSION)
+
+}
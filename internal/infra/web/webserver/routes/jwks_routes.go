@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleJWKSRoutes(webserver *webserver.WebServer, webJWKSHandler *web.WebJWKSHandler) {
+	webserver.AddHandler("/.well-known/jwks.json", http.MethodGet, webJWKSHandler.JWKS, entity.PUBLIC_PERMISSION)
+
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+)
+
+func HandleMovementRoutes(webserver *webserver.WebServer, webMovementHandler *web.WebMovementHandler, idempotency *middleware.Idempotency, authorization *middleware.Authorization) {
+	webserver.AddHandler("/accounts/{account_id}/deposits", http.MethodPost, idempotency.Handle(webMovementHandler.Deposit), entity.DEPOSIT_PERMISSION)
+	webserver.AddHandler("/accounts/{account_id}/withdrawals", http.MethodPost, authorization.OwnAccount(idempotency.Handle(webMovementHandler.Withdraw)), entity.AUTHENTICATED_PERMISSION)
+
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+)
+
+func HandleScheduledTransferRoutes(webserver *webserver.WebServer, webScheduledTransferHandler *web.WebScheduledTransferHandler, idempotency *middleware.Idempotency) {
+	webserver.AddHandler("/scheduled-transfers", http.MethodPost, idempotency.Handle(webScheduledTransferHandler.Create), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/scheduled-transfers", http.MethodGet, webScheduledTransferHandler.FindByAccountID, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/scheduled-transfers/{scheduled_transfer_id}/status", http.MethodPut, webScheduledTransferHandler.ChangeStatus, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/scheduled-transfers/{scheduled_transfer_id}/executions", http.MethodGet, webScheduledTransferHandler.FindExecutions, entity.AUTHENTICATED_PERMISSION)
+
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleSecretRoutes(webserver *webserver.WebServer, webSecretHandler *web.WebSecretHandler) {
+	webserver.AddHandler("/accounts/me/secret", http.MethodPut, webSecretHandler.ChangeSecret, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/secret/reset", http.MethodPost, webSecretHandler.RequestSecretReset, entity.PUBLIC_PERMISSION)
+	webserver.AddHandler("/secret/reset/confirm", http.MethodPost, webSecretHandler.ResetSecret, entity.PUBLIC_PERMISSION)
+
+}
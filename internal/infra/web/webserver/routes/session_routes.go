@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleSessionRoutes(webserver *webserver.WebServer, webSessionHandler *web.WebSessionHandler) {
+	webserver.AddHandler("/token/refresh", http.MethodPost, webSessionHandler.RefreshToken, entity.PUBLIC_PERMISSION)
+	webserver.AddHandler("/logout", http.MethodPost, webSessionHandler.Logout, entity.AUTHENTICATED_PERMISSION)
+
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+)
+
+func HandleTransferBatchRoutes(webserver *webserver.WebServer, webTransferBatchHandler *web.WebTransferBatchHandler, idempotency *middleware.Idempotency) {
+	webserver.AddHandler("/transfers/batch", http.MethodPost, idempotency.Handle(webTransferBatchHandler.Create), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfers/batch/{batch_id}", http.MethodGet, webTransferBatchHandler.FindByID, entity.AUTHENTICATED_PERMISSION)
+
+}
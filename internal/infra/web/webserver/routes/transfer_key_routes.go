@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleTransferKeyRoutes(webserver *webserver.WebServer, webTransferKeyHandler *web.WebTransferKeyHandler) {
+	webserver.AddHandler("/transfer-keys", http.MethodPost, webTransferKeyHandler.Create, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfer-keys", http.MethodGet, webTransferKeyHandler.FindByAccountID, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfer-keys/{key}", http.MethodGet, webTransferKeyHandler.FindByKey, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfer-keys/{key}", http.MethodDelete, webTransferKeyHandler.Delete, entity.AUTHENTICATED_PERMISSION)
+
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
+	"net/http"
+)
+
+func HandleTransferLimitRoutes(webserver *webserver.WebServer, webTransferLimitHandler *web.WebTransferLimitHandler, authorization *middleware.Authorization) {
+	webserver.AddHandler("/accounts/{account_id}/limits", http.MethodGet, authorization.OwnAccount(webTransferLimitHandler.Find), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/accounts/{account_id}/limits", http.MethodPut, authorization.OwnAccount(webTransferLimitHandler.Change), entity.AUTHENTICATED_PERMISSION)
+
+}
@@ -1,13 +1,18 @@
 package routes
 
 import (
+	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/infra/web"
 	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"lucassantoss1701/bank/internal/infra/web/webserver/middleware"
 	"net/http"
 )
 
-func HandleTransferRoutes(webserver *webserver.WebServer, webTransferHandler *web.WebTransferHandler) {
-	webserver.AddHandler("/transfers", http.MethodPost, webTransferHandler.Create, true)
-	webserver.AddHandler("/transfers", http.MethodGet, webTransferHandler.FindByAccountID, true)
+func HandleTransferRoutes(webserver *webserver.WebServer, webTransferHandler *web.WebTransferHandler, idempotency *middleware.Idempotency, authorization *middleware.Authorization) {
+	webserver.AddHandler("/transfers", http.MethodPost, idempotency.Handle(webTransferHandler.Create), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfers", http.MethodGet, webTransferHandler.FindByAccountID, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfers/{transfer_id}", http.MethodGet, webTransferHandler.FindByID, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/transfers/{transfer_id}/reversals", http.MethodPost, idempotency.Handle(webTransferHandler.Reverse), entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/accounts/{account_id}/transfers", http.MethodGet, authorization.OwnAccount(webTransferHandler.FindByAccount), entity.AUTHENTICATED_PERMISSION)
 
 }
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/infra/web"
+	"lucassantoss1701/bank/internal/infra/web/webserver"
+	"net/http"
+)
+
+func HandleTwoFactorRoutes(webserver *webserver.WebServer, webTwoFactorHandler *web.WebTwoFactorHandler) {
+	webserver.AddHandler("/mfa/totp", http.MethodPost, webTwoFactorHandler.EnrollTOTP, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/mfa/totp/confirm", http.MethodPost, webTwoFactorHandler.ConfirmTOTP, entity.AUTHENTICATED_PERMISSION)
+	webserver.AddHandler("/login/mfa", http.MethodPost, webTwoFactorHandler.LoginMFA, entity.PUBLIC_PERMISSION)
+
+}
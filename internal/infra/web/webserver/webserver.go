@@ -22,9 +22,9 @@ import (
 
 type Handler struct {
 	http.HandlerFunc
-	method  string
-	path    string
-	useAuth bool
+	method     string
+	path       string
+	permission entity.Permission
 }
 
 type WebServer struct {
@@ -32,22 +32,27 @@ type WebServer struct {
 	Handlers      []Handler
 	srv           *http.Server
 	WebServerPort string
+	auth          func(http.Handler) http.Handler
 }
 
-func NewWebServer(serverPort string) *WebServer {
+// NewWebServer creates the server, auth authenticates every route that is not public.
+func NewWebServer(serverPort string, auth func(http.Handler) http.Handler) *WebServer {
 	return &WebServer{
 		Router:        chi.NewRouter(),
 		Handlers:      []Handler{},
 		WebServerPort: serverPort,
+		auth:          auth,
 	}
 }
 
-func (s *WebServer) AddHandler(path string, method string, handler http.HandlerFunc, useAuth bool) {
+// AddHandler registers a route with the permission it requires, entity.PUBLIC_PERMISSION
+// routes are served without a token.
+func (s *WebServer) AddHandler(path string, method string, handler http.HandlerFunc, permission entity.Permission) {
 	s.Handlers = append(s.Handlers, Handler{
 		path:        path,
 		method:      method,
 		HandlerFunc: handler,
-		useAuth:     useAuth,
+		permission:  permission,
 	})
 }
 
@@ -63,7 +68,8 @@ func (s *WebServer) Start() {
 	go func() {
 		<-sig
 
-		shutdownCtx, _ := context.WithTimeout(serverCtx, 30*time.Second)
+		shutdownCtx, shutdownCancel := context.WithTimeout(serverCtx, 30*time.Second)
+		defer shutdownCancel()
 
 		go func() {
 			<-shutdownCtx.Done()
@@ -119,7 +125,7 @@ func (s *WebServer) startCHI() {
 	var noAuthHandlers []Handler
 
 	for _, handler := range s.Handlers {
-		if handler.useAuth {
+		if handler.permission != entity.PUBLIC_PERMISSION {
 			authHandlers = append(authHandlers, handler)
 		} else {
 			noAuthHandlers = append(noAuthHandlers, handler)
@@ -128,9 +134,9 @@ func (s *WebServer) startCHI() {
 	}
 
 	s.Router.Group(func(r chi.Router) {
-		r.Use(customMiddleware.Auth)
+		r.Use(s.auth)
 		for _, handler := range authHandlers {
-			r.Method(handler.method, handler.path, handler.HandlerFunc)
+			r.With(customMiddleware.RequirePermission(handler.permission)).Method(handler.method, handler.path, handler.HandlerFunc)
 		}
 	})
 
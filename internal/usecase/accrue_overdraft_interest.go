@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IAccrueOverdraftInterestUseCase interface {
+	Execute(ctx context.Context, input *AccrueOverdraftInterestUseCaseInput) (*AccrueOverdraftInterestUseCaseOutput, error)
+}
+
+// AccrueOverdraftInterestUseCase charges the daily interest of every overdrawn account. The
+// day is the date of the run in the time zone of the bank and an account is charged at most
+// once per day, so the job can run many times a day and on many instances.
+type AccrueOverdraftInterestUseCase struct {
+	accountRepository  entity.AccountRepository
+	interestRepository entity.OverdraftInterestRepository
+	ledgerRepository   entity.LedgerRepository
+	rate               entity.OverdraftInterestRate
+	location           *time.Location
+	batchSize          int
+	entity.Repository
+}
+
+func NewAccrueOverdraftInterestUseCase(accountRepository entity.AccountRepository, interestRepository entity.OverdraftInterestRepository, ledgerRepository entity.LedgerRepository, rate entity.OverdraftInterestRate, location *time.Location, batchSize int, repository entity.Repository) *AccrueOverdraftInterestUseCase {
+	return &AccrueOverdraftInterestUseCase{
+		accountRepository:  accountRepository,
+		interestRepository: interestRepository,
+		ledgerRepository:   ledgerRepository,
+		rate:               rate,
+		location:           location,
+		batchSize:          batchSize,
+		Repository:         repository,
+	}
+}
+
+func (a *AccrueOverdraftInterestUseCase) Execute(ctx context.Context, input *AccrueOverdraftInterestUseCaseInput) (*AccrueOverdraftInterestUseCaseOutput, error) {
+	accrualDate := input.Now.In(a.location).Format(entity.ACCRUAL_DATE_LAYOUT)
+
+	output := &AccrueOverdraftInterestUseCaseOutput{AccrualDate: accrualDate}
+
+	after := ""
+	for {
+		accountIDs, err := a.interestRepository.FindOverdrawnAccountIDs(ctx, accrualDate, after, a.batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, accountID := range accountIDs {
+			accrued, err := a.accrue(ctx, accountID, accrualDate, input.Now)
+			if err != nil {
+				return nil, err
+			}
+
+			if accrued {
+				output.Accrued++
+			}
+		}
+
+		// the accounts left uncharged, like the ones with interest below the minor unit, are
+		// behind the cursor and not visited again in this run
+		if len(accountIDs) < a.batchSize {
+			return output, nil
+		}
+
+		after = accountIDs[len(accountIDs)-1]
+	}
+}
+
+// accrue charges one account inside its own transaction, it tells whether the account was
+// charged: the balance may have been paid off since it was listed, and another instance may
+// have charged it first.
+func (a *AccrueOverdraftInterestUseCase) accrue(ctx context.Context, accountID string, accrualDate string, now time.Time) (accrued bool, err error) {
+	transaction, err := a.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = a.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil || !accrued {
+			_ = a.RollbackTx(transaction)
+		} else {
+			_ = a.CommitTx(transaction)
+		}
+	}()
+
+	account, err := a.accountRepository.FindByIDForUpdate(ctx, accountID, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	if !account.Balance.IsNegative() {
+		return false, nil
+	}
+
+	interest, err := entity.NewOverdraftInterest("", &account, accrualDate, a.rate, &now)
+	if err != nil {
+		return false, err
+	}
+
+	if interest.Amount.IsZero() {
+		return false, nil
+	}
+
+	err = a.interestRepository.Create(ctx, interest, transaction)
+	if err != nil {
+		if handler, ok := err.(*entity.ErrorHandler); ok && handler.TypeError == entity.CONFLICT_ERROR {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = interest.Apply()
+	if err != nil {
+		return false, err
+	}
+
+	entry, err := entity.NewOverdraftInterestJournalEntry(interest)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = a.ledgerRepository.Post(ctx, entry, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type AccrueOverdraftInterestUseCaseInput struct {
+	Now time.Time
+}
+
+func NewAccrueOverdraftInterestUseCaseInput(now time.Time) *AccrueOverdraftInterestUseCaseInput {
+	return &AccrueOverdraftInterestUseCaseInput{
+		Now: now,
+	}
+}
+
+type AccrueOverdraftInterestUseCaseOutput struct {
+	AccrualDate string
+	Accrued     int
+}
@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetOverdrawnAccount is the base origin account with a credit limit of 2000.00 BRL and a
+// balance of -1000.00 BRL, as read by FindByIDForUpdate.
+func GetOverdrawnAccount(t *testing.T) entity.Account {
+	account := GetBaseOriginAccount(t)
+	account.Balance = entity.NewMoney(-100000, entity.BRL)
+	account.CreditLimit = entity.NewMoney(200000, entity.BRL)
+
+	return *account
+}
+
+func TestAccrueOverdraftInterestUseCase_Execute(t *testing.T) {
+	// 23:30 of the 13th in São Paulo
+	now := time.Date(2023, 8, 14, 2, 30, 00, 00, time.UTC)
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	assert.Nil(t, err)
+
+	t.Run("Testing AccrueOverdraftInterestUseCase charges the overdrawn accounts", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetOverdrawnAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+
+		interestRepository := mock.NewOverdraftInterestRepositoryMock()
+		interestRepository.On("FindOverdrawnAccountIDs", ctx, "2023-08-13", "", 2).Return([]string{account.ID}, nil)
+		interestRepository.On("Create", ctx, testify.AnythingOfType("*entity.OverdraftInterest"), testify.Anything).Return(nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewAccrueOverdraftInterestUseCase(accountRepository, interestRepository, ledgerRepository, "0.0026", location, 2, repository)
+		output, err := useCase.Execute(ctx, usecase.NewAccrueOverdraftInterestUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.AccrueOverdraftInterestUseCaseOutput{AccrualDate: "2023-08-13", Accrued: 1}, output)
+
+		interestRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(interest *entity.OverdraftInterest) bool {
+			return interest.Account.ID == account.ID && interest.AccrualDate == "2023-08-13" &&
+				interest.Balance == entity.NewMoney(-100000, entity.BRL) && interest.Amount == entity.NewMoney(260, entity.BRL)
+		}), testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return entry.Type == entity.OVERDRAFT_INTEREST_ENTRY && entry.Postings[0].AccountID == account.ID && entry.Postings[0].Amount == entity.NewMoney(260, entity.BRL)
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing AccrueOverdraftInterestUseCase goes through the batches", func(t *testing.T) {
+		ctx := context.Background()
+		overdrawn := GetOverdrawnAccount(t)
+		paidOff := GetBaseDestinationAccount(t)
+		alreadyCharged := GetOverdrawnAccount(t)
+		alreadyCharged.ID = "f0b6b3f4-9c8a-4a0e-8d8f-3b7d3d7c2e11"
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, overdrawn.ID).Return(overdrawn, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, paidOff.ID).Return(*paidOff, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, alreadyCharged.ID).Return(alreadyCharged, nil)
+
+		interestRepository := mock.NewOverdraftInterestRepositoryMock()
+		interestRepository.On("FindOverdrawnAccountIDs", ctx, "2023-08-13", "", 2).Return([]string{overdrawn.ID, paidOff.ID}, nil)
+		interestRepository.On("FindOverdrawnAccountIDs", ctx, "2023-08-13", paidOff.ID, 2).Return([]string{alreadyCharged.ID}, nil)
+		interestRepository.On("Create", ctx, testify.MatchedBy(func(interest *entity.OverdraftInterest) bool {
+			return interest.Account.ID == overdrawn.ID
+		}), testify.Anything).Return(nil)
+		interestRepository.On("Create", ctx, testify.MatchedBy(func(interest *entity.OverdraftInterest) bool {
+			return interest.Account.ID == alreadyCharged.ID
+		}), testify.Anything).Return(entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("interest already accrued"))
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewAccrueOverdraftInterestUseCase(accountRepository, interestRepository, ledgerRepository, "0.0026", location, 2, repository)
+		output, err := useCase.Execute(ctx, usecase.NewAccrueOverdraftInterestUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, output.Accrued)
+
+		ledgerRepository.AssertNumberOfCalls(t, "Post", 1)
+		repository.AssertNumberOfCalls(t, "CommitTx", 1)
+		repository.AssertNumberOfCalls(t, "RollbackTx", 2)
+	})
+
+	t.Run("Testing AccrueOverdraftInterestUseCase when Post returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetOverdrawnAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+
+		interestRepository := mock.NewOverdraftInterestRepositoryMock()
+		interestRepository.On("FindOverdrawnAccountIDs", ctx, "2023-08-13", "", 100).Return([]string{account.ID}, nil)
+		interestRepository.On("Create", ctx, testify.AnythingOfType("*entity.OverdraftInterest"), testify.Anything).Return(nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, errors.New("connection closed"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewAccrueOverdraftInterestUseCase(accountRepository, interestRepository, ledgerRepository, "0.0026", location, 100, repository)
+		output, err := useCase.Execute(ctx, usecase.NewAccrueOverdraftInterestUseCaseInput(now))
+
+		assert.Nil(t, output)
+		assert.NotNil(t, err)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing AccrueOverdraftInterestUseCase when FindOverdrawnAccountIDs returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		interestRepository := mock.NewOverdraftInterestRepositoryMock()
+		interestRepository.On("FindOverdrawnAccountIDs", ctx, "2023-08-13", "", 100).Return([]string(nil), errors.New("connection closed"))
+
+		useCase := usecase.NewAccrueOverdraftInterestUseCase(mock.NewAccountRepositoryMock(), interestRepository, mock.NewLedgerRepositoryMock(), "0.0026", location, 100, mock.NewRepositoryMock())
+		output, err := useCase.Execute(ctx, usecase.NewAccrueOverdraftInterestUseCaseInput(now))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
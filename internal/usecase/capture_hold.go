@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type ICaptureHoldUseCase interface {
+	Execute(ctx context.Context, input *CaptureHoldUseCaseInput) (*CaptureHoldUseCaseOutput, error)
+}
+
+// CaptureHoldUseCase moves all or part of a hold to the destination account with a transfer
+// linked to the hold, the rest goes back to the available balance of the origin account. The
+// transfer goes through the limits, the fees and the exchange rate like any other transfer, so a
+// hold cannot be used to skip the fees or the free quota of the month.
+type CaptureHoldUseCase struct {
+	accountRepository  entity.AccountRepository
+	holdRepository     entity.HoldRepository
+	transferRepository entity.TransferRepository
+	ledgerRepository   entity.LedgerRepository
+	exchangeRateRule   *TransferExchangeRateRule
+	limitRule          *TransferLimitRule
+	feeRule            *TransferFeeRule
+	entity.Repository
+}
+
+func NewCaptureHoldUseCase(accountRepository entity.AccountRepository, holdRepository entity.HoldRepository, transferRepository entity.TransferRepository, ledgerRepository entity.LedgerRepository, exchangeRateRule *TransferExchangeRateRule, limitRule *TransferLimitRule, feeRule *TransferFeeRule, repository entity.Repository) *CaptureHoldUseCase {
+	return &CaptureHoldUseCase{
+		accountRepository:  accountRepository,
+		holdRepository:     holdRepository,
+		transferRepository: transferRepository,
+		ledgerRepository:   ledgerRepository,
+		exchangeRateRule:   exchangeRateRule,
+		limitRule:          limitRule,
+		feeRule:            feeRule,
+		Repository:         repository,
+	}
+}
+
+func (c *CaptureHoldUseCase) Execute(ctx context.Context, input *CaptureHoldUseCaseInput) (*CaptureHoldUseCaseOutput, error) {
+	if input.Principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	hold, err := c.holdRepository.FindByIDForUpdate(ctx, input.HoldID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeHoldRecipient(input.Principal, &hold, "capture")
+	if err != nil {
+		return nil, err
+	}
+
+	lockedAccounts, err := lockAccounts(ctx, c.accountRepository, transaction, hold.OriginAccount.ID, hold.DestinationAccount.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	originAccount := lockedAccounts[hold.OriginAccount.ID]
+	destinationAccount := lockedAccounts[hold.DestinationAccount.ID]
+	hold.OriginAccount = &originAccount
+	hold.DestinationAccount = &destinationAccount
+
+	amount := hold.Amount
+	if input.Amount != nil {
+		amount = *input.Amount
+	}
+
+	// the hold is given back to the origin account first, so the transfer can spend it
+	err = hold.Capture(amount, *input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := entity.NewTransfer(input.ID, &originAccount, &destinationAccount, hold.CapturedAmount, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.limitRule.Check(ctx, transfer, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.feeRule.Apply(ctx, transfer, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Amount.Currency != destinationAccount.Currency() {
+		var exchangeRate entity.ExchangeRate
+		exchangeRate, err = c.exchangeRateRule.Quote(ctx, transfer.Amount.Currency, destinationAccount.Currency(), time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		err = transfer.ApplyExchangeRate(exchangeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = transfer.MakeTransfer()
+	if err != nil {
+		return nil, err
+	}
+
+	createdTransfer, err := c.transferRepository.Create(ctx, transfer, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := entity.NewTransferJournalEntry(transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.ledgerRepository.Post(ctx, entry, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	hold.LinkTransfer(transfer)
+
+	err = c.holdRepository.Finish(ctx, &hold, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	createdTransfer.OriginAccount = transfer.OriginAccount
+	createdTransfer.DestinationAccount = transfer.DestinationAccount
+
+	return &CaptureHoldUseCaseOutput{
+		HoldUseCaseOutput: *NewHoldUseCaseOutput(&hold),
+		Transfer:          *NewMakeTransferUseCaseOutput(&createdTransfer),
+	}, nil
+}
+
+// authorizeHoldRecipient lets only the destination account capture or release a hold, the
+// holds of other accounts are hidden as not found.
+func authorizeHoldRecipient(principal *entity.Principal, hold *entity.Hold, action string) error {
+	if principal.AccountID == hold.DestinationAccount.ID {
+		return nil
+	}
+
+	if principal.AccountID == hold.OriginAccount.ID {
+		return entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add(fmt.Sprintf("only the recipient can %s the hold", action))
+	}
+
+	return entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found hold: %s", hold.ID))
+}
+
+type CaptureHoldUseCaseInput struct {
+	ID        string            `json:"-"`
+	HoldID    string            `json:"-"`
+	Principal *entity.Principal `json:"-"`
+	Amount    *entity.Money     `json:"amount,omitempty"`
+	CreatedAt *time.Time        `json:"-"`
+}
+
+// NewCaptureHoldUseCaseInput captures the whole hold when amount is nil.
+func NewCaptureHoldUseCaseInput(ID string, holdID string, principal *entity.Principal, amount *entity.Money, createdAt *time.Time) *CaptureHoldUseCaseInput {
+	return &CaptureHoldUseCaseInput{
+		ID:        ID,
+		HoldID:    holdID,
+		Principal: principal,
+		Amount:    amount,
+		CreatedAt: createdAt,
+	}
+}
+
+// CaptureHoldUseCaseOutput is the captured hold with the transfer that moved the money.
+type CaptureHoldUseCaseOutput struct {
+	HoldUseCaseOutput
+	Transfer MakeTransferUseCaseOutput `json:"transfer"`
+}
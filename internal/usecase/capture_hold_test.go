@@ -0,0 +1,249 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetActiveHold is a hold of 60 from the base origin account to the base destination account,
+// as read by FindByIDForUpdate.
+func GetActiveHold(t *testing.T) entity.Hold {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	expiresAt := createdAt.Add(168 * time.Hour)
+
+	return entity.Hold{
+		ID:                 "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		OriginAccount:      &entity.Account{ID: GetBaseOriginAccount(t).ID},
+		DestinationAccount: &entity.Account{ID: GetBaseDestinationAccount(t).ID},
+		Amount:             entity.NewMoney(60, entity.BRL),
+		CapturedAmount:     entity.NewMoney(0, entity.BRL),
+		Status:             entity.ACTIVE_HOLD,
+		ExpiresAt:          &expiresAt,
+		CreatedAt:          &createdAt,
+		UpdatedAt:          &createdAt,
+	}
+}
+
+// GetHoldingOriginAccount is the base origin account with the funds of GetActiveHold on hold.
+func GetHoldingOriginAccount(t *testing.T) entity.Account {
+	account := GetBaseOriginAccount(t)
+	account.Held = entity.NewMoney(60, entity.BRL)
+
+	return *account
+}
+
+func TestCaptureHoldUseCase_Execute(t *testing.T) {
+	capturedAt := time.Date(2023, 8, 15, 10, 00, 00, 00, time.UTC)
+	transferID := "4f1b9a6e-5d0c-4a55-8d2f-2a8f0a4c1e77"
+
+	t.Run("Testing CaptureHoldUseCase when the recipient captures part of the hold", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)        // Balance = 100, Held = 60
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+		holdRepository.On("Finish", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{ID: transferID, Amount: entity.NewMoney(45, entity.BRL), DestinationAmount: entity.NewMoney(45, entity.BRL), ExchangeRate: "1", ReversedAmount: entity.NewMoney(0, entity.BRL), Status: entity.COMPLETED_TRANSFER, CreatedAt: &capturedAt}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		amount := entity.NewMoney(45, entity.BRL)
+		useCase := usecase.NewCaptureHoldUseCase(accountRepository, holdRepository, transferRepository, ledgerRepository, usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), repository)
+		input := usecase.NewCaptureHoldUseCaseInput(transferID, "7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), &amount, &capturedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "captured", output.Status)
+		assert.Equal(t, entity.NewMoney(45, entity.BRL), output.CapturedAmount)
+		assert.Equal(t, transferID, output.TransferID)
+		assert.Equal(t, transferID, output.Transfer.ID)
+		assert.Equal(t, originAccount.ID, output.Transfer.OriginAccount.ID)
+
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.Amount == entity.NewMoney(45, entity.BRL) && transfer.OriginAccount.Balance == entity.NewMoney(55, entity.BRL) &&
+				transfer.OriginAccount.Held == entity.NewMoney(0, entity.BRL) && transfer.DestinationAccount.Balance == entity.NewMoney(245, entity.BRL)
+		}), testify.Anything)
+		holdRepository.AssertCalled(t, "Finish", ctx, testify.MatchedBy(func(hold *entity.Hold) bool {
+			return hold.Status == entity.CAPTURED_HOLD && hold.TransferID == transferID
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing CaptureHoldUseCase charges the fees of the transfer", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)        // Balance = 100, Held = 60
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+		holdRepository.On("Finish", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(nil)
+
+		fees := []entity.TransferFee{
+			{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.BRL)},
+			{Rule: "percentage", Type: entity.PERCENTAGE_FEE, Amount: entity.NewMoney(5, entity.BRL)},
+		}
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, originAccount.ID, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), testify.Anything).Return(2, nil)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{ID: transferID, Amount: entity.NewMoney(45, entity.BRL), DestinationAmount: entity.NewMoney(45, entity.BRL), ExchangeRate: "1", ReversedAmount: entity.NewMoney(0, entity.BRL), Fee: entity.NewMoney(15, entity.BRL), Fees: fees, Status: entity.COMPLETED_TRANSFER, CreatedAt: &capturedAt}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		amount := entity.NewMoney(45, entity.BRL)
+		feeRule := usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t))
+		useCase := usecase.NewCaptureHoldUseCase(accountRepository, holdRepository, transferRepository, ledgerRepository, usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), feeRule, repository)
+		input := usecase.NewCaptureHoldUseCaseInput(transferID, "7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), &amount, &capturedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(15, entity.BRL), output.Transfer.Fee)
+		assert.Len(t, output.Transfer.Fees, 2)
+
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.Fee == entity.NewMoney(15, entity.BRL) && transfer.OriginAccount.Balance == entity.NewMoney(40, entity.BRL) &&
+				transfer.DestinationAccount.Balance == entity.NewMoney(245, entity.BRL)
+		}), testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return len(entry.Postings) == 4 &&
+				entry.Postings[2].AccountID == originAccount.ID && entry.Postings[2].Direction == entity.DEBIT && entry.Postings[2].Amount == entity.NewMoney(15, entity.BRL) &&
+				entry.Postings[3].AccountID == entity.FEES_LEDGER_ACCOUNT && entry.Postings[3].Direction == entity.CREDIT && entry.Postings[3].Amount == entity.NewMoney(15, entity.BRL)
+		}), testify.Anything)
+	})
+
+	t.Run("Testing CaptureHoldUseCase when the sender tries to capture", func(t *testing.T) {
+		ctx := context.Background()
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCaptureHoldUseCase(mock.NewAccountRepositoryMock(), holdRepository, mock.NewTransferRepositoryMock(), mock.NewLedgerRepositoryMock(), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), repository)
+		input := usecase.NewCaptureHoldUseCaseInput(transferID, "7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE), nil, &capturedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "only the recipient can capture the hold", err.Error())
+	})
+
+	t.Run("Testing CaptureHoldUseCase when the hold belongs to other accounts", func(t *testing.T) {
+		ctx := context.Background()
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCaptureHoldUseCase(mock.NewAccountRepositoryMock(), holdRepository, mock.NewTransferRepositoryMock(), mock.NewLedgerRepositoryMock(), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), repository)
+		input := usecase.NewCaptureHoldUseCaseInput(transferID, "7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal("f0b6b3f4-9c8a-4a0e-8d8f-3b7d3d7c2e11", entity.CUSTOMER_ROLE), nil, &capturedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing CaptureHoldUseCase when the hold expired", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		expiredAt := capturedAt.Add(168 * time.Hour)
+		useCase := usecase.NewCaptureHoldUseCase(accountRepository, holdRepository, mock.NewTransferRepositoryMock(), mock.NewLedgerRepositoryMock(), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), repository)
+		input := usecase.NewCaptureHoldUseCaseInput(transferID, "7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), nil, &expiredAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "hold expired at 2023-08-21T10:00:00Z and cannot be captured", err.Error())
+		repository.AssertNotCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing CaptureHoldUseCase when Post returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, errors.New("connection closed"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCaptureHoldUseCase(accountRepository, holdRepository, transferRepository, ledgerRepository, usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), repository)
+		input := usecase.NewCaptureHoldUseCaseInput(transferID, "7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), nil, &capturedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		holdRepository.AssertNotCalled(t, "Finish", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+}
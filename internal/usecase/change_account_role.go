@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IChangeAccountRoleUseCase interface {
+	Execute(ctx context.Context, input *ChangeAccountRoleUseCaseInput) (*ChangeAccountRoleUseCaseOutput, error)
+}
+
+type ChangeAccountRoleUseCase struct {
+	repostiory entity.AccountRepository
+}
+
+func NewChangeAccountRoleUseCase(repostiory entity.AccountRepository) *ChangeAccountRoleUseCase {
+	return &ChangeAccountRoleUseCase{
+		repostiory: repostiory,
+	}
+}
+
+func (c *ChangeAccountRoleUseCase) Execute(ctx context.Context, input *ChangeAccountRoleUseCaseInput) (*ChangeAccountRoleUseCaseOutput, error) {
+	role, err := entity.NewRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := c.repostiory.FindByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.repostiory.UpdateRole(ctx, account.ID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChangeAccountRoleUseCaseOutput(account.ID, role), nil
+}
+
+type ChangeAccountRoleUseCaseInput struct {
+	AccountID string `json:"-"`
+	Role      string `json:"role"`
+}
+
+func NewChangeAccountRoleUseCaseInput(accountID string, role string) *ChangeAccountRoleUseCaseInput {
+	return &ChangeAccountRoleUseCaseInput{
+		AccountID: accountID,
+		Role:      role,
+	}
+}
+
+type ChangeAccountRoleUseCaseOutput struct {
+	AccountID string `json:"account_id"`
+	Role      string `json:"role"`
+}
+
+func NewChangeAccountRoleUseCaseOutput(accountID string, role entity.Role) *ChangeAccountRoleUseCaseOutput {
+	return &ChangeAccountRoleUseCaseOutput{
+		AccountID: accountID,
+		Role:      string(role),
+	}
+}
@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestChangeAccountRoleUseCase_Execute(t *testing.T) {
+	t.Run("Testing ChangeAccountRoleUseCase when have success on change the role", func(t *testing.T) {
+		ctx := context.Background()
+		ID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, ID).Return(mock.GetAccounts()[0], nil)
+		repository.On("UpdateRole", ctx, ID, entity.ADMIN_ROLE).Return(nil)
+
+		changeAccountRoleUseCase := usecase.NewChangeAccountRoleUseCase(repository)
+
+		output, err := changeAccountRoleUseCase.Execute(ctx, usecase.NewChangeAccountRoleUseCaseInput(ID, "ADMIN"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, ID, output.AccountID)
+		assert.Equal(t, "ADMIN", output.Role)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Testing ChangeAccountRoleUseCase when role is invalid", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewAccountRepositoryMock()
+
+		changeAccountRoleUseCase := usecase.NewChangeAccountRoleUseCase(repository)
+
+		output, err := changeAccountRoleUseCase.Execute(ctx, usecase.NewChangeAccountRoleUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "ROOT"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "role is invalid: ROOT", err.Error())
+		repository.AssertNotCalled(t, "UpdateRole", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeAccountRoleUseCase when account does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		ID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, ID).Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account: "+ID))
+
+		changeAccountRoleUseCase := usecase.NewChangeAccountRoleUseCase(repository)
+
+		output, err := changeAccountRoleUseCase.Execute(ctx, usecase.NewChangeAccountRoleUseCaseInput(ID, "ADMIN"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "not found account: "+ID, err.Error())
+		repository.AssertNotCalled(t, "UpdateRole", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeAccountRoleUseCase when repository returns an error on update", func(t *testing.T) {
+		ctx := context.Background()
+		ID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, ID).Return(mock.GetAccounts()[0], nil)
+		repository.On("UpdateRole", ctx, ID, entity.CUSTOMER_ROLE).Return(errors.New("error on update role"))
+
+		changeAccountRoleUseCase := usecase.NewChangeAccountRoleUseCase(repository)
+
+		output, err := changeAccountRoleUseCase.Execute(ctx, usecase.NewChangeAccountRoleUseCaseInput(ID, "CUSTOMER"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "error on update role", err.Error())
+	})
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IChangeCreditLimitUseCase interface {
+	Execute(ctx context.Context, input *ChangeCreditLimitUseCaseInput) (*ChangeCreditLimitUseCaseOutput, error)
+}
+
+// ChangeCreditLimitUseCase sets the overdraft approved for an account. The account is locked
+// while the limit changes, so a transfer cannot overdraw it beyond the new limit meanwhile.
+type ChangeCreditLimitUseCase struct {
+	accountRepository entity.AccountRepository
+	entity.Repository
+}
+
+func NewChangeCreditLimitUseCase(accountRepository entity.AccountRepository, repository entity.Repository) *ChangeCreditLimitUseCase {
+	return &ChangeCreditLimitUseCase{
+		accountRepository: accountRepository,
+		Repository:        repository,
+	}
+}
+
+func (c *ChangeCreditLimitUseCase) Execute(ctx context.Context, input *ChangeCreditLimitUseCaseInput) (*ChangeCreditLimitUseCaseOutput, error) {
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	account, err := c.accountRepository.FindByIDForUpdate(ctx, input.AccountID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = account.ChangeCreditLimit(input.CreditLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.accountRepository.UpdateCreditLimit(ctx, account.ID, account.CreditLimit, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChangeCreditLimitUseCaseOutput(&account), nil
+}
+
+type ChangeCreditLimitUseCaseInput struct {
+	AccountID   string       `json:"-"`
+	CreditLimit entity.Money `json:"credit_limit"`
+}
+
+func NewChangeCreditLimitUseCaseInput(accountID string, creditLimit entity.Money) *ChangeCreditLimitUseCaseInput {
+	return &ChangeCreditLimitUseCaseInput{
+		AccountID:   accountID,
+		CreditLimit: creditLimit,
+	}
+}
+
+type ChangeCreditLimitUseCaseOutput struct {
+	AccountID string `json:"account_id"`
+	FindBalanceByAccountUseCaseOutput
+}
+
+func NewChangeCreditLimitUseCaseOutput(account *entity.Account) *ChangeCreditLimitUseCaseOutput {
+	return &ChangeCreditLimitUseCaseOutput{
+		AccountID:                         account.ID,
+		FindBalanceByAccountUseCaseOutput: *NewFindBalanceByAccountUseCaseOutput(account),
+	}
+}
@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestChangeCreditLimitUseCase_Execute(t *testing.T) {
+	t.Run("Testing ChangeCreditLimitUseCase when have success on change the credit limit", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetOverdrawnAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+		accountRepository.On("UpdateCreditLimit", ctx, account.ID, entity.NewMoney(150000, entity.BRL)).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		output, err := usecase.NewChangeCreditLimitUseCase(accountRepository, repository).Execute(ctx, usecase.NewChangeCreditLimitUseCaseInput(account.ID, entity.NewMoney(150000, entity.BRL)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, account.ID, output.AccountID)
+		assert.Equal(t, entity.NewMoney(-100000, entity.BRL), output.Balance)
+		assert.Equal(t, entity.NewMoney(150000, entity.BRL), output.CreditLimit)
+		assert.Equal(t, entity.NewMoney(50000, entity.BRL), output.AvailableBalance)
+		accountRepository.AssertExpectations(t)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing ChangeCreditLimitUseCase when the limit is below the overdrawn amount", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetOverdrawnAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		output, err := usecase.NewChangeCreditLimitUseCase(accountRepository, repository).Execute(ctx, usecase.NewChangeCreditLimitUseCaseInput(account.ID, entity.NewMoney(0, entity.BRL)))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "credit limit cannot be lower than the overdrawn amount of 1000.00 BRL", err.Error())
+		accountRepository.AssertNotCalled(t, "UpdateCreditLimit", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing ChangeCreditLimitUseCase when account does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477").Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account: 2bd765a6-47bd-4731-9eb2-1e65542f4477"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		output, err := usecase.NewChangeCreditLimitUseCase(accountRepository, repository).Execute(ctx, usecase.NewChangeCreditLimitUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.NewMoney(150000, entity.BRL)))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing ChangeCreditLimitUseCase when UpdateCreditLimit returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetOverdrawnAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+		accountRepository.On("UpdateCreditLimit", ctx, account.ID, entity.NewMoney(150000, entity.BRL)).Return(errors.New("connection closed"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		output, err := usecase.NewChangeCreditLimitUseCase(accountRepository, repository).Execute(ctx, usecase.NewChangeCreditLimitUseCaseInput(account.ID, entity.NewMoney(150000, entity.BRL)))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertNotCalled(t, "CommitTx", transactionHandler)
+	})
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type IChangeScheduledTransferStatusUseCase interface {
+	Execute(ctx context.Context, input *ChangeScheduledTransferStatusUseCaseInput) (*ScheduledTransferUseCaseOutput, error)
+}
+
+// ChangeScheduledTransferStatusUseCase pauses, resumes or cancels a schedule of the account.
+type ChangeScheduledTransferStatusUseCase struct {
+	repository entity.ScheduledTransferRepository
+}
+
+func NewChangeScheduledTransferStatusUseCase(repository entity.ScheduledTransferRepository) *ChangeScheduledTransferStatusUseCase {
+	return &ChangeScheduledTransferStatusUseCase{
+		repository: repository,
+	}
+}
+
+func (c *ChangeScheduledTransferStatusUseCase) Execute(ctx context.Context, input *ChangeScheduledTransferStatusUseCaseInput) (*ScheduledTransferUseCaseOutput, error) {
+	scheduledTransfer, err := findOwnScheduledTransfer(ctx, c.repository, input.AccountID, input.ScheduledTransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedStatus := scheduledTransfer.Status
+	expectedNextRunAt := scheduledTransfer.NextRunAt
+
+	err = scheduledTransfer.ChangeStatus(entity.ScheduleStatus(strings.ToUpper(input.Status)), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := c.repository.Update(ctx, &scheduledTransfer, expectedStatus, expectedNextRunAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated {
+		return nil, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add(fmt.Sprintf("scheduled transfer %s changed while updating, try again", scheduledTransfer.ID))
+	}
+
+	return NewScheduledTransferUseCaseOutput(&scheduledTransfer), nil
+}
+
+// findOwnScheduledTransfer hides the schedules of other accounts as if they did not exist.
+func findOwnScheduledTransfer(ctx context.Context, repository entity.ScheduledTransferRepository, accountID string, ID string) (entity.ScheduledTransfer, error) {
+	scheduledTransfer, err := repository.FindByID(ctx, ID)
+	if err != nil {
+		return entity.ScheduledTransfer{}, err
+	}
+
+	if scheduledTransfer.OriginAccountID != accountID {
+		return entity.ScheduledTransfer{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found scheduled transfer: %s", ID))
+	}
+
+	return scheduledTransfer, nil
+}
+
+type ChangeScheduledTransferStatusUseCaseInput struct {
+	AccountID           string `json:"-"`
+	ScheduledTransferID string `json:"-"`
+	Status              string `json:"status" example:"paused"`
+}
+
+func NewChangeScheduledTransferStatusUseCaseInput(accountID string, scheduledTransferID string, status string) *ChangeScheduledTransferStatusUseCaseInput {
+	return &ChangeScheduledTransferStatusUseCaseInput{
+		AccountID:           accountID,
+		ScheduledTransferID: scheduledTransferID,
+		Status:              status,
+	}
+}
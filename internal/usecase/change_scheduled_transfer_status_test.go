@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeScheduledTransferStatusUseCase_Execute(t *testing.T) {
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+	scheduledTransferID := "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"
+
+	t.Run("Testing ChangeScheduledTransferStatusUseCase pauses a schedule", func(t *testing.T) {
+		ctx := context.Background()
+		scheduledTransfer := GetScheduledTransfers(t, scheduledTransferID)[0]
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(scheduledTransfer, nil)
+		repository.On("Update", ctx, testify.MatchedBy(func(updated *entity.ScheduledTransfer) bool {
+			return updated.Status == entity.PAUSED_SCHEDULE
+		}), entity.ACTIVE_SCHEDULE, scheduledTransfer.NextRunAt).Return(true, nil)
+
+		output, err := usecase.NewChangeScheduledTransferStatusUseCase(repository).Execute(ctx, usecase.NewChangeScheduledTransferStatusUseCaseInput(accountID, scheduledTransferID, "paused"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "paused", output.Status)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Testing ChangeScheduledTransferStatusUseCase cancels a schedule", func(t *testing.T) {
+		ctx := context.Background()
+		scheduledTransfer := GetScheduledTransfers(t, scheduledTransferID)[0]
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(scheduledTransfer, nil)
+		repository.On("Update", ctx, testify.Anything, entity.ACTIVE_SCHEDULE, scheduledTransfer.NextRunAt).Return(true, nil)
+
+		output, err := usecase.NewChangeScheduledTransferStatusUseCase(repository).Execute(ctx, usecase.NewChangeScheduledTransferStatusUseCaseInput(accountID, scheduledTransferID, "canceled"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "canceled", output.Status)
+		assert.Empty(t, output.NextRunAt)
+	})
+
+	t.Run("Testing ChangeScheduledTransferStatusUseCase with a schedule of another account", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(GetScheduledTransfers(t, scheduledTransferID)[0], nil)
+
+		_, err := usecase.NewChangeScheduledTransferStatusUseCase(repository).Execute(ctx, usecase.NewChangeScheduledTransferStatusUseCaseInput("d18551d3-cf13-49ec-b1dc-741a1f8715f6", scheduledTransferID, "paused"))
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found scheduled transfer: c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", err.Error())
+		repository.AssertNotCalled(t, "Update", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeScheduledTransferStatusUseCase with an invalid status", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(GetScheduledTransfers(t, scheduledTransferID)[0], nil)
+
+		_, err := usecase.NewChangeScheduledTransferStatusUseCase(repository).Execute(ctx, usecase.NewChangeScheduledTransferStatusUseCaseInput(accountID, scheduledTransferID, "finished"))
+
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "status must be one of ACTIVE, PAUSED or CANCELED", err.Error())
+	})
+
+	t.Run("Testing ChangeScheduledTransferStatusUseCase when the schedule changed concurrently", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(GetScheduledTransfers(t, scheduledTransferID)[0], nil)
+		repository.On("Update", ctx, testify.Anything, testify.Anything, testify.Anything).Return(false, nil)
+
+		_, err := usecase.NewChangeScheduledTransferStatusUseCase(repository).Execute(ctx, usecase.NewChangeScheduledTransferStatusUseCaseInput(accountID, scheduledTransferID, "paused"))
+
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IChangeSecretUseCase interface {
+	Execute(ctx context.Context, input *ChangeSecretUseCaseInput) error
+}
+
+// ChangeSecretUseCase replaces the secret of the logged account. The current secret is checked
+// with the same throttling of the login, and every session of the account is revoked.
+type ChangeSecretUseCase struct {
+	accountRepository      entity.AccountRepository
+	refreshTokenRepository entity.RefreshTokenRepository
+	revokedTokenRepository entity.RevokedTokenRepository
+	loginGuard             *LoginGuard
+	entity.Repository
+}
+
+func NewChangeSecretUseCase(accountRepository entity.AccountRepository, refreshTokenRepository entity.RefreshTokenRepository, revokedTokenRepository entity.RevokedTokenRepository, loginGuard *LoginGuard, repository entity.Repository) *ChangeSecretUseCase {
+	return &ChangeSecretUseCase{
+		accountRepository:      accountRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		revokedTokenRepository: revokedTokenRepository,
+		loginGuard:             loginGuard,
+		Repository:             repository,
+	}
+}
+
+func (c *ChangeSecretUseCase) Execute(ctx context.Context, input *ChangeSecretUseCaseInput) error {
+	if input.CurrentSecret == "" || input.NewSecret == "" {
+		return entity.NewErrorHandler(entity.BAD_REQUEST).Add("current secret and new secret cannot be empty")
+	}
+
+	account, err := c.accountRepository.FindByID(ctx, input.AccountID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	succeeded, err := c.loginGuard.Attempt(ctx, account.CPF, input.IP, now, func() (entity.LoginFailureReason, error) {
+		if !account.SecretIsCorrect(input.CurrentSecret) {
+			return entity.WRONG_SECRET_LOGIN_FAILURE, nil
+		}
+		return "", nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !succeeded {
+		return entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("current secret is incorrect")
+	}
+
+	err = account.ChangeSecret(input.NewSecret)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	err = c.accountRepository.UpdateSecret(ctx, account.ID, account.Secret, transaction)
+	if err != nil {
+		return err
+	}
+
+	err = c.refreshTokenRepository.RevokeByAccountID(ctx, account.ID, now, transaction)
+	if err != nil {
+		return err
+	}
+
+	// the other access tokens of the account expire on their own, they cannot be refreshed anymore
+	if input.TokenID != "" && input.TokenExpiresAt != nil {
+		var revokedToken *entity.RevokedToken
+		revokedToken, err = entity.NewRevokedToken(input.TokenID, input.TokenExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		err = c.revokedTokenRepository.Create(ctx, revokedToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type ChangeSecretUseCaseInput struct {
+	AccountID      string     `json:"-"`
+	CurrentSecret  string     `json:"current_secret"`
+	NewSecret      string     `json:"new_secret"`
+	IP             string     `json:"-"`
+	TokenID        string     `json:"-"`
+	TokenExpiresAt *time.Time `json:"-"`
+}
+
+func NewChangeSecretUseCaseInput(accountID string, currentSecret string, newSecret string, IP string, tokenID string, tokenExpiresAt *time.Time) *ChangeSecretUseCaseInput {
+	return &ChangeSecretUseCaseInput{
+		AccountID:      accountID,
+		CurrentSecret:  currentSecret,
+		NewSecret:      newSecret,
+		IP:             IP,
+		TokenID:        tokenID,
+		TokenExpiresAt: tokenExpiresAt,
+	}
+}
@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestChangeSecretUseCase_Execute(t *testing.T) {
+	IP := "203.0.113.7"
+	expiresAt := time.Date(2023, 8, 5, 8, 37, 00, 00, time.UTC)
+
+	t.Run("Testing ChangeSecretUseCase changes the secret and revokes the sessions", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetAccountWithHashedSecret(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, account.ID).Return(account, nil)
+		accountRepository.On("UpdateSecret", ctx, account.ID, testify.Anything).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("RevokeByAccountID", ctx, account.ID, testify.Anything).Return(nil)
+
+		revokedTokenRepository := mock.NewRevokedTokenRepositoryMock()
+		revokedTokenRepository.On("Create", ctx, &entity.RevokedToken{JTI: "fc84682a-3045-4bdf-b91c-10be19f89452", ExpiresAt: &expiresAt}).Return(nil)
+
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "cpf:"+account.CPF).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		changeSecretUseCase := usecase.NewChangeSecretUseCase(accountRepository, refreshTokenRepository, revokedTokenRepository, loginGuard, repository)
+		input := usecase.NewChangeSecretUseCaseInput(account.ID, "5e0542f964858f96ae7194fb2a7dd365", "n3wSecretValue", IP, "fc84682a-3045-4bdf-b91c-10be19f89452", &expiresAt)
+
+		err := changeSecretUseCase.Execute(ctx, input)
+		assert.Nil(t, err)
+
+		storedSecret := accountRepository.Calls[1].Arguments.String(2)
+		updated := entity.Account{Secret: storedSecret}
+		assert.True(t, updated.SecretIsCorrect("n3wSecretValue"))
+
+		refreshTokenRepository.AssertExpectations(t)
+		revokedTokenRepository.AssertExpectations(t)
+		loginThrottleRepository.AssertCalled(t, "Reset", ctx, "cpf:"+account.CPF)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+	})
+
+	t.Run("Testing ChangeSecretUseCase when current secret is incorrect", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetAccountWithHashedSecret(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, account.ID).Return(account, nil)
+
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		repository := mock.NewRepositoryMock()
+
+		changeSecretUseCase := usecase.NewChangeSecretUseCase(accountRepository, mock.NewRefreshTokenRepositoryMock(), mock.NewRevokedTokenRepositoryMock(), loginGuard, repository)
+		input := usecase.NewChangeSecretUseCaseInput(account.ID, "wrongSecret123", "n3wSecretValue", IP, "fc84682a-3045-4bdf-b91c-10be19f89452", &expiresAt)
+
+		err := changeSecretUseCase.Execute(ctx, input)
+		assert.Equal(t, "current secret is incorrect", err.Error())
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "cpf:"+account.CPF, testify.Anything, testify.Anything)
+		accountRepository.AssertNotCalled(t, "UpdateSecret", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing ChangeSecretUseCase when new secret is weak", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetAccountWithHashedSecret(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, account.ID).Return(account, nil)
+
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "cpf:"+account.CPF).Return(nil)
+
+		repository := mock.NewRepositoryMock()
+
+		changeSecretUseCase := usecase.NewChangeSecretUseCase(accountRepository, mock.NewRefreshTokenRepositoryMock(), mock.NewRevokedTokenRepositoryMock(), loginGuard, repository)
+		input := usecase.NewChangeSecretUseCaseInput(account.ID, "5e0542f964858f96ae7194fb2a7dd365", "short1", IP, "", nil)
+
+		err := changeSecretUseCase.Execute(ctx, input)
+		assert.Equal(t, "secret must have at least 8 characters", err.Error())
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing ChangeSecretUseCase when revoking the sessions fails", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetAccountWithHashedSecret(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, account.ID).Return(account, nil)
+		accountRepository.On("UpdateSecret", ctx, account.ID, testify.Anything).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("RevokeByAccountID", ctx, account.ID, testify.Anything).Return(errors.New("connection closed"))
+
+		revokedTokenRepository := mock.NewRevokedTokenRepositoryMock()
+
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "cpf:"+account.CPF).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		changeSecretUseCase := usecase.NewChangeSecretUseCase(accountRepository, refreshTokenRepository, revokedTokenRepository, loginGuard, repository)
+		input := usecase.NewChangeSecretUseCaseInput(account.ID, "5e0542f964858f96ae7194fb2a7dd365", "n3wSecretValue", IP, "fc84682a-3045-4bdf-b91c-10be19f89452", &expiresAt)
+
+		err := changeSecretUseCase.Execute(ctx, input)
+		assert.Equal(t, "connection closed", err.Error())
+
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+		revokedTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeSecretUseCase with empty secrets", func(t *testing.T) {
+		ctx := context.Background()
+		accountRepository := mock.NewAccountRepositoryMock()
+		loginGuard, _, _ := GetLoginGuard()
+
+		changeSecretUseCase := usecase.NewChangeSecretUseCase(accountRepository, mock.NewRefreshTokenRepositoryMock(), mock.NewRevokedTokenRepositoryMock(), loginGuard, mock.NewRepositoryMock())
+
+		err := changeSecretUseCase.Execute(ctx, usecase.NewChangeSecretUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", "", IP, "", nil))
+		assert.Equal(t, "current secret and new secret cannot be empty", err.Error())
+		accountRepository.AssertNotCalled(t, "FindByID", testify.Anything, testify.Anything)
+	})
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IChangeTransferLimitUseCase interface {
+	Execute(ctx context.Context, input *ChangeTransferLimitUseCaseInput) (*FindTransferLimitUseCaseOutput, error)
+}
+
+// ChangeTransferLimitUseCase lets the account lower its own limits, only principals allowed to
+// raise transfer limits can set a limit above the current one.
+type ChangeTransferLimitUseCase struct {
+	accountRepository entity.AccountRepository
+	limitRepository   entity.TransferLimitRepository
+	limitRule         *TransferLimitRule
+}
+
+func NewChangeTransferLimitUseCase(accountRepository entity.AccountRepository, limitRepository entity.TransferLimitRepository, limitRule *TransferLimitRule) *ChangeTransferLimitUseCase {
+	return &ChangeTransferLimitUseCase{
+		accountRepository: accountRepository,
+		limitRepository:   limitRepository,
+		limitRule:         limitRule,
+	}
+}
+
+func (c *ChangeTransferLimitUseCase) Execute(ctx context.Context, input *ChangeTransferLimitUseCaseInput) (*FindTransferLimitUseCaseOutput, error) {
+	if input.Principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	account, err := c.accountRepository.FindByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := c.limitRule.Find(ctx, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	transferLimit, err := entity.NewTransferLimit(account.ID,
+		valueOrCurrent(input.PerTransaction, current.PerTransaction),
+		valueOrCurrent(input.Daily, current.Daily),
+		valueOrCurrent(input.Monthly, current.Monthly),
+		valueOrCurrent(input.Nighttime, current.Nighttime),
+		input.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the limits are compared with the current ones and with the transfers of the account, all
+	// of them in the currency of the account. NewTransferLimit already keeps the four limits in
+	// the same currency, so checking one of them is enough
+	if transferLimit.Daily.Currency != account.Currency() {
+		return nil, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("transfer limits must be in %s", account.Currency()))
+	}
+
+	if transferLimit.Raises(current) && !input.Principal.Can(entity.RAISE_TRANSFER_LIMIT_PERMISSION) {
+		return nil, entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("transfer limits can only be lowered, ask the bank to raise them")
+	}
+
+	err = c.limitRepository.Save(ctx, transferLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFindTransferLimitUseCaseOutput(transferLimit, c.limitRule), nil
+}
+
+func valueOrCurrent(value *entity.Money, current entity.Money) entity.Money {
+	if value == nil {
+		return current
+	}
+
+	return *value
+}
+
+// ChangeTransferLimitUseCaseInput keeps the current value of every limit left empty.
+type ChangeTransferLimitUseCaseInput struct {
+	AccountID      string            `json:"-"`
+	Principal      *entity.Principal `json:"-"`
+	PerTransaction *entity.Money     `json:"per_transaction,omitempty"`
+	Daily          *entity.Money     `json:"daily,omitempty"`
+	Monthly        *entity.Money     `json:"monthly,omitempty"`
+	Nighttime      *entity.Money     `json:"nighttime,omitempty"`
+	UpdatedAt      *time.Time        `json:"-"`
+}
+
+func NewChangeTransferLimitUseCaseInput(accountID string, principal *entity.Principal, perTransaction *entity.Money, daily *entity.Money, monthly *entity.Money, nighttime *entity.Money, updatedAt *time.Time) *ChangeTransferLimitUseCaseInput {
+	return &ChangeTransferLimitUseCaseInput{
+		AccountID:      accountID,
+		Principal:      principal,
+		PerTransaction: perTransaction,
+		Daily:          daily,
+		Monthly:        monthly,
+		Nighttime:      nighttime,
+		UpdatedAt:      updatedAt,
+	}
+}
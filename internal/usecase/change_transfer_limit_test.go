@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetChangeTransferLimitUseCase changes the limits of an account that has its own limits,
+// see GetAccountTransferLimit.
+func GetChangeTransferLimitUseCase(t *testing.T, ctx context.Context, account *entity.Account) (*usecase.ChangeTransferLimitUseCase, *mock.TransferLimitRepositoryMock) {
+	accountRepository := mock.NewAccountRepositoryMock()
+	accountRepository.On("FindByID", ctx, account.ID).Return(*account, nil)
+
+	limitRepository := mock.NewTransferLimitRepositoryMock()
+	limitRepository.On("FindByAccountID", ctx, account.ID, testify.Anything).Return(GetAccountTransferLimit(t, account.ID), nil)
+	limitRepository.On("Save", ctx, testify.AnythingOfType("*entity.TransferLimit")).Return(nil)
+	limitRule := usecase.NewTransferLimitRule(limitRepository, mock.NewTransferRepositoryMock(), GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+	return usecase.NewChangeTransferLimitUseCase(accountRepository, limitRepository, limitRule), limitRepository
+}
+
+func TestChangeTransferLimitUseCase_Execute(t *testing.T) {
+	updatedAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing ChangeTransferLimitUseCase when the customer lowers a limit", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		changeTransferLimitUseCase, limitRepository := GetChangeTransferLimitUseCase(t, ctx, originAccount)
+
+		nighttime := entity.NewMoney(50, entity.BRL)
+		input := usecase.NewChangeTransferLimitUseCaseInput(originAccount.ID, entity.NewPrincipal(originAccount.ID, entity.CUSTOMER_ROLE), nil, nil, nil, &nighttime, &updatedAt)
+		output, err := changeTransferLimitUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, nighttime, output.Nighttime)
+		assert.Equal(t, entity.NewMoney(150, entity.BRL), output.Daily)
+		assert.Equal(t, "2023-08-07T10:00:00Z", output.UpdatedAt)
+		limitRepository.AssertCalled(t, "Save", ctx, testify.MatchedBy(func(transferLimit *entity.TransferLimit) bool {
+			return transferLimit.AccountID == originAccount.ID && transferLimit.Nighttime == nighttime && transferLimit.PerTransaction == entity.NewMoney(100, entity.BRL)
+		}))
+	})
+
+	t.Run("Testing ChangeTransferLimitUseCase when the customer raises a limit", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		changeTransferLimitUseCase, limitRepository := GetChangeTransferLimitUseCase(t, ctx, originAccount)
+
+		monthly := entity.NewMoney(2000, entity.BRL)
+		input := usecase.NewChangeTransferLimitUseCaseInput(originAccount.ID, entity.NewPrincipal(originAccount.ID, entity.CUSTOMER_ROLE), nil, nil, &monthly, nil, &updatedAt)
+		output, err := changeTransferLimitUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer limits can only be lowered, ask the bank to raise them", err.Error())
+		limitRepository.AssertNotCalled(t, "Save", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeTransferLimitUseCase when an admin raises a limit", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		changeTransferLimitUseCase, limitRepository := GetChangeTransferLimitUseCase(t, ctx, originAccount)
+
+		monthly := entity.NewMoney(2000, entity.BRL)
+		input := usecase.NewChangeTransferLimitUseCaseInput(originAccount.ID, entity.NewPrincipal("e2d4b0a8-9b1f-4d1e-8a5f-3c2b1a0f9e8d", entity.ADMIN_ROLE), nil, nil, &monthly, nil, &updatedAt)
+		output, err := changeTransferLimitUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, monthly, output.Monthly)
+		limitRepository.AssertCalled(t, "Save", ctx, testify.Anything)
+	})
+
+	t.Run("Testing ChangeTransferLimitUseCase when the limits are inconsistent", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		changeTransferLimitUseCase, limitRepository := GetChangeTransferLimitUseCase(t, ctx, originAccount)
+
+		daily := entity.NewMoney(90, entity.BRL)
+		input := usecase.NewChangeTransferLimitUseCaseInput(originAccount.ID, entity.NewPrincipal(originAccount.ID, entity.CUSTOMER_ROLE), nil, &daily, nil, nil, &updatedAt)
+		output, err := changeTransferLimitUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "per-transaction limit cannot be greater than the daily limit", err.Error())
+		limitRepository.AssertNotCalled(t, "Save", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeTransferLimitUseCase when the limits are not in the currency of the account", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		changeTransferLimitUseCase, limitRepository := GetChangeTransferLimitUseCase(t, ctx, originAccount)
+
+		perTransaction := entity.NewMoney(10, entity.USD)
+		daily := entity.NewMoney(20, entity.USD)
+		monthly := entity.NewMoney(30, entity.USD)
+		nighttime := entity.NewMoney(10, entity.USD)
+		input := usecase.NewChangeTransferLimitUseCaseInput(originAccount.ID, entity.NewPrincipal(originAccount.ID, entity.CUSTOMER_ROLE), &perTransaction, &daily, &monthly, &nighttime, &updatedAt)
+		output, err := changeTransferLimitUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer limits must be in BRL", err.Error())
+		limitRepository.AssertNotCalled(t, "Save", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ChangeTransferLimitUseCase without principal", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		changeTransferLimitUseCase, _ := GetChangeTransferLimitUseCase(t, ctx, originAccount)
+
+		output, err := changeTransferLimitUseCase.Execute(ctx, usecase.NewChangeTransferLimitUseCaseInput(originAccount.ID, nil, nil, nil, nil, nil, &updatedAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
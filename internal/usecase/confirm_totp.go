@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IConfirmTOTPUseCase interface {
+	Execute(ctx context.Context, input *ConfirmTOTPUseCaseInput) (*ConfirmTOTPUseCaseOutput, error)
+}
+
+// ConfirmTOTPUseCase turns the enrolled authenticator on once it produces a valid code, and
+// hands out the recovery codes used when the authenticator is lost.
+type ConfirmTOTPUseCase struct {
+	totpRepository         entity.TOTPRepository
+	recoveryCodeRepository entity.RecoveryCodeRepository
+	twoFactor              *TwoFactor
+	entity.Repository
+}
+
+func NewConfirmTOTPUseCase(totpRepository entity.TOTPRepository, recoveryCodeRepository entity.RecoveryCodeRepository, twoFactor *TwoFactor, repository entity.Repository) *ConfirmTOTPUseCase {
+	return &ConfirmTOTPUseCase{
+		totpRepository:         totpRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		twoFactor:              twoFactor,
+		Repository:             repository,
+	}
+}
+
+func (c *ConfirmTOTPUseCase) Execute(ctx context.Context, input *ConfirmTOTPUseCaseInput) (*ConfirmTOTPUseCaseOutput, error) {
+	totp, err := c.totpRepository.FindByAccountID(ctx, input.AccountID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("two-factor authentication was not enrolled")
+		}
+		return nil, err
+	}
+
+	if totp.IsConfirmed() {
+		return nil, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("two-factor authentication is already enabled")
+	}
+
+	now := time.Now()
+
+	valid, err := c.twoFactor.verifyEnrollment(ctx, &totp, input.Code, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, entity.NewErrorHandler(entity.ENTITY_ERROR).Add("totp code is invalid")
+	}
+
+	recoveryCodes, rawRecoveryCodes, err := entity.NewRecoveryCodes(input.AccountID, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	err = c.totpRepository.Confirm(ctx, input.AccountID, now, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.recoveryCodeRepository.Replace(ctx, input.AccountID, recoveryCodes, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConfirmTOTPUseCaseOutput(rawRecoveryCodes), nil
+}
+
+type ConfirmTOTPUseCaseInput struct {
+	AccountID string `json:"-"`
+	Code      string `json:"code"`
+}
+
+func NewConfirmTOTPUseCaseInput(accountID string, code string) *ConfirmTOTPUseCaseInput {
+	return &ConfirmTOTPUseCaseInput{
+		AccountID: accountID,
+		Code:      code,
+	}
+}
+
+type ConfirmTOTPUseCaseOutput struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func NewConfirmTOTPUseCaseOutput(recoveryCodes []string) *ConfirmTOTPUseCaseOutput {
+	return &ConfirmTOTPUseCaseOutput{
+		RecoveryCodes: recoveryCodes,
+	}
+}
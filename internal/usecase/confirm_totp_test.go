@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestConfirmTOTPUseCase_Execute(t *testing.T) {
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+	enrolled := entity.TOTP{AccountID: accountID, Secret: totpSecret}
+
+	t.Run("Testing ConfirmTOTPUseCase with a valid code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, recoveryCodeRepository, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(enrolled, nil)
+		totpRepository.On("UseStep", ctx, accountID, testify.Anything).Return(true, nil)
+		totpRepository.On("Confirm", ctx, accountID, testify.Anything).Return(nil)
+		recoveryCodeRepository.On("Replace", ctx, accountID, testify.Anything).Return(nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(totpRepository, recoveryCodeRepository, twoFactor, repository)
+		output, err := confirmTOTPUseCase.Execute(ctx, usecase.NewConfirmTOTPUseCaseInput(accountID, GetCurrentTOTPCode(t)))
+
+		assert.Nil(t, err)
+		assert.Len(t, output.RecoveryCodes, entity.RECOVERY_CODES_COUNT)
+
+		recoveryCodes := recoveryCodeRepository.Calls[0].Arguments.Get(2).([]*entity.RecoveryCode)
+		assert.Equal(t, entity.HashRecoveryCode(accountID, output.RecoveryCodes[0]), recoveryCodes[0].CodeHash)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing ConfirmTOTPUseCase with an invalid code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, recoveryCodeRepository, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(enrolled, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, "totp:"+accountID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		repository := mock.NewRepositoryMock()
+
+		confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(totpRepository, recoveryCodeRepository, twoFactor, repository)
+		output, err := confirmTOTPUseCase.Execute(ctx, usecase.NewConfirmTOTPUseCaseInput(accountID, "000000"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "totp code is invalid", err.Error())
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing ConfirmTOTPUseCase when the account did not enroll", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, recoveryCodeRepository, _ := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(entity.TOTP{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found totp"))
+
+		confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(totpRepository, recoveryCodeRepository, twoFactor, mock.NewRepositoryMock())
+		output, err := confirmTOTPUseCase.Execute(ctx, usecase.NewConfirmTOTPUseCaseInput(accountID, "123456"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "two-factor authentication was not enrolled", err.Error())
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing ConfirmTOTPUseCase when two-factor authentication is already enabled", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, recoveryCodeRepository, _ := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+
+		confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(totpRepository, recoveryCodeRepository, twoFactor, mock.NewRepositoryMock())
+		output, err := confirmTOTPUseCase.Execute(ctx, usecase.NewConfirmTOTPUseCaseInput(accountID, "123456"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing ConfirmTOTPUseCase when Replace returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, recoveryCodeRepository, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(enrolled, nil)
+		totpRepository.On("UseStep", ctx, accountID, testify.Anything).Return(true, nil)
+		totpRepository.On("Confirm", ctx, accountID, testify.Anything).Return(nil)
+		recoveryCodeRepository.On("Replace", ctx, accountID, testify.Anything).Return(errors.New("connection closed"))
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(totpRepository, recoveryCodeRepository, twoFactor, repository)
+		output, err := confirmTOTPUseCase.Execute(ctx, usecase.NewConfirmTOTPUseCaseInput(accountID, GetCurrentTOTPCode(t)))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+	})
+}
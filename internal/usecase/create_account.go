@@ -11,39 +11,84 @@ type ICreateAccountUseCase interface {
 }
 
 type CreateAccountUseCase struct {
-	repostiory entity.AccountRepository
+	repostiory       entity.AccountRepository
+	ledgerRepository entity.LedgerRepository
+	entity.Repository
 }
 
-func NewCreateAccountUseCase(repostiory entity.AccountRepository) *CreateAccountUseCase {
+func NewCreateAccountUseCase(repostiory entity.AccountRepository, ledgerRepository entity.LedgerRepository, repository entity.Repository) *CreateAccountUseCase {
 	return &CreateAccountUseCase{
-		repostiory: repostiory,
+		repostiory:       repostiory,
+		ledgerRepository: ledgerRepository,
+		Repository:       repository,
 	}
 }
 
 func (c *CreateAccountUseCase) Execute(ctx context.Context, input *CreateAccountUseCaseInput) (*CreateAccountUseCaseOutput, error) {
-	account, err := entity.NewAccount(input.ID, input.Name, input.CPF, input.Secret, input.Balance, input.CreatedAt)
+	// an account opened without a balance is opened in the default currency
+	balance := input.Balance
+	if balance == (entity.Money{}) {
+		balance = entity.NewMoney(0, entity.DEFAULT_CURRENCY)
+	}
+
+	account, err := entity.NewAccount(input.ID, input.Name, input.CPF, input.Secret, balance, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// the account is created empty and the initial balance is credited through the ledger
+	openingBalance := account.Balance
+	account.Balance = entity.NewMoney(0, openingBalance.Currency)
+
+	transaction, err := c.BeginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	createdAccount, err := c.repostiory.Create(ctx, account)
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	createdAccount, err := c.repostiory.Create(ctx, account, transaction)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewCreateAccountUseCaseOutput(createdAccount.ID, createdAccount.Name, createdAccount.Balance, createdAccount.CreatedAt), nil
+	if openingBalance.IsPositive() {
+		var entry *entity.JournalEntry
+
+		entry, err = entity.NewOpeningBalanceJournalEntry(createdAccount.ID, openingBalance, createdAccount.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = c.ledgerRepository.Post(ctx, entry, transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewCreateAccountUseCaseOutput(createdAccount.ID, createdAccount.Name, openingBalance, createdAccount.CreatedAt), nil
 }
 
 type CreateAccountUseCaseInput struct {
-	ID        string     `json:"-"`
-	Name      string     `json:"name"`
-	CPF       string     `json:"cpf"`
-	Secret    string     `json:"secret"`
-	Balance   int        `json:"balance"`
-	CreatedAt *time.Time `json:"-"`
+	ID        string       `json:"-"`
+	Name      string       `json:"name"`
+	CPF       string       `json:"cpf"`
+	Secret    string       `json:"secret"`
+	Balance   entity.Money `json:"balance"`
+	CreatedAt *time.Time   `json:"-"`
 }
 
-func NewCreateAccountUseCaseInput(ID, name, CPF, secret string, balance int, createdAt time.Time) *CreateAccountUseCaseInput {
+func NewCreateAccountUseCaseInput(ID, name, CPF, secret string, balance entity.Money, createdAt time.Time) *CreateAccountUseCaseInput {
 	return &CreateAccountUseCaseInput{
 		ID:        ID,
 		Name:      name,
@@ -55,13 +100,13 @@ func NewCreateAccountUseCaseInput(ID, name, CPF, secret string, balance int, cre
 }
 
 type CreateAccountUseCaseOutput struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Balance   int    `json:"balance"`
-	CreatedAt string `json:"created_at"`
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Balance   entity.Money `json:"balance"`
+	CreatedAt string       `json:"created_at"`
 }
 
-func NewCreateAccountUseCaseOutput(ID string, name string, balance int, createdAt *time.Time) *CreateAccountUseCaseOutput {
+func NewCreateAccountUseCaseOutput(ID string, name string, balance entity.Money, createdAt *time.Time) *CreateAccountUseCaseOutput {
 	return &CreateAccountUseCaseOutput{
 		ID:        ID,
 		Name:      name,
@@ -23,7 +23,15 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 		account := mock.CreateAccount()
 		repository.On("Create", ctx, testify.AnythingOfTypeArgument("*entity.Account")).Return(account, nil)
 
-		createAccountUseCase := usecase.NewCreateAccountUseCase(repository)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		baseRepository := mock.NewRepositoryMock()
+		baseRepository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		baseRepository.On("CommitTx", transactionHandler).Return(nil)
+
+		createAccountUseCase := usecase.NewCreateAccountUseCase(repository, ledgerRepository, baseRepository)
 
 		input := usecase.NewCreateAccountUseCaseInput(account.ID, account.Name, account.CPF, account.Secret, account.Balance, *account.CreatedAt)
 
@@ -38,6 +46,44 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 		assert.Equal(t, account.Balance, output.Balance)
 		assert.Equal(t, account.CreatedAt.Format(time.RFC3339), output.CreatedAt)
 
+		repository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(created *entity.Account) bool {
+			return created.Balance.IsZero()
+		}))
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return entry.Type == entity.OPENING_BALANCE_ENTRY && entry.ReferenceID == account.ID &&
+				entry.Postings[0].AccountID == entity.EXTERNAL_LEDGER_ACCOUNT && entry.Postings[0].Direction == entity.DEBIT &&
+				entry.Postings[1].AccountID == account.ID && entry.Postings[1].Direction == entity.CREDIT && entry.Postings[1].Amount == account.Balance
+		}), testify.Anything)
+		baseRepository.AssertCalled(t, "CommitTx", transactionHandler)
+		baseRepository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+	})
+
+	t.Run("Testing CreateAccountUseCase when opening balance is zero", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewAccountRepositoryMock()
+		account := mock.CreateAccount()
+		account.Balance = entity.NewMoney(0, entity.BRL)
+		repository.On("Create", ctx, testify.AnythingOfTypeArgument("*entity.Account")).Return(account, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		baseRepository := mock.NewRepositoryMock()
+		baseRepository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		baseRepository.On("CommitTx", transactionHandler).Return(nil)
+
+		createAccountUseCase := usecase.NewCreateAccountUseCase(repository, ledgerRepository, baseRepository)
+
+		input := usecase.NewCreateAccountUseCaseInput(account.ID, account.Name, account.CPF, account.Secret, account.Balance, *account.CreatedAt)
+
+		output, err := createAccountUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.True(t, output.Balance.IsZero())
+
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		baseRepository.AssertCalled(t, "CommitTx", transactionHandler)
 	})
 
 	t.Run("Testing CreateAccountUseCase when create new account return error", func(t *testing.T) {
@@ -47,7 +93,10 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 		account := mock.CreateAccount()
 		repository.On("Create", ctx, testify.AnythingOfTypeArgument("*entity.Account")).Return(account, nil)
 
-		createAccountUseCase := usecase.NewCreateAccountUseCase(repository)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		baseRepository := mock.NewRepositoryMock()
+
+		createAccountUseCase := usecase.NewCreateAccountUseCase(repository, ledgerRepository, baseRepository)
 
 		input := usecase.NewCreateAccountUseCaseInput("", "", account.CPF, account.Secret, account.Balance, *account.CreatedAt)
 
@@ -58,6 +107,7 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Equal(t, "name cannot be empty", err.Error())
 
+		baseRepository.AssertNotCalled(t, "BeginTx", ctx)
 	})
 
 	t.Run("Testing CreateAccountUseCase when repository return error", func(t *testing.T) {
@@ -67,7 +117,14 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 		account := mock.CreateAccount()
 		repository.On("Create", ctx, testify.AnythingOfTypeArgument("*entity.Account")).Return(entity.Account{}, errors.New("error on create account"))
 
-		createAccountUseCase := usecase.NewCreateAccountUseCase(repository)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		baseRepository := mock.NewRepositoryMock()
+		baseRepository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		baseRepository.On("RollbackTx", transactionHandler).Return(nil)
+
+		createAccountUseCase := usecase.NewCreateAccountUseCase(repository, ledgerRepository, baseRepository)
 
 		input := usecase.NewCreateAccountUseCaseInput(account.ID, account.Name, account.CPF, account.Secret, account.Balance, *account.CreatedAt)
 
@@ -78,5 +135,37 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Equal(t, "error on create account", err.Error())
 
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		baseRepository.AssertCalled(t, "RollbackTx", transactionHandler)
+		baseRepository.AssertNotCalled(t, "CommitTx", testify.Anything)
+	})
+
+	t.Run("Testing CreateAccountUseCase when posting the opening balance returns error", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewAccountRepositoryMock()
+		account := mock.CreateAccount()
+		repository.On("Create", ctx, testify.AnythingOfTypeArgument("*entity.Account")).Return(account, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, errors.New("error on post journal entry"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		baseRepository := mock.NewRepositoryMock()
+		baseRepository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		baseRepository.On("RollbackTx", transactionHandler).Return(nil)
+
+		createAccountUseCase := usecase.NewCreateAccountUseCase(repository, ledgerRepository, baseRepository)
+
+		input := usecase.NewCreateAccountUseCaseInput(account.ID, account.Name, account.CPF, account.Secret, account.Balance, *account.CreatedAt)
+
+		output, err := createAccountUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error on post journal entry", err.Error())
+
+		baseRepository.AssertCalled(t, "RollbackTx", transactionHandler)
+		baseRepository.AssertNotCalled(t, "CommitTx", testify.Anything)
 	})
 }
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type ICreateBeneficiaryUseCase interface {
+	Execute(ctx context.Context, input *CreateBeneficiaryUseCaseInput) (*BeneficiaryUseCaseOutput, error)
+}
+
+// CreateBeneficiaryUseCase saves a recipient of the account, informed by its account ID or by
+// one of its keys, so later transfers can be sent to the beneficiary instead. As the masked
+// name of the owner is shown, a key goes through the lookup rule and its misses are counted
+// against the account, which cannot save beneficiaries by key while it is locked out.
+type CreateBeneficiaryUseCase struct {
+	accountRepository     entity.AccountRepository
+	beneficiaryRepository entity.BeneficiaryRepository
+	destinationRule       *TransferDestinationRule
+}
+
+func NewCreateBeneficiaryUseCase(accountRepository entity.AccountRepository, beneficiaryRepository entity.BeneficiaryRepository, destinationRule *TransferDestinationRule) *CreateBeneficiaryUseCase {
+	return &CreateBeneficiaryUseCase{
+		accountRepository:     accountRepository,
+		beneficiaryRepository: beneficiaryRepository,
+		destinationRule:       destinationRule,
+	}
+}
+
+func (c *CreateBeneficiaryUseCase) Execute(ctx context.Context, input *CreateBeneficiaryUseCaseInput) (*BeneficiaryUseCaseOutput, error) {
+	destination := MakeTransferUseCaseDestinationInput{
+		ID:  input.DestinationAccountID,
+		Key: input.Key,
+	}
+
+	err := c.destinationRule.Resolve(ctx, input.AccountID, &destination)
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiary, err := entity.NewBeneficiary("", input.AccountID, destination.ID, input.Nickname, input.Favorite, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationAccount, err := c.accountRepository.FindByID(ctx, beneficiary.DestinationAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.beneficiaryRepository.Create(ctx, beneficiary)
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiary.DestinationName = destinationAccount.Name
+
+	return NewBeneficiaryUseCaseOutput(beneficiary), nil
+}
+
+type CreateBeneficiaryUseCaseInput struct {
+	AccountID            string     `json:"-"`
+	DestinationAccountID string     `json:"destination_account_id,omitempty" example:"d18551d3-cf13-49ec-b1dc-741a1f8715f6"`
+	Key                  string     `json:"key,omitempty" example:"joao@example.com"`
+	Nickname             string     `json:"nickname" example:"Joao"`
+	Favorite             bool       `json:"favorite"`
+	CreatedAt            *time.Time `json:"-"`
+}
+
+func NewCreateBeneficiaryUseCaseInput(accountID string, destinationAccountID string, key string, nickname string, favorite bool, createdAt *time.Time) *CreateBeneficiaryUseCaseInput {
+	return &CreateBeneficiaryUseCaseInput{
+		AccountID:            accountID,
+		DestinationAccountID: destinationAccountID,
+		Key:                  key,
+		Nickname:             nickname,
+		Favorite:             favorite,
+		CreatedAt:            createdAt,
+	}
+}
+
+// BeneficiaryUseCaseOutput shows only the masked name of the destination account, as a key
+// lookup does, never its ID.
+type BeneficiaryUseCaseOutput struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Nickname  string `json:"nickname"`
+	Favorite  bool   `json:"favorite"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func NewBeneficiaryUseCaseOutput(beneficiary *entity.Beneficiary) *BeneficiaryUseCaseOutput {
+	return &BeneficiaryUseCaseOutput{
+		ID:        beneficiary.ID,
+		Name:      entity.MaskName(beneficiary.DestinationName),
+		Nickname:  beneficiary.Nickname,
+		Favorite:  beneficiary.Favorite,
+		CreatedAt: beneficiary.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: beneficiary.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBeneficiaryUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing CreateBeneficiaryUseCase saves the owner of a key", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Name = "Joao Pedro Silva"
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "joao@example.com").Return(entity.TransferKey{Key: "joao@example.com", Type: entity.EMAIL_KEY, AccountID: destinationAccount.ID}, nil)
+
+		lookupRule, _ := GetTransferKeyLookupRule(keyRepository)
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("Create", ctx, testify.AnythingOfType("*entity.Beneficiary")).Return(nil)
+
+		useCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, usecase.NewTransferDestinationRule(lookupRule, beneficiaryRepository))
+		output, err := useCase.Execute(ctx, usecase.NewCreateBeneficiaryUseCaseInput(originAccount.ID, "", "Joao@Example.com", "Joao", true, &createdAt))
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, output.ID)
+		assert.Equal(t, "Joao P**** S****", output.Name)
+		assert.Equal(t, "Joao", output.Nickname)
+		assert.True(t, output.Favorite)
+		assert.Equal(t, "2023-08-14T10:00:00Z", output.CreatedAt)
+		beneficiaryRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(beneficiary *entity.Beneficiary) bool {
+			return beneficiary.AccountID == originAccount.ID && beneficiary.DestinationAccountID == destinationAccount.ID
+		}))
+	})
+
+	t.Run("Testing CreateBeneficiaryUseCase counts a key that does not exist as a miss of the account", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "lucas@example.com").
+			Return(entity.TransferKey{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found key: lucas@example.com"))
+		lookupRule, loginThrottleRepository := GetTransferKeyLookupRule(keyRepository)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+
+		useCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, usecase.NewTransferDestinationRule(lookupRule, beneficiaryRepository))
+		output, err := useCase.Execute(ctx, usecase.NewCreateBeneficiaryUseCaseInput(originAccount.ID, "", "lucas@example.com", "Lucas", false, &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "key_lookup:"+originAccount.ID, testify.Anything, testify.Anything)
+		beneficiaryRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing CreateBeneficiaryUseCase when the account looked up too many keys that do not exist", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		lastFailureAt := time.Now()
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "key_lookup:"+originAccount.ID, testify.Anything).
+			Return(entity.LoginThrottle{Key: "key_lookup:" + originAccount.ID, Failures: 3, LastFailureAt: &lastFailureAt}, nil)
+		policy := entity.NewLoginThrottlePolicy(3, time.Second, time.Minute, 15*time.Minute)
+		lookupRule := usecase.NewTransferKeyLookupRule(keyRepository, loginThrottleRepository, policy, GetThrottleRepository())
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+
+		useCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, usecase.NewTransferDestinationRule(lookupRule, beneficiaryRepository))
+		output, err := useCase.Execute(ctx, usecase.NewCreateBeneficiaryUseCaseInput(originAccount.ID, "", "joao@example.com", "Joao", false, &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, err.(*entity.ErrorHandler).TypeError)
+		keyRepository.AssertNotCalled(t, "FindByKey", testify.Anything, testify.Anything)
+		accountRepository.AssertNotCalled(t, "FindByID", testify.Anything, testify.Anything)
+		beneficiaryRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing CreateBeneficiaryUseCase when the destination account does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, "d18551d3-cf13-49ec-b1dc-741a1f8715f6").
+			Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account with ID: d18551d3-cf13-49ec-b1dc-741a1f8715f6"))
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+
+		useCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, usecase.NewTransferDestinationRule(nil, beneficiaryRepository))
+		output, err := useCase.Execute(ctx, usecase.NewCreateBeneficiaryUseCaseInput(GetBaseOriginAccount(t).ID, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", "", "", false, &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		beneficiaryRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing CreateBeneficiaryUseCase when the account saves itself", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+
+		useCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, usecase.NewTransferDestinationRule(nil, beneficiaryRepository))
+		output, err := useCase.Execute(ctx, usecase.NewCreateBeneficiaryUseCaseInput(originAccount.ID, originAccount.ID, "", "Me", false, &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "account cannot save itself as a beneficiary", err.Error())
+		accountRepository.AssertNotCalled(t, "FindByID", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing CreateBeneficiaryUseCase when the destination account is already saved", func(t *testing.T) {
+		ctx := context.Background()
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("Create", ctx, testify.AnythingOfType("*entity.Beneficiary")).
+			Return(entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("beneficiary is already saved: d18551d3-cf13-49ec-b1dc-741a1f8715f6"))
+
+		useCase := usecase.NewCreateBeneficiaryUseCase(accountRepository, beneficiaryRepository, usecase.NewTransferDestinationRule(nil, beneficiaryRepository))
+		output, err := useCase.Execute(ctx, usecase.NewCreateBeneficiaryUseCaseInput(GetBaseOriginAccount(t).ID, destinationAccount.ID, "", "", false, &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type ICreateHoldUseCase interface {
+	Execute(ctx context.Context, input *CreateHoldUseCaseInput) (*HoldUseCaseOutput, error)
+}
+
+// CreateHoldUseCase reserves funds of the origin account for a later capture by the
+// destination account. The TOTP code of high-value holds is checked here, as the capture is
+// made by the recipient without the customer present.
+type CreateHoldUseCase struct {
+	accountRepository entity.AccountRepository
+	holdRepository    entity.HoldRepository
+	twoFactorRule     *TransferTwoFactorRule
+	defaultTTL        time.Duration
+	maxTTL            time.Duration
+	entity.Repository
+}
+
+func NewCreateHoldUseCase(accountRepository entity.AccountRepository, holdRepository entity.HoldRepository, twoFactorRule *TransferTwoFactorRule, defaultTTL time.Duration, maxTTL time.Duration, repository entity.Repository) *CreateHoldUseCase {
+	return &CreateHoldUseCase{
+		accountRepository: accountRepository,
+		holdRepository:    holdRepository,
+		twoFactorRule:     twoFactorRule,
+		defaultTTL:        defaultTTL,
+		maxTTL:            maxTTL,
+		Repository:        repository,
+	}
+}
+
+func (c *CreateHoldUseCase) Execute(ctx context.Context, input *CreateHoldUseCaseInput) (*HoldUseCaseOutput, error) {
+	expiresAt := input.ExpiresAt
+	if expiresAt == nil {
+		defaultExpiresAt := input.CreatedAt.Add(c.defaultTTL)
+		expiresAt = &defaultExpiresAt
+	}
+
+	latestExpiresAt := input.CreatedAt.Add(c.maxTTL)
+	if expiresAt.After(latestExpiresAt) {
+		return nil, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("expires at cannot be later than %s", latestExpiresAt.Format(time.RFC3339)))
+	}
+
+	err := c.twoFactorRule.Check(ctx, input.OriginAccount.ID, input.Amount, input.TOTPCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	lockedAccounts, err := lockAccounts(ctx, c.accountRepository, transaction, input.OriginAccount.ID, input.DestinationAccount.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	originAccount := lockedAccounts[input.OriginAccount.ID]
+	destinationAccount := lockedAccounts[input.DestinationAccount.ID]
+
+	hold, err := entity.NewHold(input.ID, &originAccount, &destinationAccount, input.Amount, expiresAt, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.holdRepository.Create(ctx, hold, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHoldUseCaseOutput(hold), nil
+}
+
+type CreateHoldUseCaseInput struct {
+	ID                 string                          `json:"-"`
+	OriginAccount      MakeTransferUseCaseAccountInput `json:"-"`
+	DestinationAccount MakeTransferUseCaseAccountInput `json:"destination_account"`
+	Amount             entity.Money                    `json:"amount"`
+	ExpiresAt          *time.Time                      `json:"expires_at,omitempty" example:"2023-08-21T10:00:00Z"`
+	TOTPCode           string                          `json:"totp_code,omitempty"`
+	CreatedAt          *time.Time                      `json:"-"`
+}
+
+// NewCreateHoldUseCaseInput expires the hold after the default TTL when expiresAt is nil.
+func NewCreateHoldUseCaseInput(ID string, originAccountID string, destinationAccountID string, amount entity.Money, expiresAt *time.Time, TOTPCode string, createdAt *time.Time) *CreateHoldUseCaseInput {
+	return &CreateHoldUseCaseInput{
+		ID: ID,
+		OriginAccount: MakeTransferUseCaseAccountInput{
+			ID: originAccountID,
+		},
+		DestinationAccount: MakeTransferUseCaseAccountInput{
+			ID: destinationAccountID,
+		},
+		Amount:    amount,
+		ExpiresAt: expiresAt,
+		TOTPCode:  TOTPCode,
+		CreatedAt: createdAt,
+	}
+}
+
+// HoldUseCaseOutput is a hold with, once captured, the amount taken and the transfer that
+// moved it.
+type HoldUseCaseOutput struct {
+	ID                   string       `json:"id"`
+	OriginAccountID      string       `json:"origin_account_id"`
+	DestinationAccountID string       `json:"destination_account_id"`
+	Amount               entity.Money `json:"amount"`
+	CapturedAmount       entity.Money `json:"captured_amount"`
+	TransferID           string       `json:"transfer_id,omitempty"`
+	Status               string       `json:"status"`
+	ExpiresAt            string       `json:"expires_at"`
+	CreatedAt            string       `json:"created_at"`
+	UpdatedAt            string       `json:"updated_at"`
+}
+
+func NewHoldUseCaseOutput(hold *entity.Hold) *HoldUseCaseOutput {
+	return &HoldUseCaseOutput{
+		ID:                   hold.ID,
+		OriginAccountID:      hold.OriginAccount.ID,
+		DestinationAccountID: hold.DestinationAccount.ID,
+		Amount:               hold.Amount,
+		CapturedAmount:       hold.CapturedAmount,
+		TransferID:           hold.TransferID,
+		Status:               strings.ToLower(string(hold.Status)),
+		ExpiresAt:            hold.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:            hold.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            hold.UpdatedAt.Format(time.RFC3339),
+	}
+}
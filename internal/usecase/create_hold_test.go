@@ -0,0 +1,128 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHoldUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	holdID := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+	t.Run("Testing CreateHoldUseCase reserves the funds until the default expiry", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)           // Balance = 100
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("Create", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateHoldUseCase(accountRepository, holdRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), 168*time.Hour, 720*time.Hour, repository)
+		input := usecase.NewCreateHoldUseCaseInput(holdID, originAccount.ID, destinationAccount.ID, entity.NewMoney(60, entity.BRL), nil, "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.HoldUseCaseOutput{
+			ID:                   holdID,
+			OriginAccountID:      originAccount.ID,
+			DestinationAccountID: destinationAccount.ID,
+			Amount:               entity.NewMoney(60, entity.BRL),
+			CapturedAmount:       entity.NewMoney(0, entity.BRL),
+			Status:               "active",
+			ExpiresAt:            "2023-08-21T10:00:00Z",
+			CreatedAt:            "2023-08-14T10:00:00Z",
+			UpdatedAt:            "2023-08-14T10:00:00Z",
+		}, output)
+
+		holdRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(hold *entity.Hold) bool {
+			return hold.OriginAccount.Held == entity.NewMoney(60, entity.BRL) && hold.OriginAccount.Balance == entity.NewMoney(100, entity.BRL)
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing CreateHoldUseCase when the available balance is insufficient", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		originAccount.Held = entity.NewMoney(60, entity.BRL)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateHoldUseCase(accountRepository, holdRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), 168*time.Hour, 720*time.Hour, repository)
+		input := usecase.NewCreateHoldUseCaseInput(holdID, originAccount.ID, destinationAccount.ID, entity.NewMoney(50, entity.BRL), nil, "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "insufficient available balance for hold", err.Error())
+		holdRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing CreateHoldUseCase when the expiry is beyond the maximum", func(t *testing.T) {
+		expiresAt := createdAt.Add(721 * time.Hour)
+
+		useCase := usecase.NewCreateHoldUseCase(mock.NewAccountRepositoryMock(), mock.NewHoldRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), 168*time.Hour, 720*time.Hour, mock.NewRepositoryMock())
+		input := usecase.NewCreateHoldUseCaseInput(holdID, GetBaseOriginAccount(t).ID, GetBaseDestinationAccount(t).ID, entity.NewMoney(60, entity.BRL), &expiresAt, "", &createdAt)
+		output, err := useCase.Execute(context.Background(), input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "expires at cannot be later than 2023-09-13T10:00:00Z", err.Error())
+	})
+
+	t.Run("Testing CreateHoldUseCase when Create returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("Create", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(errors.New("connection closed"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateHoldUseCase(accountRepository, holdRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), 168*time.Hour, 720*time.Hour, repository)
+		input := usecase.NewCreateHoldUseCaseInput(holdID, originAccount.ID, destinationAccount.ID, entity.NewMoney(60, entity.BRL), nil, "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertNotCalled(t, "CommitTx", transactionHandler)
+	})
+}
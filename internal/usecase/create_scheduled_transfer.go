@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type ICreateScheduledTransferUseCase interface {
+	Execute(ctx context.Context, input *CreateScheduledTransferUseCaseInput) (*ScheduledTransferUseCaseOutput, error)
+}
+
+// CreateScheduledTransferUseCase stores a transfer to be made later by the scheduler. The TOTP
+// code of high-value transfers is checked here, when the customer is present, and not on
+// every execution.
+type CreateScheduledTransferUseCase struct {
+	accountRepository           entity.AccountRepository
+	scheduledTransferRepository entity.ScheduledTransferRepository
+	twoFactorRule               *TransferTwoFactorRule
+}
+
+func NewCreateScheduledTransferUseCase(accountRepository entity.AccountRepository, scheduledTransferRepository entity.ScheduledTransferRepository, twoFactorRule *TransferTwoFactorRule) *CreateScheduledTransferUseCase {
+	return &CreateScheduledTransferUseCase{
+		accountRepository:           accountRepository,
+		scheduledTransferRepository: scheduledTransferRepository,
+		twoFactorRule:               twoFactorRule,
+	}
+}
+
+func (c *CreateScheduledTransferUseCase) Execute(ctx context.Context, input *CreateScheduledTransferUseCaseInput) (*ScheduledTransferUseCaseOutput, error) {
+	scheduledTransfer, err := entity.NewScheduledTransfer(input.ID, input.OriginAccount.ID, input.DestinationAccount.ID, input.Amount, entity.ScheduleFrequency(strings.ToUpper(input.Frequency)), input.StartAt, input.EndAt, input.MaxExecutions, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	originAccount, err := c.accountRepository.FindByID(ctx, scheduledTransfer.OriginAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledTransfer.Amount.Currency != originAccount.Currency() {
+		return nil, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("transfer currency %s must be the currency of the origin account", scheduledTransfer.Amount.Currency))
+	}
+
+	_, err = c.accountRepository.FindByID(ctx, scheduledTransfer.DestinationAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.twoFactorRule.Check(ctx, scheduledTransfer.OriginAccountID, scheduledTransfer.Amount, input.TOTPCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	createdScheduledTransfer, err := c.scheduledTransferRepository.Create(ctx, scheduledTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewScheduledTransferUseCaseOutput(&createdScheduledTransfer), nil
+}
+
+type CreateScheduledTransferUseCaseInput struct {
+	ID                 string                          `json:"-"`
+	OriginAccount      MakeTransferUseCaseAccountInput `json:"-"`
+	DestinationAccount MakeTransferUseCaseAccountInput `json:"destination_account"`
+	Amount             entity.Money                    `json:"amount"`
+	Frequency          string                          `json:"frequency" example:"monthly"`
+	StartAt            *time.Time                      `json:"start_at" example:"2023-09-05T09:00:00Z"`
+	EndAt              *time.Time                      `json:"end_at,omitempty"`
+	MaxExecutions      int                             `json:"max_executions,omitempty"`
+	TOTPCode           string                          `json:"totp_code,omitempty"`
+	CreatedAt          *time.Time                      `json:"-"`
+}
+
+func NewCreateScheduledTransferUseCaseInput(ID string, originAccountID string, destinationAccountID string, amount entity.Money, frequency string, startAt *time.Time, endAt *time.Time, maxExecutions int, TOTPCode string, createdAt *time.Time) *CreateScheduledTransferUseCaseInput {
+	return &CreateScheduledTransferUseCaseInput{
+		ID: ID,
+		OriginAccount: MakeTransferUseCaseAccountInput{
+			ID: originAccountID,
+		},
+		DestinationAccount: MakeTransferUseCaseAccountInput{
+			ID: destinationAccountID,
+		},
+		Amount:        amount,
+		Frequency:     frequency,
+		StartAt:       startAt,
+		EndAt:         endAt,
+		MaxExecutions: maxExecutions,
+		TOTPCode:      TOTPCode,
+		CreatedAt:     createdAt,
+	}
+}
+
+type ScheduledTransferUseCaseOutput struct {
+	ID                   string       `json:"id"`
+	OriginAccountID      string       `json:"origin_account_id"`
+	DestinationAccountID string       `json:"destination_account_id"`
+	Amount               entity.Money `json:"amount"`
+	Frequency            string       `json:"frequency"`
+	StartAt              string       `json:"start_at"`
+	EndAt                string       `json:"end_at,omitempty"`
+	MaxExecutions        int          `json:"max_executions"`
+	Executions           int          `json:"executions"`
+	NextRunAt            string       `json:"next_run_at,omitempty"`
+	Status               string       `json:"status"`
+	CreatedAt            string       `json:"created_at"`
+}
+
+func NewScheduledTransferUseCaseOutput(scheduledTransfer *entity.ScheduledTransfer) *ScheduledTransferUseCaseOutput {
+	output := &ScheduledTransferUseCaseOutput{
+		ID:                   scheduledTransfer.ID,
+		OriginAccountID:      scheduledTransfer.OriginAccountID,
+		DestinationAccountID: scheduledTransfer.DestinationAccountID,
+		Amount:               scheduledTransfer.Amount,
+		Frequency:            strings.ToLower(string(scheduledTransfer.Frequency)),
+		StartAt:              scheduledTransfer.StartAt.Format(time.RFC3339),
+		MaxExecutions:        scheduledTransfer.MaxExecutions,
+		Executions:           scheduledTransfer.Executions,
+		Status:               strings.ToLower(string(scheduledTransfer.Status)),
+		CreatedAt:            scheduledTransfer.CreatedAt.Format(time.RFC3339),
+	}
+
+	if scheduledTransfer.EndAt != nil {
+		output.EndAt = scheduledTransfer.EndAt.Format(time.RFC3339)
+	}
+
+	if scheduledTransfer.NextRunAt != nil {
+		output.NextRunAt = scheduledTransfer.NextRunAt.Format(time.RFC3339)
+	}
+
+	return output
+}
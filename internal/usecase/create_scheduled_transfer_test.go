@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetCreateScheduledTransferUseCaseInput(amount entity.Money) *usecase.CreateScheduledTransferUseCaseInput {
+	createdAt := time.Now()
+	startAt := createdAt.Add(24 * time.Hour)
+
+	return usecase.NewCreateScheduledTransferUseCaseInput("c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", amount, "monthly", &startAt, nil, 12, "", &createdAt)
+}
+
+func TestCreateScheduledTransferUseCase_Execute(t *testing.T) {
+	t.Run("Testing CreateScheduledTransferUseCase when successful", func(t *testing.T) {
+		ctx := context.Background()
+		input := GetCreateScheduledTransferUseCaseInput(entity.NewMoney(50, entity.BRL))
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, input.OriginAccount.ID).Return(*GetBaseOriginAccount(t), nil)
+		accountRepository.On("FindByID", ctx, input.DestinationAccount.ID).Return(*GetBaseDestinationAccount(t), nil)
+
+		scheduledTransferRepository := mock.NewScheduledTransferRepositoryMock()
+		scheduledTransferRepository.On("Create", ctx, testify.MatchedBy(func(scheduledTransfer *entity.ScheduledTransfer) bool {
+			return scheduledTransfer.ID == input.ID && scheduledTransfer.Frequency == entity.MONTHLY && scheduledTransfer.NextRunAt == input.StartAt
+		})).Return(entity.ScheduledTransfer{
+			ID:                   input.ID,
+			OriginAccountID:      input.OriginAccount.ID,
+			DestinationAccountID: input.DestinationAccount.ID,
+			Amount:               input.Amount,
+			Frequency:            entity.MONTHLY,
+			StartAt:              input.StartAt,
+			MaxExecutions:        12,
+			NextRunAt:            input.StartAt,
+			Status:               entity.ACTIVE_SCHEDULE,
+			CreatedAt:            input.CreatedAt,
+		}, nil)
+
+		createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(accountRepository, scheduledTransferRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)))
+		output, err := createScheduledTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, input.ID, output.ID)
+		assert.Equal(t, "monthly", output.Frequency)
+		assert.Equal(t, "active", output.Status)
+		assert.Equal(t, input.StartAt.Format(time.RFC3339), output.NextRunAt)
+		assert.Equal(t, 12, output.MaxExecutions)
+		assert.Empty(t, output.EndAt)
+		scheduledTransferRepository.AssertExpectations(t)
+	})
+
+	t.Run("Testing CreateScheduledTransferUseCase when the schedule is invalid", func(t *testing.T) {
+		input := GetCreateScheduledTransferUseCaseInput(entity.NewMoney(50, entity.BRL))
+		input.Frequency = "yearly"
+
+		createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(mock.NewAccountRepositoryMock(), mock.NewScheduledTransferRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)))
+		output, err := createScheduledTransferUseCase.Execute(context.Background(), input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "frequency must be one of ONCE, DAILY, WEEKLY or MONTHLY", err.Error())
+	})
+
+	t.Run("Testing CreateScheduledTransferUseCase when the currency is not the currency of the origin account", func(t *testing.T) {
+		ctx := context.Background()
+		input := GetCreateScheduledTransferUseCaseInput(entity.NewMoney(50, entity.USD))
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, input.OriginAccount.ID).Return(*GetBaseOriginAccount(t), nil)
+
+		createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(accountRepository, mock.NewScheduledTransferRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)))
+		_, err := createScheduledTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, "transfer currency USD must be the currency of the origin account", err.Error())
+	})
+
+	t.Run("Testing CreateScheduledTransferUseCase when the destination account does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		input := GetCreateScheduledTransferUseCaseInput(entity.NewMoney(50, entity.BRL))
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, input.OriginAccount.ID).Return(*GetBaseOriginAccount(t), nil)
+		accountRepository.On("FindByID", ctx, input.DestinationAccount.ID).Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account"))
+
+		createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(accountRepository, mock.NewScheduledTransferRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)))
+		_, err := createScheduledTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing CreateScheduledTransferUseCase of a high-value transfer without two-factor authentication", func(t *testing.T) {
+		ctx := context.Background()
+		input := GetCreateScheduledTransferUseCaseInput(entity.NewMoney(50, entity.BRL))
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, input.OriginAccount.ID).Return(*GetBaseOriginAccount(t), nil)
+		accountRepository.On("FindByID", ctx, input.DestinationAccount.ID).Return(*GetBaseDestinationAccount(t), nil)
+
+		scheduledTransferRepository := mock.NewScheduledTransferRepositoryMock()
+
+		createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(accountRepository, scheduledTransferRepository, usecase.NewTransferTwoFactorRule(GetDisabledTwoFactor(), entity.NewMoney(10, entity.BRL)))
+		_, err := createScheduledTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+		scheduledTransferRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing CreateScheduledTransferUseCase when repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		input := GetCreateScheduledTransferUseCaseInput(entity.NewMoney(50, entity.BRL))
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, input.OriginAccount.ID).Return(*GetBaseOriginAccount(t), nil)
+		accountRepository.On("FindByID", ctx, input.DestinationAccount.ID).Return(*GetBaseDestinationAccount(t), nil)
+
+		scheduledTransferRepository := mock.NewScheduledTransferRepositoryMock()
+		scheduledTransferRepository.On("Create", ctx, testify.Anything).Return(entity.ScheduledTransfer{}, errors.New("connection closed"))
+
+		createScheduledTransferUseCase := usecase.NewCreateScheduledTransferUseCase(accountRepository, scheduledTransferRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)))
+		_, err := createScheduledTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type ICreateTransferBatchUseCase interface {
+	Execute(ctx context.Context, input *CreateTransferBatchUseCaseInput) (*TransferBatchUseCaseOutput, error)
+}
+
+// CreateTransferBatchUseCase accepts a batch of transfers from one origin account to be made in
+// the background. The whole batch is checked up front: every destination account must exist
+// and the total must fit the available balance and the limits of the origin account. Balances
+// and limits can still change before the batch is processed, each transfer is checked again
+// when it is made. The TOTP code is checked once, against the total of the batch.
+type CreateTransferBatchUseCase struct {
+	accountRepository entity.AccountRepository
+	batchRepository   entity.TransferBatchRepository
+	twoFactorRule     *TransferTwoFactorRule
+	limitRule         *TransferLimitRule
+	maxItems          int
+	entity.Repository
+}
+
+func NewCreateTransferBatchUseCase(accountRepository entity.AccountRepository, batchRepository entity.TransferBatchRepository, twoFactorRule *TransferTwoFactorRule, limitRule *TransferLimitRule, maxItems int, repository entity.Repository) *CreateTransferBatchUseCase {
+	return &CreateTransferBatchUseCase{
+		accountRepository: accountRepository,
+		batchRepository:   batchRepository,
+		twoFactorRule:     twoFactorRule,
+		limitRule:         limitRule,
+		maxItems:          maxItems,
+		Repository:        repository,
+	}
+}
+
+func (c *CreateTransferBatchUseCase) Execute(ctx context.Context, input *CreateTransferBatchUseCaseInput) (*TransferBatchUseCaseOutput, error) {
+	if len(input.Items) > c.maxItems {
+		return nil, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("a batch cannot have more than %d items", c.maxItems))
+	}
+
+	items := make([]entity.TransferBatchItem, len(input.Items))
+	for i, item := range input.Items {
+		items[i] = entity.TransferBatchItem{
+			DestinationAccountID: item.DestinationAccount.ID,
+			Amount:               item.Amount,
+		}
+	}
+
+	batch, err := entity.NewTransferBatch(input.ID, input.OriginAccount.ID, entity.TransferBatchMode(strings.ToUpper(input.Mode)), items, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.twoFactorRule.Check(ctx, batch.OriginAccountID, batch.Total, input.TOTPCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.validate(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	err = c.batchRepository.Create(ctx, batch, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransferBatchUseCaseOutput(batch), nil
+}
+
+// validate checks the destination accounts and that the origin account can afford the batch.
+func (c *CreateTransferBatchUseCase) validate(ctx context.Context, batch *entity.TransferBatch) error {
+	originAccount, err := c.accountRepository.FindByID(ctx, batch.OriginAccountID)
+	if err != nil {
+		return err
+	}
+
+	if batch.Total.Currency != originAccount.Currency() {
+		return entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("transfer currency %s must be the currency of the origin account", batch.Total.Currency))
+	}
+
+	destinationAccounts, err := c.accountRepository.FindByIDs(ctx, batch.DestinationAccountIDs())
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]bool, len(destinationAccounts))
+	for _, account := range destinationAccounts {
+		found[account.ID] = true
+	}
+
+	notFoundError := entity.NewErrorHandler(entity.NOT_FOUND_ERROR)
+	for _, item := range batch.Items {
+		if !found[item.DestinationAccountID] {
+			notFoundError.Add(fmt.Sprintf("item %d: not found account: %s", item.Position, item.DestinationAccountID))
+		}
+	}
+
+	if len(notFoundError.Messages) > 0 {
+		return notFoundError
+	}
+
+	comparison, err := originAccount.AvailableBalance().Compare(batch.Total)
+	if err != nil {
+		return err
+	}
+
+	if comparison < 0 {
+		return entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("insufficient available balance for the batch total of %s %s", batch.Total, batch.Total.Currency))
+	}
+
+	position, err := c.limitRule.CheckAll(ctx, &originAccount, batch.Amounts(), *batch.CreatedAt)
+	if err != nil {
+		if handler, ok := err.(*entity.ErrorHandler); ok && position >= 0 {
+			return entity.NewErrorHandler(handler.TypeError).Add(fmt.Sprintf("item %d: %s", position, handler.Error()))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+type CreateTransferBatchUseCaseInput struct {
+	ID            string                                `json:"-"`
+	OriginAccount MakeTransferUseCaseAccountInput       `json:"-"`
+	Mode          string                                `json:"mode" example:"atomic"`
+	Items         []CreateTransferBatchUseCaseItemInput `json:"items"`
+	TOTPCode      string                                `json:"totp_code,omitempty"`
+	CreatedAt     *time.Time                            `json:"-"`
+}
+
+type CreateTransferBatchUseCaseItemInput struct {
+	DestinationAccount MakeTransferUseCaseAccountInput `json:"destination_account"`
+	Amount             entity.Money                    `json:"amount"`
+}
+
+func NewCreateTransferBatchUseCaseInput(ID string, originAccountID string, mode string, items []CreateTransferBatchUseCaseItemInput, TOTPCode string, createdAt *time.Time) *CreateTransferBatchUseCaseInput {
+	return &CreateTransferBatchUseCaseInput{
+		ID: ID,
+		OriginAccount: MakeTransferUseCaseAccountInput{
+			ID: originAccountID,
+		},
+		Mode:      mode,
+		Items:     items,
+		TOTPCode:  TOTPCode,
+		CreatedAt: createdAt,
+	}
+}
+
+// TransferBatchUseCaseOutput is a batch with the outcome of each of its items, the items of a
+// batch still pending or processing have no outcome yet.
+type TransferBatchUseCaseOutput struct {
+	ID              string                           `json:"id"`
+	OriginAccountID string                           `json:"origin_account_id"`
+	Mode            string                           `json:"mode"`
+	Status          string                           `json:"status"`
+	Total           entity.Money                     `json:"total"`
+	Succeeded       int                              `json:"succeeded"`
+	Failed          int                              `json:"failed"`
+	FailureReason   string                           `json:"failure_reason,omitempty"`
+	Items           []TransferBatchItemUseCaseOutput `json:"items"`
+	CreatedAt       string                           `json:"created_at"`
+	UpdatedAt       string                           `json:"updated_at"`
+}
+
+type TransferBatchItemUseCaseOutput struct {
+	Position             int          `json:"position"`
+	DestinationAccountID string       `json:"destination_account_id"`
+	Amount               entity.Money `json:"amount"`
+	Status               string       `json:"status"`
+	TransferID           string       `json:"transfer_id,omitempty"`
+	FailureReason        string       `json:"failure_reason,omitempty"`
+}
+
+func NewTransferBatchUseCaseOutput(batch *entity.TransferBatch) *TransferBatchUseCaseOutput {
+	items := make([]TransferBatchItemUseCaseOutput, 0, len(batch.Items))
+	for _, item := range batch.Items {
+		items = append(items, TransferBatchItemUseCaseOutput{
+			Position:             item.Position,
+			DestinationAccountID: item.DestinationAccountID,
+			Amount:               item.Amount,
+			Status:               strings.ToLower(string(item.Status)),
+			TransferID:           item.TransferID,
+			FailureReason:        item.FailureReason,
+		})
+	}
+
+	return &TransferBatchUseCaseOutput{
+		ID:              batch.ID,
+		OriginAccountID: batch.OriginAccountID,
+		Mode:            strings.ToLower(string(batch.Mode)),
+		Status:          strings.ToLower(string(batch.Status)),
+		Total:           batch.Total,
+		Succeeded:       batch.Count(entity.SUCCEEDED_BATCH_ITEM),
+		Failed:          batch.Count(entity.FAILED_BATCH_ITEM),
+		FailureReason:   batch.FailureReason,
+		Items:           items,
+		CreatedAt:       batch.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       batch.UpdatedAt.Format(time.RFC3339),
+	}
+}
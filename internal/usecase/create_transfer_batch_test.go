@@ -0,0 +1,190 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetTransferBatchItemInputs(amounts ...int64) []usecase.CreateTransferBatchUseCaseItemInput {
+	destinationAccountIDs := []string{"d18551d3-cf13-49ec-b1dc-741a1f8715f6", "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"}
+
+	items := make([]usecase.CreateTransferBatchUseCaseItemInput, len(amounts))
+	for i, amount := range amounts {
+		items[i] = usecase.CreateTransferBatchUseCaseItemInput{
+			DestinationAccount: usecase.MakeTransferUseCaseAccountInput{ID: destinationAccountIDs[i%len(destinationAccountIDs)]},
+			Amount:             entity.NewMoney(amount, entity.BRL),
+		}
+	}
+
+	return items
+}
+
+func TestCreateTransferBatchUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	batchID := "5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e"
+	destinationAccountIDs := []string{"d18551d3-cf13-49ec-b1dc-741a1f8715f6", "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"}
+
+	t.Run("Testing CreateTransferBatchUseCase saves a pending batch", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t) // Balance = 100
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDs", ctx, destinationAccountIDs).Return([]entity.Account{{ID: destinationAccountIDs[1]}, {ID: destinationAccountIDs[0]}}, nil)
+
+		batchRepository := mock.NewTransferBatchRepositoryMock()
+		batchRepository.On("Create", ctx, testify.AnythingOfType("*entity.TransferBatch"), testify.Anything).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateTransferBatchUseCase(accountRepository, batchRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), GetTransferLimitRule(t), 1000, repository)
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, originAccount.ID, "best_effort", GetTransferBatchItemInputs(50, 30, 20), "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, batchID, output.ID)
+		assert.Equal(t, "best_effort", output.Mode)
+		assert.Equal(t, "pending", output.Status)
+		assert.Equal(t, entity.NewMoney(100, entity.BRL), output.Total)
+		assert.Len(t, output.Items, 3)
+		assert.Equal(t, usecase.TransferBatchItemUseCaseOutput{Position: 1, DestinationAccountID: destinationAccountIDs[1], Amount: entity.NewMoney(30, entity.BRL), Status: "pending"}, output.Items[1])
+		assert.Equal(t, "2023-08-14T10:00:00Z", output.CreatedAt)
+
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing CreateTransferBatchUseCase when a destination account does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDs", ctx, destinationAccountIDs).Return([]entity.Account{{ID: destinationAccountIDs[0]}}, nil)
+
+		batchRepository := mock.NewTransferBatchRepositoryMock()
+
+		useCase := usecase.NewCreateTransferBatchUseCase(accountRepository, batchRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), GetTransferLimitRule(t), 1000, mock.NewRepositoryMock())
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, originAccount.ID, "atomic", GetTransferBatchItemInputs(10, 10, 10, 10), "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, []string{
+			"item 1: not found account: 6ac7ebbf-568b-45f2-a295-bfbab73f1cf6",
+			"item 3: not found account: 6ac7ebbf-568b-45f2-a295-bfbab73f1cf6",
+		}, err.(*entity.ErrorHandler).Messages)
+		batchRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing CreateTransferBatchUseCase when the total exceeds the available balance", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDs", ctx, destinationAccountIDs).Return([]entity.Account{{ID: destinationAccountIDs[0]}, {ID: destinationAccountIDs[1]}}, nil)
+
+		useCase := usecase.NewCreateTransferBatchUseCase(accountRepository, mock.NewTransferBatchRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), GetTransferLimitRule(t), 1000, mock.NewRepositoryMock())
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, originAccount.ID, "atomic", GetTransferBatchItemInputs(60, 41), "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "insufficient available balance for the batch total of 1.01 BRL", err.Error())
+	})
+
+	t.Run("Testing CreateTransferBatchUseCase when an item goes over a limit of the origin account", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		originAccount.Balance = entity.NewMoney(10000, entity.BRL)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDs", ctx, destinationAccountIDs).Return([]entity.Account{{ID: destinationAccountIDs[0]}, {ID: destinationAccountIDs[1]}}, nil)
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, testify.Anything).Return(GetAccountTransferLimit(t, originAccount.ID), nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, testify.Anything, testify.Anything).Return(entity.NewMoney(0, entity.BRL), nil)
+
+		limitRule := usecase.NewTransferLimitRule(limitRepository, transferRepository, GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		useCase := usecase.NewCreateTransferBatchUseCase(accountRepository, mock.NewTransferBatchRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), limitRule, 1000, mock.NewRepositoryMock())
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, originAccount.ID, "atomic", GetTransferBatchItemInputs(100, 40, 20), "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "item 2: transfer exceeds the daily limit of 1.50 BRL, 0.10 BRL available", err.Error())
+	})
+
+	t.Run("Testing CreateTransferBatchUseCase when the batch has too many items", func(t *testing.T) {
+		useCase := usecase.NewCreateTransferBatchUseCase(mock.NewAccountRepositoryMock(), mock.NewTransferBatchRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), GetTransferLimitRule(t), 2, mock.NewRepositoryMock())
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, GetBaseOriginAccount(t).ID, "atomic", GetTransferBatchItemInputs(10, 10, 10), "", &createdAt)
+		output, err := useCase.Execute(context.Background(), input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "a batch cannot have more than 2 items", err.Error())
+	})
+
+	t.Run("Testing CreateTransferBatchUseCase when the total requires a totp code", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		repository := mock.NewRepositoryMock()
+
+		twoFactor, totpRepository, _, _ := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, originAccount.ID).Return(GetConfirmedTOTP(originAccount.ID), nil)
+
+		twoFactorRule := usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(50, entity.BRL))
+
+		useCase := usecase.NewCreateTransferBatchUseCase(mock.NewAccountRepositoryMock(), mock.NewTransferBatchRepositoryMock(), twoFactorRule, GetTransferLimitRule(t), 1000, repository)
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, originAccount.ID, "best_effort", GetTransferBatchItemInputs(30, 30), "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "totp code is required for transfers of 0.50 BRL or more", err.Error())
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing CreateTransferBatchUseCase when Create returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDs", ctx, destinationAccountIDs[:1]).Return([]entity.Account{{ID: destinationAccountIDs[0]}}, nil)
+
+		batchRepository := mock.NewTransferBatchRepositoryMock()
+		batchRepository.On("Create", ctx, testify.AnythingOfType("*entity.TransferBatch"), testify.Anything).Return(errors.New("connection closed"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateTransferBatchUseCase(accountRepository, batchRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), GetTransferLimitRule(t), 1000, repository)
+		input := usecase.NewCreateTransferBatchUseCaseInput(batchID, originAccount.ID, "atomic", GetTransferBatchItemInputs(10), "", &createdAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+}
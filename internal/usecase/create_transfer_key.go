@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type ICreateTransferKeyUseCase interface {
+	Execute(ctx context.Context, input *CreateTransferKeyUseCaseInput) (*TransferKeyUseCaseOutput, error)
+}
+
+// CreateTransferKeyUseCase registers a key for the account. The keys are counted while the
+// account is locked, so concurrent requests cannot go over the limit of keys per account.
+type CreateTransferKeyUseCase struct {
+	accountRepository entity.AccountRepository
+	keyRepository     entity.TransferKeyRepository
+	entity.Repository
+}
+
+func NewCreateTransferKeyUseCase(accountRepository entity.AccountRepository, keyRepository entity.TransferKeyRepository, repository entity.Repository) *CreateTransferKeyUseCase {
+	return &CreateTransferKeyUseCase{
+		accountRepository: accountRepository,
+		keyRepository:     keyRepository,
+		Repository:        repository,
+	}
+}
+
+func (c *CreateTransferKeyUseCase) Execute(ctx context.Context, input *CreateTransferKeyUseCaseInput) (*TransferKeyUseCaseOutput, error) {
+	transaction, err := c.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = c.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = c.RollbackTx(transaction)
+		} else {
+			_ = c.CommitTx(transaction)
+		}
+	}()
+
+	account, err := c.accountRepository.FindByIDForUpdate(ctx, input.AccountID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	transferKey, err := entity.NewTransferKey(&account, entity.TransferKeyType(strings.ToUpper(input.Type)), input.Key, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := c.keyRepository.CountByAccountID(ctx, account.ID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= entity.TRANSFER_KEY_MAX_PER_ACCOUNT {
+		err = entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("an account cannot have more than %d keys", entity.TRANSFER_KEY_MAX_PER_ACCOUNT))
+		return nil, err
+	}
+
+	err = c.keyRepository.Create(ctx, transferKey, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransferKeyUseCaseOutput(transferKey), nil
+}
+
+type CreateTransferKeyUseCaseInput struct {
+	AccountID string     `json:"-"`
+	Type      string     `json:"type" example:"email"`
+	Key       string     `json:"key,omitempty" example:"lucas@example.com"`
+	CreatedAt *time.Time `json:"-"`
+}
+
+func NewCreateTransferKeyUseCaseInput(accountID string, keyType string, key string, createdAt *time.Time) *CreateTransferKeyUseCaseInput {
+	return &CreateTransferKeyUseCaseInput{
+		AccountID: accountID,
+		Type:      keyType,
+		Key:       key,
+		CreatedAt: createdAt,
+	}
+}
+
+type TransferKeyUseCaseOutput struct {
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+}
+
+func NewTransferKeyUseCaseOutput(transferKey *entity.TransferKey) *TransferKeyUseCaseOutput {
+	return &TransferKeyUseCaseOutput{
+		Key:       transferKey.Key,
+		Type:      strings.ToLower(string(transferKey.Type)),
+		CreatedAt: transferKey.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTransferKeyUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing CreateTransferKeyUseCase registers the CPF of the account", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("CountByAccountID", ctx, account.ID, testify.Anything).Return(1, nil)
+		keyRepository.On("Create", ctx, testify.AnythingOfType("*entity.TransferKey"), testify.Anything).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateTransferKeyUseCase(accountRepository, keyRepository, repository)
+		output, err := useCase.Execute(ctx, usecase.NewCreateTransferKeyUseCaseInput(account.ID, "cpf", "528.492.540-88", &createdAt))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.TransferKeyUseCaseOutput{Key: "52849254088", Type: "cpf", CreatedAt: "2023-08-14T10:00:00Z"}, output)
+		keyRepository.AssertCalled(t, "Create", ctx, &entity.TransferKey{Key: "52849254088", Type: entity.CPF_KEY, AccountID: account.ID, CreatedAt: &createdAt}, testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing CreateTransferKeyUseCase when the account has all the keys it can have", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("CountByAccountID", ctx, account.ID, testify.Anything).Return(entity.TRANSFER_KEY_MAX_PER_ACCOUNT, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateTransferKeyUseCase(accountRepository, keyRepository, repository)
+		output, err := useCase.Execute(ctx, usecase.NewCreateTransferKeyUseCaseInput(account.ID, "evp", "", &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "an account cannot have more than 5 keys", err.Error())
+		keyRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing CreateTransferKeyUseCase when the key is already registered", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("CountByAccountID", ctx, account.ID, testify.Anything).Return(0, nil)
+		keyRepository.On("Create", ctx, testify.AnythingOfType("*entity.TransferKey"), testify.Anything).
+			Return(entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("key is already registered: lucas@example.com"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateTransferKeyUseCase(accountRepository, keyRepository, repository)
+		output, err := useCase.Execute(ctx, usecase.NewCreateTransferKeyUseCaseInput(account.ID, "email", "Lucas@Example.com", &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing CreateTransferKeyUseCase when the key is invalid", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewCreateTransferKeyUseCase(accountRepository, keyRepository, repository)
+		output, err := useCase.Execute(ctx, usecase.NewCreateTransferKeyUseCaseInput(account.ID, "cpf", "357.682.970-90", &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "key must be the CPF of the account", err.Error())
+		keyRepository.AssertNotCalled(t, "CountByAccountID", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IDeleteBeneficiaryUseCase interface {
+	Execute(ctx context.Context, input *DeleteBeneficiaryUseCaseInput) error
+}
+
+// DeleteBeneficiaryUseCase removes a beneficiary of the account, the transfers already sent to
+// it are kept. Beneficiaries of other accounts are reported as not found.
+type DeleteBeneficiaryUseCase struct {
+	repository entity.BeneficiaryRepository
+}
+
+func NewDeleteBeneficiaryUseCase(repository entity.BeneficiaryRepository) *DeleteBeneficiaryUseCase {
+	return &DeleteBeneficiaryUseCase{
+		repository: repository,
+	}
+}
+
+func (d *DeleteBeneficiaryUseCase) Execute(ctx context.Context, input *DeleteBeneficiaryUseCaseInput) error {
+	return d.repository.Delete(ctx, input.AccountID, input.ID)
+}
+
+type DeleteBeneficiaryUseCaseInput struct {
+	AccountID string
+	ID        string
+}
+
+func NewDeleteBeneficiaryUseCaseInput(accountID string, ID string) *DeleteBeneficiaryUseCaseInput {
+	return &DeleteBeneficiaryUseCaseInput{
+		AccountID: accountID,
+		ID:        ID,
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IDeleteTransferKeyUseCase interface {
+	Execute(ctx context.Context, input *DeleteTransferKeyUseCaseInput) error
+}
+
+// DeleteTransferKeyUseCase removes a key of the account, freeing it to be registered again by
+// any account. Keys of other accounts are reported as not found.
+type DeleteTransferKeyUseCase struct {
+	repository entity.TransferKeyRepository
+}
+
+func NewDeleteTransferKeyUseCase(repository entity.TransferKeyRepository) *DeleteTransferKeyUseCase {
+	return &DeleteTransferKeyUseCase{
+		repository: repository,
+	}
+}
+
+func (d *DeleteTransferKeyUseCase) Execute(ctx context.Context, input *DeleteTransferKeyUseCaseInput) error {
+	key := entity.NormalizeTransferKey(entity.GuessTransferKeyType(input.Key), input.Key)
+
+	return d.repository.Delete(ctx, input.AccountID, key)
+}
+
+type DeleteTransferKeyUseCaseInput struct {
+	AccountID string
+	Key       string
+}
+
+func NewDeleteTransferKeyUseCaseInput(accountID string, key string) *DeleteTransferKeyUseCaseInput {
+	return &DeleteTransferKeyUseCaseInput{
+		AccountID: accountID,
+		Key:       key,
+	}
+}
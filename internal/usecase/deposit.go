@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IDepositUseCase interface {
+	Execute(ctx context.Context, input *MovementUseCaseInput) (*MovementUseCaseOutput, error)
+}
+
+type DepositUseCase struct {
+	movementUseCase
+}
+
+func NewDepositUseCase(accountRepository entity.AccountRepository, movementRepository entity.MovementRepository, ledgerRepository entity.LedgerRepository, repository entity.Repository) *DepositUseCase {
+	return &DepositUseCase{
+		movementUseCase: movementUseCase{
+			accountRepository:  accountRepository,
+			movementRepository: movementRepository,
+			ledgerRepository:   ledgerRepository,
+			Repository:         repository,
+		},
+	}
+}
+
+func (d *DepositUseCase) Execute(ctx context.Context, input *MovementUseCaseInput) (*MovementUseCaseOutput, error) {
+	return d.execute(ctx, entity.DEPOSIT, input)
+}
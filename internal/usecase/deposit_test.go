@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestDepositUseCase_Execute(t *testing.T) {
+	t.Run("Testing DepositUseCase when have success on deposit", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t) // Balance = 100
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		movementRepository.On("Create", ctx, testify.AnythingOfType("*entity.Movement"), testify.Anything).Return(entity.Movement{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", Type: entity.DEPOSIT, Channel: entity.ATM_CHANNEL, Amount: entity.NewMoney(50, entity.BRL), CreatedAt: &createdAt}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		depositUseCase := usecase.NewDepositUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("fc84682a-3045-4bdf-b91c-10be19f89452", account.ID, entity.ATM_CHANNEL, "", entity.NewMoney(50, entity.BRL), &createdAt)
+		output, err := depositUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "fc84682a-3045-4bdf-b91c-10be19f89452", output.ID)
+		assert.Equal(t, account.ID, output.AccountID)
+		assert.Equal(t, "DEPOSIT", output.Type)
+		assert.Equal(t, entity.NewMoney(150, entity.BRL), output.Balance)
+
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return entry.Type == entity.DEPOSIT_ENTRY && entry.Postings[1].AccountID == account.ID && entry.Postings[1].Direction == entity.CREDIT
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+	})
+
+	t.Run("Testing DepositUseCase when account not found", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477").Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account"))
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		depositUseCase := usecase.NewDepositUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.ATM_CHANNEL, "", entity.NewMoney(50, entity.BRL), &createdAt)
+		output, err := depositUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "not found account", err.Error())
+		movementRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing DepositUseCase when channel is invalid", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		depositUseCase := usecase.NewDepositUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("", account.ID, "PHONE", "", entity.NewMoney(50, entity.BRL), &createdAt)
+		output, err := depositUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "channel must be one of BRANCH, ATM or ONLINE", err.Error())
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing DepositUseCase when post journal entry returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		movementRepository.On("Create", ctx, testify.AnythingOfType("*entity.Movement"), testify.Anything).Return(entity.Movement{}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, errors.New("error on post journal entry"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		depositUseCase := usecase.NewDepositUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("", account.ID, entity.ONLINE_CHANNEL, "", entity.NewMoney(50, entity.BRL), &createdAt)
+		output, err := depositUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "error on post journal entry", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IEnrollTOTPUseCase interface {
+	Execute(ctx context.Context, input *EnrollTOTPUseCaseInput) (*EnrollTOTPUseCaseOutput, error)
+}
+
+// EnrollTOTPUseCase generates a new TOTP secret for the account. It only becomes the second
+// factor after ConfirmTOTPUseCase checks a code generated from it.
+type EnrollTOTPUseCase struct {
+	repostiory entity.TOTPRepository
+	issuer     string
+}
+
+func NewEnrollTOTPUseCase(repostiory entity.TOTPRepository, issuer string) *EnrollTOTPUseCase {
+	return &EnrollTOTPUseCase{
+		repostiory: repostiory,
+		issuer:     issuer,
+	}
+}
+
+func (e *EnrollTOTPUseCase) Execute(ctx context.Context, input *EnrollTOTPUseCaseInput) (*EnrollTOTPUseCaseOutput, error) {
+	current, err := e.repostiory.FindByAccountID(ctx, input.AccountID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	if err == nil && current.IsConfirmed() {
+		return nil, entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("two-factor authentication is already enabled")
+	}
+
+	now := time.Now()
+	totp, err := entity.NewTOTP(input.AccountID, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.repostiory.Save(ctx, totp)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEnrollTOTPUseCaseOutput(totp.Secret, totp.ProvisioningURI(e.issuer, input.AccountID)), nil
+}
+
+type EnrollTOTPUseCaseInput struct {
+	AccountID string `json:"-"`
+}
+
+func NewEnrollTOTPUseCaseInput(accountID string) *EnrollTOTPUseCaseInput {
+	return &EnrollTOTPUseCaseInput{
+		AccountID: accountID,
+	}
+}
+
+type EnrollTOTPUseCaseOutput struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func NewEnrollTOTPUseCaseOutput(secret string, provisioningURI string) *EnrollTOTPUseCaseOutput {
+	return &EnrollTOTPUseCaseOutput{
+		Secret:          secret,
+		ProvisioningURI: provisioningURI,
+	}
+}
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestEnrollTOTPUseCase_Execute(t *testing.T) {
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+
+	t.Run("Testing EnrollTOTPUseCase when the account has no authenticator", func(t *testing.T) {
+		ctx := context.Background()
+		totpRepository := mock.NewTOTPRepositoryMock()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(entity.TOTP{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found totp"))
+		totpRepository.On("Save", ctx, testify.Anything).Return(nil)
+
+		output, err := usecase.NewEnrollTOTPUseCase(totpRepository, "bank-api").Execute(ctx, usecase.NewEnrollTOTPUseCaseInput(accountID))
+
+		assert.Nil(t, err)
+		saved := totpRepository.Calls[1].Arguments.Get(1).(*entity.TOTP)
+		assert.Equal(t, saved.Secret, output.Secret)
+		assert.Nil(t, saved.ConfirmedAt)
+		assert.True(t, strings.HasPrefix(output.ProvisioningURI, "otpauth://totp/bank-api:"+accountID))
+	})
+
+	t.Run("Testing EnrollTOTPUseCase replaces an authenticator not confirmed", func(t *testing.T) {
+		ctx := context.Background()
+		totpRepository := mock.NewTOTPRepositoryMock()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(entity.TOTP{AccountID: accountID, Secret: totpSecret}, nil)
+		totpRepository.On("Save", ctx, testify.Anything).Return(nil)
+
+		output, err := usecase.NewEnrollTOTPUseCase(totpRepository, "bank-api").Execute(ctx, usecase.NewEnrollTOTPUseCaseInput(accountID))
+
+		assert.Nil(t, err)
+		assert.NotEqual(t, totpSecret, output.Secret)
+	})
+
+	t.Run("Testing EnrollTOTPUseCase when two-factor authentication is already enabled", func(t *testing.T) {
+		ctx := context.Background()
+		totpRepository := mock.NewTOTPRepositoryMock()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+
+		output, err := usecase.NewEnrollTOTPUseCase(totpRepository, "bank-api").Execute(ctx, usecase.NewEnrollTOTPUseCaseInput(accountID))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "two-factor authentication is already enabled", err.Error())
+		assert.Equal(t, entity.CONFLICT_ERROR, err.(*entity.ErrorHandler).TypeError)
+		totpRepository.AssertNotCalled(t, "Save", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing EnrollTOTPUseCase when Save returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		totpRepository := mock.NewTOTPRepositoryMock()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(entity.TOTP{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found totp"))
+		totpRepository.On("Save", ctx, testify.Anything).Return(errors.New("connection closed"))
+
+		output, err := usecase.NewEnrollTOTPUseCase(totpRepository, "bank-api").Execute(ctx, usecase.NewEnrollTOTPUseCaseInput(accountID))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
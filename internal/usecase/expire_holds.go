@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IExpireHoldsUseCase interface {
+	Execute(ctx context.Context, input *ExpireHoldsUseCaseInput) (*ExpireHoldsUseCaseOutput, error)
+}
+
+// ExpireHoldsUseCase gives back the holds that passed their expiry without being captured or
+// released. A hold is finished only once, so the job can run on many instances.
+type ExpireHoldsUseCase struct {
+	accountRepository entity.AccountRepository
+	holdRepository    entity.HoldRepository
+	batchSize         int
+	entity.Repository
+}
+
+func NewExpireHoldsUseCase(accountRepository entity.AccountRepository, holdRepository entity.HoldRepository, batchSize int, repository entity.Repository) *ExpireHoldsUseCase {
+	return &ExpireHoldsUseCase{
+		accountRepository: accountRepository,
+		holdRepository:    holdRepository,
+		batchSize:         batchSize,
+		Repository:        repository,
+	}
+}
+
+func (e *ExpireHoldsUseCase) Execute(ctx context.Context, input *ExpireHoldsUseCaseInput) (*ExpireHoldsUseCaseOutput, error) {
+	output := &ExpireHoldsUseCaseOutput{}
+
+	for {
+		holdIDs, err := e.holdRepository.FindExpiredIDs(ctx, input.Now, e.batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, holdID := range holdIDs {
+			expired, err := e.expire(ctx, holdID, input.Now)
+			if err != nil {
+				return nil, err
+			}
+
+			if expired {
+				output.Expired++
+			}
+		}
+
+		// the holds of the batch are no longer active, the next query starts after them
+		if len(holdIDs) < e.batchSize {
+			return output, nil
+		}
+	}
+}
+
+// expire finishes one hold inside its own transaction, it tells whether the hold expired: it
+// may have been captured or released since it was listed.
+func (e *ExpireHoldsUseCase) expire(ctx context.Context, holdID string, now time.Time) (expired bool, err error) {
+	transaction, err := e.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = e.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil || !expired {
+			_ = e.RollbackTx(transaction)
+		} else {
+			_ = e.CommitTx(transaction)
+		}
+	}()
+
+	hold, err := e.holdRepository.FindByIDForUpdate(ctx, holdID, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	if !hold.IsExpired(now) {
+		return false, nil
+	}
+
+	originAccount, err := e.accountRepository.FindByIDForUpdate(ctx, hold.OriginAccount.ID, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	hold.OriginAccount = &originAccount
+
+	err = hold.Expire(now)
+	if err != nil {
+		return false, err
+	}
+
+	err = e.holdRepository.Finish(ctx, &hold, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type ExpireHoldsUseCaseInput struct {
+	Now time.Time
+}
+
+func NewExpireHoldsUseCaseInput(now time.Time) *ExpireHoldsUseCaseInput {
+	return &ExpireHoldsUseCaseInput{
+		Now: now,
+	}
+}
+
+type ExpireHoldsUseCaseOutput struct {
+	Expired int
+}
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireHoldsUseCase_Execute(t *testing.T) {
+	now := time.Date(2023, 8, 21, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing ExpireHoldsUseCase gives back the stale holds", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)
+		stale := GetActiveHold(t)
+		captured := GetActiveHold(t)
+		captured.ID = "b3f7e1a2-6c4d-4e8f-9a0b-1c2d3e4f5a6b"
+		captured.Status = entity.CAPTURED_HOLD
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindExpiredIDs", ctx, now, 2).Return([]string{stale.ID, captured.ID}, nil).Once()
+		holdRepository.On("FindExpiredIDs", ctx, now, 2).Return([]string{}, nil).Once()
+		holdRepository.On("FindByIDForUpdate", ctx, stale.ID, testify.Anything).Return(stale, nil)
+		holdRepository.On("FindByIDForUpdate", ctx, captured.ID, testify.Anything).Return(captured, nil)
+		holdRepository.On("Finish", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		output, err := usecase.NewExpireHoldsUseCase(accountRepository, holdRepository, 2, repository).Execute(ctx, usecase.NewExpireHoldsUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ExpireHoldsUseCaseOutput{Expired: 1}, output)
+
+		holdRepository.AssertNumberOfCalls(t, "Finish", 1)
+		holdRepository.AssertCalled(t, "Finish", ctx, testify.MatchedBy(func(hold *entity.Hold) bool {
+			return hold.ID == stale.ID && hold.Status == entity.EXPIRED_HOLD && hold.OriginAccount.Held == entity.NewMoney(0, entity.BRL)
+		}), testify.Anything)
+		repository.AssertNumberOfCalls(t, "CommitTx", 1)
+		repository.AssertNumberOfCalls(t, "RollbackTx", 1)
+	})
+
+	t.Run("Testing ExpireHoldsUseCase when Finish returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)
+		stale := GetActiveHold(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindExpiredIDs", ctx, now, 100).Return([]string{stale.ID}, nil)
+		holdRepository.On("FindByIDForUpdate", ctx, stale.ID, testify.Anything).Return(stale, nil)
+		holdRepository.On("Finish", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(errors.New("connection closed"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		output, err := usecase.NewExpireHoldsUseCase(accountRepository, holdRepository, 100, repository).Execute(ctx, usecase.NewExpireHoldsUseCaseInput(now))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertNotCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing ExpireHoldsUseCase when FindExpiredIDs returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindExpiredIDs", ctx, now, 100).Return([]string(nil), errors.New("connection closed"))
+
+		output, err := usecase.NewExpireHoldsUseCase(mock.NewAccountRepositoryMock(), holdRepository, 100, mock.NewRepositoryMock()).Execute(ctx, usecase.NewExpireHoldsUseCaseInput(now))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
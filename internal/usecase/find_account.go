@@ -7,32 +7,56 @@ import (
 )
 
 type IFindAccountUseCase interface {
-	Execute(ctx context.Context, input *FindAccountUseCaseInput) ([]FindAccountUseCaseOutput, error)
+	Execute(ctx context.Context, input *FindAccountUseCaseInput) (*FindAccountUseCasePageOutput, error)
 }
 
 type FindAccountUseCase struct {
 	repostiory entity.AccountRepository
+	paginator  *entity.Paginator
 }
 
-func NewFindAccountUseCase(repostiory entity.AccountRepository) *FindAccountUseCase {
+func NewFindAccountUseCase(repostiory entity.AccountRepository, paginator *entity.Paginator) *FindAccountUseCase {
 	return &FindAccountUseCase{
 		repostiory: repostiory,
+		paginator:  paginator,
 	}
 }
 
-func (f *FindAccountUseCase) Execute(ctx context.Context, input *FindAccountUseCaseInput) ([]FindAccountUseCaseOutput, error) {
-	accounts, err := f.repostiory.Find(ctx, input.limit, input.offset)
+func (f *FindAccountUseCase) Execute(ctx context.Context, input *FindAccountUseCaseInput) (*FindAccountUseCasePageOutput, error) {
+	page, err := f.paginator.NewPagination(input.limit, input.offset, input.cursor)
 	if err != nil {
 		return nil, err
 	}
 
-	output := []FindAccountUseCaseOutput{}
+	// one row more than the page tells whether there is a next page
+	query := *page
+	query.Limit++
+
+	accounts, err := f.repostiory.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(accounts) > page.Limit
+	if hasMore {
+		accounts = accounts[:page.Limit]
+	}
+
+	output := &FindAccountUseCasePageOutput{
+		Data:    []FindAccountUseCaseOutput{},
+		HasMore: hasMore,
+	}
 
 	for _, account := range accounts {
 
 		accountOutput := NewFindAccountUseCaseOutput(account.ID, account.Name, account.Balance, account.CreatedAt)
 
-		output = append(output, *accountOutput)
+		output.Data = append(output.Data, *accountOutput)
+	}
+
+	if hasMore {
+		last := accounts[len(accounts)-1]
+		output.NextCursor = f.paginator.EncodeCursor(entity.PageCursor{CreatedAt: *last.CreatedAt, ID: last.ID})
 	}
 
 	return output, nil
@@ -41,23 +65,31 @@ func (f *FindAccountUseCase) Execute(ctx context.Context, input *FindAccountUseC
 type FindAccountUseCaseInput struct {
 	limit  int
 	offset int
+	cursor string
 }
 
-func NewFindAccountUseCaseInput(limit int, offset int) *FindAccountUseCaseInput {
+func NewFindAccountUseCaseInput(limit int, offset int, cursor string) *FindAccountUseCaseInput {
 	return &FindAccountUseCaseInput{
 		limit:  limit,
 		offset: offset,
+		cursor: cursor,
 	}
 }
 
+type FindAccountUseCasePageOutput struct {
+	Data       []FindAccountUseCaseOutput `json:"data"`
+	NextCursor string                     `json:"next_cursor"`
+	HasMore    bool                       `json:"has_more"`
+}
+
 type FindAccountUseCaseOutput struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Balance   int    `json:"balance"`
-	CreatedAt string `json:"created_at"`
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Balance   entity.Money `json:"balance"`
+	CreatedAt string       `json:"created_at"`
 }
 
-func NewFindAccountUseCaseOutput(id string, name string, balance int, createdAt *time.Time) *FindAccountUseCaseOutput {
+func NewFindAccountUseCaseOutput(id string, name string, balance entity.Money, createdAt *time.Time) *FindAccountUseCaseOutput {
 
 	return &FindAccountUseCaseOutput{
 		ID:        id,
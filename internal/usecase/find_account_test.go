@@ -10,8 +10,16 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
 )
 
+func GetPaginator(t *testing.T) *entity.Paginator {
+	paginator, err := entity.NewPaginator("cursor-secret", 20, 100)
+	assert.Nil(t, err)
+
+	return paginator
+}
+
 func TestFindAccountUseCase_Execute(t *testing.T) {
 	t.Run("Testing FindAccountUseCase when have success on find accounts", func(t *testing.T) {
 		ctx := context.Background()
@@ -20,11 +28,11 @@ func TestFindAccountUseCase_Execute(t *testing.T) {
 
 		repository := mock.NewAccountRepositoryMock()
 		accounts := mock.GetAccounts()
-		repository.On("Find", ctx, limit, offset).Return(accounts, nil)
+		repository.On("Find", ctx, entity.Pagination{Limit: limit + 1, Offset: offset}).Return(accounts, nil)
 
-		findAccountUseCase := usecase.NewFindAccountUseCase(repository)
+		findAccountUseCase := usecase.NewFindAccountUseCase(repository, GetPaginator(t))
 
-		input := usecase.NewFindAccountUseCaseInput(limit, offset)
+		input := usecase.NewFindAccountUseCaseInput(limit, offset, "")
 
 		output, err := findAccountUseCase.Execute(ctx, input)
 
@@ -32,10 +40,61 @@ func TestFindAccountUseCase_Execute(t *testing.T) {
 
 		assert.NotEmpty(t, output)
 
-		assert.Equal(t, accounts[0].ID, output[0].ID)
-		assert.Equal(t, accounts[0].Name, output[0].Name)
-		assert.Equal(t, accounts[0].CreatedAt.Format(time.RFC3339), output[0].CreatedAt)
+		assert.Equal(t, accounts[0].ID, output.Data[0].ID)
+		assert.Equal(t, accounts[0].Name, output.Data[0].Name)
+		assert.Equal(t, accounts[0].CreatedAt.Format(time.RFC3339), output.Data[0].CreatedAt)
+		assert.False(t, output.HasMore)
+		assert.Empty(t, output.NextCursor)
+
+	})
+
+	t.Run("Testing FindAccountUseCase walks the pages with the next cursor", func(t *testing.T) {
+		ctx := context.Background()
+		paginator := GetPaginator(t)
+		first := time.Date(2023, 8, 5, 16, 00, 00, 00, time.UTC)
+		second := first.Add(time.Minute)
+
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("Find", ctx, entity.Pagination{Limit: 2}).Return([]entity.Account{
+			{ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", Name: "Lucas", CreatedAt: &first},
+			{ID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "Roger", CreatedAt: &second},
+		}, nil)
+		repository.On("Find", ctx, entity.Pagination{Limit: 2, After: &entity.PageCursor{CreatedAt: first, ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477"}}).Return([]entity.Account{
+			{ID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "Roger", CreatedAt: &second},
+		}, nil)
+
+		findAccountUseCase := usecase.NewFindAccountUseCase(repository, paginator)
+
+		output, err := findAccountUseCase.Execute(ctx, usecase.NewFindAccountUseCaseInput(1, 0, ""))
+
+		assert.Nil(t, err)
+		assert.Len(t, output.Data, 1)
+		assert.True(t, output.HasMore)
+		assert.NotEmpty(t, output.NextCursor)
+
+		output, err = findAccountUseCase.Execute(ctx, usecase.NewFindAccountUseCaseInput(1, 0, output.NextCursor))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", output.Data[0].ID)
+		assert.False(t, output.HasMore)
+		assert.Empty(t, output.NextCursor)
+	})
+
+	t.Run("Testing FindAccountUseCase when cursor is tampered", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewAccountRepositoryMock()
+
+		findAccountUseCase := usecase.NewFindAccountUseCase(repository, GetPaginator(t))
+		otherPaginator, err := entity.NewPaginator("other-secret", 20, 100)
+		assert.Nil(t, err)
+		cursor := otherPaginator.EncodeCursor(entity.PageCursor{CreatedAt: time.Now(), ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477"})
+
+		output, err := findAccountUseCase.Execute(ctx, usecase.NewFindAccountUseCaseInput(10, 0, cursor))
 
+		assert.Nil(t, output)
+		assert.Equal(t, "invalid cursor", err.Error())
+		repository.AssertNotCalled(t, "Find", ctx, testify.Anything)
 	})
 
 	t.Run("Testing FindAccountUseCase when repository returns an error", func(t *testing.T) {
@@ -45,11 +104,11 @@ func TestFindAccountUseCase_Execute(t *testing.T) {
 		offset := 0
 
 		repository := mock.NewAccountRepositoryMock()
-		repository.On("Find", ctx, limit, offset).Return([]entity.Account{}, errors.New("error on find accounts"))
+		repository.On("Find", ctx, entity.Pagination{Limit: limit + 1, Offset: offset}).Return([]entity.Account{}, errors.New("error on find accounts"))
 
-		findAccountUseCase := usecase.NewFindAccountUseCase(repository)
+		findAccountUseCase := usecase.NewFindAccountUseCase(repository, GetPaginator(t))
 
-		input := usecase.NewFindAccountUseCaseInput(limit, offset)
+		input := usecase.NewFindAccountUseCaseInput(limit, offset, "")
 
 		output, err := findAccountUseCase.Execute(ctx, input)
 
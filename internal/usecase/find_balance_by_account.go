@@ -24,7 +24,7 @@ func (g *FindBalanceByAccountUseCase) Execute(ctx context.Context, input *FindBa
 	if err != nil {
 		return nil, err
 	}
-	return NewFindBalanceByAccountUseCaseOutput(account.Balance), nil
+	return NewFindBalanceByAccountUseCaseOutput(&account), nil
 
 }
 
@@ -38,12 +38,21 @@ func NewFindBalanceByAccountUseCaseInput(id string) *FindBalanceByAccountUseCase
 	}
 }
 
+// FindBalanceByAccountUseCaseOutput shows the ledger balance, negative when the account is
+// overdrawn, next to the funds on hold and what the account can still spend with its credit
+// limit.
 type FindBalanceByAccountUseCaseOutput struct {
-	Balance int `json:"balance"`
+	Balance          entity.Money `json:"balance"`
+	Held             entity.Money `json:"held"`
+	CreditLimit      entity.Money `json:"credit_limit"`
+	AvailableBalance entity.Money `json:"available_balance"`
 }
 
-func NewFindBalanceByAccountUseCaseOutput(balance int) *FindBalanceByAccountUseCaseOutput {
+func NewFindBalanceByAccountUseCaseOutput(account *entity.Account) *FindBalanceByAccountUseCaseOutput {
 	return &FindBalanceByAccountUseCaseOutput{
-		Balance: balance,
+		Balance:          account.Balance,
+		Held:             entity.NewMoney(account.Held.Amount, account.Currency()),
+		CreditLimit:      entity.NewMoney(account.CreditLimit.Amount, account.Currency()),
+		AvailableBalance: account.AvailableBalance(),
 	}
 }
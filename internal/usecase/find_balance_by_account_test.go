@@ -32,6 +32,39 @@ func TestFindBalanceByAccountUseCase_Execute(t *testing.T) {
 		assert.Equal(t, account.Balance, output.Balance)
 	})
 
+	t.Run("Testing FindBalanceByAccountUseCase when the account is overdrawn", func(t *testing.T) {
+		ctx := context.Background()
+
+		account := GetOverdrawnAccount(t)
+
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, account.ID).Return(account, nil)
+
+		output, err := usecase.NewFindBalanceByAccountUseCase(repository).Execute(ctx, usecase.NewFindBalanceByAccountUseCaseInput(account.ID))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-100000, entity.BRL), output.Balance)
+		assert.Equal(t, entity.NewMoney(200000, entity.BRL), output.CreditLimit)
+		assert.Equal(t, entity.NewMoney(100000, entity.BRL), output.AvailableBalance)
+	})
+
+	t.Run("Testing FindBalanceByAccountUseCase when the account has funds on hold", func(t *testing.T) {
+		ctx := context.Background()
+
+		account := GetOverdrawnAccount(t)
+		account.Held = entity.NewMoney(30000, entity.BRL)
+
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, account.ID).Return(account, nil)
+
+		output, err := usecase.NewFindBalanceByAccountUseCase(repository).Execute(ctx, usecase.NewFindBalanceByAccountUseCaseInput(account.ID))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(-100000, entity.BRL), output.Balance)
+		assert.Equal(t, entity.NewMoney(30000, entity.BRL), output.Held)
+		assert.Equal(t, entity.NewMoney(70000, entity.BRL), output.AvailableBalance)
+	})
+
 	t.Run("Testing FindBalanceByAccountUseCase when repository returns an error", func(t *testing.T) {
 		ctx := context.Background()
 
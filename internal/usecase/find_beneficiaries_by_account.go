@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IFindBeneficiariesByAccountUseCase interface {
+	Execute(ctx context.Context, input *FindBeneficiariesByAccountUseCaseInput) ([]BeneficiaryUseCaseOutput, error)
+}
+
+type FindBeneficiariesByAccountUseCase struct {
+	repository entity.BeneficiaryRepository
+}
+
+func NewFindBeneficiariesByAccountUseCase(repository entity.BeneficiaryRepository) *FindBeneficiariesByAccountUseCase {
+	return &FindBeneficiariesByAccountUseCase{
+		repository: repository,
+	}
+}
+
+func (f *FindBeneficiariesByAccountUseCase) Execute(ctx context.Context, input *FindBeneficiariesByAccountUseCaseInput) ([]BeneficiaryUseCaseOutput, error) {
+	beneficiaries, err := f.repository.FindByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []BeneficiaryUseCaseOutput{}
+	for i := range beneficiaries {
+		output = append(output, *NewBeneficiaryUseCaseOutput(&beneficiaries[i]))
+	}
+
+	return output, nil
+}
+
+type FindBeneficiariesByAccountUseCaseInput struct {
+	AccountID string
+}
+
+func NewFindBeneficiariesByAccountUseCaseInput(accountID string) *FindBeneficiariesByAccountUseCaseInput {
+	return &FindBeneficiariesByAccountUseCaseInput{
+		AccountID: accountID,
+	}
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IFindBeneficiarySuggestionsUseCase interface {
+	Execute(ctx context.Context, input *FindBeneficiarySuggestionsUseCaseInput) ([]BeneficiarySuggestionUseCaseOutput, error)
+}
+
+// FindBeneficiarySuggestionsUseCase suggests as beneficiaries the accounts that most received
+// transfers from the account within the window, leaving out the ones already saved.
+type FindBeneficiarySuggestionsUseCase struct {
+	transferRepository    entity.TransferRepository
+	beneficiaryRepository entity.BeneficiaryRepository
+	window                time.Duration
+	limit                 int
+}
+
+func NewFindBeneficiarySuggestionsUseCase(transferRepository entity.TransferRepository, beneficiaryRepository entity.BeneficiaryRepository, window time.Duration, limit int) *FindBeneficiarySuggestionsUseCase {
+	return &FindBeneficiarySuggestionsUseCase{
+		transferRepository:    transferRepository,
+		beneficiaryRepository: beneficiaryRepository,
+		window:                window,
+		limit:                 limit,
+	}
+}
+
+func (f *FindBeneficiarySuggestionsUseCase) Execute(ctx context.Context, input *FindBeneficiarySuggestionsUseCaseInput) ([]BeneficiarySuggestionUseCaseOutput, error) {
+	beneficiaries, err := f.beneficiaryRepository.FindByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := map[string]bool{}
+	for _, beneficiary := range beneficiaries {
+		saved[beneficiary.DestinationAccountID] = true
+	}
+
+	// the saved beneficiaries may be among the most frequent destinations, so as many more are
+	// asked for to still fill the limit
+	destinations, err := f.transferRepository.FindFrequentDestinations(ctx, input.AccountID, input.Now.Add(-f.window), f.limit+len(saved))
+	if err != nil {
+		return nil, err
+	}
+
+	output := []BeneficiarySuggestionUseCaseOutput{}
+	for _, destination := range destinations {
+		if saved[destination.AccountID] {
+			continue
+		}
+
+		if len(output) == f.limit {
+			break
+		}
+
+		output = append(output, BeneficiarySuggestionUseCaseOutput{
+			DestinationAccountID: destination.AccountID,
+			Name:                 entity.MaskName(destination.Name),
+			Transfers:            destination.Transfers,
+			LastTransferAt:       destination.LastTransferAt.Format(time.RFC3339),
+		})
+	}
+
+	return output, nil
+}
+
+type FindBeneficiarySuggestionsUseCaseInput struct {
+	AccountID string
+	Now       time.Time
+}
+
+func NewFindBeneficiarySuggestionsUseCaseInput(accountID string, now time.Time) *FindBeneficiarySuggestionsUseCaseInput {
+	return &FindBeneficiarySuggestionsUseCaseInput{
+		AccountID: accountID,
+		Now:       now,
+	}
+}
+
+// BeneficiarySuggestionUseCaseOutput carries the ID of the destination account, which the
+// account already sent transfers to, so it can be saved with POST /beneficiaries.
+type BeneficiarySuggestionUseCaseOutput struct {
+	DestinationAccountID string `json:"destination_account_id"`
+	Name                 string `json:"name"`
+	Transfers            int    `json:"transfers"`
+	LastTransferAt       string `json:"last_transfer_at"`
+}
@@ -0,0 +1,148 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindBeneficiarySuggestionsUseCase_Execute(t *testing.T) {
+	now := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	lastTransferAt := time.Date(2023, 8, 10, 9, 30, 00, 00, time.UTC)
+
+	t.Run("Testing FindBeneficiarySuggestionsUseCase leaves out the saved beneficiaries", func(t *testing.T) {
+		ctx := context.Background()
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByAccountID", ctx, accountID).Return([]entity.Beneficiary{
+			{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", AccountID: accountID, DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6"},
+		}, nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindFrequentDestinations", ctx, accountID, now.Add(-90*24*time.Hour), 3).Return([]entity.FrequentDestination{
+			{AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "joao", Transfers: 9, LastTransferAt: &lastTransferAt},
+			{AccountID: "0c7f4d1e-8a52-4a8b-9d65-2f4b7e1a3c90", Name: "Maria Souza", Transfers: 4, LastTransferAt: &lastTransferAt},
+			{AccountID: "5e9b2c84-6d1f-4f3a-8b27-9a0c1d2e3f45", Name: "Ana", Transfers: 2, LastTransferAt: &lastTransferAt},
+		}, nil)
+
+		useCase := usecase.NewFindBeneficiarySuggestionsUseCase(transferRepository, beneficiaryRepository, 90*24*time.Hour, 2)
+		output, err := useCase.Execute(ctx, usecase.NewFindBeneficiarySuggestionsUseCaseInput(accountID, now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []usecase.BeneficiarySuggestionUseCaseOutput{
+			{DestinationAccountID: "0c7f4d1e-8a52-4a8b-9d65-2f4b7e1a3c90", Name: "Maria S****", Transfers: 4, LastTransferAt: "2023-08-10T09:30:00Z"},
+			{DestinationAccountID: "5e9b2c84-6d1f-4f3a-8b27-9a0c1d2e3f45", Name: "Ana", Transfers: 2, LastTransferAt: "2023-08-10T09:30:00Z"},
+		}, output)
+	})
+
+	t.Run("Testing FindBeneficiarySuggestionsUseCase stops at the limit", func(t *testing.T) {
+		ctx := context.Background()
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByAccountID", ctx, accountID).Return([]entity.Beneficiary{}, nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindFrequentDestinations", ctx, accountID, now.Add(-time.Hour), 1).Return([]entity.FrequentDestination{
+			{AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "joao", Transfers: 2, LastTransferAt: &lastTransferAt},
+		}, nil)
+
+		useCase := usecase.NewFindBeneficiarySuggestionsUseCase(transferRepository, beneficiaryRepository, time.Hour, 1)
+		output, err := useCase.Execute(ctx, usecase.NewFindBeneficiarySuggestionsUseCaseInput(accountID, now))
+
+		assert.Nil(t, err)
+		assert.Len(t, output, 1)
+		assert.Equal(t, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", output[0].DestinationAccountID)
+	})
+}
+
+func TestUpdateBeneficiaryUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	updatedAt := time.Date(2023, 8, 15, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing UpdateBeneficiaryUseCase marks the beneficiary as favorite", func(t *testing.T) {
+		ctx := context.Background()
+		favorite := true
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByID", ctx, "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").Return(entity.Beneficiary{
+			ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6",
+			DestinationName: "joao", Nickname: "Joao", CreatedAt: &createdAt, UpdatedAt: &createdAt,
+		}, nil)
+		beneficiaryRepository.On("Update", ctx, &entity.Beneficiary{
+			ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6",
+			DestinationName: "joao", Nickname: "Joao", Favorite: true, CreatedAt: &createdAt, UpdatedAt: &updatedAt,
+		}).Return(nil)
+
+		useCase := usecase.NewUpdateBeneficiaryUseCase(beneficiaryRepository)
+		output, err := useCase.Execute(ctx, usecase.NewUpdateBeneficiaryUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", nil, &favorite, &updatedAt))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.BeneficiaryUseCaseOutput{
+			ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", Name: "joao", Nickname: "Joao", Favorite: true, CreatedAt: "2023-08-14T10:00:00Z", UpdatedAt: "2023-08-15T10:00:00Z",
+		}, output)
+		beneficiaryRepository.AssertExpectations(t)
+	})
+
+	t.Run("Testing UpdateBeneficiaryUseCase when the beneficiary was saved by another account", func(t *testing.T) {
+		ctx := context.Background()
+		nickname := "Mom"
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByID", ctx, "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").Return(entity.Beneficiary{
+			ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", DestinationAccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477",
+			CreatedAt: &createdAt, UpdatedAt: &createdAt,
+		}, nil)
+
+		useCase := usecase.NewUpdateBeneficiaryUseCase(beneficiaryRepository)
+		output, err := useCase.Execute(ctx, usecase.NewUpdateBeneficiaryUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", &nickname, nil, &updatedAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found beneficiary: 6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", err.Error())
+		beneficiaryRepository.AssertNotCalled(t, "Update", testify.Anything, testify.Anything)
+	})
+}
+
+func TestFindBeneficiariesByAccountUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindBeneficiariesByAccountUseCase when successful", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByAccountID", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477").Return([]entity.Beneficiary{
+			{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", DestinationName: "Joao Pedro", Nickname: "Joao", Favorite: true, CreatedAt: &createdAt, UpdatedAt: &createdAt},
+		}, nil)
+
+		useCase := usecase.NewFindBeneficiariesByAccountUseCase(beneficiaryRepository)
+		output, err := useCase.Execute(ctx, usecase.NewFindBeneficiariesByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []usecase.BeneficiaryUseCaseOutput{
+			{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", Name: "Joao P****", Nickname: "Joao", Favorite: true, CreatedAt: "2023-08-14T10:00:00Z", UpdatedAt: "2023-08-14T10:00:00Z"},
+		}, output)
+	})
+}
+
+func TestDeleteBeneficiaryUseCase_Execute(t *testing.T) {
+	t.Run("Testing DeleteBeneficiaryUseCase removes the beneficiary of the account", func(t *testing.T) {
+		ctx := context.Background()
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("Delete", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").Return(nil)
+
+		useCase := usecase.NewDeleteBeneficiaryUseCase(beneficiaryRepository)
+		err := useCase.Execute(ctx, usecase.NewDeleteBeneficiaryUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60"))
+
+		assert.Nil(t, err)
+		beneficiaryRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IFindHoldUseCase interface {
+	Execute(ctx context.Context, input *FindHoldUseCaseInput) (*HoldUseCaseOutput, error)
+}
+
+// FindHoldUseCase reads a single hold. Only the accounts of the hold and principals allowed to
+// access any account can read it.
+type FindHoldUseCase struct {
+	repository entity.HoldRepository
+}
+
+func NewFindHoldUseCase(repository entity.HoldRepository) *FindHoldUseCase {
+	return &FindHoldUseCase{
+		repository: repository,
+	}
+}
+
+func (f *FindHoldUseCase) Execute(ctx context.Context, input *FindHoldUseCaseInput) (*HoldUseCaseOutput, error) {
+	if input.principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	hold, err := f.repository.FindByID(ctx, input.holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// holds of other accounts are hidden as not found
+	if !input.principal.Can(entity.ACCESS_ANY_ACCOUNT_PERMISSION) &&
+		input.principal.AccountID != hold.OriginAccount.ID &&
+		input.principal.AccountID != hold.DestinationAccount.ID {
+		return nil, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found hold: %s", hold.ID))
+	}
+
+	return NewHoldUseCaseOutput(&hold), nil
+}
+
+type FindHoldUseCaseInput struct {
+	holdID    string
+	principal *entity.Principal
+}
+
+func NewFindHoldUseCaseInput(holdID string, principal *entity.Principal) *FindHoldUseCaseInput {
+	return &FindHoldUseCaseInput{
+		holdID:    holdID,
+		principal: principal,
+	}
+}
@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindHoldUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindHoldUseCase when the sender reads the hold", func(t *testing.T) {
+		ctx := context.Background()
+		hold := GetActiveHold(t)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByID", ctx, hold.ID).Return(hold, nil)
+
+		output, err := usecase.NewFindHoldUseCase(holdRepository).Execute(ctx, usecase.NewFindHoldUseCaseInput(hold.ID, entity.NewPrincipal(hold.OriginAccount.ID, entity.CUSTOMER_ROLE)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, usecase.NewHoldUseCaseOutput(&hold), output)
+	})
+
+	t.Run("Testing FindHoldUseCase when the hold belongs to other accounts", func(t *testing.T) {
+		ctx := context.Background()
+		hold := GetActiveHold(t)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByID", ctx, hold.ID).Return(hold, nil)
+
+		output, err := usecase.NewFindHoldUseCase(holdRepository).Execute(ctx, usecase.NewFindHoldUseCaseInput(hold.ID, entity.NewPrincipal("f0b6b3f4-9c8a-4a0e-8d8f-3b7d3d7c2e11", entity.CUSTOMER_ROLE)))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found hold: "+hold.ID, err.Error())
+	})
+
+	t.Run("Testing FindHoldUseCase when an admin reads the hold", func(t *testing.T) {
+		ctx := context.Background()
+		hold := GetActiveHold(t)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByID", ctx, hold.ID).Return(hold, nil)
+
+		output, err := usecase.NewFindHoldUseCase(holdRepository).Execute(ctx, usecase.NewFindHoldUseCaseInput(hold.ID, entity.NewPrincipal("f0b6b3f4-9c8a-4a0e-8d8f-3b7d3d7c2e11", entity.ADMIN_ROLE)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, hold.ID, output.ID)
+	})
+}
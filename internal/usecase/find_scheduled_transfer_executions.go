@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type IFindScheduledTransferExecutionsUseCase interface {
+	Execute(ctx context.Context, input *FindScheduledTransferExecutionsUseCaseInput) ([]FindScheduledTransferExecutionsUseCaseOutput, error)
+}
+
+type FindScheduledTransferExecutionsUseCase struct {
+	repository entity.ScheduledTransferRepository
+}
+
+func NewFindScheduledTransferExecutionsUseCase(repository entity.ScheduledTransferRepository) *FindScheduledTransferExecutionsUseCase {
+	return &FindScheduledTransferExecutionsUseCase{
+		repository: repository,
+	}
+}
+
+func (f *FindScheduledTransferExecutionsUseCase) Execute(ctx context.Context, input *FindScheduledTransferExecutionsUseCaseInput) ([]FindScheduledTransferExecutionsUseCaseOutput, error) {
+	scheduledTransfer, err := findOwnScheduledTransfer(ctx, f.repository, input.AccountID, input.ScheduledTransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := f.repository.FindExecutions(ctx, scheduledTransfer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []FindScheduledTransferExecutionsUseCaseOutput{}
+	for _, execution := range executions {
+		output = append(output, *NewFindScheduledTransferExecutionsUseCaseOutput(execution))
+	}
+
+	return output, nil
+}
+
+type FindScheduledTransferExecutionsUseCaseInput struct {
+	AccountID           string
+	ScheduledTransferID string
+}
+
+func NewFindScheduledTransferExecutionsUseCaseInput(accountID string, scheduledTransferID string) *FindScheduledTransferExecutionsUseCaseInput {
+	return &FindScheduledTransferExecutionsUseCaseInput{
+		AccountID:           accountID,
+		ScheduledTransferID: scheduledTransferID,
+	}
+}
+
+type FindScheduledTransferExecutionsUseCaseOutput struct {
+	ID            string `json:"id"`
+	TransferID    string `json:"transfer_id,omitempty"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	ExecutedAt    string `json:"executed_at"`
+}
+
+func NewFindScheduledTransferExecutionsUseCaseOutput(execution entity.ScheduledTransferExecution) *FindScheduledTransferExecutionsUseCaseOutput {
+	return &FindScheduledTransferExecutionsUseCaseOutput{
+		ID:            execution.ID,
+		TransferID:    execution.TransferID,
+		Status:        strings.ToLower(string(execution.Status)),
+		FailureReason: execution.FailureReason,
+		ExecutedAt:    execution.ExecutedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,61 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindScheduledTransferExecutionsUseCase_Execute(t *testing.T) {
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+	scheduledTransferID := "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"
+
+	t.Run("Testing FindScheduledTransferExecutionsUseCase when successful", func(t *testing.T) {
+		ctx := context.Background()
+		executedAt := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(GetScheduledTransfers(t, scheduledTransferID)[0], nil)
+		repository.On("FindExecutions", ctx, scheduledTransferID).Return([]entity.ScheduledTransferExecution{
+			*entity.NewScheduledTransferExecution("5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11", scheduledTransferID, "", errors.New("insufficient balance"), &executedAt),
+		}, nil)
+
+		output, err := usecase.NewFindScheduledTransferExecutionsUseCase(repository).Execute(ctx, usecase.NewFindScheduledTransferExecutionsUseCaseInput(accountID, scheduledTransferID))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []usecase.FindScheduledTransferExecutionsUseCaseOutput{{
+			ID:            "5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11",
+			Status:        "failed",
+			FailureReason: "insufficient balance",
+			ExecutedAt:    "2023-08-10T09:00:30Z",
+		}}, output)
+	})
+
+	t.Run("Testing FindScheduledTransferExecutionsUseCase with a schedule of another account", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(GetScheduledTransfers(t, scheduledTransferID)[0], nil)
+
+		_, err := usecase.NewFindScheduledTransferExecutionsUseCase(repository).Execute(ctx, usecase.NewFindScheduledTransferExecutionsUseCaseInput("d18551d3-cf13-49ec-b1dc-741a1f8715f6", scheduledTransferID))
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing FindScheduledTransferExecutionsUseCase when repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByID", ctx, scheduledTransferID).Return(entity.ScheduledTransfer{}, errors.New("connection closed"))
+
+		_, err := usecase.NewFindScheduledTransferExecutionsUseCase(repository).Execute(ctx, usecase.NewFindScheduledTransferExecutionsUseCaseInput(accountID, scheduledTransferID))
+
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IFindScheduledTransfersByAccountUseCase interface {
+	Execute(ctx context.Context, input *FindScheduledTransfersByAccountUseCaseInput) (*FindScheduledTransfersByAccountUseCasePageOutput, error)
+}
+
+type FindScheduledTransfersByAccountUseCase struct {
+	repository entity.ScheduledTransferRepository
+	paginator  *entity.Paginator
+}
+
+func NewFindScheduledTransfersByAccountUseCase(repository entity.ScheduledTransferRepository, paginator *entity.Paginator) *FindScheduledTransfersByAccountUseCase {
+	return &FindScheduledTransfersByAccountUseCase{
+		repository: repository,
+		paginator:  paginator,
+	}
+}
+
+func (f *FindScheduledTransfersByAccountUseCase) Execute(ctx context.Context, input *FindScheduledTransfersByAccountUseCaseInput) (*FindScheduledTransfersByAccountUseCasePageOutput, error) {
+	page, err := f.paginator.NewPagination(input.limit, input.offset, input.cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one row more than the page tells whether there is a next page
+	query := *page
+	query.Limit++
+
+	scheduledTransfers, err := f.repository.FindByAccountID(ctx, input.accountID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(scheduledTransfers) > page.Limit
+	if hasMore {
+		scheduledTransfers = scheduledTransfers[:page.Limit]
+	}
+
+	output := &FindScheduledTransfersByAccountUseCasePageOutput{
+		Data:    []ScheduledTransferUseCaseOutput{},
+		HasMore: hasMore,
+	}
+
+	for _, scheduledTransfer := range scheduledTransfers {
+		output.Data = append(output.Data, *NewScheduledTransferUseCaseOutput(&scheduledTransfer))
+	}
+
+	if hasMore {
+		last := scheduledTransfers[len(scheduledTransfers)-1]
+		output.NextCursor = f.paginator.EncodeCursor(entity.PageCursor{CreatedAt: *last.CreatedAt, ID: last.ID})
+	}
+
+	return output, nil
+}
+
+type FindScheduledTransfersByAccountUseCaseInput struct {
+	accountID string
+	limit     int
+	offset    int
+	cursor    string
+}
+
+func NewFindScheduledTransfersByAccountUseCaseInput(accountID string, limit int, offset int, cursor string) *FindScheduledTransfersByAccountUseCaseInput {
+	return &FindScheduledTransfersByAccountUseCaseInput{
+		accountID: accountID,
+		limit:     limit,
+		offset:    offset,
+		cursor:    cursor,
+	}
+}
+
+type FindScheduledTransfersByAccountUseCasePageOutput struct {
+	Data       []ScheduledTransferUseCaseOutput `json:"data"`
+	NextCursor string                           `json:"next_cursor"`
+	HasMore    bool                             `json:"has_more"`
+}
@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetScheduledTransfers(t *testing.T, IDs ...string) []entity.ScheduledTransfer {
+	createdAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
+	startAt := time.Date(2023, 8, 10, 9, 00, 00, 00, time.UTC)
+
+	scheduledTransfers := []entity.ScheduledTransfer{}
+	for _, ID := range IDs {
+		scheduledTransfer, err := entity.NewScheduledTransfer(ID, "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(50, entity.BRL), entity.WEEKLY, &startAt, nil, 0, &createdAt)
+		assert.Nil(t, err)
+
+		scheduledTransfers = append(scheduledTransfers, *scheduledTransfer)
+	}
+
+	return scheduledTransfers
+}
+
+func TestFindScheduledTransfersByAccountUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindScheduledTransfersByAccountUseCase when successful", func(t *testing.T) {
+		ctx := context.Background()
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByAccountID", ctx, accountID, entity.Pagination{Limit: 21}).Return(GetScheduledTransfers(t, "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"), nil)
+
+		output, err := usecase.NewFindScheduledTransfersByAccountUseCase(repository, GetPaginator(t)).Execute(ctx, usecase.NewFindScheduledTransfersByAccountUseCaseInput(accountID, 0, 0, ""))
+
+		assert.Nil(t, err)
+		assert.False(t, output.HasMore)
+		assert.Empty(t, output.NextCursor)
+		assert.Len(t, output.Data, 1)
+		assert.Equal(t, "weekly", output.Data[0].Frequency)
+		assert.Equal(t, "2023-08-10T09:00:00Z", output.Data[0].NextRunAt)
+	})
+
+	t.Run("Testing FindScheduledTransfersByAccountUseCase returns the cursor of the last schedule when there are more pages", func(t *testing.T) {
+		ctx := context.Background()
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByAccountID", ctx, accountID, entity.Pagination{Limit: 2}).Return(GetScheduledTransfers(t, "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11"), nil)
+
+		paginator := GetPaginator(t)
+		output, err := usecase.NewFindScheduledTransfersByAccountUseCase(repository, paginator).Execute(ctx, usecase.NewFindScheduledTransfersByAccountUseCaseInput(accountID, 1, 0, ""))
+
+		assert.Nil(t, err)
+		assert.True(t, output.HasMore)
+		assert.Len(t, output.Data, 1)
+		assert.Equal(t, paginator.EncodeCursor(entity.PageCursor{CreatedAt: time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC), ID: "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"}), output.NextCursor)
+	})
+
+	t.Run("Testing FindScheduledTransfersByAccountUseCase when repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindByAccountID", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.Pagination{Limit: 21}).Return([]entity.ScheduledTransfer{}, errors.New("connection closed"))
+
+		output, err := usecase.NewFindScheduledTransfersByAccountUseCase(repository, GetPaginator(t)).Execute(ctx, usecase.NewFindScheduledTransfersByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", 0, 0, ""))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+
+	t.Run("Testing FindScheduledTransfersByAccountUseCase with an invalid cursor", func(t *testing.T) {
+		_, err := usecase.NewFindScheduledTransfersByAccountUseCase(mock.NewScheduledTransferRepositoryMock(), GetPaginator(t)).Execute(context.Background(), usecase.NewFindScheduledTransfersByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", 0, 0, "forged"))
+
+		assert.Equal(t, entity.BAD_REQUEST, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+	"time"
+)
+
+type IFindTransferUseCase interface {
+	Execute(ctx context.Context, input *FindTransferUseCaseInput) (*FindTransferUseCaseOutput, error)
+}
+
+// FindTransferUseCase reads a single transfer with its status history. Only the accounts of the
+// transfer and principals allowed to access any account can read it.
+type FindTransferUseCase struct {
+	repository entity.TransferRepository
+}
+
+func NewFindTransferUseCase(repository entity.TransferRepository) *FindTransferUseCase {
+	return &FindTransferUseCase{
+		repository: repository,
+	}
+}
+
+func (f *FindTransferUseCase) Execute(ctx context.Context, input *FindTransferUseCaseInput) (*FindTransferUseCaseOutput, error) {
+	if input.principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	transfer, err := f.repository.FindByID(ctx, input.transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	// transfers of other accounts are hidden as not found
+	if !input.principal.Can(entity.ACCESS_ANY_ACCOUNT_PERMISSION) &&
+		input.principal.AccountID != transfer.OriginAccount.ID &&
+		input.principal.AccountID != transfer.DestinationAccount.ID {
+		return nil, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer: %s", transfer.ID))
+	}
+
+	return NewFindTransferUseCaseOutput(&transfer), nil
+}
+
+type FindTransferUseCaseInput struct {
+	transferID string
+	principal  *entity.Principal
+}
+
+func NewFindTransferUseCaseInput(transferID string, principal *entity.Principal) *FindTransferUseCaseInput {
+	return &FindTransferUseCaseInput{
+		transferID: transferID,
+		principal:  principal,
+	}
+}
+
+// FindTransferUseCaseOutput is the transfer with every status it went through, oldest first.
+type FindTransferUseCaseOutput struct {
+	MakeTransferUseCaseOutput
+	StatusHistory []FindTransferUseCaseStatusChange `json:"status_history"`
+}
+
+type FindTransferUseCaseStatusChange struct {
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+	ChangedAt string `json:"changed_at"`
+}
+
+func NewFindTransferUseCaseOutput(transfer *entity.Transfer) *FindTransferUseCaseOutput {
+	output := &FindTransferUseCaseOutput{
+		MakeTransferUseCaseOutput: *NewMakeTransferUseCaseOutput(transfer),
+		StatusHistory:             []FindTransferUseCaseStatusChange{},
+	}
+
+	for _, change := range transfer.StatusHistory {
+		output.StatusHistory = append(output.StatusHistory, FindTransferUseCaseStatusChange{
+			Status:    strings.ToLower(string(change.Status)),
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt.Format(time.RFC3339),
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IFindTransferBatchUseCase interface {
+	Execute(ctx context.Context, input *FindTransferBatchUseCaseInput) (*TransferBatchUseCaseOutput, error)
+}
+
+// FindTransferBatchUseCase reads a single batch, it is polled until the batch is finished.
+// Only the origin account and principals allowed to access any account can read it.
+type FindTransferBatchUseCase struct {
+	repository entity.TransferBatchRepository
+}
+
+func NewFindTransferBatchUseCase(repository entity.TransferBatchRepository) *FindTransferBatchUseCase {
+	return &FindTransferBatchUseCase{
+		repository: repository,
+	}
+}
+
+func (f *FindTransferBatchUseCase) Execute(ctx context.Context, input *FindTransferBatchUseCaseInput) (*TransferBatchUseCaseOutput, error) {
+	if input.principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	batch, err := f.repository.FindByID(ctx, input.batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// batches of other accounts are hidden as not found
+	if !input.principal.Can(entity.ACCESS_ANY_ACCOUNT_PERMISSION) && input.principal.AccountID != batch.OriginAccountID {
+		return nil, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer batch: %s", batch.ID))
+	}
+
+	return NewTransferBatchUseCaseOutput(&batch), nil
+}
+
+type FindTransferBatchUseCaseInput struct {
+	batchID   string
+	principal *entity.Principal
+}
+
+func NewFindTransferBatchUseCaseInput(batchID string, principal *entity.Principal) *FindTransferBatchUseCaseInput {
+	return &FindTransferBatchUseCaseInput{
+		batchID:   batchID,
+		principal: principal,
+	}
+}
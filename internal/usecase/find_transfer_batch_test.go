@@ -0,0 +1,66 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func GetTransferBatch(t *testing.T, mode entity.TransferBatchMode) entity.TransferBatch {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+	batch, err := entity.NewTransferBatch("5b0f6d5e-2a9c-4e3b-8d6f-1c2a3b4c5d6e", "2bd765a6-47bd-4731-9eb2-1e65542f4477", mode, []entity.TransferBatchItem{
+		{DestinationAccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Amount: entity.NewMoney(50, entity.BRL)},
+		{DestinationAccountID: "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", Amount: entity.NewMoney(30, entity.BRL)},
+	}, &createdAt)
+	assert.Nil(t, err)
+
+	return *batch
+}
+
+func TestFindTransferBatchUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindTransferBatchUseCase when the origin account reads the batch", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.ATOMIC_BATCH)
+
+		batchRepository := mock.NewTransferBatchRepositoryMock()
+		batchRepository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+
+		output, err := usecase.NewFindTransferBatchUseCase(batchRepository).Execute(ctx, usecase.NewFindTransferBatchUseCaseInput(batch.ID, entity.NewPrincipal(batch.OriginAccountID, entity.CUSTOMER_ROLE)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, usecase.NewTransferBatchUseCaseOutput(&batch), output)
+	})
+
+	t.Run("Testing FindTransferBatchUseCase when a destination account reads the batch", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.ATOMIC_BATCH)
+
+		batchRepository := mock.NewTransferBatchRepositoryMock()
+		batchRepository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+
+		output, err := usecase.NewFindTransferBatchUseCase(batchRepository).Execute(ctx, usecase.NewFindTransferBatchUseCaseInput(batch.ID, entity.NewPrincipal("d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.CUSTOMER_ROLE)))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer batch: "+batch.ID, err.Error())
+	})
+
+	t.Run("Testing FindTransferBatchUseCase when an admin reads the batch", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.ATOMIC_BATCH)
+
+		batchRepository := mock.NewTransferBatchRepositoryMock()
+		batchRepository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+
+		output, err := usecase.NewFindTransferBatchUseCase(batchRepository).Execute(ctx, usecase.NewFindTransferBatchUseCaseInput(batch.ID, entity.NewPrincipal("f0b6b3f4-9c8a-4a0e-8d8f-3b7d3d7c2e11", entity.ADMIN_ROLE)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, batch.ID, output.ID)
+	})
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"strings"
+)
+
+type IFindTransferKeyUseCase interface {
+	Execute(ctx context.Context, input *FindTransferKeyUseCaseInput) (*FindTransferKeyUseCaseOutput, error)
+}
+
+// FindTransferKeyUseCase looks up a key before a transfer is sent to it, so the sender can
+// confirm the recipient. Only the masked name of the owner is shown, never the account ID.
+type FindTransferKeyUseCase struct {
+	accountRepository entity.AccountRepository
+	lookupRule        *TransferKeyLookupRule
+}
+
+func NewFindTransferKeyUseCase(accountRepository entity.AccountRepository, lookupRule *TransferKeyLookupRule) *FindTransferKeyUseCase {
+	return &FindTransferKeyUseCase{
+		accountRepository: accountRepository,
+		lookupRule:        lookupRule,
+	}
+}
+
+func (f *FindTransferKeyUseCase) Execute(ctx context.Context, input *FindTransferKeyUseCaseInput) (*FindTransferKeyUseCaseOutput, error) {
+	transferKey, err := f.lookupRule.Find(ctx, input.AccountID, input.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := f.accountRepository.FindByID(ctx, transferKey.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FindTransferKeyUseCaseOutput{
+		Key:       transferKey.Key,
+		Type:      strings.ToLower(string(transferKey.Type)),
+		OwnerName: entity.MaskName(account.Name),
+	}, nil
+}
+
+// FindTransferKeyUseCaseInput has the account looking the key up, its misses are the ones counted.
+type FindTransferKeyUseCaseInput struct {
+	AccountID string
+	Key       string
+}
+
+func NewFindTransferKeyUseCaseInput(accountID string, key string) *FindTransferKeyUseCaseInput {
+	return &FindTransferKeyUseCaseInput{
+		AccountID: accountID,
+		Key:       key,
+	}
+}
+
+type FindTransferKeyUseCaseOutput struct {
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	OwnerName string `json:"owner_name"`
+}
@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTransferKeyUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+	t.Run("Testing FindTransferKeyUseCase returns the masked name of the owner", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseDestinationAccount(t)
+		account.Name = "Joao Pedro Silva"
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, account.ID).Return(*account, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "35768297090").
+			Return(entity.TransferKey{Key: "35768297090", Type: entity.CPF_KEY, AccountID: account.ID, CreatedAt: &createdAt}, nil)
+
+		lookupRule, _ := GetTransferKeyLookupRule(keyRepository)
+
+		useCase := usecase.NewFindTransferKeyUseCase(accountRepository, lookupRule)
+		output, err := useCase.Execute(ctx, usecase.NewFindTransferKeyUseCaseInput(accountID, "357.682.970-90"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.FindTransferKeyUseCaseOutput{Key: "35768297090", Type: "cpf", OwnerName: "Joao P**** S****"}, output)
+	})
+
+	t.Run("Testing FindTransferKeyUseCase when the key does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "lucas@example.com").
+			Return(entity.TransferKey{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found key: lucas@example.com"))
+
+		lookupRule, loginThrottleRepository := GetTransferKeyLookupRule(keyRepository)
+
+		useCase := usecase.NewFindTransferKeyUseCase(mock.NewAccountRepositoryMock(), lookupRule)
+		output, err := useCase.Execute(ctx, usecase.NewFindTransferKeyUseCaseInput(accountID, "Lucas@Example.com"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "key_lookup:"+accountID, testify.Anything, testify.Anything)
+	})
+}
+
+func TestFindTransferKeysByAccountUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindTransferKeysByAccountUseCase when successful", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 14, 10, 00, 00, 00, time.UTC)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByAccountID", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477").Return([]entity.TransferKey{
+			{Key: "+5511987654321", Type: entity.PHONE_KEY, AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", CreatedAt: &createdAt},
+		}, nil)
+
+		useCase := usecase.NewFindTransferKeysByAccountUseCase(keyRepository)
+		output, err := useCase.Execute(ctx, usecase.NewFindTransferKeysByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []usecase.TransferKeyUseCaseOutput{{Key: "+5511987654321", Type: "phone", CreatedAt: "2023-08-14T10:00:00Z"}}, output)
+	})
+}
+
+func TestDeleteTransferKeyUseCase_Execute(t *testing.T) {
+	t.Run("Testing DeleteTransferKeyUseCase normalizes the key", func(t *testing.T) {
+		ctx := context.Background()
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("Delete", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477", "+5511987654321").Return(nil)
+
+		useCase := usecase.NewDeleteTransferKeyUseCase(keyRepository)
+		err := useCase.Execute(ctx, usecase.NewDeleteTransferKeyUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", "+55 11 98765-4321"))
+
+		assert.Nil(t, err)
+		keyRepository.AssertExpectations(t)
+	})
+}
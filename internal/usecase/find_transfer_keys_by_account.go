@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IFindTransferKeysByAccountUseCase interface {
+	Execute(ctx context.Context, input *FindTransferKeysByAccountUseCaseInput) ([]TransferKeyUseCaseOutput, error)
+}
+
+type FindTransferKeysByAccountUseCase struct {
+	repository entity.TransferKeyRepository
+}
+
+func NewFindTransferKeysByAccountUseCase(repository entity.TransferKeyRepository) *FindTransferKeysByAccountUseCase {
+	return &FindTransferKeysByAccountUseCase{
+		repository: repository,
+	}
+}
+
+func (f *FindTransferKeysByAccountUseCase) Execute(ctx context.Context, input *FindTransferKeysByAccountUseCaseInput) ([]TransferKeyUseCaseOutput, error) {
+	keys, err := f.repository.FindByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []TransferKeyUseCaseOutput{}
+	for i := range keys {
+		output = append(output, *NewTransferKeyUseCaseOutput(&keys[i]))
+	}
+
+	return output, nil
+}
+
+type FindTransferKeysByAccountUseCaseInput struct {
+	AccountID string
+}
+
+func NewFindTransferKeysByAccountUseCaseInput(accountID string) *FindTransferKeysByAccountUseCaseInput {
+	return &FindTransferKeysByAccountUseCaseInput{
+		AccountID: accountID,
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IFindTransferLimitUseCase interface {
+	Execute(ctx context.Context, input *FindTransferLimitUseCaseInput) (*FindTransferLimitUseCaseOutput, error)
+}
+
+type FindTransferLimitUseCase struct {
+	accountRepository entity.AccountRepository
+	limitRule         *TransferLimitRule
+}
+
+func NewFindTransferLimitUseCase(accountRepository entity.AccountRepository, limitRule *TransferLimitRule) *FindTransferLimitUseCase {
+	return &FindTransferLimitUseCase{
+		accountRepository: accountRepository,
+		limitRule:         limitRule,
+	}
+}
+
+func (f *FindTransferLimitUseCase) Execute(ctx context.Context, input *FindTransferLimitUseCaseInput) (*FindTransferLimitUseCaseOutput, error) {
+	account, err := f.accountRepository.FindByID(ctx, input.accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	transferLimit, err := f.limitRule.Find(ctx, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFindTransferLimitUseCaseOutput(&transferLimit, f.limitRule), nil
+}
+
+type FindTransferLimitUseCaseInput struct {
+	accountID string
+}
+
+func NewFindTransferLimitUseCaseInput(accountID string) *FindTransferLimitUseCaseInput {
+	return &FindTransferLimitUseCaseInput{
+		accountID: accountID,
+	}
+}
+
+// FindTransferLimitUseCaseOutput has no updated_at while the account has the default limits.
+type FindTransferLimitUseCaseOutput struct {
+	AccountID      string       `json:"account_id"`
+	PerTransaction entity.Money `json:"per_transaction"`
+	Daily          entity.Money `json:"daily"`
+	Monthly        entity.Money `json:"monthly"`
+	Nighttime      entity.Money `json:"nighttime"`
+	NightStart     string       `json:"night_start"`
+	NightEnd       string       `json:"night_end"`
+	UpdatedAt      string       `json:"updated_at,omitempty"`
+}
+
+func NewFindTransferLimitUseCaseOutput(transferLimit *entity.TransferLimit, limitRule *TransferLimitRule) *FindTransferLimitUseCaseOutput {
+	nightStart, nightEnd := limitRule.NightWindow()
+
+	output := &FindTransferLimitUseCaseOutput{
+		AccountID:      transferLimit.AccountID,
+		PerTransaction: transferLimit.PerTransaction,
+		Daily:          transferLimit.Daily,
+		Monthly:        transferLimit.Monthly,
+		Nighttime:      transferLimit.Nighttime,
+		NightStart:     nightStart,
+		NightEnd:       nightEnd,
+	}
+
+	if transferLimit.UpdatedAt != nil {
+		output.UpdatedAt = transferLimit.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return output
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTransferLimitUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindTransferLimitUseCase when the account has its own limits", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, testify.Anything).Return(GetAccountTransferLimit(t, originAccount.ID), nil)
+		limitRule := usecase.NewTransferLimitRule(limitRepository, mock.NewTransferRepositoryMock(), GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		findTransferLimitUseCase := usecase.NewFindTransferLimitUseCase(accountRepository, limitRule)
+		output, err := findTransferLimitUseCase.Execute(ctx, usecase.NewFindTransferLimitUseCaseInput(originAccount.ID))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.FindTransferLimitUseCaseOutput{
+			AccountID:      originAccount.ID,
+			PerTransaction: entity.NewMoney(100, entity.BRL),
+			Daily:          entity.NewMoney(150, entity.BRL),
+			Monthly:        entity.NewMoney(1000, entity.BRL),
+			Nighttime:      entity.NewMoney(80, entity.BRL),
+			NightStart:     "20:00",
+			NightEnd:       "06:00",
+			UpdatedAt:      "2023-08-01T10:00:00Z",
+		}, output)
+	})
+
+	t.Run("Testing FindTransferLimitUseCase when the account has the default limits", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
+
+		findTransferLimitUseCase := usecase.NewFindTransferLimitUseCase(accountRepository, GetTransferLimitRule(t))
+		output, err := findTransferLimitUseCase.Execute(ctx, usecase.NewFindTransferLimitUseCaseInput(originAccount.ID))
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(500000, entity.BRL), output.PerTransaction)
+		assert.Equal(t, entity.NewMoney(100000, entity.BRL), output.Nighttime)
+		assert.Equal(t, "", output.UpdatedAt)
+	})
+
+	t.Run("Testing FindTransferLimitUseCase when the account does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, "2bd765a6-47bd-4731-9eb2-1e65542f4477").Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account"))
+
+		findTransferLimitUseCase := usecase.NewFindTransferLimitUseCase(accountRepository, GetTransferLimitRule(t))
+		output, err := findTransferLimitUseCase.Execute(ctx, usecase.NewFindTransferLimitUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetFailedTransfer is a transfer of 500 from the base origin account to the base destination
+// account that failed for lack of balance, as read by FindByID.
+func GetFailedTransfer(t *testing.T) entity.Transfer {
+	createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+	return entity.Transfer{
+		ID:                 "237d3e7e-2f46-44e7-bf2b-f79721459241",
+		OriginAccount:      &entity.Account{ID: GetBaseOriginAccount(t).ID, Name: "Lucas"},
+		DestinationAccount: &entity.Account{ID: GetBaseDestinationAccount(t).ID, Name: "Roger"},
+		Amount:             entity.NewMoney(500, entity.BRL),
+		DestinationAmount:  entity.NewMoney(500, entity.BRL),
+		ExchangeRate:       "1",
+		ReversedAmount:     entity.NewMoney(0, entity.BRL),
+		Status:             entity.FAILED_TRANSFER,
+		FailureReason:      "insufficient balance",
+		StatusHistory: []entity.TransferStatusChange{
+			{Status: entity.PENDING_TRANSFER, ChangedAt: &createdAt},
+			{Status: entity.FAILED_TRANSFER, Reason: "insufficient balance", ChangedAt: &createdAt},
+		},
+		CreatedAt: &createdAt,
+	}
+}
+
+func TestFindTransferUseCase_Execute(t *testing.T) {
+	t.Run("Testing FindTransferUseCase when the origin account reads the transfer", func(t *testing.T) {
+		ctx := context.Background()
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByID", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241").Return(GetFailedTransfer(t), nil)
+
+		findTransferUseCase := usecase.NewFindTransferUseCase(transferRepository)
+		input := usecase.NewFindTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE))
+		output, err := findTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "237d3e7e-2f46-44e7-bf2b-f79721459241", output.ID)
+		assert.Equal(t, "failed", output.Status)
+		assert.Equal(t, "insufficient balance", output.FailureReason)
+		assert.Equal(t, "Lucas", output.OriginAccount.Name)
+		assert.Equal(t, []usecase.FindTransferUseCaseStatusChange{
+			{Status: "pending", ChangedAt: "2023-08-07T10:00:00Z"},
+			{Status: "failed", Reason: "insufficient balance", ChangedAt: "2023-08-07T10:00:00Z"},
+		}, output.StatusHistory)
+	})
+
+	t.Run("Testing FindTransferUseCase when an admin reads a transfer of other accounts", func(t *testing.T) {
+		ctx := context.Background()
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByID", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241").Return(GetFailedTransfer(t), nil)
+
+		findTransferUseCase := usecase.NewFindTransferUseCase(transferRepository)
+		input := usecase.NewFindTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal("e2d4b0a8-9b1f-4d1e-8a5f-3c2b1a0f9e8d", entity.ADMIN_ROLE))
+		output, err := findTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "237d3e7e-2f46-44e7-bf2b-f79721459241", output.ID)
+	})
+
+	t.Run("Testing FindTransferUseCase when the transfer belongs to other accounts", func(t *testing.T) {
+		ctx := context.Background()
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByID", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241").Return(GetFailedTransfer(t), nil)
+
+		findTransferUseCase := usecase.NewFindTransferUseCase(transferRepository)
+		input := usecase.NewFindTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal("e2d4b0a8-9b1f-4d1e-8a5f-3c2b1a0f9e8d", entity.CUSTOMER_ROLE))
+		output, err := findTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer: 237d3e7e-2f46-44e7-bf2b-f79721459241", err.Error())
+	})
+
+	t.Run("Testing FindTransferUseCase when the transfer does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByID", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241").Return(entity.Transfer{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found transfer: 237d3e7e-2f46-44e7-bf2b-f79721459241"))
+
+		findTransferUseCase := usecase.NewFindTransferUseCase(transferRepository)
+		input := usecase.NewFindTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE))
+		output, err := findTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
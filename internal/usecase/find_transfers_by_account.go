@@ -3,33 +3,64 @@ package usecase
 import (
 	"context"
 	"lucassantoss1701/bank/internal/entity"
+	"strings"
 	"time"
 )
 
 type IFindTransfersByAccountUseCase interface {
-	Execute(ctx context.Context, input *FindTransfersByAccountUseCaseInput) ([]FindTransfersByAccountUseCaseOutput, error)
+	Execute(ctx context.Context, input *FindTransfersByAccountUseCaseInput) (*FindTransfersByAccountUseCasePageOutput, error)
 }
 
 type FindTransfersByAccountUseCase struct {
 	repostiory entity.TransferRepository
+	paginator  *entity.Paginator
 }
 
-func NewFindTransfersByAccountUseCase(repostiory entity.TransferRepository) *FindTransfersByAccountUseCase {
+func NewFindTransfersByAccountUseCase(repostiory entity.TransferRepository, paginator *entity.Paginator) *FindTransfersByAccountUseCase {
 	return &FindTransfersByAccountUseCase{
 		repostiory: repostiory,
+		paginator:  paginator,
 	}
 }
 
-func (f *FindTransfersByAccountUseCase) Execute(ctx context.Context, input *FindTransfersByAccountUseCaseInput) ([]FindTransfersByAccountUseCaseOutput, error) {
-	transfererences, err := f.repostiory.FindByAccountID(ctx, input.accountID, input.limit, input.offset)
+func (f *FindTransfersByAccountUseCase) Execute(ctx context.Context, input *FindTransfersByAccountUseCaseInput) (*FindTransfersByAccountUseCasePageOutput, error) {
+	filter, err := entity.NewTransferFilter(input.direction, input.from, input.to, input.minAmount, input.maxAmount, input.counterpartyID)
 	if err != nil {
 		return nil, err
 	}
 
-	output := []FindTransfersByAccountUseCaseOutput{}
+	page, err := f.paginator.NewPagination(input.limit, input.offset, input.cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one row more than the page tells whether there is a next page
+	query := *page
+	query.Limit++
+
+	transfererences, err := f.repostiory.FindByAccountID(ctx, input.accountID, *filter, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(transfererences) > page.Limit
+	if hasMore {
+		transfererences = transfererences[:page.Limit]
+	}
+
+	output := &FindTransfersByAccountUseCasePageOutput{
+		Data:    []FindTransfersByAccountUseCaseOutput{},
+		HasMore: hasMore,
+	}
+
 	for _, transfer := range transfererences {
-		output = append(output, *NewFindTransfersByAccountUseCaseOutput(transfer))
+		output.Data = append(output.Data, *NewFindTransfersByAccountUseCaseOutput(transfer, input.accountID))
+
+	}
 
+	if hasMore {
+		last := transfererences[len(transfererences)-1]
+		output.NextCursor = f.paginator.EncodeCursor(entity.PageCursor{CreatedAt: *last.CreatedAt, ID: last.ID})
 	}
 
 	return output, nil
@@ -37,24 +68,60 @@ func (f *FindTransfersByAccountUseCase) Execute(ctx context.Context, input *Find
 }
 
 type FindTransfersByAccountUseCaseInput struct {
-	accountID string
-	limit     int
-	offset    int
+	accountID      string
+	limit          int
+	offset         int
+	cursor         string
+	direction      string
+	from           *time.Time
+	to             *time.Time
+	minAmount      *entity.Money
+	maxAmount      *entity.Money
+	counterpartyID string
 }
 
-func NewFindTransfersByAccountUseCaseInput(accountID string, limit int, offset int) *FindTransfersByAccountUseCaseInput {
+func NewFindTransfersByAccountUseCaseInput(accountID string, limit int, offset int, cursor string) *FindTransfersByAccountUseCaseInput {
 	return &FindTransfersByAccountUseCaseInput{
 		accountID: accountID,
 		limit:     limit,
 		offset:    offset,
+		cursor:    cursor,
 	}
 }
 
+// WithFilter restricts the history, every empty or nil argument is ignored.
+func (i *FindTransfersByAccountUseCaseInput) WithFilter(direction string, from *time.Time, to *time.Time, minAmount *entity.Money, maxAmount *entity.Money, counterpartyID string) *FindTransfersByAccountUseCaseInput {
+	i.direction = direction
+	i.from = from
+	i.to = to
+	i.minAmount = minAmount
+	i.maxAmount = maxAmount
+	i.counterpartyID = counterpartyID
+	return i
+}
+
 type FindTransfersByAccountUseCaseOutput struct {
-	ID                 string  `json:"id"`
-	DestinationAccount account `json:"destination_account"`
-	Amount             int     `json:"amount"`
-	CreatedAt          string  `json:"created_at"`
+	ID                 string                   `json:"id"`
+	Direction          string                   `json:"direction"`
+	Counterparty       account                  `json:"counterparty"`
+	OriginAccount      account                  `json:"origin_account"`
+	DestinationAccount account                  `json:"destination_account"`
+	Amount             entity.Money             `json:"amount"`
+	DestinationAmount  entity.Money             `json:"destination_amount"`
+	ExchangeRate       string                   `json:"exchange_rate"`
+	ReversalOf         string                   `json:"reversal_of,omitempty"`
+	ReversedAmount     entity.Money             `json:"reversed_amount"`
+	Fee                entity.Money             `json:"fee"`
+	Fees               []MakeTransferUseCaseFee `json:"fees"`
+	Status             string                   `json:"status"`
+	FailureReason      string                   `json:"failure_reason,omitempty"`
+	CreatedAt          string                   `json:"created_at"`
+}
+
+type FindTransfersByAccountUseCasePageOutput struct {
+	Data       []FindTransfersByAccountUseCaseOutput `json:"data"`
+	NextCursor string                                `json:"next_cursor"`
+	HasMore    bool                                  `json:"has_more"`
 }
 
 type account struct {
@@ -62,11 +129,30 @@ type account struct {
 	Name string `json:"name"`
 }
 
-func NewFindTransfersByAccountUseCaseOutput(transfer entity.Transfer) *FindTransfersByAccountUseCaseOutput {
+func NewFindTransfersByAccountUseCaseOutput(transfer entity.Transfer, accountID string) *FindTransfersByAccountUseCaseOutput {
+	counterparty := transfer.CounterpartyFor(accountID)
+
 	return &FindTransfersByAccountUseCaseOutput{
-		ID:        transfer.ID,
-		Amount:    transfer.Amount,
-		CreatedAt: transfer.CreatedAt.Format(time.RFC3339),
+		ID:                transfer.ID,
+		Direction:         strings.ToLower(string(transfer.DirectionFor(accountID))),
+		Amount:            transfer.Amount,
+		DestinationAmount: transfer.DestinationAmount,
+		ExchangeRate:      transfer.ExchangeRate,
+		ReversalOf:        transfer.ReversalOf,
+		ReversedAmount:    transfer.ReversedAmount,
+		Fee:               transfer.Fee,
+		Fees:              NewMakeTransferUseCaseFees(transfer.Fees),
+		Status:            strings.ToLower(string(transfer.Status)),
+		FailureReason:     transfer.FailureReason,
+		CreatedAt:         transfer.CreatedAt.Format(time.RFC3339),
+		Counterparty: account{
+			ID:   counterparty.ID,
+			Name: counterparty.Name,
+		},
+		OriginAccount: account{
+			ID:   transfer.OriginAccount.ID,
+			Name: transfer.OriginAccount.Name,
+		},
 		DestinationAccount: account{
 			ID:   transfer.DestinationAccount.ID,
 			Name: transfer.DestinationAccount.Name,
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
 )
 
 func TestFindTransfersByAccountUseCase_Execute(t *testing.T) {
@@ -21,22 +22,24 @@ func TestFindTransfersByAccountUseCase_Execute(t *testing.T) {
 
 		repository := mock.NewTransferRepositoryMock()
 		transfers := mock.GetTransfererences()
-		repository.On("FindByAccountID", ctx, accountID, limit, offset).Return(transfers, nil)
+		repository.On("FindByAccountID", ctx, accountID, entity.TransferFilter{}, entity.Pagination{Limit: limit + 1, Offset: offset}).Return(transfers, nil)
 
-		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository)
+		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository, GetPaginator(t))
 
-		input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, limit, offset)
+		input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, limit, offset, "")
 
 		output, err := findTransfersByAccountUseCase.Execute(ctx, input)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, output)
 
-		assert.Equal(t, transfers[0].ID, output[0].ID)
-		assert.Equal(t, transfers[0].Amount, output[0].Amount)
-		assert.Equal(t, transfers[0].CreatedAt.Format(time.RFC3339), output[0].CreatedAt)
-		assert.Equal(t, transfers[0].DestinationAccount.Name, output[0].DestinationAccount.Name)
-		assert.Equal(t, transfers[0].DestinationAccount.ID, output[0].DestinationAccount.ID)
+		assert.Equal(t, transfers[0].ID, output.Data[0].ID)
+		assert.Equal(t, transfers[0].Amount, output.Data[0].Amount)
+		assert.Equal(t, transfers[0].CreatedAt.Format(time.RFC3339), output.Data[0].CreatedAt)
+		assert.Equal(t, transfers[0].DestinationAccount.Name, output.Data[0].DestinationAccount.Name)
+		assert.Equal(t, transfers[0].DestinationAccount.ID, output.Data[0].DestinationAccount.ID)
+		assert.Equal(t, "outgoing", output.Data[0].Direction)
+		assert.Equal(t, transfers[0].DestinationAccount.ID, output.Data[0].Counterparty.ID)
 
 	})
 
@@ -48,11 +51,11 @@ func TestFindTransfersByAccountUseCase_Execute(t *testing.T) {
 		offset := 0
 
 		repository := mock.NewTransferRepositoryMock()
-		repository.On("FindByAccountID", ctx, accountID, limit, offset).Return([]entity.Transfer{}, errors.New("error on find transfer by account ID"))
+		repository.On("FindByAccountID", ctx, accountID, entity.TransferFilter{}, entity.Pagination{Limit: limit + 1, Offset: offset}).Return([]entity.Transfer{}, errors.New("error on find transfer by account ID"))
 
-		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository)
+		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository, GetPaginator(t))
 
-		input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, limit, offset)
+		input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, limit, offset, "")
 
 		output, err := findTransfersByAccountUseCase.Execute(ctx, input)
 
@@ -63,4 +66,63 @@ func TestFindTransfersByAccountUseCase_Execute(t *testing.T) {
 
 	})
 
+	t.Run("Testing FindTransfersByAccountUseCase returns the cursor of the last transfer when there are more pages", func(t *testing.T) {
+		ctx := context.Background()
+		accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+		paginator := GetPaginator(t)
+
+		repository := mock.NewTransferRepositoryMock()
+		transfers := append(mock.GetTransfererences(), mock.GetTransfererences()...)
+		transfers[1].ID = "fc84682a-3045-4bdf-b91c-10be19f89452"
+		repository.On("FindByAccountID", ctx, accountID, entity.TransferFilter{}, entity.Pagination{Limit: 2}).Return(transfers, nil)
+
+		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository, paginator)
+
+		output, err := findTransfersByAccountUseCase.Execute(ctx, usecase.NewFindTransfersByAccountUseCaseInput(accountID, 1, 0, ""))
+
+		assert.Nil(t, err)
+		assert.Len(t, output.Data, 1)
+		assert.True(t, output.HasMore)
+		assert.Equal(t, paginator.EncodeCursor(entity.PageCursor{CreatedAt: *transfers[0].CreatedAt, ID: transfers[0].ID}), output.NextCursor)
+	})
+
+	t.Run("Testing FindTransfersByAccountUseCase returns incoming transfers with the origin as counterparty", func(t *testing.T) {
+		ctx := context.Background()
+		accountID := "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"
+		minAmount := entity.NewMoney(100, entity.BRL)
+
+		repository := mock.NewTransferRepositoryMock()
+		transfers := mock.GetTransfererences()
+		expectedFilter := entity.TransferFilter{Direction: entity.INCOMING, MinAmount: &minAmount}
+		repository.On("FindByAccountID", ctx, accountID, expectedFilter, entity.Pagination{Limit: 21}).Return(transfers, nil)
+
+		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository, GetPaginator(t))
+
+		input := usecase.NewFindTransfersByAccountUseCaseInput(accountID, 20, 0, "").WithFilter("incoming", nil, nil, &minAmount, nil, "")
+
+		output, err := findTransfersByAccountUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "incoming", output.Data[0].Direction)
+		assert.Equal(t, transfers[0].OriginAccount.ID, output.Data[0].Counterparty.ID)
+		assert.Equal(t, transfers[0].OriginAccount.Name, output.Data[0].Counterparty.Name)
+	})
+
+	t.Run("Testing FindTransfersByAccountUseCase when filter is invalid", func(t *testing.T) {
+		ctx := context.Background()
+		minAmount := entity.NewMoney(500, entity.BRL)
+		maxAmount := entity.NewMoney(100, entity.BRL)
+
+		repository := mock.NewTransferRepositoryMock()
+
+		findTransfersByAccountUseCase := usecase.NewFindTransfersByAccountUseCase(repository, GetPaginator(t))
+
+		input := usecase.NewFindTransfersByAccountUseCaseInput("2bd765a6-47bd-4731-9eb2-1e65542f4477", 20, 0, "").WithFilter("sideways", nil, nil, &minAmount, &maxAmount, "")
+
+		output, err := findTransfersByAccountUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "direction must be incoming or outgoing, min_amount cannot be greater than max_amount", err.Error())
+		repository.AssertNotCalled(t, "FindByAccountID", ctx, testify.Anything, testify.Anything, testify.Anything)
+	})
 }
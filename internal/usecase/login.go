@@ -4,66 +4,100 @@ import (
 	"context"
 	"lucassantoss1701/bank/internal/entity"
 	"time"
-
-	"github.com/golang-jwt/jwt"
 )
 
 type ILoginUseCase interface {
-	Execute(ctx context.Context, input *LoginUseCaseInput) (*LoginUseCaseOutput, error)
+	Execute(ctx context.Context, input *LoginUseCaseInput) (*TokenUseCaseOutput, error)
 }
 
 type LoginUseCase struct {
-	repostiory entity.AccountRepository
+	repostiory  entity.AccountRepository
+	tokenIssuer *TokenIssuer
+	loginGuard  *LoginGuard
+	twoFactor   *TwoFactor
 }
 
-func NewLoginUseCase(repostiory entity.AccountRepository) *LoginUseCase {
+func NewLoginUseCase(repostiory entity.AccountRepository, tokenIssuer *TokenIssuer, loginGuard *LoginGuard, twoFactor *TwoFactor) *LoginUseCase {
 	return &LoginUseCase{
-		repostiory: repostiory,
+		repostiory:  repostiory,
+		tokenIssuer: tokenIssuer,
+		loginGuard:  loginGuard,
+		twoFactor:   twoFactor,
 	}
 }
 
-func (l *LoginUseCase) Execute(ctx context.Context, input *LoginUseCaseInput) (*LoginUseCaseOutput, error) {
+// Execute answers an unknown CPF and a wrong secret with the same error, so the login cannot be
+// used to find out which CPFs have an account. Accounts with two-factor authentication get an
+// MFA token instead of the session tokens.
+func (l *LoginUseCase) Execute(ctx context.Context, input *LoginUseCaseInput) (*TokenUseCaseOutput, error) {
+	now := time.Now()
+
+	// malformed CPFs cannot have an account, they are only counted for the IP
+	CPF := input.CPF
+	if !entity.REGEXCPF.MatchString(CPF) {
+		CPF = ""
+	}
 
-	account, err := l.repostiory.FindByCPF(ctx, input.CPF)
+	var account entity.Account
+	succeeded, err := l.loginGuard.Attempt(ctx, CPF, input.IP, now, func() (entity.LoginFailureReason, error) {
+		if CPF == "" {
+			entity.CompareUnknownAccountSecret(input.Secret)
+			return entity.UNKNOWN_CPF_LOGIN_FAILURE, nil
+		}
+
+		var err error
+		account, err = l.repostiory.FindByCPF(ctx, CPF)
+		if err != nil {
+			if !isNotFound(err) {
+				return "", err
+			}
+
+			entity.CompareUnknownAccountSecret(input.Secret)
+			return entity.UNKNOWN_CPF_LOGIN_FAILURE, nil
+		}
+
+		if !account.SecretIsCorrect(input.Secret) {
+			return entity.WRONG_SECRET_LOGIN_FAILURE, nil
+		}
+
+		return "", nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if !account.SecretIsCorrect(input.Secret) {
-		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("secret is incorrect")
+	if !succeeded {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("cpf or secret is incorrect")
 	}
 
-	return NewLoginUseCaseOutput(&account, input.SecretJWT), nil
-
-}
+	enabled, err := l.twoFactor.IsEnabled(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
 
-type LoginUseCaseInput struct {
-	CPF       string `json:"cpf"`
-	Secret    string `json:"secret"`
-	SecretJWT string `json:"-"`
-}
+	if enabled {
+		return l.tokenIssuer.IssueMFAToken(&account, now)
+	}
 
-func NewLoginUseCaseInput(CPF string, secret string, secretJWT string) *LoginUseCaseInput {
-	return &LoginUseCaseInput{
-		CPF:       CPF,
-		Secret:    secret,
-		SecretJWT: secretJWT,
+	output, _, err := l.tokenIssuer.Issue(ctx, &account, "", now)
+	if err != nil {
+		return nil, err
 	}
-}
 
-type LoginUseCaseOutput struct {
-	Token string `json:"token"`
-}
+	return output, nil
 
-func NewLoginUseCaseOutput(account *entity.Account, secretJWT string) *LoginUseCaseOutput {
-	claims := jwt.MapClaims{}
-	claims["account_id"] = account.ID
-	claims["exp"] = time.Now().Add(time.Hour * 24).Unix()
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, _ := token.SignedString([]byte(secretJWT))
+type LoginUseCaseInput struct {
+	CPF    string `json:"cpf"`
+	Secret string `json:"secret"`
+	IP     string `json:"-"`
+}
 
-	return &LoginUseCaseOutput{
-		Token: tokenString,
+func NewLoginUseCaseInput(CPF string, secret string, IP string) *LoginUseCaseInput {
+	return &LoginUseCaseInput{
+		CPF:    CPF,
+		Secret: secret,
+		IP:     IP,
 	}
 }
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// LoginGuard protects the login against brute force. Failed logins are counted per CPF and per
+// IP, every failure makes the next attempt wait longer until the key is locked out, and each
+// failure is kept as an audit record.
+type LoginGuard struct {
+	loginAttemptRepository  entity.LoginAttemptRepository
+	loginThrottleRepository entity.LoginThrottleRepository
+	cpfPolicy               entity.LoginThrottlePolicy
+	ipPolicy                entity.LoginThrottlePolicy
+	entity.Repository
+}
+
+func NewLoginGuard(loginAttemptRepository entity.LoginAttemptRepository, loginThrottleRepository entity.LoginThrottleRepository, cpfPolicy entity.LoginThrottlePolicy, ipPolicy entity.LoginThrottlePolicy, repository entity.Repository) *LoginGuard {
+	return &LoginGuard{
+		loginAttemptRepository:  loginAttemptRepository,
+		loginThrottleRepository: loginThrottleRepository,
+		cpfPolicy:               cpfPolicy,
+		ipPolicy:                ipPolicy,
+		Repository:              repository,
+	}
+}
+
+type throttledKey struct {
+	key    string
+	policy entity.LoginThrottlePolicy
+}
+
+func (g *LoginGuard) keys(CPF string, IP string) []throttledKey {
+	keys := make([]throttledKey, 0, 2)
+
+	if CPF != "" {
+		keys = append(keys, throttledKey{key: entity.CPFLoginThrottleKey(CPF), policy: g.cpfPolicy})
+	}
+
+	if IP != "" {
+		keys = append(keys, throttledKey{key: entity.IPLoginThrottleKey(IP), policy: g.ipPolicy})
+	}
+
+	return keys
+}
+
+// Check rejects the attempt while the CPF or the IP has to wait, the rejection is audited too.
+// It does not count anything, attempts that check a secret go through Attempt.
+func (g *LoginGuard) Check(ctx context.Context, CPF string, IP string, now time.Time) error {
+	for _, key := range g.keys(CPF, IP) {
+		throttle, err := g.loginThrottleRepository.FindByKey(ctx, key.key)
+		if err != nil {
+			return err
+		}
+
+		err = g.reject(ctx, CPF, IP, key.policy.RetryAt(throttle), now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Attempt runs attempt with the failures of the CPF and of the IP locked until its outcome is
+// stored, so a burst of parallel attempts is checked one at a time instead of all of them
+// passing before the first failure is counted. attempt returns the reason of the failure, or an
+// empty reason when the secret is correct. The CPF is locked before the IP in every attempt, so
+// two attempts never wait on each other's keys.
+func (g *LoginGuard) Attempt(ctx context.Context, CPF string, IP string, now time.Time, attempt func() (entity.LoginFailureReason, error)) (bool, error) {
+	transaction, err := g.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = g.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = g.RollbackTx(transaction)
+		} else {
+			_ = g.CommitTx(transaction)
+		}
+	}()
+
+	keys := g.keys(CPF, IP)
+
+	for _, key := range keys {
+		var throttle entity.LoginThrottle
+		throttle, err = g.loginThrottleRepository.FindByKeyForUpdate(ctx, key.key, now, transaction)
+		if err != nil {
+			return false, err
+		}
+
+		err = g.reject(ctx, CPF, IP, key.policy.RetryAt(throttle), now)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	reason, err := attempt()
+	if err != nil {
+		return false, err
+	}
+
+	if reason == "" {
+		// the failures of the IP are kept, otherwise logging into an own account would reset
+		// the count of an IP guessing secrets of other accounts
+		if CPF != "" {
+			err = g.loginThrottleRepository.Reset(ctx, entity.CPFLoginThrottleKey(CPF), transaction)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	err = g.audit(ctx, CPF, IP, reason, now)
+	if err != nil {
+		return false, err
+	}
+
+	for _, key := range keys {
+		_, err = g.loginThrottleRepository.RegisterFailure(ctx, key.key, now, now.Add(-key.policy.Lockout), transaction)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// reject audits and returns the throttling error when the key has to wait until retryAt.
+func (g *LoginGuard) reject(ctx context.Context, CPF string, IP string, retryAt time.Time, now time.Time) error {
+	if !retryAt.After(now) {
+		return nil
+	}
+
+	err := g.audit(ctx, CPF, IP, entity.THROTTLED_LOGIN_FAILURE, now)
+	if err != nil {
+		return err
+	}
+
+	return entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).
+		Add("too many failed login attempts, try again later").
+		WithRetryAfter(retryAt.Sub(now))
+}
+
+func (g *LoginGuard) audit(ctx context.Context, CPF string, IP string, reason entity.LoginFailureReason, now time.Time) error {
+	loginAttempt, err := entity.NewLoginAttempt(CPF, IP, reason, &now)
+	if err != nil {
+		return err
+	}
+
+	return g.loginAttemptRepository.Create(ctx, loginAttempt)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type ILoginMFAUseCase interface {
+	Execute(ctx context.Context, input *LoginMFAUseCaseInput) (*TokenUseCaseOutput, error)
+}
+
+// LoginMFAUseCase is the second step of the login of accounts with two-factor authentication,
+// it trades the MFA token and a TOTP or recovery code for the session tokens.
+type LoginMFAUseCase struct {
+	repostiory  entity.AccountRepository
+	verifier    entity.TokenVerifier
+	tokenIssuer *TokenIssuer
+	loginGuard  *LoginGuard
+	twoFactor   *TwoFactor
+}
+
+func NewLoginMFAUseCase(repostiory entity.AccountRepository, verifier entity.TokenVerifier, tokenIssuer *TokenIssuer, loginGuard *LoginGuard, twoFactor *TwoFactor) *LoginMFAUseCase {
+	return &LoginMFAUseCase{
+		repostiory:  repostiory,
+		verifier:    verifier,
+		tokenIssuer: tokenIssuer,
+		loginGuard:  loginGuard,
+		twoFactor:   twoFactor,
+	}
+}
+
+func (l *LoginMFAUseCase) Execute(ctx context.Context, input *LoginMFAUseCaseInput) (*TokenUseCaseOutput, error) {
+	if input.MFAToken == "" || input.Code == "" {
+		return nil, entity.NewErrorHandler(entity.BAD_REQUEST).Add("mfa token and code cannot be empty")
+	}
+
+	claims, err := l.verifier.Verify(input.MFAToken)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("mfa token is invalid")
+	}
+
+	accountID, ok := claims["account_id"].(string)
+	if typ, _ := claims["typ"].(string); !ok || typ != string(entity.MFA_TOKEN_TYPE) {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("mfa token is invalid")
+	}
+
+	account, err := l.repostiory.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	err = l.loginGuard.Check(ctx, account.CPF, input.IP, now)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := l.twoFactor.Verify(ctx, account.ID, input.Code, true, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		err = l.loginGuard.audit(ctx, account.CPF, input.IP, entity.WRONG_TOTP_LOGIN_FAILURE, now)
+		if err != nil {
+			return nil, err
+		}
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("two-factor code is invalid")
+	}
+
+	output, _, err := l.tokenIssuer.Issue(ctx, &account, "", now)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+type LoginMFAUseCaseInput struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+	IP       string `json:"-"`
+}
+
+func NewLoginMFAUseCaseInput(mfaToken string, code string, IP string) *LoginMFAUseCaseInput {
+	return &LoginMFAUseCaseInput{
+		MFAToken: mfaToken,
+		Code:     code,
+		IP:       IP,
+	}
+}
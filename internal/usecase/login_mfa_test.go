@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetMFAToken(t *testing.T, account entity.Account) string {
+	output, err := GetTokenIssuer(mock.NewRefreshTokenRepositoryMock()).IssueMFAToken(&account, time.Now())
+	assert.Nil(t, err)
+	return output.MFAToken
+}
+
+func TestLoginMFAUseCase_Execute(t *testing.T) {
+	IP := "203.0.113.7"
+	account := mock.CreateAccount()
+
+	t.Run("Testing LoginMFAUseCase with a valid totp code", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, account.ID).Return(account, nil)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("Create", ctx, testify.Anything).Return(entity.RefreshToken{}, nil)
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginThrottleRepository.On("FindByKey", ctx, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		twoFactor, totpRepository, _, twoFactorThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, account.ID).Return(GetConfirmedTOTP(account.ID), nil)
+		totpRepository.On("UseStep", ctx, account.ID, testify.Anything).Return(true, nil)
+		twoFactorThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+account.ID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		twoFactorThrottleRepository.On("Reset", ctx, "totp:"+account.ID).Return(nil)
+
+		loginMFAUseCase := usecase.NewLoginMFAUseCase(repository, keySet, GetTokenIssuer(refreshTokenRepository), loginGuard, twoFactor)
+		output, err := loginMFAUseCase.Execute(ctx, usecase.NewLoginMFAUseCaseInput(GetMFAToken(t, account), GetCurrentTOTPCode(t), IP))
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, output.Token)
+		assert.NotEmpty(t, output.RefreshToken)
+		assert.False(t, output.MFARequired)
+		loginThrottleRepository.AssertCalled(t, "FindByKey", ctx, "cpf:"+account.CPF)
+		loginAttemptRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginMFAUseCase with an invalid code", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, account.ID).Return(account, nil)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginThrottleRepository.On("FindByKey", ctx, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+		twoFactor, totpRepository, _, twoFactorThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, account.ID).Return(GetConfirmedTOTP(account.ID), nil)
+		twoFactorThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+account.ID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		twoFactorThrottleRepository.On("Reset", ctx, "totp:"+account.ID).Return(nil)
+		twoFactorThrottleRepository.On("RegisterFailure", ctx, "totp:"+account.ID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		loginMFAUseCase := usecase.NewLoginMFAUseCase(repository, keySet, GetTokenIssuer(refreshTokenRepository), loginGuard, twoFactor)
+		output, err := loginMFAUseCase.Execute(ctx, usecase.NewLoginMFAUseCaseInput(GetMFAToken(t, account), "000000", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "two-factor code is invalid", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+
+		loginAttempt := loginAttemptRepository.Calls[0].Arguments.Get(1).(*entity.LoginAttempt)
+		assert.Equal(t, entity.WRONG_TOTP_LOGIN_FAILURE, loginAttempt.Reason)
+		refreshTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginMFAUseCase with an access token instead of an mfa token", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("Create", ctx, testify.Anything).Return(entity.RefreshToken{}, nil)
+		loginGuard, _, _ := GetLoginGuard()
+		twoFactor, _, _, _ := GetTwoFactor()
+
+		session, _, err := GetTokenIssuer(refreshTokenRepository).Issue(ctx, &account, "", time.Now())
+		assert.Nil(t, err)
+
+		loginMFAUseCase := usecase.NewLoginMFAUseCase(repository, keySet, GetTokenIssuer(refreshTokenRepository), loginGuard, twoFactor)
+		output, err := loginMFAUseCase.Execute(ctx, usecase.NewLoginMFAUseCaseInput(session.Token, "123456", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "mfa token is invalid", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertNotCalled(t, "FindByID", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginMFAUseCase with a malformed mfa token", func(t *testing.T) {
+		loginGuard, _, _ := GetLoginGuard()
+		twoFactor, _, _, _ := GetTwoFactor()
+
+		loginMFAUseCase := usecase.NewLoginMFAUseCase(mock.NewAccountRepositoryMock(), keySet, GetTokenIssuer(mock.NewRefreshTokenRepositoryMock()), loginGuard, twoFactor)
+		output, err := loginMFAUseCase.Execute(context.Background(), usecase.NewLoginMFAUseCaseInput("invalid", "123456", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "mfa token is invalid", err.Error())
+	})
+
+	t.Run("Testing LoginMFAUseCase without code", func(t *testing.T) {
+		loginGuard, _, _ := GetLoginGuard()
+		twoFactor, _, _, _ := GetTwoFactor()
+
+		loginMFAUseCase := usecase.NewLoginMFAUseCase(mock.NewAccountRepositoryMock(), keySet, GetTokenIssuer(mock.NewRefreshTokenRepositoryMock()), loginGuard, twoFactor)
+		output, err := loginMFAUseCase.Execute(context.Background(), usecase.NewLoginMFAUseCaseInput(GetMFAToken(t, account), "", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "mfa token and code cannot be empty", err.Error())
+		assert.Equal(t, entity.BAD_REQUEST, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing LoginMFAUseCase when the cpf is locked out", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		repository.On("FindByID", ctx, account.ID).Return(account, nil)
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		lastFailureAt := time.Now()
+		loginThrottleRepository.On("FindByKey", ctx, "cpf:"+account.CPF).Return(entity.LoginThrottle{Failures: 5, LastFailureAt: &lastFailureAt}, nil)
+		loginThrottleRepository.On("FindByKey", ctx, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+		twoFactor, totpRepository, _, _ := GetTwoFactor()
+
+		loginMFAUseCase := usecase.NewLoginMFAUseCase(repository, keySet, GetTokenIssuer(mock.NewRefreshTokenRepositoryMock()), loginGuard, twoFactor)
+		output, err := loginMFAUseCase.Execute(ctx, usecase.NewLoginMFAUseCaseInput(GetMFAToken(t, account), "123456", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, err.(*entity.ErrorHandler).TypeError)
+		totpRepository.AssertNotCalled(t, "FindByAccountID", testify.Anything, testify.Anything)
+	})
+}
@@ -3,85 +3,400 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/infra/security"
 	"lucassantoss1701/bank/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var keySet, _ = security.GenerateKeySet()
+
+func GetTokenIssuer(refreshTokenRepository entity.RefreshTokenRepository) *usecase.TokenIssuer {
+	return usecase.NewTokenIssuer(refreshTokenRepository, keySet, 15*time.Minute, 720*time.Hour)
+}
+
+func GetThrottleRepository() *mock.RepositoryMock {
+	transactionHandler := mock.NewTransactionHandlerMock()
+
+	repository := mock.NewRepositoryMock()
+	repository.On("BeginTx", testify.Anything).Return(transactionHandler, nil)
+	repository.On("CommitTx", transactionHandler).Return(nil)
+	repository.On("RollbackTx", transactionHandler).Return(nil)
+
+	return repository
+}
+
+func GetLoginGuard() (*usecase.LoginGuard, *mock.LoginAttemptRepositoryMock, *mock.LoginThrottleRepositoryMock) {
+	loginAttemptRepository := mock.NewLoginAttemptRepositoryMock()
+	loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+
+	loginGuard := usecase.NewLoginGuard(
+		loginAttemptRepository,
+		loginThrottleRepository,
+		entity.NewLoginThrottlePolicy(5, time.Second, time.Minute, 15*time.Minute),
+		entity.NewLoginThrottlePolicy(20, time.Second, time.Minute, 15*time.Minute),
+		GetThrottleRepository(),
+	)
+
+	return loginGuard, loginAttemptRepository, loginThrottleRepository
+}
+
+func GetTwoFactor() (*usecase.TwoFactor, *mock.TOTPRepositoryMock, *mock.RecoveryCodeRepositoryMock, *mock.LoginThrottleRepositoryMock) {
+	totpRepository := mock.NewTOTPRepositoryMock()
+	recoveryCodeRepository := mock.NewRecoveryCodeRepositoryMock()
+	loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+
+	twoFactor := usecase.NewTwoFactor(
+		totpRepository,
+		recoveryCodeRepository,
+		loginThrottleRepository,
+		entity.NewLoginThrottlePolicy(5, time.Second, time.Minute, 15*time.Minute),
+		GetThrottleRepository(),
+	)
+
+	return twoFactor, totpRepository, recoveryCodeRepository, loginThrottleRepository
+}
+
+func GetDisabledTwoFactor() *usecase.TwoFactor {
+	twoFactor, totpRepository, _, _ := GetTwoFactor()
+	totpRepository.On("FindByAccountID", testify.Anything, testify.Anything).Return(entity.TOTP{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found totp"))
+
+	return twoFactor
+}
+
+func GetAccountWithHashedSecret(t *testing.T) entity.Account {
+	account := mock.CreateAccount()
+
+	secretHashed, err := bcrypt.GenerateFromPassword([]byte(account.Secret), bcrypt.DefaultCost)
+	assert.Nil(t, err)
+
+	account.Secret = string(secretHashed)
+	return account
+}
+
 func TestLoginUseCase_Execute(t *testing.T) {
+	CPF := "34688151071"
+	IP := "203.0.113.7"
+
 	t.Run("Testing LoginUseCase when have success on login", func(t *testing.T) {
 		ctx := context.Background()
 		repository := mock.NewAccountRepositoryMock()
-		account := mock.CreateAccount()
-		loginUseCase := usecase.NewLoginUseCase(repository)
-
-		secretHashed, err := bcrypt.GenerateFromPassword([]byte(account.Secret), bcrypt.DefaultCost)
-		assert.Nil(t, err)
-
-		account.Secret = string(secretHashed)
+		account := GetAccountWithHashedSecret(t)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
 
-		CPF := "34688151071"
 		secret := "5e0542f964858f96ae7194fb2a7dd365"
 
 		repository.On("FindByCPF", ctx, CPF).Return(account, nil)
+		refreshTokenRepository.On("Create", ctx, testify.Anything).Return(entity.RefreshToken{}, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "cpf:"+CPF).Return(nil)
 
-		input := usecase.NewLoginUseCaseInput(CPF, secret, "")
+		input := usecase.NewLoginUseCaseInput(CPF, secret, IP)
 
 		output, err := loginUseCase.Execute(ctx, input)
 		assert.Nil(t, err)
 
 		assert.NotEmpty(t, output)
 		assert.NotEmpty(t, output.Token)
+		assert.NotEmpty(t, output.RefreshToken)
+		assert.Equal(t, 900, output.ExpiresIn)
+
+		storedRefreshToken := refreshTokenRepository.Calls[0].Arguments.Get(1).(*entity.RefreshToken)
+		assert.Equal(t, entity.HashRefreshToken(output.RefreshToken), storedRefreshToken.TokenHash)
+		assert.Equal(t, account.ID, storedRefreshToken.AccountID)
+
+		claims, err := keySet.Verify(output.Token)
+		assert.Nil(t, err)
+		assert.Equal(t, account.ID, claims["account_id"])
+		assert.Equal(t, "CUSTOMER", claims["role"])
+		assert.Equal(t, storedRefreshToken.FamilyID, claims["sid"])
+		assert.NotEmpty(t, claims["jti"])
+
+		loginThrottleRepository.AssertCalled(t, "FindByKeyForUpdate", ctx, "cpf:"+CPF, testify.Anything)
+		loginThrottleRepository.AssertCalled(t, "FindByKeyForUpdate", ctx, "ip:"+IP, testify.Anything)
+		loginThrottleRepository.AssertCalled(t, "Reset", ctx, "cpf:"+CPF)
 	})
 
 	t.Run("Testing LoginUseCase when repository returns an error", func(t *testing.T) {
 		ctx := context.Background()
 		repository := mock.NewAccountRepositoryMock()
-		account := mock.CreateAccount()
-		loginUseCase := usecase.NewLoginUseCase(repository)
-
-		secretHashed, err := bcrypt.GenerateFromPassword([]byte(account.Secret), bcrypt.DefaultCost)
-		assert.Nil(t, err)
-
-		account.Secret = string(secretHashed)
+		account := GetAccountWithHashedSecret(t)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
 
-		CPF := "34688151071"
 		secret := "5e0542f964858f96ae7194fb2a7dd365"
 
 		repository.On("FindByCPF", ctx, CPF).Return(account, errors.New("error on find account"))
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
 
-		input := usecase.NewLoginUseCaseInput(CPF, secret, "")
+		input := usecase.NewLoginUseCaseInput(CPF, secret, IP)
 
-		_, err = loginUseCase.Execute(ctx, input)
+		_, err := loginUseCase.Execute(ctx, input)
 		assert.NotNil(t, err)
 
 		assert.Equal(t, "error on find account", err.Error())
+		loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
 	})
 
 	t.Run("Testing LoginUseCase when secret is incorret", func(t *testing.T) {
 		ctx := context.Background()
 		repository := mock.NewAccountRepositoryMock()
-		account := mock.CreateAccount()
-		loginUseCase := usecase.NewLoginUseCase(repository)
-
-		secretHashed, err := bcrypt.GenerateFromPassword([]byte(account.Secret), bcrypt.DefaultCost)
-		assert.Nil(t, err)
-
-		account.Secret = string(secretHashed)
+		account := GetAccountWithHashedSecret(t)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
 
-		CPF := "34688151071"
 		secret := "incorret secret"
 
 		repository.On("FindByCPF", ctx, CPF).Return(account, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
 
-		input := usecase.NewLoginUseCaseInput(CPF, secret, "")
+		input := usecase.NewLoginUseCaseInput(CPF, secret, IP)
 
-		_, err = loginUseCase.Execute(ctx, input)
+		_, err := loginUseCase.Execute(ctx, input)
 		assert.NotNil(t, err)
 
-		assert.Equal(t, "secret is incorrect", err.Error())
+		assert.Equal(t, "cpf or secret is incorrect", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).GetTypeError())
+		refreshTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+
+		loginAttempt := loginAttemptRepository.Calls[0].Arguments.Get(1).(*entity.LoginAttempt)
+		assert.Equal(t, CPF, loginAttempt.CPF)
+		assert.Equal(t, IP, loginAttempt.IP)
+		assert.Equal(t, entity.WRONG_SECRET_LOGIN_FAILURE, loginAttempt.Reason)
+
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "cpf:"+CPF, testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "ip:"+IP, testify.Anything, testify.Anything)
+
+		now := loginThrottleRepository.Calls[2].Arguments.Get(2).(time.Time)
+		resetBefore := loginThrottleRepository.Calls[2].Arguments.Get(3).(time.Time)
+		assert.Equal(t, 15*time.Minute, now.Sub(resetBefore))
+	})
+
+	t.Run("Testing LoginUseCase answers an unknown CPF like a wrong secret", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
+
+		repository.On("FindByCPF", ctx, CPF).Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account"))
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		_, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput(CPF, "123456", IP))
+
+		assert.Equal(t, "cpf or secret is incorrect", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).GetTypeError())
+
+		loginAttempt := loginAttemptRepository.Calls[0].Arguments.Get(1).(*entity.LoginAttempt)
+		assert.Equal(t, entity.UNKNOWN_CPF_LOGIN_FAILURE, loginAttempt.Reason)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "cpf:"+CPF, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginUseCase with a malformed CPF only counts the IP", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
+
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		_, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput("' OR 1=1 --", "123456", IP))
+
+		assert.Equal(t, "cpf or secret is incorrect", err.Error())
+		repository.AssertNotCalled(t, "FindByCPF", testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertNumberOfCalls(t, "RegisterFailure", 1)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "ip:"+IP, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginUseCase when the CPF is throttled", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
+
+		lastFailureAt := time.Now()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "cpf:"+CPF, testify.Anything).Return(entity.LoginThrottle{Key: "cpf:" + CPF, Failures: 5, LastFailureAt: &lastFailureAt}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		_, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput(CPF, "5e0542f964858f96ae7194fb2a7dd365", IP))
+
+		errorHandler := err.(*entity.ErrorHandler)
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, errorHandler.GetTypeError())
+		assert.Equal(t, "too many failed login attempts, try again later", errorHandler.Error())
+		assert.InDelta(t, (15 * time.Minute).Seconds(), errorHandler.RetryAfter.Seconds(), 1)
+
+		loginAttempt := loginAttemptRepository.Calls[0].Arguments.Get(1).(*entity.LoginAttempt)
+		assert.Equal(t, entity.THROTTLED_LOGIN_FAILURE, loginAttempt.Reason)
+		repository.AssertNotCalled(t, "FindByCPF", testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginUseCase when the IP is waiting the backoff", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, loginAttemptRepository, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
+
+		lastFailureAt := time.Now()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "cpf:"+CPF, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "ip:"+IP, testify.Anything).Return(entity.LoginThrottle{Key: "ip:" + IP, Failures: 4, LastFailureAt: &lastFailureAt}, nil)
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		_, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput(CPF, "5e0542f964858f96ae7194fb2a7dd365", IP))
+
+		errorHandler := err.(*entity.ErrorHandler)
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, errorHandler.GetTypeError())
+		assert.InDelta(t, 8, errorHandler.RetryAfter.Seconds(), 1)
+		repository.AssertNotCalled(t, "FindByCPF", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginUseCase when refresh token is not stored", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		account := GetAccountWithHashedSecret(t)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
+
+		repository.On("FindByCPF", ctx, CPF).Return(account, nil)
+		refreshTokenRepository.On("Create", ctx, testify.Anything).Return(entity.RefreshToken{}, errors.New("connection closed"))
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, testify.Anything).Return(nil)
+
+		output, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput(CPF, "5e0542f964858f96ae7194fb2a7dd365", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+
+	t.Run("Testing LoginUseCase when throttle repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, GetDisabledTwoFactor())
+
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("connection closed"))
+
+		output, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput(CPF, "5e0542f964858f96ae7194fb2a7dd365", IP))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertNotCalled(t, "FindByCPF", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LoginUseCase returns an mfa token when two-factor authentication is enabled", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewAccountRepositoryMock()
+		account := GetAccountWithHashedSecret(t)
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		loginGuard, _, loginThrottleRepository := GetLoginGuard()
+		twoFactor, totpRepository, _, _ := GetTwoFactor()
+		loginUseCase := usecase.NewLoginUseCase(repository, GetTokenIssuer(refreshTokenRepository), loginGuard, twoFactor)
+
+		repository.On("FindByCPF", ctx, CPF).Return(account, nil)
+		totpRepository.On("FindByAccountID", ctx, account.ID).Return(GetConfirmedTOTP(account.ID), nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "cpf:"+CPF).Return(nil)
+
+		output, err := loginUseCase.Execute(ctx, usecase.NewLoginUseCaseInput(CPF, "5e0542f964858f96ae7194fb2a7dd365", IP))
+
+		assert.Nil(t, err)
+		assert.True(t, output.MFARequired)
+		assert.Empty(t, output.Token)
+		assert.Empty(t, output.RefreshToken)
+		assert.Equal(t, 300, output.ExpiresIn)
+
+		claims, err := keySet.Verify(output.MFAToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "mfa", claims["typ"])
+		assert.Equal(t, account.ID, claims["account_id"])
+		refreshTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+}
+
+func TestLoginGuard_Attempt(t *testing.T) {
+	CPF := "34688151071"
+	IP := "203.0.113.7"
+	policy := entity.NewLoginThrottlePolicy(5, time.Second, time.Minute, 15*time.Minute)
+
+	t.Run("Testing Attempt counts the failure before releasing the throttles", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now()
+
+		loginAttemptRepository := mock.NewLoginAttemptRepositoryMock()
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, testify.Anything, now).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, testify.Anything, now, now.Add(-15*time.Minute)).Return(entity.LoginThrottle{Failures: 1}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		loginGuard := usecase.NewLoginGuard(loginAttemptRepository, loginThrottleRepository, policy, policy, repository)
+		succeeded, err := loginGuard.Attempt(ctx, CPF, IP, now, func() (entity.LoginFailureReason, error) {
+			loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+			repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+			return entity.WRONG_SECRET_LOGIN_FAILURE, nil
+		})
+
+		assert.Nil(t, err)
+		assert.False(t, succeeded)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "cpf:"+CPF, now, now.Add(-15*time.Minute))
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "ip:"+IP, now, now.Add(-15*time.Minute))
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing Attempt does not run the attempt while the key waits", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now()
+		lastFailureAt := now.Add(-time.Minute)
+
+		loginAttemptRepository := mock.NewLoginAttemptRepositoryMock()
+		loginAttemptRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "cpf:"+CPF, now).Return(entity.LoginThrottle{Failures: 5, LastFailureAt: &lastFailureAt}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		attempted := false
+		loginGuard := usecase.NewLoginGuard(loginAttemptRepository, loginThrottleRepository, policy, policy, repository)
+		succeeded, err := loginGuard.Attempt(ctx, CPF, IP, now, func() (entity.LoginFailureReason, error) {
+			attempted = true
+			return "", nil
+		})
+
+		assert.False(t, succeeded)
+		assert.False(t, attempted)
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, err.(*entity.ErrorHandler).GetTypeError())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+		loginThrottleRepository.AssertNotCalled(t, "FindByKeyForUpdate", ctx, "ip:"+IP, now)
 	})
 }
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type ILogoutUseCase interface {
+	Execute(ctx context.Context, input *LogoutUseCaseInput) error
+}
+
+// LogoutUseCase ends the session of the access token: the token goes to the denylist until
+// it expires and the refresh tokens of its family are revoked.
+type LogoutUseCase struct {
+	refreshTokenRepository entity.RefreshTokenRepository
+	revokedTokenRepository entity.RevokedTokenRepository
+}
+
+func NewLogoutUseCase(refreshTokenRepository entity.RefreshTokenRepository, revokedTokenRepository entity.RevokedTokenRepository) *LogoutUseCase {
+	return &LogoutUseCase{
+		refreshTokenRepository: refreshTokenRepository,
+		revokedTokenRepository: revokedTokenRepository,
+	}
+}
+
+func (l *LogoutUseCase) Execute(ctx context.Context, input *LogoutUseCaseInput) error {
+	revokedToken, err := entity.NewRevokedToken(input.TokenID, input.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	err = l.revokedTokenRepository.Create(ctx, revokedToken)
+	if err != nil {
+		return err
+	}
+
+	if input.SessionID == "" {
+		return nil
+	}
+
+	return l.refreshTokenRepository.RevokeFamily(ctx, input.SessionID, time.Now())
+}
+
+type LogoutUseCaseInput struct {
+	TokenID   string
+	SessionID string
+	ExpiresAt *time.Time
+}
+
+func NewLogoutUseCaseInput(tokenID string, sessionID string, expiresAt *time.Time) *LogoutUseCaseInput {
+	return &LogoutUseCaseInput{
+		TokenID:   tokenID,
+		SessionID: sessionID,
+		ExpiresAt: expiresAt,
+	}
+}
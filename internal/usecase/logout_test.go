@@ -0,0 +1,65 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestLogoutUseCase_Execute(t *testing.T) {
+	expiresAt := time.Date(2023, 8, 5, 8, 37, 00, 00, time.UTC)
+
+	t.Run("Testing LogoutUseCase revokes the access token and its session", func(t *testing.T) {
+		ctx := context.Background()
+
+		revokedTokenRepository := mock.NewRevokedTokenRepositoryMock()
+		revokedTokenRepository.On("Create", ctx, &entity.RevokedToken{JTI: "fc84682a-3045-4bdf-b91c-10be19f89452", ExpiresAt: &expiresAt}).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("RevokeFamily", ctx, "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", testify.Anything).Return(nil)
+
+		logoutUseCase := usecase.NewLogoutUseCase(refreshTokenRepository, revokedTokenRepository)
+
+		err := logoutUseCase.Execute(ctx, usecase.NewLogoutUseCaseInput("fc84682a-3045-4bdf-b91c-10be19f89452", "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", &expiresAt))
+
+		assert.Nil(t, err)
+		revokedTokenRepository.AssertExpectations(t)
+		refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Testing LogoutUseCase when denylist returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		revokedTokenRepository := mock.NewRevokedTokenRepositoryMock()
+		revokedTokenRepository.On("Create", ctx, testify.Anything).Return(errors.New("connection closed"))
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+
+		logoutUseCase := usecase.NewLogoutUseCase(refreshTokenRepository, revokedTokenRepository)
+
+		err := logoutUseCase.Execute(ctx, usecase.NewLogoutUseCaseInput("fc84682a-3045-4bdf-b91c-10be19f89452", "0b8b418c-da4a-4856-8b6a-eec63d6c7a6d", &expiresAt))
+
+		assert.Equal(t, "connection closed", err.Error())
+		refreshTokenRepository.AssertNotCalled(t, "RevokeFamily", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing LogoutUseCase with a token without jti", func(t *testing.T) {
+		ctx := context.Background()
+
+		revokedTokenRepository := mock.NewRevokedTokenRepositoryMock()
+
+		logoutUseCase := usecase.NewLogoutUseCase(mock.NewRefreshTokenRepositoryMock(), revokedTokenRepository)
+
+		err := logoutUseCase.Execute(ctx, usecase.NewLogoutUseCaseInput("", "", &expiresAt))
+
+		assert.Equal(t, "jti cannot be empty", err.Error())
+		revokedTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+}
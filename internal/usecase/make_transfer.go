@@ -3,54 +3,141 @@ package usecase
 import (
 	"context"
 	"lucassantoss1701/bank/internal/entity"
+	"sort"
+	"strings"
 	"time"
 )
 
 type IMakeTransferUseCase interface {
 	Execute(ctx context.Context, input *MakeTransferUseCaseInput) (*MakeTransferUseCaseOutput, error)
+	ExecuteAtomically(ctx context.Context, inputs []*MakeTransferUseCaseInput) ([]*MakeTransferUseCaseOutput, int, error)
 }
 
 type MakeTransferUseCase struct {
 	accountRepository  entity.AccountRepository
 	transferRepository entity.TransferRepository
+	ledgerRepository   entity.LedgerRepository
+	twoFactorRule      *TransferTwoFactorRule
+	exchangeRateRule   *TransferExchangeRateRule
+	limitRule          *TransferLimitRule
+	feeRule            *TransferFeeRule
+	destinationRule    *TransferDestinationRule
 	entity.Repository
 }
 
-func NewMakeTransferUseCase(accountRepository entity.AccountRepository, transferRepository entity.TransferRepository, repository entity.Repository) *MakeTransferUseCase {
+func NewMakeTransferUseCase(accountRepository entity.AccountRepository, transferRepository entity.TransferRepository, ledgerRepository entity.LedgerRepository, twoFactorRule *TransferTwoFactorRule, exchangeRateRule *TransferExchangeRateRule, limitRule *TransferLimitRule, feeRule *TransferFeeRule, destinationRule *TransferDestinationRule, repository entity.Repository) *MakeTransferUseCase {
 	return &MakeTransferUseCase{
 		accountRepository:  accountRepository,
 		transferRepository: transferRepository,
+		ledgerRepository:   ledgerRepository,
+		twoFactorRule:      twoFactorRule,
+		exchangeRateRule:   exchangeRateRule,
+		limitRule:          limitRule,
+		feeRule:            feeRule,
+		destinationRule:    destinationRule,
 		Repository:         repository,
 	}
 }
 
 func (m *MakeTransferUseCase) Execute(ctx context.Context, input *MakeTransferUseCaseInput) (*MakeTransferUseCaseOutput, error) {
 
-	originAccount, err := m.accountRepository.FindByID(ctx, input.OriginAccount.ID)
+	err := m.destinationRule.Resolve(ctx, input.OriginAccount.ID, &input.DestinationAccount)
 	if err != nil {
 		return nil, err
 	}
 
-	destinationAccount, err := m.accountRepository.FindByID(ctx, input.DestinationAccount.ID)
+	err = m.twoFactorRule.Check(ctx, input.OriginAccount.ID, input.Amount, input.TOTPCode, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	transfer, err := entity.NewTransfer(input.ID, &originAccount, &destinationAccount, input.Amount, input.CreatedAt)
+	transfer, err := m.makeTransfer(ctx, input)
 	if err != nil {
+		if transfer != nil {
+			m.recordFailure(ctx, transfer, err)
+		}
+
 		return nil, err
 	}
 
-	err = transfer.MakeTransfer()
+	return NewMakeTransferUseCaseOutput(transfer), nil
+}
+
+// makeTransfer returns the transfer it built along with the error, when it got that far, so
+// the failed attempt can be recorded.
+func (m *MakeTransferUseCase) makeTransfer(ctx context.Context, input *MakeTransferUseCaseInput) (*entity.Transfer, error) {
+	transaction, err := m.BeginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	transaction, err := m.BeginTx(ctx)
+	defer func() {
+		if r := recover(); r != nil {
+			_ = m.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = m.RollbackTx(transaction)
+		} else {
+			_ = m.CommitTx(transaction)
+		}
+	}()
+
+	lockedAccounts, err := lockAccounts(ctx, m.accountRepository, transaction, input.OriginAccount.ID, input.DestinationAccount.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	originAccount := lockedAccounts[input.OriginAccount.ID]
+	destinationAccount := lockedAccounts[input.DestinationAccount.ID]
+
+	transfer, err := m.transfer(ctx, transaction, input, &originAccount, &destinationAccount)
+	return transfer, err
+}
+
+// ExecuteAtomically makes the transfers in order inside one transaction, all of them or none.
+// The accounts of every transfer are locked before the first one is made, so the balances
+// move from one transfer to the next. When a transfer fails the whole transaction is rolled
+// back and the index of the failed input is returned with the error, -1 when the failure is
+// not of a single transfer.
+func (m *MakeTransferUseCase) ExecuteAtomically(ctx context.Context, inputs []*MakeTransferUseCaseInput) ([]*MakeTransferUseCaseOutput, int, error) {
+	for i, input := range inputs {
+		err := m.destinationRule.Resolve(ctx, input.OriginAccount.ID, &input.DestinationAccount)
+		if err != nil {
+			return nil, i, err
+		}
+
+		err = m.twoFactorRule.Check(ctx, input.OriginAccount.ID, input.Amount, input.TOTPCode, time.Now())
+		if err != nil {
+			return nil, i, err
+		}
+	}
+
+	transfers, failed, err := m.makeTransfers(ctx, inputs)
+	if err != nil {
+		if failed >= 0 && transfers[failed] != nil {
+			m.recordFailure(ctx, transfers[failed], err)
+		}
+
+		return nil, failed, err
+	}
+
+	outputs := make([]*MakeTransferUseCaseOutput, len(transfers))
+	for i, transfer := range transfers {
+		outputs[i] = NewMakeTransferUseCaseOutput(transfer)
+	}
+
+	return outputs, -1, nil
+}
+
+// makeTransfers returns, when a transfer fails, the transfers built so far along with the
+// index of the failed one.
+func (m *MakeTransferUseCase) makeTransfers(ctx context.Context, inputs []*MakeTransferUseCaseInput) (transfers []*entity.Transfer, failed int, err error) {
+	transaction, err := m.BeginTx(ctx)
+	if err != nil {
+		return nil, -1, err
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			_ = m.RollbackTx(transaction)
@@ -63,58 +150,173 @@ func (m *MakeTransferUseCase) Execute(ctx context.Context, input *MakeTransferUs
 		}
 	}()
 
-	createdTransfer, err := m.transferRepository.Create(ctx, transfer, transaction)
+	IDs := make([]string, 0, 2*len(inputs))
+	for _, input := range inputs {
+		IDs = append(IDs, input.OriginAccount.ID, input.DestinationAccount.ID)
+	}
+
+	lockedAccounts, err := lockAccounts(ctx, m.accountRepository, transaction, IDs...)
 	if err != nil {
-		return nil, err
+		return nil, -1, err
+	}
+
+	// every transfer moves the same account values, so each one sees the balances left by the previous
+	accounts := make(map[string]*entity.Account, len(lockedAccounts))
+	for ID := range lockedAccounts {
+		account := lockedAccounts[ID]
+		accounts[ID] = &account
+	}
+
+	transfers = make([]*entity.Transfer, len(inputs))
+	for i, input := range inputs {
+		transfers[i], err = m.transfer(ctx, transaction, input, accounts[input.OriginAccount.ID], accounts[input.DestinationAccount.ID])
+		if err != nil {
+			return transfers, i, err
+		}
 	}
 
-	originAccount, err = m.accountRepository.UpdateBalance(ctx, transfer.OriginAccount.ID, transfer.OriginAccount.Balance, transaction)
+	return transfers, -1, nil
+}
+
+// transfer makes one transfer between accounts already locked by the transaction, it returns
+// the transfer it built along with the error when it got that far.
+func (m *MakeTransferUseCase) transfer(ctx context.Context, transaction entity.TransactionHandler, input *MakeTransferUseCaseInput, originAccount *entity.Account, destinationAccount *entity.Account) (*entity.Transfer, error) {
+	transfer, err := entity.NewTransfer(input.ID, originAccount, destinationAccount, input.Amount, input.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	destinationAccount, err = m.accountRepository.UpdateBalance(ctx, transfer.DestinationAccount.ID, transfer.DestinationAccount.Balance, transaction)
+	err = m.limitRule.Check(ctx, transfer, transaction)
 	if err != nil {
-		return nil, err
+		return transfer, err
+	}
+
+	err = m.feeRule.Apply(ctx, transfer, transaction)
+	if err != nil {
+		return transfer, err
+	}
+
+	if transfer.Amount.Currency != destinationAccount.Currency() {
+		var exchangeRate entity.ExchangeRate
+		exchangeRate, err = m.exchangeRateRule.Quote(ctx, transfer.Amount.Currency, destinationAccount.Currency(), time.Now())
+		if err != nil {
+			return transfer, err
+		}
+
+		err = transfer.ApplyExchangeRate(exchangeRate)
+		if err != nil {
+			return transfer, err
+		}
+	}
+
+	err = transfer.MakeTransfer()
+	if err != nil {
+		return transfer, err
+	}
+
+	createdTransfer, err := m.transferRepository.Create(ctx, transfer, transaction)
+	if err != nil {
+		return transfer, err
+	}
+
+	entry, err := entity.NewTransferJournalEntry(transfer)
+	if err != nil {
+		return transfer, err
+	}
+
+	_, err = m.ledgerRepository.Post(ctx, entry, transaction)
+	if err != nil {
+		return transfer, err
 	}
 
-	createdTransfer.OriginAccount = &originAccount
-	createdTransfer.DestinationAccount = &destinationAccount
+	createdTransfer.OriginAccount = transfer.OriginAccount
+	createdTransfer.DestinationAccount = transfer.DestinationAccount
 
-	output := NewMakeTransferUseCaseOutput(&createdTransfer)
+	return &createdTransfer, nil
+}
+
+// recordFailure keeps the failed attempt for audit, outside of the rolled back transaction. Only
+// transfers that are still pending can fail, and the caller gets the error of the transfer
+// whether the attempt is recorded or not.
+func (m *MakeTransferUseCase) recordFailure(ctx context.Context, transfer *entity.Transfer, failure error) {
+	if transfer.Fail(failure) != nil {
+		return
+	}
 
-	return output, nil
+	_, _ = m.transferRepository.Create(ctx, transfer)
+}
+
+// lockAccounts reads the accounts with a row lock inside the transaction. The locks are
+// always acquired in ascending ID order so two opposite transfers cannot deadlock each other.
+func lockAccounts(ctx context.Context, accountRepository entity.AccountRepository, transaction entity.TransactionHandler, IDs ...string) (map[string]entity.Account, error) {
+	sortedIDs := make([]string, len(IDs))
+	copy(sortedIDs, IDs)
+	sort.Strings(sortedIDs)
+
+	accounts := make(map[string]entity.Account, len(sortedIDs))
+	for _, ID := range sortedIDs {
+		if _, ok := accounts[ID]; ok {
+			continue
+		}
+
+		account, err := accountRepository.FindByIDForUpdate(ctx, ID, transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[ID] = account
+	}
+
+	return accounts, nil
 }
 
 type MakeTransferUseCaseInput struct {
-	ID                 string                          `json:"-"`
-	OriginAccount      MakeTransferUseCaseAccountInput `json:"-"`
-	DestinationAccount MakeTransferUseCaseAccountInput `json:"destination_account"`
-	Amount             int                             `json:"amount"`
-	CreatedAt          *time.Time                      `json:"-"`
+	ID                 string                              `json:"-"`
+	OriginAccount      MakeTransferUseCaseAccountInput     `json:"-"`
+	DestinationAccount MakeTransferUseCaseDestinationInput `json:"destination_account"`
+	Amount             entity.Money                        `json:"amount"`
+	TOTPCode           string                              `json:"totp_code,omitempty"`
+	CreatedAt          *time.Time                          `json:"-"`
 }
 
 type MakeTransferUseCaseAccountInput struct {
 	ID string
 }
 
-func NewMakeTransferUseCaseInput(ID string, originAccountID string, destinationAccountID string, amount int, createdAt *time.Time) *MakeTransferUseCaseInput {
+// MakeTransferUseCaseDestinationInput informs the destination account by its ID, by one of its
+// keys or by a beneficiary saved by the origin account.
+type MakeTransferUseCaseDestinationInput struct {
+	ID            string
+	Key           string `example:"lucas@example.com"`
+	BeneficiaryID string `json:"beneficiary_id"`
+}
+
+func NewMakeTransferUseCaseInput(ID string, originAccountID string, destinationAccountID string, amount entity.Money, TOTPCode string, createdAt *time.Time) *MakeTransferUseCaseInput {
 	return &MakeTransferUseCaseInput{
 		ID: ID,
 		OriginAccount: MakeTransferUseCaseAccountInput{
 			ID: originAccountID,
 		},
-		DestinationAccount: MakeTransferUseCaseAccountInput{
+		DestinationAccount: MakeTransferUseCaseDestinationInput{
 			ID: destinationAccountID,
 		},
 		Amount:    amount,
+		TOTPCode:  TOTPCode,
 		CreatedAt: createdAt,
 	}
 }
 
 type MakeTransferUseCaseOutput struct {
 	ID                 string                     `json:"id"`
-	Amount             int                        `json:"amount"`
+	Amount             entity.Money               `json:"amount"`
+	DestinationAmount  entity.Money               `json:"destination_amount"`
+	ExchangeRate       string                     `json:"exchange_rate"`
+	ReversalOf         string                     `json:"reversal_of,omitempty"`
+	ReversedAmount     entity.Money               `json:"reversed_amount"`
+	Fee                entity.Money               `json:"fee"`
+	Fees               []MakeTransferUseCaseFee   `json:"fees"`
+	Status             string                     `json:"status"`
+	FailureReason      string                     `json:"failure_reason,omitempty"`
 	OriginAccount      MakeTransferUseCaseAccount `json:"origin_account"`
 	DestinationAccount MakeTransferUseCaseAccount `json:"destination_account"`
 	CreatedAt          string                     `json:"created_at"`
@@ -125,11 +327,42 @@ type MakeTransferUseCaseAccount struct {
 	Name string `json:"name"`
 }
 
+// MakeTransferUseCaseFee is the part of the fee charged by one rule, waived fees are within the
+// free quota of the rule.
+type MakeTransferUseCaseFee struct {
+	Rule   string       `json:"rule"`
+	Type   string       `json:"type"`
+	Amount entity.Money `json:"amount"`
+	Waived bool         `json:"waived"`
+}
+
+func NewMakeTransferUseCaseFees(fees []entity.TransferFee) []MakeTransferUseCaseFee {
+	output := make([]MakeTransferUseCaseFee, 0, len(fees))
+	for _, fee := range fees {
+		output = append(output, MakeTransferUseCaseFee{
+			Rule:   fee.Rule,
+			Type:   strings.ToLower(string(fee.Type)),
+			Amount: fee.Amount,
+			Waived: fee.Waived,
+		})
+	}
+
+	return output
+}
+
 func NewMakeTransferUseCaseOutput(transfer *entity.Transfer) *MakeTransferUseCaseOutput {
 	return &MakeTransferUseCaseOutput{
-		ID:        transfer.ID,
-		Amount:    transfer.Amount,
-		CreatedAt: transfer.CreatedAt.Format(time.RFC3339),
+		ID:                transfer.ID,
+		Amount:            transfer.Amount,
+		DestinationAmount: transfer.DestinationAmount,
+		ExchangeRate:      transfer.ExchangeRate,
+		ReversalOf:        transfer.ReversalOf,
+		ReversedAmount:    transfer.ReversedAmount,
+		Fee:               transfer.Fee,
+		Fees:              NewMakeTransferUseCaseFees(transfer.Fees),
+		Status:            strings.ToLower(string(transfer.Status)),
+		FailureReason:     transfer.FailureReason,
+		CreatedAt:         transfer.CreatedAt.Format(time.RFC3339),
 		OriginAccount: MakeTransferUseCaseAccount{
 			ID:   transfer.OriginAccount.ID,
 			Name: transfer.OriginAccount.Name,
@@ -2,10 +2,12 @@ package usecase_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"lucassantoss1701/bank/internal/entity"
 	"lucassantoss1701/bank/internal/entity/mock"
 	"lucassantoss1701/bank/internal/usecase"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,8 +20,8 @@ func GetBaseOriginAccount(t *testing.T) *entity.Account {
 	originAccountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
 	originAccountName := "lucas"
 	originAccountCPF := "52849254088"
-	originAccountsecret := "4578405"
-	originAccountBalance := 100
+	originAccountsecret := "lucas4578405"
+	originAccountBalance := entity.NewMoney(100, entity.BRL)
 	originAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	originAccount, err := entity.NewAccount(originAccountID, originAccountName, originAccountCPF, originAccountsecret, originAccountBalance, &originAccountCreatedAt)
 
@@ -32,8 +34,8 @@ func GetBaseDestinationAccount(t *testing.T) *entity.Account {
 	destionationAccountID := "d18551d3-cf13-49ec-b1dc-741a1f8715f6"
 	destionationAccountName := "joao"
 	destionationAccountCPF := "35768297090"
-	destionationAccountSecret := "744637"
-	destionationAccountBalance := 200
+	destionationAccountSecret := "jaque744637"
+	destionationAccountBalance := entity.NewMoney(200, entity.BRL)
 	destionationAccountCreatedAt := time.Date(2023, 8, 5, 8, 22, 00, 00, time.UTC)
 	destinationAccount, err := entity.NewAccount(destionationAccountID, destionationAccountName, destionationAccountCPF, destionationAccountSecret, destionationAccountBalance, &destionationAccountCreatedAt)
 
@@ -49,7 +51,7 @@ func ReturnTransferPointer(ctx context.Context, transfer *entity.Transfer, trans
 func TestMakeTransferUseCase_Execute(t *testing.T) {
 	t.Run("Testing MakeTransferUseCase when have success on make transfer", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
@@ -57,14 +59,12 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		originAccountAfterTransfer := *originAccount
 		destinationAccountAfterTransfer := *destinationAccount
 
-		originAccountAfterTransfer.Balance -= amount      // NewBalance = 50
-		destinationAccountAfterTransfer.Balance += amount // NewBalance  = 250
+		originAccountAfterTransfer.Balance, _ = originAccountAfterTransfer.Balance.Sub(amount)           // NewBalance = 50
+		destinationAccountAfterTransfer.Balance, _ = destinationAccountAfterTransfer.Balance.Add(amount) // NewBalance  = 250
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
-		accountRepository.On("UpdateBalance", ctx, originAccount.ID, originAccountAfterTransfer.Balance, testify.Anything).Return(originAccountAfterTransfer, nil)
-		accountRepository.On("UpdateBalance", ctx, destinationAccount.ID, destinationAccountAfterTransfer.Balance, testify.Anything).Return(destinationAccountAfterTransfer, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
 
 		transactionHandler := mock.NewTransactionHandlerMock()
 
@@ -81,10 +81,15 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		transferAfterTransaction := *transfer
 		transferAfterTransaction.OriginAccount = &originAccountAfterTransfer
 		transferAfterTransaction.DestinationAccount = &destinationAccountAfterTransfer
+		transferAfterTransaction.Status = entity.COMPLETED_TRANSFER
+		transferAfterTransaction.StatusHistory = append(transfer.StatusHistory, entity.TransferStatusChange{Status: entity.COMPLETED_TRANSFER, ChangedAt: &createdAt})
 		transferRepository.On("Create", ctx, &transferAfterTransaction, testify.Anything).Return(transferAfterTransaction, nil)
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.Nil(t, err)
@@ -97,162 +102,287 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		assert.Equal(t, originAccount.ID, output.OriginAccount.ID)
 		assert.Equal(t, originAccount.Name, output.OriginAccount.Name)
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
-		accountRepository.AssertCalled(t, "UpdateBalance", ctx, originAccount.ID, 50, testify.Anything)
-		accountRepository.AssertCalled(t, "UpdateBalance", ctx, destinationAccount.ID, 250, testify.Anything)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return entry.Type == entity.TRANSFER_ENTRY && entry.ReferenceID == transferID &&
+				entry.Postings[0].AccountID == originAccount.ID && entry.Postings[0].Direction == entity.DEBIT && entry.Postings[0].Amount == amount &&
+				entry.Postings[1].AccountID == destinationAccount.ID && entry.Postings[1].Direction == entity.CREDIT && entry.Postings[1].Amount == amount
+		}), testify.Anything)
 		transferRepository.AssertCalled(t, "Create", ctx, &transferAfterTransaction, testify.Anything)
 		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertCalled(t, "CommitTx", testify.Anything)
 		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
 	})
 
+	t.Run("Testing MakeTransferUseCase charges the fees on top of the amount", func(t *testing.T) {
+		ctx := context.Background()
+		amount := entity.NewMoney(50, entity.BRL)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		originAccount := GetBaseOriginAccount(t)           // Balance = 100
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		fees := []entity.TransferFee{
+			{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.BRL)},
+			{Rule: "percentage", Type: entity.PERCENTAGE_FEE, Amount: entity.NewMoney(5, entity.BRL)},
+		}
+
+		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
+		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, transfer.ChargeFees(fees))
+
+		originAccountAfterTransfer := *originAccount
+		originAccountAfterTransfer.Balance = entity.NewMoney(35, entity.BRL)
+		destinationAccountAfterTransfer := *destinationAccount
+		destinationAccountAfterTransfer.Balance = entity.NewMoney(250, entity.BRL)
+
+		transferAfterTransaction := *transfer
+		transferAfterTransaction.OriginAccount = &originAccountAfterTransfer
+		transferAfterTransaction.DestinationAccount = &destinationAccountAfterTransfer
+		transferAfterTransaction.Status = entity.COMPLETED_TRANSFER
+		transferAfterTransaction.StatusHistory = append(transfer.StatusHistory, entity.TransferStatusChange{Status: entity.COMPLETED_TRANSFER, ChangedAt: &createdAt})
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, originAccount.ID, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), testify.Anything).Return(2, nil)
+		transferRepository.On("Create", ctx, &transferAfterTransaction, testify.Anything).Return(transferAfterTransaction, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		feeRule := usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t))
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), feeRule, usecase.NewTransferDestinationRule(nil, nil), repository)
+		output, err := makeTransferUseCase.Execute(ctx, usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt))
+
+		assert.Nil(t, err)
+		assert.Equal(t, amount, output.DestinationAmount)
+		assert.Equal(t, entity.NewMoney(15, entity.BRL), output.Fee)
+		assert.Equal(t, []usecase.MakeTransferUseCaseFee{
+			{Rule: "flat", Type: "flat", Amount: entity.NewMoney(10, entity.BRL)},
+			{Rule: "percentage", Type: "percentage", Amount: entity.NewMoney(5, entity.BRL)},
+		}, output.Fees)
+
+		transferRepository.AssertCalled(t, "Create", ctx, &transferAfterTransaction, testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return len(entry.Postings) == 4 &&
+				entry.Postings[2].AccountID == originAccount.ID && entry.Postings[2].Direction == entity.DEBIT && entry.Postings[2].Amount == entity.NewMoney(15, entity.BRL) &&
+				entry.Postings[3].AccountID == entity.FEES_LEDGER_ACCOUNT && entry.Postings[3].Direction == entity.CREDIT && entry.Postings[3].Amount == entity.NewMoney(15, entity.BRL)
+		}), testify.Anything)
+	})
+
+	t.Run("Testing MakeTransferUseCase when the fee takes the origin account over its balance", func(t *testing.T) {
+		ctx := context.Background()
+		amount := entity.NewMoney(90, entity.BRL)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		originAccount := GetBaseOriginAccount(t)           // Balance = 100
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, originAccount.ID, testify.Anything, testify.Anything).Return(2, nil)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), []entity.TransactionHandler(nil)).Return(entity.Transfer{}, nil)
+
+		feeRule := usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t))
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, mock.NewLedgerRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), feeRule, usecase.NewTransferDestinationRule(nil, nil), repository)
+		output, err := makeTransferUseCase.Execute(ctx, usecase.NewMakeTransferUseCaseInput("", originAccount.ID, destinationAccount.ID, amount, "", &createdAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Contains(t, err.Error(), "error on update balance of origin account")
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
 	t.Run("Testing MakeTransferUseCase when origin account not found", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(entity.Account{}, errors.New("origin account not found"))
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(entity.Account{}, errors.New("origin account not found"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
 
 		transferRepository := mock.NewTransferRepositoryMock()
 		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
 
 		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
 		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
 		assert.Equal(t, "origin account not found", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "FindByID", ctx, destinationAccount.ID)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		accountRepository.AssertNotCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		repository.AssertNotCalled(t, "BeginTx", ctx)
+		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
-		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
 	t.Run("Testing MakeTransferUseCase when destination account not found", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(entity.Account{}, errors.New("destination account not found"))
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(entity.Account{}, errors.New("destination account not found"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
 
 		transferRepository := mock.NewTransferRepositoryMock()
 		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
 
 		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
 		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
 		assert.Equal(t, "destination account not found", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
-		repository.AssertNotCalled(t, "BeginTx", ctx)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
-		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 
 	})
 
 	t.Run("Testing MakeTransferUseCase when transfer amount exceeds balance", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 150
+		amount := entity.NewMoney(150, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
 
 		transferRepository := mock.NewTransferRepositoryMock()
 		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
 
 		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
 		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{}, nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
 		assert.Equal(t, "error on update balance of origin account: new balance cannot be minor than 0(insufficient balance)", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
-		transferRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
-		repository.AssertNotCalled(t, "BeginTx", ctx)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
+		// the failed attempt is recorded after the rollback, without the transaction
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.ID == transferID && transfer.Status == entity.FAILED_TRANSFER &&
+				transfer.FailureReason == "error on update balance of origin account: new balance cannot be minor than 0(insufficient balance)"
+		}), []entity.TransactionHandler(nil))
+		transferRepository.AssertNumberOfCalls(t, "Create", 1)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
-		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
 	t.Run("Testing MakeTransferUseCase when newTransfer returns an error", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
 
 		transferRepository := mock.NewTransferRepositoryMock()
 		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
 
 		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, nil)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", nil)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
 		assert.Equal(t, "created at cannot be nil", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
-		repository.AssertNotCalled(t, "BeginTx", ctx)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
-		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
 	t.Run("Testing MakeTransferUseCase when beginTx returns an error", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
 
 		transferRepository := mock.NewTransferRepositoryMock()
 
@@ -262,67 +392,64 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 
 		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
 		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
 		assert.Equal(t, "error on begin transaction", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
+		accountRepository.AssertNotCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertNotCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
 		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
 		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
 	})
 
-	t.Run("Testing MakeTransferUseCase when beginTx returns an error", func(t *testing.T) {
+	t.Run("Testing MakeTransferUseCase when accounts are locked in ascending id order", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
-		originAccount := GetBaseOriginAccount(t)           // Balance = 100
-		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+		originAccount := GetBaseDestinationAccount(t) // Balance = 200, ID = d18551d3-...
+		destinationAccount := GetBaseOriginAccount(t) // Balance = 100, ID = 2bd765a6-...
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(entity.Account{}, errors.New("destination account not found"))
 
-		transferRepository := mock.NewTransferRepositoryMock()
+		transactionHandler := mock.NewTransactionHandlerMock()
 
+		transferRepository := mock.NewTransferRepositoryMock()
 		repository := mock.NewRepositoryMock()
-		transactionHandler := mock.NewTransactionHandlerMock()
-		repository.On("BeginTx", ctx).Return(transactionHandler, errors.New("error on begin transaction"))
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
 
 		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
-
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
 		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
-		assert.Equal(t, "error on begin transaction", err.Error())
-
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
-		transferRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
-		repository.AssertCalled(t, "BeginTx", ctx)
-		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
-		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+		assert.Equal(t, "destination account not found", err.Error())
 
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
+		accountRepository.AssertNotCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
 	t.Run("Testing MakeTransferUseCase when create transfer returns an error", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
@@ -330,14 +457,12 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		originAccountAfterTransfer := *originAccount
 		destinationAccountAfterTransfer := *destinationAccount
 
-		originAccountAfterTransfer.Balance -= amount      // NewBalance = 50
-		destinationAccountAfterTransfer.Balance += amount // NewBalance  = 250
+		originAccountAfterTransfer.Balance, _ = originAccountAfterTransfer.Balance.Sub(amount)           // NewBalance = 50
+		destinationAccountAfterTransfer.Balance, _ = destinationAccountAfterTransfer.Balance.Add(amount) // NewBalance  = 250
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
-		accountRepository.On("UpdateBalance", ctx, originAccount.ID, originAccountAfterTransfer.Balance, testify.Anything).Return(originAccountAfterTransfer, nil)
-		accountRepository.On("UpdateBalance", ctx, destinationAccount.ID, destinationAccountAfterTransfer.Balance, testify.Anything).Return(destinationAccountAfterTransfer, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
 
 		transactionHandler := mock.NewTransactionHandlerMock()
 
@@ -355,32 +480,35 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		transferAfterTransaction := *transfer
 		transferAfterTransaction.OriginAccount = &originAccountAfterTransfer
 		transferAfterTransaction.DestinationAccount = &destinationAccountAfterTransfer
+		transferAfterTransaction.Status = entity.COMPLETED_TRANSFER
+		transferAfterTransaction.StatusHistory = append(transfer.StatusHistory, entity.TransferStatusChange{Status: entity.COMPLETED_TRANSFER, ChangedAt: &createdAt})
 
 		var returnedTransaction entity.Transfer
 
 		transferRepository.On("Create", ctx, &transferAfterTransaction, testify.Anything).Return(returnedTransaction, errors.New("error on create transfer"))
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
 		assert.Equal(t, "error on create transfer", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
 		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
 		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
-	t.Run("Testing MakeTransferUseCase when update origin account balance returns a error", func(t *testing.T) {
+	t.Run("Testing MakeTransferUseCase when post journal entry returns an error", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
@@ -388,16 +516,12 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		originAccountAfterTransfer := *originAccount
 		destinationAccountAfterTransfer := *destinationAccount
 
-		originAccountAfterTransfer.Balance -= amount      // NewBalance = 50
-		destinationAccountAfterTransfer.Balance += amount // NewBalance  = 250
-
-		var returnedOriginAccount entity.Account
+		originAccountAfterTransfer.Balance, _ = originAccountAfterTransfer.Balance.Sub(amount)           // NewBalance = 50
+		destinationAccountAfterTransfer.Balance, _ = destinationAccountAfterTransfer.Balance.Add(amount) // NewBalance  = 250
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
-		accountRepository.On("UpdateBalance", ctx, originAccount.ID, originAccountAfterTransfer.Balance, testify.Anything).Return(returnedOriginAccount, errors.New("error on update origin account balance"))
-		accountRepository.On("UpdateBalance", ctx, destinationAccount.ID, destinationAccountAfterTransfer.Balance, testify.Anything).Return(destinationAccountAfterTransfer, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
 
 		transactionHandler := mock.NewTransactionHandlerMock()
 
@@ -415,30 +539,34 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		transferAfterTransaction := *transfer
 		transferAfterTransaction.OriginAccount = &originAccountAfterTransfer
 		transferAfterTransaction.DestinationAccount = &destinationAccountAfterTransfer
+		transferAfterTransaction.Status = entity.COMPLETED_TRANSFER
+		transferAfterTransaction.StatusHistory = append(transfer.StatusHistory, entity.TransferStatusChange{Status: entity.COMPLETED_TRANSFER, ChangedAt: &createdAt})
 
 		transferRepository.On("Create", ctx, &transferAfterTransaction, testify.Anything).Return(transferAfterTransaction, nil)
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, errors.New("error on post journal entry"))
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
 		output, err := makeTransferUseCase.Execute(ctx, input)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, output)
-		assert.Equal(t, "error on update origin account balance", err.Error())
+		assert.Equal(t, "error on post journal entry", err.Error())
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.Anything, testify.Anything)
 		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
 		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
-	t.Run("Testing MakeTransferUseCase when update destination account balance returns a error", func(t *testing.T) {
+	t.Run("Testing MakeTransferUseCase when post journal entry panics", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
 
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
@@ -446,16 +574,12 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		originAccountAfterTransfer := *originAccount
 		destinationAccountAfterTransfer := *destinationAccount
 
-		originAccountAfterTransfer.Balance -= amount      // NewBalance = 50
-		destinationAccountAfterTransfer.Balance += amount // NewBalance  = 250
-
-		var returnedDestinationAccount entity.Account
+		originAccountAfterTransfer.Balance, _ = originAccountAfterTransfer.Balance.Sub(amount)           // NewBalance = 50
+		destinationAccountAfterTransfer.Balance, _ = destinationAccountAfterTransfer.Balance.Add(amount) // NewBalance  = 250
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
-		accountRepository.On("UpdateBalance", ctx, originAccount.ID, originAccountAfterTransfer.Balance, testify.Anything).Return(originAccountAfterTransfer, nil)
-		accountRepository.On("UpdateBalance", ctx, destinationAccount.ID, destinationAccountAfterTransfer.Balance, testify.Anything).Return(returnedDestinationAccount, errors.New("error on update destination account balance"))
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
 
 		transactionHandler := mock.NewTransactionHandlerMock()
 
@@ -473,80 +597,669 @@ func TestMakeTransferUseCase_Execute(t *testing.T) {
 		transferAfterTransaction := *transfer
 		transferAfterTransaction.OriginAccount = &originAccountAfterTransfer
 		transferAfterTransaction.DestinationAccount = &destinationAccountAfterTransfer
+		transferAfterTransaction.Status = entity.COMPLETED_TRANSFER
+		transferAfterTransaction.StatusHistory = append(transfer.StatusHistory, entity.TransferStatusChange{Status: entity.COMPLETED_TRANSFER, ChangedAt: &createdAt})
 
 		transferRepository.On("Create", ctx, &transferAfterTransaction, testify.Anything).Return(transferAfterTransaction, nil)
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
-		output, err := makeTransferUseCase.Execute(ctx, input)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Panic("panic in the process")
 
-		assert.NotNil(t, err)
-		assert.Nil(t, output)
-		assert.Equal(t, "error on update destination account balance", err.Error())
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
+
+		assert.Panics(t, func() {
+			_, _ = makeTransferUseCase.Execute(ctx, input)
+		}, "panic in the procaess")
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, originAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
 		transferRepository.AssertCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.Anything, testify.Anything)
 		repository.AssertCalled(t, "BeginTx", ctx)
 		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
 		repository.AssertCalled(t, "RollbackTx", testify.Anything)
 	})
 
-	t.Run("Testing MakeTransferUseCase when update destination account balance returns a error", func(t *testing.T) {
+	t.Run("Testing MakeTransferUseCase converts the amount between accounts of different currencies", func(t *testing.T) {
 		ctx := context.Background()
-		amount := 50
+		amount := entity.NewMoney(50, entity.BRL)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		originAccount := GetBaseOriginAccount(t)           // Balance = 100 BRL
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200 USD
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
 
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{
+			ID:                "237d3e7e-2f46-44e7-bf2b-f79721459241",
+			Amount:            amount,
+			DestinationAmount: entity.NewMoney(10, entity.USD),
+			ExchangeRate:      "0.2",
+			CreatedAt:         &createdAt,
+		}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		provider := mock.NewExchangeRateProviderMock()
+		provider.On("FindRate", ctx, entity.BRL, entity.USD).Return(GetExchangeRate(t, entity.BRL, entity.USD, "0.2", time.Now()), nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(provider, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, amount, output.Amount)
+		assert.Equal(t, entity.NewMoney(10, entity.USD), output.DestinationAmount)
+		assert.Equal(t, "0.2", output.ExchangeRate)
+
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.DestinationAmount == entity.NewMoney(10, entity.USD) && transfer.ExchangeRate == "0.2" &&
+				transfer.OriginAccount.Balance == entity.NewMoney(50, entity.BRL) && transfer.DestinationAccount.Balance == entity.NewMoney(210, entity.USD)
+		}), testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return len(entry.Postings) == 4 && entry.Postings[1].AccountID == entity.FX_LEDGER_ACCOUNT
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing MakeTransferUseCase when the exchange rate is stale", func(t *testing.T) {
+		ctx := context.Background()
+		amount := entity.NewMoney(50, entity.BRL)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		destinationAccount.Balance = entity.NewMoney(200, entity.USD)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{}, nil)
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		provider := mock.NewExchangeRateProviderMock()
+		provider.On("FindRate", ctx, entity.BRL, entity.USD).Return(GetExchangeRate(t, entity.BRL, entity.USD, "0.2", time.Now().Add(-2*time.Hour)), nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(provider, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "exchange rate from BRL to USD is stale", err.Error())
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.Status == entity.FAILED_TRANSFER && transfer.FailureReason == "exchange rate from BRL to USD is stale"
+		}), []entity.TransactionHandler(nil))
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+	})
+
+	t.Run("Testing MakeTransferUseCase when the transfer exceeds a limit of the account", func(t *testing.T) {
+		ctx := context.Background()
 		originAccount := GetBaseOriginAccount(t)           // Balance = 100
 		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+		amount := entity.NewMoney(60, entity.BRL)
 
-		originAccountAfterTransfer := *originAccount
-		destinationAccountAfterTransfer := *destinationAccount
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, testify.Anything, []entity.TransactionHandler{transactionHandler}).Return(entity.NewMoney(100, entity.BRL), nil)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), []entity.TransactionHandler(nil)).Return(entity.Transfer{}, nil)
 
-		originAccountAfterTransfer.Balance -= amount      // NewBalance = 50
-		destinationAccountAfterTransfer.Balance += amount // NewBalance  = 250
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, []entity.TransactionHandler{transactionHandler}).Return(GetAccountTransferLimit(t, originAccount.ID), nil)
+		limitRule := usecase.NewTransferLimitRule(limitRepository, transferRepository, GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), limitRule, GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		input := usecase.NewMakeTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer exceeds the daily limit of 1.50 BRL, 0.50 BRL available", err.Error())
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.Status == entity.FAILED_TRANSFER && transfer.OriginAccount.Balance == entity.NewMoney(100, entity.BRL)
+		}), []entity.TransactionHandler(nil))
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing MakeTransferUseCase when the amount requires a totp code", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		amount := entity.NewMoney(150, entity.BRL)
 
 		accountRepository := mock.NewAccountRepositoryMock()
-		accountRepository.On("FindByID", ctx, originAccount.ID).Return(*originAccount, nil)
-		accountRepository.On("FindByID", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
-		accountRepository.On("UpdateBalance", ctx, originAccount.ID, originAccountAfterTransfer.Balance, testify.Anything).Panic("panic in the process")
-		accountRepository.On("UpdateBalance", ctx, destinationAccount.ID, destinationAccountAfterTransfer.Balance, testify.Anything).Return(destinationAccountAfterTransfer, errors.New("error on update destination account balance"))
+		transferRepository := mock.NewTransferRepositoryMock()
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		repository := mock.NewRepositoryMock()
+
+		twoFactor, totpRepository, _, _ := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, originAccount.ID).Return(GetConfirmedTOTP(originAccount.ID), nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(100, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		input := usecase.NewMakeTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount.ID, destinationAccount.ID, amount, "", &createdAt)
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "totp code is required for transfers of 1.00 BRL or more", err.Error())
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing MakeTransferUseCase sends the transfer to the owner of a key", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		amount := entity.NewMoney(50, entity.BRL)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "joao@example.com").Return(entity.TransferKey{Key: "joao@example.com", Type: entity.EMAIL_KEY, AccountID: destinationAccount.ID}, nil)
+		lookupRule, _ := GetTransferKeyLookupRule(keyRepository)
 
 		transactionHandler := mock.NewTransactionHandlerMock()
 
 		repository := mock.NewRepositoryMock()
 		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
 		repository.On("CommitTx", transactionHandler).Return(nil)
-		repository.On("RollbackTx", transactionHandler).Return(nil)
 
-		transferID := "237d3e7e-2f46-44e7-bf2b-f79721459241"
 		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		transfer, err := entity.NewTransfer("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount, destinationAccount, amount, &createdAt)
+		assert.Nil(t, err)
+
 		transferRepository := mock.NewTransferRepositoryMock()
-		transfer, err := entity.NewTransfer(transferID, originAccount, destinationAccount, amount, &createdAt)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(*transfer, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(lookupRule, nil), repository)
+		input := usecase.NewMakeTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount.ID, "", amount, "", &createdAt)
+		input.DestinationAccount.Key = "Joao@Example.com"
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
 		assert.Nil(t, err)
+		assert.Equal(t, destinationAccount.ID, output.DestinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
+	})
 
-		transferAfterTransaction := *transfer
-		transferAfterTransaction.OriginAccount = &originAccountAfterTransfer
-		transferAfterTransaction.DestinationAccount = &destinationAccountAfterTransfer
+	t.Run("Testing MakeTransferUseCase counts a key that does not exist as a miss of the origin account", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		repository := mock.NewRepositoryMock()
 
-		transferRepository.On("Create", ctx, &transferAfterTransaction, testify.Anything).Return(transferAfterTransaction, nil)
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "lucas@example.com").
+			Return(entity.TransferKey{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found key: lucas@example.com"))
+		lookupRule, loginThrottleRepository := GetTransferKeyLookupRule(keyRepository)
 
-		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, repository)
-		input := usecase.NewMakeTransferUseCaseInput(transferID, originAccount.ID, destinationAccount.ID, amount, &createdAt)
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(mock.NewAccountRepositoryMock(), mock.NewTransferRepositoryMock(), mock.NewLedgerRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(lookupRule, nil), repository)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		input := usecase.NewMakeTransferUseCaseInput("", originAccount.ID, "", entity.NewMoney(50, entity.BRL), "", &createdAt)
+		input.DestinationAccount.Key = "lucas@example.com"
+		output, err := makeTransferUseCase.Execute(ctx, input)
 
-		assert.Panics(t, func() {
-			_, _ = makeTransferUseCase.Execute(ctx, input)
-		}, "panic in the procaess")
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "key_lookup:"+originAccount.ID, testify.Anything, testify.Anything)
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
 
-		accountRepository.AssertCalled(t, "FindByID", ctx, originAccount.ID)
-		accountRepository.AssertCalled(t, "FindByID", ctx, destinationAccount.ID)
-		transferRepository.AssertCalled(t, "Create", ctx, testify.Anything, testify.Anything)
-		accountRepository.AssertCalled(t, "UpdateBalance", ctx, originAccount.ID, testify.Anything)
-		accountRepository.AssertNotCalled(t, "UpdateBalance", ctx, destinationAccount.ID, testify.Anything)
-		repository.AssertCalled(t, "BeginTx", ctx)
-		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
-		repository.AssertCalled(t, "RollbackTx", testify.Anything)
+	t.Run("Testing MakeTransferUseCase sends the transfer to a beneficiary of the origin account", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+		amount := entity.NewMoney(50, entity.BRL)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByID", ctx, "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").
+			Return(entity.Beneficiary{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", AccountID: originAccount.ID, DestinationAccountID: destinationAccount.ID}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		transfer, err := entity.NewTransfer("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount, destinationAccount, amount, &createdAt)
+		assert.Nil(t, err)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(*transfer, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(accountRepository, transferRepository, ledgerRepository, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, beneficiaryRepository), repository)
+		input := usecase.NewMakeTransferUseCaseInput("237d3e7e-2f46-44e7-bf2b-f79721459241", originAccount.ID, "", amount, "", &createdAt)
+		input.DestinationAccount.BeneficiaryID = "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60"
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, destinationAccount.ID, output.DestinationAccount.ID)
+		accountRepository.AssertCalled(t, "FindByIDForUpdate", ctx, destinationAccount.ID)
+	})
+
+	t.Run("Testing MakeTransferUseCase when the beneficiary was saved by another account", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewRepositoryMock()
+
+		beneficiaryRepository := mock.NewBeneficiaryRepositoryMock()
+		beneficiaryRepository.On("FindByID", ctx, "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60").
+			Return(entity.Beneficiary{ID: "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", AccountID: GetBaseDestinationAccount(t).ID, DestinationAccountID: GetBaseOriginAccount(t).ID}, nil)
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(mock.NewAccountRepositoryMock(), mock.NewTransferRepositoryMock(), mock.NewLedgerRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, beneficiaryRepository), repository)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		input := usecase.NewMakeTransferUseCaseInput("", GetBaseOriginAccount(t).ID, "", entity.NewMoney(50, entity.BRL), "", &createdAt)
+		input.DestinationAccount.BeneficiaryID = "6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60"
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found beneficiary: 6a0d5e1a-2f0b-4c55-9f0e-3b1c2d4e5f60", err.Error())
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing MakeTransferUseCase when the destination has both an id and a key", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewRepositoryMock()
+
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(mock.NewAccountRepositoryMock(), mock.NewTransferRepositoryMock(), mock.NewLedgerRepositoryMock(), usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), repository)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		input := usecase.NewMakeTransferUseCaseInput("", GetBaseOriginAccount(t).ID, GetBaseDestinationAccount(t).ID, entity.NewMoney(50, entity.BRL), "", &createdAt)
+		input.DestinationAccount.Key = "joao@example.com"
+		output, err := makeTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "destination account must be informed by only one of its id, its key or a beneficiary", err.Error())
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+}
+
+// inMemoryBank emulates the row-level locks of the database: FindByIDForUpdate blocks
+// until the account is released by the commit or rollback of the transaction holding it.
+type inMemoryBank struct {
+	mu        sync.Mutex
+	accounts  map[string]entity.Account
+	rowLocks  map[string]*sync.Mutex
+	transfers []entity.Transfer
+}
+
+type inMemoryTransaction struct {
+	lockedIDs []string
+	balances  map[string]entity.Money
+	transfers []entity.Transfer
+}
+
+func (t *inMemoryTransaction) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, nil
+}
+
+func (t *inMemoryTransaction) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return nil
+}
+
+func newInMemoryBank(accounts ...entity.Account) *inMemoryBank {
+	bank := &inMemoryBank{
+		accounts: map[string]entity.Account{},
+		rowLocks: map[string]*sync.Mutex{},
+	}
+
+	for _, account := range accounts {
+		bank.accounts[account.ID] = account
+		bank.rowLocks[account.ID] = &sync.Mutex{}
+	}
+
+	return bank
+}
+
+func (b *inMemoryBank) Find(ctx context.Context, page entity.Pagination) ([]entity.Account, error) {
+	return nil, nil
+}
+
+func (b *inMemoryBank) FindByID(ctx context.Context, ID string) (entity.Account, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	account, ok := b.accounts[ID]
+	if !ok {
+		return entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account")
+	}
+
+	return account, nil
+}
+
+func (b *inMemoryBank) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Account, error) {
+	rowLock, ok := b.rowLocks[ID]
+	if !ok {
+		return entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account")
+	}
+
+	rowLock.Lock()
+
+	transaction := tx[0].(*inMemoryTransaction)
+	transaction.lockedIDs = append(transaction.lockedIDs, ID)
+
+	return b.FindByID(ctx, ID)
+}
+
+func (b *inMemoryBank) FindByIDs(ctx context.Context, IDs []string) ([]entity.Account, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var accounts []entity.Account
+	for _, ID := range IDs {
+		if account, ok := b.accounts[ID]; ok {
+			accounts = append(accounts, account)
+		}
+	}
+
+	return accounts, nil
+}
+
+func (b *inMemoryBank) Create(ctx context.Context, account *entity.Account, tx ...entity.TransactionHandler) (entity.Account, error) {
+	return *account, nil
+}
+
+func (b *inMemoryBank) FindByCPF(ctx context.Context, CPF string) (entity.Account, error) {
+	return entity.Account{}, nil
+}
+
+func (b *inMemoryBank) UpdateRole(ctx context.Context, ID string, role entity.Role) error {
+	return nil
+}
+
+func (b *inMemoryBank) UpdateSecret(ctx context.Context, ID string, secret string, tx ...entity.TransactionHandler) error {
+	return nil
+}
+
+func (b *inMemoryBank) UpdateCreditLimit(ctx context.Context, ID string, creditLimit entity.Money, tx ...entity.TransactionHandler) error {
+	return nil
+}
+
+func (b *inMemoryBank) BeginTx(ctx context.Context) (entity.TransactionHandler, error) {
+	return &inMemoryTransaction{balances: map[string]entity.Money{}}, nil
+}
+
+func (b *inMemoryBank) CommitTx(tx entity.TransactionHandler) error {
+	transaction := tx.(*inMemoryTransaction)
+
+	b.mu.Lock()
+	for ID, balance := range transaction.balances {
+		account := b.accounts[ID]
+		account.Balance = balance
+		b.accounts[ID] = account
+	}
+	b.transfers = append(b.transfers, transaction.transfers...)
+	b.mu.Unlock()
+
+	b.release(transaction)
+	return nil
+}
+
+func (b *inMemoryBank) RollbackTx(tx entity.TransactionHandler) error {
+	b.release(tx.(*inMemoryTransaction))
+	return nil
+}
+
+func (b *inMemoryBank) release(transaction *inMemoryTransaction) {
+	for _, ID := range transaction.lockedIDs {
+		b.rowLocks[ID].Unlock()
+	}
+	transaction.lockedIDs = nil
+}
+
+type inMemoryTransferRepository struct {
+	*inMemoryBank
+}
+
+func (r inMemoryTransferRepository) FindByAccountID(ctx context.Context, AccountID string, filter entity.TransferFilter, page entity.Pagination) ([]entity.Transfer, error) {
+	return nil, nil
+}
+
+func (r inMemoryTransferRepository) Create(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) (entity.Transfer, error) {
+	// failed attempts are recorded without a transaction and move no money
+	if len(tx) == 0 {
+		return *transfer, nil
+	}
+
+	transaction := tx[0].(*inMemoryTransaction)
+	transaction.transfers = append(transaction.transfers, *transfer)
+	return *transfer, nil
+}
+
+func (r inMemoryTransferRepository) FindByIDForUpdate(ctx context.Context, ID string, tx ...entity.TransactionHandler) (entity.Transfer, error) {
+	return entity.Transfer{}, nil
+}
+
+func (r inMemoryTransferRepository) FindByID(ctx context.Context, ID string) (entity.Transfer, error) {
+	return entity.Transfer{}, nil
+}
+
+func (r inMemoryTransferRepository) UpdateStatus(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) error {
+	return nil
+}
+
+func (r inMemoryTransferRepository) SumSent(ctx context.Context, accountID string, currency entity.Currency, since time.Time, tx ...entity.TransactionHandler) (entity.Money, error) {
+	return entity.NewMoney(0, currency), nil
+}
+
+func (r inMemoryTransferRepository) CountSent(ctx context.Context, accountID string, since time.Time, tx ...entity.TransactionHandler) (int, error) {
+	return 0, nil
+}
+
+func (r inMemoryTransferRepository) FindFrequentDestinations(ctx context.Context, accountID string, since time.Time, limit int) ([]entity.FrequentDestination, error) {
+	return nil, nil
+}
+
+func (r inMemoryTransferRepository) UpdateReversedAmount(ctx context.Context, transfer *entity.Transfer, tx ...entity.TransactionHandler) error {
+	return nil
+}
+
+type inMemoryLedgerRepository struct {
+	*inMemoryBank
+}
+
+func (r inMemoryLedgerRepository) Post(ctx context.Context, entry *entity.JournalEntry, tx ...entity.TransactionHandler) (entity.JournalEntry, error) {
+	transaction := tx[0].(*inMemoryTransaction)
+
+	for _, posting := range entry.Postings {
+		if _, ok := transaction.balances[posting.AccountID]; !ok {
+			account, err := r.FindByID(ctx, posting.AccountID)
+			if err != nil {
+				return entity.JournalEntry{}, err
+			}
+			transaction.balances[posting.AccountID] = account.Balance
+		}
+		balance, err := transaction.balances[posting.AccountID].Add(posting.SignedAmount())
+		if err != nil {
+			return entity.JournalEntry{}, err
+		}
+		transaction.balances[posting.AccountID] = balance
+	}
+
+	return *entry, nil
+}
+
+func (r inMemoryLedgerRepository) FindBalances(ctx context.Context) ([]entity.LedgerBalance, error) {
+	return nil, nil
+}
+
+func (r inMemoryLedgerRepository) FindUnbalancedEntries(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func TestMakeTransferUseCase_ExecuteConcurrently(t *testing.T) {
+	t.Run("Testing MakeTransferUseCase conserves the total money with hundreds of parallel transfers", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accounts := []entity.Account{
+			{ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", Name: "lucas", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+			{ID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "joao", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+			{ID: "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", Name: "rogerio", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+			{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", Name: "maria", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+		}
+
+		totalBefore := int64(0)
+		for _, account := range accounts {
+			totalBefore += account.Balance.Amount
+		}
+
+		bank := newInMemoryBank(accounts...)
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(bank, inMemoryTransferRepository{bank}, inMemoryLedgerRepository{bank}, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), bank)
+
+		transfers := 400
+		var wg sync.WaitGroup
+		wg.Add(transfers)
+
+		for i := 0; i < transfers; i++ {
+			origin := accounts[i%len(accounts)]
+			destination := accounts[(i*7+1)%len(accounts)]
+			amount := entity.NewMoney(int64(10+(i%90)), entity.BRL)
+
+			go func(originID, destinationID string, amount entity.Money) {
+				defer wg.Done()
+				input := usecase.NewMakeTransferUseCaseInput("", originID, destinationID, amount, "", &createdAt)
+				_, _ = makeTransferUseCase.Execute(ctx, input)
+			}(origin.ID, destination.ID, amount)
+		}
+
+		wg.Wait()
+
+		totalAfter := int64(0)
+		expectedBalances := map[string]int64{}
+		for _, account := range accounts {
+			expectedBalances[account.ID] = account.Balance.Amount
+		}
+
+		for _, transfer := range bank.transfers {
+			expectedBalances[transfer.OriginAccount.ID] -= transfer.Amount.Amount
+			expectedBalances[transfer.DestinationAccount.ID] += transfer.Amount.Amount
+		}
+
+		for ID, account := range bank.accounts {
+			totalAfter += account.Balance.Amount
+			assert.GreaterOrEqual(t, account.Balance.Amount, int64(0))
+			assert.Equal(t, expectedBalances[ID], account.Balance.Amount)
+		}
+
+		assert.NotEmpty(t, bank.transfers)
+		assert.Equal(t, totalBefore, totalAfter)
+	})
+}
+
+func TestMakeTransferUseCase_ExecuteAtomically(t *testing.T) {
+	createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+	GetAccounts := func() []entity.Account {
+		return []entity.Account{
+			{ID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", Name: "lucas", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+			{ID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", Name: "joao", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+			{ID: "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", Name: "rogerio", Balance: entity.NewMoney(1000, entity.BRL), CreatedAt: &createdAt},
+		}
+	}
+
+	t.Run("Testing MakeTransferUseCase makes every transfer with the balances left by the previous ones", func(t *testing.T) {
+		ctx := context.Background()
+		accounts := GetAccounts()
+
+		bank := newInMemoryBank(accounts...)
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(bank, inMemoryTransferRepository{bank}, inMemoryLedgerRepository{bank}, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), bank)
+
+		outputs, failed, err := makeTransferUseCase.ExecuteAtomically(ctx, []*usecase.MakeTransferUseCaseInput{
+			usecase.NewMakeTransferUseCaseInput("", accounts[0].ID, accounts[1].ID, entity.NewMoney(600, entity.BRL), "", &createdAt),
+			usecase.NewMakeTransferUseCaseInput("", accounts[0].ID, accounts[2].ID, entity.NewMoney(400, entity.BRL), "", &createdAt),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, -1, failed)
+		assert.Len(t, outputs, 2)
+		assert.Equal(t, "completed", outputs[1].Status)
+		assert.Len(t, bank.transfers, 2)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), bank.accounts[accounts[0].ID].Balance)
+		assert.Equal(t, entity.NewMoney(1600, entity.BRL), bank.accounts[accounts[1].ID].Balance)
+		assert.Equal(t, entity.NewMoney(1400, entity.BRL), bank.accounts[accounts[2].ID].Balance)
+	})
+
+	t.Run("Testing MakeTransferUseCase rolls back every transfer when one of them fails", func(t *testing.T) {
+		ctx := context.Background()
+		accounts := GetAccounts()
+
+		bank := newInMemoryBank(accounts...)
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(bank, inMemoryTransferRepository{bank}, inMemoryLedgerRepository{bank}, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), bank)
+
+		outputs, failed, err := makeTransferUseCase.ExecuteAtomically(ctx, []*usecase.MakeTransferUseCaseInput{
+			usecase.NewMakeTransferUseCaseInput("", accounts[0].ID, accounts[1].ID, entity.NewMoney(600, entity.BRL), "", &createdAt),
+			usecase.NewMakeTransferUseCaseInput("", accounts[0].ID, accounts[2].ID, entity.NewMoney(600, entity.BRL), "", &createdAt),
+		})
+
+		assert.Nil(t, outputs)
+		assert.Equal(t, 1, failed)
+		assert.Equal(t, "error on update balance of origin account: new balance cannot be minor than 0(insufficient balance)", err.Error())
+		assert.Empty(t, bank.transfers)
+		for _, account := range accounts {
+			assert.Equal(t, account.Balance, bank.accounts[account.ID].Balance)
+		}
 	})
 
+	t.Run("Testing MakeTransferUseCase when an account of the batch does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		accounts := GetAccounts()
+
+		bank := newInMemoryBank(accounts...)
+		makeTransferUseCase := usecase.NewMakeTransferUseCase(bank, inMemoryTransferRepository{bank}, inMemoryLedgerRepository{bank}, usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)), usecase.NewTransferExchangeRateRule(nil, time.Hour), GetTransferLimitRule(t), GetTransferFeeRule(t), usecase.NewTransferDestinationRule(nil, nil), bank)
+
+		outputs, failed, err := makeTransferUseCase.ExecuteAtomically(ctx, []*usecase.MakeTransferUseCaseInput{
+			usecase.NewMakeTransferUseCaseInput("", accounts[0].ID, accounts[1].ID, entity.NewMoney(100, entity.BRL), "", &createdAt),
+			usecase.NewMakeTransferUseCaseInput("", accounts[0].ID, "fc84682a-3045-4bdf-b91c-10be19f89452", entity.NewMoney(100, entity.BRL), "", &createdAt),
+		})
+
+		assert.Nil(t, outputs)
+		assert.Equal(t, -1, failed)
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Empty(t, bank.transfers)
+	})
 }
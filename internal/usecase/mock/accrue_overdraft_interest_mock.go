@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AccrueOverdraftInterestUseCaseMock struct {
+	mock.Mock
+}
+
+func NewAccrueOverdraftInterestUseCaseMock() *AccrueOverdraftInterestUseCaseMock {
+	return &AccrueOverdraftInterestUseCaseMock{}
+}
+
+func (a *AccrueOverdraftInterestUseCaseMock) Execute(ctx context.Context, input *usecase.AccrueOverdraftInterestUseCaseInput) (*usecase.AccrueOverdraftInterestUseCaseOutput, error) {
+	args := a.Called(ctx, input)
+	return args.Get(0).(*usecase.AccrueOverdraftInterestUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CaptureHoldUseCaseMock struct {
+	mock.Mock
+}
+
+func NewCaptureHoldUseCaseMock() *CaptureHoldUseCaseMock {
+	return &CaptureHoldUseCaseMock{}
+}
+
+func (c *CaptureHoldUseCaseMock) Execute(ctx context.Context, input *usecase.CaptureHoldUseCaseInput) (*usecase.CaptureHoldUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.CaptureHoldUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ChangeAccountRoleUseCaseMock struct {
+	mock.Mock
+}
+
+func NewChangeAccountRoleUseCaseMock() *ChangeAccountRoleUseCaseMock {
+	return &ChangeAccountRoleUseCaseMock{}
+}
+
+func (f *ChangeAccountRoleUseCaseMock) Execute(ctx context.Context, input *usecase.ChangeAccountRoleUseCaseInput) (*usecase.ChangeAccountRoleUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.ChangeAccountRoleUseCaseOutput), args.Error(1)
+}
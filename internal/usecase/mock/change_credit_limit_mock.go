@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ChangeCreditLimitUseCaseMock struct {
+	mock.Mock
+}
+
+func NewChangeCreditLimitUseCaseMock() *ChangeCreditLimitUseCaseMock {
+	return &ChangeCreditLimitUseCaseMock{}
+}
+
+func (c *ChangeCreditLimitUseCaseMock) Execute(ctx context.Context, input *usecase.ChangeCreditLimitUseCaseInput) (*usecase.ChangeCreditLimitUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.ChangeCreditLimitUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ChangeScheduledTransferStatusUseCaseMock struct {
+	mock.Mock
+}
+
+func NewChangeScheduledTransferStatusUseCaseMock() *ChangeScheduledTransferStatusUseCaseMock {
+	return &ChangeScheduledTransferStatusUseCaseMock{}
+}
+
+func (c *ChangeScheduledTransferStatusUseCaseMock) Execute(ctx context.Context, input *usecase.ChangeScheduledTransferStatusUseCaseInput) (*usecase.ScheduledTransferUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.ScheduledTransferUseCaseOutput), args.Error(1)
+}
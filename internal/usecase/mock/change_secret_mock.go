@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ChangeSecretUseCaseMock struct {
+	mock.Mock
+}
+
+func NewChangeSecretUseCaseMock() *ChangeSecretUseCaseMock {
+	return &ChangeSecretUseCaseMock{}
+}
+
+func (f *ChangeSecretUseCaseMock) Execute(ctx context.Context, input *usecase.ChangeSecretUseCaseInput) error {
+	args := f.Called(ctx, input)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ChangeTransferLimitUseCaseMock struct {
+	mock.Mock
+}
+
+func NewChangeTransferLimitUseCaseMock() *ChangeTransferLimitUseCaseMock {
+	return &ChangeTransferLimitUseCaseMock{}
+}
+
+func (c *ChangeTransferLimitUseCaseMock) Execute(ctx context.Context, input *usecase.ChangeTransferLimitUseCaseInput) (*usecase.FindTransferLimitUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.FindTransferLimitUseCaseOutput), args.Error(1)
+}
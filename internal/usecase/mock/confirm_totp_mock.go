@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ConfirmTOTPUseCaseMock struct {
+	mock.Mock
+}
+
+func NewConfirmTOTPUseCaseMock() *ConfirmTOTPUseCaseMock {
+	return &ConfirmTOTPUseCaseMock{}
+}
+
+func (m *ConfirmTOTPUseCaseMock) Execute(ctx context.Context, input *usecase.ConfirmTOTPUseCaseInput) (*usecase.ConfirmTOTPUseCaseOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*usecase.ConfirmTOTPUseCaseOutput), args.Error(1)
+}
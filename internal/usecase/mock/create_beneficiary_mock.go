@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateBeneficiaryUseCaseMock struct {
+	mock.Mock
+}
+
+func NewCreateBeneficiaryUseCaseMock() *CreateBeneficiaryUseCaseMock {
+	return &CreateBeneficiaryUseCaseMock{}
+}
+
+func (c *CreateBeneficiaryUseCaseMock) Execute(ctx context.Context, input *usecase.CreateBeneficiaryUseCaseInput) (*usecase.BeneficiaryUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.BeneficiaryUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateHoldUseCaseMock struct {
+	mock.Mock
+}
+
+func NewCreateHoldUseCaseMock() *CreateHoldUseCaseMock {
+	return &CreateHoldUseCaseMock{}
+}
+
+func (c *CreateHoldUseCaseMock) Execute(ctx context.Context, input *usecase.CreateHoldUseCaseInput) (*usecase.HoldUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.HoldUseCaseOutput), args.Error(1)
+}
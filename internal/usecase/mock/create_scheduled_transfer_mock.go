@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateScheduledTransferUseCaseMock struct {
+	mock.Mock
+}
+
+func NewCreateScheduledTransferUseCaseMock() *CreateScheduledTransferUseCaseMock {
+	return &CreateScheduledTransferUseCaseMock{}
+}
+
+func (c *CreateScheduledTransferUseCaseMock) Execute(ctx context.Context, input *usecase.CreateScheduledTransferUseCaseInput) (*usecase.ScheduledTransferUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.ScheduledTransferUseCaseOutput), args.Error(1)
+}
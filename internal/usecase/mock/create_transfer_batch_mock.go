@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateTransferBatchUseCaseMock struct {
+	mock.Mock
+}
+
+func NewCreateTransferBatchUseCaseMock() *CreateTransferBatchUseCaseMock {
+	return &CreateTransferBatchUseCaseMock{}
+}
+
+func (c *CreateTransferBatchUseCaseMock) Execute(ctx context.Context, input *usecase.CreateTransferBatchUseCaseInput) (*usecase.TransferBatchUseCaseOutput, error) {
+	args := c.Called(ctx, input)
+	return args.Get(0).(*usecase.TransferBatchUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateTransferKeyUseCaseMock struct {
+	mock.Mock
+}
+
+func NewCreateTransferKeyUseCaseMock() *CreateTransferKeyUseCaseMock {
+	return &CreateTransferKeyUseCaseMock{}
+}
+
+func (f *CreateTransferKeyUseCaseMock) Execute(ctx context.Context, input *usecase.CreateTransferKeyUseCaseInput) (*usecase.TransferKeyUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.TransferKeyUseCaseOutput), args.Error(1)
+}
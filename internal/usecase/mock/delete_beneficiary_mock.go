@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type DeleteBeneficiaryUseCaseMock struct {
+	mock.Mock
+}
+
+func NewDeleteBeneficiaryUseCaseMock() *DeleteBeneficiaryUseCaseMock {
+	return &DeleteBeneficiaryUseCaseMock{}
+}
+
+func (d *DeleteBeneficiaryUseCaseMock) Execute(ctx context.Context, input *usecase.DeleteBeneficiaryUseCaseInput) error {
+	args := d.Called(ctx, input)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type DeleteTransferKeyUseCaseMock struct {
+	mock.Mock
+}
+
+func NewDeleteTransferKeyUseCaseMock() *DeleteTransferKeyUseCaseMock {
+	return &DeleteTransferKeyUseCaseMock{}
+}
+
+func (f *DeleteTransferKeyUseCaseMock) Execute(ctx context.Context, input *usecase.DeleteTransferKeyUseCaseInput) error {
+	args := f.Called(ctx, input)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type DepositUseCaseMock struct {
+	mock.Mock
+}
+
+func NewDepositUseCaseMock() *DepositUseCaseMock {
+	return &DepositUseCaseMock{}
+}
+
+func (f *DepositUseCaseMock) Execute(ctx context.Context, input *usecase.MovementUseCaseInput) (*usecase.MovementUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.MovementUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type EnrollTOTPUseCaseMock struct {
+	mock.Mock
+}
+
+func NewEnrollTOTPUseCaseMock() *EnrollTOTPUseCaseMock {
+	return &EnrollTOTPUseCaseMock{}
+}
+
+func (m *EnrollTOTPUseCaseMock) Execute(ctx context.Context, input *usecase.EnrollTOTPUseCaseInput) (*usecase.EnrollTOTPUseCaseOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*usecase.EnrollTOTPUseCaseOutput), args.Error(1)
+}
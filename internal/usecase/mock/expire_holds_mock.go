@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ExpireHoldsUseCaseMock struct {
+	mock.Mock
+}
+
+func NewExpireHoldsUseCaseMock() *ExpireHoldsUseCaseMock {
+	return &ExpireHoldsUseCaseMock{}
+}
+
+func (e *ExpireHoldsUseCaseMock) Execute(ctx context.Context, input *usecase.ExpireHoldsUseCaseInput) (*usecase.ExpireHoldsUseCaseOutput, error) {
+	args := e.Called(ctx, input)
+	return args.Get(0).(*usecase.ExpireHoldsUseCaseOutput), args.Error(1)
+}
@@ -15,7 +15,7 @@ func NewFindAccountUseCaseMock() *FindAccountUseCaseMock {
 	return &FindAccountUseCaseMock{}
 }
 
-func (f *FindAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindAccountUseCaseInput) ([]usecase.FindAccountUseCaseOutput, error) {
+func (f *FindAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindAccountUseCaseInput) (*usecase.FindAccountUseCasePageOutput, error) {
 	args := f.Called(ctx, input)
-	return args.Get(0).([]usecase.FindAccountUseCaseOutput), args.Error(1)
+	return args.Get(0).(*usecase.FindAccountUseCasePageOutput), args.Error(1)
 }
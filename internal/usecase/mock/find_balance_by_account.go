@@ -19,3 +19,8 @@ func (f *MakeTransferUseCaseMock) Execute(ctx context.Context, input *usecase.Ma
 	args := f.Called(ctx, input)
 	return args.Get(0).(*usecase.MakeTransferUseCaseOutput), args.Error(1)
 }
+
+func (f *MakeTransferUseCaseMock) ExecuteAtomically(ctx context.Context, inputs []*usecase.MakeTransferUseCaseInput) ([]*usecase.MakeTransferUseCaseOutput, int, error) {
+	args := f.Called(ctx, inputs)
+	return args.Get(0).([]*usecase.MakeTransferUseCaseOutput), args.Int(1), args.Error(2)
+}
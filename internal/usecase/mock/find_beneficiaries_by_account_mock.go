@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindBeneficiariesByAccountUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindBeneficiariesByAccountUseCaseMock() *FindBeneficiariesByAccountUseCaseMock {
+	return &FindBeneficiariesByAccountUseCaseMock{}
+}
+
+func (f *FindBeneficiariesByAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindBeneficiariesByAccountUseCaseInput) ([]usecase.BeneficiaryUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).([]usecase.BeneficiaryUseCaseOutput), args.Error(1)
+}
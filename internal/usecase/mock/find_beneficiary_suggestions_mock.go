@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindBeneficiarySuggestionsUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindBeneficiarySuggestionsUseCaseMock() *FindBeneficiarySuggestionsUseCaseMock {
+	return &FindBeneficiarySuggestionsUseCaseMock{}
+}
+
+func (f *FindBeneficiarySuggestionsUseCaseMock) Execute(ctx context.Context, input *usecase.FindBeneficiarySuggestionsUseCaseInput) ([]usecase.BeneficiarySuggestionUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).([]usecase.BeneficiarySuggestionUseCaseOutput), args.Error(1)
+}
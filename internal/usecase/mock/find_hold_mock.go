@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindHoldUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindHoldUseCaseMock() *FindHoldUseCaseMock {
+	return &FindHoldUseCaseMock{}
+}
+
+func (f *FindHoldUseCaseMock) Execute(ctx context.Context, input *usecase.FindHoldUseCaseInput) (*usecase.HoldUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.HoldUseCaseOutput), args.Error(1)
+}
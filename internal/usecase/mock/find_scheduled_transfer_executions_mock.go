@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindScheduledTransferExecutionsUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindScheduledTransferExecutionsUseCaseMock() *FindScheduledTransferExecutionsUseCaseMock {
+	return &FindScheduledTransferExecutionsUseCaseMock{}
+}
+
+func (f *FindScheduledTransferExecutionsUseCaseMock) Execute(ctx context.Context, input *usecase.FindScheduledTransferExecutionsUseCaseInput) ([]usecase.FindScheduledTransferExecutionsUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).([]usecase.FindScheduledTransferExecutionsUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindScheduledTransfersByAccountUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindScheduledTransfersByAccountUseCaseMock() *FindScheduledTransfersByAccountUseCaseMock {
+	return &FindScheduledTransfersByAccountUseCaseMock{}
+}
+
+func (f *FindScheduledTransfersByAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindScheduledTransfersByAccountUseCaseInput) (*usecase.FindScheduledTransfersByAccountUseCasePageOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.FindScheduledTransfersByAccountUseCasePageOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindTransferBatchUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindTransferBatchUseCaseMock() *FindTransferBatchUseCaseMock {
+	return &FindTransferBatchUseCaseMock{}
+}
+
+func (f *FindTransferBatchUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransferBatchUseCaseInput) (*usecase.TransferBatchUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.TransferBatchUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindTransferKeyUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindTransferKeyUseCaseMock() *FindTransferKeyUseCaseMock {
+	return &FindTransferKeyUseCaseMock{}
+}
+
+func (f *FindTransferKeyUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransferKeyUseCaseInput) (*usecase.FindTransferKeyUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.FindTransferKeyUseCaseOutput), args.Error(1)
+}
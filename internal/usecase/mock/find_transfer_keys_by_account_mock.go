@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindTransferKeysByAccountUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindTransferKeysByAccountUseCaseMock() *FindTransferKeysByAccountUseCaseMock {
+	return &FindTransferKeysByAccountUseCaseMock{}
+}
+
+func (f *FindTransferKeysByAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransferKeysByAccountUseCaseInput) ([]usecase.TransferKeyUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).([]usecase.TransferKeyUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindTransferLimitUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindTransferLimitUseCaseMock() *FindTransferLimitUseCaseMock {
+	return &FindTransferLimitUseCaseMock{}
+}
+
+func (f *FindTransferLimitUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransferLimitUseCaseInput) (*usecase.FindTransferLimitUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.FindTransferLimitUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FindTransferUseCaseMock struct {
+	mock.Mock
+}
+
+func NewFindTransferUseCaseMock() *FindTransferUseCaseMock {
+	return &FindTransferUseCaseMock{}
+}
+
+func (f *FindTransferUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransferUseCaseInput) (*usecase.FindTransferUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.FindTransferUseCaseOutput), args.Error(1)
+}
@@ -15,7 +15,7 @@ func NewFindTransfersByAccountUseCaseMock() *FindTransfersByAccountUseCaseMock {
 	return &FindTransfersByAccountUseCaseMock{}
 }
 
-func (f *FindTransfersByAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransfersByAccountUseCaseInput) ([]usecase.FindTransfersByAccountUseCaseOutput, error) {
+func (f *FindTransfersByAccountUseCaseMock) Execute(ctx context.Context, input *usecase.FindTransfersByAccountUseCaseInput) (*usecase.FindTransfersByAccountUseCasePageOutput, error) {
 	args := f.Called(ctx, input)
-	return args.Get(0).([]usecase.FindTransfersByAccountUseCaseOutput), args.Error(1)
+	return args.Get(0).(*usecase.FindTransfersByAccountUseCasePageOutput), args.Error(1)
 }
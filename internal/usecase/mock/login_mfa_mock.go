@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginMFAUseCaseMock struct {
+	mock.Mock
+}
+
+func NewLoginMFAUseCaseMock() *LoginMFAUseCaseMock {
+	return &LoginMFAUseCaseMock{}
+}
+
+func (m *LoginMFAUseCaseMock) Execute(ctx context.Context, input *usecase.LoginMFAUseCaseInput) (*usecase.TokenUseCaseOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*usecase.TokenUseCaseOutput), args.Error(1)
+}
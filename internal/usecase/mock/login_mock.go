@@ -15,7 +15,7 @@ func NewLoginUseCaseMock() *LoginUseCaseMock {
 	return &LoginUseCaseMock{}
 }
 
-func (f *LoginUseCaseMock) Execute(ctx context.Context, input *usecase.LoginUseCaseInput) (*usecase.LoginUseCaseOutput, error) {
+func (f *LoginUseCaseMock) Execute(ctx context.Context, input *usecase.LoginUseCaseInput) (*usecase.TokenUseCaseOutput, error) {
 	args := f.Called(ctx, input)
-	return args.Get(0).(*usecase.LoginUseCaseOutput), args.Error(1)
+	return args.Get(0).(*usecase.TokenUseCaseOutput), args.Error(1)
 }
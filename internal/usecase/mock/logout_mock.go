@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LogoutUseCaseMock struct {
+	mock.Mock
+}
+
+func NewLogoutUseCaseMock() *LogoutUseCaseMock {
+	return &LogoutUseCaseMock{}
+}
+
+func (f *LogoutUseCaseMock) Execute(ctx context.Context, input *usecase.LogoutUseCaseInput) error {
+	args := f.Called(ctx, input)
+	return args.Error(0)
+}
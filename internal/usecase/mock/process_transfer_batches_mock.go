@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ProcessTransferBatchesUseCaseMock struct {
+	mock.Mock
+}
+
+func NewProcessTransferBatchesUseCaseMock() *ProcessTransferBatchesUseCaseMock {
+	return &ProcessTransferBatchesUseCaseMock{}
+}
+
+func (p *ProcessTransferBatchesUseCaseMock) Execute(ctx context.Context, input *usecase.ProcessTransferBatchesUseCaseInput) (*usecase.ProcessTransferBatchesUseCaseOutput, error) {
+	args := p.Called(ctx, input)
+	return args.Get(0).(*usecase.ProcessTransferBatchesUseCaseOutput), args.Error(1)
+}
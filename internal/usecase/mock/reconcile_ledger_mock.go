@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReconcileLedgerUseCaseMock struct {
+	mock.Mock
+}
+
+func NewReconcileLedgerUseCaseMock() *ReconcileLedgerUseCaseMock {
+	return &ReconcileLedgerUseCaseMock{}
+}
+
+func (f *ReconcileLedgerUseCaseMock) Execute(ctx context.Context, input *usecase.ReconcileLedgerUseCaseInput) (*usecase.ReconcileLedgerUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.ReconcileLedgerUseCaseOutput), args.Error(1)
+}
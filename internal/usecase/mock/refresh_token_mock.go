@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RefreshTokenUseCaseMock struct {
+	mock.Mock
+}
+
+func NewRefreshTokenUseCaseMock() *RefreshTokenUseCaseMock {
+	return &RefreshTokenUseCaseMock{}
+}
+
+func (f *RefreshTokenUseCaseMock) Execute(ctx context.Context, input *usecase.RefreshTokenUseCaseInput) (*usecase.TokenUseCaseOutput, error) {
+	args := f.Called(ctx, input)
+	return args.Get(0).(*usecase.TokenUseCaseOutput), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReleaseHoldUseCaseMock struct {
+	mock.Mock
+}
+
+func NewReleaseHoldUseCaseMock() *ReleaseHoldUseCaseMock {
+	return &ReleaseHoldUseCaseMock{}
+}
+
+func (r *ReleaseHoldUseCaseMock) Execute(ctx context.Context, input *usecase.ReleaseHoldUseCaseInput) (*usecase.HoldUseCaseOutput, error) {
+	args := r.Called(ctx, input)
+	return args.Get(0).(*usecase.HoldUseCaseOutput), args.Error(1)
+}
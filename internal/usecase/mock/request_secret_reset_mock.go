@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RequestSecretResetUseCaseMock struct {
+	mock.Mock
+}
+
+func NewRequestSecretResetUseCaseMock() *RequestSecretResetUseCaseMock {
+	return &RequestSecretResetUseCaseMock{}
+}
+
+func (f *RequestSecretResetUseCaseMock) Execute(ctx context.Context, input *usecase.RequestSecretResetUseCaseInput) error {
+	args := f.Called(ctx, input)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ResetSecretUseCaseMock struct {
+	mock.Mock
+}
+
+func NewResetSecretUseCaseMock() *ResetSecretUseCaseMock {
+	return &ResetSecretUseCaseMock{}
+}
+
+func (f *ResetSecretUseCaseMock) Execute(ctx context.Context, input *usecase.ResetSecretUseCaseInput) error {
+	args := f.Called(ctx, input)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReverseTransferUseCaseMock struct {
+	mock.Mock
+}
+
+func NewReverseTransferUseCaseMock() *ReverseTransferUseCaseMock {
+	return &ReverseTransferUseCaseMock{}
+}
+
+func (r *ReverseTransferUseCaseMock) Execute(ctx context.Context, input *usecase.ReverseTransferUseCaseInput) (*usecase.ReverseTransferUseCaseOutput, error) {
+	args := r.Called(ctx, input)
+	return args.Get(0).(*usecase.ReverseTransferUseCaseOutput), args.Error(1)
+}
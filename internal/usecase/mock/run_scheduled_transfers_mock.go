@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RunScheduledTransfersUseCaseMock struct {
+	mock.Mock
+}
+
+func NewRunScheduledTransfersUseCaseMock() *RunScheduledTransfersUseCaseMock {
+	return &RunScheduledTransfersUseCaseMock{}
+}
+
+func (r *RunScheduledTransfersUseCaseMock) Execute(ctx context.Context, input *usecase.RunScheduledTransfersUseCaseInput) (*usecase.RunScheduledTransfersUseCaseOutput, error) {
+	args := r.Called(ctx, input)
+	return args.Get(0).(*usecase.RunScheduledTransfersUseCaseOutput), args.Error(1)
+}
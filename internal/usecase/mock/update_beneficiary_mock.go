@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type UpdateBeneficiaryUseCaseMock struct {
+	mock.Mock
+}
+
+func NewUpdateBeneficiaryUseCaseMock() *UpdateBeneficiaryUseCaseMock {
+	return &UpdateBeneficiaryUseCaseMock{}
+}
+
+func (u *UpdateBeneficiaryUseCaseMock) Execute(ctx context.Context, input *usecase.UpdateBeneficiaryUseCaseInput) (*usecase.BeneficiaryUseCaseOutput, error) {
+	args := u.Called(ctx, input)
+	return args.Get(0).(*usecase.BeneficiaryUseCaseOutput), args.Error(1)
+}
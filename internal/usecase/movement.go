@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// movementUseCase holds what deposits and withdrawals have in common: the account is locked
+// inside a transaction, the movement is persisted and posted to the ledger before commit.
+type movementUseCase struct {
+	accountRepository  entity.AccountRepository
+	movementRepository entity.MovementRepository
+	ledgerRepository   entity.LedgerRepository
+	entity.Repository
+}
+
+func (m *movementUseCase) execute(ctx context.Context, movementType entity.MovementType, input *MovementUseCaseInput) (*MovementUseCaseOutput, error) {
+
+	transaction, err := m.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = m.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = m.RollbackTx(transaction)
+		} else {
+			_ = m.CommitTx(transaction)
+		}
+	}()
+
+	account, err := m.accountRepository.FindByIDForUpdate(ctx, input.AccountID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := entity.NewMovement(input.ID, &account, movementType, input.Channel, input.Reference, input.Amount, input.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = movement.Apply()
+	if err != nil {
+		return nil, err
+	}
+
+	createdMovement, err := m.movementRepository.Create(ctx, movement, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := entity.NewMovementJournalEntry(movement)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.ledgerRepository.Post(ctx, entry, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	createdMovement.Account = movement.Account
+
+	return NewMovementUseCaseOutput(&createdMovement), nil
+}
+
+type MovementUseCaseInput struct {
+	ID        string                 `json:"-"`
+	AccountID string                 `json:"-"`
+	Channel   entity.MovementChannel `json:"channel"`
+	Reference string                 `json:"reference"`
+	Amount    entity.Money           `json:"amount"`
+	CreatedAt *time.Time             `json:"-"`
+}
+
+func NewMovementUseCaseInput(ID string, accountID string, channel entity.MovementChannel, reference string, amount entity.Money, createdAt *time.Time) *MovementUseCaseInput {
+	return &MovementUseCaseInput{
+		ID:        ID,
+		AccountID: accountID,
+		Channel:   channel,
+		Reference: reference,
+		Amount:    amount,
+		CreatedAt: createdAt,
+	}
+}
+
+type MovementUseCaseOutput struct {
+	ID        string       `json:"id"`
+	AccountID string       `json:"account_id"`
+	Type      string       `json:"type"`
+	Channel   string       `json:"channel"`
+	Reference string       `json:"reference"`
+	Amount    entity.Money `json:"amount"`
+	Balance   entity.Money `json:"balance"`
+	CreatedAt string       `json:"created_at"`
+}
+
+func NewMovementUseCaseOutput(movement *entity.Movement) *MovementUseCaseOutput {
+	return &MovementUseCaseOutput{
+		ID:        movement.ID,
+		AccountID: movement.Account.ID,
+		Type:      string(movement.Type),
+		Channel:   string(movement.Channel),
+		Reference: movement.Reference,
+		Amount:    movement.Amount,
+		Balance:   movement.Account.Balance,
+		CreatedAt: movement.CreatedAt.Format(time.RFC3339),
+	}
+}
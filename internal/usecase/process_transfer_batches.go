@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IProcessTransferBatchesUseCase interface {
+	Execute(ctx context.Context, input *ProcessTransferBatchesUseCaseInput) (*ProcessTransferBatchesUseCaseOutput, error)
+}
+
+// ProcessTransferBatchesUseCase makes the transfers of the pending batches. Each batch is
+// claimed before its transfers are made, so it is processed at most once even with many
+// workers. The transfers of an ATOMIC batch are made in a single transaction, the ones of a
+// BEST_EFFORT batch one by one, and the outcome of every item is saved on the batch. A batch
+// whose processing breaks is failed instead of being left PROCESSING, and the batches still
+// PROCESSING after processingTimeout, whose worker stopped midway, are failed by the next run.
+type ProcessTransferBatchesUseCase struct {
+	repository          entity.TransferBatchRepository
+	makeTransferUseCase IMakeTransferUseCase
+	batchSize           int
+	processingTimeout   time.Duration
+}
+
+func NewProcessTransferBatchesUseCase(repository entity.TransferBatchRepository, makeTransferUseCase IMakeTransferUseCase, batchSize int, processingTimeout time.Duration) *ProcessTransferBatchesUseCase {
+	return &ProcessTransferBatchesUseCase{
+		repository:          repository,
+		makeTransferUseCase: makeTransferUseCase,
+		batchSize:           batchSize,
+		processingTimeout:   processingTimeout,
+	}
+}
+
+func (p *ProcessTransferBatchesUseCase) Execute(ctx context.Context, input *ProcessTransferBatchesUseCaseInput) (*ProcessTransferBatchesUseCaseOutput, error) {
+	output := &ProcessTransferBatchesUseCaseOutput{}
+
+	err := p.failStuck(ctx, input.Now, output)
+	if err != nil {
+		return nil, err
+	}
+
+	batchIDs, err := p.repository.FindPendingIDs(ctx, p.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, batchID := range batchIDs {
+		batch, err := p.repository.FindByID(ctx, batchID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = batch.Start(input.Now)
+		if err != nil {
+			continue
+		}
+
+		claimed, err := p.repository.Start(ctx, &batch)
+		if err != nil {
+			return nil, err
+		}
+
+		// another worker took the batch meanwhile
+		if !claimed {
+			continue
+		}
+
+		// from here on the batch is ours, an error fails it and the next batch is processed
+		err = p.process(ctx, &batch, input.Now)
+		if err != nil {
+			p.fail(ctx, &batch, err, input.Now)
+			output.Aborted++
+			continue
+		}
+
+		output.Processed++
+		output.Succeeded += batch.Count(entity.SUCCEEDED_BATCH_ITEM)
+		output.Failed += batch.Count(entity.FAILED_BATCH_ITEM) + batch.Count(entity.CANCELLED_BATCH_ITEM)
+	}
+
+	return output, nil
+}
+
+func (p *ProcessTransferBatchesUseCase) process(ctx context.Context, batch *entity.TransferBatch, now time.Time) error {
+	var err error
+	if batch.Mode == entity.ATOMIC_BATCH {
+		err = p.processAtomically(ctx, batch, now)
+	} else {
+		err = p.processEach(ctx, batch, now)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the batch is only marked as finished once it is stored, otherwise it could not be failed
+	finished := *batch
+	err = finished.Finish(now)
+	if err != nil {
+		return err
+	}
+
+	err = p.repository.Finish(ctx, &finished)
+	if err != nil {
+		return err
+	}
+
+	*batch = finished
+	return nil
+}
+
+// fail stores the outcome of the items already known and ends the batch as FAILED with the
+// error as the reason. Errors here are not returned: a batch that could not be failed is still
+// PROCESSING and is failed by the sweep of the stuck batches.
+func (p *ProcessTransferBatchesUseCase) fail(ctx context.Context, batch *entity.TransferBatch, failure error, now time.Time) {
+	for i := range batch.Items {
+		if batch.Items[i].Status == entity.PENDING_BATCH_ITEM {
+			continue
+		}
+
+		_ = p.repository.UpdateItem(ctx, batch.ID, &batch.Items[i])
+	}
+
+	err := batch.Fail(failure, now)
+	if err != nil {
+		return
+	}
+
+	_ = p.repository.Finish(ctx, batch)
+}
+
+// failStuck fails the batches left PROCESSING by a worker that stopped without finishing them,
+// for instance because the API was restarted. Their items keep the outcome already stored.
+func (p *ProcessTransferBatchesUseCase) failStuck(ctx context.Context, now time.Time, output *ProcessTransferBatchesUseCaseOutput) error {
+	batchIDs, err := p.repository.FindStuckIDs(ctx, now.Add(-p.processingTimeout), p.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, batchID := range batchIDs {
+		batch, err := p.repository.FindByID(ctx, batchID)
+		if err != nil {
+			return err
+		}
+
+		err = batch.Fail(errors.New("transfer batch processing timed out"), now)
+		if err != nil {
+			continue
+		}
+
+		err = p.repository.Finish(ctx, &batch)
+		if err != nil {
+			// finished by its worker since it was listed
+			if isConflict(err) {
+				continue
+			}
+			return err
+		}
+
+		output.Aborted++
+	}
+
+	return nil
+}
+
+// processEach makes the transfers one by one, the outcome of each item is saved as soon as it
+// is known so a batch interrupted midway still reports the transfers already made.
+func (p *ProcessTransferBatchesUseCase) processEach(ctx context.Context, batch *entity.TransferBatch, now time.Time) error {
+	for i, item := range batch.Items {
+		transferInput := NewMakeTransferUseCaseInput("", batch.OriginAccountID, item.DestinationAccountID, item.Amount, "", &now)
+
+		var transferID string
+		transfer, transferErr := p.makeTransferUseCase.Execute(ctx, transferInput)
+		if transferErr == nil {
+			transferID = transfer.ID
+		}
+
+		batch.RecordItem(i, transferID, transferErr)
+
+		err := p.repository.UpdateItem(ctx, batch.ID, &batch.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processAtomically makes every transfer or none of them.
+func (p *ProcessTransferBatchesUseCase) processAtomically(ctx context.Context, batch *entity.TransferBatch, now time.Time) error {
+	transferInputs := make([]*MakeTransferUseCaseInput, len(batch.Items))
+	for i, item := range batch.Items {
+		transferInputs[i] = NewMakeTransferUseCaseInput("", batch.OriginAccountID, item.DestinationAccountID, item.Amount, "", &now)
+	}
+
+	transfers, failed, transferErr := p.makeTransferUseCase.ExecuteAtomically(ctx, transferInputs)
+	if transferErr != nil {
+		batch.RollBack(failed, transferErr)
+	} else {
+		for i, transfer := range transfers {
+			batch.RecordItem(i, transfer.ID, nil)
+		}
+	}
+
+	for i := range batch.Items {
+		err := p.repository.UpdateItem(ctx, batch.ID, &batch.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type ProcessTransferBatchesUseCaseInput struct {
+	Now time.Time
+}
+
+func NewProcessTransferBatchesUseCaseInput(now time.Time) *ProcessTransferBatchesUseCaseInput {
+	return &ProcessTransferBatchesUseCaseInput{
+		Now: now,
+	}
+}
+
+// ProcessTransferBatchesUseCaseOutput counts the batches processed and their items, the
+// cancelled items of the ATOMIC batches count as failed. Aborted counts the batches failed
+// because their processing broke or timed out, their items are not counted.
+type ProcessTransferBatchesUseCaseOutput struct {
+	Processed int
+	Succeeded int
+	Failed    int
+	Aborted   int
+}
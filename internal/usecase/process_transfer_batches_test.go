@@ -0,0 +1,237 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessTransferBatchesUseCase_Execute(t *testing.T) {
+	now := time.Date(2023, 8, 14, 10, 00, 30, 00, time.UTC)
+
+	t.Run("Testing ProcessTransferBatchesUseCase makes each transfer of a best effort batch", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.BEST_EFFORT_BATCH)
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{batch.ID}, nil)
+		repository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+		repository.On("Start", ctx, testify.MatchedBy(func(started *entity.TransferBatch) bool {
+			return started.Status == entity.PROCESSING_BATCH
+		})).Return(true, nil)
+		repository.On("UpdateItem", ctx, batch.ID, testify.MatchedBy(func(item *entity.TransferBatchItem) bool {
+			return item.Position == 0 && item.Status == entity.SUCCEEDED_BATCH_ITEM && item.TransferID == "237d3e7e-2f46-44e7-bf2b-f79721459241"
+		})).Return(nil).Once()
+		repository.On("UpdateItem", ctx, batch.ID, testify.MatchedBy(func(item *entity.TransferBatchItem) bool {
+			return item.Position == 1 && item.Status == entity.FAILED_BATCH_ITEM && item.FailureReason == "insufficient balance"
+		})).Return(nil).Once()
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.Status == entity.PARTIALLY_COMPLETED_BATCH
+		})).Return(nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("Execute", ctx, usecase.NewMakeTransferUseCaseInput("", batch.OriginAccountID, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(50, entity.BRL), "", &now)).
+			Return(&usecase.MakeTransferUseCaseOutput{ID: "237d3e7e-2f46-44e7-bf2b-f79721459241"}, nil).Once()
+		makeTransferUseCase.On("Execute", ctx, testify.Anything).
+			Return((*usecase.MakeTransferUseCaseOutput)(nil), entity.NewErrorHandler(entity.ENTITY_ERROR).Add("insufficient balance")).Once()
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, makeTransferUseCase, 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{Processed: 1, Succeeded: 1, Failed: 1}, output)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase makes every transfer of an atomic batch", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.ATOMIC_BATCH)
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{batch.ID}, nil)
+		repository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+		repository.On("Start", ctx, testify.Anything).Return(true, nil)
+		repository.On("UpdateItem", ctx, batch.ID, testify.MatchedBy(func(item *entity.TransferBatchItem) bool {
+			return item.Status == entity.SUCCEEDED_BATCH_ITEM
+		})).Return(nil).Twice()
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.Status == entity.COMPLETED_BATCH && finished.Items[1].TransferID == "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+		})).Return(nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("ExecuteAtomically", ctx, []*usecase.MakeTransferUseCaseInput{
+			usecase.NewMakeTransferUseCaseInput("", batch.OriginAccountID, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(50, entity.BRL), "", &now),
+			usecase.NewMakeTransferUseCaseInput("", batch.OriginAccountID, "6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", entity.NewMoney(30, entity.BRL), "", &now),
+		}).Return([]*usecase.MakeTransferUseCaseOutput{{ID: "237d3e7e-2f46-44e7-bf2b-f79721459241"}, {ID: "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"}}, -1, nil)
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, makeTransferUseCase, 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{Processed: 1, Succeeded: 2}, output)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase rolls back an atomic batch when a transfer fails", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.ATOMIC_BATCH)
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{batch.ID}, nil)
+		repository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+		repository.On("Start", ctx, testify.Anything).Return(true, nil)
+		repository.On("UpdateItem", ctx, batch.ID, testify.Anything).Return(nil)
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.Status == entity.FAILED_BATCH && finished.FailureReason == "item 1: transfer exceeds the daily limit" &&
+				finished.Items[0].Status == entity.CANCELLED_BATCH_ITEM && finished.Items[1].Status == entity.FAILED_BATCH_ITEM
+		})).Return(nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("ExecuteAtomically", ctx, testify.Anything).
+			Return([]*usecase.MakeTransferUseCaseOutput(nil), 1, entity.NewErrorHandler(entity.ENTITY_ERROR).Add("transfer exceeds the daily limit"))
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, makeTransferUseCase, 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{Processed: 1, Failed: 2}, output)
+		repository.AssertNumberOfCalls(t, "UpdateItem", 2)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase skips the batches claimed by another worker", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.BEST_EFFORT_BATCH)
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{batch.ID}, nil)
+		repository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+		repository.On("Start", ctx, testify.Anything).Return(false, nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, makeTransferUseCase, 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{}, output)
+		makeTransferUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+		repository.AssertNotCalled(t, "Finish", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase fails a broken batch and goes on with the next one", func(t *testing.T) {
+		ctx := context.Background()
+		broken := GetTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		next := GetTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		next.ID = "0d5c9a7e-4b3f-4f2a-9e8d-7c6b5a4f3e2d"
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{broken.ID, next.ID}, nil)
+		repository.On("FindByID", ctx, broken.ID).Return(broken, nil)
+		repository.On("FindByID", ctx, next.ID).Return(next, nil)
+		repository.On("Start", ctx, testify.Anything).Return(true, nil)
+		repository.On("UpdateItem", ctx, broken.ID, testify.MatchedBy(func(item *entity.TransferBatchItem) bool {
+			return item.Position == 1
+		})).Return(errors.New("connection closed")).Once()
+		repository.On("UpdateItem", ctx, testify.Anything, testify.Anything).Return(nil)
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.ID == broken.ID && finished.Status == entity.FAILED_BATCH && finished.FailureReason == "connection closed"
+		})).Return(nil)
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.ID == next.ID && finished.Status == entity.COMPLETED_BATCH
+		})).Return(nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("Execute", ctx, testify.Anything).Return(&usecase.MakeTransferUseCaseOutput{ID: "237d3e7e-2f46-44e7-bf2b-f79721459241"}, nil)
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, makeTransferUseCase, 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{Processed: 1, Succeeded: 2, Aborted: 1}, output)
+		repository.AssertExpectations(t)
+		// the outcomes known when the batch broke are stored again before it is failed
+		repository.AssertNumberOfCalls(t, "UpdateItem", 6)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase fails a batch that could not be finished", func(t *testing.T) {
+		ctx := context.Background()
+		batch := GetTransferBatch(t, entity.ATOMIC_BATCH)
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{batch.ID}, nil)
+		repository.On("FindByID", ctx, batch.ID).Return(batch, nil)
+		repository.On("Start", ctx, testify.Anything).Return(true, nil)
+		repository.On("UpdateItem", ctx, batch.ID, testify.Anything).Return(nil)
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.Status == entity.COMPLETED_BATCH
+		})).Return(errors.New("connection closed"))
+		repository.On("Finish", ctx, testify.MatchedBy(func(finished *entity.TransferBatch) bool {
+			return finished.Status == entity.FAILED_BATCH && finished.FailureReason == "connection closed" && finished.Count(entity.SUCCEEDED_BATCH_ITEM) == 2
+		})).Return(nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("ExecuteAtomically", ctx, testify.Anything).
+			Return([]*usecase.MakeTransferUseCaseOutput{{ID: "237d3e7e-2f46-44e7-bf2b-f79721459241"}, {ID: "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"}}, -1, nil)
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, makeTransferUseCase, 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{Aborted: 1}, output)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase fails the batches stuck in processing", func(t *testing.T) {
+		ctx := context.Background()
+		stuck := GetTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		assert.Nil(t, stuck.Start(now.Add(-time.Hour)))
+		stuck.RecordItem(0, "237d3e7e-2f46-44e7-bf2b-f79721459241", nil)
+
+		finished := GetTransferBatch(t, entity.BEST_EFFORT_BATCH)
+		finished.ID = "0d5c9a7e-4b3f-4f2a-9e8d-7c6b5a4f3e2d"
+		assert.Nil(t, finished.Start(now.Add(-time.Hour)))
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{stuck.ID, finished.ID}, nil)
+		repository.On("FindByID", ctx, stuck.ID).Return(stuck, nil)
+		repository.On("FindByID", ctx, finished.ID).Return(finished, nil)
+		repository.On("Finish", ctx, testify.MatchedBy(func(failed *entity.TransferBatch) bool {
+			return failed.ID == stuck.ID && failed.Status == entity.FAILED_BATCH && failed.FailureReason == "transfer batch processing timed out" &&
+				failed.Items[0].Status == entity.SUCCEEDED_BATCH_ITEM && failed.Items[1].Status == entity.PENDING_BATCH_ITEM
+		})).Return(nil)
+		// the worker finished the second batch after it was listed
+		repository.On("Finish", ctx, testify.MatchedBy(func(failed *entity.TransferBatch) bool {
+			return failed.ID == finished.ID
+		})).Return(entity.NewErrorHandler(entity.CONFLICT_ERROR).Add("transfer batch is no longer processing: 0d5c9a7e-4b3f-4f2a-9e8d-7c6b5a4f3e2d"))
+		repository.On("FindPendingIDs", ctx, 10).Return([]string{}, nil)
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, usecaseMock.NewMakeTransferUseCaseMock(), 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.ProcessTransferBatchesUseCaseOutput{Aborted: 1}, output)
+		repository.AssertExpectations(t)
+		repository.AssertNotCalled(t, "UpdateItem", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ProcessTransferBatchesUseCase when repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewTransferBatchRepositoryMock()
+		repository.On("FindStuckIDs", ctx, now.Add(-15*time.Minute), 10).Return([]string{}, nil)
+		repository.On("FindPendingIDs", ctx, 10).Return([]string(nil), errors.New("connection closed"))
+
+		output, err := usecase.NewProcessTransferBatchesUseCase(repository, usecaseMock.NewMakeTransferUseCaseMock(), 10, 15*time.Minute).Execute(ctx, usecase.NewProcessTransferBatchesUseCaseInput(now))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
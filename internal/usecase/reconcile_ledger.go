@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IReconcileLedgerUseCase interface {
+	Execute(ctx context.Context, input *ReconcileLedgerUseCaseInput) (*ReconcileLedgerUseCaseOutput, error)
+}
+
+// ReconcileLedgerUseCase compares the cached balance of every account with the sum of its
+// postings and reports the journal entries whose postings do not balance.
+type ReconcileLedgerUseCase struct {
+	ledgerRepository entity.LedgerRepository
+}
+
+func NewReconcileLedgerUseCase(ledgerRepository entity.LedgerRepository) *ReconcileLedgerUseCase {
+	return &ReconcileLedgerUseCase{
+		ledgerRepository: ledgerRepository,
+	}
+}
+
+func (r *ReconcileLedgerUseCase) Execute(ctx context.Context, input *ReconcileLedgerUseCaseInput) (*ReconcileLedgerUseCaseOutput, error) {
+	balances, err := r.ledgerRepository.FindBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unbalancedEntries, err := r.ledgerRepository.FindUnbalancedEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []ReconcileLedgerUseCaseDriftOutput{}
+	for _, balance := range balances {
+		if balance.HasDrift() {
+			drifts = append(drifts, ReconcileLedgerUseCaseDriftOutput{
+				AccountID:     balance.AccountID,
+				CachedBalance: balance.CachedBalance,
+				LedgerBalance: balance.LedgerBalance,
+				Drift:         balance.Drift(),
+			})
+		}
+	}
+
+	return NewReconcileLedgerUseCaseOutput(len(balances), drifts, unbalancedEntries), nil
+}
+
+type ReconcileLedgerUseCaseInput struct {
+}
+
+func NewReconcileLedgerUseCaseInput() *ReconcileLedgerUseCaseInput {
+	return &ReconcileLedgerUseCaseInput{}
+}
+
+type ReconcileLedgerUseCaseOutput struct {
+	AccountsChecked   int                                 `json:"accounts_checked"`
+	Drifts            []ReconcileLedgerUseCaseDriftOutput `json:"drifts"`
+	UnbalancedEntries []string                            `json:"unbalanced_entries"`
+}
+
+type ReconcileLedgerUseCaseDriftOutput struct {
+	AccountID     string       `json:"account_id"`
+	CachedBalance entity.Money `json:"cached_balance"`
+	LedgerBalance entity.Money `json:"ledger_balance"`
+	Drift         entity.Money `json:"drift"`
+}
+
+func NewReconcileLedgerUseCaseOutput(accountsChecked int, drifts []ReconcileLedgerUseCaseDriftOutput, unbalancedEntries []string) *ReconcileLedgerUseCaseOutput {
+	return &ReconcileLedgerUseCaseOutput{
+		AccountsChecked:   accountsChecked,
+		Drifts:            drifts,
+		UnbalancedEntries: unbalancedEntries,
+	}
+}
+
+// IsConsistent reports whether the cached balances match the ledger and every journal entry balances.
+func (o *ReconcileLedgerUseCaseOutput) IsConsistent() bool {
+	return len(o.Drifts) == 0 && len(o.UnbalancedEntries) == 0
+}
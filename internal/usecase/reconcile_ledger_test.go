@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileLedgerUseCase_Execute(t *testing.T) {
+
+	t.Run("Testing ReconcileLedgerUseCase when the ledger is consistent", func(t *testing.T) {
+		ctx := context.Background()
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("FindBalances", ctx).Return([]entity.LedgerBalance{
+			{AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", CachedBalance: entity.NewMoney(100, entity.BRL), LedgerBalance: entity.NewMoney(100, entity.BRL)},
+			{AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", CachedBalance: entity.NewMoney(0, entity.BRL), LedgerBalance: entity.NewMoney(0, entity.BRL)},
+		}, nil)
+		ledgerRepository.On("FindUnbalancedEntries", ctx).Return([]string{}, nil)
+
+		reconcileLedgerUseCase := usecase.NewReconcileLedgerUseCase(ledgerRepository)
+		output, err := reconcileLedgerUseCase.Execute(ctx, usecase.NewReconcileLedgerUseCaseInput())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, output.AccountsChecked)
+		assert.Empty(t, output.Drifts)
+		assert.Empty(t, output.UnbalancedEntries)
+		assert.True(t, output.IsConsistent())
+	})
+
+	t.Run("Testing ReconcileLedgerUseCase when there are drifts and unbalanced entries", func(t *testing.T) {
+		ctx := context.Background()
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("FindBalances", ctx).Return([]entity.LedgerBalance{
+			{AccountID: "2bd765a6-47bd-4731-9eb2-1e65542f4477", CachedBalance: entity.NewMoney(150, entity.BRL), LedgerBalance: entity.NewMoney(100, entity.BRL)},
+			{AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6", CachedBalance: entity.NewMoney(200, entity.BRL), LedgerBalance: entity.NewMoney(200, entity.BRL)},
+		}, nil)
+		ledgerRepository.On("FindUnbalancedEntries", ctx).Return([]string{"6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"}, nil)
+
+		reconcileLedgerUseCase := usecase.NewReconcileLedgerUseCase(ledgerRepository)
+		output, err := reconcileLedgerUseCase.Execute(ctx, usecase.NewReconcileLedgerUseCaseInput())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, output.AccountsChecked)
+		assert.Len(t, output.Drifts, 1)
+		assert.Equal(t, "2bd765a6-47bd-4731-9eb2-1e65542f4477", output.Drifts[0].AccountID)
+		assert.Equal(t, entity.NewMoney(50, entity.BRL), output.Drifts[0].Drift)
+		assert.Equal(t, []string{"6ac7ebbf-568b-45f2-a295-bfbab73f1cf6"}, output.UnbalancedEntries)
+		assert.False(t, output.IsConsistent())
+	})
+
+	t.Run("Testing ReconcileLedgerUseCase when FindBalances returns error", func(t *testing.T) {
+		ctx := context.Background()
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("FindBalances", ctx).Return([]entity.LedgerBalance{}, errors.New("error on find balances"))
+
+		reconcileLedgerUseCase := usecase.NewReconcileLedgerUseCase(ledgerRepository)
+		output, err := reconcileLedgerUseCase.Execute(ctx, usecase.NewReconcileLedgerUseCaseInput())
+
+		assert.Nil(t, output)
+		assert.Equal(t, "error on find balances", err.Error())
+		ledgerRepository.AssertNotCalled(t, "FindUnbalancedEntries", ctx)
+	})
+
+	t.Run("Testing ReconcileLedgerUseCase when FindUnbalancedEntries returns error", func(t *testing.T) {
+		ctx := context.Background()
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("FindBalances", ctx).Return([]entity.LedgerBalance{}, nil)
+		ledgerRepository.On("FindUnbalancedEntries", ctx).Return([]string{}, errors.New("error on find unbalanced entries"))
+
+		reconcileLedgerUseCase := usecase.NewReconcileLedgerUseCase(ledgerRepository)
+		output, err := reconcileLedgerUseCase.Execute(ctx, usecase.NewReconcileLedgerUseCaseInput())
+
+		assert.Nil(t, output)
+		assert.Equal(t, "error on find unbalanced entries", err.Error())
+	})
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IRefreshTokenUseCase interface {
+	Execute(ctx context.Context, input *RefreshTokenUseCaseInput) (*TokenUseCaseOutput, error)
+}
+
+// RefreshTokenUseCase trades a refresh token for a new pair of tokens. Refresh tokens are
+// single use: presenting one that was already rotated means it leaked, so the whole family
+// is revoked.
+type RefreshTokenUseCase struct {
+	accountRepository      entity.AccountRepository
+	refreshTokenRepository entity.RefreshTokenRepository
+	tokenIssuer            *TokenIssuer
+	entity.Repository
+}
+
+func NewRefreshTokenUseCase(accountRepository entity.AccountRepository, refreshTokenRepository entity.RefreshTokenRepository, tokenIssuer *TokenIssuer, repository entity.Repository) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		accountRepository:      accountRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		tokenIssuer:            tokenIssuer,
+		Repository:             repository,
+	}
+}
+
+func (rt *RefreshTokenUseCase) Execute(ctx context.Context, input *RefreshTokenUseCaseInput) (*TokenUseCaseOutput, error) {
+	if input.RefreshToken == "" {
+		return nil, entity.NewErrorHandler(entity.BAD_REQUEST).Add("refresh token cannot be empty")
+	}
+
+	transaction, err := rt.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = rt.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = rt.RollbackTx(transaction)
+		} else {
+			_ = rt.CommitTx(transaction)
+		}
+	}()
+
+	stored, err := rt.refreshTokenRepository.FindByHashForUpdate(ctx, entity.HashRefreshToken(input.RefreshToken), transaction)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("refresh token is invalid")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// the family revocation has to be committed, so the rejection is returned without setting err
+	if stored.IsRevoked() {
+		err = rt.refreshTokenRepository.RevokeFamily(ctx, stored.FamilyID, now, transaction)
+		if err != nil {
+			return nil, err
+		}
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("refresh token was already used, the session has been revoked")
+	}
+
+	if stored.IsExpired(now) {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("refresh token is expired")
+	}
+
+	account, err := rt.accountRepository.FindByID(ctx, stored.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, refreshToken, err := rt.tokenIssuer.Issue(ctx, &account, stored.FamilyID, now, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rt.refreshTokenRepository.Revoke(ctx, stored.ID, refreshToken.ID, now, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func isNotFound(err error) bool {
+	errorHandler, ok := err.(*entity.ErrorHandler)
+	return ok && errorHandler.GetTypeError() == entity.NOT_FOUND_ERROR
+}
+
+func isConflict(err error) bool {
+	errorHandler, ok := err.(*entity.ErrorHandler)
+	return ok && errorHandler.GetTypeError() == entity.CONFLICT_ERROR
+}
+
+type RefreshTokenUseCaseInput struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func NewRefreshTokenUseCaseInput(refreshToken string) *RefreshTokenUseCaseInput {
+	return &RefreshTokenUseCaseInput{
+		RefreshToken: refreshToken,
+	}
+}
@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetStoredRefreshToken(t *testing.T, createdAt time.Time) (entity.RefreshToken, string) {
+	refreshToken, raw, err := entity.NewRefreshToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", "", &createdAt, time.Hour)
+	assert.Nil(t, err)
+	return *refreshToken, raw
+}
+
+func TestRefreshTokenUseCase_Execute(t *testing.T) {
+	t.Run("Testing RefreshTokenUseCase rotates the refresh token", func(t *testing.T) {
+		ctx := context.Background()
+		stored, raw := GetStoredRefreshToken(t, time.Now())
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, stored.AccountID).Return(mock.CreateAccount(), nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+		refreshTokenRepository.On("Create", ctx, testify.Anything).Return(entity.RefreshToken{}, nil)
+		refreshTokenRepository.On("Revoke", ctx, stored.ID, testify.Anything, testify.Anything).Return(nil)
+
+		refreshTokenUseCase := usecase.NewRefreshTokenUseCase(accountRepository, refreshTokenRepository, GetTokenIssuer(refreshTokenRepository), repository)
+
+		output, err := refreshTokenUseCase.Execute(ctx, usecase.NewRefreshTokenUseCaseInput(raw))
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, output.Token)
+		assert.NotEqual(t, raw, output.RefreshToken)
+
+		rotated := refreshTokenRepository.Calls[1].Arguments.Get(1).(*entity.RefreshToken)
+		assert.Equal(t, stored.FamilyID, rotated.FamilyID)
+		assert.Equal(t, entity.HashRefreshToken(output.RefreshToken), rotated.TokenHash)
+		refreshTokenRepository.AssertCalled(t, "Revoke", ctx, stored.ID, rotated.ID, testify.Anything)
+		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+	})
+
+	t.Run("Testing RefreshTokenUseCase revokes the family when a rotated token is reused", func(t *testing.T) {
+		ctx := context.Background()
+		stored, raw := GetStoredRefreshToken(t, time.Now())
+		revokedAt := time.Now()
+		stored.RevokedAt = &revokedAt
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+		refreshTokenRepository.On("RevokeFamily", ctx, stored.FamilyID, testify.Anything).Return(nil)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+
+		refreshTokenUseCase := usecase.NewRefreshTokenUseCase(accountRepository, refreshTokenRepository, GetTokenIssuer(refreshTokenRepository), repository)
+
+		output, err := refreshTokenUseCase.Execute(ctx, usecase.NewRefreshTokenUseCaseInput(raw))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "refresh token was already used, the session has been revoked", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+		refreshTokenRepository.AssertCalled(t, "RevokeFamily", ctx, stored.FamilyID, testify.Anything)
+		refreshTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing RefreshTokenUseCase with an expired refresh token", func(t *testing.T) {
+		ctx := context.Background()
+		stored, raw := GetStoredRefreshToken(t, time.Now().Add(-2*time.Hour))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+
+		refreshTokenUseCase := usecase.NewRefreshTokenUseCase(mock.NewAccountRepositoryMock(), refreshTokenRepository, GetTokenIssuer(refreshTokenRepository), repository)
+
+		output, err := refreshTokenUseCase.Execute(ctx, usecase.NewRefreshTokenUseCaseInput(raw))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "refresh token is expired", err.Error())
+		refreshTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing RefreshTokenUseCase with an unknown refresh token", func(t *testing.T) {
+		ctx := context.Background()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("FindByHashForUpdate", ctx, entity.HashRefreshToken("unknown")).
+			Return(entity.RefreshToken{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found refresh token"))
+
+		refreshTokenUseCase := usecase.NewRefreshTokenUseCase(mock.NewAccountRepositoryMock(), refreshTokenRepository, GetTokenIssuer(refreshTokenRepository), repository)
+
+		output, err := refreshTokenUseCase.Execute(ctx, usecase.NewRefreshTokenUseCaseInput("unknown"))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "refresh token is invalid", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing RefreshTokenUseCase without refresh token", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewRepositoryMock()
+
+		refreshTokenUseCase := usecase.NewRefreshTokenUseCase(nil, nil, nil, repository)
+
+		output, err := refreshTokenUseCase.Execute(ctx, usecase.NewRefreshTokenUseCaseInput(""))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "refresh token cannot be empty", err.Error())
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+
+	t.Run("Testing RefreshTokenUseCase rolls back when the old token cannot be revoked", func(t *testing.T) {
+		ctx := context.Background()
+		stored, raw := GetStoredRefreshToken(t, time.Now())
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByID", ctx, stored.AccountID).Return(mock.CreateAccount(), nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+		refreshTokenRepository.On("Create", ctx, testify.Anything).Return(entity.RefreshToken{}, nil)
+		refreshTokenRepository.On("Revoke", ctx, stored.ID, testify.Anything, testify.Anything).Return(errors.New("connection closed"))
+
+		refreshTokenUseCase := usecase.NewRefreshTokenUseCase(accountRepository, refreshTokenRepository, GetTokenIssuer(refreshTokenRepository), repository)
+
+		output, err := refreshTokenUseCase.Execute(ctx, usecase.NewRefreshTokenUseCaseInput(raw))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", testify.Anything)
+	})
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IReleaseHoldUseCase interface {
+	Execute(ctx context.Context, input *ReleaseHoldUseCaseInput) (*HoldUseCaseOutput, error)
+}
+
+// ReleaseHoldUseCase gives back the whole hold to the available balance of the origin account
+// when the recipient will not capture it.
+type ReleaseHoldUseCase struct {
+	accountRepository entity.AccountRepository
+	holdRepository    entity.HoldRepository
+	entity.Repository
+}
+
+func NewReleaseHoldUseCase(accountRepository entity.AccountRepository, holdRepository entity.HoldRepository, repository entity.Repository) *ReleaseHoldUseCase {
+	return &ReleaseHoldUseCase{
+		accountRepository: accountRepository,
+		holdRepository:    holdRepository,
+		Repository:        repository,
+	}
+}
+
+func (rh *ReleaseHoldUseCase) Execute(ctx context.Context, input *ReleaseHoldUseCaseInput) (*HoldUseCaseOutput, error) {
+	if input.Principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	transaction, err := rh.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = rh.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = rh.RollbackTx(transaction)
+		} else {
+			_ = rh.CommitTx(transaction)
+		}
+	}()
+
+	hold, err := rh.holdRepository.FindByIDForUpdate(ctx, input.HoldID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeHoldRecipient(input.Principal, &hold, "release")
+	if err != nil {
+		return nil, err
+	}
+
+	originAccount, err := rh.accountRepository.FindByIDForUpdate(ctx, hold.OriginAccount.ID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	hold.OriginAccount = &originAccount
+
+	err = hold.Release(input.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rh.holdRepository.Finish(ctx, &hold, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHoldUseCaseOutput(&hold), nil
+}
+
+type ReleaseHoldUseCaseInput struct {
+	HoldID    string
+	Principal *entity.Principal
+	Now       time.Time
+}
+
+func NewReleaseHoldUseCaseInput(holdID string, principal *entity.Principal, now time.Time) *ReleaseHoldUseCaseInput {
+	return &ReleaseHoldUseCaseInput{
+		HoldID:    holdID,
+		Principal: principal,
+		Now:       now,
+	}
+}
@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseHoldUseCase_Execute(t *testing.T) {
+	releasedAt := time.Date(2023, 8, 15, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing ReleaseHoldUseCase gives back the hold", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetHoldingOriginAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(originAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+		holdRepository.On("Finish", ctx, testify.AnythingOfType("*entity.Hold"), testify.Anything).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewReleaseHoldUseCase(accountRepository, holdRepository, repository)
+		input := usecase.NewReleaseHoldUseCaseInput("7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(GetBaseDestinationAccount(t).ID, entity.CUSTOMER_ROLE), releasedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "released", output.Status)
+		assert.Equal(t, releasedAt.Format(time.RFC3339), output.UpdatedAt)
+
+		holdRepository.AssertCalled(t, "Finish", ctx, testify.MatchedBy(func(hold *entity.Hold) bool {
+			return hold.Status == entity.RELEASED_HOLD && hold.OriginAccount.Held == entity.NewMoney(0, entity.BRL)
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing ReleaseHoldUseCase when the hold was already captured", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+
+		hold := GetActiveHold(t)
+		hold.Status = entity.CAPTURED_HOLD
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, hold.ID, testify.Anything).Return(hold, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewReleaseHoldUseCase(accountRepository, holdRepository, repository)
+		input := usecase.NewReleaseHoldUseCaseInput(hold.ID, entity.NewPrincipal(GetBaseDestinationAccount(t).ID, entity.CUSTOMER_ROLE), releasedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "hold is CAPTURED and cannot be released", err.Error())
+		holdRepository.AssertNotCalled(t, "Finish", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ReleaseHoldUseCase when the sender tries to release", func(t *testing.T) {
+		ctx := context.Background()
+
+		holdRepository := mock.NewHoldRepositoryMock()
+		holdRepository.On("FindByIDForUpdate", ctx, "7c9e6679-7425-40de-944b-e07fc1f90ae7", testify.Anything).Return(GetActiveHold(t), nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		useCase := usecase.NewReleaseHoldUseCase(mock.NewAccountRepositoryMock(), holdRepository, repository)
+		input := usecase.NewReleaseHoldUseCaseInput("7c9e6679-7425-40de-944b-e07fc1f90ae7", entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE), releasedAt)
+		output, err := useCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "only the recipient can release the hold", err.Error())
+	})
+
+	t.Run("Testing ReleaseHoldUseCase without principal", func(t *testing.T) {
+		useCase := usecase.NewReleaseHoldUseCase(mock.NewAccountRepositoryMock(), mock.NewHoldRepositoryMock(), mock.NewRepositoryMock())
+		output, err := useCase.Execute(context.Background(), usecase.NewReleaseHoldUseCaseInput("7c9e6679-7425-40de-944b-e07fc1f90ae7", nil, releasedAt))
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IRequestSecretResetUseCase interface {
+	Execute(ctx context.Context, input *RequestSecretResetUseCaseInput) error
+}
+
+// RequestSecretResetUseCase sends a secret reset token to the owner of the account. It answers
+// the same way whether the CPF has an account or not, so it cannot be used to find accounts.
+// Every request is counted per CPF and per IP with the backoff and lockout of the login, as a
+// request invalidates the token sent before and could otherwise keep the owner from ever
+// finishing a reset.
+type RequestSecretResetUseCase struct {
+	accountRepository          entity.AccountRepository
+	secretResetTokenRepository entity.SecretResetTokenRepository
+	loginThrottleRepository    entity.LoginThrottleRepository
+	notifier                   entity.Notifier
+	cpfPolicy                  entity.LoginThrottlePolicy
+	ipPolicy                   entity.LoginThrottlePolicy
+	ttl                        time.Duration
+	entity.Repository
+}
+
+func NewRequestSecretResetUseCase(accountRepository entity.AccountRepository, secretResetTokenRepository entity.SecretResetTokenRepository, loginThrottleRepository entity.LoginThrottleRepository, notifier entity.Notifier, cpfPolicy entity.LoginThrottlePolicy, ipPolicy entity.LoginThrottlePolicy, ttl time.Duration, repository entity.Repository) *RequestSecretResetUseCase {
+	return &RequestSecretResetUseCase{
+		accountRepository:          accountRepository,
+		secretResetTokenRepository: secretResetTokenRepository,
+		loginThrottleRepository:    loginThrottleRepository,
+		notifier:                   notifier,
+		cpfPolicy:                  cpfPolicy,
+		ipPolicy:                   ipPolicy,
+		ttl:                        ttl,
+		Repository:                 repository,
+	}
+}
+
+func (r *RequestSecretResetUseCase) Execute(ctx context.Context, input *RequestSecretResetUseCaseInput) error {
+	if !entity.REGEXCPF.MatchString(input.CPF) {
+		return entity.NewErrorHandler(entity.ENTITY_ERROR).Add("CPF is invalid")
+	}
+
+	now := time.Now()
+
+	err := r.throttle(ctx, input.CPF, input.IP, now)
+	if err != nil {
+		return err
+	}
+
+	account, err := r.accountRepository.FindByCPF(ctx, input.CPF)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// only the last token requested can be used
+	err = r.secretResetTokenRepository.InvalidateByAccountID(ctx, account.ID, now)
+	if err != nil {
+		return err
+	}
+
+	secretResetToken, raw, err := entity.NewSecretResetToken(account.ID, &now, r.ttl)
+	if err != nil {
+		return err
+	}
+
+	err = r.secretResetTokenRepository.Create(ctx, secretResetToken)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Use the token %s to choose a new secret, it expires at %s.", raw, secretResetToken.ExpiresAt.Format(time.RFC3339))
+	notification, err := entity.NewNotification(account.ID, "Secret reset", message, &now)
+	if err != nil {
+		return err
+	}
+
+	return r.notifier.Notify(ctx, notification)
+}
+
+// throttle counts the request for the CPF and for the IP, with their rows locked so parallel
+// requests are counted one after the other, and rejects it while either of them has to wait.
+// The CPF is locked before the IP, as in the login. The request is counted before the account
+// is looked up, so the answer is still the same whether the CPF has an account or not.
+func (r *RequestSecretResetUseCase) throttle(ctx context.Context, CPF string, IP string, now time.Time) error {
+	keys := []throttledKey{{key: entity.CPFSecretResetThrottleKey(CPF), policy: r.cpfPolicy}}
+	if IP != "" {
+		keys = append(keys, throttledKey{key: entity.IPSecretResetThrottleKey(IP), policy: r.ipPolicy})
+	}
+
+	transaction, err := r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			_ = r.RollbackTx(transaction)
+			panic(rec)
+		}
+		if err != nil {
+			_ = r.RollbackTx(transaction)
+		} else {
+			_ = r.CommitTx(transaction)
+		}
+	}()
+
+	for _, key := range keys {
+		var throttle entity.LoginThrottle
+		throttle, err = r.loginThrottleRepository.FindByKeyForUpdate(ctx, key.key, now, transaction)
+		if err != nil {
+			return err
+		}
+
+		retryAt := key.policy.RetryAt(throttle)
+		if retryAt.After(now) {
+			err = entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).
+				Add("too many secret reset requests, try again later").
+				WithRetryAfter(retryAt.Sub(now))
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		_, err = r.loginThrottleRepository.RegisterFailure(ctx, key.key, now, now.Add(-key.policy.Lockout), transaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type RequestSecretResetUseCaseInput struct {
+	CPF string `json:"cpf"`
+	IP  string `json:"-"`
+}
+
+func NewRequestSecretResetUseCaseInput(CPF string, IP string) *RequestSecretResetUseCaseInput {
+	return &RequestSecretResetUseCaseInput{
+		CPF: CPF,
+		IP:  IP,
+	}
+}
@@ -0,0 +1,139 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+// GetRequestSecretResetUseCase is a use case of a CPF and an IP that have not requested any
+// reset yet.
+func GetRequestSecretResetUseCase(accountRepository entity.AccountRepository, secretResetTokenRepository entity.SecretResetTokenRepository, notifier entity.Notifier) (*usecase.RequestSecretResetUseCase, *mock.LoginThrottleRepositoryMock) {
+	loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+	loginThrottleRepository.On("FindByKeyForUpdate", testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+	loginThrottleRepository.On("RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{Failures: 1}, nil)
+
+	cpfPolicy := entity.NewLoginThrottlePolicy(5, time.Second, time.Minute, 15*time.Minute)
+	ipPolicy := entity.NewLoginThrottlePolicy(20, time.Second, time.Minute, 15*time.Minute)
+
+	return usecase.NewRequestSecretResetUseCase(accountRepository, secretResetTokenRepository, loginThrottleRepository, notifier, cpfPolicy, ipPolicy, 15*time.Minute, GetThrottleRepository()), loginThrottleRepository
+}
+
+func TestRequestSecretResetUseCase_Execute(t *testing.T) {
+	CPF := "34688151071"
+
+	t.Run("Testing RequestSecretResetUseCase sends a new token", func(t *testing.T) {
+		ctx := context.Background()
+		account := mock.CreateAccount()
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByCPF", ctx, CPF).Return(account, nil)
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		secretResetTokenRepository.On("InvalidateByAccountID", ctx, account.ID, testify.Anything).Return(nil)
+		secretResetTokenRepository.On("Create", ctx, testify.Anything).Return(nil)
+
+		notifier := mock.NewNotifierMock()
+		notifier.On("Notify", ctx, testify.Anything).Return(nil)
+
+		requestSecretResetUseCase, loginThrottleRepository := GetRequestSecretResetUseCase(accountRepository, secretResetTokenRepository, notifier)
+
+		err := requestSecretResetUseCase.Execute(ctx, usecase.NewRequestSecretResetUseCaseInput(CPF, "10.0.0.1"))
+		assert.Nil(t, err)
+
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "reset_cpf:"+CPF, testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "reset_ip:10.0.0.1", testify.Anything, testify.Anything)
+
+		stored := secretResetTokenRepository.Calls[1].Arguments.Get(1).(*entity.SecretResetToken)
+		assert.Equal(t, account.ID, stored.AccountID)
+		assert.Equal(t, 15*time.Minute, stored.ExpiresAt.Sub(*stored.CreatedAt))
+
+		notification := notifier.Calls[0].Arguments.Get(1).(*entity.Notification)
+		assert.Equal(t, account.ID, notification.AccountID)
+		assert.Equal(t, "Secret reset", notification.Subject)
+
+		raw := strings.Fields(strings.TrimPrefix(notification.Message, "Use the token "))[0]
+		assert.Equal(t, stored.TokenHash, entity.HashSecretResetToken(raw))
+	})
+
+	t.Run("Testing RequestSecretResetUseCase when account not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByCPF", ctx, CPF).Return(entity.Account{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found account"))
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		notifier := mock.NewNotifierMock()
+
+		requestSecretResetUseCase, loginThrottleRepository := GetRequestSecretResetUseCase(accountRepository, secretResetTokenRepository, notifier)
+
+		err := requestSecretResetUseCase.Execute(ctx, usecase.NewRequestSecretResetUseCaseInput(CPF, "10.0.0.1"))
+		assert.Nil(t, err)
+
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "reset_cpf:"+CPF, testify.Anything, testify.Anything)
+		secretResetTokenRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything)
+		notifier.AssertNotCalled(t, "Notify", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing RequestSecretResetUseCase when repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByCPF", ctx, CPF).Return(entity.Account{}, errors.New("connection closed"))
+
+		requestSecretResetUseCase, _ := GetRequestSecretResetUseCase(accountRepository, mock.NewSecretResetTokenRepositoryMock(), mock.NewNotifierMock())
+
+		err := requestSecretResetUseCase.Execute(ctx, usecase.NewRequestSecretResetUseCaseInput(CPF, "10.0.0.1"))
+		assert.Equal(t, "connection closed", err.Error())
+	})
+
+	t.Run("Testing RequestSecretResetUseCase with invalid CPF", func(t *testing.T) {
+		ctx := context.Background()
+		accountRepository := mock.NewAccountRepositoryMock()
+
+		requestSecretResetUseCase, loginThrottleRepository := GetRequestSecretResetUseCase(accountRepository, mock.NewSecretResetTokenRepositoryMock(), mock.NewNotifierMock())
+
+		err := requestSecretResetUseCase.Execute(ctx, usecase.NewRequestSecretResetUseCaseInput("346881", "10.0.0.1"))
+		assert.Equal(t, "CPF is invalid", err.Error())
+		accountRepository.AssertNotCalled(t, "FindByCPF", testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing RequestSecretResetUseCase when the CPF requested too many resets", func(t *testing.T) {
+		ctx := context.Background()
+		lastFailureAt := time.Now()
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "reset_cpf:"+CPF, testify.Anything).
+			Return(entity.LoginThrottle{Key: "reset_cpf:" + CPF, Failures: 5, LastFailureAt: &lastFailureAt}, nil)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+
+		policy := entity.NewLoginThrottlePolicy(5, time.Second, time.Minute, 15*time.Minute)
+		requestSecretResetUseCase := usecase.NewRequestSecretResetUseCase(accountRepository, secretResetTokenRepository, loginThrottleRepository, mock.NewNotifierMock(), policy, policy, 15*time.Minute, repository)
+
+		err := requestSecretResetUseCase.Execute(ctx, usecase.NewRequestSecretResetUseCaseInput(CPF, "10.0.0.1"))
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Greater(t, err.(*entity.ErrorHandler).RetryAfter, 14*time.Minute)
+
+		accountRepository.AssertNotCalled(t, "FindByCPF", testify.Anything, testify.Anything)
+		secretResetTokenRepository.AssertNotCalled(t, "InvalidateByAccountID", testify.Anything, testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertNotCalled(t, "FindByKeyForUpdate", ctx, "reset_ip:10.0.0.1", testify.Anything)
+		loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+}
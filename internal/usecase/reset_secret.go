@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IResetSecretUseCase interface {
+	Execute(ctx context.Context, input *ResetSecretUseCaseInput) error
+}
+
+// ResetSecretUseCase sets a new secret with a secret reset token. The token is spent and every
+// session of the account is revoked in the same transaction of the new secret.
+type ResetSecretUseCase struct {
+	accountRepository          entity.AccountRepository
+	secretResetTokenRepository entity.SecretResetTokenRepository
+	refreshTokenRepository     entity.RefreshTokenRepository
+	entity.Repository
+}
+
+func NewResetSecretUseCase(accountRepository entity.AccountRepository, secretResetTokenRepository entity.SecretResetTokenRepository, refreshTokenRepository entity.RefreshTokenRepository, repository entity.Repository) *ResetSecretUseCase {
+	return &ResetSecretUseCase{
+		accountRepository:          accountRepository,
+		secretResetTokenRepository: secretResetTokenRepository,
+		refreshTokenRepository:     refreshTokenRepository,
+		Repository:                 repository,
+	}
+}
+
+func (rs *ResetSecretUseCase) Execute(ctx context.Context, input *ResetSecretUseCaseInput) error {
+	if input.Token == "" || input.NewSecret == "" {
+		return entity.NewErrorHandler(entity.BAD_REQUEST).Add("token and new secret cannot be empty")
+	}
+
+	transaction, err := rs.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = rs.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = rs.RollbackTx(transaction)
+		} else {
+			_ = rs.CommitTx(transaction)
+		}
+	}()
+
+	stored, err := rs.secretResetTokenRepository.FindByHashForUpdate(ctx, entity.HashSecretResetToken(input.Token), transaction)
+	if err != nil {
+		if isNotFound(err) {
+			err = entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("secret reset token is invalid or expired")
+		}
+		return err
+	}
+
+	now := time.Now()
+
+	if stored.IsUsed() || stored.IsExpired(now) {
+		err = entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("secret reset token is invalid or expired")
+		return err
+	}
+
+	account, err := rs.accountRepository.FindByIDForUpdate(ctx, stored.AccountID, transaction)
+	if err != nil {
+		return err
+	}
+
+	// a weak secret rolls back, so the token can be used again with a better one
+	err = account.ChangeSecret(input.NewSecret)
+	if err != nil {
+		return err
+	}
+
+	err = rs.accountRepository.UpdateSecret(ctx, account.ID, account.Secret, transaction)
+	if err != nil {
+		return err
+	}
+
+	err = rs.secretResetTokenRepository.InvalidateByAccountID(ctx, account.ID, now, transaction)
+	if err != nil {
+		return err
+	}
+
+	err = rs.refreshTokenRepository.RevokeByAccountID(ctx, account.ID, now, transaction)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type ResetSecretUseCaseInput struct {
+	Token     string `json:"token"`
+	NewSecret string `json:"new_secret"`
+}
+
+func NewResetSecretUseCaseInput(token string, newSecret string) *ResetSecretUseCaseInput {
+	return &ResetSecretUseCaseInput{
+		Token:     token,
+		NewSecret: newSecret,
+	}
+}
@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetSecretResetToken(t *testing.T, createdAt time.Time) (entity.SecretResetToken, string) {
+	secretResetToken, raw, err := entity.NewSecretResetToken("2bd765a6-47bd-4731-9eb2-1e65542f4477", &createdAt, 15*time.Minute)
+	assert.Nil(t, err)
+
+	return *secretResetToken, raw
+}
+
+func TestResetSecretUseCase_Execute(t *testing.T) {
+	t.Run("Testing ResetSecretUseCase sets the new secret and spends the token", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetAccountWithHashedSecret(t)
+		stored, raw := GetSecretResetToken(t, time.Now())
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+		accountRepository.On("UpdateSecret", ctx, account.ID, testify.Anything).Return(nil)
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		secretResetTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+		secretResetTokenRepository.On("InvalidateByAccountID", ctx, account.ID, testify.Anything).Return(nil)
+
+		refreshTokenRepository := mock.NewRefreshTokenRepositoryMock()
+		refreshTokenRepository.On("RevokeByAccountID", ctx, account.ID, testify.Anything).Return(nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		resetSecretUseCase := usecase.NewResetSecretUseCase(accountRepository, secretResetTokenRepository, refreshTokenRepository, repository)
+
+		err := resetSecretUseCase.Execute(ctx, usecase.NewResetSecretUseCaseInput(raw, "n3wSecretValue"))
+		assert.Nil(t, err)
+
+		updated := entity.Account{Secret: accountRepository.Calls[1].Arguments.String(2)}
+		assert.True(t, updated.SecretIsCorrect("n3wSecretValue"))
+
+		secretResetTokenRepository.AssertExpectations(t)
+		refreshTokenRepository.AssertExpectations(t)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing ResetSecretUseCase with an unknown token", func(t *testing.T) {
+		ctx := context.Background()
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		secretResetTokenRepository.On("FindByHashForUpdate", ctx, entity.HashSecretResetToken("unknown")).Return(entity.SecretResetToken{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found secret reset token"))
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		resetSecretUseCase := usecase.NewResetSecretUseCase(mock.NewAccountRepositoryMock(), secretResetTokenRepository, mock.NewRefreshTokenRepositoryMock(), repository)
+
+		err := resetSecretUseCase.Execute(ctx, usecase.NewResetSecretUseCaseInput("unknown", "n3wSecretValue"))
+		assert.Equal(t, "secret reset token is invalid or expired", err.Error())
+		assert.Equal(t, entity.UNAUTHORIZED_ERROR, err.(*entity.ErrorHandler).TypeError)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing ResetSecretUseCase with a used token", func(t *testing.T) {
+		ctx := context.Background()
+		stored, raw := GetSecretResetToken(t, time.Now())
+		usedAt := time.Now()
+		stored.UsedAt = &usedAt
+
+		accountRepository := mock.NewAccountRepositoryMock()
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		secretResetTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		resetSecretUseCase := usecase.NewResetSecretUseCase(accountRepository, secretResetTokenRepository, mock.NewRefreshTokenRepositoryMock(), repository)
+
+		err := resetSecretUseCase.Execute(ctx, usecase.NewResetSecretUseCaseInput(raw, "n3wSecretValue"))
+		assert.Equal(t, "secret reset token is invalid or expired", err.Error())
+		accountRepository.AssertNotCalled(t, "FindByIDForUpdate", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ResetSecretUseCase with an expired token", func(t *testing.T) {
+		ctx := context.Background()
+		stored, raw := GetSecretResetToken(t, time.Now().Add(-time.Hour))
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		secretResetTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		resetSecretUseCase := usecase.NewResetSecretUseCase(mock.NewAccountRepositoryMock(), secretResetTokenRepository, mock.NewRefreshTokenRepositoryMock(), repository)
+
+		err := resetSecretUseCase.Execute(ctx, usecase.NewResetSecretUseCaseInput(raw, "n3wSecretValue"))
+		assert.Equal(t, "secret reset token is invalid or expired", err.Error())
+	})
+
+	t.Run("Testing ResetSecretUseCase with a weak secret keeps the token", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetAccountWithHashedSecret(t)
+		stored, raw := GetSecretResetToken(t, time.Now())
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(account, nil)
+
+		secretResetTokenRepository := mock.NewSecretResetTokenRepositoryMock()
+		secretResetTokenRepository.On("FindByHashForUpdate", ctx, stored.TokenHash).Return(stored, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		resetSecretUseCase := usecase.NewResetSecretUseCase(accountRepository, secretResetTokenRepository, mock.NewRefreshTokenRepositoryMock(), repository)
+
+		err := resetSecretUseCase.Execute(ctx, usecase.NewResetSecretUseCaseInput(raw, "onlyletters"))
+		assert.Equal(t, "secret must have letters and digits", err.Error())
+
+		secretResetTokenRepository.AssertNotCalled(t, "InvalidateByAccountID", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing ResetSecretUseCase with empty fields", func(t *testing.T) {
+		ctx := context.Background()
+		repository := mock.NewRepositoryMock()
+
+		resetSecretUseCase := usecase.NewResetSecretUseCase(mock.NewAccountRepositoryMock(), mock.NewSecretResetTokenRepositoryMock(), mock.NewRefreshTokenRepositoryMock(), repository)
+
+		err := resetSecretUseCase.Execute(ctx, usecase.NewResetSecretUseCaseInput("", ""))
+		assert.Equal(t, "token and new secret cannot be empty", err.Error())
+		repository.AssertNotCalled(t, "BeginTx", testify.Anything)
+	})
+}
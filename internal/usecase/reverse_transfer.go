@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IReverseTransferUseCase interface {
+	Execute(ctx context.Context, input *ReverseTransferUseCaseInput) (*ReverseTransferUseCaseOutput, error)
+}
+
+// ReverseTransferUseCase gives back all or part of a transfer with a compensating transfer
+// linked to it. The recipient may refund a transfer it received, principals allowed to reverse
+// any transfer may reverse transfers between any accounts.
+type ReverseTransferUseCase struct {
+	accountRepository  entity.AccountRepository
+	transferRepository entity.TransferRepository
+	ledgerRepository   entity.LedgerRepository
+	window             time.Duration
+	entity.Repository
+}
+
+func NewReverseTransferUseCase(accountRepository entity.AccountRepository, transferRepository entity.TransferRepository, ledgerRepository entity.LedgerRepository, window time.Duration, repository entity.Repository) *ReverseTransferUseCase {
+	return &ReverseTransferUseCase{
+		accountRepository:  accountRepository,
+		transferRepository: transferRepository,
+		ledgerRepository:   ledgerRepository,
+		window:             window,
+		Repository:         repository,
+	}
+}
+
+func (rt *ReverseTransferUseCase) Execute(ctx context.Context, input *ReverseTransferUseCaseInput) (*ReverseTransferUseCaseOutput, error) {
+	if input.Principal == nil {
+		return nil, entity.NewErrorHandler(entity.UNAUTHORIZED_ERROR).Add("principal not found")
+	}
+
+	transaction, err := rt.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = rt.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = rt.RollbackTx(transaction)
+		} else {
+			_ = rt.CommitTx(transaction)
+		}
+	}()
+
+	transfer, err := rt.transferRepository.FindByIDForUpdate(ctx, input.TransferID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rt.authorize(input.Principal, &transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	lockedAccounts, err := lockAccounts(ctx, rt.accountRepository, transaction, transfer.OriginAccount.ID, transfer.DestinationAccount.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	originAccount := lockedAccounts[transfer.OriginAccount.ID]
+	destinationAccount := lockedAccounts[transfer.DestinationAccount.ID]
+	transfer.OriginAccount = &originAccount
+	transfer.DestinationAccount = &destinationAccount
+
+	amount := transfer.ReversibleAmount()
+	if input.Amount != nil {
+		amount = *input.Amount
+	}
+
+	reversal, err := transfer.Reverse(input.ID, amount, input.CreatedAt, rt.window)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reversal.MakeTransfer()
+	if err != nil {
+		return nil, err
+	}
+
+	createdReversal, err := rt.transferRepository.Create(ctx, reversal, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rt.transferRepository.UpdateReversedAmount(ctx, &transfer, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status == entity.REVERSED_TRANSFER {
+		err = rt.transferRepository.UpdateStatus(ctx, &transfer, transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := entity.NewTransferJournalEntry(reversal)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = rt.ledgerRepository.Post(ctx, entry, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	createdReversal.OriginAccount = reversal.OriginAccount
+	createdReversal.DestinationAccount = reversal.DestinationAccount
+
+	return &ReverseTransferUseCaseOutput{
+		MakeTransferUseCaseOutput: *NewMakeTransferUseCaseOutput(&createdReversal),
+		ReversibleAmount:          transfer.ReversibleAmount(),
+	}, nil
+}
+
+// authorize hides the transfers of other accounts as not found, the sender knows the transfer
+// but has to ask the recipient for a refund.
+func (rt *ReverseTransferUseCase) authorize(principal *entity.Principal, transfer *entity.Transfer) error {
+	if principal.Can(entity.REVERSE_ANY_TRANSFER_PERMISSION) || principal.AccountID == transfer.DestinationAccount.ID {
+		return nil
+	}
+
+	if principal.AccountID == transfer.OriginAccount.ID {
+		return entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("only the recipient can refund the transfer")
+	}
+
+	return entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found transfer: %s", transfer.ID))
+}
+
+type ReverseTransferUseCaseInput struct {
+	ID         string            `json:"-"`
+	TransferID string            `json:"-"`
+	Principal  *entity.Principal `json:"-"`
+	Amount     *entity.Money     `json:"amount,omitempty"`
+	CreatedAt  *time.Time        `json:"-"`
+}
+
+// NewReverseTransferUseCaseInput reverses the whole reversible amount when amount is nil.
+func NewReverseTransferUseCaseInput(ID string, transferID string, principal *entity.Principal, amount *entity.Money, createdAt *time.Time) *ReverseTransferUseCaseInput {
+	return &ReverseTransferUseCaseInput{
+		ID:         ID,
+		TransferID: transferID,
+		Principal:  principal,
+		Amount:     amount,
+		CreatedAt:  createdAt,
+	}
+}
+
+// ReverseTransferUseCaseOutput is the reversal, with the amount of the original transfer that
+// can still be reversed.
+type ReverseTransferUseCaseOutput struct {
+	MakeTransferUseCaseOutput
+	ReversibleAmount entity.Money `json:"reversible_amount"`
+}
@@ -0,0 +1,252 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetReversibleTransfer is a transfer of 50 from the base origin account to the base destination
+// account, as read by FindByIDForUpdate.
+func GetReversibleTransfer(t *testing.T, reversedAmount int64) entity.Transfer {
+	createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+	return entity.Transfer{
+		ID:                 "237d3e7e-2f46-44e7-bf2b-f79721459241",
+		OriginAccount:      &entity.Account{ID: GetBaseOriginAccount(t).ID},
+		DestinationAccount: &entity.Account{ID: GetBaseDestinationAccount(t).ID},
+		Amount:             entity.NewMoney(50, entity.BRL),
+		DestinationAmount:  entity.NewMoney(50, entity.BRL),
+		ExchangeRate:       "1",
+		ReversedAmount:     entity.NewMoney(reversedAmount, entity.BRL),
+		Status:             entity.COMPLETED_TRANSFER,
+		CreatedAt:          &createdAt,
+	}
+}
+
+func TestReverseTransferUseCase_Execute(t *testing.T) {
+	reversalCreatedAt := time.Date(2023, 8, 8, 10, 00, 00, 00, time.UTC)
+	reversalID := "4f1b9a6e-5d0c-4a55-8d2f-2a8f0a4c1e77"
+
+	t.Run("Testing ReverseTransferUseCase when the recipient refunds part of the transfer", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)           // Balance = 100
+		destinationAccount := GetBaseDestinationAccount(t) // Balance = 200
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(GetReversibleTransfer(t, 10), nil)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{ID: reversalID, Amount: entity.NewMoney(20, entity.BRL), DestinationAmount: entity.NewMoney(20, entity.BRL), ExchangeRate: "1", ReversalOf: "237d3e7e-2f46-44e7-bf2b-f79721459241", ReversedAmount: entity.NewMoney(0, entity.BRL), CreatedAt: &reversalCreatedAt}, nil)
+		transferRepository.On("UpdateReversedAmount", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		amount := entity.NewMoney(20, entity.BRL)
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(accountRepository, transferRepository, ledgerRepository, 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput(reversalID, "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), &amount, &reversalCreatedAt)
+		output, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, reversalID, output.ID)
+		assert.Equal(t, "237d3e7e-2f46-44e7-bf2b-f79721459241", output.ReversalOf)
+		assert.Equal(t, destinationAccount.ID, output.OriginAccount.ID)
+		assert.Equal(t, originAccount.ID, output.DestinationAccount.ID)
+		assert.Equal(t, entity.NewMoney(20, entity.BRL), output.ReversibleAmount)
+
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(reversal *entity.Transfer) bool {
+			return reversal.ID == reversalID && reversal.ReversalOf == "237d3e7e-2f46-44e7-bf2b-f79721459241" &&
+				reversal.OriginAccount.Balance == entity.NewMoney(180, entity.BRL) && reversal.DestinationAccount.Balance == entity.NewMoney(120, entity.BRL)
+		}), testify.Anything)
+		transferRepository.AssertCalled(t, "UpdateReversedAmount", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			return transfer.ID == "237d3e7e-2f46-44e7-bf2b-f79721459241" && transfer.ReversedAmount == entity.NewMoney(30, entity.BRL)
+		}), testify.Anything)
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return entry.Type == entity.REVERSAL_ENTRY && entry.ReferenceID == reversalID &&
+				entry.Postings[0].AccountID == destinationAccount.ID && entry.Postings[0].Direction == entity.DEBIT &&
+				entry.Postings[1].AccountID == originAccount.ID && entry.Postings[1].Direction == entity.CREDIT
+		}), testify.Anything)
+		transferRepository.AssertNotCalled(t, "UpdateStatus", testify.Anything, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing ReverseTransferUseCase reverses the whole reversible amount when no amount is given", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(GetReversibleTransfer(t, 10), nil)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{ID: reversalID, CreatedAt: &reversalCreatedAt}, nil)
+		transferRepository.On("UpdateReversedAmount", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(nil)
+		transferRepository.On("UpdateStatus", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(accountRepository, transferRepository, ledgerRepository, 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput(reversalID, "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal("6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", entity.ADMIN_ROLE), nil, &reversalCreatedAt)
+		output, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.True(t, output.ReversibleAmount.IsZero())
+		transferRepository.AssertCalled(t, "Create", ctx, testify.MatchedBy(func(reversal *entity.Transfer) bool {
+			return reversal.Amount == entity.NewMoney(40, entity.BRL) && reversal.Status == entity.COMPLETED_TRANSFER
+		}), testify.Anything)
+		transferRepository.AssertCalled(t, "UpdateStatus", ctx, testify.MatchedBy(func(transfer *entity.Transfer) bool {
+			last := transfer.StatusHistory[len(transfer.StatusHistory)-1]
+			return transfer.Status == entity.REVERSED_TRANSFER && last.Status == entity.REVERSED_TRANSFER && *last.ChangedAt == reversalCreatedAt
+		}), testify.Anything)
+	})
+
+	t.Run("Testing ReverseTransferUseCase when the sender asks for the reversal", func(t *testing.T) {
+		ctx := context.Background()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(GetReversibleTransfer(t, 0), nil)
+
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(mock.NewAccountRepositoryMock(), transferRepository, mock.NewLedgerRepositoryMock(), 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput("", "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(GetBaseOriginAccount(t).ID, entity.CUSTOMER_ROLE), nil, &reversalCreatedAt)
+		output, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "only the recipient can refund the transfer", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		transferRepository.AssertNotCalled(t, "Create", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing ReverseTransferUseCase when the transfer belongs to other accounts", func(t *testing.T) {
+		ctx := context.Background()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(GetReversibleTransfer(t, 0), nil)
+
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(mock.NewAccountRepositoryMock(), transferRepository, mock.NewLedgerRepositoryMock(), 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput("", "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal("6ac7ebbf-568b-45f2-a295-bfbab73f1cf6", entity.CUSTOMER_ROLE), nil, &reversalCreatedAt)
+		_, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "not found transfer: 237d3e7e-2f46-44e7-bf2b-f79721459241", err.Error())
+	})
+
+	t.Run("Testing ReverseTransferUseCase when the amount is greater than the reversible amount", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(GetReversibleTransfer(t, 40), nil)
+
+		amount := entity.NewMoney(20, entity.BRL)
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(accountRepository, transferRepository, mock.NewLedgerRepositoryMock(), 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput("", "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), &amount, &reversalCreatedAt)
+		_, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "reversal amount cannot be greater than the reversible amount of 0.10 BRL", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing ReverseTransferUseCase when the transfer does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(entity.Transfer{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found transfer: 237d3e7e-2f46-44e7-bf2b-f79721459241"))
+
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(mock.NewAccountRepositoryMock(), transferRepository, mock.NewLedgerRepositoryMock(), 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput("", "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(GetBaseDestinationAccount(t).ID, entity.CUSTOMER_ROLE), nil, &reversalCreatedAt)
+		_, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing ReverseTransferUseCase when the ledger post fails", func(t *testing.T) {
+		ctx := context.Background()
+		originAccount := GetBaseOriginAccount(t)
+		destinationAccount := GetBaseDestinationAccount(t)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, originAccount.ID).Return(*originAccount, nil)
+		accountRepository.On("FindByIDForUpdate", ctx, destinationAccount.ID).Return(*destinationAccount, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("FindByIDForUpdate", ctx, "237d3e7e-2f46-44e7-bf2b-f79721459241", testify.Anything).Return(GetReversibleTransfer(t, 0), nil)
+		transferRepository.On("Create", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(entity.Transfer{}, nil)
+		transferRepository.On("UpdateReversedAmount", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(nil)
+		transferRepository.On("UpdateStatus", ctx, testify.AnythingOfType("*entity.Transfer"), testify.Anything).Return(nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, errors.New("database error"))
+
+		reverseTransferUseCase := usecase.NewReverseTransferUseCase(accountRepository, transferRepository, ledgerRepository, 168*time.Hour, repository)
+		input := usecase.NewReverseTransferUseCaseInput("", "237d3e7e-2f46-44e7-bf2b-f79721459241", entity.NewPrincipal(destinationAccount.ID, entity.CUSTOMER_ROLE), nil, &reversalCreatedAt)
+		output, err := reverseTransferUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "database error", err.Error())
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+		repository.AssertNotCalled(t, "CommitTx", transactionHandler)
+	})
+}
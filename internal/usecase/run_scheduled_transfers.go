@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IRunScheduledTransfersUseCase interface {
+	Execute(ctx context.Context, input *RunScheduledTransfersUseCaseInput) (*RunScheduledTransfersUseCaseOutput, error)
+}
+
+// RunScheduledTransfersUseCase makes the transfers of the schedules that are due. Each
+// occurrence is claimed before the transfer is made, so it runs at most once even with many
+// workers, and its outcome is recorded as an execution of the schedule.
+type RunScheduledTransfersUseCase struct {
+	repository          entity.ScheduledTransferRepository
+	makeTransferUseCase IMakeTransferUseCase
+	batchSize           int
+}
+
+func NewRunScheduledTransfersUseCase(repository entity.ScheduledTransferRepository, makeTransferUseCase IMakeTransferUseCase, batchSize int) *RunScheduledTransfersUseCase {
+	return &RunScheduledTransfersUseCase{
+		repository:          repository,
+		makeTransferUseCase: makeTransferUseCase,
+		batchSize:           batchSize,
+	}
+}
+
+func (r *RunScheduledTransfersUseCase) Execute(ctx context.Context, input *RunScheduledTransfersUseCaseInput) (*RunScheduledTransfersUseCaseOutput, error) {
+	scheduledTransfers, err := r.repository.FindDue(ctx, input.Now, r.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RunScheduledTransfersUseCaseOutput{}
+	for _, scheduledTransfer := range scheduledTransfers {
+		expectedStatus := scheduledTransfer.Status
+		expectedNextRunAt := scheduledTransfer.NextRunAt
+
+		scheduledTransfer.RegisterExecution(input.Now)
+
+		claimed, err := r.repository.Update(ctx, &scheduledTransfer, expectedStatus, expectedNextRunAt)
+		if err != nil {
+			return nil, err
+		}
+
+		// another worker took the occurrence or the owner changed the schedule meanwhile
+		if !claimed {
+			continue
+		}
+
+		transferInput := NewMakeTransferUseCaseInput("", scheduledTransfer.OriginAccountID, scheduledTransfer.DestinationAccountID, scheduledTransfer.Amount, "", &input.Now)
+
+		var transferID string
+		transfer, transferErr := r.makeTransferUseCase.Execute(ctx, transferInput)
+		if transferErr == nil {
+			transferID = transfer.ID
+			output.Succeeded++
+		} else {
+			output.Failed++
+		}
+
+		execution := entity.NewScheduledTransferExecution("", scheduledTransfer.ID, transferID, transferErr, &input.Now)
+
+		err = r.repository.CreateExecution(ctx, execution)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+type RunScheduledTransfersUseCaseInput struct {
+	Now time.Time
+}
+
+func NewRunScheduledTransfersUseCaseInput(now time.Time) *RunScheduledTransfersUseCaseInput {
+	return &RunScheduledTransfersUseCaseInput{
+		Now: now,
+	}
+}
+
+type RunScheduledTransfersUseCaseOutput struct {
+	Succeeded int
+	Failed    int
+}
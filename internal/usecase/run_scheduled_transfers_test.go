@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	usecaseMock "lucassantoss1701/bank/internal/usecase/mock"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunScheduledTransfersUseCase_Execute(t *testing.T) {
+	now := time.Date(2023, 8, 10, 9, 00, 30, 00, time.UTC)
+
+	t.Run("Testing RunScheduledTransfersUseCase records the transfers made and the failures", func(t *testing.T) {
+		ctx := context.Background()
+		scheduledTransfers := GetScheduledTransfers(t, "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26", "5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11")
+		startAt := scheduledTransfers[0].StartAt
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindDue", ctx, now, 50).Return(scheduledTransfers, nil)
+		repository.On("Update", ctx, testify.MatchedBy(func(claimed *entity.ScheduledTransfer) bool {
+			return claimed.Executions == 1 && claimed.NextRunAt.Equal(startAt.AddDate(0, 0, 7))
+		}), entity.ACTIVE_SCHEDULE, startAt).Return(true, nil)
+		repository.On("CreateExecution", ctx, testify.MatchedBy(func(execution *entity.ScheduledTransferExecution) bool {
+			return execution.ScheduledTransferID == "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26" && execution.Status == entity.SUCCEEDED_EXECUTION && execution.TransferID == "237d3e7e-2f46-44e7-bf2b-f79721459241"
+		})).Return(nil).Once()
+		repository.On("CreateExecution", ctx, testify.MatchedBy(func(execution *entity.ScheduledTransferExecution) bool {
+			return execution.ScheduledTransferID == "5a2d0d4b-7e0a-4a8c-9f57-7f0c4e5a3d11" && execution.Status == entity.FAILED_EXECUTION && execution.FailureReason == "insufficient balance"
+		})).Return(nil).Once()
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+		makeTransferUseCase.On("Execute", ctx, usecase.NewMakeTransferUseCaseInput("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", "d18551d3-cf13-49ec-b1dc-741a1f8715f6", entity.NewMoney(50, entity.BRL), "", &now)).
+			Return(&usecase.MakeTransferUseCaseOutput{ID: "237d3e7e-2f46-44e7-bf2b-f79721459241"}, nil).Once()
+		makeTransferUseCase.On("Execute", ctx, testify.Anything).
+			Return((*usecase.MakeTransferUseCaseOutput)(nil), entity.NewErrorHandler(entity.ENTITY_ERROR).Add("insufficient balance")).Once()
+
+		output, err := usecase.NewRunScheduledTransfersUseCase(repository, makeTransferUseCase, 50).Execute(ctx, usecase.NewRunScheduledTransfersUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.RunScheduledTransfersUseCaseOutput{Succeeded: 1, Failed: 1}, output)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Testing RunScheduledTransfersUseCase skips the occurrences claimed by another worker", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindDue", ctx, now, 50).Return(GetScheduledTransfers(t, "c0b8d4a4-37b5-4bc4-9be7-fd7e4a3b3e26"), nil)
+		repository.On("Update", ctx, testify.Anything, testify.Anything, testify.Anything).Return(false, nil)
+
+		makeTransferUseCase := usecaseMock.NewMakeTransferUseCaseMock()
+
+		output, err := usecase.NewRunScheduledTransfersUseCase(repository, makeTransferUseCase, 50).Execute(ctx, usecase.NewRunScheduledTransfersUseCaseInput(now))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &usecase.RunScheduledTransfersUseCaseOutput{}, output)
+		makeTransferUseCase.AssertNotCalled(t, "Execute", testify.Anything, testify.Anything)
+		repository.AssertNotCalled(t, "CreateExecution", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing RunScheduledTransfersUseCase when repository returns an error", func(t *testing.T) {
+		ctx := context.Background()
+
+		repository := mock.NewScheduledTransferRepositoryMock()
+		repository.On("FindDue", ctx, now, 50).Return([]entity.ScheduledTransfer{}, errors.New("connection closed"))
+
+		output, err := usecase.NewRunScheduledTransfersUseCase(repository, usecaseMock.NewMakeTransferUseCaseMock(), 50).Execute(ctx, usecase.NewRunScheduledTransfersUseCaseInput(now))
+
+		assert.Nil(t, output)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
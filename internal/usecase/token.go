@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TokenIssuer hands out a short lived access token together with a refresh token, which is
+// stored hashed so it can be rotated and revoked.
+type TokenIssuer struct {
+	refreshTokenRepository entity.RefreshTokenRepository
+	signer                 entity.TokenSigner
+	accessTokenTTL         time.Duration
+	refreshTokenTTL        time.Duration
+}
+
+func NewTokenIssuer(refreshTokenRepository entity.RefreshTokenRepository, signer entity.TokenSigner, accessTokenTTL time.Duration, refreshTokenTTL time.Duration) *TokenIssuer {
+	return &TokenIssuer{
+		refreshTokenRepository: refreshTokenRepository,
+		signer:                 signer,
+		accessTokenTTL:         accessTokenTTL,
+		refreshTokenTTL:        refreshTokenTTL,
+	}
+}
+
+// Issue creates the tokens of a session. An empty familyID starts a new session, the access
+// token carries the family as "sid" so the session can be ended on logout.
+func (t *TokenIssuer) Issue(ctx context.Context, account *entity.Account, familyID string, now time.Time, tx ...entity.TransactionHandler) (*TokenUseCaseOutput, *entity.RefreshToken, error) {
+	refreshToken, rawRefreshToken, err := entity.NewRefreshToken(account.ID, familyID, &now, t.refreshTokenTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = t.refreshTokenRepository.Create(ctx, refreshToken, tx...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := account.Role
+	if role == "" {
+		role = entity.CUSTOMER_ROLE
+	}
+
+	claims := map[string]interface{}{}
+	claims["typ"] = string(entity.ACCESS_TOKEN_TYPE)
+	claims["jti"] = entity.NewUUID()
+	claims["sid"] = refreshToken.FamilyID
+	claims["account_id"] = account.ID
+	claims["role"] = string(role)
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(t.accessTokenTTL).Unix()
+
+	token, err := t.signer.Sign(claims)
+	if err != nil {
+		return nil, nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return NewTokenUseCaseOutput(token, rawRefreshToken, t.accessTokenTTL), refreshToken, nil
+}
+
+// MFA_TOKEN_TTL is how long the user has to type the TOTP code after the secret.
+const MFA_TOKEN_TTL = 5 * time.Minute
+
+// IssueMFAToken creates the short lived token handed out when the secret is correct but the
+// account also requires a TOTP code, it cannot be used as an access token.
+func (t *TokenIssuer) IssueMFAToken(account *entity.Account, now time.Time) (*TokenUseCaseOutput, error) {
+	claims := map[string]interface{}{}
+	claims["typ"] = string(entity.MFA_TOKEN_TYPE)
+	claims["jti"] = entity.NewUUID()
+	claims["account_id"] = account.ID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(MFA_TOKEN_TTL).Unix()
+
+	token, err := t.signer.Sign(claims)
+	if err != nil {
+		return nil, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add(err.Error())
+	}
+
+	return NewMFATokenUseCaseOutput(token, MFA_TOKEN_TTL), nil
+}
+
+// TokenUseCaseOutput carries either the tokens of a session or, when a TOTP code is still
+// required, the MFA token to be sent with the code to /login/mfa.
+type TokenUseCaseOutput struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func NewTokenUseCaseOutput(token string, refreshToken string, expiresIn time.Duration) *TokenUseCaseOutput {
+	return &TokenUseCaseOutput{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(expiresIn.Seconds()),
+	}
+}
+
+func NewMFATokenUseCaseOutput(mfaToken string, expiresIn time.Duration) *TokenUseCaseOutput {
+	return &TokenUseCaseOutput{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+		ExpiresIn:   int(expiresIn.Seconds()),
+	}
+}
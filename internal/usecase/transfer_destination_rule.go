@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+// TransferDestinationRule finds the account a transfer is sent to when the destination account
+// is informed by one of its keys or by a beneficiary saved by the origin account, instead of
+// its ID. Keys are looked up with the misses of the origin account counted, as in a key lookup.
+type TransferDestinationRule struct {
+	lookupRule            *TransferKeyLookupRule
+	beneficiaryRepository entity.BeneficiaryRepository
+}
+
+func NewTransferDestinationRule(lookupRule *TransferKeyLookupRule, beneficiaryRepository entity.BeneficiaryRepository) *TransferDestinationRule {
+	return &TransferDestinationRule{
+		lookupRule:            lookupRule,
+		beneficiaryRepository: beneficiaryRepository,
+	}
+}
+
+// Resolve fills the ID of the account with the owner of its key or with the account saved as
+// the beneficiary, an account informed by its ID is left as it is.
+func (t *TransferDestinationRule) Resolve(ctx context.Context, originAccountID string, account *MakeTransferUseCaseDestinationInput) error {
+	informed := 0
+	for _, field := range []string{account.ID, account.Key, account.BeneficiaryID} {
+		if field != "" {
+			informed++
+		}
+	}
+
+	if informed > 1 {
+		return entity.NewErrorHandler(entity.ENTITY_ERROR).Add("destination account must be informed by only one of its id, its key or a beneficiary")
+	}
+
+	if account.Key != "" {
+		transferKey, err := t.lookupRule.Find(ctx, originAccountID, account.Key)
+		if err != nil {
+			return err
+		}
+
+		account.ID = transferKey.AccountID
+	}
+
+	if account.BeneficiaryID != "" {
+		beneficiary, err := findBeneficiary(ctx, t.beneficiaryRepository, originAccountID, account.BeneficiaryID)
+		if err != nil {
+			return err
+		}
+
+		account.ID = beneficiary.DestinationAccountID
+	}
+
+	return nil
+}
+
+// findBeneficiary finds a beneficiary saved by the account, the ones saved by other accounts
+// are reported as not found.
+func findBeneficiary(ctx context.Context, repository entity.BeneficiaryRepository, accountID string, ID string) (entity.Beneficiary, error) {
+	beneficiary, err := repository.FindByID(ctx, ID)
+	if err != nil {
+		return entity.Beneficiary{}, err
+	}
+
+	if beneficiary.AccountID != accountID {
+		return entity.Beneficiary{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add(fmt.Sprintf("not found beneficiary: %s", ID))
+	}
+
+	return beneficiary, nil
+}
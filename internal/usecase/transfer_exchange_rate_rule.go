@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TransferExchangeRateRule quotes the rate of transfers between accounts of different
+// currencies. Rates older than maxAge are refused, so a transfer never uses a rate the
+// provider stopped updating.
+type TransferExchangeRateRule struct {
+	provider entity.ExchangeRateProvider
+	maxAge   time.Duration
+}
+
+func NewTransferExchangeRateRule(provider entity.ExchangeRateProvider, maxAge time.Duration) *TransferExchangeRateRule {
+	return &TransferExchangeRateRule{
+		provider: provider,
+		maxAge:   maxAge,
+	}
+}
+
+func (t *TransferExchangeRateRule) Quote(ctx context.Context, from entity.Currency, to entity.Currency, now time.Time) (entity.ExchangeRate, error) {
+	if from == to {
+		return entity.NewIdentityExchangeRate(from, &now), nil
+	}
+
+	if t.provider == nil {
+		return entity.ExchangeRate{}, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("exchange rate from %s to %s is not available", from, to))
+	}
+
+	exchangeRate, err := t.provider.FindRate(ctx, from, to)
+	if err != nil {
+		return entity.ExchangeRate{}, err
+	}
+
+	if exchangeRate.IsStale(now, t.maxAge) {
+		return entity.ExchangeRate{}, entity.NewErrorHandler(entity.ENTITY_ERROR).Add(fmt.Sprintf("exchange rate from %s to %s is stale", from, to))
+	}
+
+	return exchangeRate, nil
+}
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func GetExchangeRate(t *testing.T, from entity.Currency, to entity.Currency, rate string, updatedAt time.Time) entity.ExchangeRate {
+	exchangeRate, err := entity.NewExchangeRate(from, to, rate, &updatedAt)
+	assert.Nil(t, err)
+	return *exchangeRate
+}
+
+func TestTransferExchangeRateRule_Quote(t *testing.T) {
+	now := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+	t.Run("Testing Quote when both currencies are the same", func(t *testing.T) {
+		provider := mock.NewExchangeRateProviderMock()
+
+		exchangeRate, err := usecase.NewTransferExchangeRateRule(provider, time.Hour).Quote(context.Background(), entity.BRL, entity.BRL, now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1", exchangeRate.Rate)
+		provider.AssertNotCalled(t, "FindRate", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Quote when the rate is fresh", func(t *testing.T) {
+		ctx := context.Background()
+		provider := mock.NewExchangeRateProviderMock()
+		provider.On("FindRate", ctx, entity.USD, entity.BRL).Return(GetExchangeRate(t, entity.USD, entity.BRL, "4.95", now.Add(-time.Hour)), nil)
+
+		exchangeRate, err := usecase.NewTransferExchangeRateRule(provider, time.Hour).Quote(ctx, entity.USD, entity.BRL, now)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "4.95", exchangeRate.Rate)
+	})
+
+	t.Run("Testing Quote when the rate is stale", func(t *testing.T) {
+		ctx := context.Background()
+		provider := mock.NewExchangeRateProviderMock()
+		provider.On("FindRate", ctx, entity.USD, entity.BRL).Return(GetExchangeRate(t, entity.USD, entity.BRL, "4.95", now.Add(-time.Hour-time.Second)), nil)
+
+		_, err := usecase.NewTransferExchangeRateRule(provider, time.Hour).Quote(ctx, entity.USD, entity.BRL, now)
+
+		assert.Equal(t, "exchange rate from USD to BRL is stale", err.Error())
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing Quote when the provider has no rate", func(t *testing.T) {
+		ctx := context.Background()
+		provider := mock.NewExchangeRateProviderMock()
+		provider.On("FindRate", ctx, entity.USD, entity.BRL).Return(entity.ExchangeRate{}, errors.New("exchange rate from USD to BRL is not available"))
+
+		_, err := usecase.NewTransferExchangeRateRule(provider, time.Hour).Quote(ctx, entity.USD, entity.BRL, now)
+
+		assert.Equal(t, "exchange rate from USD to BRL is not available", err.Error())
+	})
+
+	t.Run("Testing Quote when there is no provider", func(t *testing.T) {
+		_, err := usecase.NewTransferExchangeRateRule(nil, time.Hour).Quote(context.Background(), entity.USD, entity.BRL, now)
+
+		assert.Equal(t, "exchange rate from USD to BRL is not available", err.Error())
+	})
+}
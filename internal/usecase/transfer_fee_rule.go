@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+// TransferFeeRule charges the fees of the schedule to a transfer. The free quota of a rule is
+// counted within the calendar month of the bank, inside the transaction holding the lock of the
+// origin account, so concurrent transfers cannot share the same free transfer.
+type TransferFeeRule struct {
+	transferRepository entity.TransferRepository
+	schedule           *entity.FeeSchedule
+	calendar           *entity.TransferLimitCalendar
+}
+
+func NewTransferFeeRule(transferRepository entity.TransferRepository, schedule *entity.FeeSchedule, calendar *entity.TransferLimitCalendar) *TransferFeeRule {
+	return &TransferFeeRule{
+		transferRepository: transferRepository,
+		schedule:           schedule,
+		calendar:           calendar,
+	}
+}
+
+func (t *TransferFeeRule) Apply(ctx context.Context, transfer *entity.Transfer, tx entity.TransactionHandler) error {
+	rules := t.schedule.RulesFor(transfer)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	sent := 0
+	if entity.HasFreeQuota(rules) {
+		var err error
+		sent, err = t.transferRepository.CountSent(ctx, transfer.OriginAccount.ID, t.calendar.MonthStart(*transfer.CreatedAt), tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	fees := make([]entity.TransferFee, 0, len(rules))
+	for _, rule := range rules {
+		fee, err := rule.Price(transfer.Amount, sent)
+		if err != nil {
+			return err
+		}
+
+		fees = append(fees, fee)
+	}
+
+	return transfer.ChargeFees(fees)
+}
@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTransferFeeRule is a rule without fees, every transfer of the tests is free.
+func GetTransferFeeRule(t *testing.T) *usecase.TransferFeeRule {
+	return usecase.NewTransferFeeRule(mock.NewTransferRepositoryMock(), &entity.FeeSchedule{}, GetTransferLimitCalendar(t))
+}
+
+// GetFeeSchedule charges 0.10 BRL per transfer after the first two of the month, plus 1% of
+// the amount, between 0.05 and 0.50 BRL, to customers.
+func GetFeeSchedule(t *testing.T) *entity.FeeSchedule {
+	schedule, err := entity.NewFeeSchedule(
+		entity.FeeRule{Name: "flat", Type: entity.FLAT_FEE, Currency: entity.BRL, Amount: entity.NewMoney(10, entity.BRL), FreePerMonth: 2},
+		entity.FeeRule{Name: "percentage", Type: entity.PERCENTAGE_FEE, Currency: entity.BRL, AccountRole: entity.CUSTOMER_ROLE, Rate: "0.01", Minimum: entity.NewMoney(5, entity.BRL), Maximum: entity.NewMoney(50, entity.BRL)},
+		entity.FeeRule{Name: "usd", Type: entity.FLAT_FEE, Currency: entity.USD, Amount: entity.NewMoney(100, entity.USD)},
+	)
+	assert.Nil(t, err)
+
+	return schedule
+}
+
+func TestTransferFeeRule_Apply(t *testing.T) {
+	originAccount := GetBaseOriginAccount(t)
+	destinationAccount := GetBaseDestinationAccount(t)
+
+	t.Run("Testing TransferFeeRule charges every rule of the transfer after the free quota", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, originAccount.ID, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), []entity.TransactionHandler{transactionHandler}).Return(2, nil)
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(80, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t)).Apply(ctx, transfer, transactionHandler)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(15, entity.BRL), transfer.Fee)
+		assert.Equal(t, []entity.TransferFee{
+			{Rule: "flat", Type: entity.FLAT_FEE, Amount: entity.NewMoney(10, entity.BRL)},
+			{Rule: "percentage", Type: entity.PERCENTAGE_FEE, Amount: entity.NewMoney(5, entity.BRL)},
+		}, transfer.Fees)
+	})
+
+	t.Run("Testing TransferFeeRule waives the rules within the free quota", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, originAccount.ID, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), []entity.TransactionHandler{nil}).Return(1, nil)
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(80, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t)).Apply(ctx, transfer, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(5, entity.BRL), transfer.Fee)
+		assert.True(t, transfer.Fees[0].Waived)
+		assert.Equal(t, entity.NewMoney(0, entity.BRL), transfer.Fees[0].Amount)
+	})
+
+	t.Run("Testing TransferFeeRule only applies the rules of the account role", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		adminAccount := GetBaseOriginAccount(t)
+		adminAccount.Role = entity.ADMIN_ROLE
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, adminAccount.ID, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), []entity.TransactionHandler{nil}).Return(5, nil)
+
+		transfer, err := entity.NewTransfer("", adminAccount, destinationAccount, entity.NewMoney(80, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t)).Apply(ctx, transfer, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(10, entity.BRL), transfer.Fee)
+		assert.Len(t, transfer.Fees, 1)
+	})
+
+	t.Run("Testing TransferFeeRule does not count the transfers when no rule has a free quota", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		usdAccount := GetBaseOriginAccount(t)
+		usdAccount.Balance = entity.NewMoney(1000, entity.USD)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+
+		transfer, err := entity.NewTransfer("", usdAccount, destinationAccount, entity.NewMoney(80, entity.USD), &createdAt)
+		assert.Nil(t, err)
+
+		err = usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t)).Apply(ctx, transfer, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.NewMoney(100, entity.USD), transfer.Fee)
+		transferRepository.AssertNotCalled(t, "CountSent")
+	})
+
+	t.Run("Testing TransferFeeRule when CountSent returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("CountSent", ctx, originAccount.ID, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), []entity.TransactionHandler{nil}).Return(0, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("connection closed"))
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(80, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = usecase.NewTransferFeeRule(transferRepository, GetFeeSchedule(t), GetTransferLimitCalendar(t)).Apply(ctx, transfer, nil)
+
+		assert.Equal(t, "connection closed", err.Error())
+		assert.True(t, transfer.Fee.IsZero())
+	})
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TransferKeyLookupRule finds the owner of a key for an account, counting the lookups of keys
+// that do not exist with the backoff and lockout of the login. Every place that answers
+// differently for a registered key and an unknown one goes through it, otherwise the keys
+// registered could be found out by guessing.
+type TransferKeyLookupRule struct {
+	keyRepository           entity.TransferKeyRepository
+	loginThrottleRepository entity.LoginThrottleRepository
+	policy                  entity.LoginThrottlePolicy
+	entity.Repository
+}
+
+func NewTransferKeyLookupRule(keyRepository entity.TransferKeyRepository, loginThrottleRepository entity.LoginThrottleRepository, policy entity.LoginThrottlePolicy, repository entity.Repository) *TransferKeyLookupRule {
+	return &TransferKeyLookupRule{
+		keyRepository:           keyRepository,
+		loginThrottleRepository: loginThrottleRepository,
+		policy:                  policy,
+		Repository:              repository,
+	}
+}
+
+// Find looks up a key written in any of the accepted formats, as a CPF with or without its
+// punctuation, with the misses of the account locked, so parallel lookups are counted one after
+// the other. A key found does not clear the misses, otherwise a known key could be looked up
+// between the guesses.
+func (t *TransferKeyLookupRule) Find(ctx context.Context, accountID string, key string) (entity.TransferKey, error) {
+	now := time.Now()
+	throttleKey := entity.TransferKeyLookupThrottleKey(accountID)
+
+	transaction, err := t.BeginTx(ctx)
+	if err != nil {
+		return entity.TransferKey{}, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = t.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = t.RollbackTx(transaction)
+		} else {
+			_ = t.CommitTx(transaction)
+		}
+	}()
+
+	throttle, err := t.loginThrottleRepository.FindByKeyForUpdate(ctx, throttleKey, now, transaction)
+	if err != nil {
+		return entity.TransferKey{}, err
+	}
+
+	retryAt := t.policy.RetryAt(throttle)
+	if retryAt.After(now) {
+		err = entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).
+			Add("too many lookups of keys that do not exist, try again later").
+			WithRetryAfter(retryAt.Sub(now))
+		return entity.TransferKey{}, err
+	}
+
+	transferKey, lookupErr := t.keyRepository.FindByKey(ctx, entity.NormalizeTransferKey(entity.GuessTransferKeyType(key), key))
+	if lookupErr != nil {
+		if !isNotFound(lookupErr) {
+			err = lookupErr
+			return entity.TransferKey{}, err
+		}
+
+		// the miss has to be committed, so the not found is returned without setting err
+		_, err = t.loginThrottleRepository.RegisterFailure(ctx, throttleKey, now, now.Add(-t.policy.Lockout), transaction)
+		if err != nil {
+			return entity.TransferKey{}, err
+		}
+		return entity.TransferKey{}, lookupErr
+	}
+
+	return transferKey, nil
+}
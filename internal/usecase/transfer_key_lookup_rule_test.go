@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTransferKeyLookupRule is a rule of an account that has not missed any key yet.
+func GetTransferKeyLookupRule(keyRepository entity.TransferKeyRepository) (*usecase.TransferKeyLookupRule, *mock.LoginThrottleRepositoryMock) {
+	loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+	loginThrottleRepository.On("FindByKeyForUpdate", testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+	loginThrottleRepository.On("RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(entity.LoginThrottle{Failures: 1}, nil)
+
+	policy := entity.NewLoginThrottlePolicy(3, time.Second, time.Minute, 15*time.Minute)
+
+	return usecase.NewTransferKeyLookupRule(keyRepository, loginThrottleRepository, policy, GetThrottleRepository()), loginThrottleRepository
+}
+
+func TestTransferKeyLookupRule_Find(t *testing.T) {
+	policy := entity.NewLoginThrottlePolicy(3, time.Second, time.Minute, 15*time.Minute)
+	accountID := "2bd765a6-47bd-4731-9eb2-1e65542f4477"
+
+	t.Run("Testing TransferKeyLookupRule finds a key without counting it", func(t *testing.T) {
+		ctx := context.Background()
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "35768297090").
+			Return(entity.TransferKey{Key: "35768297090", Type: entity.CPF_KEY, AccountID: "d18551d3-cf13-49ec-b1dc-741a1f8715f6"}, nil)
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "key_lookup:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		rule := usecase.NewTransferKeyLookupRule(keyRepository, loginThrottleRepository, policy, GetThrottleRepository())
+		transferKey, err := rule.Find(ctx, accountID, "357.682.970-90")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "d18551d3-cf13-49ec-b1dc-741a1f8715f6", transferKey.AccountID)
+		loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+		loginThrottleRepository.AssertNotCalled(t, "Reset", testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing TransferKeyLookupRule counts the lookup of a key that does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+		keyRepository.On("FindByKey", ctx, "lucas@example.com").
+			Return(entity.TransferKey{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found key: lucas@example.com"))
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "key_lookup:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, "key_lookup:"+accountID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{Failures: 1}, nil)
+
+		rule := usecase.NewTransferKeyLookupRule(keyRepository, loginThrottleRepository, policy, repository)
+		_, err := rule.Find(ctx, accountID, "Lucas@Example.com")
+
+		assert.Equal(t, entity.NOT_FOUND_ERROR, err.(*entity.ErrorHandler).TypeError)
+		loginThrottleRepository.AssertExpectations(t)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+		repository.AssertNotCalled(t, "RollbackTx", testify.Anything)
+	})
+
+	t.Run("Testing TransferKeyLookupRule when the account looked up too many keys that do not exist", func(t *testing.T) {
+		ctx := context.Background()
+		lastFailureAt := time.Now()
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		keyRepository := mock.NewTransferKeyRepositoryMock()
+
+		loginThrottleRepository := mock.NewLoginThrottleRepositoryMock()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "key_lookup:"+accountID, testify.Anything).
+			Return(entity.LoginThrottle{Key: "key_lookup:" + accountID, Failures: 3, LastFailureAt: &lastFailureAt}, nil)
+
+		rule := usecase.NewTransferKeyLookupRule(keyRepository, loginThrottleRepository, policy, repository)
+		_, err := rule.Find(ctx, accountID, "lucas@example.com")
+
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Greater(t, err.(*entity.ErrorHandler).RetryAfter, 14*time.Minute)
+		keyRepository.AssertNotCalled(t, "FindByKey", testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TransferLimitRule keeps the transfers of an account within its limits, the accounts that
+// never changed their limits get the defaults. Check runs inside the transaction holding the
+// lock of the origin account, so concurrent transfers cannot go over a limit together.
+type TransferLimitRule struct {
+	limitRepository    entity.TransferLimitRepository
+	transferRepository entity.TransferRepository
+	defaults           entity.TransferLimitDefaults
+	calendar           *entity.TransferLimitCalendar
+}
+
+func NewTransferLimitRule(limitRepository entity.TransferLimitRepository, transferRepository entity.TransferRepository, defaults entity.TransferLimitDefaults, calendar *entity.TransferLimitCalendar) *TransferLimitRule {
+	return &TransferLimitRule{
+		limitRepository:    limitRepository,
+		transferRepository: transferRepository,
+		defaults:           defaults,
+		calendar:           calendar,
+	}
+}
+
+// Find returns the limits of the account, or the defaults in the currency of the account.
+func (t *TransferLimitRule) Find(ctx context.Context, account *entity.Account, tx ...entity.TransactionHandler) (entity.TransferLimit, error) {
+	transferLimit, err := t.limitRepository.FindByAccountID(ctx, account.ID, tx...)
+	if err == nil {
+		return transferLimit, nil
+	}
+
+	if handler, ok := err.(*entity.ErrorHandler); !ok || handler.TypeError != entity.NOT_FOUND_ERROR {
+		return entity.TransferLimit{}, err
+	}
+
+	defaults, err := t.defaults.For(account.ID, account.Currency())
+	if err != nil {
+		return entity.TransferLimit{}, err
+	}
+
+	return *defaults, nil
+}
+
+func (t *TransferLimitRule) Check(ctx context.Context, transfer *entity.Transfer, tx entity.TransactionHandler) error {
+	transferLimit, err := t.Find(ctx, transfer.OriginAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	usage, night, err := t.usage(ctx, transfer.OriginAccount.ID, transfer.Amount.Currency, *transfer.CreatedAt, tx)
+	if err != nil {
+		return err
+	}
+
+	return transferLimit.Check(transfer.Amount, usage, night)
+}
+
+// CheckAll checks amounts the account will send one after another at now, each amount counts
+// towards the limits of the ones after it. It returns the index of the first amount over a
+// limit, or -1 when the limits could not be read. Nothing is locked, so CheckAll only tells
+// that the amounts fit the limits when it runs.
+func (t *TransferLimitRule) CheckAll(ctx context.Context, account *entity.Account, amounts []entity.Money, now time.Time) (int, error) {
+	transferLimit, err := t.Find(ctx, account)
+	if err != nil {
+		return -1, err
+	}
+
+	usage, night, err := t.usage(ctx, account.ID, account.Currency(), now)
+	if err != nil {
+		return -1, err
+	}
+
+	for i, amount := range amounts {
+		err = transferLimit.Check(amount, usage, night)
+		if err != nil {
+			return i, err
+		}
+
+		usage, err = usage.Add(amount, night)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return -1, nil
+}
+
+// usage sums what the account sent in each period of its limits, it tells whether now is in
+// the night.
+func (t *TransferLimitRule) usage(ctx context.Context, accountID string, currency entity.Currency, now time.Time, tx ...entity.TransactionHandler) (entity.TransferUsage, bool, error) {
+	var usage entity.TransferUsage
+	var err error
+
+	usage.Daily, err = t.transferRepository.SumSent(ctx, accountID, currency, t.calendar.DayStart(now), tx...)
+	if err != nil {
+		return entity.TransferUsage{}, false, err
+	}
+
+	usage.Monthly, err = t.transferRepository.SumSent(ctx, accountID, currency, t.calendar.MonthStart(now), tx...)
+	if err != nil {
+		return entity.TransferUsage{}, false, err
+	}
+
+	usage.Nighttime = entity.NewMoney(0, currency)
+
+	nightStart, night := t.calendar.NightStartOf(now)
+	if night {
+		usage.Nighttime, err = t.transferRepository.SumSent(ctx, accountID, currency, nightStart, tx...)
+		if err != nil {
+			return entity.TransferUsage{}, false, err
+		}
+	}
+
+	return usage, night, nil
+}
+
+// NightWindow returns the start and the end of the night as clock times such as "20:00".
+func (t *TransferLimitRule) NightWindow() (string, string) {
+	midnight := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return midnight.Add(t.calendar.NightStart).Format("15:04"), midnight.Add(t.calendar.NightEnd).Format("15:04")
+}
@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	testify "github.com/stretchr/testify/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetTransferLimitCalendar has the night from 20:00 to 06:00 in UTC.
+func GetTransferLimitCalendar(t *testing.T) *entity.TransferLimitCalendar {
+	calendar, err := entity.NewTransferLimitCalendar(20*time.Hour, 6*time.Hour, time.UTC)
+	assert.Nil(t, err)
+
+	return calendar
+}
+
+func GetTransferLimitDefaults() entity.TransferLimitDefaults {
+	return entity.TransferLimitDefaults{PerTransaction: "5000", Daily: "10000", Monthly: "50000", Nighttime: "1000"}
+}
+
+// GetTransferLimitRule is a rule with the default limits and nothing sent yet, far above the
+// amounts moved by the tests.
+func GetTransferLimitRule(t *testing.T) *usecase.TransferLimitRule {
+	limitRepository := mock.NewTransferLimitRepositoryMock()
+	limitRepository.On("FindByAccountID", testify.Anything, testify.Anything, testify.Anything).Return(entity.TransferLimit{}, entity.NewErrorHandler(entity.NOT_FOUND_ERROR).Add("not found transfer limit"))
+
+	transferRepository := mock.NewTransferRepositoryMock()
+	transferRepository.On("SumSent", testify.Anything, testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(entity.NewMoney(0, entity.BRL), nil)
+
+	return usecase.NewTransferLimitRule(limitRepository, transferRepository, GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+}
+
+// GetAccountTransferLimit is a limit of 1.00 per transfer, 1.50 a day, 10.00 a month and 0.80 a night.
+func GetAccountTransferLimit(t *testing.T, accountID string) entity.TransferLimit {
+	updatedAt := time.Date(2023, 8, 1, 10, 00, 00, 00, time.UTC)
+	transferLimit, err := entity.NewTransferLimit(accountID, entity.NewMoney(100, entity.BRL), entity.NewMoney(150, entity.BRL), entity.NewMoney(1000, entity.BRL), entity.NewMoney(80, entity.BRL), &updatedAt)
+	assert.Nil(t, err)
+
+	return *transferLimit
+}
+
+func TestTransferLimitRule_Check(t *testing.T) {
+	originAccount := GetBaseOriginAccount(t)
+	destinationAccount := GetBaseDestinationAccount(t)
+
+	t.Run("Testing TransferLimitRule sums what the account sent in the day and in the month", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, []entity.TransactionHandler{transactionHandler}).Return(GetAccountTransferLimit(t, originAccount.ID), nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, time.Date(2023, 8, 7, 0, 0, 0, 0, time.UTC), []entity.TransactionHandler{transactionHandler}).Return(entity.NewMoney(100, entity.BRL), nil)
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), []entity.TransactionHandler{transactionHandler}).Return(entity.NewMoney(500, entity.BRL), nil)
+
+		rule := usecase.NewTransferLimitRule(limitRepository, transferRepository, GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(50, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+		assert.Nil(t, rule.Check(ctx, transfer, transactionHandler))
+
+		transfer, err = entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(60, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+		err = rule.Check(ctx, transfer, transactionHandler)
+		assert.Equal(t, entity.ENTITY_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.Equal(t, "transfer exceeds the daily limit of 1.50 BRL, 0.50 BRL available", err.Error())
+		transferRepository.AssertNumberOfCalls(t, "SumSent", 4)
+	})
+
+	t.Run("Testing TransferLimitRule applies the nighttime limit at night", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 2, 00, 00, 00, time.UTC)
+		transactionHandler := mock.NewTransactionHandlerMock()
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, testify.Anything).Return(GetAccountTransferLimit(t, originAccount.ID), nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, time.Date(2023, 8, 6, 20, 0, 0, 0, time.UTC), testify.Anything).Return(entity.NewMoney(50, entity.BRL), nil)
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, testify.Anything, testify.Anything).Return(entity.NewMoney(0, entity.BRL), nil)
+
+		rule := usecase.NewTransferLimitRule(limitRepository, transferRepository, GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(40, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = rule.Check(ctx, transfer, transactionHandler)
+		assert.Equal(t, "transfer exceeds the nighttime limit of 0.80 BRL, 0.30 BRL available", err.Error())
+	})
+
+	t.Run("Testing TransferLimitRule uses the defaults when the account never changed its limits", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		originAccount := GetBaseOriginAccount(t)
+		originAccount.Balance = entity.NewMoney(1000000, entity.BRL)
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(500001, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = GetTransferLimitRule(t).Check(ctx, transfer, mock.NewTransactionHandlerMock())
+		assert.Equal(t, "transfer exceeds the per-transaction limit of 5000.00 BRL", err.Error())
+	})
+
+	t.Run("Testing TransferLimitRule when the limits cannot be read", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, testify.Anything).Return(entity.TransferLimit{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("connection closed"))
+
+		rule := usecase.NewTransferLimitRule(limitRepository, mock.NewTransferRepositoryMock(), GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		transfer, err := entity.NewTransfer("", originAccount, destinationAccount, entity.NewMoney(40, entity.BRL), &createdAt)
+		assert.Nil(t, err)
+
+		err = rule.Check(ctx, transfer, mock.NewTransactionHandlerMock())
+		assert.Equal(t, entity.INTERNAL_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+}
+
+func TestTransferLimitRule_CheckAll(t *testing.T) {
+	originAccount := GetBaseOriginAccount(t)
+
+	t.Run("Testing TransferLimitRule counts each amount towards the limits of the next ones", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, []entity.TransactionHandler(nil)).Return(GetAccountTransferLimit(t, originAccount.ID), nil)
+
+		transferRepository := mock.NewTransferRepositoryMock()
+		transferRepository.On("SumSent", ctx, originAccount.ID, entity.BRL, testify.Anything, []entity.TransactionHandler(nil)).Return(entity.NewMoney(50, entity.BRL), nil)
+
+		rule := usecase.NewTransferLimitRule(limitRepository, transferRepository, GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		position, err := rule.CheckAll(ctx, originAccount, []entity.Money{entity.NewMoney(40, entity.BRL), entity.NewMoney(60, entity.BRL)}, now)
+		assert.Nil(t, err)
+		assert.Equal(t, -1, position)
+
+		position, err = rule.CheckAll(ctx, originAccount, []entity.Money{entity.NewMoney(40, entity.BRL), entity.NewMoney(60, entity.BRL), entity.NewMoney(1, entity.BRL)}, now)
+		assert.Equal(t, 2, position)
+		assert.Equal(t, "transfer exceeds the daily limit of 1.50 BRL, 0.00 BRL available", err.Error())
+	})
+
+	t.Run("Testing TransferLimitRule when the limits cannot be read", func(t *testing.T) {
+		ctx := context.Background()
+
+		limitRepository := mock.NewTransferLimitRepositoryMock()
+		limitRepository.On("FindByAccountID", ctx, originAccount.ID, testify.Anything).Return(entity.TransferLimit{}, entity.NewErrorHandler(entity.INTERNAL_ERROR).Add("connection closed"))
+
+		rule := usecase.NewTransferLimitRule(limitRepository, mock.NewTransferRepositoryMock(), GetTransferLimitDefaults(), GetTransferLimitCalendar(t))
+
+		position, err := rule.CheckAll(ctx, originAccount, []entity.Money{entity.NewMoney(40, entity.BRL)}, time.Now())
+		assert.Equal(t, -1, position)
+		assert.Equal(t, "connection closed", err.Error())
+	})
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TransferTwoFactorRule requires a fresh TOTP code on transfers of threshold or more, a zero
+// threshold turns the rule off. Recovery codes are not accepted here, they only restore the
+// access to the account.
+type TransferTwoFactorRule struct {
+	twoFactor *TwoFactor
+	threshold entity.Money
+}
+
+func NewTransferTwoFactorRule(twoFactor *TwoFactor, threshold entity.Money) *TransferTwoFactorRule {
+	return &TransferTwoFactorRule{
+		twoFactor: twoFactor,
+		threshold: threshold,
+	}
+}
+
+func (t *TransferTwoFactorRule) Check(ctx context.Context, accountID string, amount entity.Money, code string, now time.Time) error {
+	if !t.threshold.IsPositive() {
+		return nil
+	}
+
+	// an amount in another currency cannot be compared with the threshold, so it always needs the code
+	comparison, err := amount.Compare(t.threshold)
+	if err == nil && comparison < 0 {
+		return nil
+	}
+
+	enabled, err := t.twoFactor.IsEnabled(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if !enabled {
+		return entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add(fmt.Sprintf("two-factor authentication must be enabled for transfers of %s %s or more", t.threshold, t.threshold.Currency))
+	}
+
+	if code == "" {
+		return entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add(fmt.Sprintf("totp code is required for transfers of %s %s or more", t.threshold, t.threshold.Currency))
+	}
+
+	valid, err := t.twoFactor.Verify(ctx, accountID, code, false, now)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return entity.NewErrorHandler(entity.FORBIDDEN_ERROR).Add("totp code is invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+// TwoFactor checks the second factor of an account. Wrong codes are counted per account with
+// the same backoff and lockout of the login, so 6 digits cannot be guessed.
+type TwoFactor struct {
+	totpRepository          entity.TOTPRepository
+	recoveryCodeRepository  entity.RecoveryCodeRepository
+	loginThrottleRepository entity.LoginThrottleRepository
+	policy                  entity.LoginThrottlePolicy
+	entity.Repository
+}
+
+func NewTwoFactor(totpRepository entity.TOTPRepository, recoveryCodeRepository entity.RecoveryCodeRepository, loginThrottleRepository entity.LoginThrottleRepository, policy entity.LoginThrottlePolicy, repository entity.Repository) *TwoFactor {
+	return &TwoFactor{
+		totpRepository:          totpRepository,
+		recoveryCodeRepository:  recoveryCodeRepository,
+		loginThrottleRepository: loginThrottleRepository,
+		policy:                  policy,
+		Repository:              repository,
+	}
+}
+
+// IsEnabled tells whether the account has a confirmed authenticator.
+func (t *TwoFactor) IsEnabled(ctx context.Context, accountID string) (bool, error) {
+	totp, err := t.totpRepository.FindByAccountID(ctx, accountID)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return totp.IsConfirmed(), nil
+}
+
+// Verify returns false when the code is wrong or was already used. Recovery codes are only
+// accepted when allowRecoveryCode is set.
+func (t *TwoFactor) Verify(ctx context.Context, accountID string, code string, allowRecoveryCode bool, now time.Time) (bool, error) {
+	return t.throttle(ctx, accountID, now, func() (bool, error) {
+		if entity.IsRecoveryCode(code) {
+			if !allowRecoveryCode {
+				return false, nil
+			}
+			return t.recoveryCodeRepository.Use(ctx, accountID, entity.HashRecoveryCode(accountID, code), now)
+		}
+
+		totp, err := t.totpRepository.FindByAccountID(ctx, accountID)
+		if err != nil {
+			if isNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if !totp.IsConfirmed() {
+			return false, nil
+		}
+
+		return t.useCode(ctx, &totp, code, now)
+	})
+}
+
+// verifyEnrollment checks a code of an authenticator that is not confirmed yet.
+func (t *TwoFactor) verifyEnrollment(ctx context.Context, totp *entity.TOTP, code string, now time.Time) (bool, error) {
+	return t.throttle(ctx, totp.AccountID, now, func() (bool, error) {
+		return t.useCode(ctx, totp, code, now)
+	})
+}
+
+func (t *TwoFactor) useCode(ctx context.Context, totp *entity.TOTP, code string, now time.Time) (bool, error) {
+	step, ok := totp.MatchStep(code, now)
+	if !ok {
+		return false, nil
+	}
+
+	return t.totpRepository.UseStep(ctx, totp.AccountID, step)
+}
+
+// throttle keeps the failures of the account locked while the code is checked, so parallel
+// guesses are counted one after the other and cannot all pass before the first failure.
+func (t *TwoFactor) throttle(ctx context.Context, accountID string, now time.Time, check func() (bool, error)) (bool, error) {
+	key := entity.TOTPThrottleKey(accountID)
+
+	transaction, err := t.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = t.RollbackTx(transaction)
+			panic(r)
+		}
+		if err != nil {
+			_ = t.RollbackTx(transaction)
+		} else {
+			_ = t.CommitTx(transaction)
+		}
+	}()
+
+	throttle, err := t.loginThrottleRepository.FindByKeyForUpdate(ctx, key, now, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	retryAt := t.policy.RetryAt(throttle)
+	if retryAt.After(now) {
+		err = entity.NewErrorHandler(entity.TOO_MANY_REQUESTS_ERROR).
+			Add("too many invalid two-factor codes, try again later").
+			WithRetryAfter(retryAt.Sub(now))
+		return false, err
+	}
+
+	valid, err := check()
+	if err != nil {
+		return false, err
+	}
+
+	if !valid {
+		_, err = t.loginThrottleRepository.RegisterFailure(ctx, key, now, now.Add(-t.policy.Lockout), transaction)
+		return false, err
+	}
+
+	err = t.loginThrottleRepository.Reset(ctx, key, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
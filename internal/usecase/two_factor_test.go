@@ -0,0 +1,182 @@
+package usecase_test
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+const totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func GetConfirmedTOTP(accountID string) entity.TOTP {
+	createdAt := time.Now()
+	return entity.TOTP{AccountID: accountID, Secret: totpSecret, ConfirmedAt: &createdAt, CreatedAt: &createdAt}
+}
+
+func GetCurrentTOTPCode(t *testing.T) string {
+	code, err := entity.GenerateTOTPCode(totpSecret, entity.TOTPStep(time.Now()))
+	assert.Nil(t, err)
+	return code
+}
+
+func TestTwoFactor_Verify(t *testing.T) {
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+
+	t.Run("Testing Verify with a valid totp code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+		totpRepository.On("UseStep", ctx, accountID, testify.Anything).Return(true, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+
+		valid, err := twoFactor.Verify(ctx, accountID, GetCurrentTOTPCode(t), false, time.Now())
+
+		assert.Nil(t, err)
+		assert.True(t, valid)
+		totpRepository.AssertCalled(t, "UseStep", ctx, accountID, entity.TOTPStep(time.Now()))
+		loginThrottleRepository.AssertNotCalled(t, "RegisterFailure", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Verify with a replayed totp code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+		totpRepository.On("UseStep", ctx, accountID, testify.Anything).Return(false, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, "totp:"+accountID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		valid, err := twoFactor.Verify(ctx, accountID, GetCurrentTOTPCode(t), false, time.Now())
+
+		assert.Nil(t, err)
+		assert.False(t, valid)
+		loginThrottleRepository.AssertCalled(t, "RegisterFailure", ctx, "totp:"+accountID, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Verify with a wrong totp code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, "totp:"+accountID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		valid, err := twoFactor.Verify(ctx, accountID, "000000", false, time.Now())
+
+		assert.Nil(t, err)
+		assert.False(t, valid)
+		totpRepository.AssertNotCalled(t, "UseStep", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Verify with a recovery code when it is allowed", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, _, recoveryCodeRepository, loginThrottleRepository := GetTwoFactor()
+		lastFailureAt := time.Now().Add(-time.Hour)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{Failures: 1, LastFailureAt: &lastFailureAt}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+		recoveryCodeRepository.On("Use", ctx, accountID, entity.HashRecoveryCode(accountID, "abcde-fghjk"), testify.Anything).Return(true, nil)
+
+		valid, err := twoFactor.Verify(ctx, accountID, "ABCDE-FGHJK", true, time.Now())
+
+		assert.Nil(t, err)
+		assert.True(t, valid)
+		loginThrottleRepository.AssertCalled(t, "Reset", ctx, "totp:"+accountID)
+	})
+
+	t.Run("Testing Verify with a recovery code when it is not allowed", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, _, recoveryCodeRepository, loginThrottleRepository := GetTwoFactor()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, "totp:"+accountID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		valid, err := twoFactor.Verify(ctx, accountID, "abcde-fghjk", false, time.Now())
+
+		assert.Nil(t, err)
+		assert.False(t, valid)
+		recoveryCodeRepository.AssertNotCalled(t, "Use", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("Testing Verify when the account is locked out", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, loginThrottleRepository := GetTwoFactor()
+		lastFailureAt := time.Now()
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{Failures: 5, LastFailureAt: &lastFailureAt}, nil)
+
+		valid, err := twoFactor.Verify(ctx, accountID, GetCurrentTOTPCode(t), false, time.Now())
+
+		assert.False(t, valid)
+		assert.Equal(t, "too many invalid two-factor codes, try again later", err.Error())
+		assert.Equal(t, entity.TOO_MANY_REQUESTS_ERROR, err.(*entity.ErrorHandler).TypeError)
+		assert.True(t, err.(*entity.ErrorHandler).RetryAfter > 0)
+		totpRepository.AssertNotCalled(t, "FindByAccountID", testify.Anything, testify.Anything)
+	})
+}
+
+func TestTransferTwoFactorRule_Check(t *testing.T) {
+	accountID := "8c3ea5f6-e4b0-4de3-9c4b-c6ec0c6e5c6f"
+
+	t.Run("Testing Check below the threshold", func(t *testing.T) {
+		twoFactor, _, _, _ := GetTwoFactor()
+
+		err := usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(1000, entity.BRL)).Check(context.Background(), accountID, entity.NewMoney(999, entity.BRL), "", time.Now())
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Check when the rule is turned off", func(t *testing.T) {
+		err := usecase.NewTransferTwoFactorRule(nil, entity.NewMoney(0, entity.BRL)).Check(context.Background(), accountID, entity.NewMoney(1000000, entity.BRL), "", time.Now())
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Testing Check when two-factor authentication is not enabled", func(t *testing.T) {
+		err := usecase.NewTransferTwoFactorRule(GetDisabledTwoFactor(), entity.NewMoney(1000, entity.BRL)).Check(context.Background(), accountID, entity.NewMoney(1000, entity.BRL), "", time.Now())
+
+		assert.Equal(t, "two-factor authentication must be enabled for transfers of 10.00 BRL or more", err.Error())
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing Check without totp code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, _ := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+
+		err := usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(1000, entity.BRL)).Check(ctx, accountID, entity.NewMoney(1000, entity.BRL), "", time.Now())
+
+		assert.Equal(t, "totp code is required for transfers of 10.00 BRL or more", err.Error())
+	})
+
+	t.Run("Testing Check with an invalid totp code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+		loginThrottleRepository.On("RegisterFailure", ctx, "totp:"+accountID, testify.Anything, testify.Anything).Return(entity.LoginThrottle{}, nil)
+
+		err := usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(1000, entity.BRL)).Check(ctx, accountID, entity.NewMoney(1000, entity.BRL), "000000", time.Now())
+
+		assert.Equal(t, "totp code is invalid", err.Error())
+		assert.Equal(t, entity.FORBIDDEN_ERROR, err.(*entity.ErrorHandler).TypeError)
+	})
+
+	t.Run("Testing Check with a valid totp code", func(t *testing.T) {
+		ctx := context.Background()
+		twoFactor, totpRepository, _, loginThrottleRepository := GetTwoFactor()
+		totpRepository.On("FindByAccountID", ctx, accountID).Return(GetConfirmedTOTP(accountID), nil)
+		totpRepository.On("UseStep", ctx, accountID, testify.Anything).Return(true, nil)
+		loginThrottleRepository.On("FindByKeyForUpdate", ctx, "totp:"+accountID, testify.Anything).Return(entity.LoginThrottle{}, nil)
+		loginThrottleRepository.On("Reset", ctx, "totp:"+accountID).Return(nil)
+
+		err := usecase.NewTransferTwoFactorRule(twoFactor, entity.NewMoney(1000, entity.BRL)).Check(ctx, accountID, entity.NewMoney(1000, entity.BRL), GetCurrentTOTPCode(t), time.Now())
+
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+	"time"
+)
+
+type IUpdateBeneficiaryUseCase interface {
+	Execute(ctx context.Context, input *UpdateBeneficiaryUseCaseInput) (*BeneficiaryUseCaseOutput, error)
+}
+
+// UpdateBeneficiaryUseCase renames a beneficiary of the account or marks it as favorite.
+// Beneficiaries of other accounts are reported as not found.
+type UpdateBeneficiaryUseCase struct {
+	repository entity.BeneficiaryRepository
+}
+
+func NewUpdateBeneficiaryUseCase(repository entity.BeneficiaryRepository) *UpdateBeneficiaryUseCase {
+	return &UpdateBeneficiaryUseCase{
+		repository: repository,
+	}
+}
+
+func (u *UpdateBeneficiaryUseCase) Execute(ctx context.Context, input *UpdateBeneficiaryUseCaseInput) (*BeneficiaryUseCaseOutput, error) {
+	beneficiary, err := findBeneficiary(ctx, u.repository, input.AccountID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = beneficiary.Change(input.Nickname, input.Favorite, *input.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.Update(ctx, &beneficiary)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBeneficiaryUseCaseOutput(&beneficiary), nil
+}
+
+type UpdateBeneficiaryUseCaseInput struct {
+	AccountID string     `json:"-"`
+	ID        string     `json:"-"`
+	Nickname  *string    `json:"nickname,omitempty" example:"Mom"`
+	Favorite  *bool      `json:"favorite,omitempty"`
+	UpdatedAt *time.Time `json:"-"`
+}
+
+func NewUpdateBeneficiaryUseCaseInput(accountID string, ID string, nickname *string, favorite *bool, updatedAt *time.Time) *UpdateBeneficiaryUseCaseInput {
+	return &UpdateBeneficiaryUseCaseInput{
+		AccountID: accountID,
+		ID:        ID,
+		Nickname:  nickname,
+		Favorite:  favorite,
+		UpdatedAt: updatedAt,
+	}
+}
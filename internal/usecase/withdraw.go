@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"lucassantoss1701/bank/internal/entity"
+)
+
+type IWithdrawUseCase interface {
+	Execute(ctx context.Context, input *MovementUseCaseInput) (*MovementUseCaseOutput, error)
+}
+
+type WithdrawUseCase struct {
+	movementUseCase
+}
+
+func NewWithdrawUseCase(accountRepository entity.AccountRepository, movementRepository entity.MovementRepository, ledgerRepository entity.LedgerRepository, repository entity.Repository) *WithdrawUseCase {
+	return &WithdrawUseCase{
+		movementUseCase: movementUseCase{
+			accountRepository:  accountRepository,
+			movementRepository: movementRepository,
+			ledgerRepository:   ledgerRepository,
+			Repository:         repository,
+		},
+	}
+}
+
+func (w *WithdrawUseCase) Execute(ctx context.Context, input *MovementUseCaseInput) (*MovementUseCaseOutput, error) {
+	return w.execute(ctx, entity.WITHDRAWAL, input)
+}
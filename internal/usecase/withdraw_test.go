@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"lucassantoss1701/bank/internal/entity"
+	"lucassantoss1701/bank/internal/entity/mock"
+	"lucassantoss1701/bank/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+)
+
+func TestWithdrawUseCase_Execute(t *testing.T) {
+	t.Run("Testing WithdrawUseCase when have success on withdraw", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t) // Balance = 100
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		movementRepository.On("Create", ctx, testify.AnythingOfType("*entity.Movement"), testify.Anything).Return(entity.Movement{ID: "fc84682a-3045-4bdf-b91c-10be19f89452", Type: entity.WITHDRAWAL, Channel: entity.ATM_CHANNEL, Amount: entity.NewMoney(40, entity.BRL), CreatedAt: &createdAt}, nil)
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+		ledgerRepository.On("Post", ctx, testify.AnythingOfType("*entity.JournalEntry"), testify.Anything).Return(entity.JournalEntry{}, nil)
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("CommitTx", transactionHandler).Return(nil)
+
+		withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("fc84682a-3045-4bdf-b91c-10be19f89452", account.ID, entity.ATM_CHANNEL, "", entity.NewMoney(40, entity.BRL), &createdAt)
+		output, err := withdrawUseCase.Execute(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "WITHDRAWAL", output.Type)
+		assert.Equal(t, entity.NewMoney(60, entity.BRL), output.Balance)
+
+		ledgerRepository.AssertCalled(t, "Post", ctx, testify.MatchedBy(func(entry *entity.JournalEntry) bool {
+			return entry.Type == entity.WITHDRAWAL_ENTRY && entry.Postings[0].AccountID == account.ID && entry.Postings[0].Direction == entity.DEBIT
+		}), testify.Anything)
+		repository.AssertCalled(t, "CommitTx", transactionHandler)
+	})
+
+	t.Run("Testing WithdrawUseCase when balance is insufficient", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t) // Balance = 100
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("", account.ID, entity.ATM_CHANNEL, "", entity.NewMoney(150, entity.BRL), &createdAt)
+		output, err := withdrawUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "insufficient balance for withdrawal", err.Error())
+		movementRepository.AssertNotCalled(t, "Create", ctx, testify.Anything, testify.Anything)
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing WithdrawUseCase when create movement returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		account := GetBaseOriginAccount(t)
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		accountRepository.On("FindByIDForUpdate", ctx, account.ID).Return(*account, nil)
+
+		movementRepository := mock.NewMovementRepositoryMock()
+		movementRepository.On("Create", ctx, testify.AnythingOfType("*entity.Movement"), testify.Anything).Return(entity.Movement{}, errors.New("error on create movement"))
+
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, nil)
+		repository.On("RollbackTx", transactionHandler).Return(nil)
+
+		withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("", account.ID, entity.ATM_CHANNEL, "", entity.NewMoney(50, entity.BRL), &createdAt)
+		output, err := withdrawUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "error on create movement", err.Error())
+		ledgerRepository.AssertNotCalled(t, "Post", ctx, testify.Anything, testify.Anything)
+		repository.AssertCalled(t, "RollbackTx", transactionHandler)
+	})
+
+	t.Run("Testing WithdrawUseCase when begin transaction returns an error", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2023, 8, 7, 10, 00, 00, 00, time.UTC)
+
+		accountRepository := mock.NewAccountRepositoryMock()
+		movementRepository := mock.NewMovementRepositoryMock()
+		ledgerRepository := mock.NewLedgerRepositoryMock()
+
+		transactionHandler := mock.NewTransactionHandlerMock()
+		repository := mock.NewRepositoryMock()
+		repository.On("BeginTx", ctx).Return(transactionHandler, errors.New("error on begin transaction"))
+
+		withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository, movementRepository, ledgerRepository, repository)
+		input := usecase.NewMovementUseCaseInput("", "2bd765a6-47bd-4731-9eb2-1e65542f4477", entity.ATM_CHANNEL, "", entity.NewMoney(50, entity.BRL), &createdAt)
+		output, err := withdrawUseCase.Execute(ctx, input)
+
+		assert.Nil(t, output)
+		assert.Equal(t, "error on begin transaction", err.Error())
+		accountRepository.AssertNotCalled(t, "FindByIDForUpdate", ctx, testify.Anything)
+	})
+}